@@ -0,0 +1,216 @@
+package attestation
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/metrics"
+	"github.com/0xPolygon/cdk-data-availability/pkg/merkle"
+	"github.com/0xPolygon/cdk-data-availability/reporter"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// logger is the logger of the attestation component
+var logger = log.WithComponent("attestation")
+
+// receiptPollInterval is how often the receipt of a submitted attestation is requested
+var receiptPollInterval = 5 * time.Second
+
+// ErrReverted is returned for the attestations whose transaction reverted
+var ErrReverted = errors.New("attestation transaction reverted")
+
+// Attester periodically commits on L1 to the keys of the values stored by the node, giving
+// on-chain evidence of their custody over time. Each attestation covers the batches following
+// the previous one, and holds the Merkle root of the keys of their values.
+type Attester struct {
+	cfg      config.AttestationConfig
+	pk       *ecdsa.PrivateKey
+	contract common.Address
+	db       db.DB
+	etherman etherman.Etherman
+	chainID  *big.Int
+	stop     chan struct{}
+}
+
+// New returns an Attester submitting the attestations from the address of the given key
+func New(cfg config.AttestationConfig, pk *ecdsa.PrivateKey, db db.DB, em etherman.Etherman) *Attester {
+	return &Attester{
+		cfg:      cfg,
+		pk:       pk,
+		contract: common.HexToAddress(cfg.ContractAddress),
+		db:       db,
+		etherman: em,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start submits an attestation every interval until the attester is stopped
+func (a *Attester) Start(ctx context.Context) {
+	defer reporter.Recover()
+
+	logger.Infof("starting to attest the stored keys to %s", a.contract.Hex())
+	ticker := time.NewTicker(a.cfg.Interval.Duration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := a.Attest(ctx); err != nil {
+				logger.Errorf("failed to attest the stored keys: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		case <-a.stop:
+			return
+		}
+	}
+}
+
+// Stop stops the attester
+func (a *Attester) Stop() {
+	close(a.stop)
+}
+
+// Attest submits an attestation of the keys stored for the batches following the last attestation,
+// waits for it to be mined and records it. It returns nil when no new keys were stored.
+func (a *Attester) Attest(parentCtx context.Context) (*types.Attestation, error) {
+	ctx, cancel := context.WithTimeout(parentCtx, a.cfg.Timeout.Duration)
+	defer cancel()
+
+	fromBatch := uint64(1)
+	last, err := a.db.GetLastAttestation(ctx)
+	if err == nil {
+		fromBatch = last.ToBatch + 1
+	} else if !errors.Is(err, db.ErrStateNotSynchronized) {
+		return nil, fmt.Errorf("failed to get the last attestation: %w", err)
+	}
+
+	keys, err := a.db.GetOffChainDataKeys(ctx, fromBatch, a.cfg.MaxKeys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the stored keys: %w", err)
+	}
+
+	keys = a.wholeBatches(keys)
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	leaves := make([]common.Hash, len(keys))
+	for i, key := range keys {
+		leaves[i] = key.Hash
+	}
+
+	attestation := types.Attestation{
+		Root:      merkle.Root(leaves),
+		FromBatch: fromBatch,
+		ToBatch:   keys[len(keys)-1].Number,
+		KeyCount:  uint32(len(keys)),
+	}
+
+	attestation.TxHash, err = a.submit(ctx, attestation)
+	metrics.AttestationSubmitted(attestation.ToBatch, err)
+	if err != nil {
+		return nil, err
+	}
+
+	attestation.Timestamp = time.Now().UTC()
+	if err = a.db.StoreAttestation(ctx, attestation); err != nil {
+		return nil, fmt.Errorf("failed to store the attestation: %w", err)
+	}
+
+	logger.WithFields(
+		log.FieldTxHash, attestation.TxHash.Hex(),
+	).Infof("attested %d keys of the batches %d to %d, root %s", attestation.KeyCount,
+		attestation.FromBatch, attestation.ToBatch, attestation.Root.Hex())
+
+	return &attestation, nil
+}
+
+// wholeBatches drops the keys of the last batch listed when the limit was hit, as more keys of that
+// batch may follow, so an attestation never covers part of a batch. A single batch with more keys
+// than the limit is attested partially.
+func (a *Attester) wholeBatches(keys []types.BatchKey) []types.BatchKey {
+	if uint(len(keys)) < a.cfg.MaxKeys {
+		return keys
+	}
+
+	lastBatch := keys[len(keys)-1].Number
+	for i := len(keys) - 1; i >= 0; i-- {
+		if keys[i].Number != lastBatch {
+			return keys[:i+1]
+		}
+	}
+
+	logger.WithFields(log.FieldBatchNumber, lastBatch).
+		Warnf("batch has more than %d keys, attesting only the first ones", a.cfg.MaxKeys)
+
+	return keys
+}
+
+// submit sends the attestation to the contract and waits for it to be mined
+func (a *Attester) submit(ctx context.Context, attestation types.Attestation) (common.Hash, error) {
+	if a.chainID == nil {
+		chainID, err := a.etherman.ChainID(ctx)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("failed to get the chain ID: %w", err)
+		}
+		a.chainID = chainID
+	}
+
+	opts, err := bind.NewKeyedTransactorWithChainID(a.pk, a.chainID)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	opts.Context = ctx
+
+	tx, err := a.etherman.SubmitAttestation(opts, a.contract, attestation.Root,
+		attestation.FromBatch, attestation.ToBatch, attestation.KeyCount)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to submit the attestation: %w", err)
+	}
+
+	receipt, err := a.waitMined(ctx, tx.Hash())
+	if err != nil {
+		return tx.Hash(), err
+	}
+
+	if receipt.Status != ethTypes.ReceiptStatusSuccessful {
+		return tx.Hash(), fmt.Errorf("%w: %s", ErrReverted, tx.Hash().Hex())
+	}
+
+	return tx.Hash(), nil
+}
+
+// waitMined returns the receipt of the transaction once mined
+func (a *Attester) waitMined(ctx context.Context, txHash common.Hash) (*ethTypes.Receipt, error) {
+	ticker := time.NewTicker(receiptPollInterval)
+	defer ticker.Stop()
+
+	for {
+		receipt, err := a.etherman.TransactionReceipt(ctx, txHash)
+		if err == nil {
+			return receipt, nil
+		}
+		if !errors.Is(err, ethereum.NotFound) {
+			return nil, fmt.Errorf("failed to get the receipt of %s: %w", txHash.Hex(), err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("attestation %s not mined: %w", txHash.Hex(), ctx.Err())
+		}
+	}
+}
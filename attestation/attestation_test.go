@@ -0,0 +1,141 @@
+package attestation
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/config"
+	cfgTypes "github.com/0xPolygon/cdk-data-availability/config/types"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/pkg/merkle"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var contract = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+func newTestAttester(t *testing.T, dbMock *mocks.DB, em *mocks.Etherman) *Attester {
+	t.Helper()
+
+	receiptPollInterval = time.Millisecond
+
+	pk, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	return New(config.AttestationConfig{
+		Enabled:         true,
+		ContractAddress: contract.Hex(),
+		Interval:        cfgTypes.NewDuration(time.Hour),
+		MaxKeys:         3,
+		Timeout:         cfgTypes.NewDuration(5 * time.Second),
+	}, pk, dbMock, em)
+}
+
+func TestAttester_Attest(t *testing.T) {
+	keys := []types.BatchKey{
+		{Number: 4, Hash: common.HexToHash("0x1")},
+		{Number: 4, Hash: common.HexToHash("0x2")},
+		{Number: 5, Hash: common.HexToHash("0x3")},
+	}
+	root := merkle.Root([]common.Hash{keys[0].Hash, keys[1].Hash})
+	tx := ethTypes.NewTx(&ethTypes.LegacyTx{Nonce: 1})
+
+	t.Run("no attestation yet", func(t *testing.T) {
+		dbMock := mocks.NewDB(t)
+		em := mocks.NewEtherman(t)
+		a := newTestAttester(t, dbMock, em)
+
+		dbMock.On("GetLastAttestation", mock.Anything).Return(nil, db.ErrStateNotSynchronized)
+		// the limit is hit, so the keys of the last batch are left to the next attestation
+		dbMock.On("GetOffChainDataKeys", mock.Anything, uint64(1), uint(3)).Return(keys, nil)
+		em.On("ChainID", mock.Anything).Return(big.NewInt(1337), nil).Once()
+		em.On("SubmitAttestation", mock.Anything, contract, root, uint64(1), uint64(4), uint32(2)).
+			Return(tx, nil)
+		em.On("TransactionReceipt", mock.Anything, tx.Hash()).Return(nil, ethereum.NotFound).Once()
+		em.On("TransactionReceipt", mock.Anything, tx.Hash()).
+			Return(&ethTypes.Receipt{Status: ethTypes.ReceiptStatusSuccessful}, nil).Once()
+		dbMock.On("StoreAttestation", mock.Anything, mock.MatchedBy(func(attestation types.Attestation) bool {
+			return attestation.Root == root && attestation.FromBatch == 1 && attestation.ToBatch == 4 &&
+				attestation.KeyCount == 2 && attestation.TxHash == tx.Hash()
+		})).Return(nil)
+
+		attestation, err := a.Attest(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, uint64(4), attestation.ToBatch)
+	})
+
+	t.Run("following the last attestation", func(t *testing.T) {
+		dbMock := mocks.NewDB(t)
+		em := mocks.NewEtherman(t)
+		a := newTestAttester(t, dbMock, em)
+
+		dbMock.On("GetLastAttestation", mock.Anything).Return(&types.Attestation{ToBatch: 3}, nil)
+		dbMock.On("GetOffChainDataKeys", mock.Anything, uint64(4), uint(3)).Return(keys[:1], nil)
+		em.On("ChainID", mock.Anything).Return(big.NewInt(1337), nil)
+		em.On("SubmitAttestation", mock.Anything, contract, keys[0].Hash, uint64(4), uint64(4), uint32(1)).
+			Return(tx, nil)
+		em.On("TransactionReceipt", mock.Anything, tx.Hash()).
+			Return(&ethTypes.Receipt{Status: ethTypes.ReceiptStatusSuccessful}, nil)
+		dbMock.On("StoreAttestation", mock.Anything, mock.Anything).Return(nil)
+
+		attestation, err := a.Attest(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, uint64(4), attestation.FromBatch)
+	})
+
+	t.Run("nothing to attest", func(t *testing.T) {
+		dbMock := mocks.NewDB(t)
+		a := newTestAttester(t, dbMock, mocks.NewEtherman(t))
+
+		dbMock.On("GetLastAttestation", mock.Anything).Return(&types.Attestation{ToBatch: 5}, nil)
+		dbMock.On("GetOffChainDataKeys", mock.Anything, uint64(6), uint(3)).Return([]types.BatchKey{}, nil)
+
+		attestation, err := a.Attest(context.Background())
+		require.NoError(t, err)
+		require.Nil(t, attestation)
+	})
+
+	t.Run("reverted", func(t *testing.T) {
+		dbMock := mocks.NewDB(t)
+		em := mocks.NewEtherman(t)
+		a := newTestAttester(t, dbMock, em)
+
+		dbMock.On("GetLastAttestation", mock.Anything).Return(nil, db.ErrStateNotSynchronized)
+		dbMock.On("GetOffChainDataKeys", mock.Anything, uint64(1), uint(3)).Return(keys[:1], nil)
+		em.On("ChainID", mock.Anything).Return(big.NewInt(1337), nil)
+		em.On("SubmitAttestation", mock.Anything, contract, keys[0].Hash, uint64(1), uint64(4), uint32(1)).
+			Return(tx, nil)
+		em.On("TransactionReceipt", mock.Anything, tx.Hash()).
+			Return(&ethTypes.Receipt{Status: ethTypes.ReceiptStatusFailed}, nil)
+
+		_, err := a.Attest(context.Background())
+		require.ErrorIs(t, err, ErrReverted)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		dbMock := mocks.NewDB(t)
+		a := newTestAttester(t, dbMock, mocks.NewEtherman(t))
+
+		dbMock.On("GetLastAttestation", mock.Anything).Return(nil, errors.New("test error"))
+
+		_, err := a.Attest(context.Background())
+		require.ErrorContains(t, err, "test error")
+	})
+}
+
+func TestAttester_wholeBatches(t *testing.T) {
+	a := &Attester{cfg: config.AttestationConfig{MaxKeys: 2}}
+
+	single := []types.BatchKey{{Number: 7, Hash: common.HexToHash("0x1")}, {Number: 7, Hash: common.HexToHash("0x2")}}
+	require.Equal(t, single, a.wholeBatches(single), "a batch larger than the limit is attested partially")
+	require.Equal(t, single[:1], a.wholeBatches(single[:1]))
+}
@@ -0,0 +1,272 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/synchronizer"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// logger is the logger of the audit component
+var logger = log.WithComponent("audit")
+
+// Entry is a value reported by the audit
+type Entry struct {
+	Key      common.Hash     `json:"key"`
+	BatchNum types.ArgUint64 `json:"batchNum"`
+}
+
+// Report is the result of an audit of the stored values against the sequences of a range of L1 blocks
+type Report struct {
+	FromBlock types.ArgUint64 `json:"fromBlock"`
+	ToBlock   types.ArgUint64 `json:"toBlock"`
+	// FromBatch and ToBatch are the first and last batches sequenced in the blocks
+	FromBatch types.ArgUint64 `json:"fromBatch"`
+	ToBatch   types.ArgUint64 `json:"toBatch"`
+	// Expected is the number of keys sequenced in the blocks, Stored the number of values stored
+	Expected uint64 `json:"expected"`
+	Stored   uint64 `json:"stored"`
+	// BlobSequences is the number of sequences carrying blobs, whose keys are not known from L1
+	BlobSequences uint64 `json:"blobSequences"`
+	// Corrupt are the values not matching the hash they are stored under
+	Corrupt []Entry `json:"corrupt"`
+	// Orphaned are the values of the batches of the blocks that were not sequenced in them
+	Orphaned []Entry `json:"orphaned"`
+	// Missing are the keys sequenced in the blocks without a value stored
+	Missing []Entry `json:"missing"`
+	// Pruned are the keys sequenced in the blocks whose value was deleted per the retention policy,
+	// a tombstone recording the deletion
+	Pruned []Entry `json:"pruned"`
+}
+
+// Problems returns the number of corrupt, orphaned and missing entries, the pruned ones not being a problem
+func (r *Report) Problems() int {
+	return len(r.Corrupt) + len(r.Orphaned) + len(r.Missing)
+}
+
+// Sequenced are the keys sequenced in a range of L1 blocks
+type Sequenced struct {
+	// Keys are the keys sequenced with their batch numbers
+	Keys map[common.Hash]uint64
+	// BlobBatches are the batches of the sequences carrying blobs, whose keys are not known from L1
+	BlobBatches map[uint64]bool
+	// Blocks are the L1 blocks the batches were sequenced in
+	Blocks map[uint64]uint64
+	// BlobSequences is the number of sequences carrying blobs
+	BlobSequences uint64
+	// FromBatch and ToBatch are the first and last batches sequenced, zero when none was
+	FromBatch uint64
+	ToBatch   uint64
+}
+
+// Auditor checks the stored values against the keys sequenced on L1
+type Auditor struct {
+	db         db.DB
+	etherman   etherman.Etherman
+	blockRange uint64
+	pageSize   uint
+}
+
+// New returns an Auditor filtering the sequences blockRange L1 blocks at a time, and reading the stored
+// values pageSize at a time
+func New(db db.DB, em etherman.Etherman, blockRange uint64, pageSize uint) *Auditor {
+	return &Auditor{
+		db:         db,
+		etherman:   em,
+		blockRange: blockRange,
+		pageSize:   pageSize,
+	}
+}
+
+// Audit derives the keys sequenced in the given L1 blocks, then walks all the stored values,
+// checking each one against its hash and against the keys sequenced. The stored values of the
+// batches outside of the batches sequenced in the blocks are only checked against their hash.
+func (a *Auditor) Audit(ctx context.Context, fromBlock, toBlock uint64) (*Report, error) {
+	report := &Report{FromBlock: types.ArgUint64(fromBlock), ToBlock: types.ArgUint64(toBlock)}
+
+	sequenced, err := a.Sequenced(ctx, fromBlock, toBlock)
+	if err != nil {
+		return nil, err
+	}
+	expected, blobBatches := sequenced.Keys, sequenced.BlobBatches
+	report.FromBatch = types.ArgUint64(sequenced.FromBatch)
+	report.ToBatch = types.ArgUint64(sequenced.ToBatch)
+	report.BlobSequences = sequenced.BlobSequences
+	report.Expected = uint64(len(expected))
+
+	found := make(map[common.Hash]bool, len(expected))
+	for after := (common.Hash{}); ; {
+		page, err := a.db.ListOffChainDataPage(ctx, after, a.pageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the values after %s: %w", after.Hex(), err)
+		}
+
+		for _, data := range page {
+			report.Stored++
+			entry := Entry{Key: data.Key, BatchNum: types.ArgUint64(data.BatchNum)}
+
+			if crypto.Keccak256Hash(data.Value) != data.Key {
+				report.Corrupt = append(report.Corrupt, entry)
+			}
+
+			if _, ok := expected[data.Key]; ok {
+				found[data.Key] = true
+				continue
+			}
+
+			// the values of the blobs are stored under the hash of the blob, not known from L1
+			inRange := data.BatchNum >= uint64(report.FromBatch) && data.BatchNum <= uint64(report.ToBatch)
+			if inRange && !blobBatches[data.BatchNum] {
+				report.Orphaned = append(report.Orphaned, entry)
+			}
+		}
+
+		if uint(len(page)) < a.pageSize {
+			break
+		}
+		after = page[len(page)-1].Key
+	}
+
+	notFound := make([]common.Hash, 0)
+	for key := range expected {
+		if !found[key] {
+			notFound = append(notFound, key)
+		}
+	}
+
+	pruned, err := a.Pruned(ctx, notFound)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range notFound {
+		entry := Entry{Key: key, BatchNum: types.ArgUint64(expected[key])}
+		if pruned[key] {
+			report.Pruned = append(report.Pruned, entry)
+		} else {
+			report.Missing = append(report.Missing, entry)
+		}
+	}
+	sortEntries(report.Missing)
+	sortEntries(report.Pruned)
+
+	return report, nil
+}
+
+// Pruned returns which of the given keys have a tombstone, their value having been deleted per the
+// retention policy rather than lost
+func (a *Auditor) Pruned(ctx context.Context, keys []common.Hash) (map[common.Hash]bool, error) {
+	pruned := make(map[common.Hash]bool)
+	for start := 0; start < len(keys); start += int(a.pageSize) {
+		end := start + int(a.pageSize)
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		tombstones, err := a.db.GetTombstones(ctx, keys[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the tombstones: %w", err)
+		}
+		for _, tombstone := range tombstones {
+			pruned[tombstone.Key] = true
+		}
+	}
+
+	return pruned, nil
+}
+
+// sortEntries sorts the entries by batch
+func sortEntries(entries []Entry) {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].BatchNum != entries[j].BatchNum {
+			return entries[i].BatchNum < entries[j].BatchNum
+		}
+		return entries[i].Key.Hex() < entries[j].Key.Hex()
+	})
+}
+
+// Sequenced returns the keys sequenced in the given blocks with their batch numbers
+func (a *Auditor) Sequenced(ctx context.Context, fromBlock, toBlock uint64) (*Sequenced, error) {
+	sequenced := &Sequenced{
+		Keys:        make(map[common.Hash]uint64),
+		BlobBatches: make(map[uint64]bool),
+		Blocks:      make(map[uint64]uint64),
+	}
+
+	for start := fromBlock; start <= toBlock; start += a.blockRange {
+		end := start + a.blockRange - 1
+		if end > toBlock {
+			end = toBlock
+		}
+		logger.Debugf("auditing the sequences of the blocks %d to %d", start, end)
+
+		iter, err := a.etherman.FilterSequenceBatches(&bind.FilterOpts{Context: ctx, Start: start, End: &end}, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to filter the sequences of the blocks %d to %d: %w", start, end, err)
+		}
+
+		for iter.Next() {
+			event := iter.Event
+			tx, _, err := a.etherman.GetTx(ctx, event.Raw.TxHash)
+			if err != nil {
+				_ = iter.Close()
+				return nil, fmt.Errorf("failed to get the sequence %s: %w", event.Raw.TxHash.Hex(), err)
+			}
+
+			var first uint64
+			if len(tx.BlobHashes()) > 0 {
+				sequenced.BlobSequences++
+				sequenced.BlobBatches[event.NumBatch] = true
+				sequenced.Blocks[event.NumBatch] = event.Raw.BlockNumber
+				first = event.NumBatch
+			} else {
+				keys, err := synchronizer.UnpackTxData(tx.Data())
+				var batchKeys []types.BatchKey
+				if err == nil {
+					batchKeys, err = synchronizer.SequenceBatchKeys(event.NumBatch, keys)
+				}
+				if errors.Is(err, synchronizer.ErrMalformedSequence) || errors.Is(err, synchronizer.ErrUnknownMethod) {
+					// the synchronizer skips the malformed sequences as well, and stalls on the other ones
+					logger.Warnf("skipping the sequence %s: %v", event.Raw.TxHash.Hex(), err)
+					continue
+				}
+				if err != nil {
+					_ = iter.Close()
+					return nil, fmt.Errorf("failed to unpack the sequence %s: %w", event.Raw.TxHash.Hex(), err)
+				}
+				if len(batchKeys) == 0 {
+					continue
+				}
+
+				for _, batchKey := range batchKeys {
+					sequenced.Keys[batchKey.Hash] = batchKey.Number
+					sequenced.Blocks[batchKey.Number] = event.Raw.BlockNumber
+				}
+				first = batchKeys[0].Number
+			}
+
+			if sequenced.FromBatch == 0 || first < sequenced.FromBatch {
+				sequenced.FromBatch = first
+			}
+			if event.NumBatch > sequenced.ToBatch {
+				sequenced.ToBatch = event.NumBatch
+			}
+		}
+		if err = iter.Error(); err != nil {
+			return nil, err
+		}
+		if err = iter.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	return sequenced, nil
+}
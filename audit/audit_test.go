@@ -0,0 +1,59 @@
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/etherman/ethermantest"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditor_Audit(t *testing.T) {
+	first, second, third, pruned := []byte("first"), []byte("second"), []byte("third"), []byte("pruned")
+	log1, tx1 := ethermantest.Sequence(t, 2, first, second)
+	log2, tx2 := ethermantest.Sequence(t, 4, third, pruned)
+
+	em := mocks.NewEtherman(t)
+	em.On("FilterSequenceBatches", ethermantest.BlockRange(100, 199), mock.Anything).
+		Return(ethermantest.Iterator(t, log1, log2), nil).Once()
+	em.On("GetTx", mock.Anything, tx1.Hash()).Return(tx1, false, nil)
+	em.On("GetTx", mock.Anything, tx2.Hash()).Return(tx2, false, nil)
+
+	em.On("FilterSequenceBatches", ethermantest.BlockRange(200, 250), mock.Anything).
+		Return(ethermantest.Iterator(t), nil).Once()
+
+	orphan := []byte("orphan")
+	stored := []types.OffChainData{
+		{Key: crypto.Keccak256Hash(first), Value: first, BatchNum: 1},
+		{Key: crypto.Keccak256Hash(third), Value: []byte("corrupt"), BatchNum: 3},
+		{Key: crypto.Keccak256Hash(orphan), Value: orphan, BatchNum: 2},
+		{Key: crypto.Keccak256Hash([]byte("older")), Value: []byte("older"), BatchNum: 50},
+	}
+
+	dbMock := mocks.NewDB(t)
+	dbMock.On("ListOffChainDataPage", mock.Anything, common.Hash{}, uint(3)).Return(stored[:3], nil)
+	dbMock.On("ListOffChainDataPage", mock.Anything, stored[2].Key, uint(3)).Return(stored[3:], nil)
+
+	// the value of the batch 4 was deleted per the retention policy
+	dbMock.On("GetTombstones", mock.Anything, mock.MatchedBy(func(keys []common.Hash) bool {
+		return len(keys) == 2
+	})).Return([]types.Tombstone{{Key: crypto.Keccak256Hash(pruned), BatchNum: 4}}, nil).Once()
+
+	report, err := New(dbMock, em, 100, 3).Audit(context.Background(), 100, 250)
+	require.NoError(t, err)
+
+	require.Equal(t, types.ArgUint64(1), report.FromBatch)
+	require.Equal(t, types.ArgUint64(4), report.ToBatch)
+	require.Equal(t, uint64(4), report.Expected)
+	require.Equal(t, uint64(4), report.Stored)
+	require.Equal(t, []Entry{{Key: crypto.Keccak256Hash(third), BatchNum: 3}}, report.Corrupt)
+	require.Equal(t, []Entry{{Key: crypto.Keccak256Hash(orphan), BatchNum: 2}}, report.Orphaned)
+	require.Equal(t, []Entry{{Key: crypto.Keccak256Hash(second), BatchNum: 2}}, report.Missing)
+	require.Equal(t, []Entry{{Key: crypto.Keccak256Hash(pruned), BatchNum: 4}}, report.Pruned)
+	require.Equal(t, 3, report.Problems())
+}
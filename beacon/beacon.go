@@ -0,0 +1,154 @@
+package beacon
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+)
+
+const (
+	genesisPath      = "/eth/v1/beacon/genesis"
+	specPath         = "/eth/v1/config/spec"
+	blobSidecarsPath = "/eth/v1/beacon/blob_sidecars/"
+)
+
+// Sidecar is a blob along with its KZG commitment and proof, as returned by the beacon API
+type Sidecar struct {
+	Index         string             `json:"index"`
+	Blob          kzg4844.Blob       `json:"blob"`
+	KZGCommitment kzg4844.Commitment `json:"kzg_commitment"`
+	KZGProof      kzg4844.Proof      `json:"kzg_proof"`
+}
+
+// Client gets the blobs of the L1 blocks from the beacon API of a consensus node. Blobs are only
+// kept by the consensus nodes for about 18 days, older ones need an archival node.
+type Client struct {
+	url    string
+	client *http.Client
+
+	// the genesis time and the slot duration are read once, to find the slot of a block
+	lock           sync.Mutex
+	genesisTime    uint64
+	secondsPerSlot uint64
+}
+
+// New returns a Client of the beacon API at the given URL
+func New(url string, timeout time.Duration) *Client {
+	return &Client{
+		url:    strings.TrimSuffix(url, "/"),
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// GetBlobs returns the blobs of the given versioned hashes included in the L1 block of the given
+// timestamp, in the order of the hashes. Each blob is verified against its KZG commitment, and the
+// commitment against the versioned hash.
+func (c *Client) GetBlobs(ctx context.Context, timestamp uint64, hashes []common.Hash) ([]kzg4844.Blob, error) {
+	slot, err := c.slotAt(ctx, timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	var res struct {
+		Data []Sidecar `json:"data"`
+	}
+	if err = c.get(ctx, blobSidecarsPath+strconv.FormatUint(slot, 10), &res); err != nil {
+		return nil, fmt.Errorf("failed to get the blobs of the slot %d: %w", slot, err)
+	}
+
+	byHash := make(map[common.Hash]*Sidecar, len(res.Data))
+	for i := range res.Data {
+		sidecar := &res.Data[i]
+		byHash[kzg4844.CalcBlobHashV1(sha256.New(), &sidecar.KZGCommitment)] = sidecar
+	}
+
+	blobs := make([]kzg4844.Blob, len(hashes))
+	for i, hash := range hashes {
+		sidecar, ok := byHash[hash]
+		if !ok {
+			return nil, fmt.Errorf("blob %s not found in the slot %d", hash.Hex(), slot)
+		}
+		if err = kzg4844.VerifyBlobProof(sidecar.Blob, sidecar.KZGCommitment, sidecar.KZGProof); err != nil {
+			return nil, fmt.Errorf("invalid KZG proof of the blob %s: %w", hash.Hex(), err)
+		}
+
+		blobs[i] = sidecar.Blob
+	}
+
+	return blobs, nil
+}
+
+// slotAt returns the slot of the L1 block of the given timestamp
+func (c *Client) slotAt(ctx context.Context, timestamp uint64) (uint64, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.secondsPerSlot == 0 {
+		var genesis struct {
+			Data struct {
+				GenesisTime string `json:"genesis_time"`
+			} `json:"data"`
+		}
+		if err := c.get(ctx, genesisPath, &genesis); err != nil {
+			return 0, fmt.Errorf("failed to get the genesis of the beacon chain: %w", err)
+		}
+
+		var spec struct {
+			Data struct {
+				SecondsPerSlot string `json:"SECONDS_PER_SLOT"`
+			} `json:"data"`
+		}
+		if err := c.get(ctx, specPath, &spec); err != nil {
+			return 0, fmt.Errorf("failed to get the spec of the beacon chain: %w", err)
+		}
+
+		genesisTime, err := strconv.ParseUint(genesis.Data.GenesisTime, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid genesis time %q: %w", genesis.Data.GenesisTime, err)
+		}
+		secondsPerSlot, err := strconv.ParseUint(spec.Data.SecondsPerSlot, 10, 64)
+		if err != nil || secondsPerSlot == 0 {
+			return 0, fmt.Errorf("invalid seconds per slot %q", spec.Data.SecondsPerSlot)
+		}
+
+		c.genesisTime, c.secondsPerSlot = genesisTime, secondsPerSlot
+	}
+
+	if timestamp < c.genesisTime {
+		return 0, fmt.Errorf("timestamp %d before the genesis of the beacon chain", timestamp)
+	}
+
+	return (timestamp - c.genesisTime) / c.secondsPerSlot, nil
+}
+
+// get decodes the JSON response of the given path of the beacon API
+func (c *Client) get(ctx context.Context, path string, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 512)) //nolint:gomnd
+		return fmt.Errorf("unexpected status code %d: %s", res.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	return json.NewDecoder(res.Body).Decode(result)
+}
@@ -0,0 +1,93 @@
+package beacon
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/stretchr/testify/require"
+)
+
+// newSidecar returns a sidecar of a blob holding the given data, and its versioned hash
+func newSidecar(t *testing.T, data []byte) (Sidecar, common.Hash) {
+	t.Helper()
+
+	// the first byte of every field element is left empty so that it is below the modulus
+	var blob kzg4844.Blob
+	copy(blob[1:32], data)
+
+	commitment, err := kzg4844.BlobToCommitment(blob)
+	require.NoError(t, err)
+	proof, err := kzg4844.ComputeBlobProof(blob, commitment)
+	require.NoError(t, err)
+
+	return Sidecar{Index: "0", Blob: blob, KZGCommitment: commitment, KZGProof: proof},
+		kzg4844.CalcBlobHashV1(sha256.New(), &commitment)
+}
+
+func newTestServer(t *testing.T, sidecars []Sidecar) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body interface{}
+		switch r.URL.Path {
+		case genesisPath:
+			body = map[string]interface{}{"data": map[string]string{"genesis_time": "1000"}}
+		case specPath:
+			body = map[string]interface{}{"data": map[string]string{"SECONDS_PER_SLOT": "12"}}
+		case blobSidecarsPath + "5":
+			body = map[string]interface{}{"data": sidecars}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+
+		require.NoError(t, json.NewEncoder(w).Encode(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestClient_GetBlobs(t *testing.T) {
+	first, firstHash := newSidecar(t, []byte("first"))
+	second, secondHash := newSidecar(t, []byte("second"))
+
+	t.Run("verified blobs", func(t *testing.T) {
+		srv := newTestServer(t, []Sidecar{first, second})
+
+		// the slot 5 starts 60 seconds after the genesis
+		blobs, err := New(srv.URL, time.Second).GetBlobs(context.Background(), 1065, []common.Hash{secondHash, firstHash})
+		require.NoError(t, err)
+		require.Equal(t, []kzg4844.Blob{second.Blob, first.Blob}, blobs)
+	})
+
+	t.Run("blob not found", func(t *testing.T) {
+		srv := newTestServer(t, []Sidecar{first})
+
+		_, err := New(srv.URL, time.Second).GetBlobs(context.Background(), 1060, []common.Hash{secondHash})
+		require.ErrorContains(t, err, "not found in the slot 5")
+	})
+
+	t.Run("invalid proof", func(t *testing.T) {
+		invalid := first
+		invalid.KZGProof = second.KZGProof
+		srv := newTestServer(t, []Sidecar{invalid})
+
+		_, err := New(srv.URL, time.Second).GetBlobs(context.Background(), 1060, []common.Hash{firstHash})
+		require.ErrorContains(t, err, "invalid KZG proof")
+	})
+
+	t.Run("slot without blobs", func(t *testing.T) {
+		srv := newTestServer(t, nil)
+
+		_, err := New(srv.URL, time.Second).GetBlobs(context.Background(), 1000, []common.Hash{firstHash})
+		require.ErrorContains(t, err, "unexpected status code 404")
+	})
+}
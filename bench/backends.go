@@ -0,0 +1,72 @@
+package bench
+
+import (
+	"context"
+	"sync"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+)
+
+// Backend is a storage the scenarios are run against, so that the storages can be compared
+type Backend struct {
+	Name string
+
+	// Open returns the storage, and the function releasing it once the scenario is run
+	Open func(ctx context.Context) (db.DB, func(), error)
+}
+
+var (
+	backendsLock sync.Mutex
+	backends     []Backend
+)
+
+// Register adds a backend to the ones the benchmarks of the package are run against
+func Register(backend Backend) {
+	backendsLock.Lock()
+	defer backendsLock.Unlock()
+
+	backends = append(backends, backend)
+}
+
+// Backends returns the registered backends, in the order they were registered
+func Backends() []Backend {
+	backendsLock.Lock()
+	defer backendsLock.Unlock()
+
+	return append([]Backend(nil), backends...)
+}
+
+// Postgres returns the backend storing in the PostgreSQL database of the config, whose migrations are applied
+// when opened. The database is meant for the benchmarks: the values of a scenario go through the outbox.
+func Postgres(cfg db.Config) Backend {
+	name := "postgres"
+	if cfg.PreparedStatements {
+		name = "postgres-prepared"
+	}
+
+	return Backend{
+		Name: name,
+		Open: func(ctx context.Context) (db.DB, func(), error) {
+			pg, err := db.InitContext(ctx, cfg)
+			if err != nil {
+				return nil, nil, err
+			}
+			if err = db.RunMigrationsUp(pg); err != nil {
+				_ = pg.Close()
+				return nil, nil, err
+			}
+
+			return db.NewFromConfig(pg, cfg, false), func() { _ = pg.Close() }, nil
+		},
+	}
+}
+
+// Memory returns the backend keeping the values in memory, the baseline the other backends are compared to
+func Memory() Backend {
+	return Backend{
+		Name: "memory",
+		Open: func(context.Context) (db.DB, func(), error) {
+			return db.NewMemory(0), func() {}, nil
+		},
+	}
+}
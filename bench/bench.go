@@ -0,0 +1,160 @@
+package bench
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/client"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Summary is the latency distribution and the throughput of an operation
+type Summary struct {
+	Name       string        `json:"name"`
+	Count      int           `json:"count"`
+	Errors     int           `json:"errors"`
+	Elapsed    time.Duration `json:"elapsed"`
+	Throughput float64       `json:"throughput"`
+	P50        time.Duration `json:"p50"`
+	P90        time.Duration `json:"p90"`
+	P99        time.Duration `json:"p99"`
+	Max        time.Duration `json:"max"`
+}
+
+// Summarize returns the summary of the given latencies of the operations run during elapsed, the
+// throughput being in operations per second
+func Summarize(name string, latencies []time.Duration, failures int, elapsed time.Duration) Summary {
+	summary := Summary{Name: name, Count: len(latencies), Errors: failures, Elapsed: elapsed}
+	if len(latencies) == 0 {
+		return summary
+	}
+
+	sorted := make([]time.Duration, len(latencies))
+	copy(sorted, latencies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	percentile := func(p int) time.Duration {
+		return sorted[(len(sorted)-1)*p/100]
+	}
+
+	summary.P50, summary.P90, summary.P99 = percentile(50), percentile(90), percentile(99) //nolint:gomnd
+	summary.Max = sorted[len(sorted)-1]
+	if elapsed > 0 {
+		summary.Throughput = float64(len(latencies)) / elapsed.Seconds()
+	}
+
+	return summary
+}
+
+// Values returns count synthetic values of the given size, with random content so they do not compress
+func Values(count, size int) ([]types.OffChainData, error) {
+	values := make([]types.OffChainData, count)
+	for i := range values {
+		value := make([]byte, size)
+		if _, err := rand.Read(value); err != nil {
+			return nil, err
+		}
+
+		values[i] = types.OffChainData{Key: crypto.Keccak256Hash(value), Value: value}
+	}
+
+	return values, nil
+}
+
+// Storage measures the local storage: the values are stored pageSize at a time, as the synchronizer
+// does, then read one by one, by concurrency workers
+func Storage(ctx context.Context, storage db.DB, values []types.OffChainData, pageSize, concurrency int) []Summary {
+	pages := make([][]types.OffChainData, 0, (len(values)+pageSize-1)/pageSize)
+	for start := 0; start < len(values); start += pageSize {
+		end := start + pageSize
+		if end > len(values) {
+			end = len(values)
+		}
+		pages = append(pages, values[start:end])
+	}
+
+	store := run(ctx, "store", len(pages), concurrency, func(ctx context.Context, i int) error {
+		return storage.StoreOffChainData(ctx, pages[i])
+	})
+
+	return []Summary{store, Reads(ctx, "get", storage, values, concurrency)}
+}
+
+// Reads measures the reads of the given stored values one by one, by concurrency workers
+func Reads(ctx context.Context, name string, storage db.DB, values []types.OffChainData, concurrency int) Summary {
+	return run(ctx, name, len(values), concurrency, func(ctx context.Context, i int) error {
+		data, err := storage.GetOffChainData(ctx, values[i].Key)
+		if err != nil {
+			return err
+		}
+		if len(data.Value) != len(values[i].Value) {
+			return fmt.Errorf("the value %s was not stored whole", values[i].Key.Hex())
+		}
+		return nil
+	})
+}
+
+// RPC measures the end-to-end latency of requesting the given keys to a running node, requests times
+// in total by concurrency workers
+func RPC(ctx context.Context, node client.Client, keys []common.Hash, requests, concurrency int) Summary {
+	return run(ctx, "rpc get", requests, concurrency, func(ctx context.Context, i int) error {
+		key := keys[i%len(keys)]
+		value, err := node.GetOffChainData(ctx, key)
+		if err != nil {
+			return err
+		}
+		if crypto.Keccak256Hash(value) != key {
+			return fmt.Errorf("the node returned a value not matching the key %s", key.Hex())
+		}
+		return nil
+	})
+}
+
+// run runs the operation count times by concurrency workers, and summarizes the latencies of the
+// successful runs
+func run(ctx context.Context, name string, count, concurrency int, op func(ctx context.Context, i int) error) Summary {
+	var (
+		lock      sync.Mutex
+		wg        sync.WaitGroup
+		latencies = make([]time.Duration, 0, count)
+		failures  int
+	)
+
+	next := make(chan int)
+	start := time.Now()
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range next {
+				opStart := time.Now()
+				err := op(ctx, i)
+				latency := time.Since(opStart)
+
+				lock.Lock()
+				if err != nil {
+					failures++
+				} else {
+					latencies = append(latencies, latency)
+				}
+				lock.Unlock()
+			}
+		}()
+	}
+
+	for i := 0; i < count && ctx.Err() == nil; i++ {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	return Summarize(name, latencies, failures, time.Since(start))
+}
@@ -0,0 +1,78 @@
+package bench
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarize(t *testing.T) {
+	t.Parallel()
+
+	latencies := make([]time.Duration, 100)
+	for i := range latencies {
+		// in reverse order, to check they are sorted
+		latencies[i] = time.Duration(100-i) * time.Millisecond
+	}
+
+	summary := Summarize("get", latencies, 2, 2*time.Second)
+	require.Equal(t, Summary{
+		Name:       "get",
+		Count:      100,
+		Errors:     2,
+		Elapsed:    2 * time.Second,
+		Throughput: 50,
+		P50:        50 * time.Millisecond,
+		P90:        90 * time.Millisecond,
+		P99:        99 * time.Millisecond,
+		Max:        100 * time.Millisecond,
+	}, summary)
+
+	require.Equal(t, Summary{Name: "get", Errors: 1, Elapsed: time.Second},
+		Summarize("get", nil, 1, time.Second))
+}
+
+func TestStorage(t *testing.T) {
+	t.Parallel()
+
+	values, err := Values(5, 32)
+	require.NoError(t, err)
+	require.NotEqual(t, values[0].Key, values[1].Key)
+
+	dbMock := mocks.NewDB(t)
+	dbMock.On("StoreOffChainData", mock.Anything, values[:2]).Return(nil).Once()
+	dbMock.On("StoreOffChainData", mock.Anything, values[2:4]).Return(nil).Once()
+	dbMock.On("StoreOffChainData", mock.Anything, values[4:]).Return(errors.New("test")).Once()
+	for _, value := range values {
+		value := value
+		dbMock.On("GetOffChainData", mock.Anything, value.Key).Return(&value, nil).Once()
+	}
+
+	summaries := Storage(context.Background(), dbMock, values, 2, 3)
+	require.Len(t, summaries, 2)
+	require.Equal(t, 2, summaries[0].Count)
+	require.Equal(t, 1, summaries[0].Errors)
+	require.Equal(t, 5, summaries[1].Count)
+	require.Equal(t, 0, summaries[1].Errors)
+}
+
+func TestRPC(t *testing.T) {
+	t.Parallel()
+
+	values, err := Values(2, 32)
+	require.NoError(t, err)
+
+	node := mocks.NewClient(t)
+	node.On("GetOffChainData", mock.Anything, values[0].Key).Return(values[0].Value, nil).Times(2)
+	node.On("GetOffChainData", mock.Anything, values[1].Key).Return([]byte("other"), nil).Times(2)
+
+	summary := RPC(context.Background(), node, []common.Hash{values[0].Key, values[1].Key}, 4, 2)
+	require.Equal(t, 2, summary.Count)
+	require.Equal(t, 2, summary.Errors)
+}
@@ -0,0 +1,157 @@
+package bench
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// SeededValues returns count synthetic values of the given size, the same for the same seed so that the runs
+// of a scenario are reproducible
+func SeededValues(seed int64, count, size int) []types.OffChainData {
+	// not a secret, the values only need to be reproducible and not compress
+	source := rand.New(rand.NewSource(seed)) //nolint:gosec
+
+	values := make([]types.OffChainData, count)
+	for i := range values {
+		value := make([]byte, size)
+		_, _ = source.Read(value)
+
+		values[i] = types.OffChainData{Key: crypto.Keccak256Hash(value), Value: value}
+	}
+
+	return values
+}
+
+// Backfill is the scenario of the synchronizer catching up on Events sequences of KeysPerEvent batches,
+// whose data is ValueSize bytes
+type Backfill struct {
+	Events       int
+	KeysPerEvent int
+	ValueSize    int
+	Seed         int64
+}
+
+// Sequences returns the data of the batches of each sequence, numbered from 1 in order
+func (s Backfill) Sequences() [][]types.OffChainData {
+	values := SeededValues(s.Seed, s.Events*s.KeysPerEvent, s.ValueSize)
+
+	sequences := make([][]types.OffChainData, s.Events)
+	for i := range sequences {
+		sequences[i] = values[i*s.KeysPerEvent : (i+1)*s.KeysPerEvent]
+		for j := range sequences[i] {
+			sequences[i][j].BatchNum = uint64(i*s.KeysPerEvent + j + 1)
+		}
+	}
+
+	return sequences
+}
+
+// Run stores the sequences one at a time in order, as the synchronizer does: the keys of the batches are
+// stored as unresolved, then their data with its provenance, and the keys are marked resolved
+func (s Backfill) Run(ctx context.Context, storage db.DB, sequences [][]types.OffChainData) Summary {
+	return run(ctx, "backfill", len(sequences), 1, func(ctx context.Context, i int) error {
+		keys := make([]types.BatchKey, len(sequences[i]))
+		for j, data := range sequences[i] {
+			keys[j] = types.BatchKey{Number: data.BatchNum, Hash: data.Key}
+		}
+
+		if err := storage.StoreUnresolvedBatchKeys(ctx, keys); err != nil {
+			return err
+		}
+		if err := storage.StoreOffChainData(ctx, sequences[i]); err != nil {
+			return err
+		}
+		if err := storage.StoreProvenance(ctx,
+			types.NewProvenance(sequences[i], types.ProvenanceSequencer, "")); err != nil {
+			return err
+		}
+
+		return storage.DeleteUnresolvedBatchKeys(ctx, keys)
+	})
+}
+
+// Serve is the scenario of the node serving Requests reads of Keys stored values of ValueSize bytes, sent at
+// Rate requests per second whatever the latency of the storage
+type Serve struct {
+	Keys      int
+	ValueSize int
+	Rate      int
+	Requests  int
+	Seed      int64
+}
+
+// Values returns the values read, to store before running the scenario
+func (s Serve) Values() []types.OffChainData {
+	return SeededValues(s.Seed, s.Keys, s.ValueSize)
+}
+
+// Run reads the stored values in turn at the rate of the scenario
+func (s Serve) Run(ctx context.Context, storage db.DB, values []types.OffChainData) Summary {
+	return paced(ctx, "serve", s.Requests, s.Rate, func(ctx context.Context, i int) error {
+		value := values[i%len(values)]
+		data, err := storage.GetOffChainData(ctx, value.Key)
+		if err != nil {
+			return err
+		}
+		if len(data.Value) != len(value.Value) {
+			return fmt.Errorf("the value %s was not stored whole", value.Key.Hex())
+		}
+		return nil
+	})
+}
+
+// Keys returns the keys of the values, e.g. to delete them once the scenario is run
+func Keys(values []types.OffChainData) []common.Hash {
+	keys := make([]common.Hash, len(values))
+	for i, value := range values {
+		keys[i] = value.Key
+	}
+
+	return keys
+}
+
+// paced starts the operation count times at the given rate per second, without waiting for the previous
+// ones to complete, and summarizes the latencies of the successful runs measured from when each was due, so
+// that a storage falling behind the rate shows in the latencies rather than slowing down the requests
+func paced(ctx context.Context, name string, count, rate int, op func(ctx context.Context, i int) error) Summary {
+	var (
+		lock      sync.Mutex
+		wg        sync.WaitGroup
+		latencies = make([]time.Duration, 0, count)
+		failures  int
+	)
+
+	interval := time.Second / time.Duration(rate)
+	start := time.Now()
+	for i := 0; i < count && ctx.Err() == nil; i++ {
+		due := start.Add(time.Duration(i) * interval)
+		time.Sleep(time.Until(due))
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			err := op(ctx, i)
+			latency := time.Since(due)
+
+			lock.Lock()
+			if err != nil {
+				failures++
+			} else {
+				latencies = append(latencies, latency)
+			}
+			lock.Unlock()
+		}(i)
+	}
+	wg.Wait()
+
+	return Summarize(name, latencies, failures, time.Since(start))
+}
@@ -0,0 +1,182 @@
+package bench
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeededValues(t *testing.T) {
+	t.Parallel()
+
+	values := SeededValues(1, 3, 32)
+	require.Len(t, values, 3)
+	require.Equal(t, values, SeededValues(1, 3, 32))
+	require.NotEqual(t, values, SeededValues(2, 3, 32))
+	require.NotEqual(t, values[0].Key, values[1].Key)
+}
+
+func TestBackfill(t *testing.T) {
+	t.Parallel()
+
+	scenario := Backfill{Events: 2, KeysPerEvent: 2, ValueSize: 32, Seed: 1}
+	sequences := scenario.Sequences()
+	require.Len(t, sequences, 2)
+	require.Equal(t, uint64(1), sequences[0][0].BatchNum)
+	require.Equal(t, uint64(4), sequences[1][1].BatchNum)
+
+	dbMock := mocks.NewDB(t)
+	for _, sequence := range sequences {
+		keys := []types.BatchKey{
+			{Number: sequence[0].BatchNum, Hash: sequence[0].Key},
+			{Number: sequence[1].BatchNum, Hash: sequence[1].Key},
+		}
+		dbMock.On("StoreUnresolvedBatchKeys", mock.Anything, keys).Return(nil).Once()
+		dbMock.On("StoreOffChainData", mock.Anything, sequence).Return(nil).Once()
+		dbMock.On("StoreProvenance", mock.Anything, mock.Anything).Return(nil).Once()
+		dbMock.On("DeleteUnresolvedBatchKeys", mock.Anything, keys).Return(nil).Once()
+	}
+
+	summary := scenario.Run(context.Background(), dbMock, sequences)
+	require.Equal(t, 2, summary.Count)
+	require.Equal(t, 0, summary.Errors)
+}
+
+func TestServe(t *testing.T) {
+	t.Parallel()
+
+	scenario := Serve{Keys: 2, ValueSize: 32, Rate: 1000, Requests: 4, Seed: 1}
+	values := scenario.Values()
+
+	dbMock := mocks.NewDB(t)
+	dbMock.On("GetOffChainData", mock.Anything, values[0].Key).Return(&values[0], nil).Times(2)
+	dbMock.On("GetOffChainData", mock.Anything, values[1].Key).Return(nil, errors.New("test")).Times(2)
+
+	summary := scenario.Run(context.Background(), dbMock, values)
+	require.Equal(t, 2, summary.Count)
+	require.Equal(t, 2, summary.Errors)
+}
+
+// benchBackends returns the backends to benchmark, the memory, the registered ones and the PostgreSQL database
+// set in the environment, e.g. BENCH_DB_HOST=localhost go test -run none -bench . ./bench
+func benchBackends(b *testing.B) []Backend {
+	b.Helper()
+
+	list := append([]Backend{Memory()}, Backends()...)
+	if host := os.Getenv("BENCH_DB_HOST"); host != "" {
+		env := func(name, fallback string) string {
+			if value := os.Getenv(name); value != "" {
+				return value
+			}
+			return fallback
+		}
+		cfg := db.Config{
+			Host:     host,
+			Port:     env("BENCH_DB_PORT", "5432"),
+			User:     env("BENCH_DB_USER", "committee_user"),
+			Password: env("BENCH_DB_PASSWORD", "committee_password"),
+			Name:     env("BENCH_DB_NAME", "committee_db"),
+			MaxConns: 50,
+		}
+		list = append(list, Postgres(cfg))
+		cfg.PreparedStatements = true
+		list = append(list, Postgres(cfg))
+	}
+	return list
+}
+
+// openBackend opens the storage of the backend, released once the benchmark is done
+func openBackend(b *testing.B, backend Backend) db.DB {
+	b.Helper()
+
+	storage, release, err := backend.Open(context.Background())
+	if err != nil {
+		b.Fatalf("failed to open %s: %v", backend.Name, err)
+	}
+	b.Cleanup(release)
+
+	return storage
+}
+
+// BenchmarkBackfill measures the storage of the sequences of the synchronizer catching up, an operation
+// being a sequence
+func BenchmarkBackfill(b *testing.B) {
+	const round = 64
+
+	ctx := context.Background()
+	for _, backend := range benchBackends(b) {
+		for _, keys := range []int{10, 100} {
+			for _, size := range []int{1024, 64 * 1024} {
+				b.Run(fmt.Sprintf("%s/keys=%d/size=%d", backend.Name, keys, size), func(b *testing.B) {
+					storage := openBackend(b, backend)
+					b.SetBytes(int64(keys * size))
+					b.ResetTimer()
+
+					// the sequences are generated and deleted by rounds, out of the measure
+					for done := 0; done < b.N; done += round {
+						b.StopTimer()
+						scenario := Backfill{Events: round, KeysPerEvent: keys, ValueSize: size, Seed: int64(done)}
+						if b.N-done < round {
+							scenario.Events = b.N - done
+						}
+						sequences := scenario.Sequences()
+						b.StartTimer()
+
+						summary := scenario.Run(ctx, storage, sequences)
+
+						b.StopTimer()
+						if summary.Errors > 0 {
+							b.Fatalf("%d sequences failed to be stored", summary.Errors)
+						}
+						for _, sequence := range sequences {
+							if err := storage.DeleteOffChainData(ctx, Keys(sequence)); err != nil {
+								b.Fatal(err)
+							}
+						}
+						b.StartTimer()
+					}
+				})
+			}
+		}
+	}
+}
+
+// BenchmarkServe measures the latency of the reads of the stored values at a given rate, an operation being
+// a read. The latency percentiles are reported as metrics.
+func BenchmarkServe(b *testing.B) {
+	ctx := context.Background()
+	for _, backend := range benchBackends(b) {
+		for _, rate := range []int{1000, 5000} {
+			b.Run(fmt.Sprintf("%s/rate=%d", backend.Name, rate), func(b *testing.B) {
+				storage := openBackend(b, backend)
+
+				scenario := Serve{Keys: 1000, ValueSize: 32 * 1024, Rate: rate, Requests: b.N, Seed: 1}
+				values := scenario.Values()
+				for start := 0; start < len(values); start += 100 {
+					if err := storage.StoreOffChainData(ctx, values[start:start+100]); err != nil {
+						b.Fatal(err)
+					}
+				}
+				b.Cleanup(func() { _ = storage.DeleteOffChainData(ctx, Keys(values)) })
+				b.ResetTimer()
+
+				summary := scenario.Run(ctx, storage, values)
+
+				b.StopTimer()
+				if summary.Errors > 0 {
+					b.Fatalf("%d reads failed", summary.Errors)
+				}
+				b.ReportMetric(float64(summary.P50.Microseconds()), "p50-us")
+				b.ReportMetric(float64(summary.P99.Microseconds()), "p99-us")
+			})
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/0xPolygon/cdk-data-availability/client"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// logger is the logger of the bootstrap component
+var logger = log.WithComponent("bootstrap")
+
+// Importer loads the values stored by a peer node, so that a new committee member starts with the data
+// of the committee instead of resolving every batch again. The peer is not trusted: every value is
+// checked against its key, and the import stops at the first value that does not match.
+type Importer struct {
+	peer     client.Client
+	peerURL  string
+	db       db.DB
+	pageSize uint
+	// maxValueSize bounds the size of the values imported, 0 for no limit
+	maxValueSize uint64
+}
+
+// New returns an Importer of the values of the peer of the given URL, requested pageSize at a time, refusing
+// the values larger than maxValueSize bytes
+func New(peer client.Client, peerURL string, db db.DB, pageSize uint, maxValueSize uint64) *Importer {
+	return &Importer{
+		peer:         peer,
+		peerURL:      peerURL,
+		db:           db,
+		pageSize:     pageSize,
+		maxValueSize: maxValueSize,
+	}
+}
+
+// Import pages through the values of the peer whose key follows the given key, storing each page,
+// and returns the number of values imported and the last key imported. An interrupted import is
+// resumed from the last key imported.
+func (i *Importer) Import(ctx context.Context, after common.Hash) (uint64, common.Hash, error) {
+	var count uint64
+	for {
+		page, err := i.peer.ListOffChainDataPage(ctx, after, i.pageSize)
+		if err != nil {
+			return count, after, fmt.Errorf("failed to list the values after %s: %w", after.Hex(), err)
+		}
+		if len(page) == 0 {
+			return count, after, nil
+		}
+
+		last := after
+		for _, data := range page {
+			// the keys must increase, or the peer could keep the import going forever
+			if bytes.Compare(data.Key.Bytes(), last.Bytes()) <= 0 {
+				return count, after, fmt.Errorf("the peer listed the key %s after %s", data.Key.Hex(), last.Hex())
+			}
+			if err = types.CheckValueSize(data.Value, i.maxValueSize); err != nil {
+				return count, after, fmt.Errorf("the peer returned a value too large for the key %s: %w",
+					data.Key.Hex(), err)
+			}
+			if crypto.Keccak256Hash(data.Value) != data.Key {
+				return count, after, fmt.Errorf("the peer returned a value not matching the key %s", data.Key.Hex())
+			}
+
+			last = data.Key
+		}
+
+		if err = i.db.StoreOffChainData(ctx, page); err != nil {
+			return count, after, fmt.Errorf("failed to store the values after %s: %w", after.Hex(), err)
+		}
+		if err = i.db.StoreProvenance(ctx, types.NewProvenance(page, types.ProvenancePeer, i.peerURL)); err != nil {
+			return count, after, fmt.Errorf("failed to store the provenance of the values after %s: %w",
+				after.Hex(), err)
+		}
+
+		count += uint64(len(page))
+		after = last
+		logger.Infof("imported %d values, up to the key %s", count, after.Hex())
+
+		if uint(len(page)) < i.pageSize {
+			return count, after, nil
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// sortedValues returns the given number of values sorted by key
+func sortedValues(count int) []types.OffChainData {
+	list := make([]types.OffChainData, count)
+	for i := range list {
+		value := []byte{byte(i)}
+		list[i] = types.OffChainData{Key: crypto.Keccak256Hash(value), Value: value, BatchNum: uint64(i)}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return bytes.Compare(list[i].Key.Bytes(), list[j].Key.Bytes()) < 0
+	})
+
+	return list
+}
+
+// peerURL is the URL of the peer the values are imported from
+const peerURL = "http://peer"
+
+func TestImporter_Import(t *testing.T) {
+	t.Parallel()
+
+	values := sortedValues(3)
+
+	t.Run("every page imported", func(t *testing.T) {
+		t.Parallel()
+
+		peer := mocks.NewClient(t)
+		peer.On("ListOffChainDataPage", mock.Anything, common.Hash{}, uint(2)).Return(values[:2], nil).Once()
+		peer.On("ListOffChainDataPage", mock.Anything, values[1].Key, uint(2)).Return(values[2:], nil).Once()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("StoreOffChainData", mock.Anything, values[:2]).Return(nil).Once()
+		dbMock.On("StoreOffChainData", mock.Anything, values[2:]).Return(nil).Once()
+		// the values are recorded as imported from the peer
+		dbMock.On("StoreProvenance", mock.Anything, mock.MatchedBy(func(provenance []types.Provenance) bool {
+			for _, p := range provenance {
+				if p.Source != types.ProvenancePeer || p.Origin != peerURL {
+					return false
+				}
+			}
+			return true
+		})).Return(nil).Twice()
+
+		count, last, err := New(peer, peerURL, dbMock, 2, 0).Import(context.Background(), common.Hash{})
+		require.NoError(t, err)
+		require.Equal(t, uint64(3), count)
+		require.Equal(t, values[2].Key, last)
+	})
+
+	t.Run("value not matching its key", func(t *testing.T) {
+		t.Parallel()
+
+		invalid := []types.OffChainData{values[0], {Key: values[1].Key, Value: []byte("other")}}
+		peer := mocks.NewClient(t)
+		peer.On("ListOffChainDataPage", mock.Anything, common.Hash{}, uint(2)).Return(invalid, nil).Once()
+
+		count, last, err := New(peer, peerURL, mocks.NewDB(t), 2, 0).Import(context.Background(), common.Hash{})
+		require.ErrorContains(t, err, "not matching the key")
+		require.Zero(t, count)
+		require.Equal(t, common.Hash{}, last)
+	})
+
+	t.Run("value too large", func(t *testing.T) {
+		t.Parallel()
+
+		large := []byte("large")
+		invalid := []types.OffChainData{{Key: crypto.Keccak256Hash(large), Value: large}}
+		peer := mocks.NewClient(t)
+		peer.On("ListOffChainDataPage", mock.Anything, common.Hash{}, uint(2)).Return(invalid, nil).Once()
+
+		count, _, err := New(peer, peerURL, mocks.NewDB(t), 2, 4).Import(context.Background(), common.Hash{})
+		require.ErrorContains(t, err, "5 bytes, more than the maximum of 4")
+		require.Zero(t, count)
+	})
+
+	t.Run("keys not increasing", func(t *testing.T) {
+		t.Parallel()
+
+		peer := mocks.NewClient(t)
+		peer.On("ListOffChainDataPage", mock.Anything, values[1].Key, uint(2)).Return(values[:1], nil).Once()
+
+		_, _, err := New(peer, peerURL, mocks.NewDB(t), 2, 0).Import(context.Background(), values[1].Key)
+		require.ErrorContains(t, err, "the peer listed the key")
+	})
+
+	t.Run("resumed after a failure", func(t *testing.T) {
+		t.Parallel()
+
+		peer := mocks.NewClient(t)
+		peer.On("ListOffChainDataPage", mock.Anything, common.Hash{}, uint(2)).Return(values[:2], nil).Once()
+		peer.On("ListOffChainDataPage", mock.Anything, values[1].Key, uint(2)).
+			Return(nil, errors.New("connection refused")).Once()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("StoreOffChainData", mock.Anything, values[:2]).Return(nil).Once()
+		dbMock.On("StoreProvenance", mock.Anything, mock.Anything).Return(nil).Once()
+
+		count, last, err := New(peer, peerURL, dbMock, 2, 0).Import(context.Background(), common.Hash{})
+		require.ErrorContains(t, err, "connection refused")
+		require.Equal(t, uint64(2), count)
+		require.Equal(t, values[1].Key, last)
+	})
+}
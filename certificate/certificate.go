@@ -0,0 +1,186 @@
+package certificate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/client"
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/metrics"
+	"github.com/0xPolygon/cdk-data-availability/reporter"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// logger is the logger of the certificate component
+var logger = log.WithComponent("certificate")
+
+// ErrThresholdNotMet is returned when fewer members than required signed the availability of the data
+var ErrThresholdNotMet = errors.New("not enough members signed the availability of the data")
+
+// Coordinator assembles the availability certificates of the stored values. It requests every
+// committee member to sign the availability of the data of each value, and certifies it once the
+// number of valid signatures reaches the threshold of the committee on L1.
+type Coordinator struct {
+	cfg      config.CertificateConfig
+	db       db.DB
+	etherman etherman.Etherman
+	factory  client.Factory
+	stop     chan struct{}
+}
+
+// New returns a Coordinator of the committee registered on L1
+func New(cfg config.CertificateConfig, db db.DB, em etherman.Etherman, factory client.Factory) *Coordinator {
+	return &Coordinator{
+		cfg:      cfg,
+		db:       db,
+		etherman: em,
+		factory:  factory,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start certifies the pending values every interval until the coordinator is stopped
+func (c *Coordinator) Start(ctx context.Context) {
+	defer reporter.Recover()
+
+	logger.Info("starting to assemble the availability certificates")
+	ticker := time.NewTicker(c.cfg.Interval.Duration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := c.CertifyPending(ctx); err != nil {
+				logger.Errorf("failed to certify the stored values: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+// Stop stops the coordinator
+func (c *Coordinator) Stop() {
+	close(c.stop)
+}
+
+// CertifyPending certifies up to a batch of values without a certificate, and returns how many were
+// certified. It stops on the first failure, as the members are likely still resolving the values.
+func (c *Coordinator) CertifyPending(ctx context.Context) (int, error) {
+	dbCtx, cancel := context.WithTimeout(ctx, c.cfg.Timeout.Duration)
+	keys, err := c.db.GetUncertifiedKeys(dbCtx, c.cfg.BatchSize)
+	cancel()
+	if err != nil {
+		return 0, err
+	}
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	committee, err := c.etherman.GetCurrentDataCommittee()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the committee: %w", err)
+	}
+
+	for i, key := range keys {
+		if err = c.certify(ctx, committee, key); err != nil {
+			logger.WithFields(log.FieldKeyHash, key.Hex()).Warnf("failed to certify the value: %v", err)
+			return i, err
+		}
+	}
+
+	return len(keys), nil
+}
+
+// certify assembles and stores a certificate, recording the result
+func (c *Coordinator) certify(parentCtx context.Context, committee *etherman.DataCommittee, key common.Hash) error {
+	ctx, cancel := context.WithTimeout(parentCtx, c.cfg.Timeout.Duration)
+	defer cancel()
+
+	cert, err := c.assemble(ctx, committee, key)
+	if err == nil {
+		err = c.db.StoreCertificate(ctx, *cert)
+	}
+	metrics.CertificateAssembled(err)
+
+	return err
+}
+
+// assemble requests the availability signature of every member concurrently, and returns the
+// certificate of the valid ones if they reach the threshold
+func (c *Coordinator) assemble(
+	ctx context.Context,
+	committee *etherman.DataCommittee,
+	dataHash common.Hash,
+) (*types.Certificate, error) {
+	var (
+		lock       sync.Mutex
+		wg         sync.WaitGroup
+		signatures []types.ArgBytes
+	)
+	for _, member := range committee.Members {
+		member := member
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			signature, err := c.sign(ctx, member, dataHash)
+			if err != nil {
+				logger.WithFields(log.FieldKeyHash, dataHash.Hex(), log.FieldMemberAddr, member.Addr.Hex(),
+					log.FieldMemberURL, member.URL).Debugf("no availability signature: %v", err)
+				return
+			}
+
+			lock.Lock()
+			signatures = append(signatures, signature)
+			lock.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	if uint64(len(signatures)) < committee.RequiredSignatures {
+		return nil, fmt.Errorf("%w: %d of %d", ErrThresholdNotMet, len(signatures), committee.RequiredSignatures)
+	}
+
+	cert := &types.Certificate{
+		DataHash:      dataHash,
+		CommitteeHash: committee.AddressesHash,
+		Threshold:     committee.RequiredSignatures,
+		Signatures:    signatures,
+		Timestamp:     time.Now().UTC(),
+	}
+
+	return cert, cert.SortSignatures()
+}
+
+// sign requests the availability signature of a member and checks it is signed by the member
+func (c *Coordinator) sign(
+	ctx context.Context,
+	member etherman.DataCommitteeMember,
+	dataHash common.Hash,
+) (types.ArgBytes, error) {
+	signature, err := c.factory.New(member.URL).SignAvailability(ctx, dataHash)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := types.AvailabilitySigner(dataHash, signature)
+	if err != nil {
+		return nil, err
+	}
+	if signer != member.Addr {
+		return nil, fmt.Errorf("signed by %s", signer.Hex())
+	}
+
+	return signature, nil
+}
@@ -0,0 +1,152 @@
+package certificate
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/config"
+	cfgTypes "github.com/0xPolygon/cdk-data-availability/config/types"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig() config.CertificateConfig {
+	return config.CertificateConfig{
+		Coordinator: true,
+		Interval:    cfgTypes.NewDuration(time.Minute),
+		BatchSize:   10,
+		Timeout:     cfgTypes.NewDuration(5 * time.Second),
+	}
+}
+
+// newTestCommittee returns a committee of the given keys, each member answering with its signature
+// unless it is among the failing ones
+func newTestCommittee(
+	t *testing.T,
+	dataHash common.Hash,
+	keys []*ecdsa.PrivateKey,
+	failing map[int]bool,
+) (*etherman.DataCommittee, *mocks.ClientFactory) {
+	t.Helper()
+
+	committee := &etherman.DataCommittee{
+		AddressesHash:      common.HexToHash("0xcafe"),
+		RequiredSignatures: 2,
+	}
+	factory := mocks.NewClientFactory(t)
+	for i, key := range keys {
+		url := "http://member-" + string(rune('a'+i))
+		committee.Members = append(committee.Members, etherman.DataCommitteeMember{
+			Addr: crypto.PubkeyToAddress(key.PublicKey),
+			URL:  url,
+		})
+
+		c := mocks.NewClient(t)
+		if failing[i] {
+			c.On("SignAvailability", mock.Anything, dataHash).Return(nil, errors.New("not available"))
+		} else {
+			signature, err := types.SignAvailability(dataHash, key)
+			require.NoError(t, err)
+			c.On("SignAvailability", mock.Anything, dataHash).Return(signature, nil)
+		}
+		factory.On("New", url).Return(c)
+	}
+
+	return committee, factory
+}
+
+func generateKeys(t *testing.T, count int) []*ecdsa.PrivateKey {
+	t.Helper()
+
+	keys := make([]*ecdsa.PrivateKey, count)
+	for i := range keys {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		keys[i] = key
+	}
+
+	return keys
+}
+
+func TestCoordinator_CertifyPending(t *testing.T) {
+	t.Parallel()
+
+	dataHash := crypto.Keccak256Hash([]byte("batch data"))
+
+	t.Run("threshold reached", func(t *testing.T) {
+		t.Parallel()
+
+		keys := generateKeys(t, 3)
+		committee, factory := newTestCommittee(t, dataHash, keys, map[int]bool{1: true})
+
+		em := mocks.NewEtherman(t)
+		em.On("GetCurrentDataCommittee").Return(committee, nil)
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("GetUncertifiedKeys", mock.Anything, uint(10)).Return([]common.Hash{dataHash}, nil)
+		dbMock.On("StoreCertificate", mock.Anything, mock.MatchedBy(func(cert types.Certificate) bool {
+			return cert.DataHash == dataHash && cert.CommitteeHash == committee.AddressesHash &&
+				cert.Threshold == 2 && len(cert.Signatures) == 2 &&
+				cert.Verify([]common.Address{committee.Members[0].Addr, committee.Members[2].Addr}, 2) == nil
+		})).Return(nil)
+
+		count, err := New(testConfig(), dbMock, em, factory).CertifyPending(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+	})
+
+	t.Run("threshold not reached", func(t *testing.T) {
+		t.Parallel()
+
+		keys := generateKeys(t, 3)
+		committee, factory := newTestCommittee(t, dataHash, keys, map[int]bool{0: true, 2: true})
+
+		em := mocks.NewEtherman(t)
+		em.On("GetCurrentDataCommittee").Return(committee, nil)
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("GetUncertifiedKeys", mock.Anything, uint(10)).Return([]common.Hash{dataHash}, nil)
+
+		count, err := New(testConfig(), dbMock, em, factory).CertifyPending(context.Background())
+		require.ErrorIs(t, err, ErrThresholdNotMet)
+		require.Equal(t, 0, count)
+	})
+
+	t.Run("signature of another key", func(t *testing.T) {
+		t.Parallel()
+
+		keys := generateKeys(t, 2)
+		committee, factory := newTestCommittee(t, dataHash, keys, nil)
+		// the second member answers with the signature of someone else
+		committee.Members[1].Addr = common.HexToAddress("0x1")
+
+		em := mocks.NewEtherman(t)
+		em.On("GetCurrentDataCommittee").Return(committee, nil)
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("GetUncertifiedKeys", mock.Anything, uint(10)).Return([]common.Hash{dataHash}, nil)
+
+		_, err := New(testConfig(), dbMock, em, factory).CertifyPending(context.Background())
+		require.ErrorIs(t, err, ErrThresholdNotMet)
+	})
+
+	t.Run("nothing to certify", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("GetUncertifiedKeys", mock.Anything, uint(10)).Return([]common.Hash{}, nil)
+
+		count, err := New(testConfig(), dbMock, mocks.NewEtherman(t), mocks.NewClientFactory(t)).
+			CertifyPending(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 0, count)
+	})
+}
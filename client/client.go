@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/0xPolygon/cdk-data-availability/rpc"
@@ -10,6 +11,14 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 )
 
+// ErrChallengeRefused is returned when a member answers a custody challenge with an error, e.g. as it
+// does not hold the data challenged
+var ErrChallengeRefused = errors.New("custody challenge refused")
+
+// ErrMethodNotFound is returned when the member does not implement the method called, e.g. as it runs
+// an older version of the node
+var ErrMethodNotFound = errors.New("method not found")
+
 // Factory interface for the client factory
 type Factory interface {
 	New(url string) Client
@@ -19,8 +28,13 @@ type Factory interface {
 type Client interface {
 	GetStatus(ctx context.Context) (*types.DACStatus, error)
 	GetOffChainData(ctx context.Context, hash common.Hash) ([]byte, error)
+	GetSignedOffChainData(ctx context.Context, hash common.Hash) ([]byte, []byte, error)
 	ListOffChainData(ctx context.Context, hashes []common.Hash) (map[common.Hash][]byte, error)
+	ListOffChainDataPage(ctx context.Context, after common.Hash, limit uint) ([]types.OffChainData, error)
 	SignSequence(ctx context.Context, signedSequence types.SignedSequence) ([]byte, error)
+	AnnounceKeys(ctx context.Context, announcement types.KeyAnnouncement) error
+	SignAvailability(ctx context.Context, dataHash common.Hash) ([]byte, error)
+	ChallengeCustody(ctx context.Context, hash, nonce common.Hash) (common.Hash, error)
 }
 
 // factory is the implementation of the data committee client factory
@@ -106,6 +120,28 @@ func (c *client) GetOffChainData(ctx context.Context, hash common.Hash) ([]byte,
 	return result, nil
 }
 
+// GetSignedOffChainData returns the data of the given hash along with the signature of the member over it
+func (c *client) GetSignedOffChainData(ctx context.Context, hash common.Hash) ([]byte, []byte, error) {
+	response, err := rpc.JSONRPCCallWithContext(ctx, c.url, "sync_getSignedOffChainData", hash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if response.Error != nil {
+		if response.Error.Code == rpc.NotFoundErrorCode {
+			return nil, nil, fmt.Errorf("%w: %v", ErrMethodNotFound, response.Error.Message)
+		}
+		return nil, nil, fmt.Errorf("%v %v", response.Error.Code, response.Error.Message)
+	}
+
+	var result types.SignedOffChainData
+	if err = json.Unmarshal(response.Result, &result); err != nil {
+		return nil, nil, err
+	}
+
+	return result.Value, result.Signature, nil
+}
+
 // ListOffChainData returns data based on the given hashes
 func (c *client) ListOffChainData(ctx context.Context, hashes []common.Hash) (map[common.Hash][]byte, error) {
 	response, err := rpc.JSONRPCCallWithContext(ctx, c.url, "sync_listOffChainData", hashes)
@@ -129,3 +165,90 @@ func (c *client) ListOffChainData(ctx context.Context, hashes []common.Hash) (ma
 
 	return preparedResult, nil
 }
+
+// ListOffChainDataPage returns up to limit stored values whose key follows the given key, ordered by key.
+// The values should be checked against their keys after using this method!
+func (c *client) ListOffChainDataPage(
+	ctx context.Context,
+	after common.Hash,
+	limit uint,
+) ([]types.OffChainData, error) {
+	response, err := rpc.JSONRPCCallWithContext(ctx, c.url, "sync_listOffChainDataPage", after, types.ArgUint64(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Error != nil {
+		return nil, fmt.Errorf("%v %v", response.Error.Code, response.Error.Message)
+	}
+
+	var entries []types.OffChainDataEntry
+	if err = json.Unmarshal(response.Result, &entries); err != nil {
+		return nil, err
+	}
+
+	list := make([]types.OffChainData, len(entries))
+	for i, entry := range entries {
+		list[i] = types.OffChainData{
+			Key:      entry.Key,
+			Value:    entry.Value,
+			BatchNum: uint64(entry.BatchNum),
+		}
+	}
+
+	return list, nil
+}
+
+// AnnounceKeys announces the keys of the values newly stored by this member
+func (c *client) AnnounceKeys(ctx context.Context, announcement types.KeyAnnouncement) error {
+	response, err := rpc.JSONRPCCallWithContext(ctx, c.url, "sync_announceKeys", announcement)
+	if err != nil {
+		return err
+	}
+
+	if response.Error != nil {
+		return fmt.Errorf("%v %v", response.Error.Code, response.Error.Message)
+	}
+
+	return nil
+}
+
+// SignAvailability requests the signature of the member attesting it holds the data of the given hash.
+// The signature should be validated after using this method!
+func (c *client) SignAvailability(ctx context.Context, dataHash common.Hash) ([]byte, error) {
+	response, err := rpc.JSONRPCCallWithContext(ctx, c.url, "dacert_signAvailability", dataHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Error != nil {
+		return nil, fmt.Errorf("%v %v", response.Error.Code, response.Error.Message)
+	}
+
+	var result types.ArgBytes
+	if err = json.Unmarshal(response.Result, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ChallengeCustody challenges the member to prove it holds the value of the given hash, returning its
+// answer, keccak256(value || nonce). The answer should be checked after using this method!
+func (c *client) ChallengeCustody(ctx context.Context, hash, nonce common.Hash) (common.Hash, error) {
+	response, err := rpc.JSONRPCCallWithContext(ctx, c.url, "sync_challengeCustody", hash, nonce)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	if response.Error != nil {
+		return common.Hash{}, fmt.Errorf("%w: %v %v", ErrChallengeRefused, response.Error.Code, response.Error.Message)
+	}
+
+	var result types.ArgHash
+	if err = json.Unmarshal(response.Result, &result); err != nil {
+		return common.Hash{}, err
+	}
+
+	return result.Hash(), nil
+}
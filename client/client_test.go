@@ -318,3 +318,285 @@ func TestClient_ListOffChainData(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_ListOffChainDataPage(t *testing.T) {
+	t.Parallel()
+
+	after := common.HexToHash("0x1")
+
+	tests := []struct {
+		name   string
+		result string
+		data   []types.OffChainData
+		err    error
+	}{
+		{
+			name: "successfully got a page",
+			result: fmt.Sprintf(`{"result":[{"key":"%s","value":"0x0102","batchNum":"0x7"}]}`,
+				common.HexToHash("0x2").Hex()),
+			data: []types.OffChainData{{Key: common.HexToHash("0x2"), Value: []byte{1, 2}, BatchNum: 7}},
+		},
+		{
+			name:   "last page",
+			result: `{"result":[]}`,
+			data:   []types.OffChainData{},
+		},
+		{
+			name:   "error returned by server",
+			result: `{"error":{"code":123,"message":"test error"}}`,
+			err:    errors.New("123 test error"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var res rpc.Request
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&res))
+				require.Equal(t, "sync_listOffChainDataPage", res.Method)
+				require.JSONEq(t, fmt.Sprintf(`["%s","0x64"]`, after.Hex()), string(res.Params))
+
+				_, err := fmt.Fprint(w, tt.result)
+				require.NoError(t, err)
+			}))
+			defer svr.Close()
+
+			c := &client{url: svr.URL}
+
+			got, err := c.ListOffChainDataPage(context.Background(), after, 100)
+			if tt.err != nil {
+				require.EqualError(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.data, got)
+			}
+		})
+	}
+}
+
+func TestClient_AnnounceKeys(t *testing.T) {
+	t.Parallel()
+
+	announcement := types.KeyAnnouncement{
+		Keys:      []common.Hash{common.HexToHash("0x1")},
+		Signature: []byte("signature"),
+	}
+
+	tests := []struct {
+		name   string
+		result string
+		err    error
+	}{
+		{
+			name:   "keys announced",
+			result: `{"result":null}`,
+		},
+		{
+			name:   "error returned by server",
+			result: `{"error":{"code":123,"message":"test error"}}`,
+			err:    errors.New("123 test error"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var res rpc.Request
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&res))
+				require.Equal(t, "sync_announceKeys", res.Method)
+
+				var params []types.KeyAnnouncement
+				require.NoError(t, json.Unmarshal(res.Params, &params))
+				require.Equal(t, announcement, params[0])
+
+				_, err := fmt.Fprint(w, tt.result)
+				require.NoError(t, err)
+			}))
+			defer srv.Close()
+
+			err := New(srv.URL).AnnounceKeys(context.Background(), announcement)
+			if tt.err != nil {
+				require.EqualError(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestClient_SignAvailability(t *testing.T) {
+	t.Parallel()
+
+	dataHash := common.HexToHash("0x1")
+
+	tests := []struct {
+		name   string
+		result string
+		sig    []byte
+		err    error
+	}{
+		{
+			name:   "availability signed",
+			result: `{"result":"0x0102"}`,
+			sig:    []byte{1, 2},
+		},
+		{
+			name:   "error returned by server",
+			result: `{"error":{"code":123,"message":"test error"}}`,
+			err:    errors.New("123 test error"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var res rpc.Request
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&res))
+				require.Equal(t, "dacert_signAvailability", res.Method)
+
+				var params []common.Hash
+				require.NoError(t, json.Unmarshal(res.Params, &params))
+				require.Equal(t, dataHash, params[0])
+
+				_, err := fmt.Fprint(w, tt.result)
+				require.NoError(t, err)
+			}))
+			defer srv.Close()
+
+			sig, err := New(srv.URL).SignAvailability(context.Background(), dataHash)
+			if tt.err != nil {
+				require.EqualError(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.sig, sig)
+			}
+		})
+	}
+}
+
+func TestClient_ChallengeCustody(t *testing.T) {
+	t.Parallel()
+
+	hash, nonce := common.HexToHash("0x1"), common.HexToHash("0x2")
+
+	tests := []struct {
+		name   string
+		result string
+		proof  common.Hash
+		err    error
+	}{
+		{
+			name:   "challenge answered",
+			result: fmt.Sprintf(`{"result":"%s"}`, common.HexToHash("0x3").Hex()),
+			proof:  common.HexToHash("0x3"),
+		},
+		{
+			name:   "challenge refused",
+			result: `{"error":{"code":123,"message":"the data is not available"}}`,
+			err:    errors.New("custody challenge refused: 123 the data is not available"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var res rpc.Request
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&res))
+				require.Equal(t, "sync_challengeCustody", res.Method)
+
+				var params []common.Hash
+				require.NoError(t, json.Unmarshal(res.Params, &params))
+				require.Equal(t, []common.Hash{hash, nonce}, params)
+
+				_, err := fmt.Fprint(w, tt.result)
+				require.NoError(t, err)
+			}))
+			defer srv.Close()
+
+			proof, err := New(srv.URL).ChallengeCustody(context.Background(), hash, nonce)
+			if tt.err != nil {
+				require.EqualError(t, err, tt.err.Error())
+				require.ErrorIs(t, err, ErrChallengeRefused)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.proof, proof)
+			}
+		})
+	}
+}
+
+func TestClient_GetSignedOffChainData(t *testing.T) {
+	t.Parallel()
+
+	hash := common.HexToHash("0x1")
+
+	tests := []struct {
+		name      string
+		result    string
+		value     []byte
+		signature []byte
+		err       error
+		notFound  bool
+	}{
+		{
+			name:      "signed data returned",
+			result:    `{"result":{"value":"0x0102","signature":"0x0304"}}`,
+			value:     []byte{1, 2},
+			signature: []byte{3, 4},
+		},
+		{
+			name:   "error returned",
+			result: `{"error":{"code":-32000,"message":"failed to get the requested data"}}`,
+			err:    errors.New("-32000 failed to get the requested data"),
+		},
+		{
+			name:     "method not implemented by the member",
+			result:   `{"error":{"code":-32601,"message":"the method sync_getSignedOffChainData does not exist"}}`,
+			err:      errors.New("method not found: the method sync_getSignedOffChainData does not exist"),
+			notFound: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var res rpc.Request
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&res))
+				require.Equal(t, "sync_getSignedOffChainData", res.Method)
+
+				var params []common.Hash
+				require.NoError(t, json.Unmarshal(res.Params, &params))
+				require.Equal(t, []common.Hash{hash}, params)
+
+				_, err := fmt.Fprint(w, tt.result)
+				require.NoError(t, err)
+			}))
+			defer srv.Close()
+
+			value, signature, err := New(srv.URL).GetSignedOffChainData(context.Background(), hash)
+			if tt.err != nil {
+				require.EqualError(t, err, tt.err.Error())
+				require.Equal(t, tt.notFound, errors.Is(err, ErrMethodNotFound))
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.value, value)
+				require.Equal(t, tt.signature, signature)
+			}
+		})
+	}
+}
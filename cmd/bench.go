@@ -0,0 +1,169 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/0xPolygon/cdk-data-availability/bench"
+	"github.com/0xPolygon/cdk-data-availability/client"
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/proxy"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+)
+
+// benchKeys is the number of keys listed from the node to request during the RPC benchmark
+const benchKeys = 100
+
+var (
+	benchValuesFlag = cli.IntFlag{
+		Name:     "values",
+		Usage:    "Number of synthetic values stored and read",
+		Value:    10000,
+		Required: false,
+	}
+	benchSizeFlag = cli.IntFlag{
+		Name:     "size",
+		Usage:    "Size of the synthetic values in bytes",
+		Value:    32 * 1024, //nolint:gomnd
+		Required: false,
+	}
+	benchPageSizeFlag = cli.IntFlag{
+		Name:     "page-size",
+		Usage:    "Number of values stored at a time",
+		Value:    100,
+		Required: false,
+	}
+	benchConcurrencyFlag = cli.IntFlag{
+		Name:     "concurrency",
+		Usage:    "Number of concurrent workers",
+		Value:    8,
+		Required: false,
+	}
+	benchNodeFlag = cli.StringFlag{
+		Name:     "node",
+		Usage:    "`URL` of a running node whose RPC latency is measured",
+		Required: false,
+	}
+	benchRequestsFlag = cli.IntFlag{
+		Name:     "requests",
+		Usage:    "Number of requests sent to the node",
+		Value:    1000,
+		Required: false,
+	}
+	skipStorageFlag = cli.BoolFlag{
+		Name:     "skip-storage",
+		Usage:    "Only measure the RPC latency of the node",
+		Required: false,
+	}
+)
+
+// runBenchmark measures the throughput of the local storage with synthetic values, and the RPC latency
+// of a running node, to size the hardware of a member
+func runBenchmark(cliCtx *cli.Context) error {
+	c, err := config.Load(cliCtx)
+	if err != nil {
+		return err
+	}
+	if err = c.Validate(); err != nil {
+		return err
+	}
+	setupLog(c.Log)
+
+	for _, flag := range []cli.IntFlag{
+		benchValuesFlag, benchSizeFlag, benchPageSizeFlag, benchConcurrencyFlag, benchRequestsFlag,
+	} {
+		if cliCtx.Int(flag.Name) <= 0 {
+			return fmt.Errorf("--%s must be greater than zero", flag.Name)
+		}
+	}
+
+	node := cliCtx.String(benchNodeFlag.Name)
+	skipStorage := cliCtx.Bool(skipStorageFlag.Name)
+	if skipStorage && node == "" {
+		return fmt.Errorf("--%s requires --%s", skipStorageFlag.Name, benchNodeFlag.Name)
+	}
+
+	concurrency := cliCtx.Int(benchConcurrencyFlag.Name)
+	var summaries []bench.Summary
+
+	if !skipStorage {
+		pg, err := db.InitContext(cliCtx.Context, c.DB)
+		if err != nil {
+			return err
+		}
+		defer pg.Close()
+
+		if err = db.Migrate(pg, !c.DB.DisableAutoMigrate); err != nil {
+			return err
+		}
+		storage := db.New(pg)
+
+		values, err := bench.Values(cliCtx.Int(benchValuesFlag.Name), cliCtx.Int(benchSizeFlag.Name))
+		if err != nil {
+			return err
+		}
+
+		log.Infof("storing and reading %d values of %d bytes", len(values), cliCtx.Int(benchSizeFlag.Name))
+		summaries = append(summaries,
+			bench.Storage(cliCtx.Context, storage, values, cliCtx.Int(benchPageSizeFlag.Name), concurrency)...)
+		// the same reads through prepared statements, to compare with the ones parsed on every execution
+		summaries = append(summaries,
+			bench.Reads(cliCtx.Context, "get prep", db.NewPrepared(pg), values, concurrency))
+
+		keys := make([]common.Hash, len(values))
+		for i, value := range values {
+			keys[i] = value.Key
+		}
+		if err = storage.DeleteOffChainData(cliCtx.Context, keys); err != nil {
+			return fmt.Errorf("failed to delete the synthetic values: %w", err)
+		}
+	}
+
+	if node != "" {
+		if err = proxy.Init(c.Proxy); err != nil {
+			return err
+		}
+
+		nodeClient := client.New(node)
+		page, err := nodeClient.ListOffChainDataPage(cliCtx.Context, common.Hash{}, benchKeys)
+		if err != nil {
+			return fmt.Errorf("failed to list the keys of %s: %w", node, err)
+		}
+		if len(page) == 0 {
+			return fmt.Errorf("%s stores no value to request", node)
+		}
+
+		keys := make([]common.Hash, len(page))
+		for i, data := range page {
+			keys[i] = data.Key
+		}
+
+		log.Infof("requesting %d values to %s", cliCtx.Int(benchRequestsFlag.Name), node)
+		summaries = append(summaries,
+			bench.RPC(cliCtx.Context, nodeClient, keys, cliCtx.Int(benchRequestsFlag.Name), concurrency))
+	}
+
+	if cliCtx.Bool(jsonFlag.Name) {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(summaries)
+	}
+
+	printSummaries(os.Stdout, summaries)
+	return nil
+}
+
+// printSummaries prints the benchmark results for the operators
+func printSummaries(w io.Writer, summaries []bench.Summary) {
+	fmt.Fprintf(w, "%-8s %8s %7s %10s %10s %10s %10s %10s\n",
+		"op", "count", "errors", "ops/s", "p50", "p90", "p99", "max")
+	for _, s := range summaries {
+		fmt.Fprintf(w, "%-8s %8d %7d %10.1f %10s %10s %10s %10s\n",
+			s.Name, s.Count, s.Errors, s.Throughput, s.P50, s.P90, s.P99, s.Max)
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/cdk-data-availability/bootstrap"
+	"github.com/0xPolygon/cdk-data-availability/client"
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/proxy"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	peerFlag = cli.StringFlag{
+		Name:     "peer",
+		Usage:    "RPC `URL` of the node to import the values from",
+		Required: true,
+	}
+	afterKeyFlag = cli.StringFlag{
+		Name:     "after",
+		Usage:    "Import the values whose key follows `KEY`, to resume an interrupted import",
+		Required: false,
+	}
+	pageSizeFlag = cli.UintFlag{
+		Name:     "page-size",
+		Usage:    "Number of values requested at a time, at most 100",
+		Value:    100,
+		Required: false,
+	}
+)
+
+// bootstrapFromPeer imports the values stored by a peer node into the database
+func bootstrapFromPeer(cliCtx *cli.Context) error {
+	c, err := config.Load(cliCtx)
+	if err != nil {
+		return err
+	}
+	if err = c.Validate(); err != nil {
+		return err
+	}
+	setupLog(c.Log)
+
+	if err = proxy.Init(c.Proxy); err != nil {
+		return err
+	}
+
+	var after common.Hash
+	if key := cliCtx.String(afterKeyFlag.Name); key != "" {
+		b, err := hexutil.Decode(key)
+		if err != nil || len(b) != common.HashLength {
+			return fmt.Errorf("invalid key %q", key)
+		}
+		after = common.BytesToHash(b)
+	}
+
+	pg, err := db.InitContext(cliCtx.Context, c.DB)
+	if err != nil {
+		return err
+	}
+	defer pg.Close()
+
+	if err = db.Migrate(pg, !c.DB.DisableAutoMigrate); err != nil {
+		return err
+	}
+
+	peer := cliCtx.String(peerFlag.Name)
+	storage := db.NewFromConfig(pg, c.DB, c.Stream.Enabled())
+	importer := bootstrap.New(client.New(peer), peer, storage, cliCtx.Uint(pageSizeFlag.Name), c.Limits.MaxValueSize)
+
+	log.Infof("importing the values of %s", peer)
+	count, last, err := importer.Import(cliCtx.Context, after)
+	if err != nil {
+		return fmt.Errorf("import stopped after %d values, resume it with --%s %s: %w",
+			count, afterKeyFlag.Name, last.Hex(), err)
+	}
+
+	log.Infof("imported %d values from %s", count, peer)
+	return nil
+}
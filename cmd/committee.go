@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/0xPolygon/cdk-data-availability/client"
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/discovery"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/health"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/proxy"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/urfave/cli/v2"
+)
+
+// committeeMember is a member of the committee as printed by the committee command
+type committeeMember struct {
+	Addr      common.Address `json:"addr"`
+	URL       string         `json:"url"`
+	Self      bool           `json:"self"`
+	Reachable bool           `json:"reachable"`
+	Error     string         `json:"error,omitempty"`
+}
+
+// committeeReport is the committee read from L1 as printed by the committee command
+type committeeReport struct {
+	Address            common.Address    `json:"address"`
+	RequiredSignatures uint64            `json:"requiredSignatures"`
+	AddressesHash      common.Hash       `json:"addressesHash"`
+	Members            []committeeMember `json:"members"`
+}
+
+// showCommittee prints the current committee of the L1 contract, the entry of the local key, and the
+// members whose endpoint does not answer
+func showCommittee(cliCtx *cli.Context) error {
+	c, err := config.Load(cliCtx)
+	if err != nil {
+		return err
+	}
+	if err = c.Validate(); err != nil {
+		return err
+	}
+	setupLog(c.Log)
+
+	if err = proxy.Init(c.Proxy); err != nil {
+		return err
+	}
+
+	// the command is also useful away from the node, without its key
+	var self common.Address
+	if pk, err := config.NewKeyFromKeystore(c.PrivateKey); err != nil {
+		log.Warnf("the local key is not highlighted, failed to load it: %v", err)
+	} else {
+		self = crypto.PubkeyToAddress(pk.PublicKey)
+	}
+
+	etm, err := etherman.New(cliCtx.Context, c.L1)
+	if err != nil {
+		return err
+	}
+
+	committee, err := etm.GetCurrentDataCommittee()
+	if err != nil {
+		return err
+	}
+
+	clientFactory := discovery.NewFactory(discovery.NewResolver(c.Discovery, etm), client.NewFactory())
+	checks := make([]health.Check, len(committee.Members))
+	for i, member := range committee.Members {
+		member := member
+		checks[i] = health.Check{
+			Name: member.Addr.Hex(),
+			Run: func(ctx context.Context) error {
+				_, err := clientFactory.New(member.URL).GetStatus(ctx)
+				return err
+			},
+		}
+	}
+	statuses := health.NewChecker(cliCtx.Duration(memberTimeoutFlag.Name), checks...).Run(cliCtx.Context)
+
+	report := committeeReport{
+		Address:            common.HexToAddress(c.L1.DataCommitteeAddress),
+		RequiredSignatures: committee.RequiredSignatures,
+		AddressesHash:      committee.AddressesHash,
+		Members:            make([]committeeMember, len(committee.Members)),
+	}
+	for i, member := range committee.Members {
+		report.Members[i] = committeeMember{
+			Addr:      member.Addr,
+			URL:       member.URL,
+			Self:      self != common.Address{} && member.Addr == self,
+			Reachable: statuses.Components[i].Status == health.StatusReady,
+			Error:     statuses.Components[i].Error,
+		}
+	}
+
+	if cliCtx.Bool(jsonFlag.Name) {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+
+	printCommittee(os.Stdout, report)
+	return nil
+}
+
+// printCommittee prints a committee for the operators
+func printCommittee(w io.Writer, r committeeReport) {
+	fmt.Fprintf(w, "committee %s, %d of %d signatures required\n",
+		r.Address.Hex(), r.RequiredSignatures, len(r.Members))
+
+	for _, member := range r.Members {
+		marker := " "
+		if member.Self {
+			marker = "*"
+		}
+		status := "reachable"
+		if !member.Reachable {
+			status = "UNREACHABLE: " + member.Error
+		}
+		fmt.Fprintf(w, "%s %s  %s  %s\n", marker, member.Addr.Hex(), member.URL, status)
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/health"
+	"github.com/0xPolygon/cdk-data-availability/proxy"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+)
+
+// componentConfig is the name of the check of the configuration
+const componentConfig = "config"
+
+var checkTimeoutFlag = cli.DurationFlag{
+	Name:     "timeout",
+	Usage:    "Timeout of each check",
+	Value:    10 * time.Second, //nolint:gomnd
+	Required: false,
+}
+
+// runDoctor checks, before the node starts, that its configuration is valid and that its dependencies
+// are reachable, and fails when a check does not pass
+func runDoctor(cliCtx *cli.Context) error {
+	report, err := preflight(cliCtx)
+	if err != nil {
+		report = health.Report{Components: []health.ComponentStatus{{
+			Name:   componentConfig,
+			Status: health.StatusNotReady,
+			Error:  err.Error(),
+		}}}
+	}
+
+	if cliCtx.Bool(jsonFlag.Name) {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		printChecks(os.Stdout, report)
+	}
+
+	failed := 0
+	for _, component := range report.Components {
+		if component.Status != health.StatusReady {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d check(s) failed", failed)
+	}
+
+	return nil
+}
+
+// preflight runs the checks of a valid configuration, an error being returned for an invalid one
+func preflight(cliCtx *cli.Context) (health.Report, error) {
+	c, err := config.Load(cliCtx)
+	if err != nil {
+		return health.Report{}, err
+	}
+	if err = c.Validate(); err != nil {
+		return health.Report{}, err
+	}
+	setupLog(c.Log)
+
+	if err = proxy.Init(c.Proxy); err != nil {
+		return health.Report{}, err
+	}
+
+	checks := []health.Check{{Name: componentConfig, Run: func(context.Context) error { return nil }}}
+
+	pg, err := db.InitContext(cliCtx.Context, c.DB)
+	if err != nil {
+		checks = append(checks, failedCheck(health.ComponentDB, err))
+	} else {
+		defer pg.Close()
+		checks = append(checks, health.DB(pg))
+	}
+
+	// the chain ID is checked by its own check rather than when connecting
+	l1 := c.L1
+	l1.ChainID = 0
+	etm, err := etherman.New(cliCtx.Context, l1)
+	if err != nil {
+		checks = append(checks,
+			failedCheck(health.ComponentL1, err),
+			failedCheck(health.ComponentContracts, fmt.Errorf("L1 node not reachable")))
+	} else {
+		checks = append(checks,
+			health.L1(etm, c.L1.ChainID),
+			health.Contracts(etm,
+				common.HexToAddress(c.L1.PolygonValidiumAddress),
+				common.HexToAddress(c.L1.DataCommitteeAddress)))
+	}
+
+	// a mirror does not sign and holds no key
+	if !c.Mirror.Enabled {
+		pk, err := config.NewKeyFromKeystore(c.PrivateKey)
+		if err != nil {
+			checks = append(checks,
+				failedCheck(health.ComponentSigner, fmt.Errorf("failed to decrypt the keystore: %w", err)))
+		} else {
+			checks = append(checks, health.Signer(pk))
+		}
+	}
+
+	checks = append(checks, health.Port("rpc", c.RPC.Host, c.RPC.Port))
+	if c.Admin.Enabled {
+		checks = append(checks, health.Port("admin", c.Admin.Host, c.Admin.Port))
+	}
+	if c.Metrics.Enabled {
+		checks = append(checks, health.Port("metrics", c.Metrics.Host, c.Metrics.Port))
+	}
+	if c.Debug.Enabled {
+		checks = append(checks, health.Port("debug", c.Debug.Host, c.Debug.Port))
+	}
+	if c.GraphQL.Enabled {
+		checks = append(checks, health.Port("graphql", c.GraphQL.Host, c.GraphQL.Port))
+	}
+
+	return health.NewChecker(cliCtx.Duration(checkTimeoutFlag.Name), checks...).Run(cliCtx.Context), nil
+}
+
+// failedCheck returns a check failing with the given error, for the dependencies that could not be set up
+func failedCheck(name string, err error) health.Check {
+	return health.Check{
+		Name: name,
+		Run:  func(context.Context) error { return err },
+	}
+}
+
+// printChecks prints the result of each check for the operators
+func printChecks(w io.Writer, r health.Report) {
+	for _, component := range r.Components {
+		if component.Status == health.StatusReady {
+			fmt.Fprintf(w, "PASS  %-14s %s\n", component.Name, component.Duration)
+		} else {
+			fmt.Fprintf(w, "FAIL  %-14s %s\n", component.Name, component.Error)
+		}
+	}
+}
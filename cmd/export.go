@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/export"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/proxy"
+	"github.com/0xPolygon/cdk-data-availability/synchronizer"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+)
+
+// exportPageSize is the number of keys read at a time by the export, and of records stored at a time
+// by the import
+const exportPageSize = 1000
+
+var (
+	outputFlag = cli.StringFlag{
+		Name:     "output",
+		Aliases:  []string{"o"},
+		Usage:    "Export `FILE`, compressed with gzip when its name ends with .gz",
+		Required: true,
+	}
+	fromBatchFlag = cli.Uint64Flag{
+		Name:     "from",
+		Usage:    "First batch to export",
+		Value:    1,
+		Required: false,
+	}
+	toBatchFlag = cli.Uint64Flag{
+		Name:     "to",
+		Usage:    "Last batch to export, by default up to the last batch stored",
+		Required: false,
+	}
+	exportFromBlockFlag = cli.Uint64Flag{
+		Name:     "from-block",
+		Usage:    "Export the batches sequenced from this L1 block, instead of --from",
+		Required: false,
+	}
+	exportToBlockFlag = cli.Uint64Flag{
+		Name:     "to-block",
+		Usage:    "Export the batches sequenced up to this L1 block, instead of --to",
+		Required: false,
+	}
+)
+
+// exportTrustedState writes the stored values as the batch data a rollup node recovers its trusted state from
+func exportTrustedState(cliCtx *cli.Context) error {
+	c, err := config.Load(cliCtx)
+	if err != nil {
+		return err
+	}
+	if err = c.Validate(); err != nil {
+		return err
+	}
+	setupLog(c.Log)
+
+	fromBatch, toBatch, err := exportBatches(cliCtx, c)
+	if err != nil {
+		return err
+	}
+
+	pg, err := db.InitContext(cliCtx.Context, c.DB)
+	if err != nil {
+		return err
+	}
+	defer pg.Close()
+
+	file, err := os.Create(cliCtx.String(outputFlag.Name))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var w io.Writer = file
+	var zw *gzip.Writer
+	if strings.HasSuffix(file.Name(), ".gz") {
+		zw = gzip.NewWriter(file)
+		w = zw
+	}
+
+	bw := bufio.NewWriter(w)
+	count, err := export.New(db.New(pg), exportPageSize).Export(cliCtx.Context, bw, export.Header{
+		PolygonValidiumAddress: common.HexToAddress(c.L1.PolygonValidiumAddress),
+		DataCommitteeAddress:   common.HexToAddress(c.L1.DataCommitteeAddress),
+		FromBatch:              types.ArgUint64(fromBatch),
+		ToBatch:                types.ArgUint64(toBatch),
+		CreatedAt:              time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+	if err = bw.Flush(); err != nil {
+		return err
+	}
+	if zw != nil {
+		if err = zw.Close(); err != nil {
+			return err
+		}
+	}
+
+	log.Infof("exported %d values to %s", count, file.Name())
+	return file.Close()
+}
+
+// exportBatches returns the first and last batches to export, read from L1 when a block range is given
+func exportBatches(cliCtx *cli.Context, c *config.Config) (uint64, uint64, error) {
+	fromBatch, toBatch := cliCtx.Uint64(fromBatchFlag.Name), cliCtx.Uint64(toBatchFlag.Name)
+	if !cliCtx.IsSet(exportFromBlockFlag.Name) && !cliCtx.IsSet(exportToBlockFlag.Name) {
+		return fromBatch, toBatch, nil
+	}
+	if cliCtx.IsSet(fromBatchFlag.Name) || cliCtx.IsSet(toBatchFlag.Name) {
+		return 0, 0, fmt.Errorf("the batches and the blocks to export cannot be both given")
+	}
+
+	if err := proxy.Init(c.Proxy); err != nil {
+		return 0, 0, err
+	}
+
+	etm, err := etherman.New(cliCtx.Context, c.L1)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	blockRange := cliCtx.Uint64(blockRangeFlag.Name)
+	if blockRange == 0 {
+		return 0, 0, fmt.Errorf("--%s must be greater than zero", blockRangeFlag.Name)
+	}
+
+	if fromBlock := cliCtx.Uint64(exportFromBlockFlag.Name); fromBlock > 0 {
+		last, err := synchronizer.LastBatchSequencedBefore(cliCtx.Context, etm, fromBlock, blockRange)
+		if err != nil {
+			return 0, 0, err
+		}
+		fromBatch = last + 1
+	}
+
+	if cliCtx.IsSet(exportToBlockFlag.Name) {
+		toBlock := cliCtx.Uint64(exportToBlockFlag.Name)
+		toBatch, err = synchronizer.LastBatchSequencedBefore(cliCtx.Context, etm, toBlock+1, blockRange)
+		if err != nil {
+			return 0, 0, err
+		}
+		if toBatch < fromBatch {
+			return 0, 0, fmt.Errorf("no batch sequenced up to the block %d", toBlock)
+		}
+	}
+
+	log.Infof("exporting the batches %d to %d", fromBatch, toBatch)
+	return fromBatch, toBatch, nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/export"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+)
+
+var inputFlag = cli.StringFlag{
+	Name:     "input",
+	Aliases:  []string{"i"},
+	Usage:    "Export `FILE` to import, compressed with gzip or not",
+	Required: true,
+}
+
+// importTrustedState loads an export into the database, checking every value against its hash
+func importTrustedState(cliCtx *cli.Context) error {
+	c, err := config.Load(cliCtx)
+	if err != nil {
+		return err
+	}
+	if err = c.Validate(); err != nil {
+		return err
+	}
+	setupLog(c.Log)
+
+	file, err := os.Open(cliCtx.String(inputFlag.Name))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	pg, err := db.InitContext(cliCtx.Context, c.DB)
+	if err != nil {
+		return err
+	}
+	defer pg.Close()
+
+	if err = db.Migrate(pg, !c.DB.DisableAutoMigrate); err != nil {
+		return err
+	}
+
+	importer := export.NewImporter(db.NewFromConfig(pg, c.DB, c.Stream.Enabled()), exportPageSize)
+	header, count, err := importer.Import(cliCtx.Context, file, cliCtx.String(inputFlag.Name),
+		common.HexToAddress(c.L1.PolygonValidiumAddress))
+	if err != nil {
+		return fmt.Errorf("import stopped after %d values: %w", count, err)
+	}
+
+	log.Infof("imported %d values of the batches %d to %d, exported at %s", count,
+		header.FromBatch, header.ToBatch, header.CreatedAt)
+	return nil
+}
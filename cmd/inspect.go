@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/client"
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/discovery"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/inspect"
+	"github.com/0xPolygon/cdk-data-availability/proxy"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	resolveFlag = cli.BoolFlag{
+		Name:     "resolve",
+		Usage:    "Query each committee member for the value",
+		Required: false,
+	}
+	memberTimeoutFlag = cli.DurationFlag{
+		Name:     "timeout",
+		Usage:    "Timeout of the query of each member",
+		Value:    10 * time.Second, //nolint:gomnd
+		Required: false,
+	}
+)
+
+// inspectKey prints what is known of the value of a key, locally, on L1 and with --resolve by the committee
+func inspectKey(cliCtx *cli.Context) error {
+	b, err := hexutil.Decode(cliCtx.Args().First())
+	if err != nil || len(b) != common.HashLength {
+		return fmt.Errorf("invalid key %q", cliCtx.Args().First())
+	}
+	key := common.BytesToHash(b)
+
+	c, err := config.Load(cliCtx)
+	if err != nil {
+		return err
+	}
+	if err = c.Validate(); err != nil {
+		return err
+	}
+	setupLog(c.Log)
+
+	if err = proxy.Init(c.Proxy); err != nil {
+		return err
+	}
+
+	pg, err := db.InitContext(cliCtx.Context, c.DB)
+	if err != nil {
+		return err
+	}
+	defer pg.Close()
+
+	etm, err := etherman.New(cliCtx.Context, c.L1)
+	if err != nil {
+		return err
+	}
+
+	clientFactory := discovery.NewFactory(discovery.NewResolver(c.Discovery, etm), client.NewFactory())
+	inspector := inspect.New(db.New(pg), etm, clientFactory, c.L1.GenesisBlock, cliCtx.Duration(memberTimeoutFlag.Name))
+	report, err := inspector.Inspect(cliCtx.Context, key, cliCtx.Bool(resolveFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	if cliCtx.Bool(jsonFlag.Name) {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+
+	printInspection(os.Stdout, report)
+	return nil
+}
+
+// printInspection prints the report of a key for the operators
+func printInspection(w io.Writer, r *inspect.Report) {
+	fmt.Fprintf(w, "key %s\n", r.Key.Hex())
+	if !r.Stored {
+		fmt.Fprintln(w, "not stored locally")
+	} else {
+		valid := "valid"
+		if !r.Valid {
+			valid = "CORRUPT, not matching the key"
+		}
+		fmt.Fprintf(w, "stored locally, %d bytes, %s\n", r.Size, valid)
+
+		if r.BatchNum == 0 {
+			fmt.Fprintln(w, "not yet matched to a batch")
+		} else {
+			fmt.Fprintf(w, "batch %d\n", r.BatchNum)
+		}
+	}
+
+	if r.Sequence != nil {
+		fmt.Fprintf(w, "sequenced in the L1 block %d by the transaction %s\n",
+			r.Sequence.Block, r.Sequence.TxHash.Hex())
+	} else if r.BatchNum > 0 {
+		fmt.Fprintln(w, "sequence not found on L1")
+	}
+
+	if r.Shards != nil {
+		fmt.Fprintf(w, "sharded %d+%d with the root %s, holding the shards of the member %d of %d\n",
+			r.Shards.DataShards, r.Shards.ParityShards, r.Shards.Root.Hex(), r.Shards.MemberIndex, r.Shards.Members)
+	}
+
+	for _, member := range r.Members {
+		status := "has it"
+		if !member.Has {
+			status = "missing: " + member.Error
+		}
+		fmt.Fprintf(w, "  %s  %s  %s\n", member.Addr.Hex(), member.URL, status)
+	}
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+)
+
+var (
+	keystoreOutputFlag = cli.StringFlag{
+		Name:     "output",
+		Aliases:  []string{"o"},
+		Usage:    "Keystore `FILE` written, not overwritten if it exists",
+		Required: true,
+	}
+	keystoreFileFlag = cli.StringFlag{
+		Name:     "keystore",
+		Usage:    "Keystore `FILE`",
+		Required: true,
+	}
+	passwordFlag = cli.StringFlag{
+		Name:     "password",
+		Usage:    "Password of the keystore, or a reference to it, e.g. env://KEYSTORE_PASSWORD, prompted by default",
+		Required: false,
+	}
+	newPasswordFlag = cli.StringFlag{
+		Name:     "new-password",
+		Usage:    "New password of the keystore, or a reference to it, prompted by default",
+		Required: false,
+	}
+)
+
+// keystoreCommand is the keystore command, with a subcommand per operation
+var keystoreCommand = &cli.Command{
+	Name:    "keystore",
+	Aliases: []string{},
+	Usage:   "Manage the keystore of the committee key",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "address",
+			Usage:  "Print the address of the key of a keystore, checking its password",
+			Action: keystoreAddress,
+			Flags:  []cli.Flag{&keystoreFileFlag, &passwordFlag},
+		},
+		{
+			Name:   "passwd",
+			Usage:  "Encrypt a keystore again with a new password",
+			Action: keystorePasswd,
+			Flags:  []cli.Flag{&keystoreFileFlag, &passwordFlag, &newPasswordFlag},
+		},
+	},
+}
+
+// generateKey generates a committee key into a new keystore, and prints its address to register it
+func generateKey(cliCtx *cli.Context) error {
+	path := cliCtx.String(keystoreOutputFlag.Name)
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists", path)
+	}
+
+	password, err := readPassword(cliCtx, passwordFlag.Name, "Password of the keystore", true)
+	if err != nil {
+		return err
+	}
+
+	pk, err := crypto.GenerateKey()
+	if err != nil {
+		return err
+	}
+
+	encrypted, err := config.NewKeystore(pk, password)
+	if err != nil {
+		return err
+	}
+
+	// the keystore must not be overwritten, even if it appeared while the password was typed
+	file, err := os.OpenFile(filepath.Clean(path), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600) //nolint:gomnd
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err = file.Write(encrypted); err != nil {
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+
+	fmt.Println(crypto.PubkeyToAddress(pk.PublicKey).Hex())
+	return nil
+}
+
+// keystoreAddress prints the address of the key of a keystore
+func keystoreAddress(cliCtx *cli.Context) error {
+	encrypted, err := os.ReadFile(filepath.Clean(cliCtx.String(keystoreFileFlag.Name)))
+	if err != nil {
+		return err
+	}
+
+	password, err := readPassword(cliCtx, passwordFlag.Name, "Password of the keystore", false)
+	if err != nil {
+		return err
+	}
+
+	key, err := keystore.DecryptKey(encrypted, password)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(key.Address.Hex())
+	return nil
+}
+
+// keystorePasswd encrypts a keystore again with a new password, replacing the file atomically
+func keystorePasswd(cliCtx *cli.Context) error {
+	path := filepath.Clean(cliCtx.String(keystoreFileFlag.Name))
+	encrypted, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	password, err := readPassword(cliCtx, passwordFlag.Name, "Current password of the keystore", false)
+	if err != nil {
+		return err
+	}
+	newPassword, err := readPassword(cliCtx, newPasswordFlag.Name, "New password of the keystore", true)
+	if err != nil {
+		return err
+	}
+
+	reencrypted, addr, err := config.ReencryptKeystore(encrypted, password, newPassword)
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err = os.WriteFile(tmp, reencrypted, 0600); err != nil { //nolint:gomnd
+		return err
+	}
+	if err = os.Rename(tmp, path); err != nil {
+		return err
+	}
+
+	fmt.Println(addr.Hex())
+	return nil
+}
+
+// readPassword returns the password of the given flag, resolving a reference to a secret, or prompts for it
+// on the terminal, twice for a new password
+func readPassword(cliCtx *cli.Context, flag, prompt string, confirm bool) (string, error) {
+	if cliCtx.IsSet(flag) {
+		return config.ResolveSecret(cliCtx.Context, cliCtx.String(flag))
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf("--%s is required when not run in a terminal", flag)
+	}
+
+	fmt.Fprintf(os.Stderr, "%s: ", prompt)
+	password, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+
+	if confirm {
+		fmt.Fprintf(os.Stderr, "%s again: ", prompt)
+		again, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", err
+		}
+		if string(again) != string(password) {
+			return "", errors.New("the passwords do not match")
+		}
+	}
+
+	return string(password), nil
+}
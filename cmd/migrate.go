@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/jmoiron/sqlx"
+	"github.com/urfave/cli/v2"
+)
+
+var migrateStepsFlag = cli.IntFlag{
+	Name:     "steps",
+	Usage:    "Number of migrations rolled back",
+	Value:    1,
+	Required: false,
+}
+
+// migrateCommand is the migrate command, with a subcommand per operation
+var migrateCommand = &cli.Command{
+	Name:    "migrate",
+	Aliases: []string{},
+	Usage:   "Inspect, apply or roll back the migrations of the database",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "status",
+			Usage:  "Print the migrations applied and pending",
+			Action: migrationsStatus,
+			Flags:  append([]cli.Flag{&jsonFlag}, configFlags...),
+		},
+		{
+			Name:   "up",
+			Usage:  "Apply the pending migrations",
+			Action: migrateUp,
+			Flags:  configFlags,
+		},
+		{
+			Name:   "down",
+			Usage:  "Roll back the last migrations applied",
+			Action: migrateDown,
+			Flags:  append([]cli.Flag{&migrateStepsFlag}, configFlags...),
+		},
+	},
+}
+
+// migrationsStatus prints whether each migration is applied
+func migrationsStatus(cliCtx *cli.Context) error {
+	return withMigrationsDB(cliCtx, func(pg *sqlx.DB) error {
+		status, err := db.MigrationsStatus(pg)
+		if err != nil {
+			return err
+		}
+
+		if cliCtx.Bool(jsonFlag.Name) {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(status)
+		}
+
+		for _, migration := range status {
+			applied := "pending"
+			if migration.AppliedAt != nil {
+				applied = "applied " + migration.AppliedAt.UTC().Format(time.RFC3339)
+			}
+			fmt.Printf("%-12s %s\n", migration.ID, applied)
+		}
+		return nil
+	})
+}
+
+// migrateUp applies the pending migrations
+func migrateUp(cliCtx *cli.Context) error {
+	return withMigrationsDB(cliCtx, db.RunMigrationsUp)
+}
+
+// migrateDown rolls back the last migrations applied
+func migrateDown(cliCtx *cli.Context) error {
+	steps := cliCtx.Int(migrateStepsFlag.Name)
+	if steps <= 0 {
+		return fmt.Errorf("--%s must be greater than zero", migrateStepsFlag.Name)
+	}
+
+	return withMigrationsDB(cliCtx, func(pg *sqlx.DB) error {
+		count, err := db.RunMigrationsDown(pg, steps)
+		if err != nil {
+			return err
+		}
+
+		log.Infof("rolled back %d migrations", count)
+		return nil
+	})
+}
+
+// withMigrationsDB runs the given operation on the database of the configuration
+func withMigrationsDB(cliCtx *cli.Context, op func(pg *sqlx.DB) error) error {
+	c, err := config.Load(cliCtx)
+	if err != nil {
+		return err
+	}
+	if err = c.Validate(); err != nil {
+		return err
+	}
+	setupLog(c.Log)
+
+	pg, err := db.InitContext(cliCtx.Context, c.DB)
+	if err != nil {
+		return err
+	}
+	defer pg.Close()
+
+	return op(pg)
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/proxy"
+	"github.com/0xPolygon/cdk-data-availability/prune"
+	"github.com/urfave/cli/v2"
+)
+
+// prunePageSize is the number of values deleted at a time
+const prunePageSize = 1000
+
+var (
+	beforeBatchFlag = cli.Uint64Flag{
+		Name:     "before-batch",
+		Usage:    "Prune the values of the batches before `BATCH`",
+		Required: false,
+	}
+	beforeBlockFlag = cli.Uint64Flag{
+		Name:     "before-block",
+		Usage:    "Prune the values of the batches sequenced before the L1 `BLOCK`",
+		Required: false,
+	}
+	beforeDateFlag = cli.StringFlag{
+		Name:     "before",
+		Usage:    "Prune the values of the batches sequenced before `DATE`, as 2006-01-02 or RFC 3339",
+		Required: false,
+	}
+	verifiedOnlyFlag = cli.BoolFlag{
+		Name:     "verified-only",
+		Usage:    "Prune only the values of the batches verified on L1",
+		Required: false,
+	}
+	dryRunFlag = cli.BoolFlag{
+		Name:     "dry-run",
+		Usage:    "Report the values that would be pruned without deleting them",
+		Required: false,
+	}
+	reasonFlag = cli.StringFlag{
+		Name:     "reason",
+		Usage:    "`REASON` recorded in the tombstones of the values pruned",
+		Value:    prune.DefaultReason,
+		Required: false,
+	}
+	vacuumFlag = cli.BoolFlag{
+		Name:     "vacuum",
+		Usage:    "Rewrite the table of the values once pruned, so the deleted values no longer remain in its files",
+		Required: false,
+	}
+)
+
+// pruneValues deletes the values matching the retention criteria
+func pruneValues(cliCtx *cli.Context) error {
+	c, err := config.Load(cliCtx)
+	if err != nil {
+		return err
+	}
+	if err = c.Validate(); err != nil {
+		return err
+	}
+	setupLog(c.Log)
+
+	criteria := prune.Criteria{
+		BeforeBatch:  cliCtx.Uint64(beforeBatchFlag.Name),
+		BeforeBlock:  cliCtx.Uint64(beforeBlockFlag.Name),
+		VerifiedOnly: cliCtx.Bool(verifiedOnlyFlag.Name),
+	}
+	if date := cliCtx.String(beforeDateFlag.Name); date != "" {
+		if criteria.Before, err = time.Parse(time.RFC3339, date); err != nil {
+			if criteria.Before, err = time.Parse("2006-01-02", date); err != nil {
+				return fmt.Errorf("invalid date %q, use 2006-01-02 or RFC 3339", date)
+			}
+		}
+	}
+
+	blockRange := cliCtx.Uint64(blockRangeFlag.Name)
+	if blockRange == 0 {
+		return fmt.Errorf("--%s must be greater than zero", blockRangeFlag.Name)
+	}
+
+	if err = proxy.Init(c.Proxy); err != nil {
+		return err
+	}
+
+	pg, err := db.InitContext(cliCtx.Context, c.DB)
+	if err != nil {
+		return err
+	}
+	defer pg.Close()
+
+	etm, err := etherman.New(cliCtx.Context, c.L1)
+	if err != nil {
+		return err
+	}
+
+	// the tombstones are signed by the member, a mirror holds no key
+	var pk *ecdsa.PrivateKey
+	if !c.Mirror.Enabled {
+		if pk, err = config.NewKeyFromKeystore(c.PrivateKey); err != nil {
+			return fmt.Errorf("failed to load the key signing the tombstones: %w", err)
+		}
+	}
+
+	storage := db.New(pg)
+	dryRun := cliCtx.Bool(dryRunFlag.Name)
+	result, err := prune.New(storage, etm, pk, blockRange, prunePageSize).
+		Prune(cliCtx.Context, criteria, cliCtx.String(reasonFlag.Name), dryRun)
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		log.Infof("%d values of the batches before %d would be pruned, reclaiming %d bytes of data",
+			result.Values, result.BeforeBatch, result.Size)
+	} else {
+		log.Infof("pruned %d values of the batches before %d at L1 block %d, reclaiming %d bytes of data",
+			result.Values, result.BeforeBatch, result.PrunedAtBlock, result.Size)
+	}
+
+	if cliCtx.Bool(vacuumFlag.Name) && !dryRun && result.Values > 0 {
+		log.Info("rewriting the table of the values, it is locked until done")
+		if err = storage.VacuumOffChainData(cliCtx.Context); err != nil {
+			return fmt.Errorf("failed to rewrite the table of the values: %w", err)
+		}
+	}
+
+	return nil
+}
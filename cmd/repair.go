@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/0xPolygon/cdk-data-availability/audit"
+	"github.com/0xPolygon/cdk-data-availability/client"
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/discovery"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/proxy"
+	"github.com/0xPolygon/cdk-data-availability/repair"
+	"github.com/0xPolygon/cdk-data-availability/sequencer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/urfave/cli/v2"
+)
+
+var reportFlag = cli.StringFlag{
+	Name:     "report",
+	Usage:    "Report of verify --json to repair, by default the database is audited first",
+	Required: false,
+}
+
+// repairDatabase re-fetches the missing and corrupt values found by verify from the trusted sequencer
+// and the committee, and fails when some could not be repaired
+func repairDatabase(cliCtx *cli.Context) error {
+	c, err := config.Load(cliCtx)
+	if err != nil {
+		return err
+	}
+	if err = c.Validate(); err != nil {
+		return err
+	}
+	setupLog(c.Log)
+
+	if err = proxy.Init(c.Proxy); err != nil {
+		return err
+	}
+
+	pg, err := db.InitContext(cliCtx.Context, c.DB)
+	if err != nil {
+		return err
+	}
+	defer pg.Close()
+	storage := db.NewFromConfig(pg, c.DB, c.Stream.Enabled())
+
+	etm, err := etherman.New(cliCtx.Context, c.L1)
+	if err != nil {
+		return err
+	}
+
+	var report *audit.Report
+	if path := cliCtx.String(reportFlag.Name); path != "" {
+		if report, err = readReport(path); err != nil {
+			return err
+		}
+	} else if report, err = auditDatabase(cliCtx, c, storage, etm); err != nil {
+		return err
+	}
+
+	// the local member is not queried for the values it is missing
+	var self common.Address
+	if pk, err := config.NewKeyFromKeystore(c.PrivateKey); err != nil {
+		log.Warnf("the local member is queried too, failed to load its key: %v", err)
+	} else {
+		self = crypto.PubkeyToAddress(pk.PublicKey)
+	}
+
+	// the sequencer is only resolved once, its changes are not tracked
+	c.L1.TrackSequencer = false
+	sequencerTracker := sequencer.NewTracker(c.L1, c.Timeouts, etm)
+	sequencerTracker.Start(cliCtx.Context)
+
+	clientFactory := discovery.NewFactory(discovery.NewResolver(c.Discovery, etm), client.NewFactory())
+	repairer := repair.New(storage, etm, sequencerTracker, clientFactory, self,
+		cliCtx.Duration(memberTimeoutFlag.Name), verifyPageSize, c.Limits.MaxValueSize)
+
+	log.Infof("repairing %d corrupt and %d missing values", len(report.Corrupt), len(report.Missing))
+	result, err := repairer.Repair(cliCtx.Context, report)
+	if err != nil {
+		return err
+	}
+
+	if cliCtx.Bool(jsonFlag.Name) {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err = enc.Encode(result); err != nil {
+			return err
+		}
+	} else {
+		printRepair(os.Stdout, result)
+	}
+
+	if len(result.Failed) > 0 {
+		return fmt.Errorf("%d value(s) could not be repaired", len(result.Failed))
+	}
+
+	return nil
+}
+
+// readReport reads a report written by verify --json
+func readReport(path string) (*audit.Report, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var report audit.Report
+	if err = json.NewDecoder(f).Decode(&report); err != nil {
+		return nil, fmt.Errorf("failed to read the report %s: %w", path, err)
+	}
+
+	return &report, nil
+}
+
+// printRepair prints the result of a repair for the operators
+func printRepair(w io.Writer, r *repair.Result) {
+	fmt.Fprintf(w, "%d repaired\n", len(r.Repaired))
+	for _, entry := range r.Repaired {
+		fmt.Fprintf(w, "  batch %d  %s  from %s\n", entry.BatchNum, entry.Key.Hex(), entry.Source)
+	}
+
+	fmt.Fprintf(w, "%d not repaired\n", len(r.Failed))
+	for _, entry := range r.Failed {
+		fmt.Fprintf(w, "  batch %d  %s  %s\n", entry.BatchNum, entry.Key.Hex(), entry.Error)
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/client"
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/recording"
+	"github.com/0xPolygon/cdk-data-availability/synchronizer"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	recordingFlag = cli.StringFlag{
+		Name:     "recording",
+		Aliases:  []string{"r"},
+		Usage:    "`FILE` the L1 events were recorded to with L1.RecordPath",
+		Required: true,
+	}
+	replayIntervalFlag = cli.DurationFlag{
+		Name:     "interval",
+		Usage:    "Period the synchronizer filters the events and is checked to have processed them at",
+		Value:    100 * time.Millisecond, //nolint:gomnd
+		Required: false,
+	}
+	replayTimeoutFlag = cli.DurationFlag{
+		Name:     "timeout",
+		Usage:    "Time the synchronizer has to process each event replayed",
+		Value:    time.Minute,
+		Required: false,
+	}
+)
+
+// replayReport is what a fresh node stored once the recording was replayed
+type replayReport struct {
+	recording.Stats
+	LastBlock  uint64           `json:"lastBlock"`
+	Unresolved []types.BatchKey `json:"unresolved"`
+}
+
+// replayRecording replays the L1 events of a recording against a fresh node storing in memory, and prints the
+// batches it stored as unresolved, the data being resolved from neither the sequencer nor the committee
+func replayRecording(cliCtx *cli.Context) error {
+	c, err := config.Load(cliCtx)
+	if err != nil {
+		return err
+	}
+	setupLog(c.Log)
+
+	file, err := os.Open(filepath.Clean(cliCtx.String(recordingFlag.Name)))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	replayer, err := recording.NewReplayer(file, c.L1)
+	if err != nil {
+		return err
+	}
+
+	l1 := c.L1
+	l1.RetryPeriod.Duration = cliCtx.Duration(replayIntervalFlag.Name)
+	storage := db.NewMemory(0)
+	batchSynchronizer, err := synchronizer.NewBatchSynchronizer(l1, c.Timeouts, c.Limits, common.Address{}, storage,
+		replayer.Reorgs(), replayer.Chain(), nil, nil, client.NewFactory())
+	if err != nil {
+		return err
+	}
+	batchSynchronizer.StartEvents(cliCtx.Context)
+	defer batchSynchronizer.Stop()
+
+	err = replayer.Run(cliCtx.Context, storage, l1.RetryPeriod.Duration, cliCtx.Duration(replayTimeoutFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	report := replayReport{Stats: replayer.Stats()}
+	if report.LastBlock, err = replayer.Head(cliCtx.Context); err != nil {
+		return err
+	}
+	if report.Unresolved, err = storage.GetUnresolvedBatchKeys(cliCtx.Context, math.MaxUint32); err != nil {
+		return err
+	}
+
+	if cliCtx.Bool(jsonFlag.Name) {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+
+	fmt.Printf("replayed %d logs and %d reorganizations up to the block %d, %d logs skipped\n",
+		report.Logs, report.Reorgs, report.LastBlock, report.Skipped)
+	for _, key := range report.Unresolved {
+		fmt.Printf("batch %d\t%s\n", key.Number, key.Hash.Hex())
+	}
+
+	return nil
+}
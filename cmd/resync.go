@@ -0,0 +1,48 @@
+package main
+
+import (
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/synchronizer"
+	"github.com/urfave/cli/v2"
+)
+
+var resyncFromBlockFlag = cli.Uint64Flag{
+	Name:     "from-block",
+	Usage:    "L1 `BLOCK` the synchronizer processes the sequences again from",
+	Required: true,
+}
+
+// resyncFromBlock rewinds the synchronizer to a block, for the sequences of the following blocks to be processed
+// again on the next start of the node
+func resyncFromBlock(cliCtx *cli.Context) error {
+	c, err := config.Load(cliCtx)
+	if err != nil {
+		return err
+	}
+	if err = c.Validate(); err != nil {
+		return err
+	}
+	setupLog(c.Log)
+
+	pg, err := db.InitContext(cliCtx.Context, c.DB)
+	if err != nil {
+		return err
+	}
+	defer pg.Close()
+
+	if err = db.Migrate(pg, !c.DB.DisableAutoMigrate); err != nil {
+		return err
+	}
+
+	block := cliCtx.Uint64(resyncFromBlockFlag.Name)
+	current, err := synchronizer.ResyncFrom(cliCtx.Context, db.New(pg), c.Timeouts.DBOperation.Duration, block)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("the synchronizer was at the block %d, it will process the sequences again from the block %d",
+		current, block)
+	return nil
+}
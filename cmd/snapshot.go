@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/snapshot"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+)
+
+// snapshotPageSize is the number of values read or stored at a time by the snapshots
+const snapshotPageSize = 1000
+
+var (
+	snapshotOutputFlag = cli.StringFlag{
+		Name:     "output",
+		Aliases:  []string{"o"},
+		Usage:    "Snapshot `FILE` written",
+		Required: true,
+	}
+	snapshotInputFlag = cli.StringFlag{
+		Name:     "input",
+		Aliases:  []string{"i"},
+		Usage:    "Snapshot `FILE` restored",
+		Required: true,
+	}
+)
+
+// snapshotCommand is the snapshot command, with a subcommand per operation
+var snapshotCommand = &cli.Command{
+	Name:    "snapshot",
+	Aliases: []string{},
+	Usage:   "Create a consistent snapshot of the state of the node, or restore one to a fresh node",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "create",
+			Usage:  "Write a snapshot of the values, the unresolved keys and the synchronizer cursors",
+			Action: createSnapshot,
+			Flags:  append([]cli.Flag{&snapshotOutputFlag}, configFlags...),
+		},
+		{
+			Name:   "restore",
+			Usage:  "Restore a snapshot to an empty database",
+			Action: restoreSnapshot,
+			Flags:  append([]cli.Flag{&snapshotInputFlag}, configFlags...),
+		},
+	},
+}
+
+// createSnapshot writes a snapshot of the database, while the node keeps running. The snapshot is
+// written next to its file and renamed once complete, so an interrupted run never leaves a partial one.
+func createSnapshot(cliCtx *cli.Context) error {
+	c, err := config.Load(cliCtx)
+	if err != nil {
+		return err
+	}
+	if err = c.Validate(); err != nil {
+		return err
+	}
+	setupLog(c.Log)
+
+	pg, err := db.InitContext(cliCtx.Context, c.DB)
+	if err != nil {
+		return err
+	}
+	defer pg.Close()
+
+	path := filepath.Clean(cliCtx.String(snapshotOutputFlag.Name))
+	tmp := path + ".tmp"
+	file, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600) //nolint:gomnd
+	if err != nil {
+		return err
+	}
+	defer func() {
+		file.Close()
+		os.Remove(tmp)
+	}()
+
+	snap, err := db.BeginSnapshot(cliCtx.Context, pg)
+	if err != nil {
+		return err
+	}
+	defer snap.Close()
+
+	manifest, err := snapshot.Create(cliCtx.Context, snap, file,
+		common.HexToAddress(c.L1.PolygonValidiumAddress), snapshotPageSize)
+	if err != nil {
+		return err
+	}
+	if err = file.Sync(); err != nil {
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp, path); err != nil {
+		return err
+	}
+
+	log.Infof("snapshot of %d values and %d unresolved keys written to %s, checksum %s",
+		manifest.Values, manifest.UnresolvedKeys, path, manifest.DataSHA256)
+	return nil
+}
+
+// restoreSnapshot restores a snapshot to an empty database, in a single transaction
+func restoreSnapshot(cliCtx *cli.Context) error {
+	c, err := config.Load(cliCtx)
+	if err != nil {
+		return err
+	}
+	if err = c.Validate(); err != nil {
+		return err
+	}
+	setupLog(c.Log)
+
+	file, err := os.Open(cliCtx.String(snapshotInputFlag.Name))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	pg, err := db.InitContext(cliCtx.Context, c.DB)
+	if err != nil {
+		return err
+	}
+	defer pg.Close()
+
+	if err = db.Migrate(pg, !c.DB.DisableAutoMigrate); err != nil {
+		return err
+	}
+
+	migrations, err := db.MigrationsStatus(pg)
+	if err != nil {
+		return err
+	}
+	var schemaVersion string
+	for _, migration := range migrations {
+		if migration.AppliedAt != nil {
+			schemaVersion = migration.ID
+		}
+	}
+
+	restore, err := db.BeginRestore(cliCtx.Context, pg)
+	if err != nil {
+		return err
+	}
+
+	manifest, err := snapshot.Restore(cliCtx.Context, file, restore,
+		common.HexToAddress(c.L1.PolygonValidiumAddress), schemaVersion, snapshotPageSize)
+	if err != nil {
+		if txErr := restore.Rollback(); txErr != nil {
+			return fmt.Errorf("%v: rollback caused by %v", txErr, err)
+		}
+		return err
+	}
+	if err = restore.Commit(); err != nil {
+		return err
+	}
+
+	log.Infof("restored %d values and %d unresolved keys of the snapshot created at %s",
+		manifest.Values, manifest.UnresolvedKeys, manifest.CreatedAt)
+	return nil
+}
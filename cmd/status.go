@@ -0,0 +1,64 @@
+package main
+
+import (
+	"os"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/dashboard"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	statusNodeFlag = cli.StringFlag{
+		Name:     "node",
+		Usage:    "`URL` of the RPC endpoints of the running node",
+		Value:    "http://localhost:8444",
+		Required: false,
+	}
+	statusMetricsFlag = cli.StringFlag{
+		Name:     "metrics",
+		Usage:    "`URL` of the metrics of the running node, empty when they are not exposed",
+		Value:    "http://localhost:9091/metrics",
+		Required: false,
+	}
+	watchFlag = cli.BoolFlag{
+		Name:     "watch",
+		Usage:    "Refresh the status until interrupted",
+		Required: false,
+	}
+	watchIntervalFlag = cli.DurationFlag{
+		Name:     "interval",
+		Usage:    "Refresh interval of --watch",
+		Value:    2 * time.Second, //nolint:gomnd
+		Required: false,
+	}
+)
+
+// showStatus prints the status of a running node read from its status and metrics endpoints, refreshed
+// in place with --watch, the rates of requests and the recent errors being computed between refreshes
+func showStatus(cliCtx *cli.Context) error {
+	interval := cliCtx.Duration(watchIntervalFlag.Name)
+	fetcher := dashboard.NewFetcher(cliCtx.String(statusNodeFlag.Name), cliCtx.String(statusMetricsFlag.Name),
+		interval)
+
+	watch := cliCtx.Bool(watchFlag.Name)
+	sample := fetcher.Fetch(cliCtx.Context)
+	dashboard.Render(os.Stdout, dashboard.NewView(nil, sample), watch)
+	if !watch {
+		return nil
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			prev := sample
+			sample = fetcher.Fetch(cliCtx.Context)
+			dashboard.Render(os.Stdout, dashboard.NewView(prev, sample), true)
+		case <-cliCtx.Context.Done():
+			return nil
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/0xPolygon/cdk-data-availability/audit"
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/proxy"
+	"github.com/0xPolygon/cdk-data-availability/synchronizer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+)
+
+// verifyPageSize is the number of values read at a time by the audit
+const verifyPageSize = 1000
+
+var (
+	fromBlockFlag = cli.Uint64Flag{
+		Name:     "from-block",
+		Usage:    "First L1 block audited, by default L1.GenesisBlock or the block the contract was deployed at",
+		Required: false,
+	}
+	toBlockFlag = cli.Uint64Flag{
+		Name:     "to-block",
+		Usage:    "Last L1 block audited, by default the last block processed by the synchronizer",
+		Required: false,
+	}
+	blockRangeFlag = cli.Uint64Flag{
+		Name:     "block-range",
+		Usage:    "Number of L1 blocks the sequences are filtered in at a time",
+		Value:    10000,
+		Required: false,
+	}
+	jsonFlag = cli.BoolFlag{
+		Name:     "json",
+		Usage:    "Print the report as JSON",
+		Required: false,
+	}
+)
+
+// verifyDatabase audits the stored values against the keys sequenced on L1, and fails when a problem is found
+func verifyDatabase(cliCtx *cli.Context) error {
+	c, err := config.Load(cliCtx)
+	if err != nil {
+		return err
+	}
+	if err = c.Validate(); err != nil {
+		return err
+	}
+	setupLog(c.Log)
+
+	if err = proxy.Init(c.Proxy); err != nil {
+		return err
+	}
+
+	pg, err := db.InitContext(cliCtx.Context, c.DB)
+	if err != nil {
+		return err
+	}
+	defer pg.Close()
+	storage := db.New(pg)
+
+	etm, err := etherman.New(cliCtx.Context, c.L1)
+	if err != nil {
+		return err
+	}
+
+	report, err := auditDatabase(cliCtx, c, storage, etm)
+	if err != nil {
+		return err
+	}
+
+	if cliCtx.Bool(jsonFlag.Name) {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err = enc.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		printReport(os.Stdout, report)
+	}
+
+	if problems := report.Problems(); problems > 0 {
+		return fmt.Errorf("%d problem(s) found", problems)
+	}
+
+	return nil
+}
+
+// auditDatabase audits the stored values against the keys sequenced in the L1 blocks of the flags
+func auditDatabase(
+	cliCtx *cli.Context,
+	c *config.Config,
+	storage db.DB,
+	etm etherman.Etherman,
+) (*audit.Report, error) {
+	fromBlock := cliCtx.Uint64(fromBlockFlag.Name)
+	if fromBlock == 0 {
+		fromBlock = c.L1.GenesisBlock
+	}
+	if fromBlock == 0 {
+		deployment, err := synchronizer.FindContractDeploymentBlock(cliCtx.Context, etm,
+			common.HexToAddress(c.L1.PolygonValidiumAddress))
+		if err != nil {
+			return nil, err
+		}
+		fromBlock = deployment.Uint64()
+	}
+
+	toBlock := cliCtx.Uint64(toBlockFlag.Name)
+	if toBlock == 0 {
+		last, err := storage.GetLastProcessedBlock(cliCtx.Context, string(synchronizer.L1SyncTask))
+		if err != nil {
+			return nil, err
+		}
+		toBlock = last
+	}
+	if toBlock < fromBlock {
+		return nil, fmt.Errorf("the last block %d is before the first block %d", toBlock, fromBlock)
+	}
+
+	blockRange := cliCtx.Uint64(blockRangeFlag.Name)
+	if blockRange == 0 {
+		return nil, fmt.Errorf("--%s must be greater than zero", blockRangeFlag.Name)
+	}
+
+	log.Infof("auditing the values sequenced in the L1 blocks %d to %d", fromBlock, toBlock)
+	return audit.New(storage, etm, blockRange, verifyPageSize).Audit(cliCtx.Context, fromBlock, toBlock)
+}
+
+// printReport prints a report for the operators
+func printReport(w io.Writer, r *audit.Report) {
+	fmt.Fprintf(w, "L1 blocks %d to %d, batches %d to %d\n", r.FromBlock, r.ToBlock, r.FromBatch, r.ToBatch)
+	fmt.Fprintf(w, "%d keys sequenced, %d values stored, %d sequences carrying blobs not audited\n",
+		r.Expected, r.Stored, r.BlobSequences)
+
+	for _, section := range []struct {
+		name    string
+		entries []audit.Entry
+	}{
+		{"corrupt", r.Corrupt},
+		{"orphaned", r.Orphaned},
+		{"missing", r.Missing},
+	} {
+		fmt.Fprintf(w, "%d %s\n", len(section.entries), section.name)
+		for _, entry := range section.entries {
+			fmt.Fprintf(w, "  batch %d  %s\n", entry.BatchNum, entry.Key.Hex())
+		}
+	}
+
+	// the values pruned per the retention policy are not a problem, only counted
+	fmt.Fprintf(w, "%d pruned\n", len(r.Pruned))
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+
+	dataavailability "github.com/0xPolygon/cdk-data-availability"
+	"github.com/urfave/cli/v2"
+)
+
+// printVersion prints the version information of the build, as JSON with --json for the inventory tooling
+func printVersion(cliCtx *cli.Context) error {
+	if cliCtx.Bool(jsonFlag.Name) {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(dataavailability.GetInfo())
+	}
+
+	dataavailability.PrintVersion(os.Stderr)
+	return nil
+}
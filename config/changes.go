@@ -0,0 +1,31 @@
+package config
+
+import "reflect"
+
+// Changed returns the names of the settings whose value differs between the configurations, e.g. RPC.Port
+func Changed(before, after *Config) []string {
+	return changed(reflect.ValueOf(before).Elem(), reflect.ValueOf(after).Elem(), "")
+}
+
+func changed(before, after reflect.Value, prefix string) []string {
+	var names []string
+
+	t := before.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := prefix + field.Name
+		if field.Type.Kind() == reflect.Struct && !isLeafType(field.Type) {
+			names = append(names, changed(before.Field(i), after.Field(i), name+".")...)
+			continue
+		}
+		if !reflect.DeepEqual(before.Field(i).Interface(), after.Field(i).Interface()) {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,21 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/config/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChanged(t *testing.T) {
+	before := &Config{}
+	after := &Config{}
+	require.Empty(t, Changed(before, after))
+
+	after.Log.Levels = map[string]string{"db": "debug"}
+	after.RPC.Port = 8444
+	after.L1.Timeout = types.NewDuration(time.Minute)
+	after.Stream.Kafka.Brokers = []string{"localhost:9092"}
+	require.Equal(t, []string{"Log.Levels", "RPC.Port", "Stream.Kafka.Brokers", "L1.Timeout"}, Changed(before, after))
+}
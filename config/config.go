@@ -1,15 +1,30 @@
 package config
 
 import (
+	"bytes"
 	"crypto/ecdsa"
+	"encoding"
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 
 	"github.com/0xPolygon/cdk-data-availability/config/types"
 	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/debug"
+	"github.com/0xPolygon/cdk-data-availability/diagnostics"
+	"github.com/0xPolygon/cdk-data-availability/fault"
 	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/metrics"
+	"github.com/0xPolygon/cdk-data-availability/notifier"
+	"github.com/0xPolygon/cdk-data-availability/proxy"
+	"github.com/0xPolygon/cdk-data-availability/publisher"
+	"github.com/0xPolygon/cdk-data-availability/reporter"
 	"github.com/0xPolygon/cdk-data-availability/rpc"
+	"github.com/0xPolygon/cdk-data-availability/stream"
+	"github.com/0xPolygon/cdk-data-availability/tracing"
+	"github.com/0xPolygon/cdk-data-availability/webhook"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
@@ -19,20 +34,287 @@ import (
 const (
 	// FlagCfg flag used for config aka cfg
 	FlagCfg = "cfg"
+
+	// FlagCfgFormat flag used to set the format of the config file when it can't be told by its extension
+	FlagCfgFormat = "cfg-format"
+
+	// EnvPrefix is the prefix of the environment variables overriding config values
+	EnvPrefix = "DATA_NODE"
 )
 
+// SupportedFormats are the supported config file formats
+var SupportedFormats = []string{"toml", "yaml", "yml", "json"}
+
+// decodeHooks are the hooks used to decode the config values
+var decodeHooks = []viper.DecoderConfigOption{
+	// this allows arrays to be decoded from env var separated by ",", example: MY_VAR="value1,value2,value3"
+	viper.DecodeHook(
+		mapstructure.ComposeDecodeHookFunc(
+			mapstructure.TextUnmarshallerHookFunc(),
+			mapstructure.StringToSliceHookFunc(","),
+			stringToMapHookFunc(",", "="),
+		),
+	),
+}
+
 // Config represents the full configuration of the data node
 type Config struct {
-	PrivateKey types.KeystoreFileConfig
-	DB         db.Config
-	Log        log.Config
-	RPC        rpc.Config
-	L1         L1Config
+	PrivateKey  types.KeystoreFileConfig
+	DB          db.Config
+	Log         log.Config
+	RPC         rpc.Config
+	Admin       AdminConfig
+	Metrics     metrics.Config
+	Tracing     tracing.Config
+	Debug       debug.Config
+	Notifier    notifier.Config
+	Reporter    reporter.Config
+	Diagnostics diagnostics.Config
+	Publisher   publisher.Config
+	Stream      stream.Config
+	Webhook     webhook.Config
+	Gossip      GossipConfig
+	Replay      ReplayConfig
+	Attestation AttestationConfig
+	Reconcile   ReconcileConfig
+	CrossCheck  CrossCheckConfig
+	Certificate CertificateConfig
+	Custody     CustodyConfig
+	Sharding    ShardingConfig
+	Discovery   DiscoveryConfig
+	GraphQL     GraphQLConfig
+	Mirror      MirrorConfig
+	Devnet      DevnetConfig
+	Proxy       proxy.Config
+	Fault       fault.Config
+	L1          L1Config
+	Timeouts    TimeoutsConfig
+	Limits      LimitsConfig
+	Durability  DurabilityConfig
+}
+
+// AdminConfig is the configuration of the admin API, used to operate the node at runtime.
+// It must not be exposed publicly, so it listens on localhost by default.
+type AdminConfig struct {
+	// Enabled starts the admin API
+	Enabled bool `mapstructure:"Enabled"`
+	// Host defines the network adapter that will be used to serve the admin API
+	Host string `mapstructure:"Host"`
+	// Port defines the port to serve the admin API
+	Port int `mapstructure:"Port"`
+}
+
+// MirrorConfig represents the configuration of the mirror mode, in which the node stores the data of
+// the chain of the L1 contracts without being a member of its committee, e.g. to run a public mirror
+// of the data of another chain
+type MirrorConfig struct {
+	// Enabled runs the node as a mirror: no private key is loaded and nothing is signed, the values
+	// are only stored from the committee members and the trusted sequencer of the chain
+	Enabled bool `mapstructure:"Enabled"`
+}
+
+// DevnetConfig represents the configuration of the local devnet mode, in which the node runs against a
+// simulated L1 and a committee of simulated members in the same process, so that the flow of signing,
+// storing and resolving the data of the sequences can be run locally without L1 or other nodes
+type DevnetConfig struct {
+	// Enabled runs the node on the simulated L1, with a generated key instead of PrivateKey
+	Enabled bool `mapstructure:"Enabled"`
+
+	// Members is the number of simulated members in the committee besides the node, each storing in memory.
+	// They are all required to sign, so that each sequence is signed by all but one of the committee.
+	Members int `mapstructure:"Members"`
+
+	// MembersPort is the port the RPC server of the first simulated member listens on, the next members
+	// listening on the following ports
+	MembersPort int `mapstructure:"MembersPort"`
+
+	// BlockTime is how often a block is mined on the simulated L1
+	BlockTime types.Duration `mapstructure:"BlockTime"`
+
+	// SequenceInterval is how often the simulated sequencer sends a sequence to the committee to sign,
+	// sequencing it on the simulated L1 once signed
+	SequenceInterval types.Duration `mapstructure:"SequenceInterval"`
+
+	// BatchesPerSequence is the number of batches in each sequence
+	BatchesPerSequence int `mapstructure:"BatchesPerSequence"`
+
+	// BatchSize is the size in bytes of the random data of each batch
+	BatchSize int `mapstructure:"BatchSize"`
+}
+
+// GossipConfig represents the configuration of the replication between the committee members
+type GossipConfig struct {
+	// Enabled announces the values stored by the node to the other members, and fetches
+	// the values announced by them
+	Enabled bool `mapstructure:"Enabled"`
+
+	// MaxKeys is the maximum number of keys in an announcement, larger ones are rejected
+	MaxKeys uint `mapstructure:"MaxKeys"`
+
+	// Timeout bounds each announcement sent, and each fetch of the announced values
+	Timeout types.Duration `mapstructure:"Timeout"`
+
+	// CommitteeRefresh is how often the committee members are loaded from L1
+	CommitteeRefresh types.Duration `mapstructure:"CommitteeRefresh"`
+}
+
+// ReplayConfig represents the protection against the replay of the signed requests, i.e. the requests of
+// the sequencer to sign a sequence and the announcements of the other members
+type ReplayConfig struct {
+	// Window is how far the timestamp a request was signed with can be from the current time,
+	// each request being accepted once within it
+	Window types.Duration `mapstructure:"Window"`
+
+	// RequireTimestamp rejects the requests not signed with a timestamp, which can otherwise be replayed. It must be
+	// disabled while the sequencer does not sign its requests with a timestamp.
+	RequireTimestamp bool `mapstructure:"RequireTimestamp"`
+}
+
+// AttestationConfig represents the configuration of the attestations of the stored keys submitted to L1
+type AttestationConfig struct {
+	// Enabled periodically submits the Merkle root of the keys stored since the last attestation
+	// to the attestation contract, from the address of the member
+	Enabled bool `mapstructure:"Enabled"`
+
+	// ContractAddress is the address of the attestation contract on L1
+	ContractAddress string `mapstructure:"ContractAddress"`
+
+	// Interval is how often an attestation is submitted, when new keys were stored
+	Interval types.Duration `mapstructure:"Interval"`
+
+	// MaxKeys is the maximum number of keys covered by one attestation
+	MaxKeys uint `mapstructure:"MaxKeys"`
+
+	// Timeout bounds the submission of an attestation, including waiting for it to be mined
+	Timeout types.Duration `mapstructure:"Timeout"`
+}
+
+// ReconcileConfig represents the configuration of the reconciliation of the sequences signed by the node
+// against the sequences landing on L1
+type ReconcileConfig struct {
+	// Enabled periodically matches the sequences signed, from the audit log, against the SequenceBatches
+	// events processed by the synchronizer, alerting on the signed sequences that never land and on the
+	// sequences landing with a signature of the node it has no record of
+	Enabled bool `mapstructure:"Enabled"`
+
+	// Interval is how often the reconciliation runs
+	Interval types.Duration `mapstructure:"Interval"`
+
+	// Grace is how long a signed sequence has to land on L1 before it is reported
+	Grace types.Duration `mapstructure:"Grace"`
+
+	// BlockRange is the number of L1 blocks the sequences are filtered in at a time
+	BlockRange uint64 `mapstructure:"BlockRange"`
+}
+
+// CustodyConfig represents the configuration of the custody challenges of the other committee members
+type CustodyConfig struct {
+	// Challenger periodically challenges every other committee member to prove it holds a value stored by
+	// the node, chosen at random among the batches verified on L1, alerting on the members failing to
+	Challenger bool `mapstructure:"Challenger"`
+
+	// Interval is how often the members are challenged
+	Interval types.Duration `mapstructure:"Interval"`
+
+	// Timeout bounds the answer of a member to a challenge
+	Timeout types.Duration `mapstructure:"Timeout"`
+}
+
+// ShardingConfig represents the configuration of the erasure coding of the stored values into shards
+type ShardingConfig struct {
+	// Enabled encodes each stored value into Reed-Solomon shards, stores the shards assigned to the
+	// member and serves them to the light clients sampling the availability of the data
+	Enabled bool `mapstructure:"Enabled"`
+
+	// DataShards is the number of shards a value is split into, any DataShards shards rebuild it
+	DataShards uint `mapstructure:"DataShards"`
+
+	// ParityShards is the number of parity shards computed for each value
+	ParityShards uint `mapstructure:"ParityShards"`
+
+	// Interval is how often the new values are encoded
+	Interval types.Duration `mapstructure:"Interval"`
+
+	// BatchSize is the maximum number of values encoded per interval
+	BatchSize uint `mapstructure:"BatchSize"`
+
+	// Timeout bounds the encoding and the storage of the shards of a value
+	Timeout types.Duration `mapstructure:"Timeout"`
+}
+
+// DiscoveryConfig represents the configuration of the discovery of the endpoints of the committee
+// members registered on L1 as a DNS name, dnstxt://dac.example.org, or an ENS name, ens://member.eth,
+// rather than as a URL, so that the members can move without an update of the committee on L1
+type DiscoveryConfig struct {
+	// TTL is how long a discovered URL is used before being resolved again
+	TTL types.Duration `mapstructure:"TTL"`
+
+	// Timeout bounds the resolution of an endpoint
+	Timeout types.Duration `mapstructure:"Timeout"`
+
+	// ENSRegistry is the address of the ENS registry on L1
+	ENSRegistry string `mapstructure:"ENSRegistry"`
+
+	// ENSTextKey is the key of the text record of an ENS name holding the URL of the member
+	ENSTextKey string `mapstructure:"ENSTextKey"`
+}
+
+// GraphQLConfig represents the configuration of the read-only GraphQL endpoint over the stored data
+type GraphQLConfig struct {
+	// Enabled serves the GraphQL queries at /graphql
+	Enabled bool `mapstructure:"Enabled"`
+
+	// Host defines the network adapter that will be used to serve the GraphQL queries
+	Host string `mapstructure:"Host"`
+
+	// Port defines the port to serve the GraphQL queries
+	Port int `mapstructure:"Port"`
+
+	// MaxBatchRange is the maximum number of batches queried at once
+	MaxBatchRange uint64 `mapstructure:"MaxBatchRange"`
+
+	// MaxResults is the maximum number of values or committee changes returned by a query,
+	// queries matching more are rejected
+	MaxResults uint `mapstructure:"MaxResults"`
+
+	// Timeout bounds the execution of a query
+	Timeout types.Duration `mapstructure:"Timeout"`
+}
+
+// CrossCheckConfig represents the configuration of the cross-check of the stored keys against the keys
+// sequenced on L1
+type CrossCheckConfig struct {
+	// Enabled periodically compares the keys sequenced on L1 up to the last verified batch with the keys
+	// stored, reporting the keys stored that were never sequenced and the keys sequenced that are not stored
+	Enabled bool `mapstructure:"Enabled"`
+
+	// Interval is how often the cross-check runs
+	Interval types.Duration `mapstructure:"Interval"`
+
+	// BlockRange is the number of L1 blocks the sequences are filtered in at a time
+	BlockRange uint64 `mapstructure:"BlockRange"`
+}
+
+// CertificateConfig represents the configuration of the availability certificates
+type CertificateConfig struct {
+	// Coordinator collects the availability signatures of the committee members over the stored
+	// values, and assembles their certificates once the threshold of the committee is reached
+	Coordinator bool `mapstructure:"Coordinator"`
+
+	// Interval is how often the values without a certificate are certified
+	Interval types.Duration `mapstructure:"Interval"`
+
+	// BatchSize is the maximum number of values certified per interval
+	BatchSize uint `mapstructure:"BatchSize"`
+
+	// Timeout bounds the collection of the signatures of a certificate
+	Timeout types.Duration `mapstructure:"Timeout"`
 }
 
 // L1Config is a struct that defines L1 contract and service settings
 type L1Config struct {
-	RpcURL                     string         `mapstructure:"RpcURL"`
+	// RpcURL is a secret, as the URLs of the RPC providers usually embed an API key
+	RpcURL                     string         `mapstructure:"RpcURL" secret:"true"`
 	PolygonValidiumAddress     string         `mapstructure:"PolygonValidiumAddress"`
 	DataCommitteeAddress       string         `mapstructure:"DataCommitteeAddress"`
 	Timeout                    types.Duration `mapstructure:"Timeout"`
@@ -41,49 +323,197 @@ type L1Config struct {
 	TrackSequencer             bool           `mapstructure:"TrackSequencer"`
 	TrackSequencerPollInterval types.Duration `mapstructure:"TrackSequencerPollInterval"`
 
+	// ChainID is the chain ID of L1, checked against the L1 node at startup unless it is 0
+	ChainID uint64 `mapstructure:"ChainID"`
+
 	// GenesisBlock represents the block number where PolygonValidium contract is deployed on L1
 	GenesisBlock uint64 `mapstructure:"GenesisBlock"`
+
+	// BeaconURL is the beacon API of a consensus node of L1, the blobs of the sequences carrying
+	// blobs are read from it. Sequences carrying blobs can not be synchronized when it is empty.
+	BeaconURL string `mapstructure:"BeaconURL"`
+
+	// EventConcurrency is the number of SequenceBatches events whose transactions are fetched concurrently,
+	// the events still being stored in block order. They are handled one at a time when it is 0 or 1.
+	EventConcurrency uint `mapstructure:"EventConcurrency"`
+
+	// ResolveConcurrency is the number of missing batches resolved at once from the trusted sequencer and the
+	// committee, the others waiting for one to complete. They are resolved one at a time when it is 0 or 1.
+	ResolveConcurrency uint `mapstructure:"ResolveConcurrency"`
+
+	// RecordPath is the file the synchronizer appends what it consumes from L1 to: the SequenceBatches logs, the
+	// transactions of the sequences and the reorganizations, for them to be replayed offline by the replay
+	// command. Nothing is recorded when it is empty.
+	RecordPath string `mapstructure:"RecordPath"`
+}
+
+// TimeoutsConfig groups the timeouts and retry policies of the node. The L1 requests
+// are still bounded by L1.Timeout and retried every L1.RetryPeriod.
+type TimeoutsConfig struct {
+	// DBOperation is the timeout of each database operation made by the synchronizer
+	DBOperation types.Duration `mapstructure:"DBOperation"`
+
+	// StartBlockSearch is the timeout to find the L1 block the synchronizer starts from
+	StartBlockSearch types.Duration `mapstructure:"StartBlockSearch"`
+
+	// CommitteeRPC is the timeout of the requests made to the trusted sequencer and
+	// to other committee members to get the missing batch data
+	CommitteeRPC types.Duration `mapstructure:"CommitteeRPC"`
+
+	// ReorgPollInterval is how often the latest L1 blocks are polled to detect reorgs
+	ReorgPollInterval types.Duration `mapstructure:"ReorgPollInterval"`
+
+	// L1SubscriptionRetries is the number of attempts to subscribe to L1 events before
+	// giving up. The wait between attempts starts at L1.RetryPeriod and doubles each time
+	L1SubscriptionRetries uint `mapstructure:"L1SubscriptionRetries"`
+
+	// MaxBackoff caps the wait between two attempts of an exponential backoff
+	MaxBackoff types.Duration `mapstructure:"MaxBackoff"`
 }
 
 // Load loads the configuration baseed on the cli context
 func Load(ctx *cli.Context) (*Config, error) {
-	cfg, err := Default()
+	cfg, err := load(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	viper.AutomaticEnv()
-	replacer := strings.NewReplacer(".", "_")
-	viper.SetEnvKeyReplacer(replacer)
-	viper.SetEnvPrefix("DATA_NODE")
+	if err = ResolveSecrets(ctx.Context, cfg); err != nil {
+		return nil, err
+	}
 
-	configFilePath := ctx.String(FlagCfg)
-	if configFilePath != "" {
-		dirName, fileName := filepath.Split(configFilePath)
+	return cfg, nil
+}
 
-		fileExtension := strings.TrimPrefix(filepath.Ext(fileName), ".")
-		fileNameWithoutExtension := strings.TrimSuffix(fileName, "."+fileExtension)
+// load merges the defaults, the network preset, the config file, the environment and the
+// command line flags into the configuration, without resolving the secret references
+func load(ctx *cli.Context) (*Config, error) {
+	v := viper.New()
+	v.SetConfigType("toml")
+	if err := v.ReadConfig(bytes.NewBuffer([]byte(DefaultValues))); err != nil {
+		return nil, err
+	}
 
-		viper.AddConfigPath(dirName)
-		viper.SetConfigName(fileNameWithoutExtension)
-		viper.SetConfigType(fileExtension)
-		err = viper.ReadInConfig()
+	if network := ctx.String(FlagNetwork); network != "" {
+		values, err := preset(network)
 		if err != nil {
 			return nil, err
 		}
+		if err = v.MergeConfig(bytes.NewBufferString(values)); err != nil {
+			return nil, err
+		}
 	}
 
-	decodeHooks := []viper.DecoderConfigOption{
-		// this allows arrays to be decoded from env var separated by ",", example: MY_VAR="value1,value2,value3"
-		viper.DecodeHook(
-			mapstructure.ComposeDecodeHookFunc(
-				mapstructure.TextUnmarshallerHookFunc(),
-				mapstructure.StringToSliceHookFunc(","),
-			),
-		),
+	configFilePath := ctx.String(FlagCfg)
+	if configFilePath != "" {
+		format, err := configFormat(configFilePath, ctx.String(FlagCfgFormat))
+		if err != nil {
+			return nil, err
+		}
+
+		v.SetConfigFile(configFilePath)
+		v.SetConfigType(format)
+		// merge on top of the defaults, so that every known key stays bound to its env variable
+		if err = v.MergeInConfig(); err != nil {
+			return nil, err
+		}
+	}
+
+	v.AutomaticEnv()
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.SetEnvPrefix(EnvPrefix)
+	if err := bindEnvs(v, reflect.TypeOf(Config{}), ""); err != nil {
+		return nil, err
+	}
+	if err := bindFlags(v, ctx); err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg, decodeHooks...); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// configFormat returns the format of the config file, either the one explicitly given or
+// the one matching the file extension
+func configFormat(configFilePath, format string) (string, error) {
+	if format == "" {
+		format = strings.TrimPrefix(filepath.Ext(configFilePath), ".")
+	}
+
+	format = strings.ToLower(format)
+	for _, supported := range SupportedFormats {
+		if format == supported {
+			return format, nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported config file format %q for %s, use one of %v or set it with --%s",
+		format, configFilePath, SupportedFormats, FlagCfgFormat)
+}
+
+// bindEnvs binds every field of the given config type to its environment variable,
+// e.g. DB.Host is overridden by DATA_NODE_DB_HOST, so that fields without a value in
+// the config file can still be set from the environment
+func bindEnvs(v *viper.Viper, t reflect.Type, prefix string) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("mapstructure"); ok && tag != "" {
+			name = tag
+		}
+
+		key := prefix + name
+		if field.Type.Kind() == reflect.Struct && !isLeafType(field.Type) {
+			if err := bindEnvs(v, field.Type, key+"."); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := v.BindEnv(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// stringToMapHookFunc decodes a map[string]string from a string of key value pairs,
+// e.g. DATA_NODE_LOG_LEVELS="synchronizer=debug,db=warn"
+func stringToMapHookFunc(sep, kvSep string) mapstructure.DecodeHookFuncType {
+	return func(f reflect.Type, t reflect.Type, data interface{}) (interface{}, error) {
+		if f.Kind() != reflect.String || t != reflect.TypeOf(map[string]string{}) {
+			return data, nil
+		}
+
+		values := make(map[string]string)
+		raw, _ := data.(string)
+		for _, pair := range strings.Split(raw, sep) {
+			if strings.TrimSpace(pair) == "" {
+				continue
+			}
+			key, value, found := strings.Cut(pair, kvSep)
+			if !found {
+				return nil, fmt.Errorf("invalid key value pair %q, expected key%svalue", pair, kvSep)
+			}
+			values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+
+		return values, nil
 	}
-	err = viper.Unmarshal(&cfg, decodeHooks...)
-	return cfg, err
+}
+
+// isLeafType reports whether a struct type is decoded from a single text value
+func isLeafType(t reflect.Type) bool {
+	return reflect.PointerTo(t).Implements(reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem())
 }
 
 // NewKeyFromKeystore creates a private key from a keystore file
@@ -101,3 +531,42 @@ func NewKeyFromKeystore(cfg types.KeystoreFileConfig) (*ecdsa.PrivateKey, error)
 	}
 	return key.PrivateKey, nil
 }
+
+// LimitsConfig represents the limits on the size of the data the node accepts, from the sequencer asking it
+// to sign a sequence as well as from the sequencer and the members the synchronizer resolves the batches with
+type LimitsConfig struct {
+	// MaxValueSize is the maximum size in bytes of the data of a batch
+	MaxValueSize uint64 `mapstructure:"MaxValueSize"`
+
+	// MaxSequenceSize is the maximum size in bytes of the data of all the batches of a sequence
+	MaxSequenceSize uint64 `mapstructure:"MaxSequenceSize"`
+
+	// StoreChunkSize is the size in bytes of the data resolved by the synchronizer after which it is stored,
+	// bounding the memory it holds. When zero, the data of all the batches resolved in a round is stored at once.
+	StoreChunkSize uint64 `mapstructure:"StoreChunkSize"`
+
+	// MemoryLimit is the soft memory limit in bytes of the runtime, overriding GOMEMLIMIT, from which the
+	// garbage collector runs more often. The requests of low priority are shed from 90% of it unless
+	// RPC.Overload.MaxHeapSize is set. When zero, GOMEMLIMIT applies if set.
+	MemoryLimit uint64 `mapstructure:"MemoryLimit"`
+}
+
+// Durability levels the data of a sequence must reach before the node signs it
+const (
+	// DurabilityCommitted signs once the transaction storing the data is committed
+	DurabilityCommitted = "committed"
+	// DurabilityFlushed signs once the commit is flushed to the disk of the database, whatever its
+	// synchronous_commit setting
+	DurabilityFlushed = "flushed"
+	// DurabilityReplicated signs once the commit is applied on the synchronous standbys of the database
+	DurabilityReplicated = "replicated"
+	// DurabilityArchived signs once the commit is flushed and the data published to every enabled backend
+	DurabilityArchived = "archived"
+)
+
+// DurabilityConfig represents how durably the data of a sequence is stored before the node signs it,
+// so that the node never attests data it could lose
+type DurabilityConfig struct {
+	// Level is one of committed, flushed, replicated or archived
+	Level string `mapstructure:"Level"`
+}
@@ -19,13 +19,21 @@ Timeout = "1m"
 RetryPeriod = "5s"
 BlockBatchSize = "64"
 GenesisBlock = "0"
+ChainID = 0
 TrackSequencer = true
 TrackSequencerPollInterval = "1m"
+BeaconURL = ""
+EventConcurrency = 8
+ResolveConcurrency = 4
+RecordPath = ""
 
 [Log]
 Environment = "development" # "production" or "development"
 Level = "info"
+Format = "" # "console" or "json", by default "json" in production and "console" in development
 Outputs = ["stderr"]
+# Levels of specific components, e.g. synchronizer, sequencer, etherman, rpc or db
+Levels = {}
 
 [DB]
 User = "committee_user"
@@ -35,25 +43,263 @@ Host = "cdk-data-availability-db"
 Port = "5432"
 EnableLog = false
 MaxConns = 200
+DisableAutoMigrate = false
+PreparedStatements = true
+BackfillBatchSize = 10000
+BackfillPause = "100ms"
+Memory = false
+MemoryMaxSize = 0
 
 [RPC]
 Host = "0.0.0.0"
 Port = 8444
 ReadTimeout = "60s"
+ReadHeaderTimeout = "5s"
+IdleTimeout = "60s"
 WriteTimeout = "60s"
 MaxRequestsPerIPAndSecond = 500
+MaxConns = 10000
+MaxConnsPerIP = 200
+MaxConcurrentRequestsPerIP = 100
+MaxConcurrentRequests = 500
+MaxMessageSize = 536870912
+ReusePort = false
+
+[RPC.Overload]
+MaxInFlight = 1000
+MaxDBConnsInUse = 150
+MaxHeapSize = 0
+RetryAfter = "5s"
+PriorityServices = ["datacom", "status"]
+
+[Limits]
+MaxValueSize = 16777216
+MaxSequenceSize = 268435456
+StoreChunkSize = 67108864
+MemoryLimit = 0
+
+[Durability]
+Level = "committed"
+
+[Timeouts]
+DBOperation = "2s"
+StartBlockSearch = "15s"
+CommitteeRPC = "1m"
+ReorgPollInterval = "1s"
+L1SubscriptionRetries = 5
+MaxBackoff = "1m"
+
+[Metrics]
+Enabled = false
+Host = "0.0.0.0"
+Port = 9091
+
+[Tracing]
+Enabled = false
+Endpoint = "localhost:4318"
+Insecure = true
+ServiceName = "cdk-data-availability"
+SampleRatio = 1.0
+
+[Debug]
+Enabled = false
+Host = "127.0.0.1"
+Port = 6060
+
+[Notifier]
+# Webhooks the alerts are posted to, e.g.
+# [[Notifier.Webhooks]]
+# URL = "https://hooks.slack.com/services/..."
+# Kind = "slack" # "generic", "slack" or "pagerduty"
+# RoutingKey = "" # PagerDuty integration key
+Webhooks = []
+Source = ""
+Cooldown = "10m"
+Timeout = "10s"
+CheckInterval = "30s"
+SyncLagThreshold = 100
+MemberFailureThreshold = 5
+
+[Reporter]
+DSN = ""
+Environment = ""
+SampleRate = 1.0
+
+[Diagnostics]
+Enabled = false
+Interval = "5m"
+Timeout = "10s"
+
+[Publisher]
+Interval = "30s"
+BatchSize = 100
+Timeout = "30s"
+
+[Publisher.IPFS]
+Enabled = false
+URL = "http://localhost:5001"
+Token = ""
+
+[Publisher.S3]
+Enabled = false
+Bucket = ""
+Prefix = ""
+Region = ""
+Endpoint = ""
+UsePathStyle = false
+ServerSideEncryption = ""
+KMSKeyID = ""
+StorageClass = ""
+TransitionDays = 0
+TransitionStorageClass = "GLACIER"
+
+[Publisher.Celestia]
+Enabled = false
+URL = "http://localhost:26658"
+AuthToken = ""
+Namespace = ""
+GasPrice = 0.0
+
+[Publisher.EigenDA]
+Enabled = false
+URL = "http://localhost:3100"
+
+[Publisher.Arweave]
+Enabled = false
+URL = "https://upload.ardrive.io/v1/tx"
+PrivateKey = {Path = "", Password = ""}
+
+[Stream]
+Interval = "1s"
+BatchSize = 500
+Timeout = "30s"
+
+[Stream.Kafka]
+Enabled = false
+Brokers = []
+Topic = "cdk-data-availability"
+TLS = false
+Username = ""
+Password = ""
+
+[Stream.NATS]
+Enabled = false
+URL = "nats://localhost:4222"
+Subject = "cdk-data-availability.stored"
+Token = ""
+
+[Webhook]
+# Endpoints the lifecycle events of the data are posted to, e.g.
+# [[Webhook.Endpoints]]
+# URL = "https://example.com/hooks/dac"
+# Secret = "" # key of the HMAC-SHA256 signature of the payloads
+# Events = ["data.stored", "data.pruned", "sequence.signed"] # every event if empty
+Endpoints = []
+Source = ""
+Timeout = "10s"
+MaxAttempts = 5
+RetryWait = "1s"
+QueueSize = 1000
+
+[Gossip]
+Enabled = false
+MaxKeys = 100
+Timeout = "10s"
+CommitteeRefresh = "10m"
+
+[Replay]
+Window = "1m"
+RequireTimestamp = true
+
+[Attestation]
+Enabled = false
+ContractAddress = ""
+Interval = "1h"
+MaxKeys = 10000
+Timeout = "5m"
+
+[Reconcile]
+Enabled = false
+Interval = "10m"
+Grace = "1h"
+BlockRange = 10000
+
+[CrossCheck]
+Enabled = false
+Interval = "1h"
+BlockRange = 10000
+
+[Certificate]
+Coordinator = false
+Interval = "10s"
+BatchSize = 100
+Timeout = "30s"
+
+[Custody]
+Challenger = false
+Interval = "10m"
+Timeout = "10s"
+
+[Sharding]
+Enabled = false
+DataShards = 16
+ParityShards = 16
+Interval = "10s"
+BatchSize = 100
+Timeout = "30s"
+
+[Discovery]
+TTL = "5m"
+Timeout = "10s"
+ENSRegistry = "0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e"
+ENSTextKey = "dac.url"
+
+[GraphQL]
+Enabled = false
+Host = "0.0.0.0"
+Port = 8446
+MaxBatchRange = 100
+MaxResults = 1000
+Timeout = "30s"
+
+[Mirror]
+Enabled = false
+
+[Devnet]
+Enabled = false
+Members = 2
+MembersPort = 8460
+BlockTime = "1s"
+SequenceInterval = "5s"
+BatchesPerSequence = 4
+BatchSize = 1024
+
+[Admin]
+Enabled = false
+Host = "127.0.0.1"
+Port = 8445
+
+[Proxy]
+# SOCKS5 proxy the outbound traffic is routed through, e.g. "socks5://127.0.0.1:9050" for Tor
+URL = ""
+
+[Fault]
+# injects the faults of the [[Fault.Rules]] to test the resilience of the node, never in production
+Enabled = false
+Seed = 0
 `
 
 // Default parses the default configuration values.
 func Default() (*Config, error) {
 	var cfg Config
-	viper.SetConfigType("toml")
+	v := viper.New()
+	v.SetConfigType("toml")
 
-	err := viper.ReadConfig(bytes.NewBuffer([]byte(DefaultValues)))
+	err := v.ReadConfig(bytes.NewBuffer([]byte(DefaultValues)))
 	if err != nil {
 		return nil, err
 	}
-	err = viper.Unmarshal(&cfg, viper.DecodeHook(mapstructure.TextUnmarshallerHookFunc()))
+	err = v.Unmarshal(&cfg, viper.DecodeHook(mapstructure.TextUnmarshallerHookFunc()))
 	if err != nil {
 		return nil, err
 	}
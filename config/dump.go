@@ -0,0 +1,113 @@
+package config
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// redacted replaces the plaintext secrets in the dumped configuration
+const redacted = "<redacted>"
+
+// Dump writes the effective configuration (defaults, config file, environment and flags merged)
+// in the given format. Secret references are printed as they are, but plaintext secrets
+// are redacted.
+func Dump(ctx *cli.Context, w io.Writer, format string) error {
+	cfg, err := load(ctx)
+	if err != nil {
+		return err
+	}
+
+	values, err := toMap(reflect.ValueOf(cfg).Elem())
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(format) {
+	case "", "toml":
+		return toml.NewEncoder(w).Encode(values)
+	case "yaml", "yml":
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2) //nolint:gomnd
+		return enc.Encode(values)
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		return enc.Encode(values)
+	default:
+		return fmt.Errorf("unsupported format %q, use one of %v", format, SupportedFormats)
+	}
+}
+
+// toMap converts a config struct into a map keyed by the config field names
+func toMap(v reflect.Value) (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("mapstructure"); ok && tag != "" {
+			name = tag
+		}
+
+		fv := v.Field(i)
+		switch {
+		case field.Tag.Get(secretTag) == "true":
+			values[name] = redact(fv.String())
+		case fv.CanAddr() && fv.Addr().Type().Implements(reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()):
+			text, err := fv.Addr().Interface().(encoding.TextMarshaler).MarshalText() //nolint:forcetypeassert
+			if err != nil {
+				return nil, err
+			}
+			values[name] = string(text)
+		case field.Type.Kind() == reflect.Struct:
+			nested, err := toMap(fv)
+			if err != nil {
+				return nil, err
+			}
+			values[name] = nested
+		case field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.Struct:
+			list := make([]interface{}, fv.Len())
+			for j := range list {
+				nested, err := toMap(fv.Index(j))
+				if err != nil {
+					return nil, err
+				}
+				list[j] = nested
+			}
+			values[name] = list
+		default:
+			values[name] = fv.Interface()
+		}
+	}
+
+	return values, nil
+}
+
+// redact hides a secret value, unless it is a reference to a secret store
+func redact(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	if scheme, _, found := strings.Cut(value, "://"); found {
+		if _, ok := secretResolvers[scheme]; ok {
+			return value
+		}
+	}
+
+	return redacted
+}
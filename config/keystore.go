@@ -0,0 +1,42 @@
+package config
+
+import (
+	"crypto/ecdsa"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+)
+
+// keystoreScryptN and keystoreScryptP are the scrypt parameters of the keystores written, the standard
+// ones of geth, lowered by the tests
+var keystoreScryptN, keystoreScryptP = keystore.StandardScryptN, keystore.StandardScryptP
+
+// NewKeystore encrypts the given private key with the password, in the keystore format read by
+// NewKeyFromKeystore
+func NewKeystore(pk *ecdsa.PrivateKey, password string) ([]byte, error) {
+	key := &keystore.Key{
+		Id:         uuid.New(),
+		Address:    crypto.PubkeyToAddress(pk.PublicKey),
+		PrivateKey: pk,
+	}
+
+	return keystore.EncryptKey(key, password, keystoreScryptN, keystoreScryptP)
+}
+
+// ReencryptKeystore decrypts a keystore with its password and encrypts its key again with the new
+// password, keeping the ID of the keystore, and returns it along with the address of the key
+func ReencryptKeystore(keystoreJSON []byte, password, newPassword string) ([]byte, common.Address, error) {
+	key, err := keystore.DecryptKey(keystoreJSON, password)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+
+	reencrypted, err := keystore.EncryptKey(key, newPassword, keystoreScryptN, keystoreScryptP)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+
+	return reencrypted, key.Address, nil
+}
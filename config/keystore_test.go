@@ -0,0 +1,42 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/config/types"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeystore(t *testing.T) {
+	keystoreScryptN, keystoreScryptP = keystore.LightScryptN, keystore.LightScryptP
+
+	pk, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	encrypted, err := NewKeystore(pk, "first")
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "member.keystore")
+	require.NoError(t, os.WriteFile(path, encrypted, 0600))
+
+	loaded, err := NewKeyFromKeystore(types.KeystoreFileConfig{Path: path, Password: "first"})
+	require.NoError(t, err)
+	require.Equal(t, pk.D, loaded.D)
+
+	reencrypted, addr, err := ReencryptKeystore(encrypted, "first", "second")
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(pk.PublicKey), addr)
+
+	_, err = keystore.DecryptKey(reencrypted, "first")
+	require.ErrorIs(t, err, keystore.ErrDecrypt)
+	key, err := keystore.DecryptKey(reencrypted, "second")
+	require.NoError(t, err)
+	require.Equal(t, pk.D, key.PrivateKey.D)
+
+	_, _, err = ReencryptKeystore(encrypted, "wrong", "second")
+	require.ErrorIs(t, err, keystore.ErrDecrypt)
+}
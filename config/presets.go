@@ -0,0 +1,56 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+)
+
+// FlagNetwork flag used to select a network preset
+const FlagNetwork = "network"
+
+// presets are the built-in network presets, applied on top of the defaults. The config
+// file, the environment and the flags can still override any of their values.
+// The contract addresses of public networks are specific to each CDK chain, so their
+// presets clear the default (local) addresses instead, forcing them to be set explicitly.
+var presets = map[string]string{
+	"local": `
+[L1]
+ChainID = 1337
+PolygonValidiumAddress = "0x8dAF17A20c9DBA35f005b6324F493785D239719d"
+DataCommitteeAddress = "0x68B1D87F95878fE05B998F19b66F4baba5De1aed"
+GenesisBlock = 0
+`,
+	"cardona": `
+[L1]
+ChainID = 11155111
+PolygonValidiumAddress = ""
+DataCommitteeAddress = ""
+`,
+	"mainnet": `
+[L1]
+ChainID = 1
+PolygonValidiumAddress = ""
+DataCommitteeAddress = ""
+`,
+}
+
+// Presets returns the names of the built-in network presets
+func Presets() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// preset returns the config values of the given network preset
+func preset(network string) (string, error) {
+	values, ok := presets[network]
+	if !ok {
+		return "", fmt.Errorf("unknown network %q, use one of %v", network, Presets())
+	}
+
+	return values, nil
+}
@@ -0,0 +1,228 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	awsConfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+)
+
+// secretTag marks the config fields holding secrets, e.g. `secret:"true"`.
+// Their values can reference an external secret store instead of holding the
+// plaintext secret, and they are never printed.
+const secretTag = "secret"
+
+// SecretResolver resolves a secret reference into the secret value
+type SecretResolver interface {
+	Resolve(ctx context.Context, ref *url.URL) (string, error)
+}
+
+// SecretResolverFunc is an adapter to use ordinary functions as SecretResolver
+type SecretResolverFunc func(ctx context.Context, ref *url.URL) (string, error)
+
+// Resolve calls f(ctx, ref)
+func (f SecretResolverFunc) Resolve(ctx context.Context, ref *url.URL) (string, error) {
+	return f(ctx, ref)
+}
+
+// secretResolvers are the secret resolvers by URI scheme:
+//   - env://NAME reads the environment variable NAME
+//   - file:///path/to/file reads the content of the file
+//   - vault://path/to/secret#field reads a field of a HashiCorp Vault secret,
+//     using VAULT_ADDR and VAULT_TOKEN
+//   - awssm://secret-id#field reads an AWS Secrets Manager secret, or one field
+//     of it when it is stored as JSON, using the default AWS credentials chain
+var secretResolvers = map[string]SecretResolver{
+	"env":   SecretResolverFunc(resolveEnvSecret),
+	"file":  SecretResolverFunc(resolveFileSecret),
+	"vault": SecretResolverFunc(resolveVaultSecret),
+	"awssm": SecretResolverFunc(resolveAWSSecret),
+}
+
+// ResolveSecrets replaces every secret field of the config holding a secret
+// reference (e.g. vault://secret/data/dac#db_password) with the resolved value
+func ResolveSecrets(ctx context.Context, cfg *Config) error {
+	return resolveSecrets(ctx, reflect.ValueOf(cfg).Elem(), "")
+}
+
+func resolveSecrets(ctx context.Context, v reflect.Value, prefix string) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fv := v.Field(i)
+		if field.Type.Kind() == reflect.Struct && !isLeafType(field.Type) {
+			if err := resolveSecrets(ctx, fv, prefix+field.Name+"."); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.Struct {
+			for j := 0; j < fv.Len(); j++ {
+				if err := resolveSecrets(ctx, fv.Index(j), fmt.Sprintf("%s%s.%d.", prefix, field.Name, j)); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if field.Tag.Get(secretTag) != "true" || field.Type.Kind() != reflect.String {
+			continue
+		}
+
+		value, err := resolveSecret(ctx, fv.String())
+		if err != nil {
+			return fmt.Errorf("failed to resolve secret %s%s: %w", prefix, field.Name, err)
+		}
+		fv.SetString(value)
+	}
+
+	return nil
+}
+
+// ResolveSecret returns the secret referenced by the given value, e.g. env://KEYSTORE_PASSWORD, or the
+// value itself when it is not a reference
+func ResolveSecret(ctx context.Context, value string) (string, error) {
+	return resolveSecret(ctx, value)
+}
+
+// resolveSecret returns the secret referenced by the given value, values
+// which are not a reference to a known secret store are returned unchanged
+func resolveSecret(ctx context.Context, value string) (string, error) {
+	scheme, _, found := strings.Cut(value, "://")
+	if !found {
+		return value, nil
+	}
+
+	resolver, ok := secretResolvers[scheme]
+	if !ok {
+		return value, nil
+	}
+
+	ref, err := url.Parse(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid secret reference: %w", err)
+	}
+
+	return resolver.Resolve(ctx, ref)
+}
+
+func resolveEnvSecret(_ context.Context, ref *url.URL) (string, error) {
+	value, ok := os.LookupEnv(ref.Host)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", ref.Host)
+	}
+
+	return value, nil
+}
+
+func resolveFileSecret(_ context.Context, ref *url.URL) (string, error) {
+	content, err := os.ReadFile(filepath.Clean(ref.Host + ref.Path))
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
+
+func resolveVaultSecret(ctx context.Context, ref *url.URL) (string, error) {
+	addr := os.Getenv("VAULT_ADDR")
+	if addr == "" {
+		return "", fmt.Errorf("VAULT_ADDR is not set")
+	}
+
+	if ref.Fragment == "" {
+		return "", fmt.Errorf("vault secret reference needs a #field")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		strings.TrimSuffix(addr, "/")+"/v1/"+ref.Host+ref.Path, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", os.Getenv("VAULT_TOKEN"))
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault responded with status %d", res.StatusCode)
+	}
+
+	var secret struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&secret); err != nil {
+		return "", err
+	}
+
+	// KV version 2 engines nest the secret fields in data.data
+	fields := secret.Data
+	if nested, ok := fields["data"]; ok {
+		if err = json.Unmarshal(nested, &fields); err != nil {
+			return "", err
+		}
+	}
+
+	return jsonField(fields, ref.Fragment)
+}
+
+func resolveAWSSecret(ctx context.Context, ref *url.URL) (string, error) {
+	awsCfg, err := awsConfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	secretID := ref.Host + ref.Path
+	out, err := secretsmanager.NewFromConfig(awsCfg).GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: &secretID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if out.SecretString == nil {
+		return "", fmt.Errorf("secret %s has no string value", secretID)
+	}
+
+	if ref.Fragment == "" {
+		return *out.SecretString, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal([]byte(*out.SecretString), &fields); err != nil {
+		return "", fmt.Errorf("secret %s is not a JSON object: %w", secretID, err)
+	}
+
+	return jsonField(fields, ref.Fragment)
+}
+
+// jsonField returns the string value of the given field
+func jsonField(fields map[string]json.RawMessage, name string) (string, error) {
+	raw, ok := fields[name]
+	if !ok {
+		return "", fmt.Errorf("field %s not found in secret", name)
+	}
+
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return "", fmt.Errorf("field %s is not a string: %w", name, err)
+	}
+
+	return value, nil
+}
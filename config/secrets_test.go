@@ -0,0 +1,127 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/notifier"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ResolveSecret(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "db_password")
+	require.NoError(t, os.WriteFile(secretFile, []byte("file-secret\n"), 0600))
+
+	vault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "vault-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/secret/data/dac":
+			_, _ = w.Write([]byte(`{"data": {"data": {"db_password": "vault-kv2-secret"}}}`))
+		case "/v1/kv/dac":
+			_, _ = w.Write([]byte(`{"data": {"db_password": "vault-kv1-secret"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer vault.Close()
+
+	awssm := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var input struct {
+			SecretId string //nolint:revive,stylecheck
+		}
+		_ = json.NewDecoder(r.Body).Decode(&input)
+
+		switch input.SecretId {
+		case "dac/plain":
+			_, _ = w.Write([]byte(`{"SecretString": "aws-secret"}`))
+		case "dac/json":
+			_, _ = w.Write([]byte(`{"SecretString": "{\"db_password\": \"aws-json-secret\"}"}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type": "ResourceNotFoundException"}`))
+		}
+	}))
+	defer awssm.Close()
+
+	t.Setenv("DB_PASSWORD", "env-secret")
+	t.Setenv("VAULT_ADDR", vault.URL)
+	t.Setenv("VAULT_TOKEN", "vault-token")
+	t.Setenv("AWS_ENDPOINT_URL", awssm.URL)
+	t.Setenv("AWS_REGION", "us-east-1")
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+
+	tests := []struct {
+		name          string
+		value         string
+		expected      string
+		expectedError bool
+	}{
+		{name: "plain value", value: "committee_password", expected: "committee_password"},
+		{name: "unknown scheme", value: "foo://bar", expected: "foo://bar"},
+		{name: "env", value: "env://DB_PASSWORD", expected: "env-secret"},
+		{name: "env not set", value: "env://NOT_SET_DB_PASSWORD", expectedError: true},
+		{name: "file", value: "file://" + secretFile, expected: "file-secret"},
+		{name: "file not found", value: "file:///fictitious-file", expectedError: true},
+		{name: "vault kv2", value: "vault://secret/data/dac#db_password", expected: "vault-kv2-secret"},
+		{name: "vault kv1", value: "vault://kv/dac#db_password", expected: "vault-kv1-secret"},
+		{name: "vault missing field", value: "vault://kv/dac#user", expectedError: true},
+		{name: "vault no field", value: "vault://kv/dac", expectedError: true},
+		{name: "vault not found", value: "vault://kv/other#db_password", expectedError: true},
+		{name: "aws plain", value: "awssm://dac/plain", expected: "aws-secret"},
+		{name: "aws json field", value: "awssm://dac/json#db_password", expected: "aws-json-secret"},
+		{name: "aws not found", value: "awssm://dac/other", expectedError: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := resolveSecret(context.Background(), tt.value)
+			if tt.expectedError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func Test_ResolveSecrets(t *testing.T) {
+	t.Setenv("DB_PASSWORD", "env-secret")
+	t.Setenv("KEYSTORE_PASSWORD", "keystore-secret")
+	t.Setenv("WEBHOOK_URL", "https://hooks.slack.com/services/T000/B000/secret")
+
+	cfg, err := Default()
+	require.NoError(t, err)
+
+	cfg.DB.Password = "env://DB_PASSWORD"
+	cfg.PrivateKey.Password = "env://KEYSTORE_PASSWORD"
+	cfg.Notifier.Webhooks = []notifier.WebhookConfig{{URL: "env://WEBHOOK_URL", Kind: notifier.KindSlack}}
+	// fields not marked as secret are not resolved
+	cfg.DB.User = "env://DB_PASSWORD"
+
+	require.NoError(t, ResolveSecrets(context.Background(), cfg))
+	require.Equal(t, "env-secret", cfg.DB.Password)
+	require.Equal(t, "keystore-secret", cfg.PrivateKey.Password)
+	require.Equal(t, "https://hooks.slack.com/services/T000/B000/secret", cfg.Notifier.Webhooks[0].URL)
+	require.Equal(t, "env://DB_PASSWORD", cfg.DB.User)
+
+	cfg.DB.Password = "env://NOT_SET_DB_PASSWORD"
+	require.ErrorContains(t, ResolveSecrets(context.Background(), cfg), "DB.Password")
+
+	cfg.DB.Password = "plaintext"
+	cfg.Notifier.Webhooks[0].URL = "env://NOT_SET_WEBHOOK_URL"
+	require.ErrorContains(t, ResolveSecrets(context.Background(), cfg), "Notifier.Webhooks.0.URL")
+}
@@ -5,6 +5,7 @@ type KeystoreFileConfig struct {
 	// Path is the file path for the key store file
 	Path string `mapstructure:"Path"`
 
-	// Password is the password to decrypt the key store file
-	Password string `mapstructure:"Password"`
+	// Password is the password to decrypt the key store file, it can also reference a secret,
+	// e.g. env://KEYSTORE_PASSWORD
+	Password string `mapstructure:"Password" secret:"true"`
 }
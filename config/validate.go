@@ -0,0 +1,569 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/notifier"
+	"github.com/0xPolygon/cdk-data-availability/pkg/erasure"
+	"github.com/0xPolygon/cdk-data-availability/publisher/celestia"
+	"github.com/0xPolygon/cdk-data-availability/webhook"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap/zapcore"
+)
+
+// maxGossipKeys is the maximum number of keys the values can be listed of at once
+const maxGossipKeys = 100
+
+// FieldError describes a problem found with the value of a single config field
+type FieldError struct {
+	// Field is the path of the field, e.g. L1.RpcURL
+	Field string
+	// Message explains what is wrong with the value and how to fix it
+	Message string
+}
+
+// Error returns the error message
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// ValidationError contains every problem found while validating the config
+type ValidationError []FieldError
+
+// Error returns all the problems found, one per line
+func (e ValidationError) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Error()
+	}
+
+	return fmt.Sprintf("invalid configuration, %d problem(s) found:\n  %s", len(e), strings.Join(msgs, "\n  "))
+}
+
+// validator accumulates the problems found in the config
+type validator struct {
+	errs ValidationError
+}
+
+func (v *validator) addf(field, format string, args ...interface{}) {
+	v.errs = append(v.errs, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) required(field, value string) bool {
+	if strings.TrimSpace(value) == "" {
+		v.addf(field, "is required")
+		return false
+	}
+
+	return true
+}
+
+func (v *validator) url(field, value string, schemes ...string) {
+	if !v.required(field, value) {
+		return
+	}
+
+	u, err := url.Parse(value)
+	if err != nil {
+		v.addf(field, "is not a valid URL: %v", err)
+		return
+	}
+
+	for _, scheme := range schemes {
+		if u.Scheme == scheme {
+			if u.Host == "" {
+				v.addf(field, "URL %q has no host", value)
+			}
+			return
+		}
+	}
+
+	v.addf(field, "URL %q must use one of the schemes %v", value, schemes)
+}
+
+func (v *validator) address(field, value string) {
+	if !v.required(field, value) {
+		return
+	}
+
+	if !common.IsHexAddress(value) {
+		v.addf(field, "%q is not a valid hex address", value)
+		return
+	}
+
+	// mixed case addresses carry an EIP-55 checksum that must match
+	hex := strings.TrimPrefix(strings.TrimPrefix(value, "0x"), "0X")
+	if hex != strings.ToLower(hex) && hex != strings.ToUpper(hex) {
+		if checksummed := common.HexToAddress(value).Hex(); checksummed != "0x"+hex {
+			v.addf(field, "%q has an invalid checksum, did you mean %s?", value, checksummed)
+		}
+	}
+}
+
+func (v *validator) positive(field string, value float64) {
+	if value <= 0 {
+		v.addf(field, "must be greater than zero")
+	}
+}
+
+func (v *validator) port(field string, port int) {
+	if port <= 0 || port > 65535 {
+		v.addf(field, "%d is not a valid port", port)
+	}
+}
+
+func validLogLevel(lvl string) bool {
+	_, err := zapcore.ParseLevel(lvl)
+	return err == nil
+}
+
+// listener is a network address the node listens on
+type listener struct {
+	field string
+	host  string
+	port  int
+}
+
+// listeners returns all the addresses the node is configured to listen on
+func (c *Config) listeners() []listener {
+	listeners := []listener{
+		{field: "RPC.Port", host: c.RPC.Host, port: c.RPC.Port},
+	}
+	if c.Metrics.Enabled {
+		listeners = append(listeners, listener{field: "Metrics.Port", host: c.Metrics.Host, port: c.Metrics.Port})
+	}
+	if c.Admin.Enabled {
+		listeners = append(listeners, listener{field: "Admin.Port", host: c.Admin.Host, port: c.Admin.Port})
+	}
+	if c.Debug.Enabled {
+		listeners = append(listeners, listener{field: "Debug.Port", host: c.Debug.Host, port: c.Debug.Port})
+	}
+	if c.GraphQL.Enabled {
+		listeners = append(listeners, listener{field: "GraphQL.Port", host: c.GraphQL.Host, port: c.GraphQL.Port})
+	}
+	if c.Devnet.Enabled {
+		for i := 0; i < c.Devnet.Members; i++ {
+			listeners = append(listeners, listener{field: "Devnet.MembersPort", host: "127.0.0.1",
+				port: c.Devnet.MembersPort + i})
+		}
+	}
+
+	return listeners
+}
+
+// Validate checks the whole config and reports all the problems found at once,
+// so that they can be fixed before the node starts instead of failing later on
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	// a mirror does not sign, so it holds no private key
+	if c.Mirror.Enabled {
+		if c.Gossip.Enabled {
+			v.addf("Gossip.Enabled", "a mirror can not announce the values, it holds no private key")
+		}
+		if c.Attestation.Enabled {
+			v.addf("Attestation.Enabled", "a mirror can not attest the keys, it holds no private key")
+		}
+		if c.Reconcile.Enabled {
+			v.addf("Reconcile.Enabled", "a mirror signs no sequence to reconcile")
+		}
+		if c.Certificate.Coordinator {
+			v.addf("Certificate.Coordinator", "a mirror can not sign the certificates, it holds no private key")
+		}
+		if c.Custody.Challenger {
+			v.addf("Custody.Challenger", "a mirror is not a committee member to challenge the others")
+		}
+		if c.Sharding.Enabled {
+			v.addf("Sharding.Enabled", "a mirror is not a committee member to be assigned shards")
+		}
+	} else if !c.Devnet.Enabled {
+		v.required("PrivateKey.Path", c.PrivateKey.Path)
+	}
+
+	// Devnet, the key is generated and the L1 simulated, which starts again from its genesis on restart
+	if c.Devnet.Enabled {
+		if c.Mirror.Enabled {
+			v.addf("Mirror.Enabled", "the devnet node is a member of the simulated committee, not a mirror")
+		}
+		if !c.DB.Memory {
+			v.addf("DB.Memory", "the devnet state must be kept in memory, the simulated L1 restarts from its genesis")
+		}
+		v.positive("Devnet.Members", float64(c.Devnet.Members))
+		v.positive("Devnet.BlockTime", c.Devnet.BlockTime.Seconds())
+		v.positive("Devnet.SequenceInterval", c.Devnet.SequenceInterval.Seconds())
+		v.positive("Devnet.BatchesPerSequence", float64(c.Devnet.BatchesPerSequence))
+		v.positive("Devnet.BatchSize", float64(c.Devnet.BatchSize))
+		if uint64(c.Devnet.BatchSize) > c.Limits.MaxValueSize {
+			v.addf("Devnet.BatchSize", "%d is more than Limits.MaxValueSize (%d)",
+				c.Devnet.BatchSize, c.Limits.MaxValueSize)
+		}
+	}
+
+	// L1, only the addresses of the contracts are used by the devnet
+	if !c.Devnet.Enabled {
+		v.url("L1.RpcURL", c.L1.RpcURL, "http", "https", "ws", "wss")
+	}
+	v.address("L1.PolygonValidiumAddress", c.L1.PolygonValidiumAddress)
+	v.address("L1.DataCommitteeAddress", c.L1.DataCommitteeAddress)
+	v.positive("L1.Timeout", c.L1.Timeout.Seconds())
+	if c.L1.BeaconURL != "" {
+		v.url("L1.BeaconURL", c.L1.BeaconURL, "http", "https")
+	}
+	v.positive("L1.RetryPeriod", c.L1.RetryPeriod.Seconds())
+	if c.L1.TrackSequencerPollInterval.Duration < 0 {
+		v.addf("L1.TrackSequencerPollInterval", "must not be negative")
+	}
+	if c.L1.RetryPeriod.Duration > c.L1.Timeout.Duration && c.L1.Timeout.Duration > 0 {
+		v.addf("L1.RetryPeriod", "(%v) should not be longer than L1.Timeout (%v)",
+			c.L1.RetryPeriod.Duration, c.L1.Timeout.Duration)
+	}
+
+	// Proxy
+	if c.Proxy.URL != "" {
+		v.url("Proxy.URL", c.Proxy.URL, "socks5", "socks5h")
+	}
+
+	// Log
+	if !validLogLevel(c.Log.Level) {
+		v.addf("Log.Level", "%q is not a valid level, use one of debug, info, warn, error, dpanic, panic, fatal",
+			c.Log.Level)
+	}
+	components := make([]string, 0, len(c.Log.Levels))
+	for component := range c.Log.Levels {
+		components = append(components, component)
+	}
+	sort.Strings(components)
+	for _, component := range components {
+		if lvl := c.Log.Levels[component]; !validLogLevel(lvl) {
+			v.addf("Log.Levels."+component, "%q is not a valid level", lvl)
+		}
+	}
+	if c.Log.Environment != log.EnvironmentProduction && c.Log.Environment != log.EnvironmentDevelopment {
+		v.addf("Log.Environment", "%q is not valid, use %q or %q",
+			c.Log.Environment, log.EnvironmentProduction, log.EnvironmentDevelopment)
+	}
+	if c.Log.Format != "" && c.Log.Format != log.FormatConsole && c.Log.Format != log.FormatJSON {
+		v.addf("Log.Format", "%q is not valid, use %q or %q", c.Log.Format, log.FormatConsole, log.FormatJSON)
+	}
+	if len(c.Log.Outputs) == 0 {
+		v.addf("Log.Outputs", "at least one output is required, e.g. stderr")
+	}
+
+	// DB, not connected to when the state is kept in memory
+	if !c.DB.Memory {
+		v.required("DB.Host", c.DB.Host)
+		v.required("DB.Name", c.DB.Name)
+		v.required("DB.User", c.DB.User)
+		if v.required("DB.Port", c.DB.Port) {
+			var port int
+			if _, err := fmt.Sscanf(c.DB.Port, "%d", &port); err != nil {
+				v.addf("DB.Port", "%q is not a valid port", c.DB.Port)
+			} else {
+				v.port("DB.Port", port)
+			}
+		}
+		v.positive("DB.MaxConns", float64(c.DB.MaxConns))
+		v.positive("DB.BackfillBatchSize", float64(c.DB.BackfillBatchSize))
+	}
+
+	// RPC
+	v.positive("RPC.ReadTimeout", c.RPC.ReadTimeout.Seconds())
+	v.positive("RPC.WriteTimeout", c.RPC.WriteTimeout.Seconds())
+	v.positive("RPC.MaxRequestsPerIPAndSecond", c.RPC.MaxRequestsPerIPAndSecond)
+	if c.RPC.ReadHeaderTimeout.Duration < 0 {
+		v.addf("RPC.ReadHeaderTimeout", "must not be negative")
+	}
+	if c.RPC.IdleTimeout.Duration < 0 {
+		v.addf("RPC.IdleTimeout", "must not be negative")
+	}
+	if c.RPC.MaxConns < 0 {
+		v.addf("RPC.MaxConns", "must not be negative")
+	}
+	if c.RPC.MaxConnsPerIP < 0 {
+		v.addf("RPC.MaxConnsPerIP", "must not be negative")
+	}
+	if c.RPC.MaxConcurrentRequestsPerIP < 0 {
+		v.addf("RPC.MaxConcurrentRequestsPerIP", "must not be negative")
+	}
+	if c.RPC.MaxConcurrentRequests < 0 {
+		v.addf("RPC.MaxConcurrentRequests", "must not be negative")
+	}
+	if c.RPC.Overload.MaxInFlight < 0 {
+		v.addf("RPC.Overload.MaxInFlight", "must not be negative")
+	}
+	if c.RPC.Overload.MaxDBConnsInUse < 0 {
+		v.addf("RPC.Overload.MaxDBConnsInUse", "must not be negative")
+	}
+	v.positive("RPC.Overload.RetryAfter", c.RPC.Overload.RetryAfter.Seconds())
+
+	// Limits
+	v.positive("Limits.MaxValueSize", float64(c.Limits.MaxValueSize))
+	v.positive("Limits.MaxSequenceSize", float64(c.Limits.MaxSequenceSize))
+	if c.Limits.MaxValueSize > c.Limits.MaxSequenceSize {
+		v.addf("Limits.MaxValueSize", "must not exceed Limits.MaxSequenceSize")
+	}
+	// the data is hex encoded in the JSON-RPC messages, doubling its size
+	if c.RPC.MaxMessageSize > 0 && c.RPC.MaxMessageSize < 2*c.Limits.MaxSequenceSize {
+		v.addf("RPC.MaxMessageSize", "must be at least twice Limits.MaxSequenceSize for a sequence to fit")
+	}
+
+	// Durability
+	switch c.Durability.Level {
+	case DurabilityCommitted, DurabilityFlushed:
+	case DurabilityReplicated:
+		if c.DB.Memory {
+			v.addf("Durability.Level", "replicated requires the state to be stored in the database, not in memory")
+		}
+	case DurabilityArchived:
+		if !c.Publisher.Enabled() {
+			v.addf("Durability.Level", "archived requires a publisher backend to be enabled")
+		}
+	default:
+		v.addf("Durability.Level", "%q is not valid, use committed, flushed, replicated or archived",
+			c.Durability.Level)
+	}
+
+	// Timeouts
+	v.positive("Timeouts.DBOperation", c.Timeouts.DBOperation.Seconds())
+	v.positive("Timeouts.StartBlockSearch", c.Timeouts.StartBlockSearch.Seconds())
+	v.positive("Timeouts.CommitteeRPC", c.Timeouts.CommitteeRPC.Seconds())
+	v.positive("Timeouts.ReorgPollInterval", c.Timeouts.ReorgPollInterval.Seconds())
+	v.positive("Timeouts.L1SubscriptionRetries", float64(c.Timeouts.L1SubscriptionRetries))
+	v.positive("Timeouts.MaxBackoff", c.Timeouts.MaxBackoff.Seconds())
+
+	// Tracing
+	if c.Tracing.Enabled {
+		v.required("Tracing.Endpoint", c.Tracing.Endpoint)
+		v.required("Tracing.ServiceName", c.Tracing.ServiceName)
+		if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+			v.addf("Tracing.SampleRatio", "%v must be between 0 and 1", c.Tracing.SampleRatio)
+		}
+	}
+
+	// Notifier
+	for i, webhook := range c.Notifier.Webhooks {
+		field := fmt.Sprintf("Notifier.Webhooks.%d", i)
+		v.url(field+".URL", webhook.URL, "http", "https")
+		switch webhook.Kind {
+		case notifier.KindGeneric, notifier.KindSlack:
+		case notifier.KindPagerDuty:
+			v.required(field+".RoutingKey", webhook.RoutingKey)
+		default:
+			v.addf(field+".Kind", "%q is not valid, use one of %s, %s or %s",
+				webhook.Kind, notifier.KindGeneric, notifier.KindSlack, notifier.KindPagerDuty)
+		}
+	}
+	if len(c.Notifier.Webhooks) > 0 {
+		v.positive("Notifier.Timeout", c.Notifier.Timeout.Seconds())
+		v.positive("Notifier.CheckInterval", c.Notifier.CheckInterval.Seconds())
+		v.positive("Notifier.SyncLagThreshold", float64(c.Notifier.SyncLagThreshold))
+		v.positive("Notifier.MemberFailureThreshold", float64(c.Notifier.MemberFailureThreshold))
+	}
+
+	// Webhook
+	for i, endpoint := range c.Webhook.Endpoints {
+		field := fmt.Sprintf("Webhook.Endpoints.%d", i)
+		v.url(field+".URL", endpoint.URL, "http", "https")
+		for _, event := range endpoint.Events {
+			valid := false
+			for _, eventType := range webhook.EventTypes {
+				valid = valid || event == eventType
+			}
+			if !valid {
+				v.addf(field+".Events", "%q is not valid, use %s", event, strings.Join(webhook.EventTypes, ", "))
+			}
+		}
+	}
+	if len(c.Webhook.Endpoints) > 0 {
+		v.positive("Webhook.Timeout", c.Webhook.Timeout.Seconds())
+		v.positive("Webhook.MaxAttempts", float64(c.Webhook.MaxAttempts))
+		v.positive("Webhook.RetryWait", c.Webhook.RetryWait.Seconds())
+		v.positive("Webhook.QueueSize", float64(c.Webhook.QueueSize))
+	}
+
+	// Fault
+	if c.Fault.Enabled {
+		for i, rule := range c.Fault.Rules {
+			field := fmt.Sprintf("Fault.Rules.%d", i)
+			v.required(field+".Point", rule.Point)
+			if rule.Latency.Duration < 0 {
+				v.addf(field+".Latency", "%v must not be negative", rule.Latency.Duration)
+			}
+			if rule.ErrorRate < 0 || rule.ErrorRate > 1 {
+				v.addf(field+".ErrorRate", "%v must be between 0 and 1", rule.ErrorRate)
+			}
+			if rule.DropRate < 0 || rule.DropRate > 1 {
+				v.addf(field+".DropRate", "%v must be between 0 and 1", rule.DropRate)
+			}
+		}
+	}
+
+	// Reporter
+	if c.Reporter.DSN != "" {
+		v.url("Reporter.DSN", c.Reporter.DSN, "http", "https")
+		if c.Reporter.SampleRate < 0 || c.Reporter.SampleRate > 1 {
+			v.addf("Reporter.SampleRate", "%v must be between 0 and 1", c.Reporter.SampleRate)
+		}
+	}
+
+	// Diagnostics
+	if c.Diagnostics.Enabled {
+		v.positive("Diagnostics.Interval", c.Diagnostics.Interval.Seconds())
+		v.positive("Diagnostics.Timeout", c.Diagnostics.Timeout.Seconds())
+	}
+
+	// Publisher
+	if c.Publisher.IPFS.Enabled {
+		v.url("Publisher.IPFS.URL", c.Publisher.IPFS.URL, "http", "https")
+	}
+	if c.Publisher.S3.Enabled {
+		v.required("Publisher.S3.Bucket", c.Publisher.S3.Bucket)
+		if c.Publisher.S3.Endpoint != "" {
+			v.url("Publisher.S3.Endpoint", c.Publisher.S3.Endpoint, "http", "https")
+		}
+		switch c.Publisher.S3.ServerSideEncryption {
+		case "", "AES256", "aws:kms":
+		default:
+			v.addf("Publisher.S3.ServerSideEncryption", "%q is not valid, use AES256 or aws:kms",
+				c.Publisher.S3.ServerSideEncryption)
+		}
+		if c.Publisher.S3.TransitionDays > 0 {
+			v.required("Publisher.S3.TransitionStorageClass", c.Publisher.S3.TransitionStorageClass)
+		}
+	}
+	if c.Publisher.Celestia.Enabled {
+		v.url("Publisher.Celestia.URL", c.Publisher.Celestia.URL, "http", "https")
+		if _, err := celestia.Namespace(c.Publisher.Celestia.Namespace); err != nil {
+			v.addf("Publisher.Celestia.Namespace", "%v", err)
+		}
+		if c.Publisher.Celestia.GasPrice < 0 {
+			v.addf("Publisher.Celestia.GasPrice", "must not be negative")
+		}
+	}
+	if c.Publisher.EigenDA.Enabled {
+		v.url("Publisher.EigenDA.URL", c.Publisher.EigenDA.URL, "http", "https")
+	}
+	if c.Publisher.Arweave.Enabled {
+		v.url("Publisher.Arweave.URL", c.Publisher.Arweave.URL, "http", "https")
+		v.required("Publisher.Arweave.PrivateKey.Path", c.Publisher.Arweave.PrivateKey.Path)
+	}
+	if c.Publisher.Enabled() {
+		v.positive("Publisher.Interval", c.Publisher.Interval.Seconds())
+		v.positive("Publisher.BatchSize", float64(c.Publisher.BatchSize))
+		v.positive("Publisher.Timeout", c.Publisher.Timeout.Seconds())
+	}
+
+	// Stream
+	if c.Stream.Kafka.Enabled {
+		if len(c.Stream.Kafka.Brokers) == 0 {
+			v.addf("Stream.Kafka.Brokers", "at least one broker is required")
+		}
+		v.required("Stream.Kafka.Topic", c.Stream.Kafka.Topic)
+	}
+	if c.Stream.NATS.Enabled {
+		v.url("Stream.NATS.URL", c.Stream.NATS.URL, "nats", "tls")
+		v.required("Stream.NATS.Subject", c.Stream.NATS.Subject)
+		if c.Stream.Kafka.Enabled {
+			v.addf("Stream.NATS.Enabled", "the events are streamed to one broker, Stream.Kafka is enabled")
+		}
+	}
+	if c.Stream.Enabled() {
+		v.positive("Stream.Interval", c.Stream.Interval.Seconds())
+		v.positive("Stream.BatchSize", float64(c.Stream.BatchSize))
+		v.positive("Stream.Timeout", c.Stream.Timeout.Seconds())
+	}
+
+	// Gossip
+	if c.Gossip.Enabled {
+		v.positive("Gossip.MaxKeys", float64(c.Gossip.MaxKeys))
+		if c.Gossip.MaxKeys > maxGossipKeys {
+			v.addf("Gossip.MaxKeys", "%d is more than %d, the most keys sync_listOffChainData returns at once",
+				c.Gossip.MaxKeys, maxGossipKeys)
+		}
+		v.positive("Gossip.Timeout", c.Gossip.Timeout.Seconds())
+		v.positive("Gossip.CommitteeRefresh", c.Gossip.CommitteeRefresh.Seconds())
+	}
+
+	// Attestation
+	if c.Attestation.Enabled {
+		v.address("Attestation.ContractAddress", c.Attestation.ContractAddress)
+		v.positive("Attestation.Interval", c.Attestation.Interval.Seconds())
+		v.positive("Attestation.MaxKeys", float64(c.Attestation.MaxKeys))
+		v.positive("Attestation.Timeout", c.Attestation.Timeout.Seconds())
+	}
+
+	// Replay
+	v.positive("Replay.Window", c.Replay.Window.Seconds())
+
+	// Reconcile
+	if c.Reconcile.Enabled {
+		v.positive("Reconcile.Interval", c.Reconcile.Interval.Seconds())
+		v.positive("Reconcile.Grace", c.Reconcile.Grace.Seconds())
+		v.positive("Reconcile.BlockRange", float64(c.Reconcile.BlockRange))
+	}
+
+	// CrossCheck
+	if c.CrossCheck.Enabled {
+		v.positive("CrossCheck.Interval", c.CrossCheck.Interval.Seconds())
+		v.positive("CrossCheck.BlockRange", float64(c.CrossCheck.BlockRange))
+	}
+
+	// Certificate
+	if c.Certificate.Coordinator {
+		v.positive("Certificate.Interval", c.Certificate.Interval.Seconds())
+		v.positive("Certificate.BatchSize", float64(c.Certificate.BatchSize))
+		v.positive("Certificate.Timeout", c.Certificate.Timeout.Seconds())
+	}
+
+	// Custody
+	if c.Custody.Challenger {
+		v.positive("Custody.Interval", c.Custody.Interval.Seconds())
+		v.positive("Custody.Timeout", c.Custody.Timeout.Seconds())
+	}
+
+	// Sharding
+	if c.Sharding.Enabled {
+		v.positive("Sharding.DataShards", float64(c.Sharding.DataShards))
+		v.positive("Sharding.ParityShards", float64(c.Sharding.ParityShards))
+		if c.Sharding.DataShards+c.Sharding.ParityShards > erasure.MaxShards {
+			v.addf("Sharding.ParityShards", "at most %d data and parity shards are supported", erasure.MaxShards)
+		}
+		v.positive("Sharding.Interval", c.Sharding.Interval.Seconds())
+		v.positive("Sharding.BatchSize", float64(c.Sharding.BatchSize))
+		v.positive("Sharding.Timeout", c.Sharding.Timeout.Seconds())
+	}
+
+	// Discovery
+	v.positive("Discovery.TTL", c.Discovery.TTL.Seconds())
+	v.positive("Discovery.Timeout", c.Discovery.Timeout.Seconds())
+	v.address("Discovery.ENSRegistry", c.Discovery.ENSRegistry)
+	v.required("Discovery.ENSTextKey", c.Discovery.ENSTextKey)
+
+	// GraphQL
+	if c.GraphQL.Enabled {
+		v.positive("GraphQL.MaxBatchRange", float64(c.GraphQL.MaxBatchRange))
+		v.positive("GraphQL.MaxResults", float64(c.GraphQL.MaxResults))
+		v.positive("GraphQL.Timeout", c.GraphQL.Timeout.Seconds())
+	}
+
+	// Listeners
+	ports := make(map[int]string)
+	for _, l := range c.listeners() {
+		v.port(l.field, l.port)
+		if other, ok := ports[l.port]; ok {
+			v.addf(l.field, "port %d is already used by %s", l.port, other)
+			continue
+		}
+		ports[l.port] = l.field
+	}
+
+	if len(v.errs) > 0 {
+		return v.errs
+	}
+
+	return nil
+}
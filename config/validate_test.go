@@ -0,0 +1,471 @@
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/config/types"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/fault"
+	"github.com/0xPolygon/cdk-data-availability/notifier"
+	"github.com/0xPolygon/cdk-data-availability/webhook"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Validate(t *testing.T) {
+	tests := []struct {
+		name           string
+		modify         func(cfg *Config)
+		expectedFields []string
+	}{
+		{
+			name:   "defaults are valid",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name: "lowercase address without checksum is valid",
+			modify: func(cfg *Config) {
+				cfg.L1.PolygonValidiumAddress = "0x8daf17a20c9dba35f005b6324f493785d239719d"
+			},
+		},
+		{
+			name: "missing required fields",
+			modify: func(cfg *Config) {
+				cfg.PrivateKey.Path = ""
+				cfg.L1.RpcURL = ""
+				cfg.DB.Host = ""
+			},
+			expectedFields: []string{"PrivateKey.Path", "L1.RpcURL", "DB.Host"},
+		},
+		{
+			name: "invalid urls",
+			modify: func(cfg *Config) {
+				cfg.L1.RpcURL = "tcp://localhost:8545"
+			},
+			expectedFields: []string{"L1.RpcURL"},
+		},
+		{
+			name: "invalid addresses",
+			modify: func(cfg *Config) {
+				cfg.L1.PolygonValidiumAddress = "0xDEADBEEF"
+				// last character case flipped
+				cfg.L1.DataCommitteeAddress = "0x68B1D87F95878fE05B998F19b66F4baba5De1aeD"
+			},
+			expectedFields: []string{"L1.PolygonValidiumAddress", "L1.DataCommitteeAddress"},
+		},
+		{
+			name: "invalid timeouts",
+			modify: func(cfg *Config) {
+				cfg.L1.Timeout = types.NewDuration(0)
+				cfg.RPC.ReadTimeout = types.NewDuration(-1)
+			},
+			expectedFields: []string{"L1.Timeout", "RPC.ReadTimeout"},
+		},
+		{
+			name: "retry period longer than timeout",
+			modify: func(cfg *Config) {
+				cfg.L1.RetryPeriod = types.NewDuration(cfg.L1.Timeout.Duration * 2)
+			},
+			expectedFields: []string{"L1.RetryPeriod"},
+		},
+		{
+			name: "invalid log settings",
+			modify: func(cfg *Config) {
+				cfg.Log.Level = "verbose"
+				cfg.Log.Environment = "staging"
+				cfg.Log.Format = "logfmt"
+				cfg.Log.Outputs = nil
+				cfg.Log.Levels = map[string]string{"synchronizer": "debug", "db": "quiet"}
+			},
+			expectedFields: []string{"Log.Level", "Log.Environment", "Log.Format", "Log.Outputs", "Log.Levels.db"},
+		},
+		{
+			name: "invalid ports",
+			modify: func(cfg *Config) {
+				cfg.DB.Port = "postgres"
+				cfg.RPC.Port = 70000
+			},
+			expectedFields: []string{"DB.Port", "RPC.Port"},
+		},
+		{
+			name: "invalid timeouts section",
+			modify: func(cfg *Config) {
+				cfg.Timeouts.DBOperation = types.NewDuration(0)
+				cfg.Timeouts.L1SubscriptionRetries = 0
+			},
+			expectedFields: []string{"Timeouts.DBOperation", "Timeouts.L1SubscriptionRetries"},
+		},
+		{
+			name: "admin port used by the rpc",
+			modify: func(cfg *Config) {
+				cfg.Admin.Enabled = true
+				cfg.Admin.Port = cfg.RPC.Port
+			},
+			expectedFields: []string{"Admin.Port"},
+		},
+		{
+			name: "metrics port used by the admin api",
+			modify: func(cfg *Config) {
+				cfg.Metrics.Enabled = true
+				cfg.Admin.Enabled = true
+				cfg.Admin.Port = cfg.Metrics.Port
+			},
+			expectedFields: []string{"Admin.Port"},
+		},
+		{
+			name: "invalid tracing settings",
+			modify: func(cfg *Config) {
+				cfg.Tracing.Enabled = true
+				cfg.Tracing.Endpoint = ""
+				cfg.Tracing.SampleRatio = 1.5
+			},
+			expectedFields: []string{"Tracing.Endpoint", "Tracing.SampleRatio"},
+		},
+		{
+			name: "invalid fault rules",
+			modify: func(cfg *Config) {
+				cfg.Fault.Enabled = true
+				cfg.Fault.Rules = []fault.Rule{
+					{Point: "db.*", ErrorRate: 0.5},
+					{ErrorRate: 1.5, DropRate: -1},
+				}
+			},
+			expectedFields: []string{"Fault.Rules.1.Point", "Fault.Rules.1.ErrorRate", "Fault.Rules.1.DropRate"},
+		},
+		{
+			name: "debug port used by the rpc",
+			modify: func(cfg *Config) {
+				cfg.Debug.Enabled = true
+				cfg.Debug.Port = cfg.RPC.Port
+			},
+			expectedFields: []string{"Debug.Port"},
+		},
+		{
+			name: "invalid webhooks",
+			modify: func(cfg *Config) {
+				cfg.Notifier.Webhooks = []notifier.WebhookConfig{
+					{URL: "https://hooks.slack.com/services/T000/B000/XXX", Kind: notifier.KindSlack},
+					{URL: "https://events.pagerduty.com/v2/enqueue", Kind: notifier.KindPagerDuty},
+					{URL: "ftp://alerts", Kind: "email"},
+				}
+				cfg.Notifier.SyncLagThreshold = 0
+			},
+			expectedFields: []string{
+				"Notifier.Webhooks.1.RoutingKey",
+				"Notifier.Webhooks.2.URL",
+				"Notifier.Webhooks.2.Kind",
+				"Notifier.SyncLagThreshold",
+			},
+		},
+		{
+			name: "invalid error reporting settings",
+			modify: func(cfg *Config) {
+				cfg.Reporter.DSN = "sentry.io/123"
+				cfg.Reporter.SampleRate = -1
+			},
+			expectedFields: []string{"Reporter.DSN", "Reporter.SampleRate"},
+		},
+		{
+			name: "invalid diagnostics interval",
+			modify: func(cfg *Config) {
+				cfg.Diagnostics.Enabled = true
+				cfg.Diagnostics.Interval = types.NewDuration(0)
+			},
+			expectedFields: []string{"Diagnostics.Interval"},
+		},
+		{
+			name: "invalid ipfs publisher",
+			modify: func(cfg *Config) {
+				cfg.Publisher.IPFS.Enabled = true
+				cfg.Publisher.IPFS.URL = "localhost:5001"
+				cfg.Publisher.BatchSize = 0
+			},
+			expectedFields: []string{"Publisher.IPFS.URL", "Publisher.BatchSize"},
+		},
+		{
+			name: "invalid s3 publisher",
+			modify: func(cfg *Config) {
+				cfg.Publisher.S3.Enabled = true
+				cfg.Publisher.S3.ServerSideEncryption = "none"
+			},
+			expectedFields: []string{"Publisher.S3.Bucket", "Publisher.S3.ServerSideEncryption"},
+		},
+		{
+			name: "invalid celestia publisher",
+			modify: func(cfg *Config) {
+				cfg.Publisher.Celestia.Enabled = true
+				cfg.Publisher.Celestia.Namespace = "0x0102030405060708090a0b"
+			},
+			expectedFields: []string{"Publisher.Celestia.Namespace"},
+		},
+		{
+			name: "arweave publisher without key",
+			modify: func(cfg *Config) {
+				cfg.Publisher.Arweave.Enabled = true
+			},
+			expectedFields: []string{"Publisher.Arweave.PrivateKey.Path"},
+		},
+		{
+			name: "invalid kafka stream",
+			modify: func(cfg *Config) {
+				cfg.Stream.Kafka.Enabled = true
+				cfg.Stream.Kafka.Topic = ""
+			},
+			expectedFields: []string{"Stream.Kafka.Brokers", "Stream.Kafka.Topic"},
+		},
+		{
+			name: "both stream brokers",
+			modify: func(cfg *Config) {
+				cfg.Stream.Kafka.Enabled = true
+				cfg.Stream.Kafka.Brokers = []string{"localhost:9092"}
+				cfg.Stream.NATS.Enabled = true
+			},
+			expectedFields: []string{"Stream.NATS.Enabled"},
+		},
+		{
+			name: "invalid webhook endpoint",
+			modify: func(cfg *Config) {
+				cfg.Webhook.Endpoints = []webhook.EndpointConfig{{URL: "ftp://example.com", Events: []string{"data.lost"}}}
+			},
+			expectedFields: []string{"Webhook.Endpoints.0.URL", "Webhook.Endpoints.0.Events"},
+		},
+		{
+			name: "invalid gossip",
+			modify: func(cfg *Config) {
+				cfg.Gossip.Enabled = true
+				cfg.Gossip.MaxKeys = 0
+			},
+			expectedFields: []string{"Gossip.MaxKeys"},
+		},
+		{
+			name: "invalid attestation",
+			modify: func(cfg *Config) {
+				cfg.Attestation.Enabled = true
+				cfg.Attestation.Interval = types.NewDuration(0)
+			},
+			expectedFields: []string{"Attestation.ContractAddress", "Attestation.Interval"},
+		},
+		{
+			name: "invalid limits",
+			modify: func(cfg *Config) {
+				cfg.Limits.MaxValueSize = cfg.Limits.MaxSequenceSize + 1
+				cfg.RPC.MaxMessageSize = cfg.Limits.MaxSequenceSize
+			},
+			expectedFields: []string{"Limits.MaxValueSize", "RPC.MaxMessageSize"},
+		},
+		{
+			name: "unknown durability level",
+			modify: func(cfg *Config) {
+				cfg.Durability.Level = "fsynced"
+			},
+			expectedFields: []string{"Durability.Level"},
+		},
+		{
+			name: "archived without a publisher",
+			modify: func(cfg *Config) {
+				cfg.Durability.Level = DurabilityArchived
+			},
+			expectedFields: []string{"Durability.Level"},
+		},
+		{
+			name: "negative rpc throttling",
+			modify: func(cfg *Config) {
+				cfg.RPC.IdleTimeout = types.NewDuration(-1)
+				cfg.RPC.MaxConnsPerIP = -1
+			},
+			expectedFields: []string{"RPC.IdleTimeout", "RPC.MaxConnsPerIP"},
+		},
+		{
+			name: "invalid overload limits",
+			modify: func(cfg *Config) {
+				cfg.RPC.MaxConcurrentRequests = -1
+				cfg.RPC.Overload.MaxInFlight = -1
+				cfg.RPC.Overload.MaxDBConnsInUse = -1
+				cfg.RPC.Overload.RetryAfter = types.NewDuration(0)
+			},
+			expectedFields: []string{
+				"RPC.MaxConcurrentRequests", "RPC.Overload.MaxInFlight", "RPC.Overload.MaxDBConnsInUse",
+				"RPC.Overload.RetryAfter",
+			},
+		},
+		{
+			name: "no backfill batch",
+			modify: func(cfg *Config) {
+				cfg.DB.BackfillBatchSize = 0
+			},
+			expectedFields: []string{"DB.BackfillBatchSize"},
+		},
+		{
+			name: "memory without database",
+			modify: func(cfg *Config) {
+				cfg.DB = db.Config{Memory: true}
+			},
+		},
+		{
+			name: "replicated memory",
+			modify: func(cfg *Config) {
+				cfg.DB.Memory = true
+				cfg.Durability.Level = DurabilityReplicated
+			},
+			expectedFields: []string{"Durability.Level"},
+		},
+		{
+			name: "invalid replay window",
+			modify: func(cfg *Config) {
+				cfg.Replay.Window = types.NewDuration(0)
+			},
+			expectedFields: []string{"Replay.Window"},
+		},
+		{
+			name: "invalid reconciliation",
+			modify: func(cfg *Config) {
+				cfg.Reconcile.Enabled = true
+				cfg.Reconcile.Grace = types.NewDuration(0)
+				cfg.Reconcile.BlockRange = 0
+			},
+			expectedFields: []string{"Reconcile.BlockRange", "Reconcile.Grace"},
+		},
+		{
+			name: "invalid cross-check",
+			modify: func(cfg *Config) {
+				cfg.CrossCheck.Enabled = true
+				cfg.CrossCheck.Interval = types.NewDuration(0)
+			},
+			expectedFields: []string{"CrossCheck.Interval"},
+		},
+		{
+			name: "invalid custody challenger",
+			modify: func(cfg *Config) {
+				cfg.Custody.Challenger = true
+				cfg.Custody.Timeout = types.NewDuration(0)
+			},
+			expectedFields: []string{"Custody.Timeout"},
+		},
+		{
+			name: "invalid certificate coordinator",
+			modify: func(cfg *Config) {
+				cfg.Certificate.Coordinator = true
+				cfg.Certificate.BatchSize = 0
+			},
+			expectedFields: []string{"Certificate.BatchSize"},
+		},
+		{
+			name: "too many shards",
+			modify: func(cfg *Config) {
+				cfg.Sharding.Enabled = true
+				cfg.Sharding.DataShards = 200
+				cfg.Sharding.ParityShards = 100
+			},
+			expectedFields: []string{"Sharding.ParityShards"},
+		},
+		{
+			name: "invalid beacon url",
+			modify: func(cfg *Config) {
+				cfg.L1.BeaconURL = "ws://beacon:5052"
+			},
+			expectedFields: []string{"L1.BeaconURL"},
+		},
+		{
+			name: "invalid discovery",
+			modify: func(cfg *Config) {
+				cfg.Discovery.TTL = types.NewDuration(0)
+				cfg.Discovery.ENSRegistry = "registry.eth"
+			},
+			expectedFields: []string{"Discovery.TTL", "Discovery.ENSRegistry"},
+		},
+		{
+			name: "invalid proxy url",
+			modify: func(cfg *Config) {
+				cfg.Proxy.URL = "http://127.0.0.1:9050"
+			},
+			expectedFields: []string{"Proxy.URL"},
+		},
+		{
+			name: "invalid graphql endpoint",
+			modify: func(cfg *Config) {
+				cfg.GraphQL.Enabled = true
+				cfg.GraphQL.Port = cfg.RPC.Port
+				cfg.GraphQL.MaxResults = 0
+			},
+			expectedFields: []string{"GraphQL.MaxResults", "GraphQL.Port"},
+		},
+		{
+			name: "mirror without private key",
+			modify: func(cfg *Config) {
+				cfg.Mirror.Enabled = true
+				cfg.PrivateKey.Path = ""
+			},
+		},
+		{
+			name: "mirror announcing and attesting",
+			modify: func(cfg *Config) {
+				cfg.Mirror.Enabled = true
+				cfg.Gossip.Enabled = true
+				cfg.Attestation.Enabled = true
+				cfg.Attestation.ContractAddress = "0x0000000000000000000000000000000000000001"
+			},
+			expectedFields: []string{"Attestation.Enabled", "Gossip.Enabled"},
+		},
+		{
+			name: "mirror certifying, challenging and sharding",
+			modify: func(cfg *Config) {
+				cfg.Mirror.Enabled = true
+				cfg.Certificate.Coordinator = true
+				cfg.Custody.Challenger = true
+				cfg.Sharding.Enabled = true
+			},
+			expectedFields: []string{"Certificate.Coordinator", "Custody.Challenger", "Sharding.Enabled"},
+		},
+		{
+			name: "devnet without private key nor L1",
+			modify: func(cfg *Config) {
+				cfg.Devnet.Enabled = true
+				cfg.DB.Memory = true
+				cfg.PrivateKey.Path = ""
+				cfg.L1.RpcURL = ""
+			},
+		},
+		{
+			name: "devnet on a database",
+			modify: func(cfg *Config) {
+				cfg.Devnet.Enabled = true
+				cfg.Mirror.Enabled = true
+				cfg.Devnet.MembersPort = cfg.RPC.Port - 1
+				cfg.Devnet.BatchSize = 0
+			},
+			expectedFields: []string{"Mirror.Enabled", "DB.Memory", "Devnet.BatchSize", "Devnet.MembersPort"},
+		},
+		{
+			name: "admin port ignored when disabled",
+			modify: func(cfg *Config) {
+				cfg.Admin.Port = cfg.RPC.Port
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := Default()
+			require.NoError(t, err)
+
+			tt.modify(cfg)
+
+			err = cfg.Validate()
+			if len(tt.expectedFields) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			var validationErr ValidationError
+			require.True(t, errors.As(err, &validationErr))
+
+			fields := make([]string, len(validationErr))
+			for i, fe := range validationErr {
+				fields[i] = fe.Field
+			}
+			require.ElementsMatch(t, tt.expectedFields, fields)
+		})
+	}
+}
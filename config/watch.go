@@ -0,0 +1,72 @@
+package config
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce is the time to wait for a burst of file events to settle before reloading
+const watchDebounce = 500 * time.Millisecond
+
+// Watch calls onChange every time the given config file is written, created or replaced,
+// until the context is done. The parent directory is watched rather than the file itself,
+// so that editors saving through a rename and symlink swaps (e.g. Kubernetes ConfigMaps)
+// are detected as well.
+func Watch(ctx context.Context, configFilePath string, onChange func()) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	configFile := filepath.Clean(configFilePath)
+	configDir, _ := filepath.Split(configFile)
+	if configDir == "" {
+		configDir = "."
+	}
+	realConfigFile, _ := filepath.EvalSymlinks(configFile)
+
+	if err = watcher.Add(configDir); err != nil {
+		_ = watcher.Close()
+		return err
+	}
+
+	go func() {
+		defer watcher.Close()
+
+		var debounce <-chan time.Time
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+
+				currentConfigFile, _ := filepath.EvalSymlinks(configFile)
+				fileChanged := filepath.Clean(event.Name) == configFile &&
+					event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0
+				linkChanged := currentConfigFile != "" && currentConfigFile != realConfigFile
+
+				if fileChanged || linkChanged {
+					realConfigFile = currentConfigFile
+					debounce = time.After(watchDebounce)
+				}
+			case <-debounce:
+				debounce = nil
+				onChange()
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Errorf("config file watcher error: %v", err)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return nil
+}
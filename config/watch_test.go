@@ -0,0 +1,62 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Watch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	configFile := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(configFile, []byte("[Log]\nLevel = \"info\"\n"), 0600))
+
+	changes := make(chan struct{}, 1)
+	require.NoError(t, Watch(ctx, configFile, func() {
+		changes <- struct{}{}
+	}))
+
+	t.Run("file written", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(configFile, []byte("[Log]\nLevel = \"debug\"\n"), 0600))
+
+		select {
+		case <-changes:
+		case <-time.After(5 * time.Second):
+			t.Fatal("config change not detected")
+		}
+	})
+
+	t.Run("file replaced", func(t *testing.T) {
+		replacement := filepath.Join(filepath.Dir(configFile), "config.toml.new")
+		require.NoError(t, os.WriteFile(replacement, []byte("[Log]\nLevel = \"warn\"\n"), 0600))
+		require.NoError(t, os.Rename(replacement, configFile))
+
+		select {
+		case <-changes:
+		case <-time.After(5 * time.Second):
+			t.Fatal("config change not detected")
+		}
+	})
+
+	t.Run("other file written", func(t *testing.T) {
+		otherFile := filepath.Join(filepath.Dir(configFile), "other.toml")
+		require.NoError(t, os.WriteFile(otherFile, []byte("foo"), 0600))
+
+		select {
+		case <-changes:
+			t.Fatal("unexpected config change")
+		case <-time.After(2 * watchDebounce):
+		}
+	})
+}
+
+func Test_WatchMissingDir(t *testing.T) {
+	err := Watch(context.Background(), "/fictitious-dir/config.toml", func() {})
+	require.Error(t, err)
+}
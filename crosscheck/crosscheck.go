@@ -0,0 +1,458 @@
+package crosscheck
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/audit"
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/metrics"
+	"github.com/0xPolygon/cdk-data-availability/notifier"
+	"github.com/0xPolygon/cdk-data-availability/reporter"
+	"github.com/0xPolygon/cdk-data-availability/synchronizer"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// pageSize is the number of stored values read at a time
+	pageSize = 1000
+	// maxReported is the maximum number of extra and missing keys listed in a report, all being counted
+	maxReported = 100
+)
+
+// logger is the logger of the cross-check component
+var logger = log.WithComponent("crosscheck")
+
+// Report is the result of a cross-check of the stored keys against the keys sequenced on L1
+type Report struct {
+	Timestamp time.Time `json:"timestamp"`
+	// ToBlock is the last L1 block the sequences were read up to
+	ToBlock types.ArgUint64 `json:"toBlock"`
+	// FromBatch is the first batch cross-checked, following the verified batch of the previous cross-check, and
+	// VerifiedBatch the last batch verified on L1, the keys of the batches between them being cross-checked
+	FromBatch     types.ArgUint64 `json:"fromBatch"`
+	VerifiedBatch types.ArgUint64 `json:"verifiedBatch"`
+	// Expected is the number of keys sequenced for the batches cross-checked, along with the keys still missing
+	// since the previous cross-checks, and Stored the number of values stored for the batches cross-checked
+	Expected uint64 `json:"expected"`
+	Stored   uint64 `json:"stored"`
+	// ExtraCount is the number of keys stored for the batches cross-checked that were not sequenced,
+	// possibly injected into the database, and Extra up to maxReported of them
+	ExtraCount uint64        `json:"extraCount"`
+	Extra      []audit.Entry `json:"extra"`
+	// MissingCount is the number of keys sequenced up to the verified batch that are not stored,
+	// and Missing up to maxReported of them
+	MissingCount uint64        `json:"missingCount"`
+	Missing      []audit.Entry `json:"missing"`
+	// PrunedCount is the number of keys sequenced up to the verified batch whose value was deleted per the
+	// retention policy, which are not counted as missing
+	PrunedCount uint64 `json:"prunedCount"`
+}
+
+// sequencedBatch is a batch sequenced on L1 not cross-checked yet
+type sequencedBatch struct {
+	// key is the key of the data of the batch, unknown for the batches of the sequences carrying blobs
+	key  common.Hash
+	blob bool
+	// block is the L1 block the batch was sequenced in
+	block uint64
+}
+
+// Verifier periodically derives the keys sequenced on L1 up to the last verified batch, which can no longer
+// be reorganized, and compares them with the keys stored in both directions. The sequences are read
+// incrementally from the blocks processed by the synchronizer, from the genesis block on the first pass, and
+// each pass only cross-checks the batches verified since the previous one, the keys found missing being
+// checked again until they are stored or pruned.
+type Verifier struct {
+	cfg          config.CrossCheckConfig
+	db           db.DB
+	etherman     etherman.Etherman
+	auditor      *audit.Auditor
+	genesisBlock uint64
+	validium     common.Address
+	reorgs       <-chan synchronizer.BlockReorg
+	stop         chan struct{}
+
+	// batches are the batches sequenced in the blocks before nextBlock after checkedBatch, a batch
+	// sequenced again after a reorg taking the key of its last sequence
+	batches      map[uint64]sequencedBatch
+	missing      map[common.Hash]uint64
+	fromBatch    uint64
+	checkedBatch uint64
+	nextBlock    uint64
+
+	// rewound is the lowest block the chain rewound to since the previous pass, 0 when it did not
+	rewindLock sync.Mutex
+	rewound    uint64
+
+	lock sync.RWMutex
+	last *Report
+}
+
+// New returns a Verifier reading the sequences of the validium of the L1 configuration from its genesis block,
+// or from the block its contract was deployed at when no genesis block is configured, and forgetting the
+// sequences of the blocks reorganized away as the reorgs are received
+func New(
+	cfg config.CrossCheckConfig,
+	l1 config.L1Config,
+	db db.DB,
+	em etherman.Etherman,
+	reorgs <-chan synchronizer.BlockReorg,
+) *Verifier {
+	return &Verifier{
+		cfg:          cfg,
+		db:           db,
+		etherman:     em,
+		auditor:      audit.New(db, em, cfg.BlockRange, pageSize),
+		genesisBlock: l1.GenesisBlock,
+		validium:     common.HexToAddress(l1.PolygonValidiumAddress),
+		reorgs:       reorgs,
+		stop:         make(chan struct{}),
+		batches:      make(map[uint64]sequencedBatch),
+		missing:      make(map[common.Hash]uint64),
+	}
+}
+
+// Start cross-checks the stored keys every interval until the verifier is stopped
+func (v *Verifier) Start(ctx context.Context) {
+	defer reporter.Recover()
+
+	logger.Infof("starting to cross-check the stored keys against L1 every %v", v.cfg.Interval.Duration)
+	go v.handleReorgs(ctx)
+
+	ticker := time.NewTicker(v.cfg.Interval.Duration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := v.Check(ctx); err != nil {
+				logger.Errorf("failed to cross-check the stored keys: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		case <-v.stop:
+			return
+		}
+	}
+}
+
+// handleReorgs records the blocks the chain rewinds to, without waiting for a pass to end so that the
+// detector is never blocked
+func (v *Verifier) handleReorgs(ctx context.Context) {
+	defer reporter.Recover()
+
+	for {
+		select {
+		case r, ok := <-v.reorgs:
+			if !ok {
+				return
+			}
+
+			v.rewindLock.Lock()
+			if v.rewound == 0 || r.Number < v.rewound {
+				v.rewound = r.Number
+			}
+			v.rewindLock.Unlock()
+		case <-ctx.Done():
+			return
+		case <-v.stop:
+			return
+		}
+	}
+}
+
+// Stop stops the verifier
+func (v *Verifier) Stop() {
+	close(v.stop)
+}
+
+// Last returns the report of the last cross-check, if any
+func (v *Verifier) Last() (Report, bool) {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+
+	if v.last == nil {
+		return Report{}, false
+	}
+
+	return *v.last, true
+}
+
+// Check reads the sequences of the blocks processed by the synchronizer since the previous pass, then
+// compares the keys sequenced for the batches verified since then with the keys stored, records the results
+// in the metrics and returns the report
+func (v *Verifier) Check(ctx context.Context) (Report, error) {
+	v.rewind()
+
+	head, err := v.db.GetLastProcessedBlock(ctx, string(synchronizer.L1SyncTask))
+	if err != nil {
+		return Report{}, fmt.Errorf("failed to get the last block processed by the synchronizer: %w", err)
+	}
+
+	if err = v.readSequenced(ctx, head); err != nil {
+		return Report{}, err
+	}
+
+	verified, err := v.etherman.LastVerifiedBatch(ctx)
+	if err != nil {
+		return Report{}, fmt.Errorf("failed to get the last verified batch: %w", err)
+	}
+
+	report, err := v.compare(ctx, verified)
+	if err != nil {
+		return Report{}, err
+	}
+	report.ToBlock = types.ArgUint64(v.nextBlock - 1)
+
+	metrics.CrossChecked(verified, int(report.ExtraCount), int(report.MissingCount))
+	if report.ExtraCount > 0 || report.MissingCount > 0 {
+		logger.Errorf("%d keys stored were not sequenced and %d keys sequenced are missing up to batch %d",
+			report.ExtraCount, report.MissingCount, verified)
+		notifier.KeySetMismatch(verified, int(report.ExtraCount), int(report.MissingCount))
+	}
+
+	v.lock.Lock()
+	v.last = &report
+	v.lock.Unlock()
+
+	return report, nil
+}
+
+// rewind forgets the batches sequenced in the blocks reorganized away since the previous pass, their blocks
+// being read again
+func (v *Verifier) rewind() {
+	v.rewindLock.Lock()
+	rewound := v.rewound
+	v.rewound = 0
+	v.rewindLock.Unlock()
+
+	if rewound == 0 || rewound >= v.nextBlock {
+		return
+	}
+
+	for batchNum, batch := range v.batches {
+		if batch.block >= rewound {
+			delete(v.batches, batchNum)
+		}
+	}
+	v.nextBlock = rewound
+	logger.Infof("the chain rewound to the block %d, reading the sequences again from it", rewound)
+}
+
+// readSequenced adds the batches sequenced in the blocks up to head not read yet
+func (v *Verifier) readSequenced(ctx context.Context, head uint64) error {
+	if v.nextBlock == 0 {
+		v.nextBlock = v.genesisBlock
+		if v.nextBlock == 0 {
+			deployment, err := synchronizer.FindContractDeploymentBlock(ctx, v.etherman, v.validium)
+			if err != nil {
+				return fmt.Errorf("failed to find the block the validium was deployed at: %w", err)
+			}
+			v.nextBlock = deployment.Uint64()
+		}
+	}
+	if head < v.nextBlock {
+		return nil
+	}
+
+	sequenced, err := v.auditor.Sequenced(ctx, v.nextBlock, head)
+	if err != nil {
+		return err
+	}
+
+	for key, batchNum := range sequenced.Keys {
+		if batchNum > v.checkedBatch {
+			v.batches[batchNum] = sequencedBatch{key: key, block: sequenced.Blocks[batchNum]}
+		}
+	}
+	for batchNum := range sequenced.BlobBatches {
+		if batchNum > v.checkedBatch {
+			v.batches[batchNum] = sequencedBatch{blob: true, block: sequenced.Blocks[batchNum]}
+		}
+	}
+	if sequenced.FromBatch != 0 && (v.fromBatch == 0 || sequenced.FromBatch < v.fromBatch) {
+		v.fromBatch = sequenced.FromBatch
+	}
+	v.nextBlock = head + 1
+
+	return nil
+}
+
+// compare walks the keys stored for the batches verified since the previous pass, matching them against the
+// keys sequenced for them, and checks again the keys missing since the previous passes. The batches compared
+// are forgotten once the comparison succeeded.
+func (v *Verifier) compare(ctx context.Context, verified uint64) (Report, error) {
+	fromBatch := v.checkedBatch + 1
+	if fromBatch < v.fromBatch {
+		fromBatch = v.fromBatch
+	}
+
+	report := Report{
+		Timestamp:     time.Now().UTC(),
+		FromBatch:     types.ArgUint64(fromBatch),
+		VerifiedBatch: types.ArgUint64(verified),
+	}
+
+	expected := make(map[common.Hash]uint64, len(v.missing))
+	for key, batchNum := range v.missing {
+		expected[key] = batchNum
+	}
+	for batchNum, batch := range v.batches {
+		if batchNum <= verified && !batch.blob {
+			expected[batch.key] = batchNum
+		}
+	}
+	report.Expected = uint64(len(expected))
+
+	found := make(map[common.Hash]bool, len(expected))
+	// nothing was sequenced yet when fromBatch is unknown
+	if v.fromBatch != 0 && fromBatch <= verified {
+		if err := v.walkStoredKeys(ctx, fromBatch, verified, func(key types.BatchKey) {
+			report.Stored++
+			if _, ok := expected[key.Hash]; ok {
+				found[key.Hash] = true
+				return
+			}
+
+			// the values of the blobs are stored under the hash of the blob, which can not be told from L1
+			if batch, ok := v.batches[key.Number]; !ok || !batch.blob {
+				report.ExtraCount++
+				report.Extra = appendBounded(report.Extra, key.Hash, key.Number)
+			}
+		}); err != nil {
+			return Report{}, err
+		}
+	}
+
+	// the keys still missing are out of the batches walked, their values are looked up once stored
+	if err := v.findStored(ctx, found); err != nil {
+		return Report{}, err
+	}
+
+	notFound := make([]common.Hash, 0)
+	for key := range expected {
+		if !found[key] {
+			notFound = append(notFound, key)
+		}
+	}
+
+	pruned, err := v.auditor.Pruned(ctx, notFound)
+	if err != nil {
+		return Report{}, err
+	}
+	missing := make(map[common.Hash]uint64)
+	for _, key := range notFound {
+		if pruned[key] {
+			report.PrunedCount++
+			continue
+		}
+
+		missing[key] = expected[key]
+		report.MissingCount++
+		report.Missing = appendBounded(report.Missing, key, expected[key])
+	}
+
+	sortEntries(report.Extra)
+	sortEntries(report.Missing)
+
+	// the batches verified can no longer be reorganized, only the keys still missing are kept
+	for batchNum := range v.batches {
+		if batchNum <= verified {
+			delete(v.batches, batchNum)
+		}
+	}
+	if verified > v.checkedBatch {
+		v.checkedBatch = verified
+	}
+	v.missing = missing
+
+	return report, nil
+}
+
+// walkStoredKeys calls fn with the keys of the values stored for the batches from fromBatch to toBatch,
+// read pageSize at a time without their values
+func (v *Verifier) walkStoredKeys(ctx context.Context, fromBatch, toBatch uint64, fn func(types.BatchKey)) error {
+	for fromBatch <= toBatch {
+		keys, err := v.db.GetOffChainDataKeys(ctx, fromBatch, pageSize)
+		if err != nil {
+			return fmt.Errorf("failed to get the keys from the batch %d: %w", fromBatch, err)
+		}
+
+		last := len(keys) < pageSize
+		if !last {
+			// more keys of the last batch listed may follow
+			lastBatch := keys[len(keys)-1].Number
+			for len(keys) > 0 && keys[len(keys)-1].Number == lastBatch {
+				keys = keys[:len(keys)-1]
+			}
+			if len(keys) == 0 {
+				return fmt.Errorf("the batch %d has more than %d values", lastBatch, pageSize)
+			}
+		}
+
+		for _, key := range keys {
+			if key.Number > toBatch {
+				return nil
+			}
+			fn(key)
+		}
+		if last {
+			return nil
+		}
+		fromBatch = keys[len(keys)-1].Number + 1
+	}
+
+	return nil
+}
+
+// findStored marks as found the keys missing since the previous passes whose values are stored since then
+func (v *Verifier) findStored(ctx context.Context, found map[common.Hash]bool) error {
+	keys := make([]common.Hash, 0, len(v.missing))
+	for key := range v.missing {
+		keys = append(keys, key)
+	}
+
+	for start := 0; start < len(keys); start += pageSize {
+		end := start + pageSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		stored, err := v.db.ListOffChainData(ctx, keys[start:end])
+		if err != nil {
+			return fmt.Errorf("failed to list the values missing: %w", err)
+		}
+		for _, data := range stored {
+			found[data.Key] = true
+		}
+	}
+
+	return nil
+}
+
+// appendBounded appends the entry unless maxReported entries are already listed
+func appendBounded(entries []audit.Entry, key common.Hash, batchNum uint64) []audit.Entry {
+	if len(entries) >= maxReported {
+		return entries
+	}
+
+	return append(entries, audit.Entry{Key: key, BatchNum: types.ArgUint64(batchNum)})
+}
+
+// sortEntries sorts the entries by batch
+func sortEntries(entries []audit.Entry) {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].BatchNum != entries[j].BatchNum {
+			return entries[i].BatchNum < entries[j].BatchNum
+		}
+		return entries[i].Key.Hex() < entries[j].Key.Hex()
+	})
+}
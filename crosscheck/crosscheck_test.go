@@ -0,0 +1,135 @@
+package crosscheck
+
+import (
+	"context"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/audit"
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/etherman/ethermantest"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/synchronizer"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifier_Check(t *testing.T) {
+	first, second, third := []byte("first"), []byte("second"), []byte("third")
+	log1, tx1 := ethermantest.Sequence(t, 2, first, second)
+	log2, tx2 := ethermantest.Sequence(t, 3, third)
+
+	em := mocks.NewEtherman(t)
+	em.On("FilterSequenceBatches", ethermantest.BlockRange(100, 150), mock.Anything).
+		Return(ethermantest.Iterator(t, log1, log2), nil).Once()
+	em.On("GetTx", mock.Anything, tx1.Hash()).Return(tx1, false, nil)
+	em.On("GetTx", mock.Anything, tx2.Hash()).Return(tx2, false, nil)
+	em.On("LastVerifiedBatch", mock.Anything).Return(uint64(2), nil).Once()
+	em.On("LastVerifiedBatch", mock.Anything).Return(uint64(3), nil).Once()
+
+	injected := []byte("injected")
+	dbMock := mocks.NewDB(t)
+	dbMock.On("GetLastProcessedBlock", mock.Anything, string(synchronizer.L1SyncTask)).Return(uint64(150), nil)
+	// only the keys are read, the values not assigned to a batch yet being left out
+	dbMock.On("GetOffChainDataKeys", mock.Anything, uint64(1), uint(pageSize)).Return([]types.BatchKey{
+		{Number: 1, Hash: crypto.Keccak256Hash(first)},
+		{Number: 2, Hash: crypto.Keccak256Hash(injected)},
+		{Number: 3, Hash: crypto.Keccak256Hash(third)},
+	}, nil).Once()
+	dbMock.On("GetTombstones", mock.Anything, []common.Hash{crypto.Keccak256Hash(second)}).
+		Return([]types.Tombstone{}, nil).Once()
+
+	cfg := config.CrossCheckConfig{BlockRange: 100}
+	v := New(cfg, config.L1Config{GenesisBlock: 100}, dbMock, em, nil)
+
+	report, err := v.Check(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, types.ArgUint64(150), report.ToBlock)
+	require.Equal(t, types.ArgUint64(1), report.FromBatch)
+	require.Equal(t, types.ArgUint64(2), report.VerifiedBatch)
+	require.Equal(t, uint64(2), report.Expected)
+	require.Equal(t, uint64(2), report.Stored)
+	require.Equal(t, uint64(1), report.ExtraCount)
+	require.Equal(t, []audit.Entry{{Key: crypto.Keccak256Hash(injected), BatchNum: 2}}, report.Extra)
+	require.Equal(t, uint64(1), report.MissingCount)
+	require.Equal(t, []audit.Entry{{Key: crypto.Keccak256Hash(second), BatchNum: 2}}, report.Missing)
+
+	// the blocks already read are not filtered again, only the batches verified since then being cross-checked
+	// along with the key still missing
+	dbMock.On("GetOffChainDataKeys", mock.Anything, uint64(3), uint(pageSize)).
+		Return([]types.BatchKey{{Number: 3, Hash: crypto.Keccak256Hash(third)}}, nil).Once()
+	dbMock.On("ListOffChainData", mock.Anything, []common.Hash{crypto.Keccak256Hash(second)}).
+		Return([]types.OffChainData{}, nil).Once()
+	dbMock.On("GetTombstones", mock.Anything, []common.Hash{crypto.Keccak256Hash(second)}).
+		Return([]types.Tombstone{{Key: crypto.Keccak256Hash(second), BatchNum: 2}}, nil).Once()
+	report, err = v.Check(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, types.ArgUint64(3), report.FromBatch)
+	require.Equal(t, uint64(2), report.Expected)
+	require.Equal(t, uint64(1), report.Stored)
+	require.Equal(t, uint64(0), report.ExtraCount)
+	// the value missing since then pruned per the retention policy
+	require.Equal(t, uint64(0), report.MissingCount)
+	require.Equal(t, uint64(1), report.PrunedCount)
+	require.Empty(t, v.batches)
+	require.Empty(t, v.missing)
+
+	last, ok := v.Last()
+	require.True(t, ok)
+	require.Equal(t, report, last)
+}
+
+func TestVerifier_Reorg(t *testing.T) {
+	first, second, orphaned := []byte("first"), []byte("second"), []byte("orphaned")
+	log1, tx1 := ethermantest.Sequence(t, 1, first)
+	log2, tx2 := ethermantest.Sequence(t, 2, orphaned)
+	log3, tx3 := ethermantest.Sequence(t, 2, second)
+	log1.BlockNumber, log2.BlockNumber, log3.BlockNumber = 110, 140, 135
+
+	em := mocks.NewEtherman(t)
+	em.On("FilterSequenceBatches", ethermantest.BlockRange(100, 150), mock.Anything).
+		Return(ethermantest.Iterator(t, log1, log2), nil).Once()
+	// the blocks from the one the chain rewound to are read again
+	em.On("FilterSequenceBatches", ethermantest.BlockRange(130, 150), mock.Anything).
+		Return(ethermantest.Iterator(t, log3), nil).Once()
+	for _, tx := range []*ethTypes.Transaction{tx1, tx2, tx3} {
+		em.On("GetTx", mock.Anything, tx.Hash()).Return(tx, false, nil)
+	}
+	em.On("LastVerifiedBatch", mock.Anything).Return(uint64(1), nil).Once()
+	em.On("LastVerifiedBatch", mock.Anything).Return(uint64(2), nil).Once()
+
+	dbMock := mocks.NewDB(t)
+	dbMock.On("GetLastProcessedBlock", mock.Anything, string(synchronizer.L1SyncTask)).Return(uint64(150), nil)
+	dbMock.On("GetOffChainDataKeys", mock.Anything, uint64(1), uint(pageSize)).
+		Return([]types.BatchKey{{Number: 1, Hash: crypto.Keccak256Hash(first)}}, nil).Once()
+	dbMock.On("GetOffChainDataKeys", mock.Anything, uint64(2), uint(pageSize)).
+		Return([]types.BatchKey{{Number: 2, Hash: crypto.Keccak256Hash(second)}}, nil).Once()
+
+	reorgs := make(chan synchronizer.BlockReorg)
+	v := New(config.CrossCheckConfig{BlockRange: 100}, config.L1Config{GenesisBlock: 100}, dbMock, em, reorgs)
+
+	report, err := v.Check(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, report.ExtraCount+report.MissingCount)
+	require.Equal(t, crypto.Keccak256Hash(orphaned), v.batches[2].key)
+
+	handled := make(chan struct{})
+	go func() {
+		v.handleReorgs(context.Background())
+		close(handled)
+	}()
+	reorgs <- synchronizer.BlockReorg{Number: 130}
+	close(reorgs)
+	<-handled
+
+	// the batch sequenced in the block reorganized away is replaced by its sequence on the canonical chain
+	report, err = v.Check(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), report.Expected)
+	require.Zero(t, report.ExtraCount+report.MissingCount)
+}
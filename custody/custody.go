@@ -0,0 +1,244 @@
+package custody
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/client"
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/metrics"
+	"github.com/0xPolygon/cdk-data-availability/notifier"
+	"github.com/0xPolygon/cdk-data-availability/reporter"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// pageSize is the number of stored values a value to challenge is picked from
+const pageSize = 100
+
+// logger is the logger of the custody component
+var logger = log.WithComponent("custody")
+
+// errWrongProof is the failure of the members answering a challenge with another proof than expected
+var errWrongProof = errors.New("wrong custody proof")
+
+// Challenger periodically audits the other committee members: each one is challenged to prove it holds a
+// value stored by the node by answering keccak256(value || nonce) for a random nonce, which can not be
+// computed ahead of the challenge. The values are picked at random among the batches verified on L1, so
+// that every member had the time to store them.
+type Challenger struct {
+	cfg      config.CustodyConfig
+	self     common.Address
+	db       db.DB
+	etherman etherman.Etherman
+	factory  client.Factory
+	stop     chan struct{}
+
+	lock     sync.Mutex
+	interval time.Duration
+	// reloaded is signalled when the interval changes at runtime
+	reloaded chan struct{}
+}
+
+// New returns a Challenger of the members of the committee registered on L1 other than self
+func New(
+	cfg config.CustodyConfig,
+	self common.Address,
+	db db.DB,
+	em etherman.Etherman,
+	factory client.Factory,
+) *Challenger {
+	return &Challenger{
+		cfg:      cfg,
+		self:     self,
+		db:       db,
+		etherman: em,
+		factory:  factory,
+		stop:     make(chan struct{}),
+		interval: cfg.Interval.Duration,
+		reloaded: make(chan struct{}, 1),
+	}
+}
+
+// Start challenges the members every interval until the challenger is stopped
+func (c *Challenger) Start(ctx context.Context) {
+	defer reporter.Recover()
+
+	interval := c.currentInterval()
+	logger.Infof("starting to challenge the custody of the committee members every %v", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.reloaded:
+			ticker.Reset(c.currentInterval())
+		case <-ticker.C:
+			if err := c.ChallengeMembers(ctx); err != nil {
+				logger.Errorf("failed to challenge the committee members: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+// Stop stops the challenger
+func (c *Challenger) Stop() {
+	close(c.stop)
+}
+
+// Reload changes the interval of the challenges at runtime
+func (c *Challenger) Reload(cfg config.CustodyConfig) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if cfg.Interval.Duration <= 0 || cfg.Interval.Duration == c.interval {
+		return
+	}
+	logger.Infof("custody challenge interval changed from %v to %v", c.interval, cfg.Interval.Duration)
+	c.interval = cfg.Interval.Duration
+
+	select {
+	case c.reloaded <- struct{}{}:
+	default:
+	}
+}
+
+func (c *Challenger) currentInterval() time.Duration {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.interval
+}
+
+// ChallengeMembers challenges every other member of the committee once, each over a value picked at random
+func (c *Challenger) ChallengeMembers(ctx context.Context) error {
+	verified, err := c.etherman.LastVerifiedBatch(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the last verified batch: %w", err)
+	}
+
+	committee, err := c.etherman.GetCurrentDataCommittee()
+	if err != nil {
+		return fmt.Errorf("failed to get the committee: %w", err)
+	}
+
+	for _, member := range committee.Members {
+		if member.Addr == c.self {
+			continue
+		}
+
+		data, err := c.pick(ctx, verified)
+		if err != nil {
+			return err
+		}
+		if data == nil {
+			logger.Debugf("no value of the batches up to %d picked to challenge %s over", verified, member.Addr.Hex())
+			continue
+		}
+
+		c.challenge(ctx, member, *data)
+	}
+
+	return nil
+}
+
+// challenge challenges the member over the given value, and records and returns the result
+func (c *Challenger) challenge(
+	parentCtx context.Context,
+	member etherman.DataCommitteeMember,
+	data types.OffChainData,
+) string {
+	result := c.answer(parentCtx, member, data)
+	metrics.CustodyChallenge(member.Addr.Hex(), result)
+
+	return result
+}
+
+// answer returns the result of the challenge of the member over the given value
+func (c *Challenger) answer(
+	parentCtx context.Context,
+	member etherman.DataCommitteeMember,
+	data types.OffChainData,
+) string {
+	memberLogger := logger.WithFields(log.FieldMemberAddr, member.Addr.Hex(), log.FieldMemberURL, member.URL,
+		log.FieldKeyHash, data.Key.Hex())
+
+	nonce, err := randomHash()
+	if err != nil {
+		memberLogger.Errorf("failed to generate the nonce of the challenge: %v", err)
+		return metrics.ResultError
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, c.cfg.Timeout.Duration)
+	defer cancel()
+
+	proof, err := c.factory.New(member.URL).ChallengeCustody(ctx, data.Key, nonce)
+	if err != nil && !errors.Is(err, client.ErrChallengeRefused) {
+		// the member not being reachable is not evidence of the data being discarded
+		memberLogger.Warnf("failed to challenge the member: %v", err)
+		return metrics.ResultError
+	}
+	if err == nil && proof != types.CustodyProof(data.Value, nonce) {
+		err = errWrongProof
+	}
+	if err != nil {
+		memberLogger.Errorf("member failed the custody challenge: %v", err)
+		notifier.CustodyFailed(member.Addr.Hex(), member.URL, data.Key.Hex(), err)
+		return metrics.CustodyFailed
+	}
+
+	memberLogger.Debug("member passed the custody challenge")
+	return metrics.CustodyPassed
+}
+
+// pick returns a value of the batches up to the verified one, picked at random, or nil when there is none.
+// The keys being hashes, the values following a random key are a random sample of the stored values.
+func (c *Challenger) pick(ctx context.Context, verified uint64) (*types.OffChainData, error) {
+	after, err := randomHash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to pick a value: %w", err)
+	}
+
+	page, err := c.db.ListOffChainDataPage(ctx, after, pageSize)
+	if err == nil && len(page) == 0 {
+		// the random key follows every stored key, the values are picked from the first ones
+		page, err = c.db.ListOffChainDataPage(ctx, common.Hash{}, pageSize)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the values after %s: %w", after.Hex(), err)
+	}
+
+	for _, data := range page {
+		// the values of the batches not verified yet may not be stored by every member, and the corrupt
+		// values would fail the challenges of the honest members
+		if data.BatchNum == 0 || data.BatchNum > verified || crypto.Keccak256Hash(data.Value) != data.Key {
+			continue
+		}
+
+		data := data
+		return &data, nil
+	}
+
+	return nil, nil
+}
+
+// randomHash returns a hash read from the cryptographically secure random source
+func randomHash() (common.Hash, error) {
+	var hash common.Hash
+	if _, err := rand.Read(hash[:]); err != nil {
+		return common.Hash{}, err
+	}
+
+	return hash, nil
+}
@@ -0,0 +1,172 @@
+package custody
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/client"
+	"github.com/0xPolygon/cdk-data-availability/config"
+	cfgTypes "github.com/0xPolygon/cdk-data-availability/config/types"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/metrics"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// stored returns the value stored for the given batch under its hash
+func stored(value string, batchNum uint64) types.OffChainData {
+	return types.OffChainData{Key: crypto.Keccak256Hash([]byte(value)), Value: []byte(value), BatchNum: batchNum}
+}
+
+func TestChallenger_ChallengeMembers(t *testing.T) {
+	self := common.HexToAddress("0x1")
+	other := etherman.DataCommitteeMember{Addr: common.HexToAddress("0x2"), URL: "http://other"}
+
+	em := mocks.NewEtherman(t)
+	em.On("LastVerifiedBatch", mock.Anything).Return(uint64(5), nil)
+	em.On("GetCurrentDataCommittee").Return(&etherman.DataCommittee{
+		Members: []etherman.DataCommitteeMember{{Addr: self, URL: "http://self"}, other},
+	}, nil)
+
+	// the values of the batches not verified yet and the corrupt values are not challenged
+	challenged := stored("challenged", 2)
+	corrupt := stored("corrupt", 1)
+	corrupt.Value = []byte("other")
+	dbMock := mocks.NewDB(t)
+	dbMock.On("ListOffChainDataPage", mock.Anything, mock.Anything, uint(pageSize)).Return([]types.OffChainData{
+		stored("pending", 0),
+		stored("unverified", 6),
+		corrupt,
+		challenged,
+	}, nil).Once()
+
+	// only the other member is challenged
+	otherClient := mocks.NewClient(t)
+	otherClient.On("ChallengeCustody", mock.Anything, challenged.Key, mock.Anything).Return(
+		func(_ context.Context, _, nonce common.Hash) (common.Hash, error) {
+			return types.CustodyProof(challenged.Value, nonce), nil
+		}).Once()
+	factory := mocks.NewClientFactory(t)
+	factory.On("New", other.URL).Return(otherClient).Once()
+
+	cfg := config.CustodyConfig{Timeout: cfgTypes.Duration{Duration: time.Second}}
+	require.NoError(t, New(cfg, self, dbMock, em, factory).ChallengeMembers(context.Background()))
+}
+
+func TestChallenger_Reload(t *testing.T) {
+	challenged := make(chan struct{}, 1)
+	em := mocks.NewEtherman(t)
+	em.On("LastVerifiedBatch", mock.Anything).Return(uint64(0), errors.New("unavailable")).
+		Run(func(mock.Arguments) {
+			select {
+			case challenged <- struct{}{}:
+			default:
+			}
+		})
+
+	c := New(config.CustodyConfig{Interval: cfgTypes.NewDuration(time.Hour)}, common.Address{}, mocks.NewDB(t), em,
+		mocks.NewClientFactory(t))
+	go c.Start(context.Background())
+	defer c.Stop()
+
+	// zero values are ignored
+	c.Reload(config.CustodyConfig{})
+	require.Equal(t, time.Hour, c.currentInterval())
+
+	// the members are challenged at the new interval without a restart
+	c.Reload(config.CustodyConfig{Interval: cfgTypes.NewDuration(10 * time.Millisecond)})
+	select {
+	case <-challenged:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the members were not challenged at the new interval")
+	}
+}
+
+func TestChallenger_challenge(t *testing.T) {
+	data := stored("value", 1)
+	member := etherman.DataCommitteeMember{Addr: common.HexToAddress("0x2"), URL: "http://member"}
+
+	tests := []struct {
+		name   string
+		proof  func(nonce common.Hash) common.Hash
+		err    error
+		result string
+	}{
+		{
+			name:   "member holding the value",
+			proof:  func(nonce common.Hash) common.Hash { return types.CustodyProof(data.Value, nonce) },
+			result: metrics.CustodyPassed,
+		},
+		{
+			name:   "member answering another proof",
+			proof:  func(nonce common.Hash) common.Hash { return types.CustodyProof([]byte("guess"), nonce) },
+			result: metrics.CustodyFailed,
+		},
+		{
+			name:   "member refusing the challenge",
+			err:    fmt.Errorf("%w: the data is not available", client.ErrChallengeRefused),
+			result: metrics.CustodyFailed,
+		},
+		{
+			name:   "member not reachable",
+			err:    errors.New("connection refused"),
+			result: metrics.ResultError,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			memberClient := mocks.NewClient(t)
+			memberClient.On("ChallengeCustody", mock.Anything, data.Key, mock.Anything).Return(
+				func(_ context.Context, _, nonce common.Hash) (common.Hash, error) {
+					if tt.err != nil {
+						return common.Hash{}, tt.err
+					}
+					return tt.proof(nonce), nil
+				}).Once()
+			factory := mocks.NewClientFactory(t)
+			factory.On("New", member.URL).Return(memberClient).Once()
+
+			cfg := config.CustodyConfig{Timeout: cfgTypes.Duration{Duration: time.Second}}
+			c := New(cfg, common.Address{}, mocks.NewDB(t), mocks.NewEtherman(t), factory)
+			require.Equal(t, tt.result, c.challenge(context.Background(), member, data))
+		})
+	}
+}
+
+func TestChallenger_pick(t *testing.T) {
+	t.Run("random key after every stored key", func(t *testing.T) {
+		first := stored("first", 1)
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("ListOffChainDataPage", mock.Anything, mock.MatchedBy(func(after common.Hash) bool {
+			return after != common.Hash{}
+		}), uint(pageSize)).Return([]types.OffChainData{}, nil).Once()
+		dbMock.On("ListOffChainDataPage", mock.Anything, common.Hash{}, uint(pageSize)).
+			Return([]types.OffChainData{first}, nil).Once()
+
+		c := New(config.CustodyConfig{}, common.Address{}, dbMock, mocks.NewEtherman(t), mocks.NewClientFactory(t))
+		data, err := c.pick(context.Background(), 1)
+		require.NoError(t, err)
+		require.Equal(t, &first, data)
+	})
+
+	t.Run("no value verified", func(t *testing.T) {
+		dbMock := mocks.NewDB(t)
+		dbMock.On("ListOffChainDataPage", mock.Anything, mock.Anything, uint(pageSize)).
+			Return([]types.OffChainData{stored("unverified", 2)}, nil).Once()
+
+		c := New(config.CustodyConfig{}, common.Address{}, dbMock, mocks.NewEtherman(t), mocks.NewClientFactory(t))
+		data, err := c.pick(context.Background(), 1)
+		require.NoError(t, err)
+		require.Nil(t, data)
+	})
+}
@@ -0,0 +1,312 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/health"
+	"github.com/0xPolygon/cdk-data-availability/metrics"
+	"github.com/0xPolygon/cdk-data-availability/rpc"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+// Sample is the state of a node at a point in time, read from its status and metrics endpoints
+type Sample struct {
+	Time      time.Time
+	Status    *types.DACStatus
+	Readiness *health.Report
+	Metrics   map[string]*dto.MetricFamily
+	// Errors are the endpoints that could not be read
+	Errors []string
+}
+
+// Fetcher reads the samples of a running node
+type Fetcher struct {
+	nodeURL    string
+	metricsURL string
+	http       *http.Client
+}
+
+// NewFetcher returns a Fetcher reading the status RPC endpoints of the node at nodeURL, and its metrics
+// at metricsURL when not empty, each within the given timeout
+func NewFetcher(nodeURL, metricsURL string, timeout time.Duration) *Fetcher {
+	return &Fetcher{
+		nodeURL:    nodeURL,
+		metricsURL: metricsURL,
+		http:       &http.Client{Timeout: timeout},
+	}
+}
+
+// Fetch returns a sample of the node, the endpoints that could not be read being left out of it
+func (f *Fetcher) Fetch(ctx context.Context) *Sample {
+	sample := &Sample{Time: time.Now()}
+
+	var status types.DACStatus
+	if err := call(ctx, f.nodeURL, "status_getStatus", &status); err != nil {
+		sample.Errors = append(sample.Errors, fmt.Sprintf("status: %v", err))
+	} else {
+		sample.Status = &status
+	}
+
+	var readiness health.Report
+	if err := call(ctx, f.nodeURL, "status_getReadiness", &readiness); err != nil {
+		sample.Errors = append(sample.Errors, fmt.Sprintf("readiness: %v", err))
+	} else {
+		sample.Readiness = &readiness
+	}
+
+	if f.metricsURL != "" {
+		families, err := f.fetchMetrics(ctx)
+		if err != nil {
+			sample.Errors = append(sample.Errors, fmt.Sprintf("metrics: %v", err))
+		} else {
+			sample.Metrics = families
+		}
+	}
+
+	return sample
+}
+
+// call calls a JSON-RPC method of the node, decoding its result into result
+func call(ctx context.Context, url, method string, result interface{}) error {
+	response, err := rpc.JSONRPCCallWithContext(ctx, url, method)
+	if err != nil {
+		return err
+	}
+	if response.Error != nil {
+		return fmt.Errorf("%v %v", response.Error.Code, response.Error.Message)
+	}
+
+	return json.Unmarshal(response.Result, result)
+}
+
+// fetchMetrics reads the metrics of the node in the Prometheus text format
+func (f *Fetcher) fetchMetrics(ctx context.Context) (map[string]*dto.MetricFamily, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.metricsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := f.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var parser expfmt.TextParser
+	return parser.TextToMetricFamilies(resp.Body)
+}
+
+// Rate is the number of events per second of a label between two samples
+type Rate struct {
+	Label  string
+	Total  float64
+	Errors float64
+}
+
+// View is what the dashboard displays, derived from two consecutive samples
+type View struct {
+	Time    time.Time
+	Version string
+	Uptime  string
+	Ready   bool
+	// KeyCount is the number of values stored
+	KeyCount uint64
+	// LastProcessedBlock and L1Head are only known from the metrics, SyncLag being the difference
+	LastProcessedBlock uint64
+	L1Head             uint64
+	SyncLag            uint64
+	UnresolvedBatches  uint64
+	// RPC are the requests handled per second, by method
+	RPC []Rate
+	// Members are the requests for the data of a batch sent to each committee member per second
+	Members    []Rate
+	Components []health.ComponentStatus
+	// Errors are the endpoints that could not be read, and the errors counted since the previous sample
+	Errors []string
+}
+
+// NewView returns the view of the current sample, the rates being computed from the previous one when given
+func NewView(prev, cur *Sample) *View {
+	view := &View{Time: cur.Time, Errors: append([]string{}, cur.Errors...)}
+
+	if cur.Status != nil {
+		view.Version = cur.Status.Version
+		view.Uptime = cur.Status.Uptime
+		view.KeyCount = cur.Status.KeyCount
+		view.LastProcessedBlock = cur.Status.BackfillProgress
+	}
+	if cur.Readiness != nil {
+		view.Ready = cur.Readiness.Ready
+		view.Components = cur.Readiness.Components
+	}
+
+	if cur.Metrics == nil {
+		return view
+	}
+
+	name := func(subsystem, metric string) string {
+		return metrics.Namespace + "_" + subsystem + "_" + metric
+	}
+
+	if block := gauge(cur.Metrics, name("synchronizer", "last_processed_block")); block > 0 {
+		view.LastProcessedBlock = uint64(block)
+	}
+	view.L1Head = uint64(gauge(cur.Metrics, name("synchronizer", "l1_head_block")))
+	if view.L1Head > view.LastProcessedBlock {
+		view.SyncLag = view.L1Head - view.LastProcessedBlock
+	}
+	view.UnresolvedBatches = uint64(gauge(cur.Metrics, name("synchronizer", "unresolved_batches")))
+
+	if prev == nil || prev.Metrics == nil {
+		return view
+	}
+	elapsed := cur.Time.Sub(prev.Time).Seconds()
+	if elapsed <= 0 {
+		return view
+	}
+
+	view.RPC = rates(prev.Metrics, cur.Metrics, name("rpc", "requests_total"), "method", elapsed)
+	view.Members = rates(prev.Metrics, cur.Metrics, name("synchronizer", "member_resolves_total"), "member", elapsed)
+
+	for _, rate := range view.RPC {
+		if rate.Errors > 0 {
+			view.Errors = append(view.Errors, fmt.Sprintf("rpc %s: %.0f errors", rate.Label, rate.Errors*elapsed))
+		}
+	}
+	for _, rate := range view.Members {
+		if rate.Errors > 0 {
+			view.Errors = append(view.Errors, fmt.Sprintf("member %s: %.0f failed resolves",
+				rate.Label, rate.Errors*elapsed))
+		}
+	}
+	if failed := counter(cur.Metrics, name("synchronizer", "failed_batches_total"), "", "") -
+		counter(prev.Metrics, name("synchronizer", "failed_batches_total"), "", ""); failed > 0 {
+		view.Errors = append(view.Errors, fmt.Sprintf("synchronizer: %.0f batches not resolved", failed))
+	}
+	if failed := counter(cur.Metrics, name("synchronizer", "events_total"), "result", metrics.ResultError) -
+		counter(prev.Metrics, name("synchronizer", "events_total"), "result", metrics.ResultError); failed > 0 {
+		view.Errors = append(view.Errors, fmt.Sprintf("synchronizer: %.0f events not processed", failed))
+	}
+
+	return view
+}
+
+// gauge returns the value of the gauge with the given name, 0 when not found
+func gauge(families map[string]*dto.MetricFamily, name string) float64 {
+	family, ok := families[name]
+	if !ok || len(family.GetMetric()) == 0 {
+		return 0
+	}
+
+	return family.GetMetric()[0].GetGauge().GetValue()
+}
+
+// counter returns the sum of the counters with the given name having the given label value, all of them
+// when label is empty
+func counter(families map[string]*dto.MetricFamily, name, label, value string) float64 {
+	family, ok := families[name]
+	if !ok {
+		return 0
+	}
+
+	var sum float64
+	for _, metric := range family.GetMetric() {
+		if label == "" || labelValue(metric, label) == value {
+			sum += metric.GetCounter().GetValue()
+		}
+	}
+
+	return sum
+}
+
+// rates returns the increase per second of the counters with the given name, by the value of the label,
+// the counters with the result label set to error being also counted as errors
+func rates(prev, cur map[string]*dto.MetricFamily, name, label string, elapsed float64) []Rate {
+	family, ok := cur[name]
+	if !ok {
+		return nil
+	}
+
+	byLabel := make(map[string]*Rate)
+	for _, metric := range family.GetMetric() {
+		value := labelValue(metric, label)
+		delta := metric.GetCounter().GetValue() - previous(prev[name], metric)
+		if delta <= 0 {
+			continue
+		}
+
+		rate, ok := byLabel[value]
+		if !ok {
+			rate = &Rate{Label: value}
+			byLabel[value] = rate
+		}
+		rate.Total += delta / elapsed
+		if labelValue(metric, "result") == metrics.ResultError {
+			rate.Errors += delta / elapsed
+		}
+	}
+
+	list := make([]Rate, 0, len(byLabel))
+	for _, rate := range byLabel {
+		list = append(list, *rate)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Total != list[j].Total {
+			return list[i].Total > list[j].Total
+		}
+		return list[i].Label < list[j].Label
+	})
+
+	return list
+}
+
+// previous returns the value in the previous sample of the counter with the same labels as metric
+func previous(family *dto.MetricFamily, metric *dto.Metric) float64 {
+	if family == nil {
+		return 0
+	}
+
+	for _, candidate := range family.GetMetric() {
+		if sameLabels(candidate, metric) {
+			return candidate.GetCounter().GetValue()
+		}
+	}
+
+	return 0
+}
+
+// sameLabels returns whether the two metrics have the same labels
+func sameLabels(a, b *dto.Metric) bool {
+	if len(a.GetLabel()) != len(b.GetLabel()) {
+		return false
+	}
+	for _, pair := range a.GetLabel() {
+		if labelValue(b, pair.GetName()) != pair.GetValue() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// labelValue returns the value of the label of the metric, empty when it does not have it
+func labelValue(metric *dto.Metric, name string) string {
+	for _, pair := range metric.GetLabel() {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,107 @@
+package dashboard
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/health"
+	"github.com/0xPolygon/cdk-data-availability/rpc"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/stretchr/testify/require"
+)
+
+// metricsText returns the metrics of a node having handled the given requests
+func metricsText(requests, errors int) string {
+	return fmt.Sprintf(`# TYPE dac_synchronizer_last_processed_block gauge
+dac_synchronizer_last_processed_block 90
+# TYPE dac_synchronizer_l1_head_block gauge
+dac_synchronizer_l1_head_block 100
+# TYPE dac_synchronizer_unresolved_batches gauge
+dac_synchronizer_unresolved_batches 3
+# TYPE dac_rpc_requests_total counter
+dac_rpc_requests_total{method="sync_getOffChainData",result="success"} %d
+dac_rpc_requests_total{method="sync_getOffChainData",result="error"} %d
+`, requests, errors)
+}
+
+// newNode returns a server answering the status endpoints and serving the given metrics
+func newNode(t *testing.T, metrics *string) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/metrics" {
+			_, _ = io.WriteString(w, *metrics)
+			return
+		}
+
+		var req rpc.Request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		var result interface{}
+		switch req.Method {
+		case "status_getStatus":
+			result = types.DACStatus{Version: "v1.0.0", Uptime: "1h", KeyCount: 42, BackfillProgress: 80}
+		case "status_getReadiness":
+			result = health.Report{Ready: true, Components: []health.ComponentStatus{{Name: "db", Status: "ready"}}}
+		}
+		encoded, err := json.Marshal(result)
+		require.NoError(t, err)
+		require.NoError(t, json.NewEncoder(w).Encode(rpc.Response{JSONRPC: "2.0", ID: req.ID, Result: encoded}))
+	}))
+}
+
+func TestDashboard(t *testing.T) {
+	t.Parallel()
+
+	metrics := metricsText(10, 0)
+	node := newNode(t, &metrics)
+	defer node.Close()
+
+	fetcher := NewFetcher(node.URL, node.URL+"/metrics", time.Second)
+	first := fetcher.Fetch(context.Background())
+	require.Empty(t, first.Errors)
+
+	metrics = metricsText(30, 2)
+	second := fetcher.Fetch(context.Background())
+	// the rates are computed over exactly two seconds
+	second.Time = first.Time.Add(2 * time.Second)
+
+	view := NewView(first, second)
+	require.Equal(t, "v1.0.0", view.Version)
+	require.True(t, view.Ready)
+	require.Equal(t, uint64(42), view.KeyCount)
+	// the last processed block of the metrics is more recent than the one of the status
+	require.Equal(t, uint64(90), view.LastProcessedBlock)
+	require.Equal(t, uint64(10), view.SyncLag)
+	require.Equal(t, uint64(3), view.UnresolvedBatches)
+	require.Equal(t, []Rate{{Label: "sync_getOffChainData", Total: 11, Errors: 1}}, view.RPC)
+	require.Equal(t, []string{"rpc sync_getOffChainData: 2 errors"}, view.Errors)
+
+	var out bytes.Buffer
+	Render(&out, view, false)
+	require.Contains(t, out.String(), "lag 10 blocks")
+	require.Contains(t, out.String(), "rpc sync_getOffChainData: 2 errors")
+	require.False(t, strings.HasPrefix(out.String(), clearScreen))
+}
+
+func TestFetchUnreachableNode(t *testing.T) {
+	t.Parallel()
+
+	node := httptest.NewServer(http.NotFoundHandler())
+	node.Close()
+
+	sample := NewFetcher(node.URL, node.URL+"/metrics", time.Second).Fetch(context.Background())
+	require.Len(t, sample.Errors, 3)
+
+	view := NewView(nil, sample)
+	require.False(t, view.Ready)
+	require.Len(t, view.Errors, 3)
+}
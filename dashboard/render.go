@@ -0,0 +1,64 @@
+package dashboard
+
+import (
+	"fmt"
+	"io"
+)
+
+// clearScreen moves the cursor home and clears the terminal, so that each view replaces the previous one
+const clearScreen = "\033[H\033[2J"
+
+// maxRows is the number of RPC methods and members listed at most
+const maxRows = 10
+
+// Render writes the view for the operators, clearing the terminal first when clear is set
+func Render(w io.Writer, v *View, clear bool) {
+	if clear {
+		fmt.Fprint(w, clearScreen)
+	}
+
+	ready := "READY"
+	if !v.Ready {
+		ready = "NOT READY"
+	}
+	fmt.Fprintf(w, "%s  version %s  up %s  %s\n\n", v.Time.Format("15:04:05"), v.Version, v.Uptime, ready)
+
+	fmt.Fprintf(w, "values stored         %d\n", v.KeyCount)
+	fmt.Fprintf(w, "last processed block  %d\n", v.LastProcessedBlock)
+	if v.L1Head > 0 {
+		fmt.Fprintf(w, "L1 head               %d  (lag %d blocks)\n", v.L1Head, v.SyncLag)
+	}
+	fmt.Fprintf(w, "unresolved batches    %d\n", v.UnresolvedBatches)
+
+	fmt.Fprintln(w, "\nDEPENDENCIES")
+	for _, component := range v.Components {
+		fmt.Fprintf(w, "  %-12s %-10s %s\n", component.Name, component.Status, component.Error)
+	}
+
+	renderRates(w, "RPC REQUESTS/S", v.RPC)
+	renderRates(w, "COMMITTEE RESOLVES/S", v.Members)
+
+	fmt.Fprintln(w, "\nRECENT ERRORS")
+	if len(v.Errors) == 0 {
+		fmt.Fprintln(w, "  none")
+	}
+	for _, err := range v.Errors {
+		fmt.Fprintf(w, "  %s\n", err)
+	}
+}
+
+// renderRates writes the busiest rates under the given title
+func renderRates(w io.Writer, title string, rates []Rate) {
+	fmt.Fprintf(w, "\n%s\n", title)
+	if len(rates) == 0 {
+		fmt.Fprintln(w, "  none")
+	}
+
+	for i, rate := range rates {
+		if i == maxRows {
+			fmt.Fprintln(w, "  ...")
+			break
+		}
+		fmt.Fprintf(w, "  %-44s %8.2f  %8.2f errors\n", rate.Label, rate.Total, rate.Errors)
+	}
+}
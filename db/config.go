@@ -4,7 +4,7 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/config/types"
 	"github.com/jmoiron/sqlx"
 )
 
@@ -16,8 +16,8 @@ type Config struct {
 	// Database User name
 	User string `mapstructure:"User"`
 
-	// Database Password of the user
-	Password string `mapstructure:"Password"`
+	// Database Password of the user, it can also reference a secret, e.g. vault://secret/data/dac#db_password
+	Password string `mapstructure:"Password" secret:"true"`
 
 	// Host address of database
 	Host string `mapstructure:"Host"`
@@ -31,6 +31,27 @@ type Config struct {
 
 	// MaxConns is the maximum number of connections in the pool.
 	MaxConns int `mapstructure:"MaxConns"`
+
+	// DisableAutoMigrate stops applying the pending migrations at startup. They are then applied with the
+	// migrate command and the node refuses to start while one is pending.
+	DisableAutoMigrate bool `mapstructure:"DisableAutoMigrate"`
+
+	// PreparedStatements prepares the queries run the most often once per connection, rather than having them
+	// parsed and planned on every execution. It must be disabled behind a pooler in transaction mode.
+	PreparedStatements bool `mapstructure:"PreparedStatements"`
+
+	// BackfillBatchSize is the maximum number of rows a backfill migrates in one transaction
+	BackfillBatchSize uint `mapstructure:"BackfillBatchSize"`
+
+	// BackfillPause is how long a backfill waits between two batches, leaving room to the other writes
+	BackfillPause types.Duration `mapstructure:"BackfillPause"`
+
+	// Memory keeps the state in memory rather than in the PostgreSQL database, which is then not used. The state
+	// is lost when the node stops, it is meant for the tests and the demo and development runs.
+	Memory bool `mapstructure:"Memory"`
+
+	// MemoryMaxSize is the maximum total size in bytes of the values kept in memory, unbounded when 0
+	MemoryMaxSize uint64 `mapstructure:"MemoryMaxSize"`
 }
 
 // InitContext initializes DB connection by the given config
@@ -40,16 +61,45 @@ func InitContext(ctx context.Context, cfg Config) (*sqlx.DB, error) {
 
 	conn, err := sqlx.ConnectContext(ctx, "postgres", psqlInfo)
 	if err != nil {
-		log.Errorf("Unable to connect to database: %v\n", err)
+		logger.Errorf("Unable to connect to database: %v\n", err)
 		return nil, err
 	}
 
 	conn.DB.SetMaxIdleConns(cfg.MaxConns)
 
 	if err = conn.PingContext(ctx); err != nil {
-		log.Errorf("Unable to ping the database: %v\n", err)
+		logger.Errorf("Unable to ping the database: %v\n", err)
 		return nil, err
 	}
 
 	return conn, nil
 }
+
+// NewFromConfig instantiates a DB on the pool, preparing the hot queries if the config enables it. The pool is
+// not used, and can be nil, when the config keeps the state in memory. The events of the stored values are
+// recorded in the outbox only when outbox is set, i.e. when they are streamed, since nothing else consumes them.
+func NewFromConfig(pg *sqlx.DB, cfg Config, outbox bool) DB {
+	if cfg.Memory {
+		m := newMemoryDB(cfg.MemoryMaxSize)
+		m.recordEvents = outbox
+		return instrument(m)
+	}
+
+	db := &pgDB{pg: pg, recordEvents: outbox}
+	if cfg.PreparedStatements {
+		db.stmts = newStatements(pg)
+	}
+
+	return instrument(db)
+}
+
+// SynchronousStandbyNames returns the synchronous_standby_names setting of the database, empty when
+// no standby is synchronous and the commits never wait for the replication
+func SynchronousStandbyNames(ctx context.Context, pg *sqlx.DB) (string, error) {
+	var names string
+	if err := pg.GetContext(ctx, &names, "SHOW synchronous_standby_names;"); err != nil {
+		return "", err
+	}
+
+	return names, nil
+}
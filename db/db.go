@@ -5,17 +5,32 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/0xPolygon/cdk-data-availability/log"
 	"github.com/0xPolygon/cdk-data-availability/types"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
+// logger is the logger of the db component
+var logger = log.WithComponent("db")
+
 var (
 	// ErrStateNotSynchronized indicates the state database may be empty
 	ErrStateNotSynchronized = errors.New("state not synchronized")
 )
 
+// Levels of synchronous_commit the values can be stored with by StoreOffChainDataDurably
+const (
+	// SynchronousCommitLocal waits for the commit to be flushed to the disk of the database
+	SynchronousCommitLocal = "local"
+	// SynchronousCommitRemoteApply also waits for the commit to be applied on the synchronous standbys
+	SynchronousCommitRemoteApply = "remote_apply"
+)
+
 // DB defines functions that a DB instance should implement
 type DB interface {
 	StoreLastProcessedBlock(ctx context.Context, block uint64, task string) error
@@ -27,21 +42,76 @@ type DB interface {
 
 	GetOffChainData(ctx context.Context, key common.Hash) (*types.OffChainData, error)
 	ListOffChainData(ctx context.Context, keys []common.Hash) ([]types.OffChainData, error)
+	ListOffChainDataByBatches(ctx context.Context, fromBatch, toBatch uint64, limit uint) ([]types.OffChainData, error)
+	ListOffChainDataPage(ctx context.Context, afterKey common.Hash, limit uint) ([]types.OffChainData, error)
 	StoreOffChainData(ctx context.Context, od []types.OffChainData) error
+	StoreOffChainDataDurably(ctx context.Context, od []types.OffChainData, synchronousCommit string) error
+	DeleteOffChainData(ctx context.Context, keys []common.Hash) error
+	CountOffChainDataBefore(ctx context.Context, beforeBatch uint64) (uint64, uint64, error)
+	PruneOffChainData(ctx context.Context, tombstones []types.Tombstone) error
+	GetTombstones(ctx context.Context, keys []common.Hash) ([]types.Tombstone, error)
+	VacuumOffChainData(ctx context.Context) error
 
 	CountOffchainData(ctx context.Context) (uint64, error)
+
+	StoreSignAuditEntry(ctx context.Context, entry types.SignAuditEntry) error
+	ListSignAuditEntries(ctx context.Context, fromID uint64, limit uint) ([]types.SignAuditEntry, error)
+	HasSignedSequence(ctx context.Context, sequenceHash common.Hash) (bool, error)
+
+	StoreCommitteeChanges(ctx context.Context, changes []types.CommitteeChange) error
+	ListCommitteeChanges(ctx context.Context, fromID uint64, limit uint) ([]types.CommitteeChange, error)
+
+	GetUnpublishedOffChainData(ctx context.Context, backend string, limit uint) ([]types.OffChainData, error)
+	StorePublication(ctx context.Context, publication types.Publication) error
+	GetPublication(ctx context.Context, key common.Hash, backend string) (*types.Publication, error)
+
+	GetOffChainDataKeys(ctx context.Context, fromBatch uint64, limit uint) ([]types.BatchKey, error)
+	StoreAttestation(ctx context.Context, attestation types.Attestation) error
+	GetLastAttestation(ctx context.Context) (*types.Attestation, error)
+
+	GetUncertifiedKeys(ctx context.Context, limit uint) ([]common.Hash, error)
+	StoreCertificate(ctx context.Context, certificate types.Certificate) error
+	GetCertificate(ctx context.Context, dataHash common.Hash) (*types.Certificate, error)
+
+	GetOutboxEvents(ctx context.Context, limit uint) ([]types.StoredDataEvent, error)
+	DeleteOutboxEvents(ctx context.Context, ids []uint64) error
+
+	GetUnshardedOffChainData(ctx context.Context, limit uint) ([]types.OffChainData, error)
+	StoreShards(ctx context.Context, commitment types.ShardCommitment, shards []types.Shard) error
+	GetShardCommitment(ctx context.Context, key common.Hash) (*types.ShardCommitment, error)
+	GetShards(ctx context.Context, key common.Hash, indices []uint) ([]types.Shard, error)
+
+	StoreServedValues(ctx context.Context, served []types.ServedValue) error
+	GetServedValue(ctx context.Context, key common.Hash) (*types.ServedValue, error)
+
+	StoreProvenance(ctx context.Context, provenance []types.Provenance) error
+	GetProvenance(ctx context.Context, key common.Hash) ([]types.Provenance, error)
 }
 
 // DB is the database layer of the data node
 type pgDB struct {
 	pg *sqlx.DB
+	// stmts caches the prepared statements of the hot queries, nil when they are not prepared
+	stmts *statements
+	// recordEvents records the events of the stored values in the outbox, for stream.Worker to deliver them
+	recordEvents bool
 }
 
-// New instantiates a DB
+// New instantiates a DB, recording the metrics of its operations. The events of the stored values are not recorded
+// in the outbox, see NewFromConfig.
 func New(pg *sqlx.DB) DB {
-	return &pgDB{
+	return instrument(&pgDB{
 		pg: pg,
-	}
+	})
+}
+
+// NewPrepared instantiates a DB like New, the queries run the most often, reading and storing the values,
+// being prepared rather than parsed and planned on every execution
+func NewPrepared(pg *sqlx.DB) DB {
+	return instrument(&pgDB{
+		pg:    pg,
+		stmts: newStatements(pg),
+	})
 }
 
 // StoreLastProcessedBlock stores a record of a block processed by the synchronizer for named task
@@ -164,8 +234,33 @@ func (db *pgDB) DeleteUnresolvedBatchKeys(ctx context.Context, bks []types.Batch
 	return tx.Commit()
 }
 
-// StoreOffChainData stores and array of key values in the Db
+// StoreOffChainData stores and array of key values in the Db, along with the events
+// of the transactional outbox streamed to the consumers of the DA events if enabled
 func (db *pgDB) StoreOffChainData(ctx context.Context, od []types.OffChainData) error {
+	return db.storeOffChainData(ctx, od, "")
+}
+
+// StoreOffChainDataDurably stores the values like StoreOffChainData, the commit returning once it
+// reached the given synchronous_commit level, e.g. SynchronousCommitRemoteApply
+func (db *pgDB) StoreOffChainDataDurably(
+	ctx context.Context,
+	od []types.OffChainData,
+	synchronousCommit string,
+) error {
+	switch synchronousCommit {
+	case SynchronousCommitLocal, SynchronousCommitRemoteApply:
+	default:
+		return fmt.Errorf("unsupported synchronous_commit level %q", synchronousCommit)
+	}
+
+	return db.storeOffChainData(ctx, od, synchronousCommit)
+}
+
+// storeOffChainData stores the values and their events, if the outbox is enabled, in a transaction, overriding
+// the synchronous_commit setting of the database for it if set
+func (db *pgDB) storeOffChainData(ctx context.Context, od []types.OffChainData, synchronousCommit string) error {
+	const setSynchronousCommitSQL = `SELECT set_config('synchronous_commit', $1, true);`
+
 	const storeOffChainDataSQL = `
 		INSERT INTO data_node.offchain_data (key, value, batch_num)
 		VALUES ($1, $2, $3)
@@ -173,18 +268,39 @@ func (db *pgDB) StoreOffChainData(ctx context.Context, od []types.OffChainData)
 		SET value = EXCLUDED.value, batch_num = EXCLUDED.batch_num;
 	`
 
+	const storeOutboxEventSQL = `
+		INSERT INTO data_node.outbox (key, batch_num, size, created_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (key) DO UPDATE
+		SET id = nextval('data_node.outbox_id_seq'), batch_num = EXCLUDED.batch_num,
+			size = EXCLUDED.size, created_at = EXCLUDED.created_at;
+	`
+
 	tx, err := db.pg.BeginTxx(ctx, nil)
 	if err != nil {
 		return err
 	}
 
+	if synchronousCommit != "" {
+		if _, err = tx.ExecContext(ctx, setSynchronousCommitSQL, synchronousCommit); err != nil {
+			if txErr := tx.Rollback(); txErr != nil {
+				return fmt.Errorf("%v: rollback caused by %v", txErr, err)
+			}
+
+			return err
+		}
+	}
+
 	for _, d := range od {
-		if _, err = tx.ExecContext(
-			ctx, storeOffChainDataSQL,
+		if _, err = db.txExec(
+			ctx, tx, storeOffChainDataSQL,
 			d.Key.Hex(),
 			common.Bytes2Hex(d.Value),
 			d.BatchNum,
-		); err != nil {
+		); err == nil && db.recordEvents {
+			_, err = db.txExec(ctx, tx, storeOutboxEventSQL, d.Key.Hex(), d.BatchNum, len(d.Value))
+		}
+		if err != nil {
 			if txErr := tx.Rollback(); txErr != nil {
 				return fmt.Errorf("%v: rollback caused by %v", txErr, err)
 			}
@@ -210,7 +326,7 @@ func (db *pgDB) GetOffChainData(ctx context.Context, key common.Hash) (*types.Of
 		BatchNum uint64 `db:"batch_num"`
 	}{}
 
-	if err := db.pg.QueryRowxContext(ctx, getOffchainDataSQL, key.Hex()).StructScan(&data); err != nil {
+	if err := db.queryRowx(ctx, getOffchainDataSQL, key.Hex()).StructScan(&data); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrStateNotSynchronized
 		}
@@ -231,10 +347,11 @@ func (db *pgDB) ListOffChainData(ctx context.Context, keys []common.Hash) ([]typ
 		return nil, nil
 	}
 
+	// the keys are passed as an array, so that the query is the same whatever their number
 	const listOffchainDataSQL = `
 		SELECT key, value, batch_num
 		FROM data_node.offchain_data 
-		WHERE key IN (?);
+		WHERE key = ANY($1);
 	`
 
 	preparedKeys := make([]string, len(keys))
@@ -242,22 +359,57 @@ func (db *pgDB) ListOffChainData(ctx context.Context, keys []common.Hash) ([]typ
 		preparedKeys[i] = key.Hex()
 	}
 
-	query, args, err := sqlx.In(listOffchainDataSQL, preparedKeys)
+	rows, err := db.queryx(ctx, listOffchainDataSQL, pq.Array(preparedKeys))
 	if err != nil {
 		return nil, err
 	}
 
-	// sqlx.In returns queries with the `?` bindvar, we can rebind it for our backend
-	query = db.pg.Rebind(query)
+	defer rows.Close()
+
+	list := make([]types.OffChainData, 0, len(keys))
+	for rows.Next() {
+		data := struct {
+			Key      string `db:"key"`
+			Value    string `db:"value"`
+			BatchNum uint64 `db:"batch_num"`
+		}{}
+		if err = rows.StructScan(&data); err != nil {
+			return nil, err
+		}
+
+		list = append(list, types.OffChainData{
+			Key:      common.HexToHash(data.Key),
+			Value:    common.FromHex(data.Value),
+			BatchNum: data.BatchNum,
+		})
+	}
+
+	return list, nil
+}
+
+// ListOffChainDataByBatches returns the values stored for the batches in [fromBatch, toBatch],
+// lowest batch number first
+func (db *pgDB) ListOffChainDataByBatches(
+	ctx context.Context,
+	fromBatch, toBatch uint64,
+	limit uint,
+) ([]types.OffChainData, error) {
+	const listOffChainDataByBatchesSQL = `
+		SELECT key, value, batch_num
+		FROM data_node.offchain_data
+		WHERE batch_num BETWEEN $1 AND $2
+		ORDER BY batch_num, key
+		LIMIT $3;
+	`
 
-	rows, err := db.pg.QueryxContext(ctx, query, args...)
+	rows, err := db.pg.QueryxContext(ctx, listOffChainDataByBatchesSQL, fromBatch, toBatch, limit)
 	if err != nil {
 		return nil, err
 	}
 
 	defer rows.Close()
 
-	list := make([]types.OffChainData, 0, len(keys))
+	list := make([]types.OffChainData, 0)
 	for rows.Next() {
 		data := struct {
 			Key      string `db:"key"`
@@ -275,7 +427,206 @@ func (db *pgDB) ListOffChainData(ctx context.Context, keys []common.Hash) ([]typ
 		})
 	}
 
-	return list, nil
+	return list, rows.Err()
+}
+
+// ListOffChainDataPage returns the values whose key follows the given key, ordered by key, so that
+// every value is listed by requesting the page after the last key of the previous one
+func (db *pgDB) ListOffChainDataPage(
+	ctx context.Context,
+	afterKey common.Hash,
+	limit uint,
+) ([]types.OffChainData, error) {
+	const listOffChainDataPageSQL = `
+		SELECT key, value, batch_num
+		FROM data_node.offchain_data
+		WHERE key > $1
+		ORDER BY key
+		LIMIT $2;
+	`
+
+	rows, err := db.pg.QueryxContext(ctx, listOffChainDataPageSQL, afterKey.Hex(), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	list := make([]types.OffChainData, 0)
+	for rows.Next() {
+		data := struct {
+			Key      string `db:"key"`
+			Value    string `db:"value"`
+			BatchNum uint64 `db:"batch_num"`
+		}{}
+		if err = rows.StructScan(&data); err != nil {
+			return nil, err
+		}
+
+		list = append(list, types.OffChainData{
+			Key:      common.HexToHash(data.Key),
+			Value:    common.FromHex(data.Value),
+			BatchNum: data.BatchNum,
+		})
+	}
+
+	return list, rows.Err()
+}
+
+// DeleteOffChainData deletes the values identified by the given keys
+func (db *pgDB) DeleteOffChainData(ctx context.Context, keys []common.Hash) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	const deleteOffchainDataSQL = `
+		DELETE FROM data_node.offchain_data
+		WHERE key IN (?);
+	`
+
+	preparedKeys := make([]string, len(keys))
+	for i, key := range keys {
+		preparedKeys[i] = key.Hex()
+	}
+
+	query, args, err := sqlx.In(deleteOffchainDataSQL, preparedKeys)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.pg.ExecContext(ctx, db.pg.Rebind(query), args...)
+	return err
+}
+
+// CountOffChainDataBefore returns the number and the total size of the values of the batches before the
+// given batch, the values not yet matched to a batch excluded
+func (db *pgDB) CountOffChainDataBefore(ctx context.Context, beforeBatch uint64) (uint64, uint64, error) {
+	const countOffChainDataBeforeSQL = `
+		SELECT COUNT(*), COALESCE(SUM(LENGTH(value) / 2), 0)
+		FROM data_node.offchain_data
+		WHERE batch_num > 0 AND batch_num < $1;
+	`
+
+	var count, size uint64
+	if err := db.pg.QueryRowContext(ctx, countOffChainDataBeforeSQL, beforeBatch).Scan(&count, &size); err != nil {
+		return 0, 0, err
+	}
+
+	return count, size, nil
+}
+
+// PruneOffChainData deletes the values of the tombstones, recording the tombstones in the same transaction
+// so a value is never deleted without a record of its deletion
+func (db *pgDB) PruneOffChainData(ctx context.Context, tombstones []types.Tombstone) error {
+	const (
+		storeTombstoneSQL = `
+			INSERT INTO data_node.tombstones (key, batch_num, pruned_at_block, reason, signature, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (key) DO UPDATE
+			SET batch_num = EXCLUDED.batch_num, pruned_at_block = EXCLUDED.pruned_at_block,
+				reason = EXCLUDED.reason, signature = EXCLUDED.signature, created_at = EXCLUDED.created_at;
+		`
+		deleteOffChainDataSQL = `DELETE FROM data_node.offchain_data WHERE key = $1;`
+	)
+
+	tx, err := db.pg.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range tombstones {
+		var signature interface{}
+		if len(t.Signature) > 0 {
+			signature = common.Bytes2Hex(t.Signature)
+		}
+
+		if _, err = tx.ExecContext(
+			ctx, storeTombstoneSQL,
+			t.Key.Hex(),
+			uint64(t.BatchNum),
+			uint64(t.PrunedAtBlock),
+			t.Reason,
+			signature,
+			t.Timestamp,
+		); err == nil {
+			_, err = tx.ExecContext(ctx, deleteOffChainDataSQL, t.Key.Hex())
+		}
+		if err != nil {
+			if txErr := tx.Rollback(); txErr != nil {
+				return fmt.Errorf("%v: rollback caused by %v", txErr, err)
+			}
+
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetTombstones returns the tombstones of the given keys, the keys of the values never pruned omitted
+func (db *pgDB) GetTombstones(ctx context.Context, keys []common.Hash) ([]types.Tombstone, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	const getTombstonesSQL = `
+		SELECT key, batch_num, pruned_at_block, reason, signature, created_at
+		FROM data_node.tombstones
+		WHERE key IN (?);
+	`
+
+	preparedKeys := make([]string, len(keys))
+	for i, key := range keys {
+		preparedKeys[i] = key.Hex()
+	}
+
+	query, args, err := sqlx.In(getTombstonesSQL, preparedKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.pg.QueryxContext(ctx, db.pg.Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	tombstones := make([]types.Tombstone, 0, len(keys))
+	for rows.Next() {
+		tombstone := struct {
+			Key           string         `db:"key"`
+			BatchNum      uint64         `db:"batch_num"`
+			PrunedAtBlock uint64         `db:"pruned_at_block"`
+			Reason        string         `db:"reason"`
+			Signature     sql.NullString `db:"signature"`
+			CreatedAt     time.Time      `db:"created_at"`
+		}{}
+		if err = rows.StructScan(&tombstone); err != nil {
+			return nil, err
+		}
+
+		t := types.Tombstone{
+			Key:           common.HexToHash(tombstone.Key),
+			BatchNum:      types.ArgUint64(tombstone.BatchNum),
+			PrunedAtBlock: types.ArgUint64(tombstone.PrunedAtBlock),
+			Reason:        tombstone.Reason,
+			Timestamp:     tombstone.CreatedAt,
+		}
+		if tombstone.Signature.Valid {
+			t.Signature = common.FromHex(tombstone.Signature.String)
+		}
+		tombstones = append(tombstones, t)
+	}
+
+	return tombstones, rows.Err()
+}
+
+// VacuumOffChainData rewrites the table of the values, so the rows deleted no longer remain in its files
+// until their space is reused. The table is locked while it is rewritten.
+func (db *pgDB) VacuumOffChainData(ctx context.Context) error {
+	_, err := db.pg.ExecContext(ctx, "VACUUM FULL data_node.offchain_data;")
+	return err
 }
 
 // CountOffchainData returns the count of rows in the offchain_data table
@@ -289,3 +640,864 @@ func (db *pgDB) CountOffchainData(ctx context.Context) (uint64, error) {
 
 	return count, nil
 }
+
+// StoreSignAuditEntry appends a record of a request to sign a sequence to the audit log
+func (db *pgDB) StoreSignAuditEntry(ctx context.Context, entry types.SignAuditEntry) error {
+	const storeSignAuditEntrySQL = `
+		INSERT INTO data_node.sign_audit (requester, sequence_hash, batch_count, decision, signature, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6);
+	`
+
+	if _, err := db.pg.ExecContext(
+		ctx, storeSignAuditEntrySQL,
+		entry.Requester.Hex(),
+		entry.SequenceHash.Hex(),
+		entry.BatchCount,
+		string(entry.Decision),
+		common.Bytes2Hex(entry.Signature),
+		entry.Timestamp,
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// HasSignedSequence returns whether the audit log records the signature of the sequence with the given hash
+func (db *pgDB) HasSignedSequence(ctx context.Context, sequenceHash common.Hash) (bool, error) {
+	const hasSignedSequenceSQL = `
+		SELECT EXISTS (
+			SELECT 1 FROM data_node.sign_audit WHERE sequence_hash = $1 AND decision = $2
+		);
+	`
+
+	var exists bool
+	if err := db.queryRowx(
+		ctx, hasSignedSequenceSQL, sequenceHash.Hex(), string(types.SignDecisionSigned),
+	).Scan(&exists); err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// ListSignAuditEntries returns the records of the audit log starting at the given ID, oldest first
+func (db *pgDB) ListSignAuditEntries(ctx context.Context, fromID uint64, limit uint) ([]types.SignAuditEntry, error) {
+	const listSignAuditEntriesSQL = `
+		SELECT id, requester, sequence_hash, batch_count, decision, signature, created_at
+		FROM data_node.sign_audit
+		WHERE id >= $1
+		ORDER BY id
+		LIMIT $2;
+	`
+
+	rows, err := db.pg.QueryxContext(ctx, listSignAuditEntriesSQL, fromID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	entries := make([]types.SignAuditEntry, 0)
+	for rows.Next() {
+		entry := struct {
+			ID           uint64    `db:"id"`
+			Requester    string    `db:"requester"`
+			SequenceHash string    `db:"sequence_hash"`
+			BatchCount   uint64    `db:"batch_count"`
+			Decision     string    `db:"decision"`
+			Signature    string    `db:"signature"`
+			CreatedAt    time.Time `db:"created_at"`
+		}{}
+		if err = rows.StructScan(&entry); err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, types.SignAuditEntry{
+			ID:           entry.ID,
+			Requester:    common.HexToAddress(entry.Requester),
+			SequenceHash: common.HexToHash(entry.SequenceHash),
+			BatchCount:   entry.BatchCount,
+			Decision:     types.SignDecision(entry.Decision),
+			Signature:    common.FromHex(entry.Signature),
+			Timestamp:    entry.CreatedAt,
+		})
+	}
+
+	return entries, rows.Err()
+}
+
+// StoreCommitteeChanges appends the changes of the committee members observed on L1
+func (db *pgDB) StoreCommitteeChanges(ctx context.Context, changes []types.CommitteeChange) error {
+	const storeCommitteeChangeSQL = `
+		INSERT INTO data_node.committee_changes
+			(block_number, tx_hash, kind, member, url, previous_url, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7);
+	`
+
+	tx, err := db.pg.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range changes {
+		if _, err = tx.ExecContext(
+			ctx, storeCommitteeChangeSQL,
+			c.BlockNumber,
+			c.TxHash.Hex(),
+			string(c.Kind),
+			c.Member.Hex(),
+			c.URL,
+			c.PreviousURL,
+			c.Timestamp,
+		); err != nil {
+			if txErr := tx.Rollback(); txErr != nil {
+				return fmt.Errorf("%v: rollback caused by %v", txErr, err)
+			}
+
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ListCommitteeChanges returns the changes of the committee members starting at the given ID, oldest first
+func (db *pgDB) ListCommitteeChanges(ctx context.Context, fromID uint64, limit uint) ([]types.CommitteeChange, error) {
+	const listCommitteeChangesSQL = `
+		SELECT id, block_number, tx_hash, kind, member, url, previous_url, created_at
+		FROM data_node.committee_changes
+		WHERE id >= $1
+		ORDER BY id
+		LIMIT $2;
+	`
+
+	rows, err := db.pg.QueryxContext(ctx, listCommitteeChangesSQL, fromID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	changes := make([]types.CommitteeChange, 0)
+	for rows.Next() {
+		change := struct {
+			ID          uint64    `db:"id"`
+			BlockNumber uint64    `db:"block_number"`
+			TxHash      string    `db:"tx_hash"`
+			Kind        string    `db:"kind"`
+			Member      string    `db:"member"`
+			URL         string    `db:"url"`
+			PreviousURL string    `db:"previous_url"`
+			CreatedAt   time.Time `db:"created_at"`
+		}{}
+		if err = rows.StructScan(&change); err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, types.CommitteeChange{
+			ID:          change.ID,
+			BlockNumber: change.BlockNumber,
+			TxHash:      common.HexToHash(change.TxHash),
+			Kind:        types.CommitteeChangeKind(change.Kind),
+			Member:      common.HexToAddress(change.Member),
+			URL:         change.URL,
+			PreviousURL: change.PreviousURL,
+			Timestamp:   change.CreatedAt,
+		})
+	}
+
+	return changes, rows.Err()
+}
+
+// GetUnpublishedOffChainData returns the values not yet published to the given backend, lowest batch number first
+func (db *pgDB) GetUnpublishedOffChainData(
+	ctx context.Context,
+	backend string,
+	limit uint,
+) ([]types.OffChainData, error) {
+	const getUnpublishedOffChainDataSQL = `
+		SELECT o.key, o.value, o.batch_num
+		FROM data_node.offchain_data o
+		LEFT JOIN data_node.publications p ON p.key = o.key AND p.backend = $1
+		WHERE p.key IS NULL
+		ORDER BY o.batch_num
+		LIMIT $2;
+	`
+
+	rows, err := db.pg.QueryxContext(ctx, getUnpublishedOffChainDataSQL, backend, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	list := make([]types.OffChainData, 0)
+	for rows.Next() {
+		data := struct {
+			Key      string `db:"key"`
+			Value    string `db:"value"`
+			BatchNum uint64 `db:"batch_num"`
+		}{}
+		if err = rows.StructScan(&data); err != nil {
+			return nil, err
+		}
+
+		list = append(list, types.OffChainData{
+			Key:      common.HexToHash(data.Key),
+			Value:    common.FromHex(data.Value),
+			BatchNum: data.BatchNum,
+		})
+	}
+
+	return list, rows.Err()
+}
+
+// StorePublication records a value published to an external storage backend
+func (db *pgDB) StorePublication(ctx context.Context, publication types.Publication) error {
+	const storePublicationSQL = `
+		INSERT INTO data_node.publications (key, backend, reference, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (key, backend) DO UPDATE
+		SET reference = EXCLUDED.reference, created_at = EXCLUDED.created_at;
+	`
+
+	_, err := db.pg.ExecContext(
+		ctx, storePublicationSQL,
+		publication.Key.Hex(),
+		publication.Backend,
+		publication.Reference,
+		publication.Timestamp,
+	)
+
+	return err
+}
+
+// GetPublication returns the publication of the value identified by the key to the given backend
+func (db *pgDB) GetPublication(ctx context.Context, key common.Hash, backend string) (*types.Publication, error) {
+	const getPublicationSQL = `
+		SELECT key, backend, reference, created_at
+		FROM data_node.publications
+		WHERE key = $1 AND backend = $2
+		LIMIT 1;
+	`
+
+	publication := struct {
+		Key       string    `db:"key"`
+		Backend   string    `db:"backend"`
+		Reference string    `db:"reference"`
+		CreatedAt time.Time `db:"created_at"`
+	}{}
+
+	if err := db.pg.QueryRowxContext(ctx, getPublicationSQL, key.Hex(), backend).StructScan(&publication); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrStateNotSynchronized
+		}
+
+		return nil, err
+	}
+
+	return &types.Publication{
+		Key:       common.HexToHash(publication.Key),
+		Backend:   publication.Backend,
+		Reference: publication.Reference,
+		Timestamp: publication.CreatedAt,
+	}, nil
+}
+
+// GetOffChainDataKeys returns the keys of the values stored for the batches starting at the given one,
+// lowest batch number first
+func (db *pgDB) GetOffChainDataKeys(ctx context.Context, fromBatch uint64, limit uint) ([]types.BatchKey, error) {
+	const getOffChainDataKeysSQL = `
+		SELECT batch_num, key
+		FROM data_node.offchain_data
+		WHERE batch_num >= $1
+		ORDER BY batch_num, key
+		LIMIT $2;
+	`
+
+	rows, err := db.pg.QueryxContext(ctx, getOffChainDataKeysSQL, fromBatch, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	keys := make([]types.BatchKey, 0)
+	for rows.Next() {
+		key := struct {
+			BatchNum uint64 `db:"batch_num"`
+			Key      string `db:"key"`
+		}{}
+		if err = rows.StructScan(&key); err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, types.BatchKey{
+			Number: key.BatchNum,
+			Hash:   common.HexToHash(key.Key),
+		})
+	}
+
+	return keys, rows.Err()
+}
+
+// StoreAttestation records an attestation submitted to L1
+func (db *pgDB) StoreAttestation(ctx context.Context, attestation types.Attestation) error {
+	const storeAttestationSQL = `
+		INSERT INTO data_node.attestations (root, from_batch, to_batch, key_count, tx_hash, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6);
+	`
+
+	_, err := db.pg.ExecContext(
+		ctx, storeAttestationSQL,
+		attestation.Root.Hex(),
+		attestation.FromBatch,
+		attestation.ToBatch,
+		attestation.KeyCount,
+		attestation.TxHash.Hex(),
+		attestation.Timestamp,
+	)
+
+	return err
+}
+
+// GetLastAttestation returns the latest attestation submitted to L1
+func (db *pgDB) GetLastAttestation(ctx context.Context) (*types.Attestation, error) {
+	const getLastAttestationSQL = `
+		SELECT id, root, from_batch, to_batch, key_count, tx_hash, created_at
+		FROM data_node.attestations
+		ORDER BY id DESC
+		LIMIT 1;
+	`
+
+	attestation := struct {
+		ID        uint64    `db:"id"`
+		Root      string    `db:"root"`
+		FromBatch uint64    `db:"from_batch"`
+		ToBatch   uint64    `db:"to_batch"`
+		KeyCount  uint32    `db:"key_count"`
+		TxHash    string    `db:"tx_hash"`
+		CreatedAt time.Time `db:"created_at"`
+	}{}
+
+	if err := db.pg.QueryRowxContext(ctx, getLastAttestationSQL).StructScan(&attestation); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrStateNotSynchronized
+		}
+
+		return nil, err
+	}
+
+	return &types.Attestation{
+		ID:        attestation.ID,
+		Root:      common.HexToHash(attestation.Root),
+		FromBatch: attestation.FromBatch,
+		ToBatch:   attestation.ToBatch,
+		KeyCount:  attestation.KeyCount,
+		TxHash:    common.HexToHash(attestation.TxHash),
+		Timestamp: attestation.CreatedAt,
+	}, nil
+}
+
+// GetUncertifiedKeys returns the keys of the values without an availability certificate, lowest batch number first
+func (db *pgDB) GetUncertifiedKeys(ctx context.Context, limit uint) ([]common.Hash, error) {
+	const getUncertifiedKeysSQL = `
+		SELECT o.key
+		FROM data_node.offchain_data o
+		LEFT JOIN data_node.certificates c ON c.data_hash = o.key
+		WHERE c.data_hash IS NULL
+		ORDER BY o.batch_num
+		LIMIT $1;
+	`
+
+	rows, err := db.pg.QueryxContext(ctx, getUncertifiedKeysSQL, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	keys := make([]common.Hash, 0)
+	for rows.Next() {
+		var key string
+		if err = rows.Scan(&key); err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, common.HexToHash(key))
+	}
+
+	return keys, rows.Err()
+}
+
+// StoreCertificate stores the availability certificate of a value, replacing the previous one
+func (db *pgDB) StoreCertificate(ctx context.Context, certificate types.Certificate) error {
+	const storeCertificateSQL = `
+		INSERT INTO data_node.certificates (data_hash, committee_hash, threshold, signatures, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (data_hash) DO UPDATE
+		SET committee_hash = EXCLUDED.committee_hash, threshold = EXCLUDED.threshold,
+			signatures = EXCLUDED.signatures, created_at = EXCLUDED.created_at;
+	`
+
+	signatures := make([]byte, 0, len(certificate.Signatures)*crypto.SignatureLength)
+	for _, signature := range certificate.Signatures {
+		signatures = append(signatures, signature...)
+	}
+
+	_, err := db.pg.ExecContext(
+		ctx, storeCertificateSQL,
+		certificate.DataHash.Hex(),
+		certificate.CommitteeHash.Hex(),
+		certificate.Threshold,
+		common.Bytes2Hex(signatures),
+		certificate.Timestamp,
+	)
+
+	return err
+}
+
+// GetCertificate returns the availability certificate of the value of the given hash
+func (db *pgDB) GetCertificate(ctx context.Context, dataHash common.Hash) (*types.Certificate, error) {
+	const getCertificateSQL = `
+		SELECT data_hash, committee_hash, threshold, signatures, created_at
+		FROM data_node.certificates
+		WHERE data_hash = $1
+		LIMIT 1;
+	`
+
+	certificate := struct {
+		DataHash      string    `db:"data_hash"`
+		CommitteeHash string    `db:"committee_hash"`
+		Threshold     uint64    `db:"threshold"`
+		Signatures    string    `db:"signatures"`
+		CreatedAt     time.Time `db:"created_at"`
+	}{}
+
+	if err := db.pg.QueryRowxContext(ctx, getCertificateSQL, dataHash.Hex()).StructScan(&certificate); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrStateNotSynchronized
+		}
+
+		return nil, err
+	}
+
+	raw := common.FromHex(certificate.Signatures)
+	if len(raw)%crypto.SignatureLength != 0 {
+		return nil, fmt.Errorf("invalid signatures of the certificate of %s", certificate.DataHash)
+	}
+
+	signatures := make([]types.ArgBytes, 0, len(raw)/crypto.SignatureLength)
+	for i := 0; i < len(raw); i += crypto.SignatureLength {
+		signatures = append(signatures, raw[i:i+crypto.SignatureLength])
+	}
+
+	return &types.Certificate{
+		DataHash:      common.HexToHash(certificate.DataHash),
+		CommitteeHash: common.HexToHash(certificate.CommitteeHash),
+		Threshold:     certificate.Threshold,
+		Signatures:    signatures,
+		Timestamp:     certificate.CreatedAt,
+	}, nil
+}
+
+// GetOutboxEvents returns the events of the transactional outbox not yet streamed, oldest first
+func (db *pgDB) GetOutboxEvents(ctx context.Context, limit uint) ([]types.StoredDataEvent, error) {
+	const getOutboxEventsSQL = `
+		SELECT id, key, batch_num, size, created_at
+		FROM data_node.outbox
+		ORDER BY id
+		LIMIT $1;
+	`
+
+	rows, err := db.pg.QueryxContext(ctx, getOutboxEventsSQL, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	events := make([]types.StoredDataEvent, 0)
+	for rows.Next() {
+		event := struct {
+			ID        uint64    `db:"id"`
+			Key       string    `db:"key"`
+			BatchNum  uint64    `db:"batch_num"`
+			Size      uint64    `db:"size"`
+			CreatedAt time.Time `db:"created_at"`
+		}{}
+		if err = rows.StructScan(&event); err != nil {
+			return nil, err
+		}
+
+		events = append(events, types.StoredDataEvent{
+			ID:       event.ID,
+			Key:      common.HexToHash(event.Key),
+			BatchNum: event.BatchNum,
+			Size:     event.Size,
+			StoredAt: event.CreatedAt,
+		})
+	}
+
+	return events, rows.Err()
+}
+
+// DeleteOutboxEvents deletes the streamed events of the transactional outbox. The events of the values
+// stored again since they were read have a new ID, so they are kept to be streamed again.
+func (db *pgDB) DeleteOutboxEvents(ctx context.Context, ids []uint64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	const deleteOutboxEventsSQL = `
+		DELETE FROM data_node.outbox
+		WHERE id IN (?);
+	`
+
+	query, args, err := sqlx.In(deleteOutboxEventsSQL, ids)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.pg.ExecContext(ctx, db.pg.Rebind(query), args...)
+	return err
+}
+
+// GetUnshardedOffChainData returns the values not yet erasure coded into shards, lowest batch number first
+func (db *pgDB) GetUnshardedOffChainData(ctx context.Context, limit uint) ([]types.OffChainData, error) {
+	const getUnshardedOffChainDataSQL = `
+		SELECT o.key, o.value, o.batch_num
+		FROM data_node.offchain_data o
+		LEFT JOIN data_node.shard_commitments s ON s.key = o.key
+		WHERE s.key IS NULL
+		ORDER BY o.batch_num
+		LIMIT $1;
+	`
+
+	rows, err := db.pg.QueryxContext(ctx, getUnshardedOffChainDataSQL, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	list := make([]types.OffChainData, 0)
+	for rows.Next() {
+		data := struct {
+			Key      string `db:"key"`
+			Value    string `db:"value"`
+			BatchNum uint64 `db:"batch_num"`
+		}{}
+		if err = rows.StructScan(&data); err != nil {
+			return nil, err
+		}
+
+		list = append(list, types.OffChainData{
+			Key:      common.HexToHash(data.Key),
+			Value:    common.FromHex(data.Value),
+			BatchNum: data.BatchNum,
+		})
+	}
+
+	return list, rows.Err()
+}
+
+// StoreShards records the commitment to the shards of a value along with the shards held by the node
+func (db *pgDB) StoreShards(ctx context.Context, commitment types.ShardCommitment, shards []types.Shard) error {
+	const storeShardCommitmentSQL = `
+		INSERT INTO data_node.shard_commitments
+			(key, root, data_shards, parity_shards, size, member_index, members, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (key) DO NOTHING;
+	`
+
+	const storeShardSQL = `
+		INSERT INTO data_node.shards (key, idx, data, proof)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (key, idx) DO NOTHING;
+	`
+
+	tx, err := db.pg.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(
+		ctx, storeShardCommitmentSQL,
+		commitment.Key.Hex(),
+		commitment.Root.Hex(),
+		commitment.DataShards,
+		commitment.ParityShards,
+		commitment.Size,
+		commitment.MemberIndex,
+		commitment.Members,
+		commitment.Timestamp,
+	); err != nil {
+		if txErr := tx.Rollback(); txErr != nil {
+			return fmt.Errorf("%v: rollback caused by %v", txErr, err)
+		}
+
+		return err
+	}
+
+	for _, shard := range shards {
+		proof := make([]byte, 0, len(shard.Proof)*common.HashLength)
+		for _, hash := range shard.Proof {
+			proof = append(proof, hash.Bytes()...)
+		}
+
+		if _, err = tx.ExecContext(
+			ctx, storeShardSQL,
+			shard.Key.Hex(),
+			shard.Index,
+			common.Bytes2Hex(shard.Data),
+			common.Bytes2Hex(proof),
+		); err != nil {
+			if txErr := tx.Rollback(); txErr != nil {
+				return fmt.Errorf("%v: rollback caused by %v", txErr, err)
+			}
+
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetShardCommitment returns the commitment to the shards of the value of the given key
+func (db *pgDB) GetShardCommitment(ctx context.Context, key common.Hash) (*types.ShardCommitment, error) {
+	const getShardCommitmentSQL = `
+		SELECT key, root, data_shards, parity_shards, size, member_index, members, created_at
+		FROM data_node.shard_commitments
+		WHERE key = $1
+		LIMIT 1;
+	`
+
+	commitment := struct {
+		Key          string    `db:"key"`
+		Root         string    `db:"root"`
+		DataShards   uint      `db:"data_shards"`
+		ParityShards uint      `db:"parity_shards"`
+		Size         uint64    `db:"size"`
+		MemberIndex  uint      `db:"member_index"`
+		Members      uint      `db:"members"`
+		CreatedAt    time.Time `db:"created_at"`
+	}{}
+
+	if err := db.pg.QueryRowxContext(ctx, getShardCommitmentSQL, key.Hex()).StructScan(&commitment); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrStateNotSynchronized
+		}
+
+		return nil, err
+	}
+
+	return &types.ShardCommitment{
+		Key:          common.HexToHash(commitment.Key),
+		Root:         common.HexToHash(commitment.Root),
+		DataShards:   commitment.DataShards,
+		ParityShards: commitment.ParityShards,
+		Size:         commitment.Size,
+		MemberIndex:  commitment.MemberIndex,
+		Members:      commitment.Members,
+		Timestamp:    commitment.CreatedAt,
+	}, nil
+}
+
+// GetShards returns the shards of the value of the given key held by the node among the given indices
+func (db *pgDB) GetShards(ctx context.Context, key common.Hash, indices []uint) ([]types.Shard, error) {
+	if len(indices) == 0 {
+		return nil, nil
+	}
+
+	const getShardsSQL = `
+		SELECT key, idx, data, proof
+		FROM data_node.shards
+		WHERE key = ? AND idx IN (?)
+		ORDER BY idx;
+	`
+
+	query, args, err := sqlx.In(getShardsSQL, key.Hex(), indices)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.pg.QueryxContext(ctx, db.pg.Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	shards := make([]types.Shard, 0, len(indices))
+	for rows.Next() {
+		shard := struct {
+			Key   string `db:"key"`
+			Index uint   `db:"idx"`
+			Data  string `db:"data"`
+			Proof string `db:"proof"`
+		}{}
+		if err = rows.StructScan(&shard); err != nil {
+			return nil, err
+		}
+
+		raw := common.FromHex(shard.Proof)
+		if len(raw)%common.HashLength != 0 {
+			return nil, fmt.Errorf("invalid proof of the shard %d of %s", shard.Index, shard.Key)
+		}
+
+		proof := make([]common.Hash, 0, len(raw)/common.HashLength)
+		for i := 0; i < len(raw); i += common.HashLength {
+			proof = append(proof, common.BytesToHash(raw[i:i+common.HashLength]))
+		}
+
+		shards = append(shards, types.Shard{
+			Key:   common.HexToHash(shard.Key),
+			Index: shard.Index,
+			Data:  common.FromHex(shard.Data),
+			Proof: proof,
+		})
+	}
+
+	return shards, rows.Err()
+}
+
+// StoreServedValues records the committee members the values were fetched from
+func (db *pgDB) StoreServedValues(ctx context.Context, served []types.ServedValue) error {
+	const storeServedValueSQL = `
+		INSERT INTO data_node.served_by (key, member, signature, served_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (key) DO UPDATE
+		SET member = EXCLUDED.member, signature = EXCLUDED.signature, served_at = EXCLUDED.served_at;
+	`
+
+	tx, err := db.pg.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range served {
+		var signature interface{}
+		if len(s.Signature) > 0 {
+			signature = common.Bytes2Hex(s.Signature)
+		}
+
+		if _, err = tx.ExecContext(
+			ctx, storeServedValueSQL,
+			s.Key.Hex(),
+			s.Member.Hex(),
+			signature,
+			s.Timestamp,
+		); err != nil {
+			if txErr := tx.Rollback(); txErr != nil {
+				return fmt.Errorf("%v: rollback caused by %v", txErr, err)
+			}
+
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetServedValue returns the committee member the value identified by the key was fetched from
+func (db *pgDB) GetServedValue(ctx context.Context, key common.Hash) (*types.ServedValue, error) {
+	const getServedValueSQL = `
+		SELECT key, member, signature, served_at
+		FROM data_node.served_by
+		WHERE key = $1
+		LIMIT 1;
+	`
+
+	served := struct {
+		Key       string         `db:"key"`
+		Member    string         `db:"member"`
+		Signature sql.NullString `db:"signature"`
+		ServedAt  time.Time      `db:"served_at"`
+	}{}
+
+	if err := db.pg.QueryRowxContext(ctx, getServedValueSQL, key.Hex()).StructScan(&served); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrStateNotSynchronized
+		}
+
+		return nil, err
+	}
+
+	value := &types.ServedValue{
+		Key:       common.HexToHash(served.Key),
+		Member:    common.HexToAddress(served.Member),
+		Timestamp: served.ServedAt,
+	}
+	if served.Signature.Valid {
+		value.Signature = common.FromHex(served.Signature.String)
+	}
+
+	return value, nil
+}
+
+// StoreProvenance records how the values were obtained, keeping the first time each one was obtained
+// from a given source
+func (db *pgDB) StoreProvenance(ctx context.Context, provenance []types.Provenance) error {
+	const storeProvenanceSQL = `
+		INSERT INTO data_node.provenance (key, source, origin, obtained_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (key, source, origin) DO NOTHING;
+	`
+
+	tx, err := db.pg.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range provenance {
+		if _, err = tx.ExecContext(ctx, storeProvenanceSQL, p.Key.Hex(), p.Source, p.Origin, p.ObtainedAt); err != nil {
+			if txErr := tx.Rollback(); txErr != nil {
+				return fmt.Errorf("%v: rollback caused by %v", txErr, err)
+			}
+
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetProvenance returns how the value identified by the key was obtained, in the order it was obtained
+func (db *pgDB) GetProvenance(ctx context.Context, key common.Hash) ([]types.Provenance, error) {
+	const getProvenanceSQL = `
+		SELECT key, source, origin, obtained_at
+		FROM data_node.provenance
+		WHERE key = $1
+		ORDER BY obtained_at, source, origin;
+	`
+
+	rows, err := db.pg.QueryxContext(ctx, getProvenanceSQL, key.Hex())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	provenance := make([]types.Provenance, 0)
+	for rows.Next() {
+		record := struct {
+			Key        string    `db:"key"`
+			Source     string    `db:"source"`
+			Origin     string    `db:"origin"`
+			ObtainedAt time.Time `db:"obtained_at"`
+		}{}
+		if err = rows.StructScan(&record); err != nil {
+			return nil, err
+		}
+
+		provenance = append(provenance, types.Provenance{
+			Key:        common.HexToHash(record.Key),
+			Source:     record.Source,
+			Origin:     record.Origin,
+			ObtainedAt: record.ObtainedAt,
+		})
+	}
+
+	return provenance, rows.Err()
+}
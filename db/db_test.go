@@ -1,15 +1,18 @@
 package db
 
 import (
+	"bytes"
 	"context"
 	"database/sql/driver"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/0xPolygon/cdk-data-availability/types"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 )
 
@@ -358,6 +361,7 @@ func Test_DB_StoreOffChainData(t *testing.T) {
 	testTable := []struct {
 		name      string
 		od        []types.OffChainData
+		outbox    bool
 		returnErr error
 	}{
 		{
@@ -370,6 +374,14 @@ func Test_DB_StoreOffChainData(t *testing.T) {
 				Value: []byte("value1"),
 			}},
 		},
+		{
+			name: "one value inserted with its event",
+			od: []types.OffChainData{{
+				Key:   common.HexToHash("key1"),
+				Value: []byte("value1"),
+			}},
+			outbox: true,
+		},
 		{
 			name: "several values inserted",
 			od: []types.OffChainData{{
@@ -379,6 +391,7 @@ func Test_DB_StoreOffChainData(t *testing.T) {
 				Key:   common.HexToHash("key2"),
 				Value: []byte("value2"),
 			}},
+			outbox: true,
 		},
 		{
 			name: "error returned",
@@ -407,9 +420,15 @@ func Test_DB_StoreOffChainData(t *testing.T) {
 					WithArgs(o.Key.Hex(), common.Bytes2Hex(o.Value), o.BatchNum)
 				if tt.returnErr != nil {
 					expected.WillReturnError(tt.returnErr)
-				} else {
-					expected.WillReturnResult(sqlmock.NewResult(int64(len(tt.od)), int64(len(tt.od))))
+					break
+				}
+				expected.WillReturnResult(sqlmock.NewResult(int64(len(tt.od)), int64(len(tt.od))))
+				if !tt.outbox {
+					continue
 				}
+				mock.ExpectExec(`INSERT INTO data_node\.outbox \(key, batch_num, size, created_at\) VALUES \(\$1, \$2, \$3, NOW\(\)\) ON CONFLICT \(key\) DO UPDATE`).
+					WithArgs(o.Key.Hex(), o.BatchNum, len(o.Value)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
 			}
 			if tt.returnErr == nil {
 				mock.ExpectCommit()
@@ -419,7 +438,7 @@ func Test_DB_StoreOffChainData(t *testing.T) {
 
 			wdb := sqlx.NewDb(db, "postgres")
 
-			dbPG := New(wdb)
+			dbPG := NewFromConfig(wdb, Config{}, tt.outbox)
 
 			err = dbPG.StoreOffChainData(context.Background(), tt.od)
 			if tt.returnErr != nil {
@@ -433,6 +452,141 @@ func Test_DB_StoreOffChainData(t *testing.T) {
 	}
 }
 
+func Test_DB_StoreOffChainDataDurably(t *testing.T) {
+	t.Parallel()
+
+	od := []types.OffChainData{{
+		Key:   common.HexToHash("key1"),
+		Value: []byte("value1"),
+	}}
+
+	testTable := []struct {
+		name              string
+		synchronousCommit string
+		setErr            error
+		expectedErr       string
+	}{
+		{
+			name:              "flushed locally",
+			synchronousCommit: SynchronousCommitLocal,
+		},
+		{
+			name:              "applied on the standbys",
+			synchronousCommit: SynchronousCommitRemoteApply,
+		},
+		{
+			name:              "unsupported level",
+			synchronousCommit: "off",
+			expectedErr:       `unsupported synchronous_commit level "off"`,
+		},
+		{
+			name:              "setting refused",
+			synchronousCommit: SynchronousCommitRemoteApply,
+			setErr:            errors.New("test error"),
+			expectedErr:       "test error",
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			if tt.expectedErr == "" || tt.setErr != nil {
+				mock.ExpectBegin()
+				expected := mock.ExpectExec(`SELECT set_config\('synchronous_commit', \$1, true\)`).
+					WithArgs(tt.synchronousCommit)
+				if tt.setErr != nil {
+					expected.WillReturnError(tt.setErr)
+					mock.ExpectRollback()
+				} else {
+					expected.WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectExec(`INSERT INTO data_node\.offchain_data`).
+						WithArgs(od[0].Key.Hex(), common.Bytes2Hex(od[0].Value), od[0].BatchNum).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectCommit()
+				}
+			}
+
+			err = New(sqlx.NewDb(db, "postgres")).StoreOffChainDataDurably(context.Background(), od, tt.synchronousCommit)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_SynchronousStandbyNames(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	mock.ExpectQuery(`SHOW synchronous_standby_names`).
+		WillReturnRows(sqlmock.NewRows([]string{"synchronous_standby_names"}).AddRow("ANY 1 (standby1, standby2)"))
+
+	names, err := SynchronousStandbyNames(context.Background(), sqlx.NewDb(db, "postgres"))
+	require.NoError(t, err)
+	require.Equal(t, "ANY 1 (standby1, standby2)", names)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_DB_Prepared(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	od := types.OffChainData{Key: common.HexToHash("key1"), Value: []byte("value1"), BatchNum: 1}
+
+	// the query is prepared once, then run twice through the statement
+	prepared := mock.ExpectPrepare(`SELECT key, value, batch_num FROM data_node\.offchain_data WHERE key = \$1 LIMIT 1`)
+	for i := 0; i < 2; i++ {
+		prepared.ExpectQuery().WithArgs(od.Key.Hex()).
+			WillReturnRows(sqlmock.NewRows([]string{"key", "value", "batch_num"}).
+				AddRow(od.Key.Hex(), common.Bytes2Hex(od.Value), od.BatchNum))
+	}
+
+	// the statements are prepared for the pool, then on the connection of the transaction
+	mock.ExpectBegin()
+	mock.ExpectPrepare(`INSERT INTO data_node\.offchain_data`)
+	mock.ExpectPrepare(`INSERT INTO data_node\.offchain_data`).ExpectExec().
+		WithArgs(od.Key.Hex(), common.Bytes2Hex(od.Value), od.BatchNum).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectPrepare(`INSERT INTO data_node\.outbox`)
+	mock.ExpectPrepare(`INSERT INTO data_node\.outbox`).ExpectExec().
+		WithArgs(od.Key.Hex(), od.BatchNum, len(od.Value)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	dbPG := NewFromConfig(sqlx.NewDb(db, "postgres"), Config{PreparedStatements: true}, true)
+
+	for i := 0; i < 2; i++ {
+		data, err := dbPG.GetOffChainData(context.Background(), od.Key)
+		require.NoError(t, err)
+		require.Equal(t, &od, data)
+	}
+
+	require.NoError(t, dbPG.StoreOffChainData(context.Background(), []types.OffChainData{od}))
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func Test_DB_GetOffChainData(t *testing.T) {
 	t.Parallel()
 
@@ -526,7 +680,6 @@ func Test_DB_ListOffChainData(t *testing.T) {
 		od        []types.OffChainData
 		keys      []common.Hash
 		expected  []types.OffChainData
-		sql       string
 		returnErr error
 	}{
 		{
@@ -545,7 +698,6 @@ func Test_DB_ListOffChainData(t *testing.T) {
 					BatchNum: 0,
 				},
 			},
-			sql: `SELECT key, value, batch_num FROM data_node\.offchain_data WHERE key IN \(\$1\)`,
 		},
 		{
 			name: "successfully selected two values",
@@ -574,7 +726,6 @@ func Test_DB_ListOffChainData(t *testing.T) {
 					BatchNum: 2,
 				},
 			},
-			sql: `SELECT key, value, batch_num FROM data_node\.offchain_data WHERE key IN \(\$1\, \$2\)`,
 		},
 		{
 			name: "error returned",
@@ -585,7 +736,6 @@ func Test_DB_ListOffChainData(t *testing.T) {
 			keys: []common.Hash{
 				common.BytesToHash([]byte("key1")),
 			},
-			sql:       `SELECT key, value, batch_num FROM data_node\.offchain_data WHERE key IN \(\$1\)`,
 			returnErr: errors.New("test error"),
 		},
 		{
@@ -597,7 +747,6 @@ func Test_DB_ListOffChainData(t *testing.T) {
 			keys: []common.Hash{
 				common.BytesToHash([]byte("undefined")),
 			},
-			sql:       `SELECT key, value, batch_num FROM data_node\.offchain_data WHERE key IN \(\$1\)`,
 			returnErr: ErrStateNotSynchronized,
 		},
 	}
@@ -618,13 +767,13 @@ func Test_DB_ListOffChainData(t *testing.T) {
 			// Seed data
 			seedOffchainData(t, wdb, mock, tt.od)
 
-			preparedKeys := make([]driver.Value, len(tt.keys))
+			preparedKeys := make([]string, len(tt.keys))
 			for i, key := range tt.keys {
 				preparedKeys[i] = key.Hex()
 			}
 
-			expected := mock.ExpectQuery(tt.sql).
-				WithArgs(preparedKeys...)
+			expected := mock.ExpectQuery(`SELECT key, value, batch_num FROM data_node\.offchain_data WHERE key = ANY\(\$1\)`).
+				WithArgs(pq.Array(preparedKeys))
 
 			if tt.returnErr != nil {
 				expected.WillReturnError(tt.returnErr)
@@ -755,3 +904,1447 @@ func seedUnresolvedBatchKeys(t *testing.T, db *sqlx.DB, mock sqlmock.Sqlmock, bk
 	err := New(db).StoreUnresolvedBatchKeys(context.Background(), bk)
 	require.NoError(t, err)
 }
+
+func Test_DB_StoreSignAuditEntry(t *testing.T) {
+	t.Parallel()
+
+	entry := types.SignAuditEntry{
+		Requester:    common.HexToAddress("0x1"),
+		SequenceHash: common.HexToHash("0x2"),
+		BatchCount:   2,
+		Decision:     types.SignDecisionSigned,
+		Signature:    []byte{1, 2, 3},
+		Timestamp:    time.Unix(1700000000, 0).UTC(),
+	}
+
+	testTable := []struct {
+		name      string
+		returnErr error
+	}{
+		{
+			name: "entry appended",
+		},
+		{
+			name:      "error returned",
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			expected := mock.ExpectExec(`INSERT INTO data_node\.sign_audit \(requester, sequence_hash, batch_count, decision, signature, created_at\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6\)`).
+				WithArgs(entry.Requester.Hex(), entry.SequenceHash.Hex(), entry.BatchCount, "signed", "010203", entry.Timestamp)
+			if tt.returnErr != nil {
+				expected.WillReturnError(tt.returnErr)
+			} else {
+				expected.WillReturnResult(sqlmock.NewResult(1, 1))
+			}
+
+			dbPG := New(sqlx.NewDb(db, "postgres"))
+
+			err = dbPG.StoreSignAuditEntry(context.Background(), entry)
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_ListSignAuditEntries(t *testing.T) {
+	t.Parallel()
+
+	timestamp := time.Unix(1700000000, 0).UTC()
+
+	testTable := []struct {
+		name      string
+		fromID    uint64
+		limit     uint
+		rows      [][]driver.Value
+		expected  []types.SignAuditEntry
+		returnErr error
+	}{
+		{
+			name:   "entries found",
+			fromID: 1,
+			limit:  2,
+			rows: [][]driver.Value{
+				{1, common.HexToAddress("0x1").Hex(), common.HexToHash("0x2").Hex(), 2, "signed", "010203", timestamp},
+				{2, common.HexToAddress("0x3").Hex(), common.HexToHash("0x4").Hex(), 1, "unauthorized", "", timestamp},
+			},
+			expected: []types.SignAuditEntry{
+				{
+					ID:           1,
+					Requester:    common.HexToAddress("0x1"),
+					SequenceHash: common.HexToHash("0x2"),
+					BatchCount:   2,
+					Decision:     types.SignDecisionSigned,
+					Signature:    []byte{1, 2, 3},
+					Timestamp:    timestamp,
+				},
+				{
+					ID:           2,
+					Requester:    common.HexToAddress("0x3"),
+					SequenceHash: common.HexToHash("0x4"),
+					BatchCount:   1,
+					Decision:     types.SignDecisionUnauthorized,
+					Signature:    []byte{},
+					Timestamp:    timestamp,
+				},
+			},
+		},
+		{
+			name:     "no entries found",
+			fromID:   3,
+			limit:    2,
+			expected: []types.SignAuditEntry{},
+		},
+		{
+			name:      "error returned",
+			limit:     2,
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			expected := mock.ExpectQuery(`SELECT id, requester, sequence_hash, batch_count, decision, signature, created_at FROM data_node\.sign_audit WHERE id >= \$1 ORDER BY id LIMIT \$2`).
+				WithArgs(tt.fromID, tt.limit)
+			if tt.returnErr != nil {
+				expected.WillReturnError(tt.returnErr)
+			} else {
+				rows := sqlmock.NewRows([]string{
+					"id", "requester", "sequence_hash", "batch_count", "decision", "signature", "created_at",
+				})
+				for _, row := range tt.rows {
+					rows.AddRow(row...)
+				}
+				expected.WillReturnRows(rows)
+			}
+
+			dbPG := New(sqlx.NewDb(db, "postgres"))
+
+			actual, err := dbPG.ListSignAuditEntries(context.Background(), tt.fromID, tt.limit)
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, actual)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_HasSignedSequence(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name      string
+		exists    bool
+		returnErr error
+	}{
+		{
+			name:   "sequence signed",
+			exists: true,
+		},
+		{
+			name: "sequence not signed",
+		},
+		{
+			name:      "error returned",
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			expected := mock.ExpectQuery(`SELECT EXISTS \( SELECT 1 FROM data_node\.sign_audit WHERE sequence_hash = \$1 AND decision = \$2 \)`).
+				WithArgs(common.HexToHash("0x1").Hex(), "signed")
+			if tt.returnErr != nil {
+				expected.WillReturnError(tt.returnErr)
+			} else {
+				expected.WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(tt.exists))
+			}
+
+			dbPG := New(sqlx.NewDb(db, "postgres"))
+
+			actual, err := dbPG.HasSignedSequence(context.Background(), common.HexToHash("0x1"))
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.exists, actual)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_StoreCommitteeChanges(t *testing.T) {
+	t.Parallel()
+
+	timestamp := time.Unix(1700000000, 0).UTC()
+	changes := []types.CommitteeChange{
+		{
+			BlockNumber: 10,
+			TxHash:      common.HexToHash("0x1"),
+			Kind:        types.CommitteeMemberAdded,
+			Member:      common.HexToAddress("0x2"),
+			URL:         "http://member-2",
+			Timestamp:   timestamp,
+		},
+		{
+			BlockNumber: 10,
+			TxHash:      common.HexToHash("0x1"),
+			Kind:        types.CommitteeMemberURLChanged,
+			Member:      common.HexToAddress("0x3"),
+			URL:         "http://member-3-new",
+			PreviousURL: "http://member-3",
+			Timestamp:   timestamp,
+		},
+	}
+
+	testTable := []struct {
+		name      string
+		returnErr error
+	}{
+		{
+			name: "changes appended",
+		},
+		{
+			name:      "error returned",
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			mock.ExpectBegin()
+			for _, c := range changes {
+				expected := mock.ExpectExec(`INSERT INTO data_node\.committee_changes \(block_number, tx_hash, kind, member, url, previous_url, created_at\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7\)`).
+					WithArgs(c.BlockNumber, c.TxHash.Hex(), string(c.Kind), c.Member.Hex(), c.URL, c.PreviousURL, c.Timestamp)
+				if tt.returnErr != nil {
+					expected.WillReturnError(tt.returnErr)
+					break
+				}
+				expected.WillReturnResult(sqlmock.NewResult(1, 1))
+			}
+			if tt.returnErr != nil {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectCommit()
+			}
+
+			dbPG := New(sqlx.NewDb(db, "postgres"))
+
+			err = dbPG.StoreCommitteeChanges(context.Background(), changes)
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_ListCommitteeChanges(t *testing.T) {
+	t.Parallel()
+
+	timestamp := time.Unix(1700000000, 0).UTC()
+
+	testTable := []struct {
+		name      string
+		fromID    uint64
+		limit     uint
+		rows      [][]driver.Value
+		expected  []types.CommitteeChange
+		returnErr error
+	}{
+		{
+			name:   "changes found",
+			fromID: 1,
+			limit:  2,
+			rows: [][]driver.Value{
+				{1, 10, common.HexToHash("0x1").Hex(), "added", common.HexToAddress("0x2").Hex(), "http://member-2", "", timestamp},
+				{2, 20, common.HexToHash("0x3").Hex(), "removed", common.HexToAddress("0x2").Hex(), "http://member-2", "", timestamp},
+			},
+			expected: []types.CommitteeChange{
+				{
+					ID:          1,
+					BlockNumber: 10,
+					TxHash:      common.HexToHash("0x1"),
+					Kind:        types.CommitteeMemberAdded,
+					Member:      common.HexToAddress("0x2"),
+					URL:         "http://member-2",
+					Timestamp:   timestamp,
+				},
+				{
+					ID:          2,
+					BlockNumber: 20,
+					TxHash:      common.HexToHash("0x3"),
+					Kind:        types.CommitteeMemberRemoved,
+					Member:      common.HexToAddress("0x2"),
+					URL:         "http://member-2",
+					Timestamp:   timestamp,
+				},
+			},
+		},
+		{
+			name:     "no changes found",
+			fromID:   3,
+			limit:    2,
+			expected: []types.CommitteeChange{},
+		},
+		{
+			name:      "error returned",
+			limit:     2,
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			expected := mock.ExpectQuery(`SELECT id, block_number, tx_hash, kind, member, url, previous_url, created_at FROM data_node\.committee_changes WHERE id >= \$1 ORDER BY id LIMIT \$2`).
+				WithArgs(tt.fromID, tt.limit)
+			if tt.returnErr != nil {
+				expected.WillReturnError(tt.returnErr)
+			} else {
+				rows := sqlmock.NewRows([]string{
+					"id", "block_number", "tx_hash", "kind", "member", "url", "previous_url", "created_at",
+				})
+				for _, row := range tt.rows {
+					rows.AddRow(row...)
+				}
+				expected.WillReturnRows(rows)
+			}
+
+			dbPG := New(sqlx.NewDb(db, "postgres"))
+
+			actual, err := dbPG.ListCommitteeChanges(context.Background(), tt.fromID, tt.limit)
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, actual)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_DeleteOffChainData(t *testing.T) {
+	t.Parallel()
+
+	keys := []common.Hash{common.HexToHash("0x1"), common.HexToHash("0x2")}
+
+	testTable := []struct {
+		name      string
+		returnErr error
+	}{
+		{
+			name: "values deleted",
+		},
+		{
+			name:      "error returned",
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			expected := mock.ExpectExec(`DELETE FROM data_node\.offchain_data WHERE key IN \(\$1, \$2\)`).
+				WithArgs(keys[0].Hex(), keys[1].Hex())
+			if tt.returnErr != nil {
+				expected.WillReturnError(tt.returnErr)
+			} else {
+				expected.WillReturnResult(sqlmock.NewResult(0, 2))
+			}
+
+			dbPG := New(sqlx.NewDb(db, "postgres"))
+
+			err = dbPG.DeleteOffChainData(context.Background(), keys)
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_CountOffChainDataBefore(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\), COALESCE\(SUM\(LENGTH\(value\) / 2\), 0\) FROM data_node\.offchain_data WHERE batch_num > 0 AND batch_num < \$1`).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"count", "size"}).AddRow(3, 120))
+
+	dbPG := New(sqlx.NewDb(db, "postgres"))
+
+	count, size, err := dbPG.CountOffChainDataBefore(context.Background(), 10)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), count)
+	require.Equal(t, uint64(120), size)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_DB_PruneOffChainData(t *testing.T) {
+	t.Parallel()
+
+	timestamp := time.Unix(1700000000, 0).UTC()
+	tombstones := []types.Tombstone{
+		{
+			Key:           common.HexToHash("0x1"),
+			BatchNum:      5,
+			PrunedAtBlock: 1000,
+			Reason:        "retention policy",
+			Signature:     []byte{1, 2, 3},
+			Timestamp:     timestamp,
+		},
+		{
+			// pruned by a mirror
+			Key:           common.HexToHash("0x2"),
+			BatchNum:      6,
+			PrunedAtBlock: 1000,
+			Reason:        "retention policy",
+			Timestamp:     timestamp,
+		},
+	}
+
+	testTable := []struct {
+		name      string
+		returnErr error
+	}{
+		{
+			name: "values pruned",
+		},
+		{
+			name:      "error returned",
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			mock.ExpectBegin()
+			for _, tombstone := range tombstones {
+				var signature driver.Value
+				if len(tombstone.Signature) > 0 {
+					signature = common.Bytes2Hex(tombstone.Signature)
+				}
+
+				mock.ExpectExec(`INSERT INTO data_node\.tombstones \(key, batch_num, pruned_at_block, reason, signature, created_at\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6\) ON CONFLICT \(key\) DO UPDATE`).
+					WithArgs(tombstone.Key.Hex(), uint64(tombstone.BatchNum), uint64(tombstone.PrunedAtBlock),
+						tombstone.Reason, signature, tombstone.Timestamp).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				expected := mock.ExpectExec(`DELETE FROM data_node\.offchain_data WHERE key = \$1`).
+					WithArgs(tombstone.Key.Hex())
+				if tt.returnErr != nil {
+					expected.WillReturnError(tt.returnErr)
+					break
+				}
+				expected.WillReturnResult(sqlmock.NewResult(1, 1))
+			}
+			if tt.returnErr != nil {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectCommit()
+			}
+
+			dbPG := New(sqlx.NewDb(db, "postgres"))
+
+			err = dbPG.PruneOffChainData(context.Background(), tombstones)
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_GetTombstones(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	timestamp := time.Unix(1700000000, 0).UTC()
+	keys := []common.Hash{common.HexToHash("0x1"), common.HexToHash("0x2"), common.HexToHash("0x3")}
+
+	mock.ExpectQuery(`SELECT key, batch_num, pruned_at_block, reason, signature, created_at FROM data_node\.tombstones WHERE key IN \(\$1, \$2, \$3\)`).
+		WithArgs(keys[0].Hex(), keys[1].Hex(), keys[2].Hex()).
+		WillReturnRows(sqlmock.NewRows([]string{"key", "batch_num", "pruned_at_block", "reason", "signature", "created_at"}).
+			AddRow(keys[0].Hex(), 5, 1000, "retention policy", "010203", timestamp).
+			AddRow(keys[1].Hex(), 6, 1000, "retention policy", nil, timestamp))
+
+	dbPG := New(sqlx.NewDb(db, "postgres"))
+
+	tombstones, err := dbPG.GetTombstones(context.Background(), keys)
+	require.NoError(t, err)
+	require.Equal(t, []types.Tombstone{
+		{
+			Key:           keys[0],
+			BatchNum:      5,
+			PrunedAtBlock: 1000,
+			Reason:        "retention policy",
+			Signature:     []byte{1, 2, 3},
+			Timestamp:     timestamp,
+		},
+		{
+			Key:           keys[1],
+			BatchNum:      6,
+			PrunedAtBlock: 1000,
+			Reason:        "retention policy",
+			Timestamp:     timestamp,
+		},
+	}, tombstones)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_DB_VacuumOffChainData(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	mock.ExpectExec(`VACUUM FULL data_node\.offchain_data`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	dbPG := New(sqlx.NewDb(db, "postgres"))
+
+	require.NoError(t, dbPG.VacuumOffChainData(context.Background()))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_DB_GetUnpublishedOffChainData(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name      string
+		rows      [][]driver.Value
+		expected  []types.OffChainData
+		returnErr error
+	}{
+		{
+			name: "values found",
+			rows: [][]driver.Value{
+				{common.HexToHash("0x1").Hex(), "0xabcd", 1},
+				{common.HexToHash("0x2").Hex(), "0xef", 2},
+			},
+			expected: []types.OffChainData{
+				{Key: common.HexToHash("0x1"), Value: []byte{0xab, 0xcd}, BatchNum: 1},
+				{Key: common.HexToHash("0x2"), Value: []byte{0xef}, BatchNum: 2},
+			},
+		},
+		{
+			name:     "no values found",
+			expected: []types.OffChainData{},
+		},
+		{
+			name:      "error returned",
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			expected := mock.ExpectQuery(`SELECT o\.key, o\.value, o\.batch_num FROM data_node\.offchain_data o LEFT JOIN data_node\.publications p ON p\.key = o\.key AND p\.backend = \$1 WHERE p\.key IS NULL ORDER BY o\.batch_num LIMIT \$2`).
+				WithArgs("ipfs", uint(10))
+			if tt.returnErr != nil {
+				expected.WillReturnError(tt.returnErr)
+			} else {
+				rows := sqlmock.NewRows([]string{"key", "value", "batch_num"})
+				for _, row := range tt.rows {
+					rows.AddRow(row...)
+				}
+				expected.WillReturnRows(rows)
+			}
+
+			dbPG := New(sqlx.NewDb(db, "postgres"))
+
+			actual, err := dbPG.GetUnpublishedOffChainData(context.Background(), "ipfs", 10)
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, actual)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_StorePublication(t *testing.T) {
+	t.Parallel()
+
+	publication := types.Publication{
+		Key:       common.HexToHash("0x1"),
+		Backend:   "ipfs",
+		Reference: "bafkreiexample",
+		Timestamp: time.Unix(1700000000, 0).UTC(),
+	}
+
+	testTable := []struct {
+		name      string
+		returnErr error
+	}{
+		{
+			name: "publication stored",
+		},
+		{
+			name:      "error returned",
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			expected := mock.ExpectExec(`INSERT INTO data_node\.publications \(key, backend, reference, created_at\) VALUES \(\$1, \$2, \$3, \$4\) ON CONFLICT \(key, backend\) DO UPDATE`).
+				WithArgs(publication.Key.Hex(), publication.Backend, publication.Reference, publication.Timestamp)
+			if tt.returnErr != nil {
+				expected.WillReturnError(tt.returnErr)
+			} else {
+				expected.WillReturnResult(sqlmock.NewResult(1, 1))
+			}
+
+			dbPG := New(sqlx.NewDb(db, "postgres"))
+
+			err = dbPG.StorePublication(context.Background(), publication)
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_GetPublication(t *testing.T) {
+	t.Parallel()
+
+	timestamp := time.Unix(1700000000, 0).UTC()
+	key := common.HexToHash("0x1")
+
+	testTable := []struct {
+		name      string
+		row       []driver.Value
+		expected  *types.Publication
+		returnErr error
+	}{
+		{
+			name: "publication found",
+			row:  []driver.Value{key.Hex(), "ipfs", "bafkreiexample", timestamp},
+			expected: &types.Publication{
+				Key:       key,
+				Backend:   "ipfs",
+				Reference: "bafkreiexample",
+				Timestamp: timestamp,
+			},
+		},
+		{
+			name:      "publication not found",
+			returnErr: ErrStateNotSynchronized,
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			rows := sqlmock.NewRows([]string{"key", "backend", "reference", "created_at"})
+			if tt.row != nil {
+				rows.AddRow(tt.row...)
+			}
+			mock.ExpectQuery(`SELECT key, backend, reference, created_at FROM data_node\.publications WHERE key = \$1 AND backend = \$2 LIMIT 1`).
+				WithArgs(key.Hex(), "ipfs").
+				WillReturnRows(rows)
+
+			dbPG := New(sqlx.NewDb(db, "postgres"))
+
+			actual, err := dbPG.GetPublication(context.Background(), key, "ipfs")
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, actual)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_ListOffChainDataByBatches(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT key, value, batch_num FROM data_node\.offchain_data WHERE batch_num BETWEEN \$1 AND \$2 ORDER BY batch_num, key LIMIT \$3`).
+		WithArgs(5, 6, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"key", "value", "batch_num"}).
+			AddRow(common.HexToHash("0x1").Hex(), "0x0102", 5).
+			AddRow(common.HexToHash("0x2").Hex(), "0x03", 6))
+
+	dbPG := New(sqlx.NewDb(db, "postgres"))
+
+	list, err := dbPG.ListOffChainDataByBatches(context.Background(), 5, 6, 10)
+	require.NoError(t, err)
+	require.Equal(t, []types.OffChainData{
+		{Key: common.HexToHash("0x1"), Value: []byte{1, 2}, BatchNum: 5},
+		{Key: common.HexToHash("0x2"), Value: []byte{3}, BatchNum: 6},
+	}, list)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_DB_ListOffChainDataPage(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT key, value, batch_num FROM data_node\.offchain_data WHERE key > \$1 ORDER BY key LIMIT \$2`).
+		WithArgs(common.HexToHash("0x1").Hex(), 10).
+		WillReturnRows(sqlmock.NewRows([]string{"key", "value", "batch_num"}).
+			AddRow(common.HexToHash("0x2").Hex(), "0x0102", 6).
+			AddRow(common.HexToHash("0x3").Hex(), "0x03", 5))
+
+	dbPG := New(sqlx.NewDb(db, "postgres"))
+
+	list, err := dbPG.ListOffChainDataPage(context.Background(), common.HexToHash("0x1"), 10)
+	require.NoError(t, err)
+	require.Equal(t, []types.OffChainData{
+		{Key: common.HexToHash("0x2"), Value: []byte{1, 2}, BatchNum: 6},
+		{Key: common.HexToHash("0x3"), Value: []byte{3}, BatchNum: 5},
+	}, list)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_DB_GetOffChainDataKeys(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT batch_num, key FROM data_node\.offchain_data WHERE batch_num >= \$1 ORDER BY batch_num, key LIMIT \$2`).
+		WithArgs(5, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"batch_num", "key"}).
+			AddRow(5, common.HexToHash("0x1").Hex()).
+			AddRow(6, common.HexToHash("0x2").Hex()))
+
+	dbPG := New(sqlx.NewDb(db, "postgres"))
+
+	keys, err := dbPG.GetOffChainDataKeys(context.Background(), 5, 10)
+	require.NoError(t, err)
+	require.Equal(t, []types.BatchKey{
+		{Number: 5, Hash: common.HexToHash("0x1")},
+		{Number: 6, Hash: common.HexToHash("0x2")},
+	}, keys)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_DB_StoreAttestation(t *testing.T) {
+	t.Parallel()
+
+	attestation := types.Attestation{
+		Root:      common.HexToHash("0x1"),
+		FromBatch: 1,
+		ToBatch:   10,
+		KeyCount:  12,
+		TxHash:    common.HexToHash("0x2"),
+		Timestamp: time.Unix(1700000000, 0).UTC(),
+	}
+
+	testTable := []struct {
+		name      string
+		returnErr error
+	}{
+		{
+			name: "attestation stored",
+		},
+		{
+			name:      "error returned",
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			expected := mock.ExpectExec(`INSERT INTO data_node\.attestations \(root, from_batch, to_batch, key_count, tx_hash, created_at\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6\)`).
+				WithArgs(attestation.Root.Hex(), attestation.FromBatch, attestation.ToBatch, attestation.KeyCount,
+					attestation.TxHash.Hex(), attestation.Timestamp)
+			if tt.returnErr != nil {
+				expected.WillReturnError(tt.returnErr)
+			} else {
+				expected.WillReturnResult(sqlmock.NewResult(1, 1))
+			}
+
+			dbPG := New(sqlx.NewDb(db, "postgres"))
+
+			err = dbPG.StoreAttestation(context.Background(), attestation)
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_GetLastAttestation(t *testing.T) {
+	t.Parallel()
+
+	timestamp := time.Unix(1700000000, 0).UTC()
+
+	testTable := []struct {
+		name      string
+		row       []driver.Value
+		expected  *types.Attestation
+		returnErr error
+	}{
+		{
+			name: "attestation found",
+			row: []driver.Value{
+				3, common.HexToHash("0x1").Hex(), 1, 10, 12, common.HexToHash("0x2").Hex(), timestamp,
+			},
+			expected: &types.Attestation{
+				ID:        3,
+				Root:      common.HexToHash("0x1"),
+				FromBatch: 1,
+				ToBatch:   10,
+				KeyCount:  12,
+				TxHash:    common.HexToHash("0x2"),
+				Timestamp: timestamp,
+			},
+		},
+		{
+			name:      "no attestation",
+			returnErr: ErrStateNotSynchronized,
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			rows := sqlmock.NewRows([]string{"id", "root", "from_batch", "to_batch", "key_count", "tx_hash", "created_at"})
+			if tt.row != nil {
+				rows.AddRow(tt.row...)
+			}
+			mock.ExpectQuery(`SELECT id, root, from_batch, to_batch, key_count, tx_hash, created_at FROM data_node\.attestations ORDER BY id DESC LIMIT 1`).
+				WillReturnRows(rows)
+
+			dbPG := New(sqlx.NewDb(db, "postgres"))
+
+			actual, err := dbPG.GetLastAttestation(context.Background())
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, actual)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_GetUncertifiedKeys(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT o\.key FROM data_node\.offchain_data o LEFT JOIN data_node\.certificates c ON c\.data_hash = o\.key WHERE c\.data_hash IS NULL ORDER BY o\.batch_num LIMIT \$1`).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"key"}).
+			AddRow(common.HexToHash("0x1").Hex()).
+			AddRow(common.HexToHash("0x2").Hex()))
+
+	dbPG := New(sqlx.NewDb(db, "postgres"))
+
+	keys, err := dbPG.GetUncertifiedKeys(context.Background(), 10)
+	require.NoError(t, err)
+	require.Equal(t, []common.Hash{common.HexToHash("0x1"), common.HexToHash("0x2")}, keys)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_DB_Certificate(t *testing.T) {
+	t.Parallel()
+
+	signatures := []types.ArgBytes{bytes.Repeat([]byte{1}, 65), bytes.Repeat([]byte{2}, 65)}
+	certificate := types.Certificate{
+		DataHash:      common.HexToHash("0x1"),
+		CommitteeHash: common.HexToHash("0x2"),
+		Threshold:     2,
+		Signatures:    signatures,
+		Timestamp:     time.Unix(1700000000, 0).UTC(),
+	}
+	stored := common.Bytes2Hex(append(append([]byte{}, signatures[0]...), signatures[1]...))
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	mock.ExpectExec(`INSERT INTO data_node\.certificates \(data_hash, committee_hash, threshold, signatures, created_at\) VALUES \(\$1, \$2, \$3, \$4, \$5\) ON CONFLICT \(data_hash\) DO UPDATE`).
+		WithArgs(certificate.DataHash.Hex(), certificate.CommitteeHash.Hex(), certificate.Threshold, stored,
+			certificate.Timestamp).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(`SELECT data_hash, committee_hash, threshold, signatures, created_at FROM data_node\.certificates WHERE data_hash = \$1 LIMIT 1`).
+		WithArgs(certificate.DataHash.Hex()).
+		WillReturnRows(sqlmock.NewRows([]string{"data_hash", "committee_hash", "threshold", "signatures", "created_at"}).
+			AddRow(certificate.DataHash.Hex(), certificate.CommitteeHash.Hex(), 2, stored, certificate.Timestamp))
+	mock.ExpectQuery(`SELECT data_hash, committee_hash, threshold, signatures, created_at FROM data_node\.certificates WHERE data_hash = \$1 LIMIT 1`).
+		WithArgs(common.HexToHash("0x3").Hex()).
+		WillReturnRows(sqlmock.NewRows([]string{"data_hash", "committee_hash", "threshold", "signatures", "created_at"}))
+
+	dbPG := New(sqlx.NewDb(db, "postgres"))
+
+	require.NoError(t, dbPG.StoreCertificate(context.Background(), certificate))
+
+	actual, err := dbPG.GetCertificate(context.Background(), certificate.DataHash)
+	require.NoError(t, err)
+	require.Equal(t, &certificate, actual)
+
+	_, err = dbPG.GetCertificate(context.Background(), common.HexToHash("0x3"))
+	require.ErrorIs(t, err, ErrStateNotSynchronized)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_DB_OutboxEvents(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	storedAt := time.Unix(1700000000, 0).UTC()
+	mock.ExpectQuery(`SELECT id, key, batch_num, size, created_at FROM data_node\.outbox ORDER BY id LIMIT \$1`).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "key", "batch_num", "size", "created_at"}).
+			AddRow(3, common.HexToHash("0x1").Hex(), 7, 120, storedAt).
+			AddRow(5, common.HexToHash("0x2").Hex(), 8, 64, storedAt))
+	mock.ExpectExec(`DELETE FROM data_node\.outbox WHERE id IN \(\$1, \$2\)`).
+		WithArgs(3, 5).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	dbPG := New(sqlx.NewDb(db, "postgres"))
+
+	events, err := dbPG.GetOutboxEvents(context.Background(), 10)
+	require.NoError(t, err)
+	require.Equal(t, []types.StoredDataEvent{
+		{ID: 3, Key: common.HexToHash("0x1"), BatchNum: 7, Size: 120, StoredAt: storedAt},
+		{ID: 5, Key: common.HexToHash("0x2"), BatchNum: 8, Size: 64, StoredAt: storedAt},
+	}, events)
+
+	require.NoError(t, dbPG.DeleteOutboxEvents(context.Background(), []uint64{3, 5}))
+	require.NoError(t, dbPG.DeleteOutboxEvents(context.Background(), nil))
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_DB_Shards(t *testing.T) {
+	t.Parallel()
+
+	key := common.HexToHash("0x1")
+	commitment := types.ShardCommitment{
+		Key:          key,
+		Root:         common.HexToHash("0x2"),
+		DataShards:   4,
+		ParityShards: 2,
+		Size:         100,
+		MemberIndex:  1,
+		Members:      3,
+		Timestamp:    time.Unix(1700000000, 0).UTC(),
+	}
+	shard := types.Shard{
+		Key:   key,
+		Index: 1,
+		Data:  []byte{1, 2, 3},
+		Proof: []common.Hash{common.HexToHash("0x3"), common.HexToHash("0x4")},
+	}
+	proof := common.Bytes2Hex(append(shard.Proof[0].Bytes(), shard.Proof[1].Bytes()...))
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT o\.key, o\.value, o\.batch_num FROM data_node\.offchain_data o LEFT JOIN data_node\.shard_commitments s ON s\.key = o\.key WHERE s\.key IS NULL ORDER BY o\.batch_num LIMIT \$1`).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"key", "value", "batch_num"}).AddRow(key.Hex(), "0x0102", 5))
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO data_node\.shard_commitments \(key, root, data_shards, parity_shards, size, member_index, members, created_at\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7, \$8\) ON CONFLICT \(key\) DO NOTHING`).
+		WithArgs(key.Hex(), commitment.Root.Hex(), 4, 2, 100, 1, 3, commitment.Timestamp).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`INSERT INTO data_node\.shards \(key, idx, data, proof\) VALUES \(\$1, \$2, \$3, \$4\) ON CONFLICT \(key, idx\) DO NOTHING`).
+		WithArgs(key.Hex(), 1, "010203", proof).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(`SELECT key, root, data_shards, parity_shards, size, member_index, members, created_at FROM data_node\.shard_commitments WHERE key = \$1 LIMIT 1`).
+		WithArgs(key.Hex()).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"key", "root", "data_shards", "parity_shards", "size", "member_index", "members", "created_at",
+		}).AddRow(key.Hex(), commitment.Root.Hex(), 4, 2, 100, 1, 3, commitment.Timestamp))
+	mock.ExpectQuery(`SELECT key, idx, data, proof FROM data_node\.shards WHERE key = \$1 AND idx IN \(\$2, \$3\) ORDER BY idx`).
+		WithArgs(key.Hex(), 1, 4).
+		WillReturnRows(sqlmock.NewRows([]string{"key", "idx", "data", "proof"}).AddRow(key.Hex(), 1, "010203", proof))
+
+	dbPG := New(sqlx.NewDb(db, "postgres"))
+
+	pending, err := dbPG.GetUnshardedOffChainData(context.Background(), 10)
+	require.NoError(t, err)
+	require.Equal(t, []types.OffChainData{{Key: key, Value: []byte{1, 2}, BatchNum: 5}}, pending)
+
+	require.NoError(t, dbPG.StoreShards(context.Background(), commitment, []types.Shard{shard}))
+
+	actual, err := dbPG.GetShardCommitment(context.Background(), key)
+	require.NoError(t, err)
+	require.Equal(t, &commitment, actual)
+
+	shards, err := dbPG.GetShards(context.Background(), key, []uint{1, 4})
+	require.NoError(t, err)
+	require.Equal(t, []types.Shard{shard}, shards)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_DB_StoreServedValues(t *testing.T) {
+	t.Parallel()
+
+	timestamp := time.Unix(1700000000, 0).UTC()
+	served := []types.ServedValue{
+		{
+			Key:       common.HexToHash("0x1"),
+			Member:    common.HexToAddress("0x2"),
+			Signature: []byte{1, 2, 3},
+			Timestamp: timestamp,
+		},
+		{
+			// served by a member not signing the values
+			Key:       common.HexToHash("0x3"),
+			Member:    common.HexToAddress("0x4"),
+			Timestamp: timestamp,
+		},
+	}
+
+	testTable := []struct {
+		name      string
+		returnErr error
+	}{
+		{
+			name: "served values recorded",
+		},
+		{
+			name:      "error returned",
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			mock.ExpectBegin()
+			for _, s := range served {
+				var signature driver.Value
+				if len(s.Signature) > 0 {
+					signature = common.Bytes2Hex(s.Signature)
+				}
+
+				expected := mock.ExpectExec(`INSERT INTO data_node\.served_by \(key, member, signature, served_at\) VALUES \(\$1, \$2, \$3, \$4\) ON CONFLICT \(key\) DO UPDATE SET member = EXCLUDED\.member, signature = EXCLUDED\.signature, served_at = EXCLUDED\.served_at`).
+					WithArgs(s.Key.Hex(), s.Member.Hex(), signature, s.Timestamp)
+				if tt.returnErr != nil {
+					expected.WillReturnError(tt.returnErr)
+					break
+				}
+				expected.WillReturnResult(sqlmock.NewResult(1, 1))
+			}
+			if tt.returnErr != nil {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectCommit()
+			}
+
+			dbPG := New(sqlx.NewDb(db, "postgres"))
+
+			err = dbPG.StoreServedValues(context.Background(), served)
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_GetServedValue(t *testing.T) {
+	t.Parallel()
+
+	timestamp := time.Unix(1700000000, 0).UTC()
+	key := common.HexToHash("0x1")
+	member := common.HexToAddress("0x2")
+
+	testTable := []struct {
+		name      string
+		row       []driver.Value
+		expected  *types.ServedValue
+		returnErr error
+	}{
+		{
+			name: "signed value found",
+			row:  []driver.Value{key.Hex(), member.Hex(), "010203", timestamp},
+			expected: &types.ServedValue{
+				Key:       key,
+				Member:    member,
+				Signature: []byte{1, 2, 3},
+				Timestamp: timestamp,
+			},
+		},
+		{
+			name: "unsigned value found",
+			row:  []driver.Value{key.Hex(), member.Hex(), nil, timestamp},
+			expected: &types.ServedValue{
+				Key:       key,
+				Member:    member,
+				Timestamp: timestamp,
+			},
+		},
+		{
+			name:      "value not found",
+			returnErr: ErrStateNotSynchronized,
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			rows := sqlmock.NewRows([]string{"key", "member", "signature", "served_at"})
+			if tt.row != nil {
+				rows.AddRow(tt.row...)
+			}
+			mock.ExpectQuery(`SELECT key, member, signature, served_at FROM data_node\.served_by WHERE key = \$1 LIMIT 1`).
+				WithArgs(key.Hex()).
+				WillReturnRows(rows)
+
+			dbPG := New(sqlx.NewDb(db, "postgres"))
+
+			actual, err := dbPG.GetServedValue(context.Background(), key)
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, actual)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_StoreProvenance(t *testing.T) {
+	t.Parallel()
+
+	obtainedAt := time.Unix(1700000000, 0).UTC()
+	provenance := []types.Provenance{
+		{Key: common.HexToHash("0x1"), Source: types.ProvenanceSequencer, ObtainedAt: obtainedAt},
+		{
+			Key:        common.HexToHash("0x2"),
+			Source:     types.ProvenanceMember,
+			Origin:     common.HexToAddress("0x3").Hex(),
+			ObtainedAt: obtainedAt,
+		},
+	}
+
+	testTable := []struct {
+		name      string
+		returnErr error
+	}{
+		{
+			name: "provenance recorded",
+		},
+		{
+			name:      "error returned",
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			mock.ExpectBegin()
+			for _, p := range provenance {
+				expected := mock.ExpectExec(`INSERT INTO data_node\.provenance \(key, source, origin, obtained_at\) VALUES \(\$1, \$2, \$3, \$4\) ON CONFLICT \(key, source, origin\) DO NOTHING`).
+					WithArgs(p.Key.Hex(), p.Source, p.Origin, p.ObtainedAt)
+				if tt.returnErr != nil {
+					expected.WillReturnError(tt.returnErr)
+					break
+				}
+				expected.WillReturnResult(sqlmock.NewResult(1, 1))
+			}
+			if tt.returnErr != nil {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectCommit()
+			}
+
+			dbPG := New(sqlx.NewDb(db, "postgres"))
+
+			err = dbPG.StoreProvenance(context.Background(), provenance)
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_GetProvenance(t *testing.T) {
+	t.Parallel()
+
+	key := common.HexToHash("0x1")
+	pushedAt := time.Unix(1700000000, 0).UTC()
+	fetchedAt := time.Unix(1700000100, 0).UTC()
+	member := common.HexToAddress("0x2").Hex()
+
+	testTable := []struct {
+		name      string
+		rows      [][]driver.Value
+		expected  []types.Provenance
+		returnErr error
+	}{
+		{
+			name: "provenance found",
+			rows: [][]driver.Value{
+				{key.Hex(), types.ProvenanceSequencerPush, "", pushedAt},
+				{key.Hex(), types.ProvenanceGossip, member, fetchedAt},
+			},
+			expected: []types.Provenance{
+				{Key: key, Source: types.ProvenanceSequencerPush, ObtainedAt: pushedAt},
+				{Key: key, Source: types.ProvenanceGossip, Origin: member, ObtainedAt: fetchedAt},
+			},
+		},
+		{
+			name:     "no provenance recorded",
+			expected: []types.Provenance{},
+		},
+		{
+			name:      "error returned",
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			expected := mock.ExpectQuery(`SELECT key, source, origin, obtained_at FROM data_node\.provenance WHERE key = \$1 ORDER BY obtained_at, source, origin`).
+				WithArgs(key.Hex())
+			if tt.returnErr != nil {
+				expected.WillReturnError(tt.returnErr)
+			} else {
+				rows := sqlmock.NewRows([]string{"key", "source", "origin", "obtained_at"})
+				for _, row := range tt.rows {
+					rows.AddRow(row...)
+				}
+				expected.WillReturnRows(rows)
+			}
+
+			dbPG := New(sqlx.NewDb(db, "postgres"))
+
+			actual, err := dbPG.GetProvenance(context.Background(), key)
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, actual)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,429 @@
+package db
+
+import (
+	"context"
+
+	"github.com/0xPolygon/cdk-data-availability/fault"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// faultyDB injects the faults of the db.<operation> points into the operations of the wrapped DB
+type faultyDB struct {
+	db DB
+}
+
+// injectFaults wraps the given DB injecting the faults of its operations, the DB being returned as is when no
+// fault is injected
+func injectFaults(db DB) DB {
+	if !fault.Enabled() {
+		return db
+	}
+
+	return &faultyDB{db: db}
+}
+
+// StoreLastProcessedBlock calls StoreLastProcessedBlock of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) StoreLastProcessedBlock(ctx context.Context, block uint64, task string) error {
+	if err := fault.Before(ctx, "db.StoreLastProcessedBlock"); err != nil {
+		return err
+	}
+	err := f.db.StoreLastProcessedBlock(ctx, block, task)
+	return fault.After("db.StoreLastProcessedBlock", err)
+}
+
+// GetLastProcessedBlock calls GetLastProcessedBlock of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) GetLastProcessedBlock(ctx context.Context, task string) (uint64, error) {
+	if err := fault.Before(ctx, "db.GetLastProcessedBlock"); err != nil {
+		return 0, err
+	}
+	block, err := f.db.GetLastProcessedBlock(ctx, task)
+	return block, fault.After("db.GetLastProcessedBlock", err)
+}
+
+// StoreUnresolvedBatchKeys calls StoreUnresolvedBatchKeys of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) StoreUnresolvedBatchKeys(ctx context.Context, bks []types.BatchKey) error {
+	if err := fault.Before(ctx, "db.StoreUnresolvedBatchKeys"); err != nil {
+		return err
+	}
+	err := f.db.StoreUnresolvedBatchKeys(ctx, bks)
+	return fault.After("db.StoreUnresolvedBatchKeys", err)
+}
+
+// GetUnresolvedBatchKeys calls GetUnresolvedBatchKeys of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) GetUnresolvedBatchKeys(ctx context.Context, limit uint) ([]types.BatchKey, error) {
+	if err := fault.Before(ctx, "db.GetUnresolvedBatchKeys"); err != nil {
+		return nil, err
+	}
+	bks, err := f.db.GetUnresolvedBatchKeys(ctx, limit)
+	return bks, fault.After("db.GetUnresolvedBatchKeys", err)
+}
+
+// DeleteUnresolvedBatchKeys calls DeleteUnresolvedBatchKeys of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) DeleteUnresolvedBatchKeys(ctx context.Context, bks []types.BatchKey) error {
+	if err := fault.Before(ctx, "db.DeleteUnresolvedBatchKeys"); err != nil {
+		return err
+	}
+	err := f.db.DeleteUnresolvedBatchKeys(ctx, bks)
+	return fault.After("db.DeleteUnresolvedBatchKeys", err)
+}
+
+// GetOffChainData calls GetOffChainData of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) GetOffChainData(ctx context.Context, key common.Hash) (*types.OffChainData, error) {
+	if err := fault.Before(ctx, "db.GetOffChainData"); err != nil {
+		return nil, err
+	}
+	data, err := f.db.GetOffChainData(ctx, key)
+	return data, fault.After("db.GetOffChainData", err)
+}
+
+// ListOffChainData calls ListOffChainData of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) ListOffChainData(ctx context.Context, keys []common.Hash) ([]types.OffChainData, error) {
+	if err := fault.Before(ctx, "db.ListOffChainData"); err != nil {
+		return nil, err
+	}
+	list, err := f.db.ListOffChainData(ctx, keys)
+	return list, fault.After("db.ListOffChainData", err)
+}
+
+// StoreOffChainData calls StoreOffChainData of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) StoreOffChainData(ctx context.Context, od []types.OffChainData) error {
+	if err := fault.Before(ctx, "db.StoreOffChainData"); err != nil {
+		return err
+	}
+	err := f.db.StoreOffChainData(ctx, od)
+	return fault.After("db.StoreOffChainData", err)
+}
+
+// StoreOffChainDataDurably calls StoreOffChainDataDurably of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) StoreOffChainDataDurably(
+	ctx context.Context,
+	od []types.OffChainData,
+	synchronousCommit string,
+) error {
+	if err := fault.Before(ctx, "db.StoreOffChainDataDurably"); err != nil {
+		return err
+	}
+	err := f.db.StoreOffChainDataDurably(ctx, od, synchronousCommit)
+	return fault.After("db.StoreOffChainDataDurably", err)
+}
+
+// ListOffChainDataByBatches calls ListOffChainDataByBatches of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) ListOffChainDataByBatches(
+	ctx context.Context,
+	fromBatch, toBatch uint64,
+	limit uint,
+) ([]types.OffChainData, error) {
+	if err := fault.Before(ctx, "db.ListOffChainDataByBatches"); err != nil {
+		return nil, err
+	}
+	list, err := f.db.ListOffChainDataByBatches(ctx, fromBatch, toBatch, limit)
+	return list, fault.After("db.ListOffChainDataByBatches", err)
+}
+
+// ListOffChainDataPage calls ListOffChainDataPage of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) ListOffChainDataPage(
+	ctx context.Context,
+	afterKey common.Hash,
+	limit uint,
+) ([]types.OffChainData, error) {
+	if err := fault.Before(ctx, "db.ListOffChainDataPage"); err != nil {
+		return nil, err
+	}
+	list, err := f.db.ListOffChainDataPage(ctx, afterKey, limit)
+	return list, fault.After("db.ListOffChainDataPage", err)
+}
+
+// DeleteOffChainData calls DeleteOffChainData of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) DeleteOffChainData(ctx context.Context, keys []common.Hash) error {
+	if err := fault.Before(ctx, "db.DeleteOffChainData"); err != nil {
+		return err
+	}
+	err := f.db.DeleteOffChainData(ctx, keys)
+	return fault.After("db.DeleteOffChainData", err)
+}
+
+// CountOffChainDataBefore calls CountOffChainDataBefore of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) CountOffChainDataBefore(ctx context.Context, beforeBatch uint64) (uint64, uint64, error) {
+	if err := fault.Before(ctx, "db.CountOffChainDataBefore"); err != nil {
+		return 0, 0, err
+	}
+	count, size, err := f.db.CountOffChainDataBefore(ctx, beforeBatch)
+	return count, size, fault.After("db.CountOffChainDataBefore", err)
+}
+
+// PruneOffChainData calls PruneOffChainData of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) PruneOffChainData(ctx context.Context, tombstones []types.Tombstone) error {
+	if err := fault.Before(ctx, "db.PruneOffChainData"); err != nil {
+		return err
+	}
+	err := f.db.PruneOffChainData(ctx, tombstones)
+	return fault.After("db.PruneOffChainData", err)
+}
+
+// GetTombstones calls GetTombstones of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) GetTombstones(ctx context.Context, keys []common.Hash) ([]types.Tombstone, error) {
+	if err := fault.Before(ctx, "db.GetTombstones"); err != nil {
+		return nil, err
+	}
+	tombstones, err := f.db.GetTombstones(ctx, keys)
+	return tombstones, fault.After("db.GetTombstones", err)
+}
+
+// VacuumOffChainData calls VacuumOffChainData of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) VacuumOffChainData(ctx context.Context) error {
+	if err := fault.Before(ctx, "db.VacuumOffChainData"); err != nil {
+		return err
+	}
+	err := f.db.VacuumOffChainData(ctx)
+	return fault.After("db.VacuumOffChainData", err)
+}
+
+// CountOffchainData calls CountOffchainData of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) CountOffchainData(ctx context.Context) (uint64, error) {
+	if err := fault.Before(ctx, "db.CountOffchainData"); err != nil {
+		return 0, err
+	}
+	count, err := f.db.CountOffchainData(ctx)
+	return count, fault.After("db.CountOffchainData", err)
+}
+
+// StoreSignAuditEntry calls StoreSignAuditEntry of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) StoreSignAuditEntry(ctx context.Context, entry types.SignAuditEntry) error {
+	if err := fault.Before(ctx, "db.StoreSignAuditEntry"); err != nil {
+		return err
+	}
+	err := f.db.StoreSignAuditEntry(ctx, entry)
+	return fault.After("db.StoreSignAuditEntry", err)
+}
+
+// ListSignAuditEntries calls ListSignAuditEntries of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) ListSignAuditEntries(
+	ctx context.Context,
+	fromID uint64,
+	limit uint,
+) ([]types.SignAuditEntry, error) {
+	if err := fault.Before(ctx, "db.ListSignAuditEntries"); err != nil {
+		return nil, err
+	}
+	entries, err := f.db.ListSignAuditEntries(ctx, fromID, limit)
+	return entries, fault.After("db.ListSignAuditEntries", err)
+}
+
+// HasSignedSequence calls HasSignedSequence of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) HasSignedSequence(ctx context.Context, sequenceHash common.Hash) (bool, error) {
+	if err := fault.Before(ctx, "db.HasSignedSequence"); err != nil {
+		return false, err
+	}
+	exists, err := f.db.HasSignedSequence(ctx, sequenceHash)
+	return exists, fault.After("db.HasSignedSequence", err)
+}
+
+// StoreCommitteeChanges calls StoreCommitteeChanges of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) StoreCommitteeChanges(ctx context.Context, changes []types.CommitteeChange) error {
+	if err := fault.Before(ctx, "db.StoreCommitteeChanges"); err != nil {
+		return err
+	}
+	err := f.db.StoreCommitteeChanges(ctx, changes)
+	return fault.After("db.StoreCommitteeChanges", err)
+}
+
+// ListCommitteeChanges calls ListCommitteeChanges of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) ListCommitteeChanges(
+	ctx context.Context,
+	fromID uint64,
+	limit uint,
+) ([]types.CommitteeChange, error) {
+	if err := fault.Before(ctx, "db.ListCommitteeChanges"); err != nil {
+		return nil, err
+	}
+	changes, err := f.db.ListCommitteeChanges(ctx, fromID, limit)
+	return changes, fault.After("db.ListCommitteeChanges", err)
+}
+
+// GetUnpublishedOffChainData calls GetUnpublishedOffChainData of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) GetUnpublishedOffChainData(
+	ctx context.Context,
+	backend string,
+	limit uint,
+) ([]types.OffChainData, error) {
+	if err := fault.Before(ctx, "db.GetUnpublishedOffChainData"); err != nil {
+		return nil, err
+	}
+	data, err := f.db.GetUnpublishedOffChainData(ctx, backend, limit)
+	return data, fault.After("db.GetUnpublishedOffChainData", err)
+}
+
+// StorePublication calls StorePublication of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) StorePublication(ctx context.Context, publication types.Publication) error {
+	if err := fault.Before(ctx, "db.StorePublication"); err != nil {
+		return err
+	}
+	err := f.db.StorePublication(ctx, publication)
+	return fault.After("db.StorePublication", err)
+}
+
+// GetPublication calls GetPublication of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) GetPublication(
+	ctx context.Context,
+	key common.Hash,
+	backend string,
+) (*types.Publication, error) {
+	if err := fault.Before(ctx, "db.GetPublication"); err != nil {
+		return nil, err
+	}
+	publication, err := f.db.GetPublication(ctx, key, backend)
+	return publication, fault.After("db.GetPublication", err)
+}
+
+// GetOffChainDataKeys calls GetOffChainDataKeys of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) GetOffChainDataKeys(
+	ctx context.Context,
+	fromBatch uint64,
+	limit uint,
+) ([]types.BatchKey, error) {
+	if err := fault.Before(ctx, "db.GetOffChainDataKeys"); err != nil {
+		return nil, err
+	}
+	keys, err := f.db.GetOffChainDataKeys(ctx, fromBatch, limit)
+	return keys, fault.After("db.GetOffChainDataKeys", err)
+}
+
+// StoreAttestation calls StoreAttestation of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) StoreAttestation(ctx context.Context, attestation types.Attestation) error {
+	if err := fault.Before(ctx, "db.StoreAttestation"); err != nil {
+		return err
+	}
+	err := f.db.StoreAttestation(ctx, attestation)
+	return fault.After("db.StoreAttestation", err)
+}
+
+// GetLastAttestation calls GetLastAttestation of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) GetLastAttestation(ctx context.Context) (*types.Attestation, error) {
+	if err := fault.Before(ctx, "db.GetLastAttestation"); err != nil {
+		return nil, err
+	}
+	attestation, err := f.db.GetLastAttestation(ctx)
+	return attestation, fault.After("db.GetLastAttestation", err)
+}
+
+// GetUncertifiedKeys calls GetUncertifiedKeys of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) GetUncertifiedKeys(ctx context.Context, limit uint) ([]common.Hash, error) {
+	if err := fault.Before(ctx, "db.GetUncertifiedKeys"); err != nil {
+		return nil, err
+	}
+	keys, err := f.db.GetUncertifiedKeys(ctx, limit)
+	return keys, fault.After("db.GetUncertifiedKeys", err)
+}
+
+// StoreCertificate calls StoreCertificate of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) StoreCertificate(ctx context.Context, certificate types.Certificate) error {
+	if err := fault.Before(ctx, "db.StoreCertificate"); err != nil {
+		return err
+	}
+	err := f.db.StoreCertificate(ctx, certificate)
+	return fault.After("db.StoreCertificate", err)
+}
+
+// GetCertificate calls GetCertificate of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) GetCertificate(ctx context.Context, dataHash common.Hash) (*types.Certificate, error) {
+	if err := fault.Before(ctx, "db.GetCertificate"); err != nil {
+		return nil, err
+	}
+	certificate, err := f.db.GetCertificate(ctx, dataHash)
+	return certificate, fault.After("db.GetCertificate", err)
+}
+
+// GetOutboxEvents calls GetOutboxEvents of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) GetOutboxEvents(ctx context.Context, limit uint) ([]types.StoredDataEvent, error) {
+	if err := fault.Before(ctx, "db.GetOutboxEvents"); err != nil {
+		return nil, err
+	}
+	events, err := f.db.GetOutboxEvents(ctx, limit)
+	return events, fault.After("db.GetOutboxEvents", err)
+}
+
+// DeleteOutboxEvents calls DeleteOutboxEvents of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) DeleteOutboxEvents(ctx context.Context, ids []uint64) error {
+	if err := fault.Before(ctx, "db.DeleteOutboxEvents"); err != nil {
+		return err
+	}
+	err := f.db.DeleteOutboxEvents(ctx, ids)
+	return fault.After("db.DeleteOutboxEvents", err)
+}
+
+// GetUnshardedOffChainData calls GetUnshardedOffChainData of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) GetUnshardedOffChainData(ctx context.Context, limit uint) ([]types.OffChainData, error) {
+	if err := fault.Before(ctx, "db.GetUnshardedOffChainData"); err != nil {
+		return nil, err
+	}
+	list, err := f.db.GetUnshardedOffChainData(ctx, limit)
+	return list, fault.After("db.GetUnshardedOffChainData", err)
+}
+
+// StoreShards calls StoreShards of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) StoreShards(
+	ctx context.Context,
+	commitment types.ShardCommitment,
+	shards []types.Shard,
+) error {
+	if err := fault.Before(ctx, "db.StoreShards"); err != nil {
+		return err
+	}
+	err := f.db.StoreShards(ctx, commitment, shards)
+	return fault.After("db.StoreShards", err)
+}
+
+// GetShardCommitment calls GetShardCommitment of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) GetShardCommitment(ctx context.Context, key common.Hash) (*types.ShardCommitment, error) {
+	if err := fault.Before(ctx, "db.GetShardCommitment"); err != nil {
+		return nil, err
+	}
+	commitment, err := f.db.GetShardCommitment(ctx, key)
+	return commitment, fault.After("db.GetShardCommitment", err)
+}
+
+// GetShards calls GetShards of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) GetShards(ctx context.Context, key common.Hash, indices []uint) ([]types.Shard, error) {
+	if err := fault.Before(ctx, "db.GetShards"); err != nil {
+		return nil, err
+	}
+	shards, err := f.db.GetShards(ctx, key, indices)
+	return shards, fault.After("db.GetShards", err)
+}
+
+// StoreServedValues calls StoreServedValues of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) StoreServedValues(ctx context.Context, served []types.ServedValue) error {
+	if err := fault.Before(ctx, "db.StoreServedValues"); err != nil {
+		return err
+	}
+	err := f.db.StoreServedValues(ctx, served)
+	return fault.After("db.StoreServedValues", err)
+}
+
+// GetServedValue calls GetServedValue of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) GetServedValue(ctx context.Context, key common.Hash) (*types.ServedValue, error) {
+	if err := fault.Before(ctx, "db.GetServedValue"); err != nil {
+		return nil, err
+	}
+	served, err := f.db.GetServedValue(ctx, key)
+	return served, fault.After("db.GetServedValue", err)
+}
+
+// StoreProvenance calls StoreProvenance of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) StoreProvenance(ctx context.Context, provenance []types.Provenance) error {
+	if err := fault.Before(ctx, "db.StoreProvenance"); err != nil {
+		return err
+	}
+	err := f.db.StoreProvenance(ctx, provenance)
+	return fault.After("db.StoreProvenance", err)
+}
+
+// GetProvenance calls GetProvenance of the wrapped DB, injecting the faults of its point
+func (f *faultyDB) GetProvenance(ctx context.Context, key common.Hash) ([]types.Provenance, error) {
+	if err := fault.Before(ctx, "db.GetProvenance"); err != nil {
+		return nil, err
+	}
+	provenance, err := f.db.GetProvenance(ctx, key)
+	return provenance, fault.After("db.GetProvenance", err)
+}
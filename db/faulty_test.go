@@ -0,0 +1,35 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/fault"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// not parallel, the faults being injected into the whole process
+func Test_InjectFaults(t *testing.T) {
+	ctx := context.Background()
+	_, ok := NewMemory(0).(*instrumentedDB).db.(*faultyDB)
+	require.False(t, ok)
+
+	fault.Init(fault.Config{Enabled: true, Rules: []fault.Rule{
+		{Point: "db.StoreOffChainData", DropRate: 1},
+		{Point: "db.GetOffChainData", ErrorRate: 1},
+	}})
+	t.Cleanup(func() { fault.Init(fault.Config{}) })
+	m := NewMemory(0)
+
+	// the data is stored, the result of the operation being dropped
+	data := types.OffChainData{Key: common.HexToHash("0x1"), Value: []byte{1}}
+	require.ErrorIs(t, m.StoreOffChainData(ctx, []types.OffChainData{data}), fault.ErrDropped)
+	list, err := m.ListOffChainData(ctx, []common.Hash{data.Key})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+
+	_, err = m.GetOffChainData(ctx, data.Key)
+	require.ErrorIs(t, err, fault.ErrInjected)
+}
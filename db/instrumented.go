@@ -0,0 +1,403 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/metrics"
+	"github.com/0xPolygon/cdk-data-availability/tracing"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentedDB records the metrics and the traces of every operation of the wrapped DB
+type instrumentedDB struct {
+	db DB
+}
+
+// instrument wraps the given DB recording the metrics and the traces of its operations, the faults injected
+// into them included
+func instrument(db DB) DB {
+	return &instrumentedDB{db: injectFaults(db)}
+}
+
+// observe starts the span of a DB operation, the returned function ends it
+// and records the metrics of the operation once done
+func observe(ctx context.Context, op string) (context.Context, func(error)) {
+	start := time.Now()
+	ctx, span := tracing.Start(ctx, "db."+op, trace.SpanKindClient,
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", op),
+	)
+
+	return ctx, func(err error) {
+		metrics.DBOperation(op, start, err)
+		tracing.End(span, err)
+	}
+}
+
+// StoreLastProcessedBlock calls StoreLastProcessedBlock of the wrapped DB
+func (i *instrumentedDB) StoreLastProcessedBlock(ctx context.Context, block uint64, task string) error {
+	ctx, done := observe(ctx, "StoreLastProcessedBlock")
+	err := i.db.StoreLastProcessedBlock(ctx, block, task)
+	done(err)
+	return err
+}
+
+// GetLastProcessedBlock calls GetLastProcessedBlock of the wrapped DB
+func (i *instrumentedDB) GetLastProcessedBlock(ctx context.Context, task string) (uint64, error) {
+	ctx, done := observe(ctx, "GetLastProcessedBlock")
+	block, err := i.db.GetLastProcessedBlock(ctx, task)
+	done(err)
+	return block, err
+}
+
+// StoreUnresolvedBatchKeys calls StoreUnresolvedBatchKeys of the wrapped DB
+func (i *instrumentedDB) StoreUnresolvedBatchKeys(ctx context.Context, bks []types.BatchKey) error {
+	ctx, done := observe(ctx, "StoreUnresolvedBatchKeys")
+	err := i.db.StoreUnresolvedBatchKeys(ctx, bks)
+	done(err)
+	return err
+}
+
+// GetUnresolvedBatchKeys calls GetUnresolvedBatchKeys of the wrapped DB
+func (i *instrumentedDB) GetUnresolvedBatchKeys(ctx context.Context, limit uint) ([]types.BatchKey, error) {
+	ctx, done := observe(ctx, "GetUnresolvedBatchKeys")
+	bks, err := i.db.GetUnresolvedBatchKeys(ctx, limit)
+	done(err)
+	return bks, err
+}
+
+// DeleteUnresolvedBatchKeys calls DeleteUnresolvedBatchKeys of the wrapped DB
+func (i *instrumentedDB) DeleteUnresolvedBatchKeys(ctx context.Context, bks []types.BatchKey) error {
+	ctx, done := observe(ctx, "DeleteUnresolvedBatchKeys")
+	err := i.db.DeleteUnresolvedBatchKeys(ctx, bks)
+	done(err)
+	return err
+}
+
+// GetOffChainData calls GetOffChainData of the wrapped DB
+func (i *instrumentedDB) GetOffChainData(ctx context.Context, key common.Hash) (*types.OffChainData, error) {
+	ctx, done := observe(ctx, "GetOffChainData")
+	data, err := i.db.GetOffChainData(ctx, key)
+	done(err)
+	return data, err
+}
+
+// ListOffChainData calls ListOffChainData of the wrapped DB
+func (i *instrumentedDB) ListOffChainData(ctx context.Context, keys []common.Hash) ([]types.OffChainData, error) {
+	ctx, done := observe(ctx, "ListOffChainData")
+	list, err := i.db.ListOffChainData(ctx, keys)
+	done(err)
+	return list, err
+}
+
+// StoreOffChainData calls StoreOffChainData of the wrapped DB
+func (i *instrumentedDB) StoreOffChainData(ctx context.Context, od []types.OffChainData) error {
+	ctx, done := observe(ctx, "StoreOffChainData")
+	err := i.db.StoreOffChainData(ctx, od)
+	done(err)
+	return err
+}
+
+// StoreOffChainDataDurably calls StoreOffChainDataDurably of the wrapped DB
+func (i *instrumentedDB) StoreOffChainDataDurably(
+	ctx context.Context,
+	od []types.OffChainData,
+	synchronousCommit string,
+) error {
+	ctx, done := observe(ctx, "StoreOffChainDataDurably")
+	err := i.db.StoreOffChainDataDurably(ctx, od, synchronousCommit)
+	done(err)
+	return err
+}
+
+// ListOffChainDataByBatches calls ListOffChainDataByBatches of the wrapped DB
+func (i *instrumentedDB) ListOffChainDataByBatches(
+	ctx context.Context,
+	fromBatch, toBatch uint64,
+	limit uint,
+) ([]types.OffChainData, error) {
+	ctx, done := observe(ctx, "ListOffChainDataByBatches")
+	list, err := i.db.ListOffChainDataByBatches(ctx, fromBatch, toBatch, limit)
+	done(err)
+	return list, err
+}
+
+// ListOffChainDataPage calls ListOffChainDataPage of the wrapped DB
+func (i *instrumentedDB) ListOffChainDataPage(
+	ctx context.Context,
+	afterKey common.Hash,
+	limit uint,
+) ([]types.OffChainData, error) {
+	ctx, done := observe(ctx, "ListOffChainDataPage")
+	list, err := i.db.ListOffChainDataPage(ctx, afterKey, limit)
+	done(err)
+	return list, err
+}
+
+// DeleteOffChainData calls DeleteOffChainData of the wrapped DB
+func (i *instrumentedDB) DeleteOffChainData(ctx context.Context, keys []common.Hash) error {
+	ctx, done := observe(ctx, "DeleteOffChainData")
+	err := i.db.DeleteOffChainData(ctx, keys)
+	done(err)
+	return err
+}
+
+// CountOffChainDataBefore calls CountOffChainDataBefore of the wrapped DB
+func (i *instrumentedDB) CountOffChainDataBefore(ctx context.Context, beforeBatch uint64) (uint64, uint64, error) {
+	ctx, done := observe(ctx, "CountOffChainDataBefore")
+	count, size, err := i.db.CountOffChainDataBefore(ctx, beforeBatch)
+	done(err)
+	return count, size, err
+}
+
+// PruneOffChainData calls PruneOffChainData of the wrapped DB
+func (i *instrumentedDB) PruneOffChainData(ctx context.Context, tombstones []types.Tombstone) error {
+	ctx, done := observe(ctx, "PruneOffChainData")
+	err := i.db.PruneOffChainData(ctx, tombstones)
+	done(err)
+	return err
+}
+
+// GetTombstones calls GetTombstones of the wrapped DB
+func (i *instrumentedDB) GetTombstones(ctx context.Context, keys []common.Hash) ([]types.Tombstone, error) {
+	ctx, done := observe(ctx, "GetTombstones")
+	tombstones, err := i.db.GetTombstones(ctx, keys)
+	done(err)
+	return tombstones, err
+}
+
+// VacuumOffChainData calls VacuumOffChainData of the wrapped DB
+func (i *instrumentedDB) VacuumOffChainData(ctx context.Context) error {
+	ctx, done := observe(ctx, "VacuumOffChainData")
+	err := i.db.VacuumOffChainData(ctx)
+	done(err)
+	return err
+}
+
+// CountOffchainData calls CountOffchainData of the wrapped DB
+func (i *instrumentedDB) CountOffchainData(ctx context.Context) (uint64, error) {
+	ctx, done := observe(ctx, "CountOffchainData")
+	count, err := i.db.CountOffchainData(ctx)
+	done(err)
+	return count, err
+}
+
+// StoreSignAuditEntry calls StoreSignAuditEntry of the wrapped DB
+func (i *instrumentedDB) StoreSignAuditEntry(ctx context.Context, entry types.SignAuditEntry) error {
+	ctx, done := observe(ctx, "StoreSignAuditEntry")
+	err := i.db.StoreSignAuditEntry(ctx, entry)
+	done(err)
+	return err
+}
+
+// ListSignAuditEntries calls ListSignAuditEntries of the wrapped DB
+func (i *instrumentedDB) ListSignAuditEntries(
+	ctx context.Context,
+	fromID uint64,
+	limit uint,
+) ([]types.SignAuditEntry, error) {
+	ctx, done := observe(ctx, "ListSignAuditEntries")
+	entries, err := i.db.ListSignAuditEntries(ctx, fromID, limit)
+	done(err)
+	return entries, err
+}
+
+// HasSignedSequence calls HasSignedSequence of the wrapped DB
+func (i *instrumentedDB) HasSignedSequence(ctx context.Context, sequenceHash common.Hash) (bool, error) {
+	ctx, done := observe(ctx, "HasSignedSequence")
+	exists, err := i.db.HasSignedSequence(ctx, sequenceHash)
+	done(err)
+	return exists, err
+}
+
+// StoreCommitteeChanges calls StoreCommitteeChanges of the wrapped DB
+func (i *instrumentedDB) StoreCommitteeChanges(ctx context.Context, changes []types.CommitteeChange) error {
+	ctx, done := observe(ctx, "StoreCommitteeChanges")
+	err := i.db.StoreCommitteeChanges(ctx, changes)
+	done(err)
+	return err
+}
+
+// ListCommitteeChanges calls ListCommitteeChanges of the wrapped DB
+func (i *instrumentedDB) ListCommitteeChanges(
+	ctx context.Context,
+	fromID uint64,
+	limit uint,
+) ([]types.CommitteeChange, error) {
+	ctx, done := observe(ctx, "ListCommitteeChanges")
+	changes, err := i.db.ListCommitteeChanges(ctx, fromID, limit)
+	done(err)
+	return changes, err
+}
+
+// GetUnpublishedOffChainData calls GetUnpublishedOffChainData of the wrapped DB
+func (i *instrumentedDB) GetUnpublishedOffChainData(
+	ctx context.Context,
+	backend string,
+	limit uint,
+) ([]types.OffChainData, error) {
+	ctx, done := observe(ctx, "GetUnpublishedOffChainData")
+	data, err := i.db.GetUnpublishedOffChainData(ctx, backend, limit)
+	done(err)
+	return data, err
+}
+
+// StorePublication calls StorePublication of the wrapped DB
+func (i *instrumentedDB) StorePublication(ctx context.Context, publication types.Publication) error {
+	ctx, done := observe(ctx, "StorePublication")
+	err := i.db.StorePublication(ctx, publication)
+	done(err)
+	return err
+}
+
+// GetPublication calls GetPublication of the wrapped DB
+func (i *instrumentedDB) GetPublication(
+	ctx context.Context,
+	key common.Hash,
+	backend string,
+) (*types.Publication, error) {
+	ctx, done := observe(ctx, "GetPublication")
+	publication, err := i.db.GetPublication(ctx, key, backend)
+	done(err)
+	return publication, err
+}
+
+// GetOffChainDataKeys calls GetOffChainDataKeys of the wrapped DB
+func (i *instrumentedDB) GetOffChainDataKeys(
+	ctx context.Context,
+	fromBatch uint64,
+	limit uint,
+) ([]types.BatchKey, error) {
+	ctx, done := observe(ctx, "GetOffChainDataKeys")
+	keys, err := i.db.GetOffChainDataKeys(ctx, fromBatch, limit)
+	done(err)
+	return keys, err
+}
+
+// StoreAttestation calls StoreAttestation of the wrapped DB
+func (i *instrumentedDB) StoreAttestation(ctx context.Context, attestation types.Attestation) error {
+	ctx, done := observe(ctx, "StoreAttestation")
+	err := i.db.StoreAttestation(ctx, attestation)
+	done(err)
+	return err
+}
+
+// GetLastAttestation calls GetLastAttestation of the wrapped DB
+func (i *instrumentedDB) GetLastAttestation(ctx context.Context) (*types.Attestation, error) {
+	ctx, done := observe(ctx, "GetLastAttestation")
+	attestation, err := i.db.GetLastAttestation(ctx)
+	done(err)
+	return attestation, err
+}
+
+// GetUncertifiedKeys calls GetUncertifiedKeys of the wrapped DB
+func (i *instrumentedDB) GetUncertifiedKeys(ctx context.Context, limit uint) ([]common.Hash, error) {
+	ctx, done := observe(ctx, "GetUncertifiedKeys")
+	keys, err := i.db.GetUncertifiedKeys(ctx, limit)
+	done(err)
+	return keys, err
+}
+
+// StoreCertificate calls StoreCertificate of the wrapped DB
+func (i *instrumentedDB) StoreCertificate(ctx context.Context, certificate types.Certificate) error {
+	ctx, done := observe(ctx, "StoreCertificate")
+	err := i.db.StoreCertificate(ctx, certificate)
+	done(err)
+	return err
+}
+
+// GetCertificate calls GetCertificate of the wrapped DB
+func (i *instrumentedDB) GetCertificate(ctx context.Context, dataHash common.Hash) (*types.Certificate, error) {
+	ctx, done := observe(ctx, "GetCertificate")
+	certificate, err := i.db.GetCertificate(ctx, dataHash)
+	done(err)
+	return certificate, err
+}
+
+// GetOutboxEvents calls GetOutboxEvents of the wrapped DB
+func (i *instrumentedDB) GetOutboxEvents(ctx context.Context, limit uint) ([]types.StoredDataEvent, error) {
+	ctx, done := observe(ctx, "GetOutboxEvents")
+	events, err := i.db.GetOutboxEvents(ctx, limit)
+	done(err)
+	return events, err
+}
+
+// DeleteOutboxEvents calls DeleteOutboxEvents of the wrapped DB
+func (i *instrumentedDB) DeleteOutboxEvents(ctx context.Context, ids []uint64) error {
+	ctx, done := observe(ctx, "DeleteOutboxEvents")
+	err := i.db.DeleteOutboxEvents(ctx, ids)
+	done(err)
+	return err
+}
+
+// GetUnshardedOffChainData calls GetUnshardedOffChainData of the wrapped DB
+func (i *instrumentedDB) GetUnshardedOffChainData(ctx context.Context, limit uint) ([]types.OffChainData, error) {
+	ctx, done := observe(ctx, "GetUnshardedOffChainData")
+	list, err := i.db.GetUnshardedOffChainData(ctx, limit)
+	done(err)
+	return list, err
+}
+
+// StoreShards calls StoreShards of the wrapped DB
+func (i *instrumentedDB) StoreShards(
+	ctx context.Context,
+	commitment types.ShardCommitment,
+	shards []types.Shard,
+) error {
+	ctx, done := observe(ctx, "StoreShards")
+	err := i.db.StoreShards(ctx, commitment, shards)
+	done(err)
+	return err
+}
+
+// GetShardCommitment calls GetShardCommitment of the wrapped DB
+func (i *instrumentedDB) GetShardCommitment(ctx context.Context, key common.Hash) (*types.ShardCommitment, error) {
+	ctx, done := observe(ctx, "GetShardCommitment")
+	commitment, err := i.db.GetShardCommitment(ctx, key)
+	done(err)
+	return commitment, err
+}
+
+// GetShards calls GetShards of the wrapped DB
+func (i *instrumentedDB) GetShards(ctx context.Context, key common.Hash, indices []uint) ([]types.Shard, error) {
+	ctx, done := observe(ctx, "GetShards")
+	shards, err := i.db.GetShards(ctx, key, indices)
+	done(err)
+	return shards, err
+}
+
+// StoreServedValues calls StoreServedValues of the wrapped DB
+func (i *instrumentedDB) StoreServedValues(ctx context.Context, served []types.ServedValue) error {
+	ctx, done := observe(ctx, "StoreServedValues")
+	err := i.db.StoreServedValues(ctx, served)
+	done(err)
+	return err
+}
+
+// GetServedValue calls GetServedValue of the wrapped DB
+func (i *instrumentedDB) GetServedValue(ctx context.Context, key common.Hash) (*types.ServedValue, error) {
+	ctx, done := observe(ctx, "GetServedValue")
+	served, err := i.db.GetServedValue(ctx, key)
+	done(err)
+	return served, err
+}
+
+// StoreProvenance calls StoreProvenance of the wrapped DB
+func (i *instrumentedDB) StoreProvenance(ctx context.Context, provenance []types.Provenance) error {
+	ctx, done := observe(ctx, "StoreProvenance")
+	err := i.db.StoreProvenance(ctx, provenance)
+	done(err)
+	return err
+}
+
+// GetProvenance calls GetProvenance of the wrapped DB
+func (i *instrumentedDB) GetProvenance(ctx context.Context, key common.Hash) ([]types.Provenance, error) {
+	ctx, done := observe(ctx, "GetProvenance")
+	provenance, err := i.db.GetProvenance(ctx, key)
+	done(err)
+	return provenance, err
+}
@@ -0,0 +1,806 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrMemoryFull is returned when storing values would exceed the maximum size of the in-memory storage
+var ErrMemoryFull = errors.New("the in-memory storage is full")
+
+// publicationKey identifies the publication of a value to a backend
+type publicationKey struct {
+	key     common.Hash
+	backend string
+}
+
+// memoryDB keeps the state in memory, with the semantics of the tables of the database: the operations of
+// a call are applied all at once or not at all, and deleting a value deletes what references it.
+// Nothing survives a restart, it is meant for the tests and the demo and development runs.
+type memoryDB struct {
+	lock sync.RWMutex
+
+	// maxSize is the maximum total size of the values, 0 when unbounded
+	maxSize uint64
+	size    uint64
+	// recordEvents records the events of the stored values in the outbox
+	recordEvents bool
+
+	tasks        map[string]uint64
+	unresolved   map[types.BatchKey]struct{}
+	values       map[common.Hash]types.OffChainData
+	outbox       map[common.Hash]types.StoredDataEvent
+	lastEventID  uint64
+	tombstones   map[common.Hash]types.Tombstone
+	audit        []types.SignAuditEntry
+	changes      []types.CommitteeChange
+	publications map[publicationKey]types.Publication
+	attestations []types.Attestation
+	certificates map[common.Hash]types.Certificate
+	commitments  map[common.Hash]types.ShardCommitment
+	shards       map[common.Hash]map[uint]types.Shard
+	served       map[common.Hash]types.ServedValue
+	provenance   map[common.Hash][]types.Provenance
+}
+
+// NewMemory instantiates a DB keeping the state in memory, the values taking at most maxSize bytes
+// (unbounded when 0). Like a migrated database, the L1 task starts at block 0. The events of the stored values are
+// not recorded in the outbox, see NewFromConfig.
+func NewMemory(maxSize uint64) DB {
+	return instrument(newMemoryDB(maxSize))
+}
+
+func newMemoryDB(maxSize uint64) *memoryDB {
+	return &memoryDB{
+		maxSize:      maxSize,
+		tasks:        map[string]uint64{"L1": 0},
+		unresolved:   make(map[types.BatchKey]struct{}),
+		values:       make(map[common.Hash]types.OffChainData),
+		outbox:       make(map[common.Hash]types.StoredDataEvent),
+		tombstones:   make(map[common.Hash]types.Tombstone),
+		publications: make(map[publicationKey]types.Publication),
+		certificates: make(map[common.Hash]types.Certificate),
+		commitments:  make(map[common.Hash]types.ShardCommitment),
+		shards:       make(map[common.Hash]map[uint]types.Shard),
+		served:       make(map[common.Hash]types.ServedValue),
+		provenance:   make(map[common.Hash][]types.Provenance),
+	}
+}
+
+// StoreLastProcessedBlock stores a record of a block processed by the synchronizer for named task
+func (m *memoryDB) StoreLastProcessedBlock(_ context.Context, block uint64, task string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.tasks[task] = block
+
+	return nil
+}
+
+// GetLastProcessedBlock returns the latest block successfully processed by the synchronizer for named task,
+// sql.ErrNoRows when the task never processed one
+func (m *memoryDB) GetLastProcessedBlock(_ context.Context, task string) (uint64, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	block, ok := m.tasks[task]
+	if !ok {
+		return 0, sql.ErrNoRows
+	}
+
+	return block, nil
+}
+
+// StoreUnresolvedBatchKeys stores unresolved batch keys
+func (m *memoryDB) StoreUnresolvedBatchKeys(_ context.Context, bks []types.BatchKey) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, bk := range bks {
+		m.unresolved[bk] = struct{}{}
+	}
+
+	return nil
+}
+
+// GetUnresolvedBatchKeys returns the unresolved batch keys, lowest batch number first
+func (m *memoryDB) GetUnresolvedBatchKeys(_ context.Context, limit uint) ([]types.BatchKey, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	var bks []types.BatchKey
+	for bk := range m.unresolved {
+		bks = append(bks, bk)
+	}
+	sort.Slice(bks, func(i, j int) bool {
+		if bks[i].Number != bks[j].Number {
+			return bks[i].Number < bks[j].Number
+		}
+		return bytes.Compare(bks[i].Hash[:], bks[j].Hash[:]) < 0
+	})
+
+	return bks[:limited(len(bks), limit)], nil
+}
+
+// DeleteUnresolvedBatchKeys deletes the unresolved batch keys
+func (m *memoryDB) DeleteUnresolvedBatchKeys(_ context.Context, bks []types.BatchKey) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, bk := range bks {
+		delete(m.unresolved, bk)
+	}
+
+	return nil
+}
+
+// GetOffChainData returns the value identified by the key
+func (m *memoryDB) GetOffChainData(_ context.Context, key common.Hash) (*types.OffChainData, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	data, ok := m.values[key]
+	if !ok {
+		return nil, ErrStateNotSynchronized
+	}
+	data = copyOffChainData(data)
+
+	return &data, nil
+}
+
+// ListOffChainData returns values identified by the given keys
+func (m *memoryDB) ListOffChainData(_ context.Context, keys []common.Hash) ([]types.OffChainData, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	listed := make(map[common.Hash]bool, len(keys))
+	list := make([]types.OffChainData, 0, len(keys))
+	for _, key := range keys {
+		data, ok := m.values[key]
+		if !ok || listed[key] {
+			continue
+		}
+		listed[key] = true
+		list = append(list, copyOffChainData(data))
+	}
+
+	return list, nil
+}
+
+// ListOffChainDataByBatches returns the values stored for the batches in [fromBatch, toBatch],
+// lowest batch number first
+func (m *memoryDB) ListOffChainDataByBatches(
+	_ context.Context,
+	fromBatch, toBatch uint64,
+	limit uint,
+) ([]types.OffChainData, error) {
+	return m.listValues(func(data types.OffChainData) bool {
+		return data.BatchNum >= fromBatch && data.BatchNum <= toBatch
+	}, byBatch, limit), nil
+}
+
+// ListOffChainDataPage returns the values whose key follows the given key, ordered by key
+func (m *memoryDB) ListOffChainDataPage(
+	_ context.Context,
+	afterKey common.Hash,
+	limit uint,
+) ([]types.OffChainData, error) {
+	return m.listValues(func(data types.OffChainData) bool {
+		return bytes.Compare(data.Key[:], afterKey[:]) > 0
+	}, byKey, limit), nil
+}
+
+// StoreOffChainData stores the values along with the events of the outbox if enabled
+func (m *memoryDB) StoreOffChainData(_ context.Context, od []types.OffChainData) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	// the size once the values are stored, the last value of a key replacing the stored one
+	stored := make(map[common.Hash]int, len(od))
+	for _, d := range od {
+		stored[d.Key] = len(d.Value)
+	}
+	size := m.size
+	for key, n := range stored {
+		if previous, ok := m.values[key]; ok {
+			size -= uint64(len(previous.Value))
+		}
+		size += uint64(n)
+	}
+	if m.maxSize > 0 && size > m.maxSize {
+		return ErrMemoryFull
+	}
+
+	now := time.Now()
+	for _, d := range od {
+		m.values[d.Key] = copyOffChainData(d)
+		if !m.recordEvents {
+			continue
+		}
+		m.lastEventID++
+		m.outbox[d.Key] = types.StoredDataEvent{
+			ID:       m.lastEventID,
+			Key:      d.Key,
+			BatchNum: d.BatchNum,
+			Size:     uint64(len(d.Value)),
+			StoredAt: now,
+		}
+	}
+	m.size = size
+
+	return nil
+}
+
+// StoreOffChainDataDurably stores the values like StoreOffChainData, the level being validated as the values
+// are never more durable than the memory of the process
+func (m *memoryDB) StoreOffChainDataDurably(
+	ctx context.Context,
+	od []types.OffChainData,
+	synchronousCommit string,
+) error {
+	switch synchronousCommit {
+	case SynchronousCommitLocal, SynchronousCommitRemoteApply:
+	default:
+		return fmt.Errorf("unsupported synchronous_commit level %q", synchronousCommit)
+	}
+
+	return m.StoreOffChainData(ctx, od)
+}
+
+// DeleteOffChainData deletes the values identified by the given keys
+func (m *memoryDB) DeleteOffChainData(_ context.Context, keys []common.Hash) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, key := range keys {
+		m.deleteValue(key)
+	}
+
+	return nil
+}
+
+// CountOffChainDataBefore returns the number and the total size of the values of the batches before the
+// given batch, the values not yet matched to a batch excluded
+func (m *memoryDB) CountOffChainDataBefore(_ context.Context, beforeBatch uint64) (uint64, uint64, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	var count, size uint64
+	for _, data := range m.values {
+		if data.BatchNum > 0 && data.BatchNum < beforeBatch {
+			count++
+			size += uint64(len(data.Value))
+		}
+	}
+
+	return count, size, nil
+}
+
+// PruneOffChainData deletes the values of the tombstones, recording the tombstones
+func (m *memoryDB) PruneOffChainData(_ context.Context, tombstones []types.Tombstone) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, t := range tombstones {
+		t.Signature = common.CopyBytes(t.Signature)
+		m.tombstones[t.Key] = t
+		m.deleteValue(t.Key)
+	}
+
+	return nil
+}
+
+// GetTombstones returns the tombstones of the given keys, the keys of the values never pruned omitted
+func (m *memoryDB) GetTombstones(_ context.Context, keys []common.Hash) ([]types.Tombstone, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	listed := make(map[common.Hash]bool, len(keys))
+	tombstones := make([]types.Tombstone, 0, len(keys))
+	for _, key := range keys {
+		t, ok := m.tombstones[key]
+		if !ok || listed[key] {
+			continue
+		}
+		listed[key] = true
+		t.Signature = common.CopyBytes(t.Signature)
+		tombstones = append(tombstones, t)
+	}
+
+	return tombstones, nil
+}
+
+// VacuumOffChainData does nothing, the memory of the deleted values being reclaimed by the garbage collector
+func (m *memoryDB) VacuumOffChainData(context.Context) error {
+	return nil
+}
+
+// CountOffchainData returns the number of values
+func (m *memoryDB) CountOffchainData(context.Context) (uint64, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return uint64(len(m.values)), nil
+}
+
+// StoreSignAuditEntry appends a record of a request to sign a sequence to the audit log
+func (m *memoryDB) StoreSignAuditEntry(_ context.Context, entry types.SignAuditEntry) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	entry.ID = uint64(len(m.audit)) + 1
+	entry.Signature = common.CopyBytes(entry.Signature)
+	m.audit = append(m.audit, entry)
+
+	return nil
+}
+
+// ListSignAuditEntries returns the records of the audit log starting at the given ID, oldest first
+func (m *memoryDB) ListSignAuditEntries(_ context.Context, fromID uint64, limit uint) ([]types.SignAuditEntry, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	entries := make([]types.SignAuditEntry, 0)
+	for _, entry := range m.audit[idOffset(fromID, len(m.audit)):] {
+		if uint(len(entries)) == limit {
+			break
+		}
+		entry.Signature = common.CopyBytes(entry.Signature)
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// HasSignedSequence returns whether the audit log records the signature of the sequence with the given hash
+func (m *memoryDB) HasSignedSequence(_ context.Context, sequenceHash common.Hash) (bool, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	for _, entry := range m.audit {
+		if entry.SequenceHash == sequenceHash && entry.Decision == types.SignDecisionSigned {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// StoreCommitteeChanges appends the changes of the committee members observed on L1
+func (m *memoryDB) StoreCommitteeChanges(_ context.Context, changes []types.CommitteeChange) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, change := range changes {
+		change.ID = uint64(len(m.changes)) + 1
+		m.changes = append(m.changes, change)
+	}
+
+	return nil
+}
+
+// ListCommitteeChanges returns the changes of the committee members starting at the given ID, oldest first
+func (m *memoryDB) ListCommitteeChanges(_ context.Context, fromID uint64, limit uint) ([]types.CommitteeChange, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	changes := m.changes[idOffset(fromID, len(m.changes)):]
+
+	return append(make([]types.CommitteeChange, 0), changes[:limited(len(changes), limit)]...), nil
+}
+
+// GetUnpublishedOffChainData returns the values not yet published to the given backend, lowest batch number first
+func (m *memoryDB) GetUnpublishedOffChainData(
+	_ context.Context,
+	backend string,
+	limit uint,
+) ([]types.OffChainData, error) {
+	return m.listValues(func(data types.OffChainData) bool {
+		_, ok := m.publications[publicationKey{key: data.Key, backend: backend}]
+		return !ok
+	}, byBatch, limit), nil
+}
+
+// StorePublication records a value published to an external storage backend
+func (m *memoryDB) StorePublication(_ context.Context, publication types.Publication) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.publications[publicationKey{key: publication.Key, backend: publication.Backend}] = publication
+
+	return nil
+}
+
+// GetPublication returns the publication of the value identified by the key to the given backend
+func (m *memoryDB) GetPublication(_ context.Context, key common.Hash, backend string) (*types.Publication, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	publication, ok := m.publications[publicationKey{key: key, backend: backend}]
+	if !ok {
+		return nil, ErrStateNotSynchronized
+	}
+
+	return &publication, nil
+}
+
+// GetOffChainDataKeys returns the keys of the values stored for the batches starting at the given one,
+// lowest batch number first
+func (m *memoryDB) GetOffChainDataKeys(_ context.Context, fromBatch uint64, limit uint) ([]types.BatchKey, error) {
+	list := m.listValues(func(data types.OffChainData) bool {
+		return data.BatchNum >= fromBatch
+	}, byBatch, limit)
+
+	keys := make([]types.BatchKey, 0, len(list))
+	for _, data := range list {
+		keys = append(keys, types.BatchKey{Number: data.BatchNum, Hash: data.Key})
+	}
+
+	return keys, nil
+}
+
+// StoreAttestation records an attestation submitted to L1
+func (m *memoryDB) StoreAttestation(_ context.Context, attestation types.Attestation) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	attestation.ID = uint64(len(m.attestations)) + 1
+	m.attestations = append(m.attestations, attestation)
+
+	return nil
+}
+
+// GetLastAttestation returns the latest attestation submitted to L1
+func (m *memoryDB) GetLastAttestation(context.Context) (*types.Attestation, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	if len(m.attestations) == 0 {
+		return nil, ErrStateNotSynchronized
+	}
+	attestation := m.attestations[len(m.attestations)-1]
+
+	return &attestation, nil
+}
+
+// GetUncertifiedKeys returns the keys of the values without an availability certificate, lowest batch number first
+func (m *memoryDB) GetUncertifiedKeys(_ context.Context, limit uint) ([]common.Hash, error) {
+	list := m.listValues(func(data types.OffChainData) bool {
+		_, ok := m.certificates[data.Key]
+		return !ok
+	}, byBatch, limit)
+
+	keys := make([]common.Hash, 0, len(list))
+	for _, data := range list {
+		keys = append(keys, data.Key)
+	}
+
+	return keys, nil
+}
+
+// StoreCertificate stores the availability certificate of a value, replacing the previous one
+func (m *memoryDB) StoreCertificate(_ context.Context, certificate types.Certificate) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.certificates[certificate.DataHash] = copyCertificate(certificate)
+
+	return nil
+}
+
+// GetCertificate returns the availability certificate of the value of the given hash
+func (m *memoryDB) GetCertificate(_ context.Context, dataHash common.Hash) (*types.Certificate, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	certificate, ok := m.certificates[dataHash]
+	if !ok {
+		return nil, ErrStateNotSynchronized
+	}
+	certificate = copyCertificate(certificate)
+
+	return &certificate, nil
+}
+
+// GetOutboxEvents returns the events of the outbox not yet streamed, oldest first
+func (m *memoryDB) GetOutboxEvents(_ context.Context, limit uint) ([]types.StoredDataEvent, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	events := make([]types.StoredDataEvent, 0, len(m.outbox))
+	for _, event := range m.outbox {
+		events = append(events, event)
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+
+	return events[:limited(len(events), limit)], nil
+}
+
+// DeleteOutboxEvents deletes the streamed events of the outbox. The events of the values stored again since
+// they were read have a new ID, so they are kept to be streamed again.
+func (m *memoryDB) DeleteOutboxEvents(_ context.Context, ids []uint64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	deleted := make(map[uint64]bool, len(ids))
+	for _, id := range ids {
+		deleted[id] = true
+	}
+	for key, event := range m.outbox {
+		if deleted[event.ID] {
+			delete(m.outbox, key)
+		}
+	}
+
+	return nil
+}
+
+// GetUnshardedOffChainData returns the values not yet erasure coded into shards, lowest batch number first
+func (m *memoryDB) GetUnshardedOffChainData(_ context.Context, limit uint) ([]types.OffChainData, error) {
+	return m.listValues(func(data types.OffChainData) bool {
+		_, ok := m.commitments[data.Key]
+		return !ok
+	}, byBatch, limit), nil
+}
+
+// StoreShards records the commitment to the shards of a value along with the shards held by the node,
+// keeping the ones already recorded
+func (m *memoryDB) StoreShards(_ context.Context, commitment types.ShardCommitment, shards []types.Shard) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	// the shards of a value are deleted along with it, so they can't be stored without it
+	if _, ok := m.values[commitment.Key]; !ok {
+		return fmt.Errorf("no value %s to store the shards of", commitment.Key.Hex())
+	}
+	for _, shard := range shards {
+		if _, ok := m.commitments[shard.Key]; !ok && shard.Key != commitment.Key {
+			return fmt.Errorf("no commitment to the shard %d of %s", shard.Index, shard.Key.Hex())
+		}
+	}
+
+	if _, ok := m.commitments[commitment.Key]; !ok {
+		m.commitments[commitment.Key] = commitment
+	}
+	for _, shard := range shards {
+		held, ok := m.shards[shard.Key]
+		if !ok {
+			held = make(map[uint]types.Shard)
+			m.shards[shard.Key] = held
+		}
+		if _, ok := held[shard.Index]; !ok {
+			held[shard.Index] = copyShard(shard)
+		}
+	}
+
+	return nil
+}
+
+// GetShardCommitment returns the commitment to the shards of the value of the given key
+func (m *memoryDB) GetShardCommitment(_ context.Context, key common.Hash) (*types.ShardCommitment, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	commitment, ok := m.commitments[key]
+	if !ok {
+		return nil, ErrStateNotSynchronized
+	}
+
+	return &commitment, nil
+}
+
+// GetShards returns the shards of the value of the given key held by the node among the given indices
+func (m *memoryDB) GetShards(_ context.Context, key common.Hash, indices []uint) ([]types.Shard, error) {
+	if len(indices) == 0 {
+		return nil, nil
+	}
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	listed := make(map[uint]bool, len(indices))
+	shards := make([]types.Shard, 0, len(indices))
+	for _, index := range indices {
+		shard, ok := m.shards[key][index]
+		if !ok || listed[index] {
+			continue
+		}
+		listed[index] = true
+		shards = append(shards, copyShard(shard))
+	}
+	sort.Slice(shards, func(i, j int) bool { return shards[i].Index < shards[j].Index })
+
+	return shards, nil
+}
+
+// StoreServedValues records the committee members the values were fetched from
+func (m *memoryDB) StoreServedValues(_ context.Context, served []types.ServedValue) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, s := range served {
+		if _, ok := m.values[s.Key]; !ok {
+			return fmt.Errorf("no value %s to record the member it was served by", s.Key.Hex())
+		}
+	}
+	for _, s := range served {
+		s.Signature = common.CopyBytes(s.Signature)
+		m.served[s.Key] = s
+	}
+
+	return nil
+}
+
+// GetServedValue returns the committee member the value identified by the key was fetched from
+func (m *memoryDB) GetServedValue(_ context.Context, key common.Hash) (*types.ServedValue, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	served, ok := m.served[key]
+	if !ok {
+		return nil, ErrStateNotSynchronized
+	}
+	served.Signature = common.CopyBytes(served.Signature)
+
+	return &served, nil
+}
+
+// StoreProvenance records how the values were obtained, keeping the first time each one was obtained
+// from a given source
+func (m *memoryDB) StoreProvenance(_ context.Context, provenance []types.Provenance) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, p := range provenance {
+		if _, ok := m.values[p.Key]; !ok {
+			return fmt.Errorf("no value %s to record the provenance of", p.Key.Hex())
+		}
+	}
+	for _, p := range provenance {
+		recorded := false
+		for _, r := range m.provenance[p.Key] {
+			if r.Source == p.Source && r.Origin == p.Origin {
+				recorded = true
+				break
+			}
+		}
+		if !recorded {
+			m.provenance[p.Key] = append(m.provenance[p.Key], p)
+		}
+	}
+
+	return nil
+}
+
+// GetProvenance returns how the value identified by the key was obtained, in the order it was obtained
+func (m *memoryDB) GetProvenance(_ context.Context, key common.Hash) ([]types.Provenance, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	provenance := append(make([]types.Provenance, 0), m.provenance[key]...)
+	sort.Slice(provenance, func(i, j int) bool {
+		if !provenance[i].ObtainedAt.Equal(provenance[j].ObtainedAt) {
+			return provenance[i].ObtainedAt.Before(provenance[j].ObtainedAt)
+		}
+		if provenance[i].Source != provenance[j].Source {
+			return provenance[i].Source < provenance[j].Source
+		}
+		return provenance[i].Origin < provenance[j].Origin
+	})
+
+	return provenance, nil
+}
+
+// deleteValue deletes the value of the key along with what references it, the lock being held
+func (m *memoryDB) deleteValue(key common.Hash) {
+	data, ok := m.values[key]
+	if !ok {
+		return
+	}
+
+	m.size -= uint64(len(data.Value))
+	delete(m.values, key)
+	delete(m.outbox, key)
+	delete(m.commitments, key)
+	delete(m.shards, key)
+	delete(m.served, key)
+	delete(m.provenance, key)
+}
+
+// listValues returns a copy of the values matching the filter in the given order, at most limit of them
+func (m *memoryDB) listValues(
+	filter func(types.OffChainData) bool,
+	less func(a, b types.OffChainData) bool,
+	limit uint,
+) []types.OffChainData {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	list := make([]types.OffChainData, 0)
+	for _, data := range m.values {
+		if filter(data) {
+			list = append(list, data)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return less(list[i], list[j]) })
+
+	list = list[:limited(len(list), limit)]
+	for i := range list {
+		list[i] = copyOffChainData(list[i])
+	}
+
+	return list
+}
+
+// byBatch orders the values by batch number, then by key
+func byBatch(a, b types.OffChainData) bool {
+	if a.BatchNum != b.BatchNum {
+		return a.BatchNum < b.BatchNum
+	}
+	return byKey(a, b)
+}
+
+// byKey orders the values by key
+func byKey(a, b types.OffChainData) bool {
+	return bytes.Compare(a.Key[:], b.Key[:]) < 0
+}
+
+// limited returns the number of the n rows returned with the given limit
+func limited(n int, limit uint) int {
+	if uint(n) > limit {
+		return int(limit)
+	}
+	return n
+}
+
+// idOffset returns the index of the record with the given ID among n records numbered from 1
+func idOffset(fromID uint64, n int) int {
+	if fromID <= 1 {
+		return 0
+	}
+	if fromID > uint64(n) {
+		return n
+	}
+	return int(fromID - 1)
+}
+
+func copyOffChainData(data types.OffChainData) types.OffChainData {
+	data.Value = common.CopyBytes(data.Value)
+	return data
+}
+
+func copyCertificate(certificate types.Certificate) types.Certificate {
+	signatures := make([]types.ArgBytes, len(certificate.Signatures))
+	for i, signature := range certificate.Signatures {
+		signatures[i] = common.CopyBytes(signature)
+	}
+	certificate.Signatures = signatures
+	return certificate
+}
+
+func copyShard(shard types.Shard) types.Shard {
+	shard.Data = common.CopyBytes(shard.Data)
+	shard.Proof = append([]common.Hash(nil), shard.Proof...)
+	return shard
+}
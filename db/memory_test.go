@@ -0,0 +1,375 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Memory_LastProcessedBlock(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	m := NewMemory(0)
+
+	block, err := m.GetLastProcessedBlock(ctx, "L1")
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), block)
+
+	_, err = m.GetLastProcessedBlock(ctx, "committee")
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	require.NoError(t, m.StoreLastProcessedBlock(ctx, 10, "committee"))
+	block, err = m.GetLastProcessedBlock(ctx, "committee")
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), block)
+}
+
+func Test_Memory_UnresolvedBatchKeys(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	m := NewMemory(0)
+
+	keys := []types.BatchKey{
+		{Number: 2, Hash: common.HexToHash("0x2")},
+		{Number: 1, Hash: common.HexToHash("0x1")},
+	}
+	require.NoError(t, m.StoreUnresolvedBatchKeys(ctx, keys))
+	require.NoError(t, m.StoreUnresolvedBatchKeys(ctx, keys[:1]))
+
+	stored, err := m.GetUnresolvedBatchKeys(ctx, 10)
+	require.NoError(t, err)
+	require.Equal(t, []types.BatchKey{keys[1], keys[0]}, stored)
+
+	stored, err = m.GetUnresolvedBatchKeys(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, []types.BatchKey{keys[1]}, stored)
+
+	require.NoError(t, m.DeleteUnresolvedBatchKeys(ctx, keys[1:]))
+	stored, err = m.GetUnresolvedBatchKeys(ctx, 10)
+	require.NoError(t, err)
+	require.Equal(t, []types.BatchKey{keys[0]}, stored)
+}
+
+func Test_Memory_OffChainData(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	m := NewFromConfig(nil, Config{Memory: true}, true)
+
+	od := []types.OffChainData{
+		{Key: common.HexToHash("0x2"), Value: []byte("value2"), BatchNum: 2},
+		{Key: common.HexToHash("0x1"), Value: []byte("value1"), BatchNum: 1},
+		{Key: common.HexToHash("0x3"), Value: []byte("value3")},
+	}
+	require.NoError(t, m.StoreOffChainData(ctx, od))
+
+	t.Run("get", func(t *testing.T) {
+		data, err := m.GetOffChainData(ctx, od[0].Key)
+		require.NoError(t, err)
+		require.Equal(t, od[0], *data)
+
+		// the stored value is a copy
+		data.Value[0] = 'x'
+		data, err = m.GetOffChainData(ctx, od[0].Key)
+		require.NoError(t, err)
+		require.Equal(t, od[0], *data)
+
+		_, err = m.GetOffChainData(ctx, common.HexToHash("0xff"))
+		require.ErrorIs(t, err, ErrStateNotSynchronized)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		list, err := m.ListOffChainData(ctx, []common.Hash{od[1].Key, common.HexToHash("0xff"), od[1].Key})
+		require.NoError(t, err)
+		require.Equal(t, od[1:2], list)
+
+		list, err = m.ListOffChainDataByBatches(ctx, 1, 2, 10)
+		require.NoError(t, err)
+		require.Equal(t, []types.OffChainData{od[1], od[0]}, list)
+
+		list, err = m.ListOffChainDataPage(ctx, od[0].Key, 10)
+		require.NoError(t, err)
+		for _, data := range list {
+			require.Positive(t, data.Key.Big().Cmp(od[0].Key.Big()))
+		}
+
+		keys, err := m.GetOffChainDataKeys(ctx, 1, 1)
+		require.NoError(t, err)
+		require.Equal(t, []types.BatchKey{{Number: 1, Hash: od[1].Key}}, keys)
+	})
+
+	t.Run("count", func(t *testing.T) {
+		count, err := m.CountOffchainData(ctx)
+		require.NoError(t, err)
+		require.Equal(t, uint64(3), count)
+
+		// the values not matched to a batch are excluded
+		count, size, err := m.CountOffChainDataBefore(ctx, 10)
+		require.NoError(t, err)
+		require.Equal(t, uint64(2), count)
+		require.Equal(t, uint64(12), size)
+	})
+
+	t.Run("outbox", func(t *testing.T) {
+		events, err := m.GetOutboxEvents(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, events, 3)
+		require.Equal(t, od[0].Key, events[0].Key)
+		require.Equal(t, uint64(len(od[0].Value)), events[0].Size)
+	})
+}
+
+func Test_Memory_OutboxEventsStoredAgain(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	m := NewFromConfig(nil, Config{Memory: true}, true)
+
+	od := []types.OffChainData{{Key: common.HexToHash("0x1"), Value: []byte("value1"), BatchNum: 1}}
+	require.NoError(t, m.StoreOffChainData(ctx, od))
+	events, err := m.GetOutboxEvents(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	// stored again once read, the event is streamed again
+	require.NoError(t, m.StoreOffChainData(ctx, od))
+	require.NoError(t, m.DeleteOutboxEvents(ctx, []uint64{events[0].ID}))
+
+	again, err := m.GetOutboxEvents(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, again, 1)
+	require.Greater(t, again[0].ID, events[0].ID)
+
+	require.NoError(t, m.DeleteOutboxEvents(ctx, []uint64{again[0].ID}))
+	again, err = m.GetOutboxEvents(ctx, 10)
+	require.NoError(t, err)
+	require.Empty(t, again)
+
+	// without a stream nothing consumes the events, which are not recorded
+	m = NewMemory(0)
+	require.NoError(t, m.StoreOffChainData(ctx, od))
+	events, err = m.GetOutboxEvents(ctx, 10)
+	require.NoError(t, err)
+	require.Empty(t, events)
+}
+
+func Test_Memory_MaxSize(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	m := NewMemory(10)
+
+	require.NoError(t, m.StoreOffChainData(ctx, []types.OffChainData{
+		{Key: common.HexToHash("0x1"), Value: []byte("value1")},
+	}))
+
+	// the values are stored all at once or not at all
+	err := m.StoreOffChainData(ctx, []types.OffChainData{
+		{Key: common.HexToHash("0x2"), Value: []byte("va")},
+		{Key: common.HexToHash("0x3"), Value: []byte("value3")},
+	})
+	require.ErrorIs(t, err, ErrMemoryFull)
+	count, err := m.CountOffchainData(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), count)
+
+	// a value stored again replaces its size
+	require.NoError(t, m.StoreOffChainData(ctx, []types.OffChainData{
+		{Key: common.HexToHash("0x1"), Value: []byte("value1..")},
+		{Key: common.HexToHash("0x2"), Value: []byte("va")},
+	}))
+
+	// the space of the deleted values is reused
+	require.NoError(t, m.DeleteOffChainData(ctx, []common.Hash{common.HexToHash("0x1")}))
+	require.NoError(t, m.StoreOffChainData(ctx, []types.OffChainData{
+		{Key: common.HexToHash("0x3"), Value: []byte("value3")},
+	}))
+}
+
+func Test_Memory_DeleteCascades(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	m := NewFromConfig(nil, Config{Memory: true}, true)
+
+	key := common.HexToHash("0x1")
+	require.ErrorContains(t, m.StoreProvenance(ctx, []types.Provenance{{Key: key, Source: types.ProvenanceSequencer}}),
+		"no value")
+
+	require.NoError(t, m.StoreOffChainData(ctx, []types.OffChainData{{Key: key, Value: []byte("value1"), BatchNum: 1}}))
+	require.NoError(t, m.StoreProvenance(ctx, []types.Provenance{{Key: key, Source: types.ProvenanceSequencer}}))
+	require.NoError(t, m.StoreServedValues(ctx, []types.ServedValue{{Key: key, Member: common.HexToAddress("0x1")}}))
+	require.NoError(t, m.StoreShards(ctx,
+		types.ShardCommitment{Key: key, DataShards: 1, ParityShards: 1},
+		[]types.Shard{{Key: key, Index: 1, Data: []byte("shard1")}},
+	))
+	require.NoError(t, m.StoreCertificate(ctx, types.Certificate{DataHash: key}))
+
+	tombstone := types.Tombstone{Key: key, BatchNum: 1, PrunedAtBlock: 10, Reason: "retention", Timestamp: time.Now()}
+	require.NoError(t, m.PruneOffChainData(ctx, []types.Tombstone{tombstone}))
+
+	_, err := m.GetOffChainData(ctx, key)
+	require.ErrorIs(t, err, ErrStateNotSynchronized)
+	_, err = m.GetShardCommitment(ctx, key)
+	require.ErrorIs(t, err, ErrStateNotSynchronized)
+	_, err = m.GetServedValue(ctx, key)
+	require.ErrorIs(t, err, ErrStateNotSynchronized)
+	shards, err := m.GetShards(ctx, key, []uint{1})
+	require.NoError(t, err)
+	require.Empty(t, shards)
+	provenance, err := m.GetProvenance(ctx, key)
+	require.NoError(t, err)
+	require.Empty(t, provenance)
+	events, err := m.GetOutboxEvents(ctx, 10)
+	require.NoError(t, err)
+	require.Empty(t, events)
+
+	// the certificates and the tombstones are kept
+	_, err = m.GetCertificate(ctx, key)
+	require.NoError(t, err)
+	tombstones, err := m.GetTombstones(ctx, []common.Hash{key, common.HexToHash("0xff")})
+	require.NoError(t, err)
+	require.Equal(t, []types.Tombstone{tombstone}, tombstones)
+}
+
+func Test_Memory_Shards(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	m := NewMemory(0)
+
+	key := common.HexToHash("0x1")
+	require.NoError(t, m.StoreOffChainData(ctx, []types.OffChainData{{Key: key, Value: []byte("value1")}}))
+
+	commitment := types.ShardCommitment{Key: key, Root: common.HexToHash("0xa"), DataShards: 2, ParityShards: 1}
+	shards := []types.Shard{
+		{Key: key, Index: 2, Data: []byte("shard2"), Proof: []common.Hash{common.HexToHash("0xc")}},
+		{Key: key, Index: 0, Data: []byte("shard0")},
+	}
+	require.NoError(t, m.StoreShards(ctx, commitment, shards))
+
+	// the recorded commitment and shards are kept
+	require.NoError(t, m.StoreShards(ctx,
+		types.ShardCommitment{Key: key, Root: common.HexToHash("0xb")},
+		[]types.Shard{{Key: key, Index: 0, Data: []byte("other")}},
+	))
+
+	stored, err := m.GetShardCommitment(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, commitment, *stored)
+
+	held, err := m.GetShards(ctx, key, []uint{0, 1, 2})
+	require.NoError(t, err)
+	require.Equal(t, []types.Shard{shards[1], shards[0]}, held)
+
+	unsharded, err := m.GetUnshardedOffChainData(ctx, 10)
+	require.NoError(t, err)
+	require.Empty(t, unsharded)
+}
+
+func Test_Memory_Logs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	m := NewMemory(0)
+
+	sequenceHash := common.HexToHash("0x5")
+	require.NoError(t, m.StoreSignAuditEntry(ctx, types.SignAuditEntry{
+		SequenceHash: sequenceHash, Decision: types.SignDecisionUnauthorized,
+	}))
+	signed, err := m.HasSignedSequence(ctx, sequenceHash)
+	require.NoError(t, err)
+	require.False(t, signed)
+
+	require.NoError(t, m.StoreSignAuditEntry(ctx, types.SignAuditEntry{
+		SequenceHash: sequenceHash, Decision: types.SignDecisionSigned,
+	}))
+	signed, err = m.HasSignedSequence(ctx, sequenceHash)
+	require.NoError(t, err)
+	require.True(t, signed)
+
+	entries, err := m.ListSignAuditEntries(ctx, 2, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, uint64(2), entries[0].ID)
+
+	require.NoError(t, m.StoreCommitteeChanges(ctx, []types.CommitteeChange{
+		{Kind: types.CommitteeMemberAdded}, {Kind: types.CommitteeMemberRemoved}, {Kind: types.CommitteeMemberAdded},
+	}))
+	changes, err := m.ListCommitteeChanges(ctx, 2, 1)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	require.Equal(t, uint64(2), changes[0].ID)
+	require.Equal(t, types.CommitteeMemberRemoved, changes[0].Kind)
+
+	_, err = m.GetLastAttestation(ctx)
+	require.ErrorIs(t, err, ErrStateNotSynchronized)
+	require.NoError(t, m.StoreAttestation(ctx, types.Attestation{FromBatch: 1, ToBatch: 2}))
+	require.NoError(t, m.StoreAttestation(ctx, types.Attestation{FromBatch: 3, ToBatch: 4}))
+	attestation, err := m.GetLastAttestation(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), attestation.ID)
+	require.Equal(t, uint64(3), attestation.FromBatch)
+}
+
+func Test_Memory_Publications(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	m := NewMemory(0)
+
+	od := []types.OffChainData{
+		{Key: common.HexToHash("0x1"), Value: []byte("value1"), BatchNum: 1},
+		{Key: common.HexToHash("0x2"), Value: []byte("value2"), BatchNum: 2},
+	}
+	require.NoError(t, m.StoreOffChainData(ctx, od))
+	require.NoError(t, m.StorePublication(ctx, types.Publication{Key: od[0].Key, Backend: "s3", Reference: "ref"}))
+
+	unpublished, err := m.GetUnpublishedOffChainData(ctx, "s3", 10)
+	require.NoError(t, err)
+	require.Equal(t, od[1:], unpublished)
+
+	unpublished, err = m.GetUnpublishedOffChainData(ctx, "celestia", 10)
+	require.NoError(t, err)
+	require.Equal(t, od, unpublished)
+
+	publication, err := m.GetPublication(ctx, od[0].Key, "s3")
+	require.NoError(t, err)
+	require.Equal(t, "ref", publication.Reference)
+	_, err = m.GetPublication(ctx, od[1].Key, "s3")
+	require.ErrorIs(t, err, ErrStateNotSynchronized)
+}
+
+func Test_Memory_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	m := NewMemory(0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			data := types.OffChainData{Key: common.BigToHash(common.Big1), Value: []byte{byte(i)}, BatchNum: uint64(i)}
+			assert.NoError(t, m.StoreOffChainData(ctx, []types.OffChainData{data}))
+			_, err := m.GetOffChainData(ctx, data.Key)
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	count, err := m.CountOffchainData(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), count)
+}
@@ -1,7 +1,9 @@
 package db
 
 import (
-	"github.com/0xPolygon/cdk-data-availability/log"
+	"fmt"
+	"time"
+
 	"github.com/gobuffalo/packr/v2"
 	"github.com/jmoiron/sqlx"
 	migrate "github.com/rubenv/sql-migrate"
@@ -11,20 +13,104 @@ var packrMigrations = packr.New("migrations", "./migrations")
 
 // RunMigrationsUp runs migrate-up for the given config.
 func RunMigrationsUp(pg *sqlx.DB) error {
-	log.Info("running migrations up")
+	logger.Info("running migrations up")
 	return runMigrations(pg, migrate.Up)
 }
 
+// PendingMigrations returns the number of migrations not yet applied to the database
+func PendingMigrations(pg *sqlx.DB) (int, error) {
+	var migrations = &migrate.PackrMigrationSource{Box: packrMigrations}
+	planned, _, err := migrate.PlanMigration(pg.DB, "postgres", migrations, migrate.Up, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(planned), nil
+}
+
+// RunMigrationsDown rolls back the last count migrations applied, and returns the number rolled back
+func RunMigrationsDown(pg *sqlx.DB, count int) (int, error) {
+	logger.Infof("rolling back %d migrations", count)
+	var migrations = onlineSource{source: &migrate.PackrMigrationSource{Box: packrMigrations}}
+	return migrate.ExecMax(pg.DB, "postgres", migrations, migrate.Down, count)
+}
+
+// MigrationStatus is whether a migration is applied to the database
+type MigrationStatus struct {
+	ID string `json:"id"`
+	// AppliedAt is nil for a pending migration
+	AppliedAt *time.Time `json:"appliedAt"`
+}
+
+// MigrationsStatus returns the status of every migration, in the order they are applied
+func MigrationsStatus(pg *sqlx.DB) ([]MigrationStatus, error) {
+	var migrations = &migrate.PackrMigrationSource{Box: packrMigrations}
+	known, err := migrations.FindMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := migrate.GetMigrationRecords(pg.DB, "postgres")
+	if err != nil {
+		return nil, err
+	}
+	applied := make(map[string]time.Time, len(records))
+	for _, record := range records {
+		applied[record.Id] = record.AppliedAt
+	}
+
+	status := make([]MigrationStatus, len(known))
+	for i, migration := range known {
+		status[i].ID = migration.Id
+		if at, ok := applied[migration.Id]; ok {
+			status[i].AppliedAt = &at
+		}
+	}
+
+	return status, nil
+}
+
+// Migrate applies the pending migrations when auto is set, and otherwise fails when a migration is
+// pending, so that the migrations are only applied explicitly with the migrate command
+func Migrate(pg *sqlx.DB, auto bool) error {
+	if auto {
+		return RunMigrationsUp(pg)
+	}
+
+	pending, err := PendingMigrations(pg)
+	if err != nil {
+		return err
+	}
+	if pending > 0 {
+		return fmt.Errorf("%d migrations pending and DB.DisableAutoMigrate set, apply them with the migrate command",
+			pending)
+	}
+
+	return nil
+}
+
 // runMigrations will execute pending migrations if needed to keep
 // the database updated with the latest changes in either direction,
 // up or down.
 func runMigrations(db *sqlx.DB, direction migrate.MigrationDirection) error {
-	var migrations = &migrate.PackrMigrationSource{Box: packrMigrations}
-	nMigrations, err := migrate.Exec(db.DB, "postgres", migrations, direction)
-	if err != nil {
-		return err
+	var migrations = onlineSource{source: &migrate.PackrMigrationSource{Box: packrMigrations}}
+
+	var total int
+	for attempt := 1; ; attempt++ {
+		// the migrations applied before one timing out acquiring its locks are recorded and not run again
+		nMigrations, err := migrate.Exec(db.DB, "postgres", migrations, direction)
+		total += nMigrations
+		if err == nil {
+			break
+		}
+		if !isLockTimeout(err) || attempt == migrationAttempts {
+			return err
+		}
+
+		logger.Warnf("migration timed out acquiring its locks, retrying: %v", err)
+		time.Sleep(time.Duration(attempt) * migrationRetryDelay)
 	}
 
-	log.Info("successfully ran ", nMigrations, " migrations")
+	logger.Info("successfully ran ", total, " migrations")
 	return nil
 }
@@ -0,0 +1,28 @@
+-- +migrate Down
+DROP TABLE IF EXISTS data_node.sign_audit CASCADE;
+DROP FUNCTION IF EXISTS data_node.sign_audit_append_only();
+
+-- +migrate Up
+CREATE TABLE data_node.sign_audit
+(
+    id            BIGSERIAL PRIMARY KEY,
+    requester     VARCHAR(255) NOT NULL,
+    sequence_hash VARCHAR(255) NOT NULL,
+    batch_count   BIGINT NOT NULL,
+    decision      VARCHAR(32) NOT NULL,
+    signature     VARCHAR(255) NOT NULL DEFAULT '',
+    created_at    TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- The audit log is append-only, records can never be changed or removed
+-- +migrate StatementBegin
+CREATE FUNCTION data_node.sign_audit_append_only() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'data_node.sign_audit is append-only';
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+CREATE TRIGGER sign_audit_append_only
+    BEFORE UPDATE OR DELETE OR TRUNCATE ON data_node.sign_audit
+    FOR EACH STATEMENT EXECUTE PROCEDURE data_node.sign_audit_append_only();
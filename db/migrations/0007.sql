@@ -0,0 +1,17 @@
+-- +migrate Down
+DROP TABLE IF EXISTS data_node.committee_changes CASCADE;
+
+-- +migrate Up
+CREATE TABLE data_node.committee_changes
+(
+    id            BIGSERIAL PRIMARY KEY,
+    block_number  BIGINT NOT NULL,
+    tx_hash       VARCHAR(255) NOT NULL,
+    kind          VARCHAR(32) NOT NULL,
+    member        VARCHAR(255) NOT NULL,
+    url           VARCHAR NOT NULL,
+    previous_url  VARCHAR NOT NULL DEFAULT '',
+    created_at    TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS committee_changes_member_idx ON data_node.committee_changes (member);
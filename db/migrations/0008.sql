@@ -0,0 +1,12 @@
+-- +migrate Down
+DROP TABLE IF EXISTS data_node.publications CASCADE;
+
+-- +migrate Up
+CREATE TABLE data_node.publications
+(
+    key           VARCHAR NOT NULL,
+    backend       VARCHAR(32) NOT NULL,
+    reference     VARCHAR NOT NULL,
+    created_at    TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (key, backend)
+);
@@ -0,0 +1,14 @@
+-- +migrate Down
+DROP TABLE IF EXISTS data_node.attestations CASCADE;
+
+-- +migrate Up
+CREATE TABLE data_node.attestations
+(
+    id            SERIAL PRIMARY KEY,
+    root          VARCHAR NOT NULL,
+    from_batch    BIGINT NOT NULL,
+    to_batch      BIGINT NOT NULL,
+    key_count     INTEGER NOT NULL,
+    tx_hash       VARCHAR NOT NULL,
+    created_at    TIMESTAMP WITH TIME ZONE NOT NULL
+);
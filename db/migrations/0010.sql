@@ -0,0 +1,12 @@
+-- +migrate Down
+DROP TABLE IF EXISTS data_node.certificates CASCADE;
+
+-- +migrate Up
+CREATE TABLE data_node.certificates
+(
+    data_hash       VARCHAR PRIMARY KEY,
+    committee_hash  VARCHAR NOT NULL,
+    threshold       INTEGER NOT NULL,
+    signatures      VARCHAR NOT NULL,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL
+);
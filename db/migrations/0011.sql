@@ -0,0 +1,14 @@
+-- +migrate Down
+DROP TABLE IF EXISTS data_node.outbox CASCADE;
+
+-- +migrate Up
+CREATE TABLE data_node.outbox
+(
+    key           VARCHAR PRIMARY KEY REFERENCES data_node.offchain_data (key) ON DELETE CASCADE,
+    id            BIGSERIAL NOT NULL,
+    batch_num     BIGINT NOT NULL,
+    size          INTEGER NOT NULL,
+    created_at    TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX outbox_id_idx ON data_node.outbox (id);
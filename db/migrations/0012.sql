@@ -0,0 +1,25 @@
+-- +migrate Down
+DROP TABLE IF EXISTS data_node.shards CASCADE;
+DROP TABLE IF EXISTS data_node.shard_commitments CASCADE;
+
+-- +migrate Up
+CREATE TABLE data_node.shard_commitments
+(
+    key           VARCHAR PRIMARY KEY REFERENCES data_node.offchain_data (key) ON DELETE CASCADE,
+    root          VARCHAR NOT NULL,
+    data_shards   INTEGER NOT NULL,
+    parity_shards INTEGER NOT NULL,
+    size          INTEGER NOT NULL,
+    member_index  INTEGER NOT NULL,
+    members       INTEGER NOT NULL,
+    created_at    TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE TABLE data_node.shards
+(
+    key           VARCHAR NOT NULL REFERENCES data_node.shard_commitments (key) ON DELETE CASCADE,
+    idx           INTEGER NOT NULL,
+    data          VARCHAR NOT NULL,
+    proof         VARCHAR NOT NULL,
+    PRIMARY KEY (key, idx)
+);
@@ -0,0 +1,5 @@
+-- +migrate Down
+DROP INDEX IF EXISTS data_node.sign_audit_sequence_hash_idx;
+
+-- +migrate Up
+CREATE INDEX IF NOT EXISTS sign_audit_sequence_hash_idx ON data_node.sign_audit (sequence_hash);
@@ -0,0 +1,11 @@
+-- +migrate Down
+DROP TABLE IF EXISTS data_node.served_by CASCADE;
+
+-- +migrate Up
+CREATE TABLE data_node.served_by
+(
+    key           VARCHAR PRIMARY KEY REFERENCES data_node.offchain_data (key) ON DELETE CASCADE,
+    member        VARCHAR NOT NULL,
+    signature     VARCHAR,
+    served_at     TIMESTAMP WITH TIME ZONE NOT NULL
+);
@@ -0,0 +1,13 @@
+-- +migrate Down
+DROP TABLE IF EXISTS data_node.tombstones CASCADE;
+
+-- +migrate Up
+CREATE TABLE data_node.tombstones
+(
+    key             VARCHAR PRIMARY KEY,
+    batch_num       BIGINT NOT NULL,
+    pruned_at_block BIGINT NOT NULL,
+    reason          VARCHAR NOT NULL,
+    signature       VARCHAR,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL
+);
@@ -0,0 +1,12 @@
+-- +migrate Down
+DROP TABLE IF EXISTS data_node.provenance CASCADE;
+
+-- +migrate Up
+CREATE TABLE data_node.provenance
+(
+    key           VARCHAR NOT NULL REFERENCES data_node.offchain_data (key) ON DELETE CASCADE,
+    source        VARCHAR NOT NULL,
+    origin        VARCHAR NOT NULL DEFAULT '',
+    obtained_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (key, source, origin)
+);
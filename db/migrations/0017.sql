@@ -0,0 +1,11 @@
+-- +migrate Down
+DROP TABLE IF EXISTS data_node.backfills CASCADE;
+
+-- +migrate Up
+CREATE TABLE data_node.backfills
+(
+    name          VARCHAR PRIMARY KEY,
+    position      VARCHAR NOT NULL DEFAULT '',
+    completed     BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at    TIMESTAMP WITH TIME ZONE NOT NULL
+);
@@ -0,0 +1,9 @@
+-- The index is built without blocking the writes to the table, which can't be done in a transaction.
+-- A build that failed leaves an invalid index behind, dropped before building it again.
+
+-- +migrate Down notransaction
+DROP INDEX CONCURRENTLY IF EXISTS data_node.offchain_data_batch_num_idx;
+
+-- +migrate Up notransaction
+DROP INDEX CONCURRENTLY IF EXISTS data_node.offchain_data_batch_num_idx;
+CREATE INDEX CONCURRENTLY offchain_data_batch_num_idx ON data_node.offchain_data (batch_num, key);
@@ -0,0 +1,176 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+const (
+	// migrationLockTimeout bounds how long a migration waits for the locks it takes, so that a migration
+	// queued behind a long transaction doesn't block all the queries queued behind it in turn
+	migrationLockTimeout = "5s"
+
+	// migrationAttempts is how many times the migrations are run when one times out acquiring its locks
+	migrationAttempts = 5
+
+	// lockNotAvailable is the code of the error raised when the lock_timeout expires
+	lockNotAvailable = "55P03"
+)
+
+// migrationRetryDelay is the delay before running again the migrations, multiplied by the attempt
+var migrationRetryDelay = time.Second
+
+// onlineSource sets the lock_timeout of the migrations run in a transaction. The migrations run
+// without one, e.g. to create an index CONCURRENTLY, only take locks that don't block the writes.
+type onlineSource struct {
+	source migrate.MigrationSource
+}
+
+// FindMigrations returns the migrations of the source, starting by setting the lock_timeout
+func (s onlineSource) FindMigrations() ([]*migrate.Migration, error) {
+	const setLockTimeoutSQL = "SET LOCAL lock_timeout = '" + migrationLockTimeout + "';\n"
+
+	migrations, err := s.source.FindMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	online := make([]*migrate.Migration, len(migrations))
+	for i, migration := range migrations {
+		m := *migration
+		if !m.DisableTransactionUp {
+			m.Up = append([]string{setLockTimeoutSQL}, m.Up...)
+		}
+		if !m.DisableTransactionDown {
+			m.Down = append([]string{setLockTimeoutSQL}, m.Down...)
+		}
+		online[i] = &m
+	}
+
+	return online, nil
+}
+
+// isLockTimeout reports whether the error is a statement giving up on acquiring a lock
+func isLockTimeout(err error) bool {
+	// the errors of the migrations don't unwrap to the error of the statement
+	var txErr *migrate.TxError
+	if errors.As(err, &txErr) {
+		err = txErr.Err
+	}
+
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == lockNotAvailable
+}
+
+// Backfill migrates the existing rows of a table in batches, each in its own short transaction, so that
+// the migration of a large table neither holds its locks nor delays the writes to it for long. It runs
+// after the schema migrations, while the node is serving, and resumes where it stopped on restart.
+type Backfill struct {
+	// Name identifies the backfill, and its progress in data_node.backfills
+	Name string
+
+	// Batch migrates at most limit rows after the cursor, and returns the cursor of the last row
+	// migrated, or an empty cursor once no row is left
+	Batch func(ctx context.Context, tx *sqlx.Tx, cursor string, limit uint) (string, error)
+}
+
+// backfills are the backfills run in order after the migrations, a migration changing the existing
+// rows of a large table registers one here rather than updating them all in its transaction
+var backfills []Backfill
+
+// RunBackfills runs the backfills not completed yet, pausing between their batches
+func RunBackfills(ctx context.Context, pg *sqlx.DB, batchSize uint, pause time.Duration) error {
+	return runBackfills(ctx, pg, backfills, batchSize, pause)
+}
+
+func runBackfills(ctx context.Context, pg *sqlx.DB, list []Backfill, batchSize uint, pause time.Duration) error {
+	for _, backfill := range list {
+		if err := runBackfill(ctx, pg, backfill, batchSize, pause); err != nil {
+			return fmt.Errorf("backfill %s: %w", backfill.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func runBackfill(ctx context.Context, pg *sqlx.DB, backfill Backfill, batchSize uint, pause time.Duration) error {
+	const getBackfillSQL = `SELECT position, completed FROM data_node.backfills WHERE name = $1;`
+
+	var progress struct {
+		Position  string `db:"position"`
+		Completed bool   `db:"completed"`
+	}
+	if err := pg.GetContext(ctx, &progress, getBackfillSQL, backfill.Name); err != nil &&
+		!errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	if progress.Completed {
+		return nil
+	}
+
+	logger.Infof("running backfill %s from %q", backfill.Name, progress.Position)
+	cursor := progress.Position
+	for {
+		next, err := runBackfillBatch(ctx, pg, backfill, cursor, batchSize)
+		switch {
+		case isLockTimeout(err):
+			// the batch is run again after the pause, once the conflicting transaction is done
+			logger.Warnf("backfill %s timed out acquiring its locks after %q", backfill.Name, cursor)
+		case err != nil:
+			return err
+		case next == "":
+			logger.Infof("backfill %s completed", backfill.Name)
+			return nil
+		default:
+			cursor = next
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pause):
+		}
+	}
+}
+
+// runBackfillBatch runs a batch of the backfill and records its progress in the same transaction
+func runBackfillBatch(
+	ctx context.Context, pg *sqlx.DB, backfill Backfill, cursor string, limit uint,
+) (string, error) {
+	const setLockTimeoutSQL = `SELECT set_config('lock_timeout', $1, true);`
+
+	const storeBackfillSQL = `
+		INSERT INTO data_node.backfills (name, position, completed, updated_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (name) DO UPDATE
+		SET position = EXCLUDED.position, completed = EXCLUDED.completed, updated_at = EXCLUDED.updated_at;
+	`
+
+	tx, err := pg.BeginTxx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var next string
+	if _, err = tx.ExecContext(ctx, setLockTimeoutSQL, migrationLockTimeout); err == nil {
+		if next, err = backfill.Batch(ctx, tx, cursor, limit); err == nil {
+			_, err = tx.ExecContext(ctx, storeBackfillSQL, backfill.Name, next, next == "")
+		}
+	}
+	if err != nil {
+		if txErr := tx.Rollback(); txErr != nil {
+			return "", fmt.Errorf("%v: rollback caused by %v", txErr, err)
+		}
+
+		return "", err
+	}
+
+	return next, tx.Commit()
+}
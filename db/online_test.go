@@ -0,0 +1,200 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	migrate "github.com/rubenv/sql-migrate"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_OnlineSource(t *testing.T) {
+	source := onlineSource{source: &migrate.MemoryMigrationSource{Migrations: []*migrate.Migration{
+		{Id: "1", Up: []string{"CREATE TABLE t (id INT);"}, Down: []string{"DROP TABLE t;"}},
+		{
+			Id:                     "2",
+			Up:                     []string{"CREATE INDEX CONCURRENTLY t_idx ON t (id);"},
+			Down:                   []string{"DROP INDEX CONCURRENTLY t_idx;"},
+			DisableTransactionUp:   true,
+			DisableTransactionDown: true,
+		},
+	}}}
+
+	migrations, err := source.FindMigrations()
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+
+	require.Len(t, migrations[0].Up, 2)
+	require.Contains(t, migrations[0].Up[0], "SET LOCAL lock_timeout")
+	require.Equal(t, "CREATE TABLE t (id INT);", migrations[0].Up[1])
+	require.Len(t, migrations[0].Down, 2)
+	require.Contains(t, migrations[0].Down[0], "SET LOCAL lock_timeout")
+
+	// SET LOCAL has no effect outside of a transaction
+	require.Equal(t, []string{"CREATE INDEX CONCURRENTLY t_idx ON t (id);"}, migrations[1].Up)
+	require.Equal(t, []string{"DROP INDEX CONCURRENTLY t_idx;"}, migrations[1].Down)
+
+	// the migrations of the source are left untouched
+	migrations, err = source.source.FindMigrations()
+	require.NoError(t, err)
+	require.Len(t, migrations[0].Up, 1)
+}
+
+func Test_OnlineSource_Packr(t *testing.T) {
+	migrations, err := onlineSource{source: &migrate.PackrMigrationSource{Box: packrMigrations}}.FindMigrations()
+	require.NoError(t, err)
+
+	// an index is only built CONCURRENTLY outside of a transaction
+	for _, migration := range migrations {
+		for _, statement := range migration.Up {
+			if strings.Contains(statement, "CONCURRENTLY") {
+				require.True(t, migration.DisableTransactionUp, migration.Id)
+			}
+		}
+		for _, statement := range migration.Down {
+			if strings.Contains(statement, "CONCURRENTLY") {
+				require.True(t, migration.DisableTransactionDown, migration.Id)
+			}
+		}
+	}
+}
+
+func Test_IsLockTimeout(t *testing.T) {
+	lockTimeout := &pq.Error{Code: lockNotAvailable}
+
+	require.True(t, isLockTimeout(lockTimeout))
+	require.True(t, isLockTimeout(fmt.Errorf("backfill: %w", lockTimeout)))
+	require.True(t, isLockTimeout(&migrate.TxError{Migration: &migrate.Migration{Id: "1"}, Err: lockTimeout}))
+
+	require.False(t, isLockTimeout(nil))
+	require.False(t, isLockTimeout(errors.New("error")))
+	require.False(t, isLockTimeout(&pq.Error{Code: "23505"}))
+	require.False(t, isLockTimeout(&migrate.TxError{Migration: &migrate.Migration{Id: "1"}, Err: errors.New("error")}))
+}
+
+func Test_RunBackfills(t *testing.T) {
+	const (
+		getBackfillSQL    = `SELECT position, completed FROM data_node.backfills WHERE name = \$1`
+		setLockTimeoutSQL = `SELECT set_config\('lock_timeout', \$1, true\)`
+		storeBackfillSQL  = `INSERT INTO data_node.backfills \(name, position, completed, updated_at\)`
+	)
+
+	// batch migrates the rows 1 to 5, two at a time
+	batch := func(ctx context.Context, tx *sqlx.Tx, cursor string, limit uint) (string, error) {
+		var from int
+		if cursor != "" {
+			if _, err := fmt.Sscan(cursor, &from); err != nil {
+				return "", err
+			}
+		}
+		if from >= 5 {
+			return "", nil
+		}
+		if _, err := tx.ExecContext(ctx, "UPDATE rows SET migrated = TRUE WHERE id > $1 AND id <= $2",
+			from, from+int(limit)); err != nil {
+			return "", err
+		}
+
+		return fmt.Sprint(from + int(limit)), nil
+	}
+
+	expectBatch := func(mock sqlmock.Sqlmock, name string, from int, next string, batchErr error) {
+		mock.ExpectBegin()
+		mock.ExpectExec(setLockTimeoutSQL).WithArgs(migrationLockTimeout).WillReturnResult(sqlmock.NewResult(0, 0))
+		if next != "" {
+			expected := mock.ExpectExec(`UPDATE rows`).WithArgs(from, from+2)
+			if batchErr != nil {
+				expected.WillReturnError(batchErr)
+				mock.ExpectRollback()
+				return
+			}
+			expected.WillReturnResult(sqlmock.NewResult(0, 2))
+		}
+		mock.ExpectExec(storeBackfillSQL).WithArgs(name, next, next == "").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+	}
+
+	t.Run("runs the batches until completed", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(getBackfillSQL).WithArgs("test").WillReturnRows(sqlmock.NewRows([]string{"position", "completed"}))
+		expectBatch(mock, "test", 0, "2", nil)
+		expectBatch(mock, "test", 2, "4", nil)
+		expectBatch(mock, "test", 4, "6", nil)
+		expectBatch(mock, "test", 6, "", nil)
+
+		err = runBackfills(context.Background(), sqlx.NewDb(db, "postgres"),
+			[]Backfill{{Name: "test", Batch: batch}}, 2, 0)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("resumes from the stored position", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(getBackfillSQL).WithArgs("test").
+			WillReturnRows(sqlmock.NewRows([]string{"position", "completed"}).AddRow("4", false))
+		expectBatch(mock, "test", 4, "6", nil)
+		expectBatch(mock, "test", 6, "", nil)
+
+		err = runBackfills(context.Background(), sqlx.NewDb(db, "postgres"),
+			[]Backfill{{Name: "test", Batch: batch}}, 2, 0)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("skips the completed backfills", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(getBackfillSQL).WithArgs("test").
+			WillReturnRows(sqlmock.NewRows([]string{"position", "completed"}).AddRow("", true))
+
+		err = runBackfills(context.Background(), sqlx.NewDb(db, "postgres"),
+			[]Backfill{{Name: "test", Batch: batch}}, 2, 0)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("runs the batch again after a lock timeout", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(getBackfillSQL).WithArgs("test").
+			WillReturnRows(sqlmock.NewRows([]string{"position", "completed"}).AddRow("4", false))
+		expectBatch(mock, "test", 4, "6", &pq.Error{Code: lockNotAvailable})
+		expectBatch(mock, "test", 4, "6", nil)
+		expectBatch(mock, "test", 6, "", nil)
+
+		err = runBackfills(context.Background(), sqlx.NewDb(db, "postgres"),
+			[]Backfill{{Name: "test", Batch: batch}}, 2, 0)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("stops on an error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(getBackfillSQL).WithArgs("test").WillReturnRows(sqlmock.NewRows([]string{"position", "completed"}))
+		expectBatch(mock, "test", 0, "2", errors.New("test error"))
+
+		err = runBackfills(context.Background(), sqlx.NewDb(db, "postgres"),
+			[]Backfill{{Name: "test", Batch: batch}}, 2, 0)
+		require.ErrorContains(t, err, "backfill test: test error")
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
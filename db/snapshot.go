@@ -0,0 +1,250 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+)
+
+// ErrNotEmpty is returned when restoring a snapshot to a database already holding values
+var ErrNotEmpty = errors.New("the database is not empty")
+
+// Snapshot is a consistent view of the state of the node: the values, the unresolved batch keys and the
+// blocks processed by the synchronizer tasks, all read in a single read only repeatable read transaction
+// while the node keeps running
+type Snapshot struct {
+	tx *sqlx.Tx
+}
+
+// BeginSnapshot starts a snapshot of the database, to be closed with Close
+func BeginSnapshot(ctx context.Context, pg *sqlx.DB) (*Snapshot, error) {
+	tx, err := pg.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Snapshot{tx: tx}, nil
+}
+
+// SchemaVersion returns the ID of the last migration applied to the database
+func (s *Snapshot) SchemaVersion(ctx context.Context) (string, error) {
+	const getSchemaVersionSQL = "SELECT id FROM gorp_migrations ORDER BY id DESC LIMIT 1;"
+
+	var version string
+	if err := s.tx.QueryRowContext(ctx, getSchemaVersionSQL).Scan(&version); err != nil {
+		return "", err
+	}
+
+	return version, nil
+}
+
+// LastProcessedBlocks returns the last block processed by each synchronizer task
+func (s *Snapshot) LastProcessedBlocks(ctx context.Context) (map[string]uint64, error) {
+	const getLastProcessedBlocksSQL = "SELECT task, block FROM data_node.sync_tasks;"
+
+	rows, err := s.tx.QueryContext(ctx, getLastProcessedBlocksSQL)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	blocks := make(map[string]uint64)
+	for rows.Next() {
+		var (
+			task  string
+			block uint64
+		)
+		if err = rows.Scan(&task, &block); err != nil {
+			return nil, err
+		}
+		blocks[task] = block
+	}
+
+	return blocks, rows.Err()
+}
+
+// UnresolvedBatchKeys returns all the unresolved batch keys, ordered by batch number
+func (s *Snapshot) UnresolvedBatchKeys(ctx context.Context) ([]types.BatchKey, error) {
+	const getUnresolvedBatchKeysSQL = "SELECT num, hash FROM data_node.unresolved_batches ORDER BY num, hash;"
+
+	rows, err := s.tx.QueryContext(ctx, getUnresolvedBatchKeysSQL)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var bks []types.BatchKey
+	for rows.Next() {
+		var (
+			num  uint64
+			hash string
+		)
+		if err = rows.Scan(&num, &hash); err != nil {
+			return nil, err
+		}
+		bks = append(bks, types.BatchKey{Number: num, Hash: common.HexToHash(hash)})
+	}
+
+	return bks, rows.Err()
+}
+
+// OffChainDataPage returns at most limit values whose key follows the given key, ordered by key
+func (s *Snapshot) OffChainDataPage(
+	ctx context.Context,
+	afterKey common.Hash,
+	limit uint,
+) ([]types.OffChainData, error) {
+	const listOffChainDataPageSQL = `
+		SELECT key, value, batch_num
+		FROM data_node.offchain_data
+		WHERE key > $1
+		ORDER BY key
+		LIMIT $2;
+	`
+
+	rows, err := s.tx.QueryContext(ctx, listOffChainDataPageSQL, afterKey.Hex(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := make([]types.OffChainData, 0, limit)
+	for rows.Next() {
+		var (
+			key, value string
+			batchNum   uint64
+		)
+		if err = rows.Scan(&key, &value, &batchNum); err != nil {
+			return nil, err
+		}
+		list = append(list, types.OffChainData{
+			Key:      common.HexToHash(key),
+			Value:    common.FromHex(value),
+			BatchNum: batchNum,
+		})
+	}
+
+	return list, rows.Err()
+}
+
+// Close ends the snapshot
+func (s *Snapshot) Close() error {
+	return s.tx.Rollback()
+}
+
+// Restore loads a snapshot into an empty database in a single transaction, so that a failed restore
+// leaves the database empty. The values restored are not streamed as new values to the consumers of
+// the DA events.
+type Restore struct {
+	tx *sqlx.Tx
+}
+
+// BeginRestore starts the restore of a snapshot, to be ended with Commit or Rollback. ErrNotEmpty is
+// returned when the database already holds values.
+func BeginRestore(ctx context.Context, pg *sqlx.DB) (*Restore, error) {
+	const countSQL = `
+		SELECT (SELECT COUNT(*) FROM data_node.offchain_data) + (SELECT COUNT(*) FROM data_node.unresolved_batches);
+	`
+
+	tx, err := pg.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var count uint64
+	if err = tx.QueryRowContext(ctx, countSQL).Scan(&count); err == nil && count > 0 {
+		err = ErrNotEmpty
+	}
+	if err != nil {
+		if txErr := tx.Rollback(); txErr != nil {
+			return nil, fmt.Errorf("%v: rollback caused by %v", txErr, err)
+		}
+
+		return nil, err
+	}
+
+	return &Restore{tx: tx}, nil
+}
+
+// StoreOffChainData stores the given values
+func (r *Restore) StoreOffChainData(ctx context.Context, od []types.OffChainData) error {
+	const storeOffChainDataSQL = `
+		INSERT INTO data_node.offchain_data (key, value, batch_num)
+		VALUES ($1, $2, $3);
+	`
+
+	for _, d := range od {
+		if _, err := r.tx.ExecContext(ctx, storeOffChainDataSQL,
+			d.Key.Hex(), common.Bytes2Hex(d.Value), d.BatchNum); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// StoreProvenance records how the restored values were obtained
+func (r *Restore) StoreProvenance(ctx context.Context, provenance []types.Provenance) error {
+	const storeProvenanceSQL = `
+		INSERT INTO data_node.provenance (key, source, origin, obtained_at)
+		VALUES ($1, $2, $3, $4);
+	`
+
+	for _, p := range provenance {
+		if _, err := r.tx.ExecContext(ctx, storeProvenanceSQL,
+			p.Key.Hex(), p.Source, p.Origin, p.ObtainedAt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// StoreUnresolvedBatchKeys stores the given unresolved batch keys
+func (r *Restore) StoreUnresolvedBatchKeys(ctx context.Context, bks []types.BatchKey) error {
+	const storeUnresolvedBatchesSQL = `
+		INSERT INTO data_node.unresolved_batches (num, hash)
+		VALUES ($1, $2);
+	`
+
+	for _, bk := range bks {
+		if _, err := r.tx.ExecContext(ctx, storeUnresolvedBatchesSQL, bk.Number, bk.Hash.Hex()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// StoreLastProcessedBlocks stores the last block processed by each synchronizer task
+func (r *Restore) StoreLastProcessedBlocks(ctx context.Context, blocks map[string]uint64) error {
+	const storeLastProcessedBlockSQL = `
+		INSERT INTO data_node.sync_tasks (task, block)
+		VALUES ($1, $2)
+		ON CONFLICT (task) DO UPDATE
+		SET block = EXCLUDED.block, processed = NOW();
+	`
+
+	for task, block := range blocks {
+		if _, err := r.tx.ExecContext(ctx, storeLastProcessedBlockSQL, task, block); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Commit ends the restore, storing what was restored
+func (r *Restore) Commit() error {
+	return r.tx.Commit()
+}
+
+// Rollback ends the restore, discarding what was restored
+func (r *Restore) Rollback() error {
+	return r.tx.Rollback()
+}
@@ -0,0 +1,125 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Snapshot(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	key := common.HexToHash("0x1")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT id FROM gorp_migrations ORDER BY id DESC LIMIT 1`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("0012.sql"))
+	mock.ExpectQuery(`SELECT task, block FROM data_node\.sync_tasks`).
+		WillReturnRows(sqlmock.NewRows([]string{"task", "block"}).AddRow("L1", 100))
+	mock.ExpectQuery(`SELECT num, hash FROM data_node\.unresolved_batches ORDER BY num, hash`).
+		WillReturnRows(sqlmock.NewRows([]string{"num", "hash"}).AddRow(3, common.HexToHash("0x2").Hex()))
+	mock.ExpectQuery(`SELECT key, value, batch_num FROM data_node\.offchain_data WHERE key > \$1 ORDER BY key LIMIT \$2`).
+		WithArgs(common.Hash{}.Hex(), 10).
+		WillReturnRows(sqlmock.NewRows([]string{"key", "value", "batch_num"}).AddRow(key.Hex(), "0102", 2))
+	mock.ExpectRollback()
+
+	snapshot, err := BeginSnapshot(context.Background(), sqlx.NewDb(db, "postgres"))
+	require.NoError(t, err)
+
+	version, err := snapshot.SchemaVersion(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "0012.sql", version)
+
+	blocks, err := snapshot.LastProcessedBlocks(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, map[string]uint64{"L1": 100}, blocks)
+
+	bks, err := snapshot.UnresolvedBatchKeys(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []types.BatchKey{{Number: 3, Hash: common.HexToHash("0x2")}}, bks)
+
+	page, err := snapshot.OffChainDataPage(context.Background(), common.Hash{}, 10)
+	require.NoError(t, err)
+	require.Equal(t, []types.OffChainData{{Key: key, Value: []byte{1, 2}, BatchNum: 2}}, page)
+
+	require.NoError(t, snapshot.Close())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_Restore(t *testing.T) {
+	t.Parallel()
+
+	const countSQL = `SELECT \(SELECT COUNT\(\*\) FROM data_node\.offchain_data\) \+ ` +
+		`\(SELECT COUNT\(\*\) FROM data_node\.unresolved_batches\)`
+
+	t.Run("empty database", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		key := common.HexToHash("0x1")
+		restoredAt := time.Unix(1700000000, 0).UTC()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(countSQL).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectExec(`INSERT INTO data_node\.offchain_data \(key, value, batch_num\) VALUES \(\$1, \$2, \$3\)`).
+			WithArgs(key.Hex(), "0102", uint64(2)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`INSERT INTO data_node\.provenance \(key, source, origin, obtained_at\) VALUES \(\$1, \$2, \$3, \$4\)`).
+			WithArgs(key.Hex(), types.ProvenanceSnapshot, "abcd", restoredAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`INSERT INTO data_node\.unresolved_batches \(num, hash\) VALUES \(\$1, \$2\)`).
+			WithArgs(uint64(3), key.Hex()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`INSERT INTO data_node\.sync_tasks \(task, block\) VALUES \(\$1, \$2\) ON CONFLICT \(task\) DO UPDATE SET block = EXCLUDED\.block, processed = NOW\(\)`).
+			WithArgs("L1", uint64(100)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		restore, err := BeginRestore(context.Background(), sqlx.NewDb(db, "postgres"))
+		require.NoError(t, err)
+		require.NoError(t, restore.StoreOffChainData(context.Background(),
+			[]types.OffChainData{{Key: key, Value: []byte{1, 2}, BatchNum: 2}}))
+		require.NoError(t, restore.StoreProvenance(context.Background(), []types.Provenance{{
+			Key:        key,
+			Source:     types.ProvenanceSnapshot,
+			Origin:     "abcd",
+			ObtainedAt: restoredAt,
+		}}))
+		require.NoError(t, restore.StoreUnresolvedBatchKeys(context.Background(),
+			[]types.BatchKey{{Number: 3, Hash: key}}))
+		require.NoError(t, restore.StoreLastProcessedBlocks(context.Background(), map[string]uint64{"L1": 100}))
+		require.NoError(t, restore.Commit())
+
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database not empty", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(countSQL).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+		mock.ExpectRollback()
+
+		_, err = BeginRestore(context.Background(), sqlx.NewDb(db, "postgres"))
+		require.ErrorIs(t, err, ErrNotEmpty)
+
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
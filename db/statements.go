@@ -0,0 +1,79 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// statements caches the prepared statements of the hot queries. Each query is prepared once for the pool
+// the first time it runs, database/sql preparing it again on every connection it is then used on, so that
+// postgres parses it once per connection and can reuse its plan.
+type statements struct {
+	pg    *sqlx.DB
+	lock  sync.Mutex
+	stmts map[string]*sqlx.Stmt
+}
+
+// newStatements returns an empty cache of the statements prepared on the given pool
+func newStatements(pg *sqlx.DB) *statements {
+	return &statements{
+		pg:    pg,
+		stmts: make(map[string]*sqlx.Stmt),
+	}
+}
+
+// get returns the statement of the query, preparing it if it is not cached yet
+func (s *statements) get(ctx context.Context, query string) (*sqlx.Stmt, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if stmt, ok := s.stmts[query]; ok {
+		return stmt, nil
+	}
+
+	stmt, err := s.pg.PreparexContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	s.stmts[query] = stmt
+
+	return stmt, nil
+}
+
+// queryRowx runs a query returning at most one row, through its prepared statement when the hot
+// queries are prepared. A query that can not be prepared is run unprepared.
+func (db *pgDB) queryRowx(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
+	if db.stmts != nil {
+		if stmt, err := db.stmts.get(ctx, query); err == nil {
+			return stmt.QueryRowxContext(ctx, args...)
+		}
+	}
+
+	return db.pg.QueryRowxContext(ctx, query, args...)
+}
+
+// queryx runs a query, through its prepared statement when the hot queries are prepared
+func (db *pgDB) queryx(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error) {
+	if db.stmts != nil {
+		if stmt, err := db.stmts.get(ctx, query); err == nil {
+			return stmt.QueryxContext(ctx, args...)
+		}
+	}
+
+	return db.pg.QueryxContext(ctx, query, args...)
+}
+
+// txExec executes a statement in the transaction, through its prepared statement when the hot
+// queries are prepared
+func (db *pgDB) txExec(ctx context.Context, tx *sqlx.Tx, query string, args ...interface{}) (sql.Result, error) {
+	if db.stmts != nil {
+		if stmt, err := db.stmts.get(ctx, query); err == nil {
+			return tx.StmtxContext(ctx, stmt).ExecContext(ctx, args...)
+		}
+	}
+
+	return tx.ExecContext(ctx, query, args...)
+}
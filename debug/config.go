@@ -0,0 +1,14 @@
+package debug
+
+// Config represents the configuration of the debug listener
+type Config struct {
+	// Enabled exposes the pprof profiles and the expvar variables of the node.
+	// It must not be exposed publicly, so it listens on localhost by default.
+	Enabled bool `mapstructure:"Enabled"`
+
+	// Host defines the network adapter that will be used to serve the debug endpoints
+	Host string `mapstructure:"Host"`
+
+	// Port defines the port to serve the debug endpoints at /debug/pprof/ and /debug/vars
+	Port int `mapstructure:"Port"`
+}
@@ -0,0 +1,61 @@
+package debug
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/log"
+)
+
+// readHeaderTimeout bounds the time to read the headers of a debug request. There is no
+// write timeout, as CPU profiles and traces are written after the requested duration.
+const readHeaderTimeout = 10 * time.Second
+
+// Handler serves the pprof profiles at /debug/pprof/ and the expvar variables at /debug/vars
+func Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/vars", expvar.Handler())
+
+	return mux
+}
+
+// Server serves the debug endpoints on a dedicated listener
+type Server struct {
+	srv *http.Server
+}
+
+// NewServer returns the debug server
+func NewServer(cfg Config) *Server {
+	return &Server{
+		srv: &http.Server{
+			Addr:              fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+			Handler:           Handler(),
+			ReadHeaderTimeout: readHeaderTimeout,
+		},
+	}
+}
+
+// Start serves the debug endpoints until the server is stopped
+func (s *Server) Start() error {
+	log.Infof("debug server started: %s", s.srv.Addr)
+	if err := s.srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// Stop shuts down the debug server
+func (s *Server) Stop() error {
+	return s.srv.Shutdown(context.Background())
+}
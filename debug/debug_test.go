@@ -0,0 +1,45 @@
+package debug
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+	}{
+		{
+			name:     "pprof index",
+			path:     "/debug/pprof/",
+			expected: "goroutine",
+		},
+		{
+			name:     "goroutine dump",
+			path:     "/debug/pprof/goroutine?debug=1",
+			expected: "goroutine profile",
+		},
+		{
+			name:     "expvar variables",
+			path:     "/debug/vars",
+			expected: `"memstats"`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			res := httptest.NewRecorder()
+			Handler().ServeHTTP(res, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			require.Equal(t, http.StatusOK, res.Code)
+			require.Contains(t, res.Body.String(), tt.expected)
+		})
+	}
+}
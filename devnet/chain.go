@@ -0,0 +1,576 @@
+package devnet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/etherman/smartcontracts/attestation/dacattestation"
+	"github.com/0xPolygon/cdk-data-availability/etherman/smartcontracts/etrog/polygondatacommittee"
+	"github.com/0xPolygon/cdk-data-availability/etherman/smartcontracts/etrog/polygonvalidium"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// defaultChainID is the chain ID of the simulated L1 when L1.ChainID is not set
+const defaultChainID = 1337
+
+var (
+	// validiumABI is the ABI of the simulated PolygonValidium contract, parsed once
+	validiumABI = mustABI(polygonvalidium.PolygonvalidiumMetaData)
+	// committeeABI is the ABI of the simulated PolygonDataCommittee contract
+	committeeABI = mustABI(polygondatacommittee.PolygondatacommitteeMetaData)
+	// attestationABI is the ABI of the attestation contract the attestations are submitted to
+	attestationABI = mustABI(dacattestation.DacattestationMetaData)
+
+	// contractCode is the code of the simulated contracts, only checked not to be empty
+	contractCode = []byte{0x60, 0x80, 0x60, 0x40}
+)
+
+// mustABI returns the ABI of the binding, panicking if it can not be parsed
+func mustABI(metaData *bind.MetaData) *abi.ABI {
+	parsed, err := metaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// Chain is a simulated L1 running the PolygonValidium and PolygonDataCommittee contracts of the devnet.
+// It implements etherman.Etherman for the node to run on: the sequences and the attestations are
+// transactions mined in a block of their own, and the empty blocks are mined by Mine.
+type Chain struct {
+	chainID       *big.Int
+	validium      common.Address
+	dataCommittee common.Address
+	sequencer     common.Address
+	committee     etherman.DataCommittee
+
+	lock      sync.RWMutex
+	headers   []*ethTypes.Header
+	bodies    [][]*ethTypes.Transaction
+	txs       map[common.Hash]*ethTypes.Transaction
+	receipts  map[common.Hash]*ethTypes.Receipt
+	logs      []ethTypes.Log
+	lastBatch uint64
+	nonce     uint64
+	// forks is the number of reorganizations, set in the extra data of the blocks of the forks so that
+	// they don't hash as the blocks they replace
+	forks uint64
+}
+
+// NewChain returns a simulated L1 at its genesis block, on which the contracts of the L1 config are deployed
+// with the given trusted sequencer and no committee
+func NewChain(cfg config.L1Config, sequencer common.Address) *Chain {
+	chainID := cfg.ChainID
+	if chainID == 0 {
+		chainID = defaultChainID
+	}
+
+	genesis := &ethTypes.Header{
+		Number:     new(big.Int),
+		Time:       uint64(time.Now().Unix()),
+		Difficulty: new(big.Int),
+	}
+
+	return &Chain{
+		chainID:       new(big.Int).SetUint64(chainID),
+		validium:      common.HexToAddress(cfg.PolygonValidiumAddress),
+		dataCommittee: common.HexToAddress(cfg.DataCommitteeAddress),
+		sequencer:     sequencer,
+		headers:       []*ethTypes.Header{genesis},
+		bodies:        [][]*ethTypes.Transaction{nil},
+		txs:           make(map[common.Hash]*ethTypes.Transaction),
+		receipts:      make(map[common.Hash]*ethTypes.Receipt),
+	}
+}
+
+// UpdateCommittee sets up the committee of the given members, required of which must sign each sequence,
+// mining the transaction and its CommitteeUpdated event
+func (c *Chain) UpdateCommittee(
+	members []etherman.DataCommitteeMember,
+	required uint64,
+) (*ethTypes.Transaction, error) {
+	urls := make([]string, len(members))
+	addresses := make([]byte, 0, len(members)*common.AddressLength)
+	for i, member := range members {
+		urls[i] = member.URL
+		addresses = append(addresses, member.Addr.Bytes()...)
+	}
+	committeeHash := crypto.Keccak256Hash(addresses)
+
+	data, err := committeeABI.Pack("setupCommittee", new(big.Int).SetUint64(required), urls, addresses)
+	if err != nil {
+		return nil, err
+	}
+	eventData, err := committeeABI.Events["CommitteeUpdated"].Inputs.NonIndexed().Pack(committeeHash)
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.committee = etherman.DataCommittee{
+		AddressesHash:      committeeHash,
+		Members:            append([]etherman.DataCommitteeMember(nil), members...),
+		RequiredSignatures: required,
+	}
+
+	tx := c.newTx(c.dataCommittee, data)
+	c.mine(tx, &ethTypes.Log{
+		Address: c.dataCommittee,
+		Topics:  []common.Hash{committeeABI.Events["CommitteeUpdated"].ID},
+		Data:    eventData,
+	})
+
+	return tx, nil
+}
+
+// Mine mines an empty block, returning its number
+func (c *Chain) Mine() uint64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.mine(nil, nil).Number.Uint64()
+}
+
+// SequenceBatches sequences the batches of the given keys to the PolygonValidium contract with the
+// dataAvailabilityMessage of the committee, as the trusted sequencer does, returning the transaction
+func (c *Chain) SequenceBatches(keys []common.Hash, message []byte) (*ethTypes.Transaction, error) {
+	batches := make([]polygonvalidium.PolygonValidiumEtrogValidiumBatchData, len(keys))
+	for i, key := range keys {
+		batches[i].TransactionsHash = key
+	}
+
+	data, err := validiumABI.Pack("sequenceBatchesValidium", batches, c.sequencer, message)
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.lastBatch += uint64(len(keys))
+	eventData, err := validiumABI.Events["SequenceBatches"].Inputs.NonIndexed().Pack(common.Hash{})
+	if err != nil {
+		return nil, err
+	}
+
+	tx := c.newTx(c.validium, data)
+	c.mine(tx, &ethTypes.Log{
+		Address: c.validium,
+		Topics: []common.Hash{
+			validiumABI.Events["SequenceBatches"].ID,
+			common.BigToHash(new(big.Int).SetUint64(c.lastBatch)),
+		},
+		Data: eventData,
+	})
+
+	return tx, nil
+}
+
+// newTx returns a transaction of the trusted sequencer to the contract, not signed as nothing checks it
+func (c *Chain) newTx(to common.Address, data []byte) *ethTypes.Transaction {
+	tx := ethTypes.NewTx(&ethTypes.LegacyTx{
+		Nonce:    c.nonce,
+		To:       &to,
+		Gas:      uint64(len(data)) * 16, //nolint:gomnd
+		GasPrice: big.NewInt(1),
+		Data:     data,
+	})
+	c.nonce++
+
+	return tx
+}
+
+// mine mines a block holding the transaction and its log when given, returning its header. The lock must
+// be held.
+func (c *Chain) mine(tx *ethTypes.Transaction, log *ethTypes.Log) *ethTypes.Header {
+	parent := c.headers[len(c.headers)-1]
+	header := &ethTypes.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number, big.NewInt(1)),
+		Time:       uint64(time.Now().Unix()),
+		Difficulty: new(big.Int),
+	}
+	if c.forks > 0 {
+		header.Extra = new(big.Int).SetUint64(c.forks).Bytes()
+	}
+	if header.Time <= parent.Time {
+		header.Time = parent.Time + 1
+	}
+
+	c.headers = append(c.headers, header)
+	c.bodies = append(c.bodies, nil)
+	if tx != nil {
+		c.include(tx, log)
+	}
+
+	return header
+}
+
+// include adds the transaction and its log when given to the latest block. The lock must be held.
+func (c *Chain) include(tx *ethTypes.Transaction, log *ethTypes.Log) {
+	header := c.headers[len(c.headers)-1]
+	receipt := &ethTypes.Receipt{
+		Status:      ethTypes.ReceiptStatusSuccessful,
+		TxHash:      tx.Hash(),
+		BlockHash:   header.Hash(),
+		BlockNumber: header.Number,
+	}
+	if log != nil {
+		log.BlockNumber = header.Number.Uint64()
+		log.BlockHash = header.Hash()
+		log.TxHash = tx.Hash()
+		log.Index = uint(len(c.logs))
+		c.logs = append(c.logs, *log)
+		receipt.Logs = []*ethTypes.Log{log}
+	}
+	c.txs[tx.Hash()] = tx
+	c.receipts[tx.Hash()] = receipt
+	c.bodies[len(c.bodies)-1] = append(c.bodies[len(c.bodies)-1], tx)
+}
+
+// Include includes the transaction of a sequence, sequenced by other means, with its SequenceBatches log in
+// the block of the number, mining the empty blocks before it. The transaction is added to the latest block
+// when it is of the number, the block hashing the same, and the log is emitted by the PolygonValidium contract
+// of the chain whatever its address. It returns the header of the block.
+func (c *Chain) Include(number uint64, tx *ethTypes.Transaction, log ethTypes.Log) (*ethTypes.Header, error) {
+	sequenced := validiumABI.Events["SequenceBatches"].ID
+	if len(log.Topics) < 2 || log.Topics[0] != sequenced { //nolint:gomnd
+		return nil, errors.New("the log is not a SequenceBatches event")
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	head := c.headers[len(c.headers)-1].Number.Uint64()
+	if number == 0 || number < head {
+		return nil, fmt.Errorf("can not include a transaction in the block %d, the chain is at the block %d",
+			number, head)
+	}
+	for ; head < number-1; head++ {
+		c.mine(nil, nil)
+	}
+
+	included := &ethTypes.Log{
+		Address: c.validium,
+		Topics:  append([]common.Hash(nil), log.Topics...),
+		Data:    log.Data,
+	}
+	if head == number {
+		c.include(tx, included)
+	} else {
+		c.mine(tx, included)
+	}
+	c.lastBatch = log.Topics[1].Big().Uint64()
+
+	return ethTypes.CopyHeader(c.headers[len(c.headers)-1]), nil
+}
+
+// Reorg rewinds the chain by depth blocks, as a reorganization of L1 to a fork of the remaining blocks does:
+// the transactions and the events of the blocks are dropped, and the batches they sequenced are sequenced
+// again from the last batch remaining. The committee is kept. It returns the header of the block the chain
+// was rewound to, the blocks mined next being those of the fork.
+func (c *Chain) Reorg(depth uint64) (*ethTypes.Header, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if depth >= uint64(len(c.headers)) {
+		return nil, fmt.Errorf("can not rewind %d blocks, the chain has %d blocks after its genesis",
+			depth, len(c.headers)-1)
+	}
+
+	keep := len(c.headers) - int(depth)
+	for _, body := range c.bodies[keep:] {
+		for _, tx := range body {
+			delete(c.txs, tx.Hash())
+			delete(c.receipts, tx.Hash())
+		}
+	}
+	c.headers = c.headers[:keep]
+	c.bodies = c.bodies[:keep]
+
+	head := c.headers[keep-1].Number.Uint64()
+	sequenced := validiumABI.Events["SequenceBatches"].ID
+	c.lastBatch = 0
+	for i := len(c.logs) - 1; i >= 0; i-- {
+		if c.logs[i].BlockNumber > head {
+			c.logs = c.logs[:i]
+			continue
+		}
+		if c.logs[i].Address == c.validium && c.logs[i].Topics[0] == sequenced {
+			c.lastBatch = c.logs[i].Topics[1].Big().Uint64()
+			break
+		}
+	}
+	c.forks++
+
+	return ethTypes.CopyHeader(c.headers[keep-1]), nil
+}
+
+// header returns the header of the block, the latest when number is nil. The lock must be held.
+func (c *Chain) header(number *big.Int) (*ethTypes.Header, int, error) {
+	if number == nil {
+		return c.headers[len(c.headers)-1], len(c.headers) - 1, nil
+	}
+	if !number.IsUint64() || number.Uint64() >= uint64(len(c.headers)) {
+		return nil, 0, ethereum.NotFound
+	}
+
+	return c.headers[number.Uint64()], int(number.Uint64()), nil
+}
+
+// GetTx returns the transaction of the hash, never pending as the transactions are mined right away
+func (c *Chain) GetTx(_ context.Context, txHash common.Hash) (*ethTypes.Transaction, bool, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	tx, ok := c.txs[txHash]
+	if !ok {
+		return nil, false, ethereum.NotFound
+	}
+	return tx, false, nil
+}
+
+// HeaderByNumber returns the header of the block, the latest when number is nil
+func (c *Chain) HeaderByNumber(_ context.Context, number *big.Int) (*ethTypes.Header, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	header, _, err := c.header(number)
+	if err != nil {
+		return nil, err
+	}
+	return ethTypes.CopyHeader(header), nil
+}
+
+// BlockByNumber returns the block with its transactions, the latest when number is nil
+func (c *Chain) BlockByNumber(_ context.Context, number *big.Int) (*ethTypes.Block, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	header, i, err := c.header(number)
+	if err != nil {
+		return nil, err
+	}
+	return ethTypes.NewBlockWithHeader(header).WithBody(c.bodies[i], nil), nil
+}
+
+// CodeAt returns placeholder code for the contracts of the devnet, deployed from the genesis block
+func (c *Chain) CodeAt(_ context.Context, account common.Address, _ *big.Int) ([]byte, error) {
+	if account == c.validium || account == c.dataCommittee {
+		return contractCode, nil
+	}
+	return nil, nil
+}
+
+// CallContract is not supported, the simulated contracts are only read through the methods of the chain
+func (c *Chain) CallContract(context.Context, ethereum.CallMsg, *big.Int) ([]byte, error) {
+	return nil, errors.New("contract calls are not supported by the simulated L1")
+}
+
+// ChainID returns the chain ID of the simulated L1
+func (c *Chain) ChainID(context.Context) (*big.Int, error) {
+	return new(big.Int).Set(c.chainID), nil
+}
+
+// TransactionReceipt returns the receipt of the mined transaction
+func (c *Chain) TransactionReceipt(_ context.Context, txHash common.Hash) (*ethTypes.Receipt, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	receipt, ok := c.receipts[txHash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return receipt, nil
+}
+
+// GetCurrentDataCommittee returns the committee of the devnet
+func (c *Chain) GetCurrentDataCommittee() (*etherman.DataCommittee, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	committee := c.committee
+	committee.Members = append([]etherman.DataCommitteeMember(nil), c.committee.Members...)
+
+	return &committee, nil
+}
+
+// GetCurrentDataCommitteeMembers returns the members of the committee of the devnet
+func (c *Chain) GetCurrentDataCommitteeMembers() ([]etherman.DataCommitteeMember, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return append([]etherman.DataCommitteeMember(nil), c.committee.Members...), nil
+}
+
+// GetDataCommitteeMembers returns the current members of the committee of the devnet, whatever the block
+func (c *Chain) GetDataCommitteeMembers(context.Context, *big.Int) ([]etherman.DataCommitteeMember, error) {
+	return c.GetCurrentDataCommitteeMembers()
+}
+
+// FilterCommitteeUpdated returns an iterator over the CommitteeUpdated events of the committee updates
+func (c *Chain) FilterCommitteeUpdated(
+	opts *bind.FilterOpts,
+) (*polygondatacommittee.PolygondatacommitteeCommitteeUpdatedIterator, error) {
+	filterer, err := polygondatacommittee.NewPolygondatacommitteeFilterer(c.dataCommittee, c)
+	if err != nil {
+		return nil, err
+	}
+	return filterer.FilterCommitteeUpdated(opts)
+}
+
+// TrustedSequencer returns the address of the simulated sequencer
+func (c *Chain) TrustedSequencer(context.Context) (common.Address, error) {
+	return c.sequencer, nil
+}
+
+// LastVerifiedBatch returns the last sequenced batch, the batches being verified once sequenced
+func (c *Chain) LastVerifiedBatch(context.Context) (uint64, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.lastBatch, nil
+}
+
+// WatchSetTrustedSequencer returns a subscription receiving no event, the sequencer never changing
+func (c *Chain) WatchSetTrustedSequencer(
+	context.Context,
+	chan *polygonvalidium.PolygonvalidiumSetTrustedSequencer,
+) (event.Subscription, error) {
+	return idleSubscription(), nil
+}
+
+// TrustedSequencerURL returns no URL: the simulated sequencer does not serve the batches, which the node
+// resolves from the committee
+func (c *Chain) TrustedSequencerURL(context.Context) (string, error) {
+	return "", nil
+}
+
+// WatchSetTrustedSequencerURL returns a subscription receiving no event, the sequencer never changing
+func (c *Chain) WatchSetTrustedSequencerURL(
+	context.Context,
+	chan *polygonvalidium.PolygonvalidiumSetTrustedSequencerURL,
+) (event.Subscription, error) {
+	return idleSubscription(), nil
+}
+
+// FilterSequenceBatches returns an iterator over the SequenceBatches events of the sequenced batches
+func (c *Chain) FilterSequenceBatches(
+	opts *bind.FilterOpts,
+	numBatch []uint64,
+) (*polygonvalidium.PolygonvalidiumSequenceBatchesIterator, error) {
+	filterer, err := polygonvalidium.NewPolygonvalidiumFilterer(c.validium, c)
+	if err != nil {
+		return nil, err
+	}
+	return filterer.FilterSequenceBatches(opts, numBatch)
+}
+
+// SubmitAttestation mines the transaction attesting the root of the keys of the batches to the contract
+func (c *Chain) SubmitAttestation(
+	_ *bind.TransactOpts,
+	contract common.Address,
+	root common.Hash,
+	fromBatch, toBatch uint64,
+	keyCount uint32,
+) (*ethTypes.Transaction, error) {
+	data, err := attestationABI.Pack("attest", root, fromBatch, toBatch, keyCount)
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	tx := c.newTx(contract, data)
+	c.mine(tx, nil)
+
+	return tx, nil
+}
+
+// FilterLogs returns the logs of the blocks matching the query, for the bindings to filter the events
+func (c *Chain) FilterLogs(_ context.Context, query ethereum.FilterQuery) ([]ethTypes.Log, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	var logs []ethTypes.Log
+	for _, log := range c.logs {
+		if matches(query, log) {
+			logs = append(logs, log)
+		}
+	}
+
+	return logs, nil
+}
+
+// SubscribeFilterLogs returns a subscription receiving no log, the events being read by FilterLogs
+func (c *Chain) SubscribeFilterLogs(
+	context.Context,
+	ethereum.FilterQuery,
+	chan<- ethTypes.Log,
+) (ethereum.Subscription, error) {
+	return idleSubscription(), nil
+}
+
+// matches returns whether the log matches the addresses, the block range and the topics of the query
+func matches(query ethereum.FilterQuery, log ethTypes.Log) bool {
+	if query.BlockHash != nil && log.BlockHash != *query.BlockHash {
+		return false
+	}
+	if query.FromBlock != nil && new(big.Int).SetUint64(log.BlockNumber).Cmp(query.FromBlock) < 0 {
+		return false
+	}
+	if query.ToBlock != nil && new(big.Int).SetUint64(log.BlockNumber).Cmp(query.ToBlock) > 0 {
+		return false
+	}
+
+	if len(query.Addresses) > 0 {
+		found := false
+		for _, addr := range query.Addresses {
+			found = found || addr == log.Address
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(query.Topics) > len(log.Topics) {
+		return false
+	}
+	for i, topics := range query.Topics {
+		if len(topics) == 0 {
+			continue
+		}
+		found := false
+		for _, topic := range topics {
+			found = found || topic == log.Topics[i]
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// idleSubscription returns a subscription receiving nothing until it is unsubscribed
+func idleSubscription() event.Subscription {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	})
+}
@@ -0,0 +1,250 @@
+package devnet
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/synchronizer"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestChain(t *testing.T) *Chain {
+	t.Helper()
+
+	cfg, err := config.Default()
+	require.NoError(t, err)
+
+	return NewChain(cfg.L1, common.HexToAddress("0x1"))
+}
+
+func TestChain_Blocks(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	chain := newTestChain(t)
+
+	chainID, err := chain.ChainID(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(defaultChainID), chainID.Uint64())
+
+	require.Equal(t, uint64(1), chain.Mine())
+	require.Equal(t, uint64(2), chain.Mine())
+
+	latest, err := chain.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), latest.Number.Uint64())
+
+	parent, err := chain.HeaderByNumber(ctx, big.NewInt(1))
+	require.NoError(t, err)
+	require.Equal(t, parent.Hash(), latest.ParentHash)
+	require.Less(t, parent.Time, latest.Time)
+
+	block, err := chain.BlockByNumber(ctx, big.NewInt(2))
+	require.NoError(t, err)
+	require.Equal(t, latest.Hash(), block.Hash())
+
+	_, err = chain.HeaderByNumber(ctx, big.NewInt(3))
+	require.ErrorIs(t, err, ethereum.NotFound)
+
+	code, err := chain.CodeAt(ctx, chain.validium, nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, code)
+	code, err = chain.CodeAt(ctx, common.HexToAddress("0x2"), nil)
+	require.NoError(t, err)
+	require.Empty(t, code)
+}
+
+func TestChain_UpdateCommittee(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	chain := newTestChain(t)
+	members := []etherman.DataCommitteeMember{
+		{Addr: common.HexToAddress("0x10"), URL: "http://127.0.0.1:1"},
+		{Addr: common.HexToAddress("0x20"), URL: "http://127.0.0.1:2"},
+	}
+
+	tx, err := chain.UpdateCommittee(members, 1)
+	require.NoError(t, err)
+
+	committee, err := chain.GetCurrentDataCommittee()
+	require.NoError(t, err)
+	require.Equal(t, members, committee.Members)
+	require.Equal(t, uint64(1), committee.RequiredSignatures)
+	require.Equal(t, crypto.Keccak256Hash(members[0].Addr.Bytes(), members[1].Addr.Bytes()), committee.AddressesHash)
+
+	iter, err := chain.FilterCommitteeUpdated(&bind.FilterOpts{Context: ctx})
+	require.NoError(t, err)
+	require.True(t, iter.Next())
+	require.Equal(t, committee.AddressesHash, common.Hash(iter.Event.CommitteeHash))
+	require.Equal(t, tx.Hash(), iter.Event.Raw.TxHash)
+	require.Equal(t, uint64(1), iter.Event.Raw.BlockNumber)
+	require.False(t, iter.Next())
+}
+
+func TestChain_SequenceBatches(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	chain := newTestChain(t)
+	chain.Mine()
+
+	first := []common.Hash{common.HexToHash("0x1"), common.HexToHash("0x2")}
+	_, err := chain.SequenceBatches(first, []byte{1})
+	require.NoError(t, err)
+	second := []common.Hash{common.HexToHash("0x3")}
+	tx, err := chain.SequenceBatches(second, []byte{2})
+	require.NoError(t, err)
+
+	lastBatch, err := chain.LastVerifiedBatch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), lastBatch)
+
+	end := uint64(3)
+	iter, err := chain.FilterSequenceBatches(&bind.FilterOpts{Context: ctx, Start: 3, End: &end}, nil)
+	require.NoError(t, err)
+	require.True(t, iter.Next())
+	require.Equal(t, uint64(3), iter.Event.NumBatch)
+	require.Equal(t, tx.Hash(), iter.Event.Raw.TxHash)
+	require.False(t, iter.Next())
+
+	found, pending, err := chain.GetTx(ctx, tx.Hash())
+	require.NoError(t, err)
+	require.False(t, pending)
+
+	keys, err := synchronizer.UnpackTxData(found.Data())
+	require.NoError(t, err)
+	require.Equal(t, second, keys)
+	message, err := synchronizer.UnpackDataAvailabilityMessage(found.Data())
+	require.NoError(t, err)
+	require.Equal(t, []byte{2}, message)
+
+	receipt, err := chain.TransactionReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), receipt.BlockNumber.Uint64())
+	require.Len(t, receipt.Logs, 1)
+
+	block, err := chain.BlockByNumber(ctx, receipt.BlockNumber)
+	require.NoError(t, err)
+	require.Equal(t, receipt.BlockHash, block.Hash())
+	require.Len(t, block.Transactions(), 1)
+
+	_, _, err = chain.GetTx(ctx, common.HexToHash("0x1"))
+	require.ErrorIs(t, err, ethereum.NotFound)
+}
+
+func TestChain_SubmitAttestation(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	chain := newTestChain(t)
+
+	tx, err := chain.SubmitAttestation(nil, common.HexToAddress("0x3"), common.HexToHash("0x1"), 1, 2, 2)
+	require.NoError(t, err)
+	require.Equal(t, common.HexToAddress("0x3"), *tx.To())
+
+	receipt, err := chain.TransactionReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), receipt.Status)
+}
+
+func TestChain_Reorg(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	chain := newTestChain(t)
+
+	_, err := chain.SequenceBatches([]common.Hash{common.HexToHash("0x1")}, nil)
+	require.NoError(t, err)
+	orphan, err := chain.SequenceBatches([]common.Hash{common.HexToHash("0x2")}, nil)
+	require.NoError(t, err)
+	replaced, err := chain.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	chain.Mine()
+
+	head, err := chain.Reorg(2)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), head.Number.Uint64())
+
+	lastBatch, err := chain.LastVerifiedBatch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), lastBatch)
+	_, _, err = chain.GetTx(ctx, orphan.Hash())
+	require.ErrorIs(t, err, ethereum.NotFound)
+
+	// the batch 2 is sequenced again in a block of the fork
+	_, err = chain.SequenceBatches([]common.Hash{common.HexToHash("0x3")}, nil)
+	require.NoError(t, err)
+	fork, err := chain.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, replaced.Number, fork.Number)
+	require.NotEqual(t, replaced.Hash(), fork.Hash())
+
+	iter, err := chain.FilterSequenceBatches(&bind.FilterOpts{Context: ctx}, nil)
+	require.NoError(t, err)
+	var batches []uint64
+	for iter.Next() {
+		batches = append(batches, iter.Event.NumBatch)
+	}
+	require.Equal(t, []uint64{1, 2}, batches)
+
+	_, err = chain.Reorg(3)
+	require.EqualError(t, err, "can not rewind 3 blocks, the chain has 2 blocks after its genesis")
+}
+
+func TestChain_Include(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	source := newTestChain(t)
+	first, err := source.SequenceBatches([]common.Hash{common.HexToHash("0x1")}, nil)
+	require.NoError(t, err)
+	second, err := source.SequenceBatches([]common.Hash{common.HexToHash("0x2")}, nil)
+	require.NoError(t, err)
+	firstReceipt, err := source.TransactionReceipt(ctx, first.Hash())
+	require.NoError(t, err)
+	secondReceipt, err := source.TransactionReceipt(ctx, second.Hash())
+	require.NoError(t, err)
+
+	chain := newTestChain(t)
+	header, err := chain.Include(3, first, *firstReceipt.Logs[0])
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), header.Number.Uint64())
+
+	// the second sequence joins the first in its block
+	header, err = chain.Include(3, second, *secondReceipt.Logs[0])
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), header.Number.Uint64())
+
+	block, err := chain.BlockByNumber(ctx, big.NewInt(3))
+	require.NoError(t, err)
+	require.Len(t, block.Transactions(), 2)
+	require.Equal(t, header.Hash(), block.Hash())
+
+	lastBatch, err := chain.LastVerifiedBatch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), lastBatch)
+
+	iter, err := chain.FilterSequenceBatches(&bind.FilterOpts{Context: ctx}, nil)
+	require.NoError(t, err)
+	var txs []common.Hash
+	for iter.Next() {
+		require.Equal(t, uint64(3), iter.Event.Raw.BlockNumber)
+		require.Equal(t, header.Hash(), iter.Event.Raw.BlockHash)
+		txs = append(txs, iter.Event.Raw.TxHash)
+	}
+	require.Equal(t, []common.Hash{first.Hash(), second.Hash()}, txs)
+
+	_, err = chain.Include(2, first, *firstReceipt.Logs[0])
+	require.EqualError(t, err, "can not include a transaction in the block 2, the chain is at the block 3")
+	_, err = chain.Include(4, first, ethTypes.Log{})
+	require.EqualError(t, err, "the log is not a SequenceBatches event")
+}
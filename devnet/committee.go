@@ -0,0 +1,94 @@
+package devnet
+
+import (
+	"crypto/ecdsa"
+	"net"
+	"strconv"
+
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/pkg/replay"
+	"github.com/0xPolygon/cdk-data-availability/rpc"
+	"github.com/0xPolygon/cdk-data-availability/sequencer"
+	"github.com/0xPolygon/cdk-data-availability/services/datacom"
+	"github.com/0xPolygon/cdk-data-availability/services/sync"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Member is a simulated member of the committee of the devnet, storing in memory the data of the sequences
+// it signs and serving it to the other members as a node does
+type Member struct {
+	etherman.DataCommitteeMember
+
+	key     *ecdsa.PrivateKey
+	storage db.DB
+	server  *rpc.Server
+}
+
+// NewMember returns a member with a generated key, serving the datacom and sync endpoints at the given port.
+// The trusted sequencer is the one known to the tracker.
+func NewMember(c config.Config, port int, tracker *sequencer.Tracker) (*Member, error) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	storage := db.NewMemory(0)
+
+	rpcCfg := c.RPC
+	rpcCfg.Host = "127.0.0.1"
+	rpcCfg.Port = port
+	server := rpc.NewServer(rpcCfg, []rpc.Service{
+		{
+			Name:    sync.APISYNC,
+			Service: sync.NewEndpoints(storage, key),
+		},
+		{
+			Name: datacom.APIDATACOM,
+			Service: datacom.NewEndpoints(
+				storage,
+				key,
+				tracker,
+				replay.New(c.Replay.Window.Duration, c.Replay.RequireTimestamp),
+				c.Limits,
+				config.DurabilityConfig{Level: config.DurabilityCommitted},
+				nil,
+			),
+		},
+	})
+
+	return &Member{
+		DataCommitteeMember: etherman.DataCommitteeMember{
+			Addr: crypto.PubkeyToAddress(key.PublicKey),
+			URL:  localURL(rpcCfg.Host, port),
+		},
+		key:     key,
+		storage: storage,
+		server:  server,
+	}, nil
+}
+
+// Storage returns the storage of the member
+func (m *Member) Storage() db.DB {
+	return m.storage
+}
+
+// Start serves the endpoints of the member until it is stopped
+func (m *Member) Start() error {
+	return m.server.Start()
+}
+
+// Stop stops serving the endpoints of the member
+func (m *Member) Stop() error {
+	return m.server.Stop()
+}
+
+// localURL returns the URL a server listening on the host and port is reached at locally
+func localURL(host string, port int) string {
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "127.0.0.1"
+	}
+
+	return "http://" + net.JoinHostPort(host, strconv.Itoa(port))
+}
@@ -0,0 +1,144 @@
+package devnet
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/client"
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/reporter"
+	"github.com/0xPolygon/cdk-data-availability/sequencer"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// logger is the logger of the devnet component
+var logger = log.WithComponent("devnet")
+
+// Devnet runs the node against a simulated L1 and committee in the same process. The committee is the node
+// and the simulated members, all of them but one being required to sign, and the simulated sequencer has a
+// sequence signed and sequenced every interval so that the node signs, stores and resolves data as it would
+// on a real network.
+type Devnet struct {
+	cfg       config.DevnetConfig
+	chain     *Chain
+	members   []*Member
+	tracker   *sequencer.Tracker
+	sequencer *Sequencer
+	stop      chan struct{}
+}
+
+// New returns the devnet of the node signing with the key, reached at the RPC server of the config
+func New(c config.Config, pk *ecdsa.PrivateKey) (*Devnet, error) {
+	sequencerKey, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	chain := NewChain(c.L1, crypto.PubkeyToAddress(sequencerKey.PublicKey))
+
+	// the sequencer never changes, the members read it once from the simulated L1
+	l1 := c.L1
+	l1.TrackSequencer = false
+	tracker := sequencer.NewTracker(l1, c.Timeouts, chain)
+
+	committee := []etherman.DataCommitteeMember{{
+		Addr: crypto.PubkeyToAddress(pk.PublicKey),
+		URL:  localURL(c.RPC.Host, c.RPC.Port),
+	}}
+	members := make([]*Member, c.Devnet.Members)
+	for i := range members {
+		if members[i], err = NewMember(c, c.Devnet.MembersPort+i, tracker); err != nil {
+			return nil, err
+		}
+		committee = append(committee, members[i].DataCommitteeMember)
+	}
+	if _, err = chain.UpdateCommittee(committee, uint64(len(members))); err != nil {
+		return nil, err
+	}
+
+	return &Devnet{
+		cfg:     c.Devnet,
+		chain:   chain,
+		members: members,
+		tracker: tracker,
+		sequencer: NewSequencer(
+			sequencerKey, chain, client.NewFactory(), c.Devnet.BatchesPerSequence, c.Devnet.BatchSize,
+		),
+		stop: make(chan struct{}),
+	}, nil
+}
+
+// Chain returns the simulated L1, for the node to run on
+func (d *Devnet) Chain() *Chain {
+	return d.chain
+}
+
+// Members returns the simulated members of the committee
+func (d *Devnet) Members() []*Member {
+	return d.members
+}
+
+// Start starts the simulated members, then mines a block every BlockTime and has a sequence signed every
+// SequenceInterval until the devnet is stopped
+func (d *Devnet) Start(ctx context.Context) {
+	defer reporter.Recover()
+
+	d.tracker.Start(ctx)
+	for _, member := range d.members {
+		go func(member *Member) {
+			if err := member.Start(); err != nil {
+				logger.Errorf("failed to start the member %s: %v", member.Addr.Hex(), err)
+			}
+		}(member)
+	}
+
+	logger.Infof("devnet started with %d simulated members, sequencer %s",
+		len(d.members), d.sequencer.Address().Hex())
+
+	blocks := time.NewTicker(d.cfg.BlockTime.Duration)
+	defer blocks.Stop()
+	sequences := time.NewTicker(d.cfg.SequenceInterval.Duration)
+	defer sequences.Stop()
+
+	for {
+		select {
+		case <-blocks.C:
+			d.chain.Mine()
+		case <-sequences.C:
+			d.sequence(ctx)
+		case <-ctx.Done():
+			return
+		case <-d.stop:
+			return
+		}
+	}
+}
+
+// sequence has a sequence signed and sequenced, within the interval before the next one
+func (d *Devnet) sequence(parentCtx context.Context) {
+	ctx, cancel := context.WithTimeout(parentCtx, d.cfg.SequenceInterval.Duration)
+	defer cancel()
+
+	tx, err := d.sequencer.Sequence(ctx)
+	if err != nil {
+		logger.Warnf("failed to sequence: %v", err)
+		return
+	}
+
+	lastBatch, _ := d.chain.LastVerifiedBatch(ctx)
+	logger.Infof("sequenced up to the batch %d in the transaction %s", lastBatch, tx.Hash().Hex())
+}
+
+// Stop stops the devnet and its members
+func (d *Devnet) Stop() {
+	close(d.stop)
+	for _, member := range d.members {
+		if err := member.Stop(); err != nil {
+			logger.Errorf("failed to stop the member %s: %v", member.Addr.Hex(), err)
+		}
+	}
+	d.tracker.Stop()
+}
@@ -0,0 +1,161 @@
+package devnet
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/client"
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/sequencer"
+	"github.com/0xPolygon/cdk-data-availability/synchronizer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// freePort returns a port nothing listens on
+func freePort(t *testing.T) int {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+// startMember starts the member, waiting for it to serve
+func startMember(t *testing.T, member *Member) {
+	t.Helper()
+
+	go func() { _ = member.Start() }()
+	t.Cleanup(func() { _ = member.Stop() })
+
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", member.URL[len("http://"):])
+		if err == nil {
+			_ = conn.Close()
+		}
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestSequencer_Sequence(t *testing.T) {
+	ctx := context.Background()
+	c, err := config.Default()
+	require.NoError(t, err)
+
+	sequencerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chain := NewChain(c.L1, crypto.PubkeyToAddress(sequencerKey.PublicKey))
+	tracker := sequencer.NewTracker(c.L1, c.Timeouts, chain)
+	tracker.Start(ctx)
+	t.Cleanup(tracker.Stop)
+
+	members := make([]*Member, 3)
+	committee := make([]etherman.DataCommitteeMember, len(members))
+	for i := range members {
+		members[i], err = NewMember(*c, freePort(t), tracker)
+		require.NoError(t, err)
+		startMember(t, members[i])
+		committee[i] = members[i].DataCommitteeMember
+	}
+	_, err = chain.UpdateCommittee(committee, 2)
+	require.NoError(t, err)
+
+	seq := NewSequencer(sequencerKey, chain, client.NewFactory(), 2, 32)
+	for round := 0; round < len(members); round++ {
+		tx, err := seq.Sequence(ctx)
+		require.NoError(t, err)
+
+		keys, err := synchronizer.UnpackTxData(tx.Data())
+		require.NoError(t, err)
+		require.Len(t, keys, 2)
+
+		// the members are asked from the next one at each round, the last one being left out
+		message, err := synchronizer.UnpackDataAvailabilityMessage(tx.Data())
+		require.NoError(t, err)
+		require.Len(t, message, 2*65+len(members)*common.AddressLength)
+
+		for i, member := range members {
+			_, err = member.Storage().GetOffChainData(ctx, keys[0])
+			if i == (round+2)%len(members) {
+				require.Error(t, err)
+
+				// the member left out resolves the data from the others
+				value, err := client.New(members[round].URL).GetOffChainData(ctx, keys[0])
+				require.NoError(t, err)
+				require.Equal(t, keys[0], crypto.Keccak256Hash(value))
+			} else {
+				require.NoError(t, err)
+			}
+		}
+	}
+
+	lastBatch, err := chain.LastVerifiedBatch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2*len(members)), lastBatch)
+}
+
+func TestSequencer_NotEnoughSignatures(t *testing.T) {
+	t.Parallel()
+
+	c, err := config.Default()
+	require.NoError(t, err)
+
+	sequencerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chain := NewChain(c.L1, crypto.PubkeyToAddress(sequencerKey.PublicKey))
+	_, err = chain.UpdateCommittee([]etherman.DataCommitteeMember{
+		{Addr: crypto.PubkeyToAddress(sequencerKey.PublicKey), URL: localURL("", freePort(t))},
+	}, 1)
+	require.NoError(t, err)
+
+	seq := NewSequencer(sequencerKey, chain, client.NewFactory(), 1, 32)
+	_, err = seq.Sequence(context.Background())
+	require.EqualError(t, err, "0 members signed the sequence, 1 signatures are required")
+
+	lastBatch, err := chain.LastVerifiedBatch(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, lastBatch)
+}
+
+func TestDevnet(t *testing.T) {
+	c, err := config.Default()
+	require.NoError(t, err)
+	c.Devnet.Enabled = true
+	c.DB.Memory = true
+	c.RPC.Port = freePort(t)
+	c.Devnet.Members = 1
+	c.Devnet.MembersPort = freePort(t)
+	c.Devnet.BlockTime.Duration = 10 * time.Millisecond
+	c.Devnet.SequenceInterval.Duration = 50 * time.Millisecond
+	require.NoError(t, c.Validate())
+
+	pk, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	devnet, err := New(*c, pk)
+	require.NoError(t, err)
+
+	committee, err := devnet.Chain().GetCurrentDataCommittee()
+	require.NoError(t, err)
+	require.Len(t, committee.Members, 2)
+	require.Equal(t, crypto.PubkeyToAddress(pk.PublicKey), committee.Members[0].Addr)
+	require.Equal(t, uint64(1), committee.RequiredSignatures)
+
+	go devnet.Start(context.Background())
+	defer devnet.Stop()
+
+	// the node is not running, the sequences are signed by the simulated member
+	require.Eventually(t, func() bool {
+		lastBatch, err := devnet.Chain().LastVerifiedBatch(context.Background())
+		return err == nil && lastBatch >= uint64(c.Devnet.BatchesPerSequence)
+	}, 10*time.Second, 10*time.Millisecond)
+
+	head, err := devnet.Chain().HeaderByNumber(context.Background(), nil)
+	require.NoError(t, err)
+	require.Greater(t, head.Number.Uint64(), uint64(2))
+}
@@ -0,0 +1,103 @@
+package devnet
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/client"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Sequencer is the simulated trusted sequencer of the devnet: it has the sequences of random batches signed
+// by the committee, then sequences them on the simulated L1 with the signatures collected
+type Sequencer struct {
+	key       *ecdsa.PrivateKey
+	chain     *Chain
+	clients   client.Factory
+	batches   int
+	batchSize int
+	// round is the number of sequences requested, rotating the member asked first
+	round int
+}
+
+// NewSequencer returns a sequencer signing its requests with the key, whose sequences hold the given number
+// of batches of batchSize bytes
+func NewSequencer(
+	key *ecdsa.PrivateKey,
+	chain *Chain,
+	clients client.Factory,
+	batches, batchSize int,
+) *Sequencer {
+	return &Sequencer{
+		key:       key,
+		chain:     chain,
+		clients:   clients,
+		batches:   batches,
+		batchSize: batchSize,
+	}
+}
+
+// Address returns the address of the sequencer
+func (s *Sequencer) Address() common.Address {
+	return crypto.PubkeyToAddress(s.key.PublicKey)
+}
+
+// Sequence has a new sequence signed by the committee and sequences it, returning its transaction. The
+// members are asked in turn until enough signed, starting from the next member at each round, so that the
+// members left out of a sequence resolve its data from the others.
+func (s *Sequencer) Sequence(ctx context.Context) (*ethTypes.Transaction, error) {
+	sequence := make(types.Sequence, s.batches)
+	keys := make([]common.Hash, s.batches)
+	for i := range sequence {
+		sequence[i] = make([]byte, s.batchSize)
+		if _, err := rand.Read(sequence[i]); err != nil {
+			return nil, err
+		}
+		keys[i] = crypto.Keccak256Hash(sequence[i])
+	}
+
+	signed, err := sequence.SignRequest(s.key, uint64(time.Now().Unix()))
+	if err != nil {
+		return nil, err
+	}
+
+	committee, err := s.chain.GetCurrentDataCommittee()
+	if err != nil {
+		return nil, err
+	}
+	members := committee.Members
+
+	signatures := make(map[common.Address][]byte, committee.RequiredSignatures)
+	for i := 0; i < len(members) && uint64(len(signatures)) < committee.RequiredSignatures; i++ {
+		member := members[(s.round+i)%len(members)]
+		signature, err := s.clients.New(member.URL).SignSequence(ctx, *signed)
+		if err != nil {
+			logger.Warnf("member %s at %s failed to sign the sequence: %v", member.Addr.Hex(), member.URL, err)
+			continue
+		}
+		signatures[member.Addr] = signature
+	}
+	s.round++
+
+	if uint64(len(signatures)) < committee.RequiredSignatures {
+		return nil, fmt.Errorf("%d members signed the sequence, %d signatures are required",
+			len(signatures), committee.RequiredSignatures)
+	}
+
+	// the signatures in the order of the committee, followed by the addresses of all the members
+	var message []byte
+	for _, member := range members {
+		message = append(message, signatures[member.Addr]...)
+	}
+	for _, member := range members {
+		message = append(message, member.Addr.Bytes()...)
+	}
+
+	return s.chain.SequenceBatches(keys, message)
+}
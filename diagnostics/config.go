@@ -0,0 +1,15 @@
+package diagnostics
+
+import "github.com/0xPolygon/cdk-data-availability/config/types"
+
+// Config represents the configuration of the periodic self-diagnostics
+type Config struct {
+	// Enabled runs the self-diagnostics every Interval
+	Enabled bool `mapstructure:"Enabled"`
+
+	// Interval is the time between two self-diagnostics runs
+	Interval types.Duration `mapstructure:"Interval"`
+
+	// Timeout bounds each step of a self-diagnostics run
+	Timeout types.Duration `mapstructure:"Timeout"`
+}
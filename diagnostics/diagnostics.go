@@ -0,0 +1,213 @@
+package diagnostics
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/client"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/metrics"
+	"github.com/0xPolygon/cdk-data-availability/reporter"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// logger is the logger of the diagnostics component
+var logger = log.WithComponent("diagnostics")
+
+// Steps of a self-diagnostics run
+const (
+	// StepStore stores a synthetic value in the database
+	StepStore = "store"
+	// StepFetch fetches the synthetic value through the RPC endpoint of the node
+	StepFetch = "fetch"
+	// StepSign signs a dummy payload with the private key of the node
+	StepSign = "sign"
+	// StepCleanup deletes the synthetic value
+	StepCleanup = "cleanup"
+)
+
+// scratchPrefix prefixes the synthetic values, so they are never mistaken for batch data
+const scratchPrefix = "dac-self-diagnostics:"
+
+// errSkipped is the error of the steps not run because a previous step failed
+var errSkipped = errors.New("skipped, a previous step failed")
+
+// StepResult is the outcome of a step of a self-diagnostics run
+type StepResult struct {
+	Step     string `json:"step"`
+	Passed   bool   `json:"passed"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+// Report is the outcome of a self-diagnostics run, passed when every step did
+type Report struct {
+	Passed    bool         `json:"passed"`
+	Steps     []StepResult `json:"steps"`
+	Timestamp time.Time    `json:"timestamp"`
+}
+
+// Runner periodically exercises the node end to end: a synthetic value is stored in the
+// database, fetched back through the RPC endpoint of the node and deleted, and a dummy
+// payload is signed with the private key of the node
+type Runner struct {
+	cfg    Config
+	db     db.DB
+	client client.Client
+	pk     *ecdsa.PrivateKey
+	stop   chan struct{}
+
+	lock sync.RWMutex
+	last *Report
+}
+
+// New returns a Runner fetching the synthetic values from the RPC endpoint at rpcURL. The sign
+// step is skipped when pk is nil.
+func New(cfg Config, db db.DB, rpcURL string, pk *ecdsa.PrivateKey) *Runner {
+	return &Runner{
+		cfg:    cfg,
+		db:     db,
+		client: client.New(rpcURL),
+		pk:     pk,
+		stop:   make(chan struct{}),
+	}
+}
+
+// Start runs the self-diagnostics every interval until the runner is stopped
+func (r *Runner) Start(ctx context.Context) {
+	defer reporter.Recover()
+
+	logger.Infof("starting self-diagnostics every %v", r.cfg.Interval.Duration)
+	ticker := time.NewTicker(r.cfg.Interval.Duration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.Run(ctx)
+		case <-ctx.Done():
+			return
+		case <-r.stop:
+			return
+		}
+	}
+}
+
+// Stop stops the runner
+func (r *Runner) Stop() {
+	close(r.stop)
+}
+
+// Last returns the report of the last run, if any
+func (r *Runner) Last() (Report, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if r.last == nil {
+		return Report{}, false
+	}
+
+	return *r.last, true
+}
+
+// Run runs the self-diagnostics once, records the results in the metrics and returns the report
+func (r *Runner) Run(ctx context.Context) Report {
+	report := Report{Passed: true, Timestamp: time.Now().UTC()}
+	record := func(step string, err error, start time.Time) {
+		result := StepResult{Step: step, Passed: err == nil, Duration: time.Since(start).String()}
+		if err != nil {
+			result.Error = err.Error()
+			report.Passed = false
+			logger.Warnf("self-diagnostics step %s failed: %v", step, err)
+		}
+		metrics.DiagnosticsStep(step, result.Passed)
+		report.Steps = append(report.Steps, result)
+	}
+
+	value := []byte(fmt.Sprintf("%s%d", scratchPrefix, time.Now().UnixNano()))
+	key := crypto.Keccak256Hash(value)
+
+	start := time.Now()
+	err := r.step(ctx, func(ctx context.Context) error {
+		return r.db.StoreOffChainData(ctx, []types.OffChainData{{Key: key, Value: value}})
+	})
+	record(StepStore, err, start)
+	stored := err == nil
+
+	start = time.Now()
+	if stored {
+		err = r.step(ctx, func(ctx context.Context) error {
+			fetched, err := r.client.GetOffChainData(ctx, key)
+			if err != nil {
+				return err
+			}
+			if !bytes.Equal(fetched, value) {
+				return fmt.Errorf("unexpected value fetched for key %s", key.Hex())
+			}
+
+			return nil
+		})
+	} else {
+		err = errSkipped
+	}
+	record(StepFetch, err, start)
+
+	// the nodes holding no private key, e.g. the mirrors, do not sign
+	if r.pk != nil {
+		start = time.Now()
+		record(StepSign, r.step(ctx, r.sign), start)
+	}
+
+	start = time.Now()
+	if stored {
+		err = r.step(ctx, func(ctx context.Context) error {
+			return r.db.DeleteOffChainData(ctx, []common.Hash{key})
+		})
+	} else {
+		err = errSkipped
+	}
+	record(StepCleanup, err, start)
+
+	metrics.DiagnosticsRun(report.Passed)
+
+	r.lock.Lock()
+	r.last = &report
+	r.lock.Unlock()
+
+	return report
+}
+
+// step runs a step bounded by the timeout
+func (r *Runner) step(parentCtx context.Context, fn func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(parentCtx, r.cfg.Timeout.Duration)
+	defer cancel()
+
+	return fn(ctx)
+}
+
+// sign signs a dummy payload and checks the signer recovered from the signature
+func (r *Runner) sign(context.Context) error {
+	hash := crypto.Keccak256([]byte(scratchPrefix + "sign"))
+	signature, err := crypto.Sign(hash, r.pk)
+	if err != nil {
+		return err
+	}
+
+	signer, err := crypto.SigToPub(hash, signature)
+	if err != nil {
+		return err
+	}
+	if crypto.PubkeyToAddress(*signer) != crypto.PubkeyToAddress(r.pk.PublicKey) {
+		return fmt.Errorf("signature recovered to %s", crypto.PubkeyToAddress(*signer).Hex())
+	}
+
+	return nil
+}
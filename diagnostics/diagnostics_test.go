@@ -0,0 +1,131 @@
+package diagnostics_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cfgTypes "github.com/0xPolygon/cdk-data-availability/config/types"
+	"github.com/0xPolygon/cdk-data-availability/diagnostics"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// nodeServer answers sync_getOffChainData with the value returned by the given function
+func nodeServer(t *testing.T, value func() []byte) string {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		result, err := json.Marshal(types.ArgBytes(value()))
+		require.NoError(t, err)
+
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"result":  json.RawMessage(result),
+		}))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv.URL
+}
+
+func testConfig() diagnostics.Config {
+	return diagnostics.Config{
+		Enabled:  true,
+		Interval: cfgTypes.NewDuration(time.Minute),
+		Timeout:  cfgTypes.NewDuration(5 * time.Second),
+	}
+}
+
+func TestRunner_Run(t *testing.T) {
+	pk, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	t.Run("every step passed", func(t *testing.T) {
+		var stored atomic.Value
+		stored.Store([]byte{})
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("StoreOffChainData", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				od := args.Get(1).([]types.OffChainData) //nolint:forcetypeassert
+				require.Len(t, od, 1)
+				require.Equal(t, crypto.Keccak256Hash(od[0].Value), od[0].Key)
+				stored.Store(od[0].Value)
+			}).
+			Return(nil).Once()
+		dbMock.On("DeleteOffChainData", mock.Anything, mock.MatchedBy(func(keys []common.Hash) bool {
+			return len(keys) == 1 && keys[0] == crypto.Keccak256Hash(stored.Load().([]byte)) //nolint:forcetypeassert
+		})).Return(nil).Once()
+
+		url := nodeServer(t, func() []byte { return stored.Load().([]byte) }) //nolint:forcetypeassert
+		r := diagnostics.New(testConfig(), dbMock, url, pk)
+
+		_, ok := r.Last()
+		require.False(t, ok)
+
+		report := r.Run(context.Background())
+		require.True(t, report.Passed)
+		require.Len(t, report.Steps, 4)
+		for i, step := range []string{
+			diagnostics.StepStore, diagnostics.StepFetch, diagnostics.StepSign, diagnostics.StepCleanup,
+		} {
+			require.Equal(t, step, report.Steps[i].Step)
+			require.True(t, report.Steps[i].Passed, report.Steps[i].Error)
+		}
+
+		last, ok := r.Last()
+		require.True(t, ok)
+		require.Equal(t, report, last)
+	})
+
+	t.Run("fetch and cleanup skipped when the value is not stored", func(t *testing.T) {
+		dbMock := mocks.NewDB(t)
+		dbMock.On("StoreOffChainData", mock.Anything, mock.Anything).
+			Return(errors.New("test error")).Once()
+
+		r := diagnostics.New(testConfig(), dbMock, nodeServer(t, func() []byte { return nil }), pk)
+
+		report := r.Run(context.Background())
+		require.False(t, report.Passed)
+		skipped := "skipped, a previous step failed"
+		require.Equal(t, []string{"test error", skipped, "", skipped}, []string{
+			report.Steps[0].Error, report.Steps[1].Error, report.Steps[2].Error, report.Steps[3].Error,
+		})
+	})
+
+	t.Run("sign skipped without a private key", func(t *testing.T) {
+		dbMock := mocks.NewDB(t)
+		dbMock.On("StoreOffChainData", mock.Anything, mock.Anything).Return(errors.New("test error")).Once()
+
+		r := diagnostics.New(testConfig(), dbMock, nodeServer(t, func() []byte { return nil }), nil)
+
+		report := r.Run(context.Background())
+		require.Len(t, report.Steps, 3)
+		require.Equal(t, diagnostics.StepCleanup, report.Steps[2].Step)
+	})
+
+	t.Run("unexpected value fetched", func(t *testing.T) {
+		dbMock := mocks.NewDB(t)
+		dbMock.On("StoreOffChainData", mock.Anything, mock.Anything).Return(nil).Once()
+		dbMock.On("DeleteOffChainData", mock.Anything, mock.Anything).Return(nil).Once()
+
+		r := diagnostics.New(testConfig(), dbMock, nodeServer(t, func() []byte { return []byte("other") }), pk)
+
+		report := r.Run(context.Background())
+		require.False(t, report.Passed)
+		require.False(t, report.Steps[1].Passed)
+		require.Contains(t, report.Steps[1].Error, "unexpected value fetched")
+		require.True(t, report.Steps[3].Passed)
+	})
+}
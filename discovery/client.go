@@ -0,0 +1,123 @@
+package discovery
+
+import (
+	"context"
+
+	"github.com/0xPolygon/cdk-data-availability/client"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// discoveringClient is the client of a discoverable endpoint, which discovers its URL, or gets it
+// from the cache, before each request
+type discoveringClient struct {
+	endpoint string
+	factory  *factory
+}
+
+// client returns the client of the current URL of the endpoint
+func (c *discoveringClient) client(ctx context.Context) (client.Client, error) {
+	url, err := c.factory.resolver.Resolve(ctx, c.endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.factory.factory.New(url), nil
+}
+
+// GetStatus returns the status of the member
+func (c *discoveringClient) GetStatus(ctx context.Context) (*types.DACStatus, error) {
+	cl, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return cl.GetStatus(ctx)
+}
+
+// GetOffChainData returns the value of the given key
+func (c *discoveringClient) GetOffChainData(ctx context.Context, hash common.Hash) ([]byte, error) {
+	cl, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return cl.GetOffChainData(ctx, hash)
+}
+
+// GetSignedOffChainData returns the value of the given key along with the signature of the member over it
+func (c *discoveringClient) GetSignedOffChainData(ctx context.Context, hash common.Hash) ([]byte, []byte, error) {
+	cl, err := c.client(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return cl.GetSignedOffChainData(ctx, hash)
+}
+
+// ListOffChainData returns the values of the given keys
+func (c *discoveringClient) ListOffChainData(
+	ctx context.Context,
+	hashes []common.Hash,
+) (map[common.Hash][]byte, error) {
+	cl, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return cl.ListOffChainData(ctx, hashes)
+}
+
+// ListOffChainDataPage returns a page of the values stored, in the order of their keys
+func (c *discoveringClient) ListOffChainDataPage(
+	ctx context.Context,
+	after common.Hash,
+	limit uint,
+) ([]types.OffChainData, error) {
+	cl, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return cl.ListOffChainDataPage(ctx, after, limit)
+}
+
+// SignSequence requests the signature of the given sequence
+func (c *discoveringClient) SignSequence(ctx context.Context, signedSequence types.SignedSequence) ([]byte, error) {
+	cl, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return cl.SignSequence(ctx, signedSequence)
+}
+
+// AnnounceKeys announces the given keys to the member
+func (c *discoveringClient) AnnounceKeys(ctx context.Context, announcement types.KeyAnnouncement) error {
+	cl, err := c.client(ctx)
+	if err != nil {
+		return err
+	}
+
+	return cl.AnnounceKeys(ctx, announcement)
+}
+
+// SignAvailability requests the signature of the availability of the given data hash
+func (c *discoveringClient) SignAvailability(ctx context.Context, dataHash common.Hash) ([]byte, error) {
+	cl, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return cl.SignAvailability(ctx, dataHash)
+}
+
+// ChallengeCustody challenges the member to prove it holds the value of the given hash
+func (c *discoveringClient) ChallengeCustody(ctx context.Context, hash, nonce common.Hash) (common.Hash, error) {
+	cl, err := c.client(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return cl.ChallengeCustody(ctx, hash, nonce)
+}
@@ -0,0 +1,152 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/client"
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/log"
+)
+
+const (
+	// SchemeDNS is the scheme of the endpoints discovered from the TXT records of a DNS name
+	SchemeDNS = "dnstxt"
+	// SchemeENS is the scheme of the endpoints discovered from a text record of an ENS name
+	SchemeENS = "ens"
+)
+
+// logger is the logger of the discovery component
+var logger = log.WithComponent("discovery")
+
+// IsDiscoverable returns whether the endpoint is a name to discover the URL of, rather than a URL
+func IsDiscoverable(endpoint string) bool {
+	return strings.HasPrefix(endpoint, SchemeDNS+"://") || strings.HasPrefix(endpoint, SchemeENS+"://")
+}
+
+// entry is a discovered URL, used until it expires
+type entry struct {
+	url     string
+	expires time.Time
+}
+
+// Resolver discovers the URLs of the committee members registered on L1 as a name: the first
+// TXT record of a DNS name that is an HTTP URL, or the text record of an ENS name under the
+// configured key. The URLs are cached for the configured TTL.
+type Resolver struct {
+	cfg       config.DiscoveryConfig
+	etherman  etherman.Etherman
+	lookupTXT func(ctx context.Context, name string) ([]string, error)
+
+	lock  sync.Mutex
+	cache map[string]entry
+}
+
+// NewResolver returns a Resolver reading the ENS names through the given etherman
+func NewResolver(cfg config.DiscoveryConfig, em etherman.Etherman) *Resolver {
+	return &Resolver{
+		cfg:       cfg,
+		etherman:  em,
+		lookupTXT: net.DefaultResolver.LookupTXT,
+		cache:     make(map[string]entry),
+	}
+}
+
+// Resolve returns the URL of the given endpoint, which is returned as is unless it is discoverable
+func (r *Resolver) Resolve(ctx context.Context, endpoint string) (string, error) {
+	if !IsDiscoverable(endpoint) {
+		return endpoint, nil
+	}
+
+	r.lock.Lock()
+	cached, ok := r.cache[endpoint]
+	r.lock.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.url, nil
+	}
+
+	discovered, err := r.discover(ctx, endpoint)
+	if err != nil {
+		return "", fmt.Errorf("failed to discover the URL of %s: %w", endpoint, err)
+	}
+
+	if discovered != cached.url {
+		logger.Infof("discovered the URL %s of %s", discovered, endpoint)
+	}
+
+	r.lock.Lock()
+	r.cache[endpoint] = entry{url: discovered, expires: time.Now().Add(r.cfg.TTL.Duration)}
+	r.lock.Unlock()
+
+	return discovered, nil
+}
+
+// discover looks the URL of an endpoint up
+func (r *Resolver) discover(parentCtx context.Context, endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("no name in %q", endpoint)
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, r.cfg.Timeout.Duration)
+	defer cancel()
+
+	if u.Scheme == SchemeENS {
+		text, err := r.lookupENS(ctx, u.Host)
+		if err != nil {
+			return "", err
+		}
+		if !isHTTPURL(text) {
+			return "", fmt.Errorf("the %s text record %q is not an HTTP URL", r.cfg.ENSTextKey, text)
+		}
+
+		return text, nil
+	}
+
+	records, err := r.lookupTXT(ctx, u.Host)
+	if err != nil {
+		return "", err
+	}
+	for _, record := range records {
+		if isHTTPURL(record) {
+			return record, nil
+		}
+	}
+
+	return "", fmt.Errorf("no TXT record of %s is an HTTP URL", u.Host)
+}
+
+func isHTTPURL(value string) bool {
+	u, err := url.Parse(value)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// factory creates the clients of the committee members, discovering their URLs when needed
+type factory struct {
+	resolver *Resolver
+	factory  client.Factory
+}
+
+// NewFactory returns a client factory discovering the URLs of the discoverable endpoints with the
+// resolver, and creating the clients of the URLs with the given factory
+func NewFactory(resolver *Resolver, f client.Factory) client.Factory {
+	return &factory{resolver: resolver, factory: f}
+}
+
+// New returns a client of the given endpoint, discovering its URL on each request if needed
+func (f *factory) New(endpoint string) client.Client {
+	if !IsDiscoverable(endpoint) {
+		return f.factory.New(endpoint)
+	}
+
+	return &discoveringClient{endpoint: endpoint, factory: f}
+}
@@ -0,0 +1,153 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/config"
+	cfgTypes "github.com/0xPolygon/cdk-data-availability/config/types"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var registry = common.HexToAddress("0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e")
+
+func testConfig() config.DiscoveryConfig {
+	return config.DiscoveryConfig{
+		TTL:         cfgTypes.NewDuration(time.Minute),
+		Timeout:     cfgTypes.NewDuration(time.Second),
+		ENSRegistry: registry.Hex(),
+		ENSTextKey:  "dac.url",
+	}
+}
+
+// newDNSResolver returns a Resolver looking the TXT records up in the given records, counting the lookups
+func newDNSResolver(t *testing.T, records map[string][]string, lookups *int) *Resolver {
+	t.Helper()
+
+	r := NewResolver(testConfig(), mocks.NewEtherman(t))
+	r.lookupTXT = func(_ context.Context, name string) ([]string, error) {
+		*lookups++
+		txt, ok := records[name]
+		if !ok {
+			return nil, errors.New("no such host")
+		}
+		return txt, nil
+	}
+
+	return r
+}
+
+func TestNamehash(t *testing.T) {
+	require.Equal(t, common.Hash{}, namehash(""))
+	require.Equal(t, common.HexToHash("0x93cdeb708b7545dc668eb9280176169d1c33cfd8ed6f04690a0bcc88a93fc4ae"),
+		namehash("eth"))
+	require.Equal(t, common.HexToHash("0xde9b09fd7c5f901e23a3f19fecc54828e9c848539801e86591bd9801b019f84f"),
+		namehash("foo.eth"))
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	t.Run("URL", func(t *testing.T) {
+		var lookups int
+		url, err := newDNSResolver(t, nil, &lookups).Resolve(context.Background(), "http://member:8444")
+		require.NoError(t, err)
+		require.Equal(t, "http://member:8444", url)
+		require.Zero(t, lookups)
+	})
+
+	t.Run("DNS TXT record", func(t *testing.T) {
+		var lookups int
+		r := newDNSResolver(t, map[string][]string{
+			"dac.example.org": {"v=spf1 -all", "https://dac.example.org:8444"},
+		}, &lookups)
+
+		url, err := r.Resolve(context.Background(), "dnstxt://dac.example.org")
+		require.NoError(t, err)
+		require.Equal(t, "https://dac.example.org:8444", url)
+
+		// cached until the TTL expires
+		_, err = r.Resolve(context.Background(), "dnstxt://dac.example.org")
+		require.NoError(t, err)
+		require.Equal(t, 1, lookups)
+
+		r.cache["dnstxt://dac.example.org"] = entry{url: url, expires: time.Now().Add(-time.Second)}
+		_, err = r.Resolve(context.Background(), "dnstxt://dac.example.org")
+		require.NoError(t, err)
+		require.Equal(t, 2, lookups)
+	})
+
+	t.Run("DNS name without URL", func(t *testing.T) {
+		var lookups int
+		r := newDNSResolver(t, map[string][]string{"dac.example.org": {"v=spf1 -all"}}, &lookups)
+
+		_, err := r.Resolve(context.Background(), "dnstxt://dac.example.org")
+		require.ErrorContains(t, err, "no TXT record of dac.example.org is an HTTP URL")
+
+		_, err = r.Resolve(context.Background(), "dnstxt://unknown.example.org")
+		require.ErrorContains(t, err, "no such host")
+	})
+
+	t.Run("ENS text record", func(t *testing.T) {
+		resolver := common.HexToAddress("0x231b0Ee14048e9dCcD1d247744d114a4EB5E8E63")
+		node := namehash("member.eth")
+
+		em := mocks.NewEtherman(t)
+		em.On("CallContract", mock.Anything, mock.MatchedBy(func(msg ethereum.CallMsg) bool {
+			return *msg.To == registry
+		}), (*big.Int)(nil)).Return(func(_ context.Context, msg ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+			input, err := ensMethods.Pack("resolver", node)
+			require.NoError(t, err)
+			require.Equal(t, input, msg.Data)
+			return ensMethods.Methods["resolver"].Outputs.Pack(resolver)
+		}).Once()
+		em.On("CallContract", mock.Anything, mock.MatchedBy(func(msg ethereum.CallMsg) bool {
+			return *msg.To == resolver
+		}), (*big.Int)(nil)).Return(func(_ context.Context, msg ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+			input, err := ensMethods.Pack("text", node, "dac.url")
+			require.NoError(t, err)
+			require.Equal(t, input, msg.Data)
+			return ensMethods.Methods["text"].Outputs.Pack("https://member.example.org")
+		}).Once()
+
+		url, err := NewResolver(testConfig(), em).Resolve(context.Background(), "ens://Member.eth")
+		require.NoError(t, err)
+		require.Equal(t, "https://member.example.org", url)
+	})
+
+	t.Run("ENS name without resolver", func(t *testing.T) {
+		em := mocks.NewEtherman(t)
+		em.On("CallContract", mock.Anything, mock.Anything, (*big.Int)(nil)).
+			Return(ensMethods.Methods["resolver"].Outputs.Pack(common.Address{}))
+
+		_, err := NewResolver(testConfig(), em).Resolve(context.Background(), "ens://member.eth")
+		require.ErrorContains(t, err, "ENS name member.eth has no resolver")
+	})
+}
+
+func TestFactory_New(t *testing.T) {
+	var lookups int
+	r := newDNSResolver(t, map[string][]string{"dac.example.org": {"https://dac.example.org:8444"}}, &lookups)
+
+	dacClient := mocks.NewClient(t)
+	dacClient.On("GetOffChainData", mock.Anything, common.HexToHash("0x1")).Return([]byte("value"), nil)
+
+	clientFactory := mocks.NewClientFactory(t)
+	clientFactory.On("New", "https://dac.example.org:8444").Return(dacClient)
+	clientFactory.On("New", "http://member:8444").Return(dacClient)
+
+	f := NewFactory(r, clientFactory)
+	require.Equal(t, dacClient, f.New("http://member:8444"))
+
+	value, err := f.New("dnstxt://dac.example.org").GetOffChainData(context.Background(), common.HexToHash("0x1"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+
+	_, err = f.New("dnstxt://unknown.example.org").GetOffChainData(context.Background(), common.HexToHash("0x1"))
+	require.ErrorContains(t, err, "failed to discover the URL of dnstxt://unknown.example.org")
+}
@@ -0,0 +1,94 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ensABI holds the methods of the ENS registry and of the public resolver read to discover a URL
+const ensABI = `[
+	{"name":"resolver","type":"function","stateMutability":"view",
+		"inputs":[{"name":"node","type":"bytes32"}],"outputs":[{"name":"","type":"address"}]},
+	{"name":"text","type":"function","stateMutability":"view",
+		"inputs":[{"name":"node","type":"bytes32"},{"name":"key","type":"string"}],
+		"outputs":[{"name":"","type":"string"}]}
+]`
+
+var ensMethods = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(ensABI))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// namehash returns the ENS node of a name, as defined by EIP-137
+func namehash(name string) common.Hash {
+	var node common.Hash
+	if name == "" {
+		return node
+	}
+
+	labels := strings.Split(name, ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		node = crypto.Keccak256Hash(node.Bytes(), crypto.Keccak256([]byte(labels[i])))
+	}
+
+	return node
+}
+
+// lookupENS returns the text record of the given key of an ENS name, read from the resolver
+// the name is set to in the ENS registry
+func (r *Resolver) lookupENS(ctx context.Context, name string) (string, error) {
+	node := namehash(strings.ToLower(name))
+
+	var resolver common.Address
+	if err := r.call(ctx, common.HexToAddress(r.cfg.ENSRegistry), &resolver, "resolver", node); err != nil {
+		return "", fmt.Errorf("failed to get the resolver of %s: %w", name, err)
+	}
+	if resolver == (common.Address{}) {
+		return "", fmt.Errorf("ENS name %s has no resolver", name)
+	}
+
+	var text string
+	if err := r.call(ctx, resolver, &text, "text", node, r.cfg.ENSTextKey); err != nil {
+		return "", fmt.Errorf("failed to get the %s text record of %s: %w", r.cfg.ENSTextKey, name, err)
+	}
+	if text == "" {
+		return "", fmt.Errorf("ENS name %s has no %s text record", name, r.cfg.ENSTextKey)
+	}
+
+	return text, nil
+}
+
+// call calls a method of the ENS contracts and unpacks its single result
+func (r *Resolver) call(
+	ctx context.Context,
+	contract common.Address,
+	result interface{},
+	method string,
+	args ...interface{},
+) error {
+	input, err := ensMethods.Pack(method, args...)
+	if err != nil {
+		return err
+	}
+
+	output, err := r.etherman.CallContract(ctx, ethereum.CallMsg{To: &contract, Data: input}, nil)
+	if err != nil {
+		return err
+	}
+
+	values, err := ensMethods.Unpack(method, output)
+	if err != nil {
+		return err
+	}
+
+	return ensMethods.Methods[method].Outputs.Copy(result, values)
+}
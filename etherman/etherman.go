@@ -6,16 +6,27 @@ import (
 	"math/big"
 
 	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/etherman/smartcontracts/attestation/dacattestation"
 	"github.com/0xPolygon/cdk-data-availability/etherman/smartcontracts/etrog/polygondatacommittee"
 	"github.com/0xPolygon/cdk-data-availability/etherman/smartcontracts/etrog/polygonvalidium"
 	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/proxy"
+	"github.com/0xPolygon/cdk-data-availability/tracing"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/event"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/gorilla/websocket"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// logger is the logger of the etherman component
+var logger = log.WithComponent("etherman")
+
 // DataCommitteeMember represents a member of the Data Committee
 type DataCommitteeMember struct {
 	Addr common.Address
@@ -35,10 +46,18 @@ type Etherman interface {
 	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
 	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
 	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
+	CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+	ChainID(ctx context.Context) (*big.Int, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
 
 	GetCurrentDataCommittee() (*DataCommittee, error)
 	GetCurrentDataCommitteeMembers() ([]DataCommitteeMember, error)
+	GetDataCommitteeMembers(ctx context.Context, blockNumber *big.Int) ([]DataCommitteeMember, error)
+	FilterCommitteeUpdated(
+		opts *bind.FilterOpts,
+	) (*polygondatacommittee.PolygondatacommitteeCommitteeUpdatedIterator, error)
 	TrustedSequencer(ctx context.Context) (common.Address, error)
+	LastVerifiedBatch(ctx context.Context) (uint64, error)
 	WatchSetTrustedSequencer(
 		ctx context.Context,
 		events chan *polygonvalidium.PolygonvalidiumSetTrustedSequencer,
@@ -52,13 +71,22 @@ type Etherman interface {
 		opts *bind.FilterOpts,
 		numBatch []uint64,
 	) (*polygonvalidium.PolygonvalidiumSequenceBatchesIterator, error)
+
+	SubmitAttestation(
+		opts *bind.TransactOpts,
+		contract common.Address,
+		root common.Hash,
+		fromBatch, toBatch uint64,
+		keyCount uint32,
+	) (*types.Transaction, error)
 }
 
 // etherman is the implementation of EtherMan.
 type etherman struct {
-	EthClient     *ethclient.Client
-	CDKValidium   *polygonvalidium.Polygonvalidium
-	DataCommittee *polygondatacommittee.Polygondatacommittee
+	EthClient       *ethclient.Client
+	CDKValidium     *polygonvalidium.Polygonvalidium
+	ValidiumAddress common.Address
+	DataCommittee   *polygondatacommittee.Polygondatacommittee
 }
 
 // New creates a new etherman
@@ -66,11 +94,24 @@ func New(ctx context.Context, cfg config.L1Config) (Etherman, error) {
 	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout.Duration)
 	defer cancel()
 
-	ethClient, err := ethclient.DialContext(ctx, cfg.RpcURL)
+	// the websocket connections go through the proxy as the HTTP ones, which use the default transport
+	rpcClient, err := ethrpc.DialOptions(ctx, cfg.RpcURL,
+		ethrpc.WithWebsocketDialer(websocket.Dialer{Proxy: proxy.Func()}))
 	if err != nil {
-		log.Errorf("error connecting to %s: %+v", cfg.RpcURL, err)
+		logger.Errorf("error connecting to %s: %+v", cfg.RpcURL, err)
 		return nil, err
 	}
+	ethClient := ethclient.NewClient(rpcClient)
+
+	if cfg.ChainID != 0 {
+		chainID, err := ethClient.ChainID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if chainID.Uint64() != cfg.ChainID {
+			return nil, fmt.Errorf("L1 node at %s is on chain %d, expected chain %d", cfg.RpcURL, chainID, cfg.ChainID)
+		}
+	}
 
 	cdkValidium, err := polygonvalidium.NewPolygonvalidium(
 		common.HexToAddress(cfg.PolygonValidiumAddress),
@@ -89,34 +130,78 @@ func New(ctx context.Context, cfg config.L1Config) (Etherman, error) {
 	}
 
 	return &etherman{
-		EthClient:     ethClient,
-		CDKValidium:   cdkValidium,
-		DataCommittee: dataCommittee,
+		EthClient:       ethClient,
+		CDKValidium:     cdkValidium,
+		ValidiumAddress: common.HexToAddress(cfg.PolygonValidiumAddress),
+		DataCommittee:   dataCommittee,
 	}, nil
 }
 
 // GetTx function get ethereum tx
-func (e *etherman) GetTx(ctx context.Context, txHash common.Hash) (*types.Transaction, bool, error) {
+func (e *etherman) GetTx(ctx context.Context, txHash common.Hash) (tx *types.Transaction, pending bool, err error) {
+	ctx, span := startSpan(ctx, "GetTx")
+	defer func() { tracing.End(span, err) }()
+
 	return e.EthClient.TransactionByHash(ctx, txHash)
 }
 
 // HeaderByNumber returns header by number from the eth client
-func (e *etherman) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+func (e *etherman) HeaderByNumber(ctx context.Context, number *big.Int) (header *types.Header, err error) {
+	ctx, span := startSpan(ctx, "HeaderByNumber")
+	defer func() { tracing.End(span, err) }()
+
 	return e.EthClient.HeaderByNumber(ctx, number)
 }
 
 // BlockByNumber returns a block by the given number
-func (e *etherman) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+func (e *etherman) BlockByNumber(ctx context.Context, number *big.Int) (block *types.Block, err error) {
+	ctx, span := startSpan(ctx, "BlockByNumber")
+	defer func() { tracing.End(span, err) }()
+
 	return e.EthClient.BlockByNumber(ctx, number)
 }
 
 // CodeAt returns the contract code of the given account.
-func (e *etherman) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+func (e *etherman) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) (code []byte, err error) {
+	ctx, span := startSpan(ctx, "CodeAt")
+	defer func() { tracing.End(span, err) }()
+
 	return e.EthClient.CodeAt(ctx, account, blockNumber)
 }
 
+// CallContract executes a message call against the state of the given block, the latest one if nil
+func (e *etherman) CallContract(
+	ctx context.Context,
+	msg ethereum.CallMsg,
+	blockNumber *big.Int,
+) (result []byte, err error) {
+	ctx, span := startSpan(ctx, "CallContract")
+	defer func() { tracing.End(span, err) }()
+
+	return e.EthClient.CallContract(ctx, msg, blockNumber)
+}
+
+// ChainID returns the chain ID of the L1 node
+func (e *etherman) ChainID(ctx context.Context) (chainID *big.Int, err error) {
+	ctx, span := startSpan(ctx, "ChainID")
+	defer func() { tracing.End(span, err) }()
+
+	return e.EthClient.ChainID(ctx)
+}
+
+// TransactionReceipt returns the receipt of a mined transaction
+func (e *etherman) TransactionReceipt(ctx context.Context, txHash common.Hash) (receipt *types.Receipt, err error) {
+	ctx, span := startSpan(ctx, "TransactionReceipt")
+	defer func() { tracing.End(span, err) }()
+
+	return e.EthClient.TransactionReceipt(ctx, txHash)
+}
+
 // TrustedSequencer gets trusted sequencer address
-func (e *etherman) TrustedSequencer(ctx context.Context) (common.Address, error) {
+func (e *etherman) TrustedSequencer(ctx context.Context) (addr common.Address, err error) {
+	ctx, span := startSpan(ctx, "TrustedSequencer")
+	defer func() { tracing.End(span, err) }()
+
 	return e.CDKValidium.TrustedSequencer(&bind.CallOpts{
 		Context: ctx,
 		Pending: false,
@@ -132,7 +217,10 @@ func (e *etherman) WatchSetTrustedSequencer(
 }
 
 // TrustedSequencerURL gets trusted sequencer's RPC url
-func (e *etherman) TrustedSequencerURL(ctx context.Context) (string, error) {
+func (e *etherman) TrustedSequencerURL(ctx context.Context) (url string, err error) {
+	ctx, span := startSpan(ctx, "TrustedSequencerURL")
+	defer func() { tracing.End(span, err) }()
+
 	return e.CDKValidium.TrustedSequencerURL(&bind.CallOpts{
 		Context: ctx,
 		Pending: false,
@@ -149,8 +237,48 @@ func (e *etherman) WatchSetTrustedSequencerURL(
 
 // FilterSequenceBatches retrieves filtered batches on CDK validium
 func (e *etherman) FilterSequenceBatches(opts *bind.FilterOpts,
-	numBatch []uint64) (*polygonvalidium.PolygonvalidiumSequenceBatchesIterator, error) {
-	return e.CDKValidium.FilterSequenceBatches(opts, numBatch)
+	numBatch []uint64) (it *polygonvalidium.PolygonvalidiumSequenceBatchesIterator, err error) {
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, span := startSpan(ctx, "FilterSequenceBatches")
+	defer func() { tracing.End(span, err) }()
+
+	traced := *opts
+	traced.Context = ctx
+	return e.CDKValidium.FilterSequenceBatches(&traced, numBatch)
+}
+
+// SubmitAttestation sends a transaction attesting the custody of the keys of the given Merkle root
+// to the attestation contract
+func (e *etherman) SubmitAttestation(
+	opts *bind.TransactOpts,
+	contract common.Address,
+	root common.Hash,
+	fromBatch, toBatch uint64,
+	keyCount uint32,
+) (tx *types.Transaction, err error) {
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, span := startSpan(ctx, "SubmitAttestation")
+	defer func() { tracing.End(span, err) }()
+
+	attestation, err := dacattestation.NewDacattestation(contract, e.EthClient)
+	if err != nil {
+		return nil, err
+	}
+
+	traced := *opts
+	traced.Context = ctx
+	return attestation.Attest(&traced, root, fromBatch, toBatch, keyCount)
+}
+
+// startSpan starts the span of a query to the L1 node
+func startSpan(ctx context.Context, method string) (context.Context, trace.Span) {
+	return tracing.Start(ctx, "l1."+method, trace.SpanKindClient, attribute.String("l1.method", method))
 }
 
 // GetCurrentDataCommittee return the currently registered data committee
@@ -179,15 +307,28 @@ func (e *etherman) GetCurrentDataCommittee() (*DataCommittee, error) {
 
 // GetCurrentDataCommitteeMembers return the currently registered data committee members
 func (e *etherman) GetCurrentDataCommitteeMembers() ([]DataCommitteeMember, error) {
-	members := []DataCommitteeMember{}
+	return e.GetDataCommitteeMembers(context.Background(), nil)
+}
+
+// GetDataCommitteeMembers return the data committee members registered at the given block,
+// or the latest one if nil. Reading the members of an old block requires an archive node.
+func (e *etherman) GetDataCommitteeMembers(
+	ctx context.Context,
+	blockNumber *big.Int,
+) (members []DataCommitteeMember, err error) {
+	ctx, span := startSpan(ctx, "GetDataCommitteeMembers")
+	defer func() { tracing.End(span, err) }()
 
-	nMembers, err := e.DataCommittee.GetAmountOfMembers(&bind.CallOpts{Pending: false})
+	opts := &bind.CallOpts{Context: ctx, Pending: false, BlockNumber: blockNumber}
+
+	nMembers, err := e.DataCommittee.GetAmountOfMembers(opts)
 	if err != nil {
 		return nil, fmt.Errorf("error getting GetAmountOfMembers from L1 SC: %w", err)
 	}
 
+	members = []DataCommitteeMember{}
 	for i := int64(0); i < nMembers.Int64(); i++ {
-		member, err := e.DataCommittee.Members(&bind.CallOpts{Pending: false}, big.NewInt(i))
+		member, err := e.DataCommittee.Members(opts, big.NewInt(i))
 		if err != nil {
 			return nil, fmt.Errorf("error getting Members %d from L1 SC: %w", i, err)
 		}
@@ -200,3 +341,19 @@ func (e *etherman) GetCurrentDataCommitteeMembers() ([]DataCommitteeMember, erro
 
 	return members, nil
 }
+
+// FilterCommitteeUpdated retrieves the updates of the data committee
+func (e *etherman) FilterCommitteeUpdated(
+	opts *bind.FilterOpts,
+) (it *polygondatacommittee.PolygondatacommitteeCommitteeUpdatedIterator, err error) {
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, span := startSpan(ctx, "FilterCommitteeUpdated")
+	defer func() { tracing.End(span, err) }()
+
+	traced := *opts
+	traced.Context = ctx
+	return e.DataCommittee.FilterCommitteeUpdated(&traced)
+}
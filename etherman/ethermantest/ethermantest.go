@@ -0,0 +1,121 @@
+// Package ethermantest provides the SequenceBatches events and the sequences read from L1 by the tests
+package ethermantest
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/etherman/smartcontracts/etrog/polygonvalidium"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// validium returns the ABI of the validium contract
+func validium(t testing.TB) abi.ABI {
+	t.Helper()
+
+	parsed, err := abi.JSON(strings.NewReader(polygonvalidium.PolygonvalidiumABI))
+	require.NoError(t, err)
+
+	return parsed
+}
+
+// Log returns the SequenceBatches log of a sequence up to the batch lastBatch
+func Log(t testing.TB, lastBatch uint64) ethTypes.Log {
+	t.Helper()
+
+	event := validium(t).Events["SequenceBatches"]
+	data, err := event.Inputs.NonIndexed().Pack(common.Hash{})
+	require.NoError(t, err)
+
+	return ethTypes.Log{
+		Topics: []common.Hash{event.ID, common.BigToHash(new(big.Int).SetUint64(lastBatch))},
+		Data:   data,
+	}
+}
+
+// Sequence returns the SequenceBatches log and the transaction sequencing the given values up to the batch
+// lastBatch, with a data availability message that is not signed
+func Sequence(t testing.TB, lastBatch uint64, values ...[]byte) (ethTypes.Log, *ethTypes.Transaction) {
+	t.Helper()
+
+	return sequence(t, lastBatch, []byte{1}, values...)
+}
+
+// SignedSequence returns the hash to sign of the given values, and the SequenceBatches log and the transaction
+// sequencing them up to the batch lastBatch with the signature of the given key
+func SignedSequence(
+	t testing.TB,
+	lastBatch uint64,
+	signer *ecdsa.PrivateKey,
+	values ...[]byte,
+) (common.Hash, ethTypes.Log, *ethTypes.Transaction) {
+	t.Helper()
+
+	keys := make([]common.Hash, len(values))
+	for i, value := range values {
+		keys[i] = crypto.Keccak256Hash(value)
+	}
+	hash := types.HashToSignFromKeys(keys)
+
+	sig, err := crypto.Sign(hash.Bytes(), signer)
+	require.NoError(t, err)
+	sig[64] += 27
+	message := append(sig, crypto.PubkeyToAddress(signer.PublicKey).Bytes()...)
+
+	log, tx := sequence(t, lastBatch, message, values...)
+
+	return hash, log, tx
+}
+
+// sequence returns the log and the transaction sequencing the values with the data availability message
+func sequence(
+	t testing.TB,
+	lastBatch uint64,
+	message []byte,
+	values ...[]byte,
+) (ethTypes.Log, *ethTypes.Transaction) {
+	t.Helper()
+
+	batches := make([]polygonvalidium.PolygonValidiumEtrogValidiumBatchData, len(values))
+	for i, value := range values {
+		batches[i].TransactionsHash = crypto.Keccak256Hash(value)
+	}
+
+	method := validium(t).Methods["sequenceBatchesValidium"]
+	data, err := method.Inputs.Pack(batches, common.HexToAddress("0xABCD"), message)
+	require.NoError(t, err)
+
+	tx := ethTypes.NewTx(&ethTypes.LegacyTx{GasPrice: big.NewInt(1), Data: append(method.ID, data...)})
+
+	log := Log(t, lastBatch)
+	log.TxHash = tx.Hash()
+
+	return log, tx
+}
+
+// Iterator returns an iterator over the SequenceBatches events of the logs, as returned by FilterSequenceBatches
+func Iterator(t testing.TB, logs ...ethTypes.Log) *polygonvalidium.PolygonvalidiumSequenceBatchesIterator {
+	t.Helper()
+
+	iter, err := etherman.FilterSequenceBatchesFromLogs(logs, &bind.FilterOpts{}, nil)
+	require.NoError(t, err)
+
+	return iter
+}
+
+// BlockRange matches the options of FilterSequenceBatches filtering the blocks from start to end
+func BlockRange(start, end uint64) interface{} {
+	return mock.MatchedBy(func(opts *bind.FilterOpts) bool {
+		return opts.Start == start && opts.End != nil && *opts.End == end
+	})
+}
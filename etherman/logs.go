@@ -0,0 +1,46 @@
+package etherman
+
+import (
+	"context"
+	"errors"
+
+	"github.com/0xPolygon/cdk-data-availability/etherman/smartcontracts/etrog/polygonvalidium"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// FilterSequenceBatchesFromLogs returns an iterator over the SequenceBatches events of the logs, which were
+// filtered already, e.g. to read again the events of an iterator once consumed
+func FilterSequenceBatchesFromLogs(
+	logs []types.Log,
+	opts *bind.FilterOpts,
+	numBatch []uint64,
+) (*polygonvalidium.PolygonvalidiumSequenceBatchesIterator, error) {
+	filterer, err := polygonvalidium.NewPolygonvalidiumFilterer(common.Address{}, &logFilterer{logs: logs})
+	if err != nil {
+		return nil, err
+	}
+
+	return filterer.FilterSequenceBatches(opts, numBatch)
+}
+
+// logFilterer returns the given logs to the event iterators
+type logFilterer struct {
+	logs []types.Log
+}
+
+// FilterLogs returns the logs, whatever the query, as they were filtered already
+func (f *logFilterer) FilterLogs(context.Context, ethereum.FilterQuery) ([]types.Log, error) {
+	return f.logs, nil
+}
+
+// SubscribeFilterLogs is not supported, the events being filtered only
+func (f *logFilterer) SubscribeFilterLogs(
+	context.Context,
+	ethereum.FilterQuery,
+	chan<- types.Log,
+) (ethereum.Subscription, error) {
+	return nil, errors.New("not supported")
+}
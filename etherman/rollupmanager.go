@@ -0,0 +1,61 @@
+package etherman
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/0xPolygon/cdk-data-availability/tracing"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+// rollupManagerABI holds the methods of the rollup manager of the validium read by the node
+const rollupManagerABI = `[
+	{"name":"rollupAddressToID","type":"function","stateMutability":"view",
+		"inputs":[{"name":"rollupAddress","type":"address"}],"outputs":[{"name":"rollupID","type":"uint32"}]},
+	{"name":"getLastVerifiedBatch","type":"function","stateMutability":"view",
+		"inputs":[{"name":"rollupID","type":"uint32"}],"outputs":[{"name":"","type":"uint64"}]}
+]`
+
+var rollupManagerMethods = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(rollupManagerABI))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// LastVerifiedBatch returns the last batch of the validium verified on L1, read from its rollup manager
+func (e *etherman) LastVerifiedBatch(ctx context.Context) (batch uint64, err error) {
+	ctx, span := startSpan(ctx, "LastVerifiedBatch")
+	defer func() { tracing.End(span, err) }()
+
+	opts := &bind.CallOpts{Context: ctx}
+	managerAddr, err := e.CDKValidium.RollupManager(opts)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the rollup manager: %w", err)
+	}
+	manager := bind.NewBoundContract(managerAddr, rollupManagerMethods, e.EthClient, e.EthClient, e.EthClient)
+
+	var out []interface{}
+	if err = manager.Call(opts, &out, "rollupAddressToID", e.ValidiumAddress); err != nil {
+		return 0, fmt.Errorf("failed to get the rollup ID: %w", err)
+	}
+	rollupID, ok := out[0].(uint32)
+	if !ok || rollupID == 0 {
+		return 0, fmt.Errorf("the validium %s is not registered in the rollup manager %s",
+			e.ValidiumAddress.Hex(), managerAddr.Hex())
+	}
+
+	out = nil
+	if err = manager.Call(opts, &out, "getLastVerifiedBatch", rollupID); err != nil {
+		return 0, fmt.Errorf("failed to get the last verified batch: %w", err)
+	}
+	batch, ok = out[0].(uint64)
+	if !ok {
+		return 0, fmt.Errorf("unexpected last verified batch %v", out[0])
+	}
+
+	return batch, nil
+}
@@ -9,8 +9,18 @@ gen() {
     abigen --bin ${forkName}/bin/${package}.bin --abi ${forkName}/abi/${package}.abi --pkg=${package} --out=${forkName}/${package}/${package}.go
 }
 
+# genabi generates the binding of a contract whose bytecode is not part of the repository
+genabi() {
+    local package=$1
+    local dir=$2
+
+    abigen --abi ${dir}/abi/${package}.abi --pkg=${package} --out=${dir}/${package}/${package}.go
+}
+
 gen polygonvalidium etrog
 gen polygondatacommittee etrog
 
 gen polygonvalidium elderberry
 gen polygondatacommittee elderberry
+
+genabi dacattestation attestation
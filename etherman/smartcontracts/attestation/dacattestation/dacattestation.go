@@ -0,0 +1,350 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package dacattestation
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// DacattestationMetaData contains all meta data concerning the Dacattestation contract.
+var DacattestationMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"root\",\"type\":\"bytes32\"},{\"internalType\":\"uint64\",\"name\":\"fromBatch\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"toBatch\",\"type\":\"uint64\"},{\"internalType\":\"uint32\",\"name\":\"keyCount\",\"type\":\"uint32\"}],\"name\":\"attest\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"member\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"root\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"fromBatch\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"toBatch\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"uint32\",\"name\":\"keyCount\",\"type\":\"uint32\"}],\"name\":\"Attested\",\"type\":\"event\"}]",
+}
+
+// DacattestationABI is the input ABI used to generate the binding from.
+// Deprecated: Use DacattestationMetaData.ABI instead.
+var DacattestationABI = DacattestationMetaData.ABI
+
+// Dacattestation is an auto generated Go binding around an Ethereum contract.
+type Dacattestation struct {
+	DacattestationCaller     // Read-only binding to the contract
+	DacattestationTransactor // Write-only binding to the contract
+	DacattestationFilterer   // Log filterer for contract events
+}
+
+// DacattestationCaller is an auto generated read-only Go binding around an Ethereum contract.
+type DacattestationCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// DacattestationTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type DacattestationTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// DacattestationFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type DacattestationFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// DacattestationSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type DacattestationSession struct {
+	Contract     *Dacattestation   // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// DacattestationCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type DacattestationCallerSession struct {
+	Contract *DacattestationCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts         // Call options to use throughout this session
+}
+
+// DacattestationTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type DacattestationTransactorSession struct {
+	Contract     *DacattestationTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts         // Transaction auth options to use throughout this session
+}
+
+// DacattestationRaw is an auto generated low-level Go binding around an Ethereum contract.
+type DacattestationRaw struct {
+	Contract *Dacattestation // Generic contract binding to access the raw methods on
+}
+
+// DacattestationCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type DacattestationCallerRaw struct {
+	Contract *DacattestationCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// DacattestationTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type DacattestationTransactorRaw struct {
+	Contract *DacattestationTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewDacattestation creates a new instance of Dacattestation, bound to a specific deployed contract.
+func NewDacattestation(address common.Address, backend bind.ContractBackend) (*Dacattestation, error) {
+	contract, err := bindDacattestation(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Dacattestation{DacattestationCaller: DacattestationCaller{contract: contract}, DacattestationTransactor: DacattestationTransactor{contract: contract}, DacattestationFilterer: DacattestationFilterer{contract: contract}}, nil
+}
+
+// NewDacattestationCaller creates a new read-only instance of Dacattestation, bound to a specific deployed contract.
+func NewDacattestationCaller(address common.Address, caller bind.ContractCaller) (*DacattestationCaller, error) {
+	contract, err := bindDacattestation(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &DacattestationCaller{contract: contract}, nil
+}
+
+// NewDacattestationTransactor creates a new write-only instance of Dacattestation, bound to a specific deployed contract.
+func NewDacattestationTransactor(address common.Address, transactor bind.ContractTransactor) (*DacattestationTransactor, error) {
+	contract, err := bindDacattestation(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &DacattestationTransactor{contract: contract}, nil
+}
+
+// NewDacattestationFilterer creates a new log filterer instance of Dacattestation, bound to a specific deployed contract.
+func NewDacattestationFilterer(address common.Address, filterer bind.ContractFilterer) (*DacattestationFilterer, error) {
+	contract, err := bindDacattestation(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &DacattestationFilterer{contract: contract}, nil
+}
+
+// bindDacattestation binds a generic wrapper to an already deployed contract.
+func bindDacattestation(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := DacattestationMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Dacattestation *DacattestationRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Dacattestation.Contract.DacattestationCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Dacattestation *DacattestationRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Dacattestation.Contract.DacattestationTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Dacattestation *DacattestationRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Dacattestation.Contract.DacattestationTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Dacattestation *DacattestationCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Dacattestation.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Dacattestation *DacattestationTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Dacattestation.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Dacattestation *DacattestationTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Dacattestation.Contract.contract.Transact(opts, method, params...)
+}
+
+// Attest is a paid mutator transaction binding the contract method 0x187c8be8.
+//
+// Solidity: function attest(bytes32 root, uint64 fromBatch, uint64 toBatch, uint32 keyCount) returns()
+func (_Dacattestation *DacattestationTransactor) Attest(opts *bind.TransactOpts, root [32]byte, fromBatch uint64, toBatch uint64, keyCount uint32) (*types.Transaction, error) {
+	return _Dacattestation.contract.Transact(opts, "attest", root, fromBatch, toBatch, keyCount)
+}
+
+// Attest is a paid mutator transaction binding the contract method 0x187c8be8.
+//
+// Solidity: function attest(bytes32 root, uint64 fromBatch, uint64 toBatch, uint32 keyCount) returns()
+func (_Dacattestation *DacattestationSession) Attest(root [32]byte, fromBatch uint64, toBatch uint64, keyCount uint32) (*types.Transaction, error) {
+	return _Dacattestation.Contract.Attest(&_Dacattestation.TransactOpts, root, fromBatch, toBatch, keyCount)
+}
+
+// Attest is a paid mutator transaction binding the contract method 0x187c8be8.
+//
+// Solidity: function attest(bytes32 root, uint64 fromBatch, uint64 toBatch, uint32 keyCount) returns()
+func (_Dacattestation *DacattestationTransactorSession) Attest(root [32]byte, fromBatch uint64, toBatch uint64, keyCount uint32) (*types.Transaction, error) {
+	return _Dacattestation.Contract.Attest(&_Dacattestation.TransactOpts, root, fromBatch, toBatch, keyCount)
+}
+
+// DacattestationAttestedIterator is returned from FilterAttested and is used to iterate over the raw logs and unpacked data for Attested events raised by the Dacattestation contract.
+type DacattestationAttestedIterator struct {
+	Event *DacattestationAttested // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *DacattestationAttestedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(DacattestationAttested)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(DacattestationAttested)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *DacattestationAttestedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *DacattestationAttestedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// DacattestationAttested represents a Attested event raised by the Dacattestation contract.
+type DacattestationAttested struct {
+	Member    common.Address
+	Root      [32]byte
+	FromBatch uint64
+	ToBatch   uint64
+	KeyCount  uint32
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterAttested is a free log retrieval operation binding the contract event 0x3cbe3d5b038ec271314911df10c138784c5990cb5289c6d2d6ba0762cf28e89f.
+//
+// Solidity: event Attested(address indexed member, bytes32 root, uint64 fromBatch, uint64 toBatch, uint32 keyCount)
+func (_Dacattestation *DacattestationFilterer) FilterAttested(opts *bind.FilterOpts, member []common.Address) (*DacattestationAttestedIterator, error) {
+
+	var memberRule []interface{}
+	for _, memberItem := range member {
+		memberRule = append(memberRule, memberItem)
+	}
+
+	logs, sub, err := _Dacattestation.contract.FilterLogs(opts, "Attested", memberRule)
+	if err != nil {
+		return nil, err
+	}
+	return &DacattestationAttestedIterator{contract: _Dacattestation.contract, event: "Attested", logs: logs, sub: sub}, nil
+}
+
+// WatchAttested is a free log subscription operation binding the contract event 0x3cbe3d5b038ec271314911df10c138784c5990cb5289c6d2d6ba0762cf28e89f.
+//
+// Solidity: event Attested(address indexed member, bytes32 root, uint64 fromBatch, uint64 toBatch, uint32 keyCount)
+func (_Dacattestation *DacattestationFilterer) WatchAttested(opts *bind.WatchOpts, sink chan<- *DacattestationAttested, member []common.Address) (event.Subscription, error) {
+
+	var memberRule []interface{}
+	for _, memberItem := range member {
+		memberRule = append(memberRule, memberItem)
+	}
+
+	logs, sub, err := _Dacattestation.contract.WatchLogs(opts, "Attested", memberRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(DacattestationAttested)
+				if err := _Dacattestation.contract.UnpackLog(event, "Attested", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAttested is a log parse operation binding the contract event 0x3cbe3d5b038ec271314911df10c138784c5990cb5289c6d2d6ba0762cf28e89f.
+//
+// Solidity: event Attested(address indexed member, bytes32 root, uint64 fromBatch, uint64 toBatch, uint32 keyCount)
+func (_Dacattestation *DacattestationFilterer) ParseAttested(log types.Log) (*DacattestationAttested, error) {
+	event := new(DacattestationAttested)
+	if err := _Dacattestation.contract.UnpackLog(event, "Attested", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
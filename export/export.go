@@ -0,0 +1,156 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// Format is the name of the format of the exports, the batch data a rollup node recovers its
+	// trusted state from
+	Format = "cdk-trusted-state"
+
+	// Version is the version of the format of the exports
+	Version = 1
+)
+
+// Header is the first line of an export, describing the chain and the batches exported
+type Header struct {
+	Format                 string          `json:"format"`
+	Version                uint            `json:"version"`
+	PolygonValidiumAddress common.Address  `json:"polygonValidiumAddress"`
+	DataCommitteeAddress   common.Address  `json:"dataCommitteeAddress"`
+	FromBatch              types.ArgUint64 `json:"fromBatch"`
+	// ToBatch is the last batch exported, 0 exports up to the last batch stored
+	ToBatch   types.ArgUint64 `json:"toBatch"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+// Record is a line of an export following the header, the L2 data of a batch. A batch sequenced again
+// after a reorg of L1 has a record for each of its values, the one matching the transactions hash of
+// the batch on L1 being the one to recover.
+type Record struct {
+	BatchNumber      types.ArgUint64 `json:"batchNumber"`
+	TransactionsHash common.Hash     `json:"transactionsHash"`
+	Size             int             `json:"size"`
+	BatchL2Data      types.ArgBytes  `json:"batchL2Data"`
+}
+
+// Exporter writes the stored values as the batch data of a rollup node, one JSON document per line,
+// ordered by batch number and then by key
+type Exporter struct {
+	db       db.DB
+	pageSize uint
+}
+
+// New returns an Exporter reading the stored values pageSize at a time
+func New(db db.DB, pageSize uint) *Exporter {
+	return &Exporter{
+		db:       db,
+		pageSize: pageSize,
+	}
+}
+
+// Export writes the header, then a record for each value stored for the batches of the header, and
+// returns the number of records written. The values not yet matched to a batch, with the batch
+// number 0, are not exported.
+func (e *Exporter) Export(ctx context.Context, w io.Writer, header Header) (uint64, error) {
+	header.Format, header.Version = Format, Version
+
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(header); err != nil {
+		return 0, err
+	}
+
+	var count uint64
+	for fromBatch := uint64(header.FromBatch); ; {
+		if fromBatch == 0 {
+			fromBatch = 1
+		}
+
+		keys, last, err := e.page(ctx, fromBatch, uint64(header.ToBatch))
+		if err != nil {
+			return count, err
+		}
+		if len(keys) == 0 {
+			return count, nil
+		}
+
+		hashes := make([]common.Hash, len(keys))
+		for i, key := range keys {
+			hashes[i] = key.Hash
+		}
+
+		list, err := e.db.ListOffChainData(ctx, hashes)
+		if err != nil {
+			return count, fmt.Errorf("failed to list the values from the batch %d: %w", fromBatch, err)
+		}
+
+		values := make(map[common.Hash][]byte, len(list))
+		for _, data := range list {
+			values[data.Key] = data.Value
+		}
+
+		for _, key := range keys {
+			value, ok := values[key.Hash]
+			if !ok || crypto.Keccak256Hash(value) != key.Hash {
+				return count, fmt.Errorf("the value %s of the batch %d is missing or corrupted",
+					key.Hash.Hex(), key.Number)
+			}
+
+			if err = enc.Encode(Record{
+				BatchNumber:      types.ArgUint64(key.Number),
+				TransactionsHash: key.Hash,
+				Size:             len(value),
+				BatchL2Data:      value,
+			}); err != nil {
+				return count, err
+			}
+			count++
+		}
+
+		if last {
+			return count, nil
+		}
+		fromBatch = keys[len(keys)-1].Number + 1
+	}
+}
+
+// page returns the keys of the whole batches listed from the given batch, and whether they are the
+// last ones to export
+func (e *Exporter) page(ctx context.Context, fromBatch, toBatch uint64) ([]types.BatchKey, bool, error) {
+	keys, err := e.db.GetOffChainDataKeys(ctx, fromBatch, e.pageSize)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get the keys from the batch %d: %w", fromBatch, err)
+	}
+
+	last := uint(len(keys)) < e.pageSize
+	if !last {
+		// more keys of the last batch listed may follow
+		lastBatch := keys[len(keys)-1].Number
+		for len(keys) > 0 && keys[len(keys)-1].Number == lastBatch {
+			keys = keys[:len(keys)-1]
+		}
+		if len(keys) == 0 {
+			return nil, false, fmt.Errorf("the batch %d has more than %d values", lastBatch, e.pageSize)
+		}
+	}
+
+	if toBatch > 0 {
+		for i, key := range keys {
+			if key.Number > toBatch {
+				return keys[:i], true, nil
+			}
+		}
+	}
+
+	return keys, last, nil
+}
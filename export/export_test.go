@@ -0,0 +1,138 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newValue returns a value and its key for the given batch
+func newValue(batchNum uint64, value string) (types.OffChainData, types.BatchKey) {
+	key := crypto.Keccak256Hash([]byte(value))
+	return types.OffChainData{Key: key, Value: []byte(value), BatchNum: batchNum},
+		types.BatchKey{Number: batchNum, Hash: key}
+}
+
+// readExport returns the header and the records of an export
+func readExport(t *testing.T, export []byte) (Header, []Record) {
+	t.Helper()
+
+	scanner := bufio.NewScanner(bytes.NewReader(export))
+	require.True(t, scanner.Scan())
+
+	var header Header
+	require.NoError(t, json.Unmarshal(scanner.Bytes(), &header))
+
+	records := make([]Record, 0)
+	for scanner.Scan() {
+		var record Record
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+
+	return header, records
+}
+
+func TestExporter_Export(t *testing.T) {
+	t.Parallel()
+
+	first, firstKey := newValue(1, "first")
+	second, secondKey := newValue(2, "second")
+	reorged, reorgedKey := newValue(2, "reorged")
+	third, thirdKey := newValue(3, "third")
+	header := Header{
+		PolygonValidiumAddress: common.HexToAddress("0x1"),
+		DataCommitteeAddress:   common.HexToAddress("0x2"),
+		CreatedAt:              time.Unix(1700000000, 0).UTC(),
+	}
+
+	t.Run("batches in order", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		// the batches cut by the page size are listed again with the next page
+		dbMock.On("GetOffChainDataKeys", mock.Anything, uint64(1), uint(3)).
+			Return([]types.BatchKey{firstKey, secondKey, reorgedKey}, nil).Once()
+		dbMock.On("ListOffChainData", mock.Anything, []common.Hash{firstKey.Hash}).
+			Return([]types.OffChainData{first}, nil).Once()
+		dbMock.On("GetOffChainDataKeys", mock.Anything, uint64(2), uint(3)).
+			Return([]types.BatchKey{secondKey, reorgedKey, thirdKey}, nil).Once()
+		dbMock.On("ListOffChainData", mock.Anything, []common.Hash{secondKey.Hash, reorgedKey.Hash}).
+			Return([]types.OffChainData{reorged, second}, nil).Once()
+		dbMock.On("GetOffChainDataKeys", mock.Anything, uint64(3), uint(3)).
+			Return([]types.BatchKey{thirdKey}, nil).Once()
+		dbMock.On("ListOffChainData", mock.Anything, []common.Hash{thirdKey.Hash}).
+			Return([]types.OffChainData{third}, nil).Once()
+
+		var buf bytes.Buffer
+		count, err := New(dbMock, 3).Export(context.Background(), &buf, header)
+		require.NoError(t, err)
+		require.Equal(t, uint64(4), count)
+
+		exported, records := readExport(t, buf.Bytes())
+		require.Equal(t, Format, exported.Format)
+		require.Equal(t, uint(Version), exported.Version)
+		require.Equal(t, header.DataCommitteeAddress, exported.DataCommitteeAddress)
+		require.Equal(t, []Record{
+			{BatchNumber: 1, TransactionsHash: firstKey.Hash, Size: 5, BatchL2Data: []byte("first")},
+			{BatchNumber: 2, TransactionsHash: secondKey.Hash, Size: 6, BatchL2Data: []byte("second")},
+			{BatchNumber: 2, TransactionsHash: reorgedKey.Hash, Size: 7, BatchL2Data: []byte("reorged")},
+			{BatchNumber: 3, TransactionsHash: thirdKey.Hash, Size: 5, BatchL2Data: []byte("third")},
+		}, records)
+	})
+
+	t.Run("up to a batch", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("GetOffChainDataKeys", mock.Anything, uint64(2), uint(3)).
+			Return([]types.BatchKey{secondKey, thirdKey}, nil).Once()
+		dbMock.On("ListOffChainData", mock.Anything, []common.Hash{secondKey.Hash}).
+			Return([]types.OffChainData{second}, nil).Once()
+
+		toSecond := header
+		toSecond.FromBatch, toSecond.ToBatch = 2, 2
+
+		var buf bytes.Buffer
+		count, err := New(dbMock, 3).Export(context.Background(), &buf, toSecond)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), count)
+	})
+
+	t.Run("corrupted value", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("GetOffChainDataKeys", mock.Anything, uint64(1), uint(3)).
+			Return([]types.BatchKey{firstKey}, nil).Once()
+		dbMock.On("ListOffChainData", mock.Anything, []common.Hash{firstKey.Hash}).
+			Return([]types.OffChainData{{Key: firstKey.Hash, Value: []byte("other"), BatchNum: 1}}, nil).Once()
+
+		_, err := New(dbMock, 3).Export(context.Background(), &bytes.Buffer{}, header)
+		require.ErrorContains(t, err, "missing or corrupted")
+	})
+
+	t.Run("batch larger than a page", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("GetOffChainDataKeys", mock.Anything, uint64(2), uint(2)).
+			Return([]types.BatchKey{secondKey, reorgedKey}, nil).Once()
+
+		fromSecond := header
+		fromSecond.FromBatch = 2
+
+		_, err := New(dbMock, 2).Export(context.Background(), &bytes.Buffer{}, fromSecond)
+		require.ErrorContains(t, err, "the batch 2 has more than 2 values")
+	})
+}
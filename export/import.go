@@ -0,0 +1,123 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// gzipMagic are the first bytes of a gzip stream
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// Importer loads an export into the database. The export is not trusted: every record is checked
+// against its transactions hash and size, and the import stops at the first record that does not match.
+type Importer struct {
+	db       db.DB
+	pageSize uint
+}
+
+// NewImporter returns an Importer storing the records of an export pageSize at a time
+func NewImporter(db db.DB, pageSize uint) *Importer {
+	return &Importer{
+		db:       db,
+		pageSize: pageSize,
+	}
+}
+
+// Import reads an export, compressed with gzip or not, of the chain of the given PolygonValidium
+// contract, stores its records, and returns its header and the number of records imported. The values
+// are recorded as imported from the given origin, e.g. the file of the export.
+func (i *Importer) Import(
+	ctx context.Context,
+	r io.Reader,
+	origin string,
+	polygonValidium common.Address,
+) (Header, uint64, error) {
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(len(gzipMagic)); err == nil && bytes.Equal(magic, gzipMagic) {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return Header{}, 0, err
+		}
+		defer zr.Close()
+		r = zr
+	} else {
+		r = br
+	}
+
+	dec := json.NewDecoder(r)
+
+	var header Header
+	if err := dec.Decode(&header); err != nil {
+		return header, 0, fmt.Errorf("failed to read the header: %w", err)
+	}
+	if header.Format != Format || header.Version != Version {
+		return header, 0, fmt.Errorf("unsupported format %s version %d", header.Format, header.Version)
+	}
+	if header.PolygonValidiumAddress != polygonValidium {
+		return header, 0, fmt.Errorf("the export is of the PolygonValidium contract %s, not %s",
+			header.PolygonValidiumAddress.Hex(), polygonValidium.Hex())
+	}
+
+	var count uint64
+	page := make([]types.OffChainData, 0, i.pageSize)
+	for {
+		var record Record
+		err := dec.Decode(&record)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return header, count, fmt.Errorf("failed to read the record %d: %w", count+uint64(len(page))+1, err)
+		}
+
+		if crypto.Keccak256Hash(record.BatchL2Data) != record.TransactionsHash ||
+			len(record.BatchL2Data) != record.Size {
+			return header, count, fmt.Errorf("the data of the batch %d does not match its transactions hash %s",
+				record.BatchNumber, record.TransactionsHash.Hex())
+		}
+
+		page = append(page, types.OffChainData{
+			Key:      record.TransactionsHash,
+			Value:    record.BatchL2Data,
+			BatchNum: uint64(record.BatchNumber),
+		})
+		if uint(len(page)) == i.pageSize {
+			if err = i.store(ctx, page, origin); err != nil {
+				return header, count, fmt.Errorf("failed to store the values up to the batch %d: %w",
+					record.BatchNumber, err)
+			}
+			count += uint64(len(page))
+			page = make([]types.OffChainData, 0, i.pageSize)
+		}
+	}
+
+	if len(page) > 0 {
+		if err := i.store(ctx, page, origin); err != nil {
+			return header, count, fmt.Errorf("failed to store the values up to the batch %d: %w",
+				page[len(page)-1].BatchNum, err)
+		}
+		count += uint64(len(page))
+	}
+
+	return header, count, nil
+}
+
+// store stores the values along with their provenance
+func (i *Importer) store(ctx context.Context, page []types.OffChainData, origin string) error {
+	if err := i.db.StoreOffChainData(ctx, page); err != nil {
+		return err
+	}
+
+	return i.db.StoreProvenance(ctx, types.NewProvenance(page, types.ProvenanceExport, origin))
+}
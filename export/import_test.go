@@ -0,0 +1,140 @@
+package export
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// writeExport returns an export of the given header and records
+func writeExport(t *testing.T, header Header, records ...Record) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	require.NoError(t, enc.Encode(header))
+	for _, record := range records {
+		require.NoError(t, enc.Encode(record))
+	}
+
+	return buf.Bytes()
+}
+
+// origin is the file the values are imported from
+const origin = "export.jsonl"
+
+// provenanceOf matches the provenance of the given values imported from the origin
+func provenanceOf(od []types.OffChainData) interface{} {
+	return mock.MatchedBy(func(provenance []types.Provenance) bool {
+		if len(provenance) != len(od) {
+			return false
+		}
+		for i := range od {
+			if provenance[i].Key != od[i].Key || provenance[i].Source != types.ProvenanceExport ||
+				provenance[i].Origin != origin {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func TestImporter_Import(t *testing.T) {
+	t.Parallel()
+
+	first, _ := newValue(1, "first")
+	second, _ := newValue(2, "second")
+	third, _ := newValue(3, "third")
+	validium := common.HexToAddress("0x1")
+	header := Header{Format: Format, Version: Version, PolygonValidiumAddress: validium}
+	record := func(data types.OffChainData) Record {
+		return Record{
+			BatchNumber:      types.ArgUint64(data.BatchNum),
+			TransactionsHash: data.Key,
+			Size:             len(data.Value),
+			BatchL2Data:      data.Value,
+		}
+	}
+
+	t.Run("records in pages", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("StoreOffChainData", mock.Anything, []types.OffChainData{first, second}).Return(nil).Once()
+		dbMock.On("StoreProvenance", mock.Anything, provenanceOf([]types.OffChainData{first, second})).
+			Return(nil).Once()
+		dbMock.On("StoreOffChainData", mock.Anything, []types.OffChainData{third}).Return(nil).Once()
+		dbMock.On("StoreProvenance", mock.Anything, provenanceOf([]types.OffChainData{third})).Return(nil).Once()
+
+		export := writeExport(t, header, record(first), record(second), record(third))
+		imported, count, err := NewImporter(dbMock, 2).
+			Import(context.Background(), bytes.NewReader(export), origin, validium)
+		require.NoError(t, err)
+		require.Equal(t, uint64(3), count)
+		require.Equal(t, validium, imported.PolygonValidiumAddress)
+	})
+
+	t.Run("compressed export", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("StoreOffChainData", mock.Anything, []types.OffChainData{first}).Return(nil).Once()
+		dbMock.On("StoreProvenance", mock.Anything, provenanceOf([]types.OffChainData{first})).Return(nil).Once()
+
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		_, err := zw.Write(writeExport(t, header, record(first)))
+		require.NoError(t, err)
+		require.NoError(t, zw.Close())
+
+		_, count, err := NewImporter(dbMock, 2).Import(context.Background(), &buf, origin, validium)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), count)
+	})
+
+	t.Run("export of another chain", func(t *testing.T) {
+		t.Parallel()
+
+		export := writeExport(t, header, record(first))
+		_, _, err := NewImporter(mocks.NewDB(t), 2).Import(context.Background(), bytes.NewReader(export),
+			origin, common.HexToAddress("0x2"))
+		require.ErrorContains(t, err, "the export is of the PolygonValidium contract")
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		t.Parallel()
+
+		newer := header
+		newer.Version = Version + 1
+
+		export := writeExport(t, newer)
+		_, _, err := NewImporter(mocks.NewDB(t), 2).
+			Import(context.Background(), bytes.NewReader(export), origin, validium)
+		require.ErrorContains(t, err, "unsupported format")
+	})
+
+	t.Run("record not matching its hash", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("StoreOffChainData", mock.Anything, []types.OffChainData{first}).Return(nil).Once()
+		dbMock.On("StoreProvenance", mock.Anything, provenanceOf([]types.OffChainData{first})).Return(nil).Once()
+
+		corrupted := record(second)
+		corrupted.BatchL2Data = []byte("other")
+
+		export := writeExport(t, header, record(first), corrupted, record(third))
+		_, count, err := NewImporter(dbMock, 1).
+			Import(context.Background(), bytes.NewReader(export), origin, validium)
+		require.ErrorContains(t, err, "the data of the batch 2 does not match")
+		require.Equal(t, uint64(1), count)
+	})
+}
@@ -0,0 +1,33 @@
+package fault
+
+import "github.com/0xPolygon/cdk-data-availability/config/types"
+
+// Config represents the configuration of the faults injected into the node, to validate its resilience before
+// relying on it. It must never be enabled in production.
+type Config struct {
+	// Enabled injects the faults of the rules
+	Enabled bool `mapstructure:"Enabled"`
+
+	// Seed of the random draws of the faults, so that a run injecting them can be reproduced. 0 seeds them randomly.
+	Seed int64 `mapstructure:"Seed"`
+
+	// Rules are the faults injected, every rule matching a point applying to it
+	Rules []Rule `mapstructure:"Rules"`
+}
+
+// Rule represents the faults injected at the points it matches
+type Rule struct {
+	// Point is the name of the points the rule applies to, e.g. db.StoreOffChainData, client.sync_getOffChainData
+	// or synchronizer.resolve. A trailing * matches any point starting with what precedes it, e.g. db.* or *.
+	Point string `mapstructure:"Point"`
+
+	// Latency delays the operations of the points
+	Latency types.Duration `mapstructure:"Latency"`
+
+	// ErrorRate is the ratio of the operations failing without being run, from 0 to 1
+	ErrorRate float64 `mapstructure:"ErrorRate"`
+
+	// DropRate is the ratio of the operations run successfully whose result is dropped, from 0 to 1, the caller
+	// failing as if the response had been lost
+	DropRate float64 `mapstructure:"DropRate"`
+}
@@ -0,0 +1,138 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/log"
+)
+
+var (
+	// ErrInjected is returned for the operations failed by a rule, without being run
+	ErrInjected = errors.New("injected fault")
+
+	// ErrDropped is returned for the operations whose result is dropped by a rule, once run
+	ErrDropped = errors.New("injected fault: the result was dropped")
+)
+
+// logger is the logger of the fault component
+var logger = log.WithComponent("fault")
+
+// current is the injector of the faults, nil when none is injected
+var current atomic.Pointer[injector]
+
+// injector draws the faults of the rules
+type injector struct {
+	rules []Rule
+
+	lock sync.Mutex
+	rand *rand.Rand
+}
+
+// Init injects the faults of the config at the points of the node: the calls of the client to the committee members
+// and the sequencer (client.<method>), the operations of the storage (db.<operation>), and the filtering of the L1
+// events (synchronizer.filterEvents) and the resolution of the batches (synchronizer.resolve) by the synchronizer.
+// Nothing is injected when disabled. It must be called before the storage is created for its faults to be injected.
+func Init(cfg Config) {
+	if !cfg.Enabled {
+		current.Store(nil)
+		return
+	}
+
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	current.Store(&injector{
+		rules: cfg.Rules,
+		rand:  rand.New(rand.NewSource(seed)), //nolint:gosec
+	})
+	logger.Warnf("faults are injected by %d rules with the seed %d, the node is not meant for production",
+		len(cfg.Rules), seed)
+}
+
+// Enabled tells whether faults are injected
+func Enabled() bool {
+	return current.Load() != nil
+}
+
+// Before applies the rules of the point before its operation is run: it waits for their latency, and fails with
+// ErrInjected when the operation must not be run. It fails with the error of the context if done while waiting.
+func Before(ctx context.Context, point string) error {
+	i := current.Load()
+	if i == nil {
+		return nil
+	}
+
+	var latency time.Duration
+	fail := false
+	for _, rule := range i.rules {
+		if !matches(rule.Point, point) {
+			continue
+		}
+		latency += rule.Latency.Duration
+		fail = fail || i.draw(rule.ErrorRate)
+	}
+
+	if latency > 0 {
+		timer := time.NewTimer(latency)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if fail {
+		logger.Debugf("failing %s", point)
+		return fmt.Errorf("%w at %s", ErrInjected, point)
+	}
+	return nil
+}
+
+// After applies the rules of the point once its operation is run with the given error: it returns the error, or
+// ErrDropped when the operation succeeded but its result must be dropped.
+func After(point string, err error) error {
+	i := current.Load()
+	if i == nil || err != nil {
+		return err
+	}
+
+	for _, rule := range i.rules {
+		if matches(rule.Point, point) && i.draw(rule.DropRate) {
+			logger.Debugf("dropping the result of %s", point)
+			return fmt.Errorf("%w at %s", ErrDropped, point)
+		}
+	}
+
+	return nil
+}
+
+// draw tells whether a fault of the given rate is injected
+func (i *injector) draw(rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	return i.rand.Float64() < rate
+}
+
+// matches tells whether the point of a rule matches the point
+func matches(pattern, point string) bool {
+	if strings.HasSuffix(pattern, "*") {
+		return strings.HasPrefix(point, strings.TrimSuffix(pattern, "*"))
+	}
+
+	return pattern == point
+}
@@ -0,0 +1,88 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/config/types"
+	"github.com/stretchr/testify/require"
+)
+
+// inject injects the faults of the rules until the end of the test
+func inject(t *testing.T, rules ...Rule) {
+	t.Helper()
+
+	Init(Config{Enabled: true, Seed: 1, Rules: rules})
+	t.Cleanup(func() { Init(Config{}) })
+}
+
+func TestInit(t *testing.T) {
+	Init(Config{Rules: []Rule{{Point: "*", ErrorRate: 1}}})
+	require.False(t, Enabled())
+	require.NoError(t, Before(context.Background(), "db.GetOffChainData"))
+	require.NoError(t, After("db.GetOffChainData", nil))
+
+	inject(t)
+	require.True(t, Enabled())
+}
+
+func TestBefore(t *testing.T) {
+	inject(t,
+		Rule{Point: "db.*", ErrorRate: 1},
+		Rule{Point: "client.sync_getOffChainData", Latency: types.NewDuration(20 * time.Millisecond)},
+		Rule{Point: "client.*", Latency: types.NewDuration(10 * time.Millisecond)},
+	)
+	ctx := context.Background()
+
+	err := Before(ctx, "db.StoreOffChainData")
+	require.ErrorIs(t, err, ErrInjected)
+	require.EqualError(t, err, "injected fault at db.StoreOffChainData")
+	require.NoError(t, Before(ctx, "synchronizer.resolve"))
+
+	// the latencies of all the rules matching the point add up
+	start := time.Now()
+	require.NoError(t, Before(ctx, "client.sync_getOffChainData"))
+	require.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	require.ErrorIs(t, Before(cancelled, "client.sync_getOffChainData"), context.Canceled)
+}
+
+func TestAfter(t *testing.T) {
+	inject(t, Rule{Point: "client.datacom_signSequence", DropRate: 1})
+
+	err := After("client.datacom_signSequence", nil)
+	require.ErrorIs(t, err, ErrDropped)
+	require.EqualError(t, err, "injected fault: the result was dropped at client.datacom_signSequence")
+
+	// the errors of the operations are returned as is
+	failed := errors.New("failed")
+	require.Equal(t, failed, After("client.datacom_signSequence", failed))
+	require.NoError(t, After("client.sync_getOffChainData", nil))
+}
+
+func TestRates(t *testing.T) {
+	inject(t, Rule{Point: "synchronizer.resolve", ErrorRate: 0.5})
+
+	failed := 0
+	for i := 0; i < 1000; i++ {
+		if Before(context.Background(), "synchronizer.resolve") != nil {
+			failed++
+		}
+	}
+	require.InDelta(t, 500, failed, 100)
+
+	// the faults drawn with a seed are reproduced
+	draw := func() []bool {
+		inject(t, Rule{Point: "synchronizer.resolve", ErrorRate: 0.5})
+		draws := make([]bool, 20)
+		for i := range draws {
+			draws[i] = Before(context.Background(), "synchronizer.resolve") != nil
+		}
+		return draws
+	}
+	require.Equal(t, draw(), draw())
+}
@@ -0,0 +1,371 @@
+package gossip
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/client"
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/metrics"
+	"github.com/0xPolygon/cdk-data-availability/pkg/replay"
+	"github.com/0xPolygon/cdk-data-availability/reporter"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/0xPolygon/cdk-data-availability/webhook"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// logger is the logger of the gossip component
+var logger = log.WithComponent("gossip")
+
+// queueSize is the number of announcements, sent or received, waiting to be processed.
+// Further ones are dropped, the synchronizer resolves the values anyway.
+const queueSize = 256
+
+var (
+	// ErrDisabled is returned for the announcements received while the gossip is disabled
+	ErrDisabled = errors.New("gossip is disabled")
+	// ErrNotMember is returned for the announcements not signed by a committee member
+	ErrNotMember = errors.New("announcement not signed by a committee member")
+	// ErrTooManyKeys is returned for the announcements of more keys than allowed
+	ErrTooManyKeys = errors.New("too many keys announced")
+	// ErrQueueFull is returned for the announcements received while too many are pending
+	ErrQueueFull = errors.New("too many announcements pending")
+)
+
+// gossip is the Gossip announcing the stored values, if any
+var gossip atomic.Pointer[Gossip]
+
+// received is an announcement received from a member
+type received struct {
+	member etherman.DataCommitteeMember
+	keys   []common.Hash
+}
+
+// Gossip replicates the values between the committee members. The keys of the values stored
+// by the node are announced to the other members, signed with the key of the node, and the
+// members fetch the values they miss directly from the node. The values are verified against
+// their keys, and stored until the synchronizer finds their batch on L1.
+type Gossip struct {
+	cfg       config.GossipConfig
+	pk        *ecdsa.PrivateKey
+	self      common.Address
+	db        db.DB
+	etherman  etherman.Etherman
+	factory   client.Factory
+	outgoing  chan []common.Hash
+	incoming  chan received
+	stop      chan struct{}
+	lock      sync.RWMutex
+	committee map[common.Address]etherman.DataCommitteeMember
+	replay    *replay.Guard
+	// maxValueSize bounds the size of the values fetched, 0 for no limit
+	maxValueSize uint64
+
+	settingsLock     sync.Mutex
+	committeeRefresh time.Duration
+	// reloaded is signalled when the refresh interval of the committee changes at runtime
+	reloaded chan struct{}
+}
+
+// New returns a Gossip announcing the values signed with the given key, and fetching the values of at most
+// limits.MaxValueSize bytes
+func New(
+	cfg config.GossipConfig,
+	limits config.LimitsConfig,
+	pk *ecdsa.PrivateKey,
+	db db.DB,
+	em etherman.Etherman,
+	factory client.Factory,
+	guard *replay.Guard,
+) *Gossip {
+	return &Gossip{
+		cfg:       cfg,
+		pk:        pk,
+		self:      crypto.PubkeyToAddress(pk.PublicKey),
+		db:        db,
+		etherman:  em,
+		factory:   factory,
+		outgoing:  make(chan []common.Hash, queueSize),
+		incoming:  make(chan received, queueSize),
+		stop:      make(chan struct{}),
+		committee: make(map[common.Address]etherman.DataCommitteeMember),
+		replay:    guard,
+
+		maxValueSize: limits.MaxValueSize,
+
+		committeeRefresh: cfg.CommitteeRefresh.Duration,
+		reloaded:         make(chan struct{}, 1),
+	}
+}
+
+// Announce announces the keys of the values newly stored by the node, if the gossip is running
+func Announce(keys []common.Hash) {
+	if g := gossip.Load(); g != nil && len(keys) > 0 {
+		g.Announce(keys)
+	}
+}
+
+// Receive handles an announcement received from a member, if the gossip is running
+func Receive(announcement types.KeyAnnouncement) error {
+	g := gossip.Load()
+	if g == nil {
+		return ErrDisabled
+	}
+
+	return g.Receive(announcement)
+}
+
+// Start processes the announcements until the gossip is stopped
+func (g *Gossip) Start(ctx context.Context) {
+	defer reporter.Recover()
+
+	logger.Info("starting gossip")
+	if err := g.refreshCommittee(); err != nil {
+		logger.Errorf("failed to load the committee: %v", err)
+	}
+	gossip.Store(g)
+	defer gossip.CompareAndSwap(g, nil)
+
+	ticker := time.NewTicker(g.refreshInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-g.reloaded:
+			ticker.Reset(g.refreshInterval())
+		case keys := <-g.outgoing:
+			g.send(ctx, keys)
+		case r := <-g.incoming:
+			if err := g.fetch(ctx, r); err != nil {
+				logger.WithFields(log.FieldMemberAddr, r.member.Addr.Hex(), log.FieldMemberURL, r.member.URL).
+					Warnf("failed to fetch the announced values: %v", err)
+			}
+		case <-ticker.C:
+			if err := g.refreshCommittee(); err != nil {
+				logger.Errorf("failed to load the committee: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		case <-g.stop:
+			return
+		}
+	}
+}
+
+// Stop stops the gossip
+func (g *Gossip) Stop() {
+	close(g.stop)
+}
+
+// Reload changes the refresh interval of the committee at runtime
+func (g *Gossip) Reload(cfg config.GossipConfig) {
+	g.settingsLock.Lock()
+	defer g.settingsLock.Unlock()
+
+	if cfg.CommitteeRefresh.Duration <= 0 || cfg.CommitteeRefresh.Duration == g.committeeRefresh {
+		return
+	}
+	logger.Infof("gossip committee refresh changed from %v to %v", g.committeeRefresh, cfg.CommitteeRefresh.Duration)
+	g.committeeRefresh = cfg.CommitteeRefresh.Duration
+
+	select {
+	case g.reloaded <- struct{}{}:
+	default:
+	}
+}
+
+func (g *Gossip) refreshInterval() time.Duration {
+	g.settingsLock.Lock()
+	defer g.settingsLock.Unlock()
+	return g.committeeRefresh
+}
+
+// Announce queues the keys to be announced, split in announcements of up to MaxKeys
+func (g *Gossip) Announce(keys []common.Hash) {
+	for start := 0; start < len(keys); start += int(g.cfg.MaxKeys) {
+		end := start + int(g.cfg.MaxKeys)
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		select {
+		case g.outgoing <- keys[start:end]:
+		default:
+			metrics.GossipAnnouncement(metrics.GossipSent, metrics.GossipDropped)
+		}
+	}
+}
+
+// Receive verifies an announcement and queues the announced values to be fetched
+func (g *Gossip) Receive(announcement types.KeyAnnouncement) error {
+	if uint(len(announcement.Keys)) > g.cfg.MaxKeys {
+		metrics.GossipAnnouncement(metrics.GossipReceived, metrics.GossipRejected)
+		return ErrTooManyKeys
+	}
+
+	signer, err := announcement.Signer()
+	if err != nil {
+		metrics.GossipAnnouncement(metrics.GossipReceived, metrics.GossipRejected)
+		return err
+	}
+
+	g.lock.RLock()
+	member, ok := g.committee[signer]
+	g.lock.RUnlock()
+	if !ok || signer == g.self {
+		metrics.GossipAnnouncement(metrics.GossipReceived, metrics.GossipRejected)
+		return ErrNotMember
+	}
+
+	hash := common.BytesToHash(announcement.HashToSign())
+	if err = g.replay.Check(hash, uint64(announcement.Timestamp)); err != nil {
+		metrics.GossipAnnouncement(metrics.GossipReceived, metrics.GossipRejected)
+		return err
+	}
+
+	select {
+	case g.incoming <- received{member: member, keys: announcement.Keys}:
+		metrics.GossipAnnouncement(metrics.GossipReceived, metrics.ResultSuccess)
+		return nil
+	default:
+		metrics.GossipAnnouncement(metrics.GossipReceived, metrics.GossipDropped)
+		return ErrQueueFull
+	}
+}
+
+// send signs an announcement of the keys and sends it to every other member
+func (g *Gossip) send(parentCtx context.Context, keys []common.Hash) {
+	announcement := types.KeyAnnouncement{Keys: keys, Timestamp: types.ArgUint64(time.Now().Unix())}
+	if err := announcement.Sign(g.pk); err != nil {
+		logger.Errorf("failed to sign the announcement: %v", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, g.cfg.Timeout.Duration)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for _, member := range g.members() {
+		wg.Add(1)
+		go func(member etherman.DataCommitteeMember) {
+			defer wg.Done()
+
+			err := g.factory.New(member.URL).AnnounceKeys(ctx, announcement)
+			metrics.GossipAnnouncement(metrics.GossipSent, metrics.Result(err))
+			if err != nil {
+				logger.WithFields(log.FieldMemberAddr, member.Addr.Hex(), log.FieldMemberURL, member.URL).
+					Debugf("failed to announce %d keys: %v", len(keys), err)
+			}
+		}(member)
+	}
+	wg.Wait()
+}
+
+// fetch fetches the announced values missing locally from the member announcing them
+func (g *Gossip) fetch(parentCtx context.Context, r received) error {
+	ctx, cancel := context.WithTimeout(parentCtx, g.cfg.Timeout.Duration)
+	defer cancel()
+
+	existing, err := g.db.ListOffChainData(ctx, r.keys)
+	if err != nil {
+		return err
+	}
+
+	stored := make(map[common.Hash]struct{}, len(existing))
+	for _, data := range existing {
+		stored[data.Key] = struct{}{}
+	}
+
+	missing := make([]common.Hash, 0, len(r.keys))
+	for _, key := range r.keys {
+		if _, ok := stored[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	values, err := g.factory.New(r.member.URL).ListOffChainData(ctx, missing)
+	if err != nil {
+		return err
+	}
+
+	data := make([]types.OffChainData, 0, len(missing))
+	for _, key := range missing {
+		value, ok := values[key]
+		if !ok {
+			continue
+		}
+		// the member may be malicious or faulty, the values are checked before being hashed or stored
+		if err = types.CheckValueSize(value, g.maxValueSize); err != nil {
+			return fmt.Errorf("value of %s returned for key %s: %w", r.member.Addr.Hex(), key.Hex(), err)
+		}
+		if crypto.Keccak256Hash(value) != key {
+			return fmt.Errorf("unexpected value returned for key %s", key.Hex())
+		}
+
+		// the batch number is set by the synchronizer once the batch is found on L1
+		data = append(data, types.OffChainData{Key: key, Value: value})
+	}
+	if len(data) == 0 {
+		return nil
+	}
+
+	if err = g.db.StoreOffChainData(ctx, data); err != nil {
+		return err
+	}
+	provenance := types.NewProvenance(data, types.ProvenanceGossip, r.member.Addr.Hex())
+	if err = g.db.StoreProvenance(ctx, provenance); err != nil {
+		return err
+	}
+	webhook.DataStored(data)
+
+	metrics.GossipFetched(len(data))
+	logger.WithFields(log.FieldMemberAddr, r.member.Addr.Hex()).Debugf("fetched %d announced values", len(data))
+
+	return nil
+}
+
+// refreshCommittee loads the committee members from L1
+func (g *Gossip) refreshCommittee() error {
+	members, err := g.etherman.GetCurrentDataCommitteeMembers()
+	if err != nil {
+		return err
+	}
+
+	committee := make(map[common.Address]etherman.DataCommitteeMember, len(members))
+	for _, member := range members {
+		committee[member.Addr] = member
+	}
+
+	g.lock.Lock()
+	g.committee = committee
+	g.lock.Unlock()
+
+	return nil
+}
+
+// members returns the other committee members
+func (g *Gossip) members() []etherman.DataCommitteeMember {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	members := make([]etherman.DataCommitteeMember, 0, len(g.committee))
+	for _, member := range g.committee {
+		if member.Addr != g.self && member.URL != "" {
+			members = append(members, member)
+		}
+	}
+
+	return members
+}
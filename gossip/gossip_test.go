@@ -0,0 +1,219 @@
+package gossip
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/config"
+	cfgTypes "github.com/0xPolygon/cdk-data-availability/config/types"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/pkg/replay"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig() config.GossipConfig {
+	return config.GossipConfig{
+		Enabled:          true,
+		MaxKeys:          2,
+		Timeout:          cfgTypes.NewDuration(5 * time.Second),
+		CommitteeRefresh: cfgTypes.NewDuration(time.Minute),
+	}
+}
+
+// newTestGossip returns a Gossip of the first key, in a committee of every key
+func newTestGossip(t *testing.T, keys []*ecdsa.PrivateKey, dbMock *mocks.DB, factory *mocks.ClientFactory) *Gossip {
+	t.Helper()
+
+	members := make([]etherman.DataCommitteeMember, len(keys))
+	for i, key := range keys {
+		members[i] = etherman.DataCommitteeMember{
+			Addr: crypto.PubkeyToAddress(key.PublicKey),
+			URL:  "http://member-" + string(rune('a'+i)),
+		}
+	}
+
+	em := mocks.NewEtherman(t)
+	em.On("GetCurrentDataCommitteeMembers").Return(members, nil).Once()
+
+	g := New(testConfig(), config.LimitsConfig{MaxValueSize: 16}, keys[0], dbMock, em, factory, replay.New(time.Minute, false))
+	require.NoError(t, g.refreshCommittee())
+
+	return g
+}
+
+func generateKeys(t *testing.T, count int) []*ecdsa.PrivateKey {
+	t.Helper()
+
+	keys := make([]*ecdsa.PrivateKey, count)
+	for i := range keys {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		keys[i] = key
+	}
+
+	return keys
+}
+
+func TestGossip_Reload(t *testing.T) {
+	refreshed := make(chan struct{}, 1)
+	em := mocks.NewEtherman(t)
+	em.On("GetCurrentDataCommitteeMembers").Return(nil, errors.New("unavailable")).
+		Run(func(mock.Arguments) {
+			select {
+			case refreshed <- struct{}{}:
+			default:
+			}
+		})
+
+	cfg := testConfig()
+	cfg.CommitteeRefresh = cfgTypes.NewDuration(time.Hour)
+	g := New(cfg, config.LimitsConfig{}, generateKeys(t, 1)[0], mocks.NewDB(t), em, mocks.NewClientFactory(t),
+		replay.New(time.Minute, false))
+	go g.Start(context.Background())
+	defer g.Stop()
+	<-refreshed
+
+	// zero values are ignored
+	g.Reload(config.GossipConfig{})
+	require.Equal(t, time.Hour, g.refreshInterval())
+
+	// the committee is refreshed at the new interval without a restart
+	g.Reload(config.GossipConfig{CommitteeRefresh: cfgTypes.NewDuration(10 * time.Millisecond)})
+	select {
+	case <-refreshed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the committee was not refreshed at the new interval")
+	}
+}
+
+func TestGossip_Receive(t *testing.T) {
+	keys := generateKeys(t, 3)
+	outsider := generateKeys(t, 1)[0]
+	g := newTestGossip(t, keys, nil, nil)
+
+	announce := func(key *ecdsa.PrivateKey, hashes ...common.Hash) error {
+		a := types.KeyAnnouncement{Keys: hashes}
+		require.NoError(t, a.Sign(key))
+		return g.Receive(a)
+	}
+
+	require.NoError(t, announce(keys[1], common.HexToHash("0x1")))
+	r := <-g.incoming
+	require.Equal(t, crypto.PubkeyToAddress(keys[1].PublicKey), r.member.Addr)
+	require.Equal(t, []common.Hash{common.HexToHash("0x1")}, r.keys)
+
+	require.ErrorIs(t, announce(outsider, common.HexToHash("0x1")), ErrNotMember)
+	require.ErrorIs(t, announce(keys[0], common.HexToHash("0x1")), ErrNotMember)
+	require.ErrorIs(t, announce(keys[1], common.HexToHash("0x1"), common.HexToHash("0x2"), common.HexToHash("0x3")),
+		ErrTooManyKeys)
+	require.Error(t, g.Receive(types.KeyAnnouncement{Keys: []common.Hash{common.HexToHash("0x1")}}))
+
+	// an announcement signed with a timestamp is accepted once
+	a := types.KeyAnnouncement{Keys: []common.Hash{common.HexToHash("0x2")}, Timestamp: types.ArgUint64(time.Now().Unix())}
+	require.NoError(t, a.Sign(keys[1]))
+	require.NoError(t, g.Receive(a))
+	<-g.incoming
+	require.ErrorIs(t, g.Receive(a), replay.ErrReplayed)
+}
+
+func TestGossip_Announce(t *testing.T) {
+	keys := generateKeys(t, 3)
+	factory := mocks.NewClientFactory(t)
+	g := newTestGossip(t, keys, nil, factory)
+
+	hashes := []common.Hash{common.HexToHash("0x1"), common.HexToHash("0x2"), common.HexToHash("0x3")}
+	g.Announce(hashes)
+	require.Len(t, g.outgoing, 2)
+
+	// a member failing does not prevent the others from being announced the keys
+	results := map[string]error{"http://member-b": nil, "http://member-c": errors.New("member unavailable")}
+	for url, err := range results {
+		member := mocks.NewClient(t)
+		member.On("AnnounceKeys", mock.Anything, mock.MatchedBy(func(a types.KeyAnnouncement) bool {
+			signer, err := a.Signer()
+			return err == nil && signer == crypto.PubkeyToAddress(keys[0].PublicKey) && len(a.Keys) == 2 &&
+				a.Timestamp > 0
+		})).Return(err).Once()
+		factory.On("New", url).Return(member).Once()
+	}
+
+	g.send(context.Background(), <-g.outgoing)
+}
+
+func TestGossip_Fetch(t *testing.T) {
+	keys := generateKeys(t, 2)
+	stored := []byte("stored")
+	missing := []byte("missing")
+	member := etherman.DataCommitteeMember{Addr: crypto.PubkeyToAddress(keys[1].PublicKey), URL: "http://member-b"}
+	announced := []common.Hash{crypto.Keccak256Hash(stored), crypto.Keccak256Hash(missing)}
+
+	t.Run("missing values fetched", func(t *testing.T) {
+		dbMock := mocks.NewDB(t)
+		dbMock.On("ListOffChainData", mock.Anything, announced).
+			Return([]types.OffChainData{{Key: announced[0], Value: stored}}, nil).Once()
+		dbMock.On("StoreOffChainData", mock.Anything, []types.OffChainData{{Key: announced[1], Value: missing}}).
+			Return(nil).Once()
+		dbMock.On("StoreProvenance", mock.Anything, mock.MatchedBy(func(provenance []types.Provenance) bool {
+			return len(provenance) == 1 && provenance[0].Key == announced[1] &&
+				provenance[0].Source == types.ProvenanceGossip && provenance[0].Origin == member.Addr.Hex()
+		})).Return(nil).Once()
+
+		client := mocks.NewClient(t)
+		client.On("ListOffChainData", mock.Anything, []common.Hash{announced[1]}).
+			Return(map[common.Hash][]byte{announced[1]: missing}, nil).Once()
+		factory := mocks.NewClientFactory(t)
+		factory.On("New", member.URL).Return(client).Once()
+
+		g := newTestGossip(t, keys, dbMock, factory)
+		require.NoError(t, g.fetch(context.Background(), received{member: member, keys: announced}))
+	})
+
+	t.Run("nothing missing", func(t *testing.T) {
+		dbMock := mocks.NewDB(t)
+		dbMock.On("ListOffChainData", mock.Anything, announced[:1]).
+			Return([]types.OffChainData{{Key: announced[0], Value: stored}}, nil).Once()
+
+		g := newTestGossip(t, keys, dbMock, mocks.NewClientFactory(t))
+		require.NoError(t, g.fetch(context.Background(), received{member: member, keys: announced[:1]}))
+	})
+
+	t.Run("unexpected value returned", func(t *testing.T) {
+		dbMock := mocks.NewDB(t)
+		dbMock.On("ListOffChainData", mock.Anything, announced[1:]).Return(nil, nil).Once()
+
+		client := mocks.NewClient(t)
+		client.On("ListOffChainData", mock.Anything, announced[1:]).
+			Return(map[common.Hash][]byte{announced[1]: stored}, nil).Once()
+		factory := mocks.NewClientFactory(t)
+		factory.On("New", member.URL).Return(client).Once()
+
+		g := newTestGossip(t, keys, dbMock, factory)
+		err := g.fetch(context.Background(), received{member: member, keys: announced[1:]})
+		require.ErrorContains(t, err, "unexpected value returned")
+	})
+
+	t.Run("value too large", func(t *testing.T) {
+		large := []byte("more than 16 bytes")
+		key := crypto.Keccak256Hash(large)
+		dbMock := mocks.NewDB(t)
+		dbMock.On("ListOffChainData", mock.Anything, []common.Hash{key}).Return(nil, nil).Once()
+
+		client := mocks.NewClient(t)
+		client.On("ListOffChainData", mock.Anything, []common.Hash{key}).
+			Return(map[common.Hash][]byte{key: large}, nil).Once()
+		factory := mocks.NewClientFactory(t)
+		factory.On("New", member.URL).Return(client).Once()
+
+		g := newTestGossip(t, keys, dbMock, factory)
+		err := g.fetch(context.Background(), received{member: member, keys: []common.Hash{key}})
+		require.ErrorContains(t, err, "18 bytes, more than the maximum of 16")
+	})
+}
@@ -0,0 +1,135 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/graph-gophers/graphql-go"
+)
+
+// logger is the logger of the graphql component
+var logger = log.WithComponent("graphql")
+
+const (
+	// maxDepth bounds the nesting of the queries
+	maxDepth = 10
+
+	// maxRequestSize bounds the size of the body of the queries
+	maxRequestSize = 1 << 20
+
+	// readHeaderTimeout bounds the time to read the headers of a query
+	readHeaderTimeout = 10 * time.Second
+)
+
+// request is the body of a GraphQL query
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Handler serves the read-only GraphQL queries over the stored data at /graphql
+type Handler struct {
+	schema  *graphql.Schema
+	timeout time.Duration
+}
+
+// NewHandler returns the Handler of the GraphQL queries
+func NewHandler(cfg config.GraphQLConfig, db db.DB) (*Handler, error) {
+	resolver := &Resolver{
+		cfg:       cfg,
+		db:        db,
+		startTime: time.Now(),
+	}
+
+	schema, err := graphql.ParseSchema(schema, resolver, graphql.MaxDepth(maxDepth))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the GraphQL schema: %w", err)
+	}
+
+	return &Handler{schema: schema, timeout: cfg.Timeout.Duration}, nil
+}
+
+// ServeHTTP executes the query posted, explorer frontends can query it from any origin
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding")
+
+	if r.Method == http.MethodOptions {
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "method "+r.Method+" not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req request
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&req); err != nil {
+		http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	res := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	body, err := json.Marshal(res)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(body); err != nil {
+		logger.Errorf("failed to write the response: %v", err)
+	}
+}
+
+// Server serves the GraphQL queries on a dedicated listener
+type Server struct {
+	srv *http.Server
+}
+
+// NewServer returns the GraphQL server
+func NewServer(cfg config.GraphQLConfig, db db.DB) (*Server, error) {
+	handler, err := NewHandler(cfg, db)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/graphql", handler)
+
+	return &Server{
+		srv: &http.Server{
+			Addr:              fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+			Handler:           mux,
+			ReadHeaderTimeout: readHeaderTimeout,
+			WriteTimeout:      cfg.Timeout.Duration + readHeaderTimeout,
+		},
+	}, nil
+}
+
+// Start serves the GraphQL queries until the server is stopped
+func (s *Server) Start() error {
+	logger.Infof("graphql server started: %s", s.srv.Addr)
+	if err := s.srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// Stop shuts down the GraphQL server
+func (s *Server) Stop() error {
+	return s.srv.Shutdown(context.Background())
+}
@@ -0,0 +1,141 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/config"
+	cfgTypes "github.com/0xPolygon/cdk-data-availability/config/types"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// response is the body of the response to a query
+type response struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func query(t *testing.T, dbMock db.DB, q string) response {
+	t.Helper()
+
+	handler, err := NewHandler(config.GraphQLConfig{
+		MaxBatchRange: 10,
+		MaxResults:    2,
+		Timeout:       cfgTypes.NewDuration(time.Second),
+	}, dbMock)
+	require.NoError(t, err)
+
+	body, err := json.Marshal(request{Query: q})
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body)))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var res response
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+
+	return res
+}
+
+func TestHandler_Data(t *testing.T) {
+	key := common.HexToHash("0x1")
+
+	t.Run("stored", func(t *testing.T) {
+		dbMock := mocks.NewDB(t)
+		dbMock.On("GetOffChainData", mock.Anything, key).
+			Return(&types.OffChainData{Key: key, Value: []byte{1, 2}, BatchNum: 16}, nil).Once()
+
+		res := query(t, dbMock, `{ data(key: "`+key.Hex()+`") { key value batchNumber size } }`)
+		require.Empty(t, res.Errors)
+		require.Equal(t, map[string]interface{}{
+			"key": key.Hex(), "value": "0x0102", "batchNumber": "0x10", "size": float64(2),
+		}, res.Data["data"])
+	})
+
+	t.Run("not stored", func(t *testing.T) {
+		dbMock := mocks.NewDB(t)
+		dbMock.On("GetOffChainData", mock.Anything, key).Return(nil, db.ErrStateNotSynchronized).Once()
+
+		res := query(t, dbMock, `{ data(key: "`+key.Hex()+`") { key } }`)
+		require.Empty(t, res.Errors)
+		require.Nil(t, res.Data["data"])
+	})
+}
+
+func TestHandler_Batches(t *testing.T) {
+	t.Run("values of the range", func(t *testing.T) {
+		dbMock := mocks.NewDB(t)
+		dbMock.On("ListOffChainDataByBatches", mock.Anything, uint64(5), uint64(6), uint(3)).
+			Return([]types.OffChainData{
+				{Key: common.HexToHash("0x1"), Value: []byte{1}, BatchNum: 5},
+				{Key: common.HexToHash("0x2"), Value: []byte{2}, BatchNum: 6},
+			}, nil).Once()
+
+		res := query(t, dbMock, `{ batches(from: "0x5", to: "0x6") { batchNumber } }`)
+		require.Empty(t, res.Errors)
+		require.Equal(t, []interface{}{
+			map[string]interface{}{"batchNumber": "0x5"},
+			map[string]interface{}{"batchNumber": "0x6"},
+		}, res.Data["batches"])
+	})
+
+	t.Run("too many values", func(t *testing.T) {
+		dbMock := mocks.NewDB(t)
+		dbMock.On("ListOffChainDataByBatches", mock.Anything, uint64(5), uint64(5), uint(3)).
+			Return(make([]types.OffChainData, 3), nil).Once()
+
+		res := query(t, dbMock, `{ batch(number: "0x5") { key } }`)
+		require.Len(t, res.Errors, 1)
+		require.Contains(t, res.Errors[0].Message, "narrow the range")
+	})
+
+	t.Run("range too large", func(t *testing.T) {
+		res := query(t, mocks.NewDB(t), `{ batches(from: "0x1", to: "0x20") { key } }`)
+		require.Len(t, res.Errors, 1)
+		require.Contains(t, res.Errors[0].Message, "too many batches")
+	})
+}
+
+func TestHandler_CommitteeHistory(t *testing.T) {
+	member := common.HexToAddress("0xabc")
+	dbMock := mocks.NewDB(t)
+	dbMock.On("ListCommitteeChanges", mock.Anything, uint64(0), uint(2)).
+		Return([]types.CommitteeChange{
+			{ID: 1, BlockNumber: 10, Kind: types.CommitteeMemberAdded, Member: member},
+			{ID: 2, BlockNumber: 20, Kind: types.CommitteeMemberAdded, Member: common.HexToAddress("0xdef")},
+		}, nil).Once()
+	dbMock.On("ListCommitteeChanges", mock.Anything, uint64(3), uint(2)).
+		Return([]types.CommitteeChange{
+			{ID: 3, BlockNumber: 30, Kind: types.CommitteeMemberURLChanged, Member: member, URL: "http://new"},
+			{ID: 4, BlockNumber: 40, Kind: types.CommitteeMemberRemoved, Member: member},
+		}, nil).Once()
+
+	res := query(t, dbMock, `{ committeeHistory(toBlock: "0x1e", member: "`+member.Hex()+`") { id kind url } }`)
+	require.Empty(t, res.Errors)
+	require.Equal(t, []interface{}{
+		map[string]interface{}{"id": "0x1", "kind": "added", "url": ""},
+		map[string]interface{}{"id": "0x3", "kind": "url_changed", "url": "http://new"},
+	}, res.Data["committeeHistory"])
+}
+
+func TestHandler_Status(t *testing.T) {
+	dbMock := mocks.NewDB(t)
+	dbMock.On("CountOffchainData", mock.Anything).Return(uint64(7), nil).Once()
+	dbMock.On("GetLastProcessedBlock", mock.Anything, "L1").Return(uint64(100), nil).Once()
+
+	res := query(t, dbMock, `{ status { keyCount backfillProgress } }`)
+	require.Empty(t, res.Errors)
+	require.Equal(t, map[string]interface{}{"keyCount": "0x7", "backfillProgress": "0x64"}, res.Data["status"])
+}
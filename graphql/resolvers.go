@@ -0,0 +1,250 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	dataavailability "github.com/0xPolygon/cdk-data-availability"
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/synchronizer"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/graph-gophers/graphql-go"
+)
+
+// errInternal is returned instead of the errors of the database, which are only logged
+var errInternal = errors.New("internal error")
+
+// Resolver resolves the queries of the schema
+type Resolver struct {
+	cfg       config.GraphQLConfig
+	db        db.DB
+	startTime time.Time
+}
+
+// Data resolves the value of a key, or nil if it is not stored
+func (r *Resolver) Data(ctx context.Context, args struct{ Key common.Hash }) (*dataResolver, error) {
+	data, err := r.db.GetOffChainData(ctx, args.Key)
+	if errors.Is(err, db.ErrStateNotSynchronized) {
+		return nil, nil
+	}
+	if err != nil {
+		logger.Errorf("failed to get the data of %s: %v", args.Key.Hex(), err)
+		return nil, errInternal
+	}
+
+	return &dataResolver{data: *data}, nil
+}
+
+// DataByKeys resolves the values of the keys that are stored
+func (r *Resolver) DataByKeys(ctx context.Context, args struct{ Keys []common.Hash }) ([]*dataResolver, error) {
+	if uint(len(args.Keys)) > r.cfg.MaxResults {
+		return nil, fmt.Errorf("too many keys, at most %d are allowed", r.cfg.MaxResults)
+	}
+
+	list, err := r.db.ListOffChainData(ctx, args.Keys)
+	if err != nil {
+		logger.Errorf("failed to list the data of %d keys: %v", len(args.Keys), err)
+		return nil, errInternal
+	}
+
+	return newDataResolvers(list), nil
+}
+
+// Batch resolves the values stored for a batch
+func (r *Resolver) Batch(ctx context.Context, args struct{ Number hexutil.Uint64 }) ([]*dataResolver, error) {
+	return r.Batches(ctx, struct{ From, To hexutil.Uint64 }{args.Number, args.Number})
+}
+
+// Batches resolves the values stored for a range of batches
+func (r *Resolver) Batches(ctx context.Context, args struct{ From, To hexutil.Uint64 }) ([]*dataResolver, error) {
+	from, to := uint64(args.From), uint64(args.To)
+	if to < from {
+		return nil, fmt.Errorf("invalid range, batch %d is before batch %d", to, from)
+	}
+	if to-from >= r.cfg.MaxBatchRange {
+		return nil, fmt.Errorf("too many batches, at most %d are allowed", r.cfg.MaxBatchRange)
+	}
+
+	// one more value than allowed is requested to tell whether some would be left out
+	list, err := r.db.ListOffChainDataByBatches(ctx, from, to, r.cfg.MaxResults+1)
+	if err != nil {
+		logger.Errorf("failed to list the data of the batches %d to %d: %v", from, to, err)
+		return nil, errInternal
+	}
+	if uint(len(list)) > r.cfg.MaxResults {
+		return nil, fmt.Errorf("more than %d values stored for the batches, narrow the range", r.cfg.MaxResults)
+	}
+
+	return newDataResolvers(list), nil
+}
+
+// Status resolves the status of the node
+func (r *Resolver) Status(ctx context.Context) (*statusResolver, error) {
+	keyCount, err := r.db.CountOffchainData(ctx)
+	if err != nil {
+		logger.Errorf("failed to count the stored data: %v", err)
+		return nil, errInternal
+	}
+
+	backfillProgress, err := r.db.GetLastProcessedBlock(ctx, string(synchronizer.L1SyncTask))
+	if err != nil {
+		logger.Errorf("failed to get last block processed by the synchronizer: %v", err)
+		return nil, errInternal
+	}
+
+	return &statusResolver{status: types.DACStatus{
+		Version:          dataavailability.Version,
+		Uptime:           time.Since(r.startTime).String(),
+		KeyCount:         keyCount,
+		BackfillProgress: backfillProgress,
+	}}, nil
+}
+
+// committeeHistoryArgs are the filters of the committee history, unset ones match every change
+type committeeHistoryArgs struct {
+	FromBlock *hexutil.Uint64
+	ToBlock   *hexutil.Uint64
+	Member    *common.Address
+}
+
+// matches tells whether the change passes the filters
+func (args committeeHistoryArgs) matches(change types.CommitteeChange) bool {
+	if args.FromBlock != nil && change.BlockNumber < uint64(*args.FromBlock) {
+		return false
+	}
+	if args.ToBlock != nil && change.BlockNumber > uint64(*args.ToBlock) {
+		return false
+	}
+
+	return args.Member == nil || change.Member == *args.Member
+}
+
+// CommitteeHistory resolves the changes of the committee members matching the filters. The changes
+// are recorded in the order of their blocks, so they are read until one is past the last block.
+func (r *Resolver) CommitteeHistory(
+	ctx context.Context,
+	args committeeHistoryArgs,
+) ([]*committeeChangeResolver, error) {
+	resolvers := make([]*committeeChangeResolver, 0)
+
+	var fromID uint64
+	for {
+		changes, err := r.db.ListCommitteeChanges(ctx, fromID, r.cfg.MaxResults)
+		if err != nil {
+			logger.Errorf("failed to list the committee changes from %d: %v", fromID, err)
+			return nil, errInternal
+		}
+
+		for _, change := range changes {
+			if args.ToBlock != nil && change.BlockNumber > uint64(*args.ToBlock) {
+				return resolvers, nil
+			}
+			if !args.matches(change) {
+				continue
+			}
+			if uint(len(resolvers)) == r.cfg.MaxResults {
+				return nil, fmt.Errorf("more than %d committee changes, narrow the filters", r.cfg.MaxResults)
+			}
+
+			resolvers = append(resolvers, &committeeChangeResolver{change: change})
+		}
+
+		if uint(len(changes)) < r.cfg.MaxResults {
+			return resolvers, nil
+		}
+		fromID = changes[len(changes)-1].ID + 1
+	}
+}
+
+// dataResolver resolves the fields of a value
+type dataResolver struct {
+	data types.OffChainData
+}
+
+func newDataResolvers(list []types.OffChainData) []*dataResolver {
+	resolvers := make([]*dataResolver, len(list))
+	for i, data := range list {
+		resolvers[i] = &dataResolver{data: data}
+	}
+
+	return resolvers
+}
+
+func (r *dataResolver) Key() common.Hash {
+	return r.data.Key
+}
+
+func (r *dataResolver) Value() hexutil.Bytes {
+	return r.data.Value
+}
+
+func (r *dataResolver) BatchNumber() hexutil.Uint64 {
+	return hexutil.Uint64(r.data.BatchNum)
+}
+
+func (r *dataResolver) Size() int32 {
+	return int32(len(r.data.Value))
+}
+
+// statusResolver resolves the fields of the status of the node
+type statusResolver struct {
+	status types.DACStatus
+}
+
+func (r *statusResolver) Version() string {
+	return r.status.Version
+}
+
+func (r *statusResolver) Uptime() string {
+	return r.status.Uptime
+}
+
+func (r *statusResolver) KeyCount() hexutil.Uint64 {
+	return hexutil.Uint64(r.status.KeyCount)
+}
+
+func (r *statusResolver) BackfillProgress() hexutil.Uint64 {
+	return hexutil.Uint64(r.status.BackfillProgress)
+}
+
+// committeeChangeResolver resolves the fields of a change of a committee member
+type committeeChangeResolver struct {
+	change types.CommitteeChange
+}
+
+func (r *committeeChangeResolver) ID() hexutil.Uint64 {
+	return hexutil.Uint64(r.change.ID)
+}
+
+func (r *committeeChangeResolver) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(r.change.BlockNumber)
+}
+
+func (r *committeeChangeResolver) TxHash() common.Hash {
+	return r.change.TxHash
+}
+
+func (r *committeeChangeResolver) Kind() string {
+	return string(r.change.Kind)
+}
+
+func (r *committeeChangeResolver) Member() common.Address {
+	return r.change.Member
+}
+
+func (r *committeeChangeResolver) URL() string {
+	return r.change.URL
+}
+
+func (r *committeeChangeResolver) PreviousURL() string {
+	return r.change.PreviousURL
+}
+
+func (r *committeeChangeResolver) Timestamp() graphql.Time {
+	return graphql.Time{Time: r.change.Timestamp}
+}
@@ -0,0 +1,58 @@
+package graphql
+
+// schema is the GraphQL schema of the stored data, following the scalars of EIP-1767:
+// Bytes32 and Bytes are hex encoded, and Long is a hex encoded 64 bits unsigned integer
+const schema = `
+	scalar Bytes32
+	scalar Bytes
+	scalar Address
+	scalar Long
+	scalar Time
+
+	# Data is a value stored by the node
+	type Data {
+		# Key is the keccak256 hash of the value
+		key: Bytes32!
+		value: Bytes!
+		# BatchNumber is the batch the value belongs to, zero until the batch is found on L1
+		batchNumber: Long!
+		size: Int!
+	}
+
+	# Status is the status of the node
+	type Status {
+		version: String!
+		uptime: String!
+		keyCount: Long!
+		# BackfillProgress is the last L1 block processed by the synchronizer
+		backfillProgress: Long!
+	}
+
+	# CommitteeChange is a change of a committee member observed on L1
+	type CommitteeChange {
+		id: Long!
+		blockNumber: Long!
+		txHash: Bytes32!
+		# Kind is added, removed or url_changed
+		kind: String!
+		member: Address!
+		url: String!
+		previousUrl: String!
+		timestamp: Time!
+	}
+
+	type Query {
+		# data returns the value of the key, or null if it is not stored
+		data(key: Bytes32!): Data
+		# dataByKeys returns the values of the keys that are stored
+		dataByKeys(keys: [Bytes32!]!): [Data!]!
+		# batch returns the values stored for the batch
+		batch(number: Long!): [Data!]!
+		# batches returns the values stored for the batches in [from, to], lowest batch number first
+		batches(from: Long!, to: Long!): [Data!]!
+		status: Status!
+		# committeeHistory returns the changes of the committee members in the L1 blocks
+		# [fromBlock, toBlock], oldest first, optionally only those of a member
+		committeeHistory(fromBlock: Long, toBlock: Long, member: Address): [CommitteeChange!]!
+	}
+`
@@ -0,0 +1,141 @@
+package health
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/jmoiron/sqlx"
+)
+
+// Names of the dependencies checked
+const (
+	ComponentL1        = "l1"
+	ComponentContracts = "contracts"
+	ComponentDB        = "db"
+	ComponentSigner    = "signer"
+	// ComponentPort prefixes the names of the port checks, run before the node starts
+	ComponentPort = "port"
+)
+
+// L1 checks that the L1 node is reachable and, unless the expected chain ID is 0, on the expected chain
+func L1(em etherman.Etherman, expectedChainID uint64) Check {
+	return Check{
+		Name: ComponentL1,
+		Run: func(ctx context.Context) error {
+			chainID, err := em.ChainID(ctx)
+			if err != nil {
+				return fmt.Errorf("L1 node not reachable: %w", err)
+			}
+
+			if expectedChainID != 0 && chainID.Uint64() != expectedChainID {
+				return fmt.Errorf("L1 node is on chain %d, expected chain %d", chainID, expectedChainID)
+			}
+
+			return nil
+		},
+	}
+}
+
+// Contracts checks that the validium and data committee contracts are deployed and respond
+func Contracts(em etherman.Etherman, validium, dataCommittee common.Address) Check {
+	return Check{
+		Name: ComponentContracts,
+		Run: func(ctx context.Context) error {
+			contracts := []struct {
+				name string
+				addr common.Address
+			}{
+				{"PolygonValidium", validium},
+				{"PolygonDataCommittee", dataCommittee},
+			}
+			for _, contract := range contracts {
+				code, err := em.CodeAt(ctx, contract.addr, nil)
+				if err != nil {
+					return fmt.Errorf("failed to get the code of %s at %s: %w", contract.name, contract.addr.Hex(), err)
+				}
+				if len(code) == 0 {
+					return fmt.Errorf("no %s contract deployed at %s", contract.name, contract.addr.Hex())
+				}
+			}
+
+			if _, err := em.TrustedSequencer(ctx); err != nil {
+				return fmt.Errorf("failed to get the trusted sequencer: %w", err)
+			}
+
+			return nil
+		},
+	}
+}
+
+// DB checks that the database is reachable and its migrations are up to date
+func DB(pg *sqlx.DB) Check {
+	return Check{
+		Name: ComponentDB,
+		Run: func(ctx context.Context) error {
+			if err := pg.PingContext(ctx); err != nil {
+				return fmt.Errorf("database not reachable: %w", err)
+			}
+
+			pending, err := db.PendingMigrations(pg)
+			if err != nil {
+				return fmt.Errorf("failed to check the migrations: %w", err)
+			}
+			if pending > 0 {
+				return fmt.Errorf("%d migrations pending", pending)
+			}
+
+			return nil
+		},
+	}
+}
+
+// Signer checks that the private key of the node is available to sign the sequences
+func Signer(pk *ecdsa.PrivateKey) Check {
+	return Check{
+		Name: ComponentSigner,
+		Run: func(context.Context) error {
+			if pk == nil {
+				return fmt.Errorf("no private key loaded")
+			}
+
+			hash := crypto.Keccak256([]byte("readiness"))
+			signature, err := crypto.Sign(hash, pk)
+			if err != nil {
+				return fmt.Errorf("failed to sign: %w", err)
+			}
+
+			pub, err := crypto.SigToPub(hash, signature)
+			if err != nil {
+				return fmt.Errorf("failed to recover the signer: %w", err)
+			}
+			if crypto.PubkeyToAddress(*pub) != crypto.PubkeyToAddress(pk.PublicKey) {
+				return fmt.Errorf("signature does not match the private key")
+			}
+
+			return nil
+		},
+	}
+}
+
+// Port checks that the port a server of the node listens on is free, so it is only meaningful
+// before the node starts
+func Port(server, host string, port int) Check {
+	return Check{
+		Name: ComponentPort + " " + server,
+		Run: func(context.Context) error {
+			l, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+			if err != nil {
+				return fmt.Errorf("the %s server cannot listen: %w", server, err)
+			}
+
+			return l.Close()
+		},
+	}
+}
@@ -0,0 +1,106 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Statuses of a component
+const (
+	// StatusReady the dependency is available
+	StatusReady = "ready"
+	// StatusNotReady the dependency is not available, or did not answer in time
+	StatusNotReady = "not_ready"
+)
+
+// Check verifies that a dependency of the node is available
+type Check struct {
+	// Name of the dependency, e.g. l1 or db
+	Name string
+	// Run returns an error when the dependency is not available
+	Run func(ctx context.Context) error
+}
+
+// ComponentStatus is the readiness of a dependency of the node
+type ComponentStatus struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+// Report is the readiness of the node, ready when every dependency is
+type Report struct {
+	Ready      bool              `json:"ready"`
+	Components []ComponentStatus `json:"components"`
+}
+
+// Checker verifies the dependencies of the node independently, so one of them
+// failing or hanging does not hide the state of the others
+type Checker struct {
+	checks  []Check
+	timeout time.Duration
+}
+
+// NewChecker returns a Checker running the given checks, each bounded by the timeout
+func NewChecker(timeout time.Duration, checks ...Check) *Checker {
+	return &Checker{
+		checks:  checks,
+		timeout: timeout,
+	}
+}
+
+// Run runs every check concurrently and reports their results in the order they were given
+func (c *Checker) Run(ctx context.Context) Report {
+	report := Report{
+		Ready:      true,
+		Components: make([]ComponentStatus, len(c.checks)),
+	}
+
+	var wg sync.WaitGroup
+	for i, check := range c.checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			report.Components[i] = c.run(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	for _, component := range report.Components {
+		if component.Status != StatusReady {
+			report.Ready = false
+		}
+	}
+
+	return report
+}
+
+func (c *Checker) run(parentCtx context.Context, check Check) ComponentStatus {
+	ctx, cancel := context.WithTimeout(parentCtx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	status := ComponentStatus{Name: check.Name, Status: StatusReady}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- check.Run(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	if err != nil {
+		status.Status = StatusNotReady
+		status.Error = err.Error()
+	}
+	status.Duration = time.Since(start).String()
+
+	return status
+}
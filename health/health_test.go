@@ -0,0 +1,89 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecker_Run(t *testing.T) {
+	checker := NewChecker(50*time.Millisecond,
+		Check{Name: "ok", Run: func(context.Context) error { return nil }},
+		Check{Name: "failing", Run: func(context.Context) error { return errors.New("test error") }},
+		Check{Name: "hanging", Run: func(ctx context.Context) error {
+			time.Sleep(time.Second)
+			return nil
+		}},
+	)
+
+	report := checker.Run(context.Background())
+	require.False(t, report.Ready)
+	require.Len(t, report.Components, 3)
+
+	require.Equal(t, "ok", report.Components[0].Name)
+	require.Equal(t, StatusReady, report.Components[0].Status)
+	require.Empty(t, report.Components[0].Error)
+
+	require.Equal(t, "failing", report.Components[1].Name)
+	require.Equal(t, StatusNotReady, report.Components[1].Status)
+	require.Equal(t, "test error", report.Components[1].Error)
+
+	require.Equal(t, "hanging", report.Components[2].Name)
+	require.Equal(t, StatusNotReady, report.Components[2].Status)
+	require.Equal(t, context.DeadlineExceeded.Error(), report.Components[2].Error)
+
+	require.True(t, NewChecker(time.Second).Run(context.Background()).Ready)
+}
+
+func TestL1(t *testing.T) {
+	em := mocks.NewEtherman(t)
+	em.On("ChainID", mock.Anything).Return(big.NewInt(1), nil).Twice()
+
+	require.NoError(t, L1(em, 1).Run(context.Background()))
+	require.EqualError(t, L1(em, 11155111).Run(context.Background()), "L1 node is on chain 1, expected chain 11155111")
+}
+
+func TestContracts(t *testing.T) {
+	validium := common.HexToAddress("0x1")
+	dataCommittee := common.HexToAddress("0x2")
+
+	em := mocks.NewEtherman(t)
+	em.On("CodeAt", mock.Anything, validium, (*big.Int)(nil)).Return([]byte{0x60, 0x80}, nil).Twice()
+	em.On("CodeAt", mock.Anything, dataCommittee, (*big.Int)(nil)).Return([]byte{0x60, 0x80}, nil).Once()
+	em.On("CodeAt", mock.Anything, dataCommittee, (*big.Int)(nil)).Return([]byte{}, nil).Once()
+	em.On("TrustedSequencer", mock.Anything).Return(common.HexToAddress("0x3"), nil).Once()
+
+	require.NoError(t, Contracts(em, validium, dataCommittee).Run(context.Background()))
+	require.EqualError(t, Contracts(em, validium, dataCommittee).Run(context.Background()),
+		"no PolygonDataCommittee contract deployed at "+dataCommittee.Hex())
+}
+
+func TestSigner(t *testing.T) {
+	pk, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	require.NoError(t, Signer(pk).Run(context.Background()))
+	require.EqualError(t, Signer(nil).Run(context.Background()), "no private key loaded")
+}
+
+func TestPort(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := l.Addr().(*net.TCPAddr).Port
+
+	check := Port("rpc", "127.0.0.1", port)
+	require.Equal(t, "port rpc", check.Name)
+	require.ErrorContains(t, check.Run(context.Background()), "the rpc server cannot listen")
+
+	require.NoError(t, l.Close())
+	require.NoError(t, check.Run(context.Background()))
+}
@@ -0,0 +1,161 @@
+package inspect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/client"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Sequence is the L1 transaction sequencing the batch of a value
+type Sequence struct {
+	Block  uint64      `json:"block"`
+	TxHash common.Hash `json:"txHash"`
+}
+
+// Member is whether a committee member has a value
+type Member struct {
+	Addr common.Address `json:"addr"`
+	URL  string         `json:"url"`
+	Has  bool           `json:"has"`
+	// Error is the reason the member could not be queried, or returned a value not matching the key
+	Error string `json:"error,omitempty"`
+}
+
+// Report is what is known of a value, locally, on L1 and by the committee
+type Report struct {
+	Key    common.Hash `json:"key"`
+	Stored bool        `json:"stored"`
+	Size   int         `json:"size"`
+	// Valid is whether the stored value hashes to its key
+	Valid bool `json:"valid"`
+	// BatchNum is the batch of the value, 0 if not yet matched to a batch
+	BatchNum uint64 `json:"batchNum"`
+	// Sequence is the last L1 transaction sequencing the batch, if found
+	Sequence *Sequence              `json:"sequence,omitempty"`
+	Shards   *types.ShardCommitment `json:"shards,omitempty"`
+	// Members are the committee members queried for the value, with --resolve
+	Members []Member `json:"members,omitempty"`
+}
+
+// Inspector gathers what is known of a value during an incident
+type Inspector struct {
+	db           db.DB
+	etherman     etherman.Etherman
+	factory      client.Factory
+	genesisBlock uint64
+	timeout      time.Duration
+}
+
+// New returns an Inspector looking for the sequences from the given L1 block, and querying each member
+// with the given timeout
+func New(
+	db db.DB,
+	em etherman.Etherman,
+	factory client.Factory,
+	genesisBlock uint64,
+	timeout time.Duration,
+) *Inspector {
+	return &Inspector{
+		db:           db,
+		etherman:     em,
+		factory:      factory,
+		genesisBlock: genesisBlock,
+		timeout:      timeout,
+	}
+}
+
+// Inspect returns the report of the value of the given key, querying the committee members when resolve is set
+func (i *Inspector) Inspect(ctx context.Context, key common.Hash, resolve bool) (*Report, error) {
+	report := &Report{Key: key}
+
+	data, err := i.db.GetOffChainData(ctx, key)
+	if err != nil && !errors.Is(err, db.ErrStateNotSynchronized) {
+		return nil, fmt.Errorf("failed to get the value: %w", err)
+	}
+	if data != nil {
+		report.Stored = true
+		report.Size = len(data.Value)
+		report.Valid = crypto.Keccak256Hash(data.Value) == key
+		report.BatchNum = data.BatchNum
+	}
+
+	if report.BatchNum > 0 {
+		if report.Sequence, err = i.sequence(ctx, report.BatchNum); err != nil {
+			return nil, err
+		}
+	}
+
+	shards, err := i.db.GetShardCommitment(ctx, key)
+	if err != nil && !errors.Is(err, db.ErrStateNotSynchronized) {
+		return nil, fmt.Errorf("failed to get the shards: %w", err)
+	}
+	report.Shards = shards
+
+	if resolve {
+		committee, err := i.etherman.GetCurrentDataCommittee()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the committee: %w", err)
+		}
+		report.Members = i.query(ctx, committee.Members, key)
+	}
+
+	return report, nil
+}
+
+// sequence returns the last L1 transaction sequencing the given batch, nil if not found
+func (i *Inspector) sequence(ctx context.Context, batchNum uint64) (*Sequence, error) {
+	iter, err := i.etherman.FilterSequenceBatches(
+		&bind.FilterOpts{Context: ctx, Start: i.genesisBlock}, []uint64{batchNum})
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter the sequences of the batch %d: %w", batchNum, err)
+	}
+	defer iter.Close()
+
+	var sequence *Sequence
+	for iter.Next() {
+		sequence = &Sequence{Block: iter.Event.Raw.BlockNumber, TxHash: iter.Event.Raw.TxHash}
+	}
+
+	return sequence, iter.Error()
+}
+
+// query asks every member concurrently for the value of the given key
+func (i *Inspector) query(ctx context.Context, members []etherman.DataCommitteeMember, key common.Hash) []Member {
+	var wg sync.WaitGroup
+	result := make([]Member, len(members))
+	for n, member := range members {
+		n, member := n, member
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, i.timeout)
+			defer cancel()
+
+			result[n] = Member{Addr: member.Addr, URL: member.URL}
+			value, err := i.factory.New(member.URL).GetOffChainData(ctx, key)
+			switch {
+			case err != nil:
+				result[n].Error = err.Error()
+			case crypto.Keccak256Hash(value) != key:
+				result[n].Error = "value not matching the key"
+			default:
+				result[n].Has = true
+			}
+		}()
+	}
+	wg.Wait()
+
+	return result
+}
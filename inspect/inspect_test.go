@@ -0,0 +1,93 @@
+package inspect
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/etherman/ethermantest"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspector_Inspect(t *testing.T) {
+	value := []byte("value")
+	key := crypto.Keccak256Hash(value)
+	txHash := common.HexToHash("0xabc")
+
+	t.Run("stored and sequenced", func(t *testing.T) {
+		log := ethermantest.Log(t, 7)
+		log.BlockNumber, log.TxHash = 150, txHash
+
+		em := mocks.NewEtherman(t)
+		em.On("FilterSequenceBatches", mock.MatchedBy(func(opts *bind.FilterOpts) bool {
+			return opts.Start == 100
+		}), []uint64{7}).Return(ethermantest.Iterator(t, log), nil).Once()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("GetOffChainData", mock.Anything, key).
+			Return(&types.OffChainData{Key: key, Value: value, BatchNum: 7}, nil).Once()
+		dbMock.On("GetShardCommitment", mock.Anything, key).Return(nil, db.ErrStateNotSynchronized).Once()
+
+		report, err := New(dbMock, em, mocks.NewClientFactory(t), 100, time.Second).
+			Inspect(context.Background(), key, false)
+		require.NoError(t, err)
+		require.Equal(t, &Report{
+			Key:      key,
+			Stored:   true,
+			Size:     len(value),
+			Valid:    true,
+			BatchNum: 7,
+			Sequence: &Sequence{Block: 150, TxHash: txHash},
+		}, report)
+	})
+
+	t.Run("resolved from the members", func(t *testing.T) {
+		first, second := common.HexToAddress("0x1"), common.HexToAddress("0x2")
+
+		em := mocks.NewEtherman(t)
+		em.On("GetCurrentDataCommittee").Return(&etherman.DataCommittee{Members: []etherman.DataCommitteeMember{
+			{Addr: first, URL: "http://first"},
+			{Addr: second, URL: "http://second"},
+		}}, nil).Once()
+
+		firstClient := mocks.NewClient(t)
+		firstClient.On("GetOffChainData", mock.Anything, key).Return(value, nil).Once()
+		secondClient := mocks.NewClient(t)
+		secondClient.On("GetOffChainData", mock.Anything, key).Return(nil, errors.New("data not found")).Once()
+
+		factory := mocks.NewClientFactory(t)
+		factory.On("New", "http://first").Return(firstClient).Once()
+		factory.On("New", "http://second").Return(secondClient).Once()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("GetOffChainData", mock.Anything, key).Return(nil, db.ErrStateNotSynchronized).Once()
+		dbMock.On("GetShardCommitment", mock.Anything, key).Return(nil, db.ErrStateNotSynchronized).Once()
+
+		report, err := New(dbMock, em, factory, 100, time.Second).Inspect(context.Background(), key, true)
+		require.NoError(t, err)
+		require.False(t, report.Stored)
+		require.Nil(t, report.Sequence)
+		require.Equal(t, []Member{
+			{Addr: first, URL: "http://first", Has: true},
+			{Addr: second, URL: "http://second", Error: "data not found"},
+		}, report.Members)
+	})
+
+	t.Run("failure to read the database", func(t *testing.T) {
+		dbMock := mocks.NewDB(t)
+		dbMock.On("GetOffChainData", mock.Anything, key).Return(nil, errors.New("test")).Once()
+
+		_, err := New(dbMock, mocks.NewEtherman(t), mocks.NewClientFactory(t), 100, time.Second).
+			Inspect(context.Background(), key, false)
+		require.ErrorContains(t, err, "test")
+	})
+}
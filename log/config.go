@@ -10,6 +10,12 @@ type Config struct {
 	Environment Environment `mapstructure:"Environment" jsonschema:"enum=production,enum=development"`
 	// Level of log. As lower value more logs are going to be generated
 	Level string `mapstructure:"Level" jsonschema:"enum=debug,enum=info,enum=warn,enum=error,enum=dpanic,enum=panic,enum=fatal"` //nolint:lll
+	// Levels overrides the level of specific components, e.g. synchronizer = "debug".
+	// The components without a level of their own log at Level
+	Levels map[string]string `mapstructure:"Levels"`
+	// Format of the logs ("console" or "json"). By default, production logs are written in
+	// JSON and development logs in the console format
+	Format Format `mapstructure:"Format" jsonschema:"enum=console,enum=json"`
 	// Outputs
 	Outputs []string `mapstructure:"Outputs"`
 }
@@ -0,0 +1,24 @@
+package log
+
+// Keys of the fields shared by the logs of every component, so the same value is
+// always logged under the same key and can be queried without parsing the message
+const (
+	// FieldComponent is the component logging, set by WithComponent
+	FieldComponent = "component"
+	// FieldMethod is the JSON-RPC method handled or called
+	FieldMethod = "method"
+	// FieldRequestID is the ID of the JSON-RPC request handled
+	FieldRequestID = "request_id"
+	// FieldKeyHash is the hash of the offchain data (the batch L2 data hash)
+	FieldKeyHash = "key_hash"
+	// FieldBatchNumber is the number of the batch
+	FieldBatchNumber = "batch_number"
+	// FieldBlockNumber is the number of the L1 block
+	FieldBlockNumber = "block_number"
+	// FieldTxHash is the hash of the L1 transaction
+	FieldTxHash = "tx_hash"
+	// FieldMemberAddr is the address of the committee member
+	FieldMemberAddr = "member_addr"
+	// FieldMemberURL is the URL of the committee member or the trusted sequencer
+	FieldMemberURL = "member_url"
+)
@@ -0,0 +1,142 @@
+package log
+
+import (
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+var (
+	// rootLevel is the level of the root logger and of the components without a level of their own
+	rootLevel = zap.NewAtomicLevelAt(zap.DebugLevel)
+
+	levelsLock sync.RWMutex
+	// componentLevels are the levels set for specific components, e.g. synchronizer=debug
+	componentLevels = make(map[string]zapcore.Level)
+	// components are the component loggers, rebuilt when the root logger changes
+	components = make(map[string]*Logger)
+)
+
+// componentEnabler enables the log levels of a component, falling back to the
+// root level when the component has no level of its own
+type componentEnabler string
+
+// Enabled implements zapcore.LevelEnabler
+func (c componentEnabler) Enabled(lvl zapcore.Level) bool {
+	levelsLock.RLock()
+	componentLevel, ok := componentLevels[string(c)]
+	levelsLock.RUnlock()
+
+	if ok {
+		return componentLevel.Enabled(lvl)
+	}
+
+	return rootLevel.Enabled(lvl)
+}
+
+// WithComponent returns the Logger of the given component (e.g. synchronizer),
+// which logs at the level set for the component instead of the root one.
+// Component loggers are kept up to date when the root Logger is initialized.
+func WithComponent(name string) *Logger {
+	getDefaultLog()
+
+	levelsLock.Lock()
+	defer levelsLock.Unlock()
+
+	if l, ok := components[name]; ok {
+		return l
+	}
+
+	l := &Logger{x: componentLogger(name)}
+	components[name] = l
+	return l
+}
+
+// componentLogger derives the zap logger of a component from the base logger
+func componentLogger(name string) *zap.SugaredLogger {
+	return base.WithOptions(
+		// the returned Logger methods are called directly, not through the package functions
+		zap.AddCallerSkip(-1),
+		zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			// the base core is built at debug level, so increasing it never fails
+			c, err := zapcore.NewIncreaseLevelCore(core, componentEnabler(name))
+			if err != nil {
+				return core
+			}
+			return c
+		}),
+	).With(FieldComponent, name)
+}
+
+// rebuildComponents derives again every component logger from the new base logger
+func rebuildComponents() {
+	levelsLock.Lock()
+	defer levelsLock.Unlock()
+
+	for name, l := range components {
+		l.x = componentLogger(name)
+	}
+}
+
+// SetLevel changes the level of the root logger at runtime, without rebuilding it
+func SetLevel(lvl string) error {
+	return rootLevel.UnmarshalText([]byte(lvl))
+}
+
+// SetComponentLevel changes the level of a component at runtime. An empty level
+// removes the level of the component, which then logs at the root level again.
+func SetComponentLevel(component, lvl string) error {
+	if lvl == "" {
+		levelsLock.Lock()
+		delete(componentLevels, component)
+		levelsLock.Unlock()
+		return nil
+	}
+
+	var level zapcore.Level
+	if err := level.UnmarshalText([]byte(lvl)); err != nil {
+		return fmt.Errorf("error on setting log level of %s: %s", component, err)
+	}
+
+	levelsLock.Lock()
+	componentLevels[component] = level
+	levelsLock.Unlock()
+	return nil
+}
+
+// SetComponentLevels replaces the levels of all the components
+func SetComponentLevels(levels map[string]string) error {
+	parsed := make(map[string]zapcore.Level, len(levels))
+	for component, lvl := range levels {
+		var level zapcore.Level
+		if err := level.UnmarshalText([]byte(lvl)); err != nil {
+			return fmt.Errorf("error on setting log level of %s: %s", component, err)
+		}
+		parsed[component] = level
+	}
+
+	levelsLock.Lock()
+	componentLevels = parsed
+	levelsLock.Unlock()
+	return nil
+}
+
+// Levels returns the root level, keyed by "root", along with the level of every
+// known component, either set explicitly or inherited from the root one
+func Levels() map[string]string {
+	levelsLock.RLock()
+	defer levelsLock.RUnlock()
+
+	root := rootLevel.Level().String()
+	levels := map[string]string{"root": root}
+	for name := range components {
+		levels[name] = root
+	}
+	for name, lvl := range componentLevels {
+		levels[name] = lvl.String()
+	}
+
+	return levels
+}
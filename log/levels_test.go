@@ -0,0 +1,52 @@
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestComponentLevels(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "test.log")
+
+	sync := WithComponent("sync-test")
+	rpc := WithComponent("rpc-test")
+
+	Init(Config{
+		Environment: EnvironmentProduction,
+		Level:       "info",
+		Levels:      map[string]string{"sync-test": "debug"},
+		Outputs:     []string{logFile},
+	})
+
+	sync.Debug("sync debug")
+	rpc.Debug("rpc debug")
+	rpc.Info("rpc info")
+	Debug("root debug")
+
+	require.NoError(t, SetComponentLevel("rpc-test", "error"))
+	require.NoError(t, SetLevel("debug"))
+	rpc.Warn("rpc warn")
+	Debug("root debug after change")
+
+	require.NoError(t, SetComponentLevel("rpc-test", ""))
+	rpc.Debug("rpc debug after reset")
+
+	require.Error(t, SetComponentLevel("rpc-test", "verbose"))
+	require.Equal(t, map[string]string{"root": "debug", "sync-test": "debug", "rpc-test": "debug"}, Levels())
+
+	content, err := os.ReadFile(logFile)
+	require.NoError(t, err)
+
+	logs := string(content)
+	require.Contains(t, logs, "sync debug")
+	require.Contains(t, logs, `"component":"sync-test"`)
+	require.NotContains(t, logs, "rpc debug\"")
+	require.Contains(t, logs, "rpc info")
+	require.NotContains(t, logs, "root debug\"")
+	require.NotContains(t, logs, "rpc warn")
+	require.Contains(t, logs, "root debug after change")
+	require.Contains(t, logs, "rpc debug after reset")
+}
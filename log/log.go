@@ -21,6 +21,16 @@ const (
 	EnvironmentDevelopment = Environment("development")
 )
 
+// Format represents the possible log formats.
+type Format string
+
+const (
+	// FormatConsole human readable log format.
+	FormatConsole = Format("console")
+	// FormatJSON log format, one JSON object per line with the same keys in every environment.
+	FormatJSON = Format("json")
+)
+
 // Logger is a wrapper providing logging facilities.
 type Logger struct {
 	x *zap.SugaredLogger
@@ -29,12 +39,21 @@ type Logger struct {
 // root logger
 var log *Logger
 
+// base logger, at debug level, from which the root and the component loggers are derived
+var base *zap.SugaredLogger
+
+// built is the logger built from the config, before adding the extra cores
+var built *zap.SugaredLogger
+
+// cores are the extra cores every log entry is also written to, e.g. an error reporter
+var cores []zapcore.Core
+
 func getDefaultLog() *Logger {
 	if log != nil {
 		return log
 	}
 	// default level: debug
-	zapLogger, _, err := NewLogger(Config{
+	zapLogger, err := newRootLogger(Config{
 		Environment: EnvironmentDevelopment,
 		Level:       "debug",
 		Outputs:     []string{"stderr"},
@@ -42,7 +61,7 @@ func getDefaultLog() *Logger {
 	if err != nil {
 		panic(err)
 	}
-	log = &Logger{x: zapLogger}
+	setRootLogger(zapLogger)
 	return log
 }
 
@@ -52,11 +71,48 @@ func getDefaultLog() *Logger {
 // should be added at the outputs array. To avoid printing the logs but storing
 // them on a file, can use []string{"pathtofile.log"}
 func Init(cfg Config) {
-	zapLogger, _, err := NewLogger(cfg)
+	zapLogger, err := newRootLogger(cfg)
 	if err != nil {
 		panic(err)
 	}
-	log = &Logger{x: zapLogger}
+	setRootLogger(zapLogger)
+	rebuildComponents()
+}
+
+// setRootLogger sets the base logger and the root logger derived from it
+func setRootLogger(zapLogger *zap.SugaredLogger) {
+	built = zapLogger
+	base = zapLogger
+	if len(cores) > 0 {
+		base = zapLogger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return zapcore.NewTee(append([]zapcore.Core{core}, cores...)...)
+		}))
+	}
+	log = &Logger{x: base.WithOptions(zap.IncreaseLevel(rootLevel))}
+}
+
+// AddCore writes every log entry to the given core as well, which decides the entries
+// it handles (e.g. only errors). It applies to the root and the component loggers.
+func AddCore(core zapcore.Core) {
+	getDefaultLog()
+
+	cores = append(cores, core)
+	setRootLogger(built)
+	rebuildComponents()
+}
+
+// newRootLogger creates the base logger, the level of the root logger and of each
+// component derived from it can be changed at runtime
+func newRootLogger(cfg Config) (*zap.SugaredLogger, error) {
+	if err := rootLevel.UnmarshalText([]byte(cfg.Level)); err != nil {
+		return nil, fmt.Errorf("error on setting log level: %s", err)
+	}
+	if err := SetComponentLevels(cfg.Levels); err != nil {
+		return nil, err
+	}
+
+	// build at the lowest level, the root and component levels are enforced on top of it
+	return newLogger(cfg, zap.NewAtomicLevelAt(zap.DebugLevel))
 }
 
 // NewLogger creates the logger with defined level. outputs defines the outputs where the
@@ -71,6 +127,15 @@ func NewLogger(cfg Config) (*zap.SugaredLogger, *zap.AtomicLevel, error) {
 		return nil, nil, fmt.Errorf("error on setting log level: %s", err)
 	}
 
+	logger, err := newLogger(cfg, level)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return logger, &level, nil
+}
+
+func newLogger(cfg Config, level zap.AtomicLevel) (*zap.SugaredLogger, error) {
 	var zapCfg zap.Config
 
 	switch cfg.Environment {
@@ -80,6 +145,13 @@ func NewLogger(cfg Config) (*zap.SugaredLogger, *zap.AtomicLevel, error) {
 		zapCfg = zap.NewDevelopmentConfig()
 		zapCfg.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
 	}
+	switch cfg.Format {
+	case FormatJSON:
+		zapCfg.Encoding = string(FormatJSON)
+		zapCfg.EncoderConfig = jsonEncoderConfig()
+	case FormatConsole:
+		zapCfg.Encoding = string(FormatConsole)
+	}
 	zapCfg.Level = level
 	zapCfg.OutputPaths = cfg.Outputs
 	zapCfg.InitialFields = map[string]interface{}{
@@ -89,13 +161,25 @@ func NewLogger(cfg Config) (*zap.SugaredLogger, *zap.AtomicLevel, error) {
 
 	logger, err := zapCfg.Build()
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	defer logger.Sync() //nolint:errcheck
 
 	// skip 2 callers: one for our wrapper methods and one for the package functions
 	withOptions := logger.WithOptions(zap.AddCallerSkip(2)) //nolint:gomnd
-	return withOptions.Sugar(), &level, nil
+	return withOptions.Sugar(), nil
+}
+
+// jsonEncoderConfig returns the keys and encoders of the JSON logs, so they can be
+// ingested and queried without depending on the environment of the node
+func jsonEncoderConfig() zapcore.EncoderConfig {
+	encoderCfg := zap.NewProductionEncoderConfig()
+	encoderCfg.TimeKey = "ts"
+	encoderCfg.EncodeTime = zapcore.ISO8601TimeEncoder
+	encoderCfg.EncodeDuration = zapcore.StringDurationEncoder
+	encoderCfg.EncodeLevel = zapcore.LowercaseLevelEncoder
+
+	return encoderCfg
 }
 
 // WithFields returns a new Logger (derived from the root one) with additional
@@ -0,0 +1,44 @@
+package log
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONFormat(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "test.log")
+
+	component := WithComponent("json-test")
+
+	Init(Config{
+		Environment: EnvironmentDevelopment,
+		Level:       "debug",
+		Format:      FormatJSON,
+		Outputs:     []string{logFile},
+	})
+
+	component.WithFields(FieldKeyHash, "0x01", FieldBatchNumber, 42).Info("resolved")
+
+	f, err := os.Open(logFile)
+	require.NoError(t, err)
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	require.True(t, scanner.Scan())
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+
+	require.Equal(t, "info", entry["level"])
+	require.Equal(t, "resolved", entry["msg"])
+	require.Equal(t, "json-test", entry[FieldComponent])
+	require.Equal(t, "0x01", entry[FieldKeyHash])
+	require.Equal(t, float64(42), entry[FieldBatchNumber])
+	require.Contains(t, entry, "ts")
+	require.Contains(t, entry, "caller")
+}
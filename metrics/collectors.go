@@ -0,0 +1,340 @@
+package metrics
+
+import "time"
+
+// Subsystems of the node metrics
+const (
+	subsystemRPC          = "rpc"
+	subsystemClient       = "client"
+	subsystemSynchronizer = "synchronizer"
+	subsystemDB           = "db"
+	subsystemSigner       = "signer"
+	subsystemDiagnostics  = "diagnostics"
+	subsystemPublisher    = "publisher"
+	subsystemGossip       = "gossip"
+	subsystemAttestation  = "attestation"
+	subsystemCertificate  = "certificate"
+	subsystemStream       = "stream"
+	subsystemWebhook      = "webhook"
+	subsystemSharding     = "sharding"
+	subsystemReconcile    = "reconcile"
+	subsystemCrossCheck   = "crosscheck"
+	subsystemCustody      = "custody"
+	subsystemReporter     = "reporter"
+)
+
+// Sources a batch can be resolved from
+const (
+	// SourceSequencer is the trusted sequencer
+	SourceSequencer = "sequencer"
+	// SourceMember is another committee member
+	SourceMember = "member"
+	// SourceBlob is a blob of a sequence, read from the beacon API
+	SourceBlob = "blob"
+)
+
+// Directions and results of the gossip announcements, besides ResultSuccess and ResultError
+const (
+	// GossipSent are the announcements sent to the other members
+	GossipSent = "sent"
+	// GossipReceived are the announcements received from the other members
+	GossipReceived = "received"
+	// GossipRejected is the result of the announcements received which are invalid
+	GossipRejected = "rejected"
+	// GossipDropped is the result of the announcements not processed as too many were pending
+	GossipDropped = "dropped"
+)
+
+// Reasons of the connections and requests refused by the JSON-RPC server
+const (
+	// ThrottleConnections is the reason of the connections refused as too many were open
+	ThrottleConnections = "connections"
+	// ThrottleConnectionsPerIP is the reason of the connections refused as too many were open from their IP
+	ThrottleConnectionsPerIP = "connections_per_ip"
+	// ThrottleRequestsPerIP is the reason of the requests refused as too many were in flight from their IP
+	ThrottleRequestsPerIP = "requests_per_ip"
+	// ThrottleRequests is the reason of the requests of low priority refused as too many were in flight
+	ThrottleRequests = "requests"
+	// ThrottleOverloadInFlight is the reason of the requests refused as too many were in flight overall
+	ThrottleOverloadInFlight = "overload_in_flight"
+	// ThrottleOverloadDBPool is the reason of the requests refused as too many connections of the database
+	// pool were in use
+	ThrottleOverloadDBPool = "overload_db_pool"
+	// ThrottleOverloadMemory is the reason of the requests refused as the heap was too large
+	ThrottleOverloadMemory = "overload_memory"
+)
+
+// Results of the reconciliation of the signed sequences against L1
+const (
+	// ReconcileLanded is the result of the signed sequences that landed on L1
+	ReconcileLanded = "landed"
+	// ReconcileNotLanded is the result of the signed sequences that did not land on L1 in time
+	ReconcileNotLanded = "not_landed"
+	// ReconcileUnrecorded is the result of the sequences landed with a signature of the node it has no record of
+	ReconcileUnrecorded = "unrecorded"
+	// ReconcileMalformed is the result of the sequences landed that can not be decoded, skipped
+	ReconcileMalformed = "malformed"
+)
+
+// Results of the custody challenges of the other members, besides ResultError for the members not reached
+const (
+	// CustodyPassed is the result of the challenges answered with the expected proof
+	CustodyPassed = "passed"
+	// CustodyFailed is the result of the challenges refused or answered with another proof
+	CustodyFailed = "failed"
+)
+
+// WebhookDropped is the result of the webhook events not delivered as too many were pending,
+// besides ResultSuccess and ResultError
+const WebhookDropped = "dropped"
+
+// EventSkipped is the result of the SequenceBatches events whose sequence can not be decoded,
+// besides ResultSuccess and ResultError
+const EventSkipped = "skipped"
+
+// Results of a request to sign a sequence, besides ResultError
+const (
+	// SignResultSigned is the result of the sequences signed
+	SignResultSigned = "signed"
+	// SignResultUnauthorized is the result of the sequences not sent by the trusted sequencer
+	SignResultUnauthorized = "unauthorized"
+	// SignResultReplayed is the result of the requests replayed or signed outside of the replay window
+	SignResultReplayed = "replayed"
+)
+
+var (
+	rpcRequests = NewCounterVec(subsystemRPC, "requests_total",
+		"Number of JSON-RPC requests handled, by method and result.", "method", "result")
+	rpcRequestDuration = NewHistogramVec(subsystemRPC, "request_duration_seconds",
+		"Time taken to handle a JSON-RPC request, by method.", "method")
+	rpcThrottled = NewCounterVec(subsystemRPC, "throttled_total",
+		"Number of connections and requests refused by the JSON-RPC server, by reason.", "reason")
+
+	clientRequests = NewCounterVec(subsystemClient, "requests_total",
+		"Number of JSON-RPC requests sent to other nodes, by method and result.", "method", "result")
+	clientRequestDuration = NewHistogramVec(subsystemClient, "request_duration_seconds",
+		"Time taken by the JSON-RPC requests sent to other nodes, by method.", "method")
+
+	syncLastProcessedBlock = NewGauge(subsystemSynchronizer, "last_processed_block",
+		"Last L1 block processed by the synchronizer.")
+	syncL1Head = NewGauge(subsystemSynchronizer, "l1_head_block",
+		"Latest L1 block seen by the synchronizer.")
+	syncEvents = NewCounterVec(subsystemSynchronizer, "events_total",
+		"Number of SequenceBatches events processed, by result.", "result")
+	syncUnresolvedBatches = NewGauge(subsystemSynchronizer, "unresolved_batches",
+		"Number of batches whose data is pending to be resolved in the last round.")
+	syncResolvedBatches = NewCounterVec(subsystemSynchronizer, "resolved_batches_total",
+		"Number of batches whose data was resolved, by source.", "source")
+	syncFailedBatches = NewCounter(subsystemSynchronizer, "failed_batches_total",
+		"Number of attempts to resolve the data of a batch that failed.")
+	syncMemberResolves = NewCounterVec(subsystemSynchronizer, "member_resolves_total",
+		"Number of requests to a committee member for the data of a batch, by member and result.", "member", "result")
+	syncMemberResolveDuration = NewHistogramVec(subsystemSynchronizer, "member_resolve_duration_seconds",
+		"Time taken by a committee member to return the data of a batch, by member and result.", "member", "result")
+
+	dbOperations = NewCounterVec(subsystemDB, "operations_total",
+		"Number of database operations, by operation and result.", "operation", "result")
+	dbOperationDuration = NewHistogramVec(subsystemDB, "operation_duration_seconds",
+		"Time taken by the database operations, by operation.", "operation")
+
+	signerSignatures = NewCounterVec(subsystemSigner, "sequences_total",
+		"Number of sequences the node was asked to sign, by result.", "result")
+
+	diagnosticsRuns = NewCounterVec(subsystemDiagnostics, "runs_total",
+		"Number of self-diagnostics runs, by result.", "result")
+	diagnosticsStepPassed = NewGaugeVec(subsystemDiagnostics, "step_passed",
+		"Whether each step of the last self-diagnostics run passed (1) or failed (0), by step.", "step")
+
+	publisherValues = NewCounterVec(subsystemPublisher, "values_total",
+		"Number of values published to an external storage backend, by backend and result.", "backend", "result")
+	publisherDuration = NewHistogramVec(subsystemPublisher, "publish_duration_seconds",
+		"Time taken to publish a value to an external storage backend, by backend.", "backend")
+
+	gossipAnnouncements = NewCounterVec(subsystemGossip, "announcements_total",
+		"Number of announcements of stored keys, by direction (sent or received) and result.", "direction", "result")
+	gossipFetchedValues = NewCounter(subsystemGossip, "fetched_values_total",
+		"Number of values fetched from the members announcing them.")
+
+	attestationSubmissions = NewCounterVec(subsystemAttestation, "submissions_total",
+		"Number of attestations of the stored keys submitted to L1, by result.", "result")
+	attestationLastBatch = NewGauge(subsystemAttestation, "last_attested_batch",
+		"Number of the last batch covered by an attestation mined on L1.")
+
+	certificatesAssembled = NewCounterVec(subsystemCertificate, "assembled_total",
+		"Number of availability certificates assembled by the coordinator, by result.", "result")
+
+	streamEvents = NewCounterVec(subsystemStream, "events_total",
+		"Number of events of the stored values streamed to a message broker, by broker and result.", "broker", "result")
+
+	webhookDeliveries = NewCounterVec(subsystemWebhook, "deliveries_total",
+		"Number of events posted to the webhook endpoints, by event type and result.", "event", "result")
+
+	reconciledSequences = NewCounterVec(subsystemReconcile, "sequences_total",
+		"Number of sequences reconciled between the audit log and L1, by result.", "result")
+
+	crossCheckExtraKeys = NewGauge(subsystemCrossCheck, "extra_keys",
+		"Number of keys stored for the batches up to the last verified one that were not sequenced on L1.")
+	crossCheckMissingKeys = NewGauge(subsystemCrossCheck, "missing_keys",
+		"Number of keys sequenced on L1 up to the last verified batch that are not stored.")
+	crossCheckVerifiedBatch = NewGauge(subsystemCrossCheck, "verified_batch",
+		"Last verified batch the stored keys were cross-checked up to.")
+
+	custodyChallenges = NewCounterVec(subsystemCustody, "challenges_total",
+		"Number of custody challenges of the other committee members, by member and result.", "member", "result")
+
+	shardedValues = NewCounterVec(subsystemSharding, "values_total",
+		"Number of values erasure coded into shards, by result.", "result")
+
+	recoveredPanics = NewCounterVec(subsystemReporter, "recovered_panics_total",
+		"Number of panics recovered without stopping the node, by component.", "component")
+)
+
+// RPCRequest records a JSON-RPC request handled by the node
+func RPCRequest(method string, start time.Time, failed bool) {
+	rpcRequests.WithLabelValues(method, result(failed)).Inc()
+	rpcRequestDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
+
+// RPCThrottled records a connection or a request refused by the JSON-RPC server
+func RPCThrottled(reason string) {
+	rpcThrottled.WithLabelValues(reason).Inc()
+}
+
+// ClientRequest records a JSON-RPC request sent to another node
+func ClientRequest(method string, start time.Time, failed bool) {
+	clientRequests.WithLabelValues(method, result(failed)).Inc()
+	clientRequestDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
+
+// LastProcessedBlock records the last L1 block processed by the synchronizer
+func LastProcessedBlock(block uint64) {
+	syncLastProcessedBlock.Set(float64(block))
+}
+
+// L1Head records the latest L1 block seen by the synchronizer
+func L1Head(block uint64) {
+	syncL1Head.Set(float64(block))
+}
+
+// SequenceEvent records a SequenceBatches event processed by the synchronizer
+func SequenceEvent(err error) {
+	syncEvents.WithLabelValues(Result(err)).Inc()
+}
+
+// SequenceEventSkipped records a SequenceBatches event skipped by the synchronizer, its sequence being malformed
+func SequenceEventSkipped() {
+	syncEvents.WithLabelValues(EventSkipped).Inc()
+}
+
+// UnresolvedBatches records the number of batches pending to be resolved
+func UnresolvedBatches(count int) {
+	syncUnresolvedBatches.Set(float64(count))
+}
+
+// BatchResolved records the data of a batch resolved from the given source
+func BatchResolved(source string) {
+	syncResolvedBatches.WithLabelValues(source).Inc()
+}
+
+// BatchFailed records a failed attempt to resolve the data of a batch
+func BatchFailed() {
+	syncFailedBatches.Inc()
+}
+
+// MemberResolve records a request to the committee member with the given address for the
+// data of a batch, which failed if the member did not return the expected data
+func MemberResolve(member string, start time.Time, err error) {
+	syncMemberResolves.WithLabelValues(member, Result(err)).Inc()
+	syncMemberResolveDuration.WithLabelValues(member, Result(err)).Observe(time.Since(start).Seconds())
+}
+
+// DBOperation records a database operation
+func DBOperation(operation string, start time.Time, err error) {
+	dbOperations.WithLabelValues(operation, Result(err)).Inc()
+	dbOperationDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+}
+
+// SignSequence records the result of a request to sign a sequence
+func SignSequence(result string) {
+	signerSignatures.WithLabelValues(result).Inc()
+}
+
+// DiagnosticsRun records the result of a self-diagnostics run
+func DiagnosticsRun(passed bool) {
+	diagnosticsRuns.WithLabelValues(result(!passed)).Inc()
+}
+
+// DiagnosticsStep records the result of a step of the last self-diagnostics run
+func DiagnosticsStep(step string, passed bool) {
+	value := 0.0
+	if passed {
+		value = 1
+	}
+	diagnosticsStepPassed.WithLabelValues(step).Set(value)
+}
+
+// PublishValue records a value published to an external storage backend
+func PublishValue(backend string, start time.Time, err error) {
+	publisherValues.WithLabelValues(backend, Result(err)).Inc()
+	publisherDuration.WithLabelValues(backend).Observe(time.Since(start).Seconds())
+}
+
+// GossipAnnouncement records an announcement sent to or received from another member
+func GossipAnnouncement(direction, result string) {
+	gossipAnnouncements.WithLabelValues(direction, result).Inc()
+}
+
+// GossipFetched records the values fetched from a member announcing them
+func GossipFetched(count int) {
+	gossipFetchedValues.Add(float64(count))
+}
+
+// AttestationSubmitted records an attestation submitted to L1, and the last batch it covers when mined
+func AttestationSubmitted(toBatch uint64, err error) {
+	attestationSubmissions.WithLabelValues(Result(err)).Inc()
+	if err == nil {
+		attestationLastBatch.Set(float64(toBatch))
+	}
+}
+
+// CertificateAssembled records an availability certificate assembled by the coordinator
+func CertificateAssembled(err error) {
+	certificatesAssembled.WithLabelValues(Result(err)).Inc()
+}
+
+// StreamEvents records the events streamed to a message broker
+func StreamEvents(broker string, count int, err error) {
+	streamEvents.WithLabelValues(broker, Result(err)).Add(float64(count))
+}
+
+// WebhookDelivery records the delivery of an event to a webhook endpoint
+func WebhookDelivery(event, result string) {
+	webhookDeliveries.WithLabelValues(event, result).Inc()
+}
+
+// ShardedValue records a value erasure coded into shards
+func ShardedValue(err error) {
+	shardedValues.WithLabelValues(Result(err)).Inc()
+}
+
+// SequenceReconciled records a sequence reconciled between the audit log and L1
+func SequenceReconciled(result string) {
+	reconciledSequences.WithLabelValues(result).Inc()
+}
+
+// CrossChecked records the result of a cross-check of the stored keys against L1 up to the verified batch
+func CrossChecked(verifiedBatch uint64, extra, missing int) {
+	crossCheckVerifiedBatch.Set(float64(verifiedBatch))
+	crossCheckExtraKeys.Set(float64(extra))
+	crossCheckMissingKeys.Set(float64(missing))
+}
+
+// CustodyChallenge records a custody challenge of another committee member
+func CustodyChallenge(member, result string) {
+	custodyChallenges.WithLabelValues(member, result).Inc()
+}
+
+// PanicRecovered records a panic of the component recovered without stopping the node
+func PanicRecovered(component string) {
+	recoveredPanics.WithLabelValues(component).Inc()
+}
@@ -0,0 +1,13 @@
+package metrics
+
+// Config represents the configuration of the metrics listener
+type Config struct {
+	// Enabled exposes the metrics in the Prometheus format
+	Enabled bool `mapstructure:"Enabled"`
+
+	// Host defines the network adapter that will be used to serve the metrics
+	Host string `mapstructure:"Host"`
+
+	// Port defines the port to serve the metrics at /metrics
+	Port int `mapstructure:"Port"`
+}
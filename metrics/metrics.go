@@ -0,0 +1,158 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Namespace prefixes the name of every metric of the node. Metric names follow the
+// Prometheus conventions: <namespace>_<subsystem>_<name>_<unit>, with counters
+// ending in _total and durations measured in seconds.
+const Namespace = "dac"
+
+const (
+	// ResultSuccess is the result label of the operations that succeeded
+	ResultSuccess = "success"
+	// ResultError is the result label of the operations that failed
+	ResultError = "error"
+
+	// readHeaderTimeout bounds the time to read the headers of a scrape request
+	readHeaderTimeout = 10 * time.Second
+)
+
+// registry holds the metrics of the node, along with the Go runtime and process ones
+var registry = prometheus.NewRegistry()
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Registry returns the registry of the node metrics
+func Registry() *prometheus.Registry {
+	return registry
+}
+
+// Result returns the result label of an operation given its error
+func Result(err error) string {
+	return result(err != nil)
+}
+
+func result(failed bool) string {
+	if failed {
+		return ResultError
+	}
+
+	return ResultSuccess
+}
+
+// NewCounterVec creates and registers a counter partitioned by the given labels
+func NewCounterVec(subsystem, name, help string, labels ...string) *prometheus.CounterVec {
+	c := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: subsystem,
+		Name:      name,
+		Help:      help,
+	}, labels)
+	registry.MustRegister(c)
+	return c
+}
+
+// NewCounter creates and registers a counter
+func NewCounter(subsystem, name, help string) prometheus.Counter {
+	c := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: subsystem,
+		Name:      name,
+		Help:      help,
+	})
+	registry.MustRegister(c)
+	return c
+}
+
+// NewHistogramVec creates and registers a histogram with the default buckets,
+// suited for durations in seconds, partitioned by the given labels
+func NewHistogramVec(subsystem, name, help string, labels ...string) *prometheus.HistogramVec {
+	h := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: subsystem,
+		Name:      name,
+		Help:      help,
+		Buckets:   prometheus.DefBuckets,
+	}, labels)
+	registry.MustRegister(h)
+	return h
+}
+
+// NewGauge creates and registers a gauge
+func NewGauge(subsystem, name, help string) prometheus.Gauge {
+	g := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: subsystem,
+		Name:      name,
+		Help:      help,
+	})
+	registry.MustRegister(g)
+	return g
+}
+
+// NewGaugeVec creates and registers a gauge partitioned by the given labels
+func NewGaugeVec(subsystem, name, help string, labels ...string) *prometheus.GaugeVec {
+	g := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: subsystem,
+		Name:      name,
+		Help:      help,
+	}, labels)
+	registry.MustRegister(g)
+	return g
+}
+
+// Handler returns the HTTP handler serving the node metrics
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// Server serves the node metrics on a dedicated listener
+type Server struct {
+	srv *http.Server
+}
+
+// NewServer returns the metrics server
+func NewServer(cfg Config) *Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+
+	return &Server{
+		srv: &http.Server{
+			Addr:              fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+			Handler:           mux,
+			ReadHeaderTimeout: readHeaderTimeout,
+		},
+	}
+}
+
+// Start serves the metrics until the server is stopped
+func (s *Server) Start() error {
+	log.Infof("metrics server started: %s", s.srv.Addr)
+	if err := s.srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// Stop shuts down the metrics server
+func (s *Server) Stop() error {
+	return s.srv.Shutdown(context.Background())
+}
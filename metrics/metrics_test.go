@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorders(t *testing.T) {
+	RPCRequest("sync_getOffChainData", time.Now(), false)
+	RPCRequest("sync_getOffChainData", time.Now(), true)
+	require.Equal(t, 1.0, testutil.ToFloat64(rpcRequests.WithLabelValues("sync_getOffChainData", ResultSuccess)))
+	require.Equal(t, 1.0, testutil.ToFloat64(rpcRequests.WithLabelValues("sync_getOffChainData", ResultError)))
+
+	DBOperation("GetOffChainData", time.Now(), errors.New("test error"))
+	require.Equal(t, 1.0, testutil.ToFloat64(dbOperations.WithLabelValues("GetOffChainData", ResultError)))
+
+	LastProcessedBlock(42)
+	require.Equal(t, 42.0, testutil.ToFloat64(syncLastProcessedBlock))
+
+	L1Head(50)
+	require.Equal(t, 50.0, testutil.ToFloat64(syncL1Head))
+
+	BatchResolved(SourceMember)
+	require.Equal(t, 1.0, testutil.ToFloat64(syncResolvedBatches.WithLabelValues(SourceMember)))
+
+	MemberResolve("0x01", time.Now(), nil)
+	MemberResolve("0x01", time.Now(), errors.New("test error"))
+	require.Equal(t, 1.0, testutil.ToFloat64(syncMemberResolves.WithLabelValues("0x01", ResultSuccess)))
+	require.Equal(t, 1.0, testutil.ToFloat64(syncMemberResolves.WithLabelValues("0x01", ResultError)))
+
+	SignSequence(SignResultUnauthorized)
+	require.Equal(t, 1.0, testutil.ToFloat64(signerSignatures.WithLabelValues(SignResultUnauthorized)))
+
+	PublishValue("ipfs", time.Now(), nil)
+	require.Equal(t, 1.0, testutil.ToFloat64(publisherValues.WithLabelValues("ipfs", ResultSuccess)))
+
+	GossipAnnouncement(GossipReceived, GossipRejected)
+	require.Equal(t, 1.0, testutil.ToFloat64(gossipAnnouncements.WithLabelValues(GossipReceived, GossipRejected)))
+	GossipFetched(3)
+	require.Equal(t, 3.0, testutil.ToFloat64(gossipFetchedValues))
+	AttestationSubmitted(12, nil)
+	require.Equal(t, 12.0, testutil.ToFloat64(attestationLastBatch))
+	AttestationSubmitted(15, errors.New("reverted"))
+	require.Equal(t, 12.0, testutil.ToFloat64(attestationLastBatch))
+	require.Equal(t, 1.0, testutil.ToFloat64(attestationSubmissions.WithLabelValues(ResultError)))
+	CertificateAssembled(nil)
+	require.Equal(t, 1.0, testutil.ToFloat64(certificatesAssembled.WithLabelValues(ResultSuccess)))
+	StreamEvents("kafka", 3, nil)
+	require.Equal(t, 3.0, testutil.ToFloat64(streamEvents.WithLabelValues("kafka", ResultSuccess)))
+	WebhookDelivery("data.stored", WebhookDropped)
+	require.Equal(t, 1.0, testutil.ToFloat64(webhookDeliveries.WithLabelValues("data.stored", WebhookDropped)))
+	ShardedValue(nil)
+	require.Equal(t, 1.0, testutil.ToFloat64(shardedValues.WithLabelValues(ResultSuccess)))
+}
+
+func TestHandler(t *testing.T) {
+	ClientRequest("status_getStatus", time.Now(), false)
+
+	res := httptest.NewRecorder()
+	Handler().ServeHTTP(res, httptest.NewRequest("GET", "/metrics", nil))
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.Contains(t, string(body), `dac_client_requests_total{method="status_getStatus",result="success"} 1`)
+	require.Contains(t, string(body), "dac_client_request_duration_seconds_bucket")
+	require.Contains(t, string(body), "go_goroutines")
+}
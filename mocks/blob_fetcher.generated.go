@@ -0,0 +1,100 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	common "github.com/ethereum/go-ethereum/common"
+
+	kzg4844 "github.com/ethereum/go-ethereum/crypto/kzg4844"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BlobFetcher is an autogenerated mock type for the BlobFetcher type
+type BlobFetcher struct {
+	mock.Mock
+}
+
+type BlobFetcher_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BlobFetcher) EXPECT() *BlobFetcher_Expecter {
+	return &BlobFetcher_Expecter{mock: &_m.Mock}
+}
+
+// GetBlobs provides a mock function with given fields: ctx, timestamp, hashes
+func (_m *BlobFetcher) GetBlobs(ctx context.Context, timestamp uint64, hashes []common.Hash) ([]kzg4844.Blob, error) {
+	ret := _m.Called(ctx, timestamp, hashes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlobs")
+	}
+
+	var r0 []kzg4844.Blob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, []common.Hash) ([]kzg4844.Blob, error)); ok {
+		return rf(ctx, timestamp, hashes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, []common.Hash) []kzg4844.Blob); ok {
+		r0 = rf(ctx, timestamp, hashes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]kzg4844.Blob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, []common.Hash) error); ok {
+		r1 = rf(ctx, timestamp, hashes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BlobFetcher_GetBlobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBlobs'
+type BlobFetcher_GetBlobs_Call struct {
+	*mock.Call
+}
+
+// GetBlobs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - timestamp uint64
+//   - hashes []common.Hash
+func (_e *BlobFetcher_Expecter) GetBlobs(ctx interface{}, timestamp interface{}, hashes interface{}) *BlobFetcher_GetBlobs_Call {
+	return &BlobFetcher_GetBlobs_Call{Call: _e.mock.On("GetBlobs", ctx, timestamp, hashes)}
+}
+
+func (_c *BlobFetcher_GetBlobs_Call) Run(run func(ctx context.Context, timestamp uint64, hashes []common.Hash)) *BlobFetcher_GetBlobs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].([]common.Hash))
+	})
+	return _c
+}
+
+func (_c *BlobFetcher_GetBlobs_Call) Return(_a0 []kzg4844.Blob, _a1 error) *BlobFetcher_GetBlobs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BlobFetcher_GetBlobs_Call) RunAndReturn(run func(context.Context, uint64, []common.Hash) ([]kzg4844.Blob, error)) *BlobFetcher_GetBlobs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBlobFetcher creates a new instance of BlobFetcher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBlobFetcher(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BlobFetcher {
+	mock := &BlobFetcher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -25,6 +25,113 @@ func (_m *Client) EXPECT() *Client_Expecter {
 	return &Client_Expecter{mock: &_m.Mock}
 }
 
+// AnnounceKeys provides a mock function with given fields: ctx, announcement
+func (_m *Client) AnnounceKeys(ctx context.Context, announcement types.KeyAnnouncement) error {
+	ret := _m.Called(ctx, announcement)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AnnounceKeys")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.KeyAnnouncement) error); ok {
+		r0 = rf(ctx, announcement)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Client_AnnounceKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AnnounceKeys'
+type Client_AnnounceKeys_Call struct {
+	*mock.Call
+}
+
+// AnnounceKeys is a helper method to define mock.On call
+//   - ctx context.Context
+//   - announcement types.KeyAnnouncement
+func (_e *Client_Expecter) AnnounceKeys(ctx interface{}, announcement interface{}) *Client_AnnounceKeys_Call {
+	return &Client_AnnounceKeys_Call{Call: _e.mock.On("AnnounceKeys", ctx, announcement)}
+}
+
+func (_c *Client_AnnounceKeys_Call) Run(run func(ctx context.Context, announcement types.KeyAnnouncement)) *Client_AnnounceKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.KeyAnnouncement))
+	})
+	return _c
+}
+
+func (_c *Client_AnnounceKeys_Call) Return(_a0 error) *Client_AnnounceKeys_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_AnnounceKeys_Call) RunAndReturn(run func(context.Context, types.KeyAnnouncement) error) *Client_AnnounceKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ChallengeCustody provides a mock function with given fields: ctx, hash, nonce
+func (_m *Client) ChallengeCustody(ctx context.Context, hash common.Hash, nonce common.Hash) (common.Hash, error) {
+	ret := _m.Called(ctx, hash, nonce)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChallengeCustody")
+	}
+
+	var r0 common.Hash
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, common.Hash) (common.Hash, error)); ok {
+		return rf(ctx, hash, nonce)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, common.Hash) common.Hash); ok {
+		r0 = rf(ctx, hash, nonce)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(common.Hash)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash, common.Hash) error); ok {
+		r1 = rf(ctx, hash, nonce)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_ChallengeCustody_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChallengeCustody'
+type Client_ChallengeCustody_Call struct {
+	*mock.Call
+}
+
+// ChallengeCustody is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hash common.Hash
+//   - nonce common.Hash
+func (_e *Client_Expecter) ChallengeCustody(ctx interface{}, hash interface{}, nonce interface{}) *Client_ChallengeCustody_Call {
+	return &Client_ChallengeCustody_Call{Call: _e.mock.On("ChallengeCustody", ctx, hash, nonce)}
+}
+
+func (_c *Client_ChallengeCustody_Call) Run(run func(ctx context.Context, hash common.Hash, nonce common.Hash)) *Client_ChallengeCustody_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash), args[2].(common.Hash))
+	})
+	return _c
+}
+
+func (_c *Client_ChallengeCustody_Call) Return(_a0 common.Hash, _a1 error) *Client_ChallengeCustody_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_ChallengeCustody_Call) RunAndReturn(run func(context.Context, common.Hash, common.Hash) (common.Hash, error)) *Client_ChallengeCustody_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetOffChainData provides a mock function with given fields: ctx, hash
 func (_m *Client) GetOffChainData(ctx context.Context, hash common.Hash) ([]byte, error) {
 	ret := _m.Called(ctx, hash)
@@ -84,6 +191,74 @@ func (_c *Client_GetOffChainData_Call) RunAndReturn(run func(context.Context, co
 	return _c
 }
 
+// GetSignedOffChainData provides a mock function with given fields: ctx, hash
+func (_m *Client) GetSignedOffChainData(ctx context.Context, hash common.Hash) ([]byte, []byte, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSignedOffChainData")
+	}
+
+	var r0 []byte
+	var r1 []byte
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) ([]byte, []byte, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) []byte); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash) []byte); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, common.Hash) error); ok {
+		r2 = rf(ctx, hash)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Client_GetSignedOffChainData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSignedOffChainData'
+type Client_GetSignedOffChainData_Call struct {
+	*mock.Call
+}
+
+// GetSignedOffChainData is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hash common.Hash
+func (_e *Client_Expecter) GetSignedOffChainData(ctx interface{}, hash interface{}) *Client_GetSignedOffChainData_Call {
+	return &Client_GetSignedOffChainData_Call{Call: _e.mock.On("GetSignedOffChainData", ctx, hash)}
+}
+
+func (_c *Client_GetSignedOffChainData_Call) Run(run func(ctx context.Context, hash common.Hash)) *Client_GetSignedOffChainData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash))
+	})
+	return _c
+}
+
+func (_c *Client_GetSignedOffChainData_Call) Return(_a0 []byte, _a1 []byte, _a2 error) *Client_GetSignedOffChainData_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *Client_GetSignedOffChainData_Call) RunAndReturn(run func(context.Context, common.Hash) ([]byte, []byte, error)) *Client_GetSignedOffChainData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetStatus provides a mock function with given fields: ctx
 func (_m *Client) GetStatus(ctx context.Context) (*types.DACStatus, error) {
 	ret := _m.Called(ctx)
@@ -201,6 +376,125 @@ func (_c *Client_ListOffChainData_Call) RunAndReturn(run func(context.Context, [
 	return _c
 }
 
+// ListOffChainDataPage provides a mock function with given fields: ctx, after, limit
+func (_m *Client) ListOffChainDataPage(ctx context.Context, after common.Hash, limit uint) ([]types.OffChainData, error) {
+	ret := _m.Called(ctx, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOffChainDataPage")
+	}
+
+	var r0 []types.OffChainData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, uint) ([]types.OffChainData, error)); ok {
+		return rf(ctx, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, uint) []types.OffChainData); ok {
+		r0 = rf(ctx, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.OffChainData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash, uint) error); ok {
+		r1 = rf(ctx, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_ListOffChainDataPage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOffChainDataPage'
+type Client_ListOffChainDataPage_Call struct {
+	*mock.Call
+}
+
+// ListOffChainDataPage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - after common.Hash
+//   - limit uint
+func (_e *Client_Expecter) ListOffChainDataPage(ctx interface{}, after interface{}, limit interface{}) *Client_ListOffChainDataPage_Call {
+	return &Client_ListOffChainDataPage_Call{Call: _e.mock.On("ListOffChainDataPage", ctx, after, limit)}
+}
+
+func (_c *Client_ListOffChainDataPage_Call) Run(run func(ctx context.Context, after common.Hash, limit uint)) *Client_ListOffChainDataPage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *Client_ListOffChainDataPage_Call) Return(_a0 []types.OffChainData, _a1 error) *Client_ListOffChainDataPage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_ListOffChainDataPage_Call) RunAndReturn(run func(context.Context, common.Hash, uint) ([]types.OffChainData, error)) *Client_ListOffChainDataPage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SignAvailability provides a mock function with given fields: ctx, dataHash
+func (_m *Client) SignAvailability(ctx context.Context, dataHash common.Hash) ([]byte, error) {
+	ret := _m.Called(ctx, dataHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SignAvailability")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) ([]byte, error)); ok {
+		return rf(ctx, dataHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) []byte); ok {
+		r0 = rf(ctx, dataHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash) error); ok {
+		r1 = rf(ctx, dataHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_SignAvailability_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SignAvailability'
+type Client_SignAvailability_Call struct {
+	*mock.Call
+}
+
+// SignAvailability is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dataHash common.Hash
+func (_e *Client_Expecter) SignAvailability(ctx interface{}, dataHash interface{}) *Client_SignAvailability_Call {
+	return &Client_SignAvailability_Call{Call: _e.mock.On("SignAvailability", ctx, dataHash)}
+}
+
+func (_c *Client_SignAvailability_Call) Run(run func(ctx context.Context, dataHash common.Hash)) *Client_SignAvailability_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash))
+	})
+	return _c
+}
+
+func (_c *Client_SignAvailability_Call) Return(_a0 []byte, _a1 error) *Client_SignAvailability_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_SignAvailability_Call) RunAndReturn(run func(context.Context, common.Hash) ([]byte, error)) *Client_SignAvailability_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SignSequence provides a mock function with given fields: ctx, signedSequence
 func (_m *Client) SignSequence(ctx context.Context, signedSequence types.SignedSequence) ([]byte, error) {
 	ret := _m.Called(ctx, signedSequence)
@@ -25,6 +25,70 @@ func (_m *DB) EXPECT() *DB_Expecter {
 	return &DB_Expecter{mock: &_m.Mock}
 }
 
+// CountOffChainDataBefore provides a mock function with given fields: ctx, beforeBatch
+func (_m *DB) CountOffChainDataBefore(ctx context.Context, beforeBatch uint64) (uint64, uint64, error) {
+	ret := _m.Called(ctx, beforeBatch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountOffChainDataBefore")
+	}
+
+	var r0 uint64
+	var r1 uint64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (uint64, uint64, error)); ok {
+		return rf(ctx, beforeBatch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) uint64); ok {
+		r0 = rf(ctx, beforeBatch)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) uint64); ok {
+		r1 = rf(ctx, beforeBatch)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uint64) error); ok {
+		r2 = rf(ctx, beforeBatch)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DB_CountOffChainDataBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountOffChainDataBefore'
+type DB_CountOffChainDataBefore_Call struct {
+	*mock.Call
+}
+
+// CountOffChainDataBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - beforeBatch uint64
+func (_e *DB_Expecter) CountOffChainDataBefore(ctx interface{}, beforeBatch interface{}) *DB_CountOffChainDataBefore_Call {
+	return &DB_CountOffChainDataBefore_Call{Call: _e.mock.On("CountOffChainDataBefore", ctx, beforeBatch)}
+}
+
+func (_c *DB_CountOffChainDataBefore_Call) Run(run func(ctx context.Context, beforeBatch uint64)) *DB_CountOffChainDataBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *DB_CountOffChainDataBefore_Call) Return(_a0 uint64, _a1 uint64, _a2 error) *DB_CountOffChainDataBefore_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *DB_CountOffChainDataBefore_Call) RunAndReturn(run func(context.Context, uint64) (uint64, uint64, error)) *DB_CountOffChainDataBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CountOffchainData provides a mock function with given fields: ctx
 func (_m *DB) CountOffchainData(ctx context.Context) (uint64, error) {
 	ret := _m.Called(ctx)
@@ -81,6 +145,100 @@ func (_c *DB_CountOffchainData_Call) RunAndReturn(run func(context.Context) (uin
 	return _c
 }
 
+// DeleteOffChainData provides a mock function with given fields: ctx, keys
+func (_m *DB) DeleteOffChainData(ctx context.Context, keys []common.Hash) error {
+	ret := _m.Called(ctx, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteOffChainData")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []common.Hash) error); ok {
+		r0 = rf(ctx, keys)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DB_DeleteOffChainData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteOffChainData'
+type DB_DeleteOffChainData_Call struct {
+	*mock.Call
+}
+
+// DeleteOffChainData is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keys []common.Hash
+func (_e *DB_Expecter) DeleteOffChainData(ctx interface{}, keys interface{}) *DB_DeleteOffChainData_Call {
+	return &DB_DeleteOffChainData_Call{Call: _e.mock.On("DeleteOffChainData", ctx, keys)}
+}
+
+func (_c *DB_DeleteOffChainData_Call) Run(run func(ctx context.Context, keys []common.Hash)) *DB_DeleteOffChainData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]common.Hash))
+	})
+	return _c
+}
+
+func (_c *DB_DeleteOffChainData_Call) Return(_a0 error) *DB_DeleteOffChainData_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DB_DeleteOffChainData_Call) RunAndReturn(run func(context.Context, []common.Hash) error) *DB_DeleteOffChainData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteOutboxEvents provides a mock function with given fields: ctx, ids
+func (_m *DB) DeleteOutboxEvents(ctx context.Context, ids []uint64) error {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteOutboxEvents")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uint64) error); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DB_DeleteOutboxEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteOutboxEvents'
+type DB_DeleteOutboxEvents_Call struct {
+	*mock.Call
+}
+
+// DeleteOutboxEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []uint64
+func (_e *DB_Expecter) DeleteOutboxEvents(ctx interface{}, ids interface{}) *DB_DeleteOutboxEvents_Call {
+	return &DB_DeleteOutboxEvents_Call{Call: _e.mock.On("DeleteOutboxEvents", ctx, ids)}
+}
+
+func (_c *DB_DeleteOutboxEvents_Call) Run(run func(ctx context.Context, ids []uint64)) *DB_DeleteOutboxEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]uint64))
+	})
+	return _c
+}
+
+func (_c *DB_DeleteOutboxEvents_Call) Return(_a0 error) *DB_DeleteOutboxEvents_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DB_DeleteOutboxEvents_Call) RunAndReturn(run func(context.Context, []uint64) error) *DB_DeleteOutboxEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteUnresolvedBatchKeys provides a mock function with given fields: ctx, bks
 func (_m *DB) DeleteUnresolvedBatchKeys(ctx context.Context, bks []types.BatchKey) error {
 	ret := _m.Called(ctx, bks)
@@ -128,6 +286,123 @@ func (_c *DB_DeleteUnresolvedBatchKeys_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// GetCertificate provides a mock function with given fields: ctx, dataHash
+func (_m *DB) GetCertificate(ctx context.Context, dataHash common.Hash) (*types.Certificate, error) {
+	ret := _m.Called(ctx, dataHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCertificate")
+	}
+
+	var r0 *types.Certificate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) (*types.Certificate, error)); ok {
+		return rf(ctx, dataHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) *types.Certificate); ok {
+		r0 = rf(ctx, dataHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Certificate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash) error); ok {
+		r1 = rf(ctx, dataHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_GetCertificate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCertificate'
+type DB_GetCertificate_Call struct {
+	*mock.Call
+}
+
+// GetCertificate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dataHash common.Hash
+func (_e *DB_Expecter) GetCertificate(ctx interface{}, dataHash interface{}) *DB_GetCertificate_Call {
+	return &DB_GetCertificate_Call{Call: _e.mock.On("GetCertificate", ctx, dataHash)}
+}
+
+func (_c *DB_GetCertificate_Call) Run(run func(ctx context.Context, dataHash common.Hash)) *DB_GetCertificate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash))
+	})
+	return _c
+}
+
+func (_c *DB_GetCertificate_Call) Return(_a0 *types.Certificate, _a1 error) *DB_GetCertificate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_GetCertificate_Call) RunAndReturn(run func(context.Context, common.Hash) (*types.Certificate, error)) *DB_GetCertificate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLastAttestation provides a mock function with given fields: ctx
+func (_m *DB) GetLastAttestation(ctx context.Context) (*types.Attestation, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastAttestation")
+	}
+
+	var r0 *types.Attestation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*types.Attestation, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *types.Attestation); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Attestation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_GetLastAttestation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastAttestation'
+type DB_GetLastAttestation_Call struct {
+	*mock.Call
+}
+
+// GetLastAttestation is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *DB_Expecter) GetLastAttestation(ctx interface{}) *DB_GetLastAttestation_Call {
+	return &DB_GetLastAttestation_Call{Call: _e.mock.On("GetLastAttestation", ctx)}
+}
+
+func (_c *DB_GetLastAttestation_Call) Run(run func(ctx context.Context)) *DB_GetLastAttestation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *DB_GetLastAttestation_Call) Return(_a0 *types.Attestation, _a1 error) *DB_GetLastAttestation_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_GetLastAttestation_Call) RunAndReturn(run func(context.Context) (*types.Attestation, error)) *DB_GetLastAttestation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetLastProcessedBlock provides a mock function with given fields: ctx, task
 func (_m *DB) GetLastProcessedBlock(ctx context.Context, task string) (uint64, error) {
 	ret := _m.Called(ctx, task)
@@ -244,29 +519,29 @@ func (_c *DB_GetOffChainData_Call) RunAndReturn(run func(context.Context, common
 	return _c
 }
 
-// GetUnresolvedBatchKeys provides a mock function with given fields: ctx, limit
-func (_m *DB) GetUnresolvedBatchKeys(ctx context.Context, limit uint) ([]types.BatchKey, error) {
-	ret := _m.Called(ctx, limit)
+// GetOffChainDataKeys provides a mock function with given fields: ctx, fromBatch, limit
+func (_m *DB) GetOffChainDataKeys(ctx context.Context, fromBatch uint64, limit uint) ([]types.BatchKey, error) {
+	ret := _m.Called(ctx, fromBatch, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUnresolvedBatchKeys")
+		panic("no return value specified for GetOffChainDataKeys")
 	}
 
 	var r0 []types.BatchKey
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]types.BatchKey, error)); ok {
-		return rf(ctx, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint) ([]types.BatchKey, error)); ok {
+		return rf(ctx, fromBatch, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint) []types.BatchKey); ok {
-		r0 = rf(ctx, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint) []types.BatchKey); ok {
+		r0 = rf(ctx, fromBatch, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]types.BatchKey)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
-		r1 = rf(ctx, limit)
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint) error); ok {
+		r1 = rf(ctx, fromBatch, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -274,58 +549,59 @@ func (_m *DB) GetUnresolvedBatchKeys(ctx context.Context, limit uint) ([]types.B
 	return r0, r1
 }
 
-// DB_GetUnresolvedBatchKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUnresolvedBatchKeys'
-type DB_GetUnresolvedBatchKeys_Call struct {
+// DB_GetOffChainDataKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOffChainDataKeys'
+type DB_GetOffChainDataKeys_Call struct {
 	*mock.Call
 }
 
-// GetUnresolvedBatchKeys is a helper method to define mock.On call
+// GetOffChainDataKeys is a helper method to define mock.On call
 //   - ctx context.Context
+//   - fromBatch uint64
 //   - limit uint
-func (_e *DB_Expecter) GetUnresolvedBatchKeys(ctx interface{}, limit interface{}) *DB_GetUnresolvedBatchKeys_Call {
-	return &DB_GetUnresolvedBatchKeys_Call{Call: _e.mock.On("GetUnresolvedBatchKeys", ctx, limit)}
+func (_e *DB_Expecter) GetOffChainDataKeys(ctx interface{}, fromBatch interface{}, limit interface{}) *DB_GetOffChainDataKeys_Call {
+	return &DB_GetOffChainDataKeys_Call{Call: _e.mock.On("GetOffChainDataKeys", ctx, fromBatch, limit)}
 }
 
-func (_c *DB_GetUnresolvedBatchKeys_Call) Run(run func(ctx context.Context, limit uint)) *DB_GetUnresolvedBatchKeys_Call {
+func (_c *DB_GetOffChainDataKeys_Call) Run(run func(ctx context.Context, fromBatch uint64, limit uint)) *DB_GetOffChainDataKeys_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uint))
+		run(args[0].(context.Context), args[1].(uint64), args[2].(uint))
 	})
 	return _c
 }
 
-func (_c *DB_GetUnresolvedBatchKeys_Call) Return(_a0 []types.BatchKey, _a1 error) *DB_GetUnresolvedBatchKeys_Call {
+func (_c *DB_GetOffChainDataKeys_Call) Return(_a0 []types.BatchKey, _a1 error) *DB_GetOffChainDataKeys_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *DB_GetUnresolvedBatchKeys_Call) RunAndReturn(run func(context.Context, uint) ([]types.BatchKey, error)) *DB_GetUnresolvedBatchKeys_Call {
+func (_c *DB_GetOffChainDataKeys_Call) RunAndReturn(run func(context.Context, uint64, uint) ([]types.BatchKey, error)) *DB_GetOffChainDataKeys_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListOffChainData provides a mock function with given fields: ctx, keys
-func (_m *DB) ListOffChainData(ctx context.Context, keys []common.Hash) ([]types.OffChainData, error) {
-	ret := _m.Called(ctx, keys)
+// GetOutboxEvents provides a mock function with given fields: ctx, limit
+func (_m *DB) GetOutboxEvents(ctx context.Context, limit uint) ([]types.StoredDataEvent, error) {
+	ret := _m.Called(ctx, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListOffChainData")
+		panic("no return value specified for GetOutboxEvents")
 	}
 
-	var r0 []types.OffChainData
+	var r0 []types.StoredDataEvent
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, []common.Hash) ([]types.OffChainData, error)); ok {
-		return rf(ctx, keys)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]types.StoredDataEvent, error)); ok {
+		return rf(ctx, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, []common.Hash) []types.OffChainData); ok {
-		r0 = rf(ctx, keys)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []types.StoredDataEvent); ok {
+		r0 = rf(ctx, limit)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]types.OffChainData)
+			r0 = ret.Get(0).([]types.StoredDataEvent)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, []common.Hash) error); ok {
-		r1 = rf(ctx, keys)
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -333,54 +609,1192 @@ func (_m *DB) ListOffChainData(ctx context.Context, keys []common.Hash) ([]types
 	return r0, r1
 }
 
-// DB_ListOffChainData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOffChainData'
-type DB_ListOffChainData_Call struct {
+// DB_GetOutboxEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOutboxEvents'
+type DB_GetOutboxEvents_Call struct {
 	*mock.Call
 }
 
-// ListOffChainData is a helper method to define mock.On call
+// GetOutboxEvents is a helper method to define mock.On call
 //   - ctx context.Context
-//   - keys []common.Hash
-func (_e *DB_Expecter) ListOffChainData(ctx interface{}, keys interface{}) *DB_ListOffChainData_Call {
-	return &DB_ListOffChainData_Call{Call: _e.mock.On("ListOffChainData", ctx, keys)}
+//   - limit uint
+func (_e *DB_Expecter) GetOutboxEvents(ctx interface{}, limit interface{}) *DB_GetOutboxEvents_Call {
+	return &DB_GetOutboxEvents_Call{Call: _e.mock.On("GetOutboxEvents", ctx, limit)}
 }
 
-func (_c *DB_ListOffChainData_Call) Run(run func(ctx context.Context, keys []common.Hash)) *DB_ListOffChainData_Call {
+func (_c *DB_GetOutboxEvents_Call) Run(run func(ctx context.Context, limit uint)) *DB_GetOutboxEvents_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].([]common.Hash))
+		run(args[0].(context.Context), args[1].(uint))
 	})
 	return _c
 }
 
-func (_c *DB_ListOffChainData_Call) Return(_a0 []types.OffChainData, _a1 error) *DB_ListOffChainData_Call {
+func (_c *DB_GetOutboxEvents_Call) Return(_a0 []types.StoredDataEvent, _a1 error) *DB_GetOutboxEvents_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *DB_ListOffChainData_Call) RunAndReturn(run func(context.Context, []common.Hash) ([]types.OffChainData, error)) *DB_ListOffChainData_Call {
+func (_c *DB_GetOutboxEvents_Call) RunAndReturn(run func(context.Context, uint) ([]types.StoredDataEvent, error)) *DB_GetOutboxEvents_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// StoreLastProcessedBlock provides a mock function with given fields: ctx, block, task
-func (_m *DB) StoreLastProcessedBlock(ctx context.Context, block uint64, task string) error {
-	ret := _m.Called(ctx, block, task)
+// GetProvenance provides a mock function with given fields: ctx, key
+func (_m *DB) GetProvenance(ctx context.Context, key common.Hash) ([]types.Provenance, error) {
+	ret := _m.Called(ctx, key)
 
 	if len(ret) == 0 {
-		panic("no return value specified for StoreLastProcessedBlock")
+		panic("no return value specified for GetProvenance")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint64, string) error); ok {
-		r0 = rf(ctx, block, task)
+	var r0 []types.Provenance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) ([]types.Provenance, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) []types.Provenance); ok {
+		r0 = rf(ctx, key)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.Provenance)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// DB_StoreLastProcessedBlock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreLastProcessedBlock'
+// DB_GetProvenance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProvenance'
+type DB_GetProvenance_Call struct {
+	*mock.Call
+}
+
+// GetProvenance is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key common.Hash
+func (_e *DB_Expecter) GetProvenance(ctx interface{}, key interface{}) *DB_GetProvenance_Call {
+	return &DB_GetProvenance_Call{Call: _e.mock.On("GetProvenance", ctx, key)}
+}
+
+func (_c *DB_GetProvenance_Call) Run(run func(ctx context.Context, key common.Hash)) *DB_GetProvenance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash))
+	})
+	return _c
+}
+
+func (_c *DB_GetProvenance_Call) Return(_a0 []types.Provenance, _a1 error) *DB_GetProvenance_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_GetProvenance_Call) RunAndReturn(run func(context.Context, common.Hash) ([]types.Provenance, error)) *DB_GetProvenance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPublication provides a mock function with given fields: ctx, key, backend
+func (_m *DB) GetPublication(ctx context.Context, key common.Hash, backend string) (*types.Publication, error) {
+	ret := _m.Called(ctx, key, backend)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPublication")
+	}
+
+	var r0 *types.Publication
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, string) (*types.Publication, error)); ok {
+		return rf(ctx, key, backend)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, string) *types.Publication); ok {
+		r0 = rf(ctx, key, backend)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Publication)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash, string) error); ok {
+		r1 = rf(ctx, key, backend)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_GetPublication_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPublication'
+type DB_GetPublication_Call struct {
+	*mock.Call
+}
+
+// GetPublication is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key common.Hash
+//   - backend string
+func (_e *DB_Expecter) GetPublication(ctx interface{}, key interface{}, backend interface{}) *DB_GetPublication_Call {
+	return &DB_GetPublication_Call{Call: _e.mock.On("GetPublication", ctx, key, backend)}
+}
+
+func (_c *DB_GetPublication_Call) Run(run func(ctx context.Context, key common.Hash, backend string)) *DB_GetPublication_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *DB_GetPublication_Call) Return(_a0 *types.Publication, _a1 error) *DB_GetPublication_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_GetPublication_Call) RunAndReturn(run func(context.Context, common.Hash, string) (*types.Publication, error)) *DB_GetPublication_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetServedValue provides a mock function with given fields: ctx, key
+func (_m *DB) GetServedValue(ctx context.Context, key common.Hash) (*types.ServedValue, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetServedValue")
+	}
+
+	var r0 *types.ServedValue
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) (*types.ServedValue, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) *types.ServedValue); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.ServedValue)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_GetServedValue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetServedValue'
+type DB_GetServedValue_Call struct {
+	*mock.Call
+}
+
+// GetServedValue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key common.Hash
+func (_e *DB_Expecter) GetServedValue(ctx interface{}, key interface{}) *DB_GetServedValue_Call {
+	return &DB_GetServedValue_Call{Call: _e.mock.On("GetServedValue", ctx, key)}
+}
+
+func (_c *DB_GetServedValue_Call) Run(run func(ctx context.Context, key common.Hash)) *DB_GetServedValue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash))
+	})
+	return _c
+}
+
+func (_c *DB_GetServedValue_Call) Return(_a0 *types.ServedValue, _a1 error) *DB_GetServedValue_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_GetServedValue_Call) RunAndReturn(run func(context.Context, common.Hash) (*types.ServedValue, error)) *DB_GetServedValue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetShardCommitment provides a mock function with given fields: ctx, key
+func (_m *DB) GetShardCommitment(ctx context.Context, key common.Hash) (*types.ShardCommitment, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetShardCommitment")
+	}
+
+	var r0 *types.ShardCommitment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) (*types.ShardCommitment, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) *types.ShardCommitment); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.ShardCommitment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_GetShardCommitment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetShardCommitment'
+type DB_GetShardCommitment_Call struct {
+	*mock.Call
+}
+
+// GetShardCommitment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key common.Hash
+func (_e *DB_Expecter) GetShardCommitment(ctx interface{}, key interface{}) *DB_GetShardCommitment_Call {
+	return &DB_GetShardCommitment_Call{Call: _e.mock.On("GetShardCommitment", ctx, key)}
+}
+
+func (_c *DB_GetShardCommitment_Call) Run(run func(ctx context.Context, key common.Hash)) *DB_GetShardCommitment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash))
+	})
+	return _c
+}
+
+func (_c *DB_GetShardCommitment_Call) Return(_a0 *types.ShardCommitment, _a1 error) *DB_GetShardCommitment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_GetShardCommitment_Call) RunAndReturn(run func(context.Context, common.Hash) (*types.ShardCommitment, error)) *DB_GetShardCommitment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetShards provides a mock function with given fields: ctx, key, indices
+func (_m *DB) GetShards(ctx context.Context, key common.Hash, indices []uint) ([]types.Shard, error) {
+	ret := _m.Called(ctx, key, indices)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetShards")
+	}
+
+	var r0 []types.Shard
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, []uint) ([]types.Shard, error)); ok {
+		return rf(ctx, key, indices)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, []uint) []types.Shard); ok {
+		r0 = rf(ctx, key, indices)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.Shard)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash, []uint) error); ok {
+		r1 = rf(ctx, key, indices)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_GetShards_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetShards'
+type DB_GetShards_Call struct {
+	*mock.Call
+}
+
+// GetShards is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key common.Hash
+//   - indices []uint
+func (_e *DB_Expecter) GetShards(ctx interface{}, key interface{}, indices interface{}) *DB_GetShards_Call {
+	return &DB_GetShards_Call{Call: _e.mock.On("GetShards", ctx, key, indices)}
+}
+
+func (_c *DB_GetShards_Call) Run(run func(ctx context.Context, key common.Hash, indices []uint)) *DB_GetShards_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash), args[2].([]uint))
+	})
+	return _c
+}
+
+func (_c *DB_GetShards_Call) Return(_a0 []types.Shard, _a1 error) *DB_GetShards_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_GetShards_Call) RunAndReturn(run func(context.Context, common.Hash, []uint) ([]types.Shard, error)) *DB_GetShards_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTombstones provides a mock function with given fields: ctx, keys
+func (_m *DB) GetTombstones(ctx context.Context, keys []common.Hash) ([]types.Tombstone, error) {
+	ret := _m.Called(ctx, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTombstones")
+	}
+
+	var r0 []types.Tombstone
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []common.Hash) ([]types.Tombstone, error)); ok {
+		return rf(ctx, keys)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []common.Hash) []types.Tombstone); ok {
+		r0 = rf(ctx, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.Tombstone)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []common.Hash) error); ok {
+		r1 = rf(ctx, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_GetTombstones_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTombstones'
+type DB_GetTombstones_Call struct {
+	*mock.Call
+}
+
+// GetTombstones is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keys []common.Hash
+func (_e *DB_Expecter) GetTombstones(ctx interface{}, keys interface{}) *DB_GetTombstones_Call {
+	return &DB_GetTombstones_Call{Call: _e.mock.On("GetTombstones", ctx, keys)}
+}
+
+func (_c *DB_GetTombstones_Call) Run(run func(ctx context.Context, keys []common.Hash)) *DB_GetTombstones_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]common.Hash))
+	})
+	return _c
+}
+
+func (_c *DB_GetTombstones_Call) Return(_a0 []types.Tombstone, _a1 error) *DB_GetTombstones_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_GetTombstones_Call) RunAndReturn(run func(context.Context, []common.Hash) ([]types.Tombstone, error)) *DB_GetTombstones_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUncertifiedKeys provides a mock function with given fields: ctx, limit
+func (_m *DB) GetUncertifiedKeys(ctx context.Context, limit uint) ([]common.Hash, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUncertifiedKeys")
+	}
+
+	var r0 []common.Hash
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]common.Hash, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []common.Hash); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]common.Hash)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_GetUncertifiedKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUncertifiedKeys'
+type DB_GetUncertifiedKeys_Call struct {
+	*mock.Call
+}
+
+// GetUncertifiedKeys is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit uint
+func (_e *DB_Expecter) GetUncertifiedKeys(ctx interface{}, limit interface{}) *DB_GetUncertifiedKeys_Call {
+	return &DB_GetUncertifiedKeys_Call{Call: _e.mock.On("GetUncertifiedKeys", ctx, limit)}
+}
+
+func (_c *DB_GetUncertifiedKeys_Call) Run(run func(ctx context.Context, limit uint)) *DB_GetUncertifiedKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *DB_GetUncertifiedKeys_Call) Return(_a0 []common.Hash, _a1 error) *DB_GetUncertifiedKeys_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_GetUncertifiedKeys_Call) RunAndReturn(run func(context.Context, uint) ([]common.Hash, error)) *DB_GetUncertifiedKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUnpublishedOffChainData provides a mock function with given fields: ctx, backend, limit
+func (_m *DB) GetUnpublishedOffChainData(ctx context.Context, backend string, limit uint) ([]types.OffChainData, error) {
+	ret := _m.Called(ctx, backend, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUnpublishedOffChainData")
+	}
+
+	var r0 []types.OffChainData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint) ([]types.OffChainData, error)); ok {
+		return rf(ctx, backend, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint) []types.OffChainData); ok {
+		r0 = rf(ctx, backend, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.OffChainData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint) error); ok {
+		r1 = rf(ctx, backend, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_GetUnpublishedOffChainData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUnpublishedOffChainData'
+type DB_GetUnpublishedOffChainData_Call struct {
+	*mock.Call
+}
+
+// GetUnpublishedOffChainData is a helper method to define mock.On call
+//   - ctx context.Context
+//   - backend string
+//   - limit uint
+func (_e *DB_Expecter) GetUnpublishedOffChainData(ctx interface{}, backend interface{}, limit interface{}) *DB_GetUnpublishedOffChainData_Call {
+	return &DB_GetUnpublishedOffChainData_Call{Call: _e.mock.On("GetUnpublishedOffChainData", ctx, backend, limit)}
+}
+
+func (_c *DB_GetUnpublishedOffChainData_Call) Run(run func(ctx context.Context, backend string, limit uint)) *DB_GetUnpublishedOffChainData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *DB_GetUnpublishedOffChainData_Call) Return(_a0 []types.OffChainData, _a1 error) *DB_GetUnpublishedOffChainData_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_GetUnpublishedOffChainData_Call) RunAndReturn(run func(context.Context, string, uint) ([]types.OffChainData, error)) *DB_GetUnpublishedOffChainData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUnresolvedBatchKeys provides a mock function with given fields: ctx, limit
+func (_m *DB) GetUnresolvedBatchKeys(ctx context.Context, limit uint) ([]types.BatchKey, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUnresolvedBatchKeys")
+	}
+
+	var r0 []types.BatchKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]types.BatchKey, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []types.BatchKey); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.BatchKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_GetUnresolvedBatchKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUnresolvedBatchKeys'
+type DB_GetUnresolvedBatchKeys_Call struct {
+	*mock.Call
+}
+
+// GetUnresolvedBatchKeys is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit uint
+func (_e *DB_Expecter) GetUnresolvedBatchKeys(ctx interface{}, limit interface{}) *DB_GetUnresolvedBatchKeys_Call {
+	return &DB_GetUnresolvedBatchKeys_Call{Call: _e.mock.On("GetUnresolvedBatchKeys", ctx, limit)}
+}
+
+func (_c *DB_GetUnresolvedBatchKeys_Call) Run(run func(ctx context.Context, limit uint)) *DB_GetUnresolvedBatchKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *DB_GetUnresolvedBatchKeys_Call) Return(_a0 []types.BatchKey, _a1 error) *DB_GetUnresolvedBatchKeys_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_GetUnresolvedBatchKeys_Call) RunAndReturn(run func(context.Context, uint) ([]types.BatchKey, error)) *DB_GetUnresolvedBatchKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUnshardedOffChainData provides a mock function with given fields: ctx, limit
+func (_m *DB) GetUnshardedOffChainData(ctx context.Context, limit uint) ([]types.OffChainData, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUnshardedOffChainData")
+	}
+
+	var r0 []types.OffChainData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]types.OffChainData, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []types.OffChainData); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.OffChainData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_GetUnshardedOffChainData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUnshardedOffChainData'
+type DB_GetUnshardedOffChainData_Call struct {
+	*mock.Call
+}
+
+// GetUnshardedOffChainData is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit uint
+func (_e *DB_Expecter) GetUnshardedOffChainData(ctx interface{}, limit interface{}) *DB_GetUnshardedOffChainData_Call {
+	return &DB_GetUnshardedOffChainData_Call{Call: _e.mock.On("GetUnshardedOffChainData", ctx, limit)}
+}
+
+func (_c *DB_GetUnshardedOffChainData_Call) Run(run func(ctx context.Context, limit uint)) *DB_GetUnshardedOffChainData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *DB_GetUnshardedOffChainData_Call) Return(_a0 []types.OffChainData, _a1 error) *DB_GetUnshardedOffChainData_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_GetUnshardedOffChainData_Call) RunAndReturn(run func(context.Context, uint) ([]types.OffChainData, error)) *DB_GetUnshardedOffChainData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HasSignedSequence provides a mock function with given fields: ctx, sequenceHash
+func (_m *DB) HasSignedSequence(ctx context.Context, sequenceHash common.Hash) (bool, error) {
+	ret := _m.Called(ctx, sequenceHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasSignedSequence")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) (bool, error)); ok {
+		return rf(ctx, sequenceHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) bool); ok {
+		r0 = rf(ctx, sequenceHash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash) error); ok {
+		r1 = rf(ctx, sequenceHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_HasSignedSequence_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasSignedSequence'
+type DB_HasSignedSequence_Call struct {
+	*mock.Call
+}
+
+// HasSignedSequence is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sequenceHash common.Hash
+func (_e *DB_Expecter) HasSignedSequence(ctx interface{}, sequenceHash interface{}) *DB_HasSignedSequence_Call {
+	return &DB_HasSignedSequence_Call{Call: _e.mock.On("HasSignedSequence", ctx, sequenceHash)}
+}
+
+func (_c *DB_HasSignedSequence_Call) Run(run func(ctx context.Context, sequenceHash common.Hash)) *DB_HasSignedSequence_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash))
+	})
+	return _c
+}
+
+func (_c *DB_HasSignedSequence_Call) Return(_a0 bool, _a1 error) *DB_HasSignedSequence_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_HasSignedSequence_Call) RunAndReturn(run func(context.Context, common.Hash) (bool, error)) *DB_HasSignedSequence_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCommitteeChanges provides a mock function with given fields: ctx, fromID, limit
+func (_m *DB) ListCommitteeChanges(ctx context.Context, fromID uint64, limit uint) ([]types.CommitteeChange, error) {
+	ret := _m.Called(ctx, fromID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCommitteeChanges")
+	}
+
+	var r0 []types.CommitteeChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint) ([]types.CommitteeChange, error)); ok {
+		return rf(ctx, fromID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint) []types.CommitteeChange); ok {
+		r0 = rf(ctx, fromID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.CommitteeChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint) error); ok {
+		r1 = rf(ctx, fromID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_ListCommitteeChanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCommitteeChanges'
+type DB_ListCommitteeChanges_Call struct {
+	*mock.Call
+}
+
+// ListCommitteeChanges is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fromID uint64
+//   - limit uint
+func (_e *DB_Expecter) ListCommitteeChanges(ctx interface{}, fromID interface{}, limit interface{}) *DB_ListCommitteeChanges_Call {
+	return &DB_ListCommitteeChanges_Call{Call: _e.mock.On("ListCommitteeChanges", ctx, fromID, limit)}
+}
+
+func (_c *DB_ListCommitteeChanges_Call) Run(run func(ctx context.Context, fromID uint64, limit uint)) *DB_ListCommitteeChanges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *DB_ListCommitteeChanges_Call) Return(_a0 []types.CommitteeChange, _a1 error) *DB_ListCommitteeChanges_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_ListCommitteeChanges_Call) RunAndReturn(run func(context.Context, uint64, uint) ([]types.CommitteeChange, error)) *DB_ListCommitteeChanges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOffChainData provides a mock function with given fields: ctx, keys
+func (_m *DB) ListOffChainData(ctx context.Context, keys []common.Hash) ([]types.OffChainData, error) {
+	ret := _m.Called(ctx, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOffChainData")
+	}
+
+	var r0 []types.OffChainData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []common.Hash) ([]types.OffChainData, error)); ok {
+		return rf(ctx, keys)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []common.Hash) []types.OffChainData); ok {
+		r0 = rf(ctx, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.OffChainData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []common.Hash) error); ok {
+		r1 = rf(ctx, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_ListOffChainData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOffChainData'
+type DB_ListOffChainData_Call struct {
+	*mock.Call
+}
+
+// ListOffChainData is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keys []common.Hash
+func (_e *DB_Expecter) ListOffChainData(ctx interface{}, keys interface{}) *DB_ListOffChainData_Call {
+	return &DB_ListOffChainData_Call{Call: _e.mock.On("ListOffChainData", ctx, keys)}
+}
+
+func (_c *DB_ListOffChainData_Call) Run(run func(ctx context.Context, keys []common.Hash)) *DB_ListOffChainData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]common.Hash))
+	})
+	return _c
+}
+
+func (_c *DB_ListOffChainData_Call) Return(_a0 []types.OffChainData, _a1 error) *DB_ListOffChainData_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_ListOffChainData_Call) RunAndReturn(run func(context.Context, []common.Hash) ([]types.OffChainData, error)) *DB_ListOffChainData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOffChainDataByBatches provides a mock function with given fields: ctx, fromBatch, toBatch, limit
+func (_m *DB) ListOffChainDataByBatches(ctx context.Context, fromBatch uint64, toBatch uint64, limit uint) ([]types.OffChainData, error) {
+	ret := _m.Called(ctx, fromBatch, toBatch, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOffChainDataByBatches")
+	}
+
+	var r0 []types.OffChainData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, uint) ([]types.OffChainData, error)); ok {
+		return rf(ctx, fromBatch, toBatch, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, uint) []types.OffChainData); ok {
+		r0 = rf(ctx, fromBatch, toBatch, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.OffChainData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64, uint) error); ok {
+		r1 = rf(ctx, fromBatch, toBatch, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_ListOffChainDataByBatches_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOffChainDataByBatches'
+type DB_ListOffChainDataByBatches_Call struct {
+	*mock.Call
+}
+
+// ListOffChainDataByBatches is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fromBatch uint64
+//   - toBatch uint64
+//   - limit uint
+func (_e *DB_Expecter) ListOffChainDataByBatches(ctx interface{}, fromBatch interface{}, toBatch interface{}, limit interface{}) *DB_ListOffChainDataByBatches_Call {
+	return &DB_ListOffChainDataByBatches_Call{Call: _e.mock.On("ListOffChainDataByBatches", ctx, fromBatch, toBatch, limit)}
+}
+
+func (_c *DB_ListOffChainDataByBatches_Call) Run(run func(ctx context.Context, fromBatch uint64, toBatch uint64, limit uint)) *DB_ListOffChainDataByBatches_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(uint64), args[3].(uint))
+	})
+	return _c
+}
+
+func (_c *DB_ListOffChainDataByBatches_Call) Return(_a0 []types.OffChainData, _a1 error) *DB_ListOffChainDataByBatches_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_ListOffChainDataByBatches_Call) RunAndReturn(run func(context.Context, uint64, uint64, uint) ([]types.OffChainData, error)) *DB_ListOffChainDataByBatches_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOffChainDataPage provides a mock function with given fields: ctx, afterKey, limit
+func (_m *DB) ListOffChainDataPage(ctx context.Context, afterKey common.Hash, limit uint) ([]types.OffChainData, error) {
+	ret := _m.Called(ctx, afterKey, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOffChainDataPage")
+	}
+
+	var r0 []types.OffChainData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, uint) ([]types.OffChainData, error)); ok {
+		return rf(ctx, afterKey, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, uint) []types.OffChainData); ok {
+		r0 = rf(ctx, afterKey, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.OffChainData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash, uint) error); ok {
+		r1 = rf(ctx, afterKey, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_ListOffChainDataPage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOffChainDataPage'
+type DB_ListOffChainDataPage_Call struct {
+	*mock.Call
+}
+
+// ListOffChainDataPage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - afterKey common.Hash
+//   - limit uint
+func (_e *DB_Expecter) ListOffChainDataPage(ctx interface{}, afterKey interface{}, limit interface{}) *DB_ListOffChainDataPage_Call {
+	return &DB_ListOffChainDataPage_Call{Call: _e.mock.On("ListOffChainDataPage", ctx, afterKey, limit)}
+}
+
+func (_c *DB_ListOffChainDataPage_Call) Run(run func(ctx context.Context, afterKey common.Hash, limit uint)) *DB_ListOffChainDataPage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *DB_ListOffChainDataPage_Call) Return(_a0 []types.OffChainData, _a1 error) *DB_ListOffChainDataPage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_ListOffChainDataPage_Call) RunAndReturn(run func(context.Context, common.Hash, uint) ([]types.OffChainData, error)) *DB_ListOffChainDataPage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSignAuditEntries provides a mock function with given fields: ctx, fromID, limit
+func (_m *DB) ListSignAuditEntries(ctx context.Context, fromID uint64, limit uint) ([]types.SignAuditEntry, error) {
+	ret := _m.Called(ctx, fromID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSignAuditEntries")
+	}
+
+	var r0 []types.SignAuditEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint) ([]types.SignAuditEntry, error)); ok {
+		return rf(ctx, fromID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint) []types.SignAuditEntry); ok {
+		r0 = rf(ctx, fromID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.SignAuditEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint) error); ok {
+		r1 = rf(ctx, fromID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_ListSignAuditEntries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSignAuditEntries'
+type DB_ListSignAuditEntries_Call struct {
+	*mock.Call
+}
+
+// ListSignAuditEntries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fromID uint64
+//   - limit uint
+func (_e *DB_Expecter) ListSignAuditEntries(ctx interface{}, fromID interface{}, limit interface{}) *DB_ListSignAuditEntries_Call {
+	return &DB_ListSignAuditEntries_Call{Call: _e.mock.On("ListSignAuditEntries", ctx, fromID, limit)}
+}
+
+func (_c *DB_ListSignAuditEntries_Call) Run(run func(ctx context.Context, fromID uint64, limit uint)) *DB_ListSignAuditEntries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *DB_ListSignAuditEntries_Call) Return(_a0 []types.SignAuditEntry, _a1 error) *DB_ListSignAuditEntries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_ListSignAuditEntries_Call) RunAndReturn(run func(context.Context, uint64, uint) ([]types.SignAuditEntry, error)) *DB_ListSignAuditEntries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PruneOffChainData provides a mock function with given fields: ctx, tombstones
+func (_m *DB) PruneOffChainData(ctx context.Context, tombstones []types.Tombstone) error {
+	ret := _m.Called(ctx, tombstones)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PruneOffChainData")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []types.Tombstone) error); ok {
+		r0 = rf(ctx, tombstones)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DB_PruneOffChainData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PruneOffChainData'
+type DB_PruneOffChainData_Call struct {
+	*mock.Call
+}
+
+// PruneOffChainData is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tombstones []types.Tombstone
+func (_e *DB_Expecter) PruneOffChainData(ctx interface{}, tombstones interface{}) *DB_PruneOffChainData_Call {
+	return &DB_PruneOffChainData_Call{Call: _e.mock.On("PruneOffChainData", ctx, tombstones)}
+}
+
+func (_c *DB_PruneOffChainData_Call) Run(run func(ctx context.Context, tombstones []types.Tombstone)) *DB_PruneOffChainData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]types.Tombstone))
+	})
+	return _c
+}
+
+func (_c *DB_PruneOffChainData_Call) Return(_a0 error) *DB_PruneOffChainData_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DB_PruneOffChainData_Call) RunAndReturn(run func(context.Context, []types.Tombstone) error) *DB_PruneOffChainData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StoreAttestation provides a mock function with given fields: ctx, attestation
+func (_m *DB) StoreAttestation(ctx context.Context, attestation types.Attestation) error {
+	ret := _m.Called(ctx, attestation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StoreAttestation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.Attestation) error); ok {
+		r0 = rf(ctx, attestation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DB_StoreAttestation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreAttestation'
+type DB_StoreAttestation_Call struct {
+	*mock.Call
+}
+
+// StoreAttestation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - attestation types.Attestation
+func (_e *DB_Expecter) StoreAttestation(ctx interface{}, attestation interface{}) *DB_StoreAttestation_Call {
+	return &DB_StoreAttestation_Call{Call: _e.mock.On("StoreAttestation", ctx, attestation)}
+}
+
+func (_c *DB_StoreAttestation_Call) Run(run func(ctx context.Context, attestation types.Attestation)) *DB_StoreAttestation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.Attestation))
+	})
+	return _c
+}
+
+func (_c *DB_StoreAttestation_Call) Return(_a0 error) *DB_StoreAttestation_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DB_StoreAttestation_Call) RunAndReturn(run func(context.Context, types.Attestation) error) *DB_StoreAttestation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StoreCertificate provides a mock function with given fields: ctx, certificate
+func (_m *DB) StoreCertificate(ctx context.Context, certificate types.Certificate) error {
+	ret := _m.Called(ctx, certificate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StoreCertificate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.Certificate) error); ok {
+		r0 = rf(ctx, certificate)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DB_StoreCertificate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreCertificate'
+type DB_StoreCertificate_Call struct {
+	*mock.Call
+}
+
+// StoreCertificate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - certificate types.Certificate
+func (_e *DB_Expecter) StoreCertificate(ctx interface{}, certificate interface{}) *DB_StoreCertificate_Call {
+	return &DB_StoreCertificate_Call{Call: _e.mock.On("StoreCertificate", ctx, certificate)}
+}
+
+func (_c *DB_StoreCertificate_Call) Run(run func(ctx context.Context, certificate types.Certificate)) *DB_StoreCertificate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.Certificate))
+	})
+	return _c
+}
+
+func (_c *DB_StoreCertificate_Call) Return(_a0 error) *DB_StoreCertificate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DB_StoreCertificate_Call) RunAndReturn(run func(context.Context, types.Certificate) error) *DB_StoreCertificate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StoreCommitteeChanges provides a mock function with given fields: ctx, changes
+func (_m *DB) StoreCommitteeChanges(ctx context.Context, changes []types.CommitteeChange) error {
+	ret := _m.Called(ctx, changes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StoreCommitteeChanges")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []types.CommitteeChange) error); ok {
+		r0 = rf(ctx, changes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DB_StoreCommitteeChanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreCommitteeChanges'
+type DB_StoreCommitteeChanges_Call struct {
+	*mock.Call
+}
+
+// StoreCommitteeChanges is a helper method to define mock.On call
+//   - ctx context.Context
+//   - changes []types.CommitteeChange
+func (_e *DB_Expecter) StoreCommitteeChanges(ctx interface{}, changes interface{}) *DB_StoreCommitteeChanges_Call {
+	return &DB_StoreCommitteeChanges_Call{Call: _e.mock.On("StoreCommitteeChanges", ctx, changes)}
+}
+
+func (_c *DB_StoreCommitteeChanges_Call) Run(run func(ctx context.Context, changes []types.CommitteeChange)) *DB_StoreCommitteeChanges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]types.CommitteeChange))
+	})
+	return _c
+}
+
+func (_c *DB_StoreCommitteeChanges_Call) Return(_a0 error) *DB_StoreCommitteeChanges_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DB_StoreCommitteeChanges_Call) RunAndReturn(run func(context.Context, []types.CommitteeChange) error) *DB_StoreCommitteeChanges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StoreLastProcessedBlock provides a mock function with given fields: ctx, block, task
+func (_m *DB) StoreLastProcessedBlock(ctx context.Context, block uint64, task string) error {
+	ret := _m.Called(ctx, block, task)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StoreLastProcessedBlock")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string) error); ok {
+		r0 = rf(ctx, block, task)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DB_StoreLastProcessedBlock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreLastProcessedBlock'
 type DB_StoreLastProcessedBlock_Call struct {
 	*mock.Call
 }
@@ -457,6 +1871,290 @@ func (_c *DB_StoreOffChainData_Call) RunAndReturn(run func(context.Context, []ty
 	return _c
 }
 
+// StoreOffChainDataDurably provides a mock function with given fields: ctx, od, synchronousCommit
+func (_m *DB) StoreOffChainDataDurably(ctx context.Context, od []types.OffChainData, synchronousCommit string) error {
+	ret := _m.Called(ctx, od, synchronousCommit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StoreOffChainDataDurably")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []types.OffChainData, string) error); ok {
+		r0 = rf(ctx, od, synchronousCommit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DB_StoreOffChainDataDurably_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreOffChainDataDurably'
+type DB_StoreOffChainDataDurably_Call struct {
+	*mock.Call
+}
+
+// StoreOffChainDataDurably is a helper method to define mock.On call
+//   - ctx context.Context
+//   - od []types.OffChainData
+//   - synchronousCommit string
+func (_e *DB_Expecter) StoreOffChainDataDurably(ctx interface{}, od interface{}, synchronousCommit interface{}) *DB_StoreOffChainDataDurably_Call {
+	return &DB_StoreOffChainDataDurably_Call{Call: _e.mock.On("StoreOffChainDataDurably", ctx, od, synchronousCommit)}
+}
+
+func (_c *DB_StoreOffChainDataDurably_Call) Run(run func(ctx context.Context, od []types.OffChainData, synchronousCommit string)) *DB_StoreOffChainDataDurably_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]types.OffChainData), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *DB_StoreOffChainDataDurably_Call) Return(_a0 error) *DB_StoreOffChainDataDurably_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DB_StoreOffChainDataDurably_Call) RunAndReturn(run func(context.Context, []types.OffChainData, string) error) *DB_StoreOffChainDataDurably_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StoreProvenance provides a mock function with given fields: ctx, provenance
+func (_m *DB) StoreProvenance(ctx context.Context, provenance []types.Provenance) error {
+	ret := _m.Called(ctx, provenance)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StoreProvenance")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []types.Provenance) error); ok {
+		r0 = rf(ctx, provenance)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DB_StoreProvenance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreProvenance'
+type DB_StoreProvenance_Call struct {
+	*mock.Call
+}
+
+// StoreProvenance is a helper method to define mock.On call
+//   - ctx context.Context
+//   - provenance []types.Provenance
+func (_e *DB_Expecter) StoreProvenance(ctx interface{}, provenance interface{}) *DB_StoreProvenance_Call {
+	return &DB_StoreProvenance_Call{Call: _e.mock.On("StoreProvenance", ctx, provenance)}
+}
+
+func (_c *DB_StoreProvenance_Call) Run(run func(ctx context.Context, provenance []types.Provenance)) *DB_StoreProvenance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]types.Provenance))
+	})
+	return _c
+}
+
+func (_c *DB_StoreProvenance_Call) Return(_a0 error) *DB_StoreProvenance_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DB_StoreProvenance_Call) RunAndReturn(run func(context.Context, []types.Provenance) error) *DB_StoreProvenance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StorePublication provides a mock function with given fields: ctx, publication
+func (_m *DB) StorePublication(ctx context.Context, publication types.Publication) error {
+	ret := _m.Called(ctx, publication)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StorePublication")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.Publication) error); ok {
+		r0 = rf(ctx, publication)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DB_StorePublication_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StorePublication'
+type DB_StorePublication_Call struct {
+	*mock.Call
+}
+
+// StorePublication is a helper method to define mock.On call
+//   - ctx context.Context
+//   - publication types.Publication
+func (_e *DB_Expecter) StorePublication(ctx interface{}, publication interface{}) *DB_StorePublication_Call {
+	return &DB_StorePublication_Call{Call: _e.mock.On("StorePublication", ctx, publication)}
+}
+
+func (_c *DB_StorePublication_Call) Run(run func(ctx context.Context, publication types.Publication)) *DB_StorePublication_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.Publication))
+	})
+	return _c
+}
+
+func (_c *DB_StorePublication_Call) Return(_a0 error) *DB_StorePublication_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DB_StorePublication_Call) RunAndReturn(run func(context.Context, types.Publication) error) *DB_StorePublication_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StoreServedValues provides a mock function with given fields: ctx, served
+func (_m *DB) StoreServedValues(ctx context.Context, served []types.ServedValue) error {
+	ret := _m.Called(ctx, served)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StoreServedValues")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []types.ServedValue) error); ok {
+		r0 = rf(ctx, served)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DB_StoreServedValues_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreServedValues'
+type DB_StoreServedValues_Call struct {
+	*mock.Call
+}
+
+// StoreServedValues is a helper method to define mock.On call
+//   - ctx context.Context
+//   - served []types.ServedValue
+func (_e *DB_Expecter) StoreServedValues(ctx interface{}, served interface{}) *DB_StoreServedValues_Call {
+	return &DB_StoreServedValues_Call{Call: _e.mock.On("StoreServedValues", ctx, served)}
+}
+
+func (_c *DB_StoreServedValues_Call) Run(run func(ctx context.Context, served []types.ServedValue)) *DB_StoreServedValues_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]types.ServedValue))
+	})
+	return _c
+}
+
+func (_c *DB_StoreServedValues_Call) Return(_a0 error) *DB_StoreServedValues_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DB_StoreServedValues_Call) RunAndReturn(run func(context.Context, []types.ServedValue) error) *DB_StoreServedValues_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StoreShards provides a mock function with given fields: ctx, commitment, shards
+func (_m *DB) StoreShards(ctx context.Context, commitment types.ShardCommitment, shards []types.Shard) error {
+	ret := _m.Called(ctx, commitment, shards)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StoreShards")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.ShardCommitment, []types.Shard) error); ok {
+		r0 = rf(ctx, commitment, shards)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DB_StoreShards_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreShards'
+type DB_StoreShards_Call struct {
+	*mock.Call
+}
+
+// StoreShards is a helper method to define mock.On call
+//   - ctx context.Context
+//   - commitment types.ShardCommitment
+//   - shards []types.Shard
+func (_e *DB_Expecter) StoreShards(ctx interface{}, commitment interface{}, shards interface{}) *DB_StoreShards_Call {
+	return &DB_StoreShards_Call{Call: _e.mock.On("StoreShards", ctx, commitment, shards)}
+}
+
+func (_c *DB_StoreShards_Call) Run(run func(ctx context.Context, commitment types.ShardCommitment, shards []types.Shard)) *DB_StoreShards_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.ShardCommitment), args[2].([]types.Shard))
+	})
+	return _c
+}
+
+func (_c *DB_StoreShards_Call) Return(_a0 error) *DB_StoreShards_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DB_StoreShards_Call) RunAndReturn(run func(context.Context, types.ShardCommitment, []types.Shard) error) *DB_StoreShards_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StoreSignAuditEntry provides a mock function with given fields: ctx, entry
+func (_m *DB) StoreSignAuditEntry(ctx context.Context, entry types.SignAuditEntry) error {
+	ret := _m.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StoreSignAuditEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.SignAuditEntry) error); ok {
+		r0 = rf(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DB_StoreSignAuditEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreSignAuditEntry'
+type DB_StoreSignAuditEntry_Call struct {
+	*mock.Call
+}
+
+// StoreSignAuditEntry is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entry types.SignAuditEntry
+func (_e *DB_Expecter) StoreSignAuditEntry(ctx interface{}, entry interface{}) *DB_StoreSignAuditEntry_Call {
+	return &DB_StoreSignAuditEntry_Call{Call: _e.mock.On("StoreSignAuditEntry", ctx, entry)}
+}
+
+func (_c *DB_StoreSignAuditEntry_Call) Run(run func(ctx context.Context, entry types.SignAuditEntry)) *DB_StoreSignAuditEntry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.SignAuditEntry))
+	})
+	return _c
+}
+
+func (_c *DB_StoreSignAuditEntry_Call) Return(_a0 error) *DB_StoreSignAuditEntry_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DB_StoreSignAuditEntry_Call) RunAndReturn(run func(context.Context, types.SignAuditEntry) error) *DB_StoreSignAuditEntry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // StoreUnresolvedBatchKeys provides a mock function with given fields: ctx, bks
 func (_m *DB) StoreUnresolvedBatchKeys(ctx context.Context, bks []types.BatchKey) error {
 	ret := _m.Called(ctx, bks)
@@ -504,6 +2202,52 @@ func (_c *DB_StoreUnresolvedBatchKeys_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// VacuumOffChainData provides a mock function with given fields: ctx
+func (_m *DB) VacuumOffChainData(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VacuumOffChainData")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DB_VacuumOffChainData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VacuumOffChainData'
+type DB_VacuumOffChainData_Call struct {
+	*mock.Call
+}
+
+// VacuumOffChainData is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *DB_Expecter) VacuumOffChainData(ctx interface{}) *DB_VacuumOffChainData_Call {
+	return &DB_VacuumOffChainData_Call{Call: _e.mock.On("VacuumOffChainData", ctx)}
+}
+
+func (_c *DB_VacuumOffChainData_Call) Run(run func(ctx context.Context)) *DB_VacuumOffChainData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *DB_VacuumOffChainData_Call) Return(_a0 error) *DB_VacuumOffChainData_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DB_VacuumOffChainData_Call) RunAndReturn(run func(context.Context) error) *DB_VacuumOffChainData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewDB creates a new instance of DB. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewDB(t interface {
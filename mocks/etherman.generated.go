@@ -10,12 +10,16 @@ import (
 
 	context "context"
 
+	ethereum "github.com/ethereum/go-ethereum"
+
 	etherman "github.com/0xPolygon/cdk-data-availability/etherman"
 
 	event "github.com/ethereum/go-ethereum/event"
 
 	mock "github.com/stretchr/testify/mock"
 
+	polygondatacommittee "github.com/0xPolygon/cdk-data-availability/etherman/smartcontracts/etrog/polygondatacommittee"
+
 	polygonvalidium "github.com/0xPolygon/cdk-data-availability/etherman/smartcontracts/etrog/polygonvalidium"
 
 	types "github.com/ethereum/go-ethereum/core/types"
@@ -93,6 +97,124 @@ func (_c *Etherman_BlockByNumber_Call) RunAndReturn(run func(context.Context, *b
 	return _c
 }
 
+// CallContract provides a mock function with given fields: ctx, msg, blockNumber
+func (_m *Etherman) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	ret := _m.Called(ctx, msg, blockNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CallContract")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ethereum.CallMsg, *big.Int) ([]byte, error)); ok {
+		return rf(ctx, msg, blockNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ethereum.CallMsg, *big.Int) []byte); ok {
+		r0 = rf(ctx, msg, blockNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ethereum.CallMsg, *big.Int) error); ok {
+		r1 = rf(ctx, msg, blockNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Etherman_CallContract_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CallContract'
+type Etherman_CallContract_Call struct {
+	*mock.Call
+}
+
+// CallContract is a helper method to define mock.On call
+//   - ctx context.Context
+//   - msg ethereum.CallMsg
+//   - blockNumber *big.Int
+func (_e *Etherman_Expecter) CallContract(ctx interface{}, msg interface{}, blockNumber interface{}) *Etherman_CallContract_Call {
+	return &Etherman_CallContract_Call{Call: _e.mock.On("CallContract", ctx, msg, blockNumber)}
+}
+
+func (_c *Etherman_CallContract_Call) Run(run func(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int)) *Etherman_CallContract_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(ethereum.CallMsg), args[2].(*big.Int))
+	})
+	return _c
+}
+
+func (_c *Etherman_CallContract_Call) Return(_a0 []byte, _a1 error) *Etherman_CallContract_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Etherman_CallContract_Call) RunAndReturn(run func(context.Context, ethereum.CallMsg, *big.Int) ([]byte, error)) *Etherman_CallContract_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ChainID provides a mock function with given fields: ctx
+func (_m *Etherman) ChainID(ctx context.Context) (*big.Int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChainID")
+	}
+
+	var r0 *big.Int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*big.Int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *big.Int); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Etherman_ChainID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChainID'
+type Etherman_ChainID_Call struct {
+	*mock.Call
+}
+
+// ChainID is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Etherman_Expecter) ChainID(ctx interface{}) *Etherman_ChainID_Call {
+	return &Etherman_ChainID_Call{Call: _e.mock.On("ChainID", ctx)}
+}
+
+func (_c *Etherman_ChainID_Call) Run(run func(ctx context.Context)) *Etherman_ChainID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Etherman_ChainID_Call) Return(_a0 *big.Int, _a1 error) *Etherman_ChainID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Etherman_ChainID_Call) RunAndReturn(run func(context.Context) (*big.Int, error)) *Etherman_ChainID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CodeAt provides a mock function with given fields: ctx, account, blockNumber
 func (_m *Etherman) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
 	ret := _m.Called(ctx, account, blockNumber)
@@ -153,6 +275,64 @@ func (_c *Etherman_CodeAt_Call) RunAndReturn(run func(context.Context, common.Ad
 	return _c
 }
 
+// FilterCommitteeUpdated provides a mock function with given fields: opts
+func (_m *Etherman) FilterCommitteeUpdated(opts *bind.FilterOpts) (*polygondatacommittee.PolygondatacommitteeCommitteeUpdatedIterator, error) {
+	ret := _m.Called(opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FilterCommitteeUpdated")
+	}
+
+	var r0 *polygondatacommittee.PolygondatacommitteeCommitteeUpdatedIterator
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*bind.FilterOpts) (*polygondatacommittee.PolygondatacommitteeCommitteeUpdatedIterator, error)); ok {
+		return rf(opts)
+	}
+	if rf, ok := ret.Get(0).(func(*bind.FilterOpts) *polygondatacommittee.PolygondatacommitteeCommitteeUpdatedIterator); ok {
+		r0 = rf(opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*polygondatacommittee.PolygondatacommitteeCommitteeUpdatedIterator)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*bind.FilterOpts) error); ok {
+		r1 = rf(opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Etherman_FilterCommitteeUpdated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FilterCommitteeUpdated'
+type Etherman_FilterCommitteeUpdated_Call struct {
+	*mock.Call
+}
+
+// FilterCommitteeUpdated is a helper method to define mock.On call
+//   - opts *bind.FilterOpts
+func (_e *Etherman_Expecter) FilterCommitteeUpdated(opts interface{}) *Etherman_FilterCommitteeUpdated_Call {
+	return &Etherman_FilterCommitteeUpdated_Call{Call: _e.mock.On("FilterCommitteeUpdated", opts)}
+}
+
+func (_c *Etherman_FilterCommitteeUpdated_Call) Run(run func(opts *bind.FilterOpts)) *Etherman_FilterCommitteeUpdated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*bind.FilterOpts))
+	})
+	return _c
+}
+
+func (_c *Etherman_FilterCommitteeUpdated_Call) Return(_a0 *polygondatacommittee.PolygondatacommitteeCommitteeUpdatedIterator, _a1 error) *Etherman_FilterCommitteeUpdated_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Etherman_FilterCommitteeUpdated_Call) RunAndReturn(run func(*bind.FilterOpts) (*polygondatacommittee.PolygondatacommitteeCommitteeUpdatedIterator, error)) *Etherman_FilterCommitteeUpdated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FilterSequenceBatches provides a mock function with given fields: opts, numBatch
 func (_m *Etherman) FilterSequenceBatches(opts *bind.FilterOpts, numBatch []uint64) (*polygonvalidium.PolygonvalidiumSequenceBatchesIterator, error) {
 	ret := _m.Called(opts, numBatch)
@@ -326,6 +506,65 @@ func (_c *Etherman_GetCurrentDataCommitteeMembers_Call) RunAndReturn(run func()
 	return _c
 }
 
+// GetDataCommitteeMembers provides a mock function with given fields: ctx, blockNumber
+func (_m *Etherman) GetDataCommitteeMembers(ctx context.Context, blockNumber *big.Int) ([]etherman.DataCommitteeMember, error) {
+	ret := _m.Called(ctx, blockNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDataCommitteeMembers")
+	}
+
+	var r0 []etherman.DataCommitteeMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *big.Int) ([]etherman.DataCommitteeMember, error)); ok {
+		return rf(ctx, blockNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *big.Int) []etherman.DataCommitteeMember); ok {
+		r0 = rf(ctx, blockNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]etherman.DataCommitteeMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *big.Int) error); ok {
+		r1 = rf(ctx, blockNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Etherman_GetDataCommitteeMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDataCommitteeMembers'
+type Etherman_GetDataCommitteeMembers_Call struct {
+	*mock.Call
+}
+
+// GetDataCommitteeMembers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - blockNumber *big.Int
+func (_e *Etherman_Expecter) GetDataCommitteeMembers(ctx interface{}, blockNumber interface{}) *Etherman_GetDataCommitteeMembers_Call {
+	return &Etherman_GetDataCommitteeMembers_Call{Call: _e.mock.On("GetDataCommitteeMembers", ctx, blockNumber)}
+}
+
+func (_c *Etherman_GetDataCommitteeMembers_Call) Run(run func(ctx context.Context, blockNumber *big.Int)) *Etherman_GetDataCommitteeMembers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*big.Int))
+	})
+	return _c
+}
+
+func (_c *Etherman_GetDataCommitteeMembers_Call) Return(_a0 []etherman.DataCommitteeMember, _a1 error) *Etherman_GetDataCommitteeMembers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Etherman_GetDataCommitteeMembers_Call) RunAndReturn(run func(context.Context, *big.Int) ([]etherman.DataCommitteeMember, error)) *Etherman_GetDataCommitteeMembers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetTx provides a mock function with given fields: ctx, txHash
 func (_m *Etherman) GetTx(ctx context.Context, txHash common.Hash) (*types.Transaction, bool, error) {
 	ret := _m.Called(ctx, txHash)
@@ -451,6 +690,184 @@ func (_c *Etherman_HeaderByNumber_Call) RunAndReturn(run func(context.Context, *
 	return _c
 }
 
+// LastVerifiedBatch provides a mock function with given fields: ctx
+func (_m *Etherman) LastVerifiedBatch(ctx context.Context) (uint64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LastVerifiedBatch")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (uint64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) uint64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Etherman_LastVerifiedBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LastVerifiedBatch'
+type Etherman_LastVerifiedBatch_Call struct {
+	*mock.Call
+}
+
+// LastVerifiedBatch is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Etherman_Expecter) LastVerifiedBatch(ctx interface{}) *Etherman_LastVerifiedBatch_Call {
+	return &Etherman_LastVerifiedBatch_Call{Call: _e.mock.On("LastVerifiedBatch", ctx)}
+}
+
+func (_c *Etherman_LastVerifiedBatch_Call) Run(run func(ctx context.Context)) *Etherman_LastVerifiedBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Etherman_LastVerifiedBatch_Call) Return(_a0 uint64, _a1 error) *Etherman_LastVerifiedBatch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Etherman_LastVerifiedBatch_Call) RunAndReturn(run func(context.Context) (uint64, error)) *Etherman_LastVerifiedBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SubmitAttestation provides a mock function with given fields: opts, contract, root, fromBatch, toBatch, keyCount
+func (_m *Etherman) SubmitAttestation(opts *bind.TransactOpts, contract common.Address, root common.Hash, fromBatch uint64, toBatch uint64, keyCount uint32) (*types.Transaction, error) {
+	ret := _m.Called(opts, contract, root, fromBatch, toBatch, keyCount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubmitAttestation")
+	}
+
+	var r0 *types.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*bind.TransactOpts, common.Address, common.Hash, uint64, uint64, uint32) (*types.Transaction, error)); ok {
+		return rf(opts, contract, root, fromBatch, toBatch, keyCount)
+	}
+	if rf, ok := ret.Get(0).(func(*bind.TransactOpts, common.Address, common.Hash, uint64, uint64, uint32) *types.Transaction); ok {
+		r0 = rf(opts, contract, root, fromBatch, toBatch, keyCount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*bind.TransactOpts, common.Address, common.Hash, uint64, uint64, uint32) error); ok {
+		r1 = rf(opts, contract, root, fromBatch, toBatch, keyCount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Etherman_SubmitAttestation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubmitAttestation'
+type Etherman_SubmitAttestation_Call struct {
+	*mock.Call
+}
+
+// SubmitAttestation is a helper method to define mock.On call
+//   - opts *bind.TransactOpts
+//   - contract common.Address
+//   - root common.Hash
+//   - fromBatch uint64
+//   - toBatch uint64
+//   - keyCount uint32
+func (_e *Etherman_Expecter) SubmitAttestation(opts interface{}, contract interface{}, root interface{}, fromBatch interface{}, toBatch interface{}, keyCount interface{}) *Etherman_SubmitAttestation_Call {
+	return &Etherman_SubmitAttestation_Call{Call: _e.mock.On("SubmitAttestation", opts, contract, root, fromBatch, toBatch, keyCount)}
+}
+
+func (_c *Etherman_SubmitAttestation_Call) Run(run func(opts *bind.TransactOpts, contract common.Address, root common.Hash, fromBatch uint64, toBatch uint64, keyCount uint32)) *Etherman_SubmitAttestation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*bind.TransactOpts), args[1].(common.Address), args[2].(common.Hash), args[3].(uint64), args[4].(uint64), args[5].(uint32))
+	})
+	return _c
+}
+
+func (_c *Etherman_SubmitAttestation_Call) Return(_a0 *types.Transaction, _a1 error) *Etherman_SubmitAttestation_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Etherman_SubmitAttestation_Call) RunAndReturn(run func(*bind.TransactOpts, common.Address, common.Hash, uint64, uint64, uint32) (*types.Transaction, error)) *Etherman_SubmitAttestation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TransactionReceipt provides a mock function with given fields: ctx, txHash
+func (_m *Etherman) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	ret := _m.Called(ctx, txHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransactionReceipt")
+	}
+
+	var r0 *types.Receipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) (*types.Receipt, error)); ok {
+		return rf(ctx, txHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) *types.Receipt); ok {
+		r0 = rf(ctx, txHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Receipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash) error); ok {
+		r1 = rf(ctx, txHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Etherman_TransactionReceipt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransactionReceipt'
+type Etherman_TransactionReceipt_Call struct {
+	*mock.Call
+}
+
+// TransactionReceipt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - txHash common.Hash
+func (_e *Etherman_Expecter) TransactionReceipt(ctx interface{}, txHash interface{}) *Etherman_TransactionReceipt_Call {
+	return &Etherman_TransactionReceipt_Call{Call: _e.mock.On("TransactionReceipt", ctx, txHash)}
+}
+
+func (_c *Etherman_TransactionReceipt_Call) Run(run func(ctx context.Context, txHash common.Hash)) *Etherman_TransactionReceipt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash))
+	})
+	return _c
+}
+
+func (_c *Etherman_TransactionReceipt_Call) Return(_a0 *types.Receipt, _a1 error) *Etherman_TransactionReceipt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Etherman_TransactionReceipt_Call) RunAndReturn(run func(context.Context, common.Hash) (*types.Receipt, error)) *Etherman_TransactionReceipt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // TrustedSequencer provides a mock function with given fields: ctx
 func (_m *Etherman) TrustedSequencer(ctx context.Context) (common.Address, error) {
 	ret := _m.Called(ctx)
@@ -0,0 +1,232 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/log"
+)
+
+// Conditions the node alerts on
+const (
+	// ConditionSyncLag the synchronizer fell behind the L1 head
+	ConditionSyncLag = "sync_lag"
+	// ConditionMemberFailures a committee member repeatedly failed to return the data of a batch
+	ConditionMemberFailures = "member_failures"
+	// ConditionDBUnavailable the database can not be reached
+	ConditionDBUnavailable = "db_unavailable"
+	// ConditionSigningRejected a request to sign a sequence was rejected by the signing policy
+	ConditionSigningRejected = "signing_rejected"
+	// ConditionSequenceNotLanded a sequence signed by the node did not land on L1 in time
+	ConditionSequenceNotLanded = "sequence_not_landed"
+	// ConditionUnrecordedSignature a sequence landed on L1 with a signature of the node it has no record of
+	ConditionUnrecordedSignature = "unrecorded_signature"
+	// ConditionKeySetMismatch the keys stored do not match the keys sequenced on L1
+	ConditionKeySetMismatch = "key_set_mismatch"
+	// ConditionCustodyFailed a committee member failed to prove it holds a value
+	ConditionCustodyFailed = "custody_failed"
+	// ConditionUnknownSequenceMethod the synchronizer is stalled on a sequence whose transaction it can not decode
+	ConditionUnknownSequenceMethod = "unknown_sequence_method"
+)
+
+// notifier is the Notifier the alerts are raised through, it sends nothing until Init is called
+var notifier atomic.Pointer[Notifier]
+
+func init() {
+	notifier.Store(New(Config{}))
+}
+
+// Init sets up the Notifier the alerts are raised through
+func Init(cfg Config) {
+	notifier.Store(New(cfg))
+}
+
+// SyncLag alerts when the synchronizer is further behind the L1 head than the threshold
+func SyncLag(lastProcessed, head uint64) {
+	n := notifier.Load()
+	if head <= lastProcessed || head-lastProcessed <= n.cfg.SyncLagThreshold {
+		return
+	}
+
+	n.Notify(Alert{
+		Condition: ConditionSyncLag,
+		Severity:  SeverityWarning,
+		Summary:   fmt.Sprintf("synchronizer is %d L1 blocks behind", head-lastProcessed),
+		Details: map[string]interface{}{
+			log.FieldBlockNumber: lastProcessed,
+			"head":               head,
+		},
+	})
+}
+
+// MemberResolveFailed counts a failure to resolve data from a committee member,
+// alerting once the consecutive failures reach the threshold
+func MemberResolveFailed(addr, url string, err error) {
+	n := notifier.Load()
+
+	n.lock.Lock()
+	n.memberFailures[addr]++
+	failures := n.memberFailures[addr]
+	n.lock.Unlock()
+
+	if n.cfg.MemberFailureThreshold == 0 || failures < n.cfg.MemberFailureThreshold {
+		return
+	}
+
+	n.Notify(Alert{
+		Condition: ConditionMemberFailures,
+		Subject:   addr,
+		Severity:  SeverityWarning,
+		Summary:   fmt.Sprintf("committee member %s failed to return data %d times in a row", addr, failures),
+		Details: map[string]interface{}{
+			log.FieldMemberAddr: addr,
+			log.FieldMemberURL:  url,
+			"error":             err.Error(),
+		},
+	})
+}
+
+// MemberResolved resets the consecutive failures of a committee member
+func MemberResolved(addr string) {
+	n := notifier.Load()
+
+	n.lock.Lock()
+	delete(n.memberFailures, addr)
+	n.lock.Unlock()
+}
+
+// DBUnavailable alerts that the database can not be reached
+func DBUnavailable(err error) {
+	notifier.Load().Notify(Alert{
+		Condition: ConditionDBUnavailable,
+		Severity:  SeverityCritical,
+		Summary:   "database is unavailable",
+		Details:   map[string]interface{}{"error": err.Error()},
+	})
+}
+
+// SigningRejected alerts that a request to sign a sequence was rejected by the signing policy
+func SigningRejected(requester, reason string) {
+	notifier.Load().Notify(Alert{
+		Condition: ConditionSigningRejected,
+		Subject:   requester,
+		Severity:  SeverityWarning,
+		Summary:   fmt.Sprintf("rejected request to sign a sequence from %s: %s", requester, reason),
+		Details:   map[string]interface{}{"requester": requester, "reason": reason},
+	})
+}
+
+// SequenceNotLanded alerts that a sequence signed by the node did not land on L1 within the grace period,
+// the sequencer not using the signature or having changed the sequence before sequencing it
+func SequenceNotLanded(sequenceHash string, batchCount uint64, signedAt time.Time) {
+	notifier.Load().Notify(Alert{
+		Condition: ConditionSequenceNotLanded,
+		Subject:   sequenceHash,
+		Severity:  SeverityWarning,
+		Summary:   fmt.Sprintf("sequence %s signed by the node did not land on L1 in time", sequenceHash),
+		Details: map[string]interface{}{
+			"sequence_hash": sequenceHash,
+			"batch_count":   batchCount,
+			"signed_at":     signedAt,
+		},
+	})
+}
+
+// UnrecordedSignature alerts that a sequence landed on L1 with a signature of the node that is not in its audit
+// log, the key of the node signing outside of it or the sequence landing with other hashes than the ones signed
+func UnrecordedSignature(sequenceHash, txHash string, block uint64) {
+	notifier.Load().Notify(Alert{
+		Condition: ConditionUnrecordedSignature,
+		Subject:   sequenceHash,
+		Severity:  SeverityCritical,
+		Summary:   fmt.Sprintf("sequence %s landed with a signature of the node it has no record of", sequenceHash),
+		Details: map[string]interface{}{
+			"sequence_hash":      sequenceHash,
+			log.FieldTxHash:      txHash,
+			log.FieldBlockNumber: block,
+		},
+	})
+}
+
+// KeySetMismatch alerts that the keys stored for the batches up to the last verified one do not match the keys
+// sequenced on L1, the extra keys having possibly been injected into the database
+func KeySetMismatch(verifiedBatch uint64, extra, missing int) {
+	severity := SeverityWarning
+	if extra > 0 {
+		severity = SeverityCritical
+	}
+
+	notifier.Load().Notify(Alert{
+		Condition: ConditionKeySetMismatch,
+		Severity:  severity,
+		Summary: fmt.Sprintf("%d keys stored were not sequenced and %d keys sequenced are missing up to batch %d",
+			extra, missing, verifiedBatch),
+		Details: map[string]interface{}{
+			"verified_batch": verifiedBatch,
+			"extra":          extra,
+			"missing":        missing,
+		},
+	})
+}
+
+// CustodyFailed alerts that a committee member failed a custody challenge, refusing it or answering with
+// another proof than expected, i.e. the member may have discarded the data while claiming to store it
+func CustodyFailed(addr, url, key string, err error) {
+	notifier.Load().Notify(Alert{
+		Condition: ConditionCustodyFailed,
+		Subject:   addr,
+		Severity:  SeverityCritical,
+		Summary:   fmt.Sprintf("committee member %s failed to prove it holds the data of %s", addr, key),
+		Details: map[string]interface{}{
+			log.FieldMemberAddr: addr,
+			log.FieldMemberURL:  url,
+			log.FieldKeyHash:    key,
+			"error":             err.Error(),
+		},
+	})
+}
+
+// UnknownSequenceMethod alerts that the synchronizer is stalled on a sequence sent by a transaction it can not
+// decode, e.g. through a multisig or by a new fork of the contract, until the node is updated to decode it
+func UnknownSequenceMethod(txHash string, block uint64, err error) {
+	notifier.Load().Notify(Alert{
+		Condition: ConditionUnknownSequenceMethod,
+		Subject:   txHash,
+		Severity:  SeverityCritical,
+		Summary:   fmt.Sprintf("synchronizer is stalled on the sequence %s of an unknown method", txHash),
+		Details: map[string]interface{}{
+			log.FieldTxHash:      txHash,
+			log.FieldBlockNumber: block,
+			"error":              err.Error(),
+		},
+	})
+}
+
+// MonitorDB checks the availability of the database until the context is done,
+// alerting when it can not be reached
+func MonitorDB(ctx context.Context, ping func(ctx context.Context) error) {
+	n := notifier.Load()
+	if len(n.cfg.Webhooks) == 0 || n.cfg.CheckInterval.Duration <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(n.cfg.CheckInterval.Duration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			pingCtx, cancel := context.WithTimeout(ctx, n.cfg.CheckInterval.Duration)
+			err := ping(pingCtx)
+			cancel()
+			if err != nil {
+				logger.Errorf("database is unavailable: %v", err)
+				DBUnavailable(err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package notifier
+
+import "github.com/0xPolygon/cdk-data-availability/config/types"
+
+const (
+	// KindGeneric posts the alert as it is, as a JSON object
+	KindGeneric = "generic"
+	// KindSlack posts the alert as a Slack incoming webhook message
+	KindSlack = "slack"
+	// KindPagerDuty posts the alert as a PagerDuty Events API v2 event
+	KindPagerDuty = "pagerduty"
+)
+
+// Config represents the configuration of the alert notifications
+type Config struct {
+	// Webhooks are the endpoints the alerts are posted to, no alert is sent when empty
+	Webhooks []WebhookConfig `mapstructure:"Webhooks"`
+
+	// Source identifies the node in the alerts, the hostname by default
+	Source string `mapstructure:"Source"`
+
+	// Cooldown is the minimum time between two alerts of the same condition
+	Cooldown types.Duration `mapstructure:"Cooldown"`
+
+	// Timeout bounds each request to a webhook
+	Timeout types.Duration `mapstructure:"Timeout"`
+
+	// CheckInterval is how often the database availability is checked
+	CheckInterval types.Duration `mapstructure:"CheckInterval"`
+
+	// SyncLagThreshold is the number of L1 blocks the synchronizer can fall behind before alerting
+	SyncLagThreshold uint64 `mapstructure:"SyncLagThreshold"`
+
+	// MemberFailureThreshold is the number of consecutive failures to resolve data from
+	// the same committee member before alerting
+	MemberFailureThreshold uint `mapstructure:"MemberFailureThreshold"`
+}
+
+// WebhookConfig represents an endpoint the alerts are posted to
+type WebhookConfig struct {
+	// URL of the webhook
+	URL string `mapstructure:"URL" secret:"true"`
+
+	// Kind is the payload format: generic, slack or pagerduty
+	Kind string `mapstructure:"Kind"`
+
+	// RoutingKey is the integration key of the PagerDuty service
+	RoutingKey string `mapstructure:"RoutingKey" secret:"true"`
+}
@@ -0,0 +1,175 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/log"
+)
+
+// logger is the logger of the notifier component
+var logger = log.WithComponent("notifier")
+
+// Severity is the severity of an alert, as understood by PagerDuty
+type Severity string
+
+const (
+	// SeverityCritical the node can not do its job
+	SeverityCritical = Severity("critical")
+	// SeverityWarning the node is degraded
+	SeverityWarning = Severity("warning")
+)
+
+// Alert is a critical condition detected by the node
+type Alert struct {
+	// Condition identifies the kind of alert
+	Condition string `json:"condition"`
+	// Subject is what the alert is about if there can be several at once, e.g. the member address.
+	// Alerts of the same condition and subject are rate limited together.
+	Subject string `json:"subject,omitempty"`
+	// Severity of the alert
+	Severity Severity `json:"severity"`
+	// Summary describes the alert in a single line
+	Summary string `json:"summary"`
+	// Details gives context to the alert, e.g. the member URL
+	Details map[string]interface{} `json:"details,omitempty"`
+	// Source identifies the node raising the alert
+	Source string `json:"source"`
+	// Timestamp is the time the alert was raised
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// key identifies the alerts that are rate limited and deduplicated together
+func (a Alert) key() string {
+	if a.Subject == "" {
+		return a.Condition
+	}
+
+	return a.Condition + "/" + a.Subject
+}
+
+// Notifier posts the alerts to the configured webhooks
+type Notifier struct {
+	cfg    Config
+	client *http.Client
+
+	lock     sync.Mutex
+	lastSent map[string]time.Time
+	// memberFailures are the consecutive failures to resolve data from each member
+	memberFailures map[string]uint
+}
+
+// New returns a Notifier posting to the webhooks of the given config
+func New(cfg Config) *Notifier {
+	if cfg.Source == "" {
+		cfg.Source, _ = os.Hostname()
+	}
+
+	return &Notifier{
+		cfg:            cfg,
+		client:         &http.Client{Timeout: cfg.Timeout.Duration},
+		lastSent:       make(map[string]time.Time),
+		memberFailures: make(map[string]uint),
+	}
+}
+
+// Notify posts the alert to every webhook in the background, unless an alert of the
+// same condition was sent within the cooldown period
+func (n *Notifier) Notify(alert Alert) {
+	if len(n.cfg.Webhooks) == 0 {
+		return
+	}
+
+	key := alert.key()
+
+	n.lock.Lock()
+	if last, ok := n.lastSent[key]; ok && time.Since(last) < n.cfg.Cooldown.Duration {
+		n.lock.Unlock()
+		return
+	}
+	n.lastSent[key] = time.Now()
+	n.lock.Unlock()
+
+	alert.Source = n.cfg.Source
+	if alert.Timestamp.IsZero() {
+		alert.Timestamp = time.Now().UTC()
+	}
+
+	for _, webhook := range n.cfg.Webhooks {
+		go func(webhook WebhookConfig) {
+			if err := n.post(webhook, alert); err != nil {
+				logger.Errorf("failed to post the %s alert to the %s webhook: %v", alert.Condition, webhook.Kind, err)
+			}
+		}(webhook)
+	}
+}
+
+// post sends the alert to the webhook in the format it expects
+func (n *Notifier) post(webhook WebhookConfig, alert Alert) error {
+	body, err := json.Marshal(payload(webhook, alert))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), n.cfg.Timeout.Duration)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status code %d", res.StatusCode)
+	}
+
+	return nil
+}
+
+// payload returns the body of the request to the webhook
+func payload(webhook WebhookConfig, alert Alert) interface{} {
+	switch webhook.Kind {
+	case KindSlack:
+		text := fmt.Sprintf("[%s] %s: %s", alert.Severity, alert.Source, alert.Summary)
+		keys := make([]string, 0, len(alert.Details))
+		for key := range alert.Details {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			text += fmt.Sprintf("\n• %s: %v", key, alert.Details[key])
+		}
+		return map[string]interface{}{"text": text}
+	case KindPagerDuty:
+		return map[string]interface{}{
+			"routing_key":  webhook.RoutingKey,
+			"event_action": "trigger",
+			"dedup_key":    alert.Source + "/" + alert.key(),
+			"payload": map[string]interface{}{
+				"summary":        alert.Summary,
+				"source":         alert.Source,
+				"severity":       alert.Severity,
+				"timestamp":      alert.Timestamp.Format(time.RFC3339),
+				"component":      "cdk-data-availability",
+				"class":          alert.Condition,
+				"custom_details": alert.Details,
+			},
+		}
+	default:
+		return alert
+	}
+}
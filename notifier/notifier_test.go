@@ -0,0 +1,156 @@
+package notifier
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/config/types"
+	"github.com/stretchr/testify/require"
+)
+
+// webhookServer returns a webhook receiving the posted payloads
+func webhookServer(t *testing.T) (string, chan map[string]interface{}) {
+	t.Helper()
+
+	payloads := make(chan map[string]interface{}, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		var payload map[string]interface{}
+		require.NoError(t, json.Unmarshal(body, &payload))
+		payloads <- payload
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv.URL, payloads
+}
+
+func receive(t *testing.T, payloads chan map[string]interface{}) map[string]interface{} {
+	t.Helper()
+
+	select {
+	case payload := <-payloads:
+		return payload
+	case <-time.After(5 * time.Second):
+		t.Fatal("no alert posted")
+		return nil
+	}
+}
+
+func testConfig(webhooks ...WebhookConfig) Config {
+	return Config{
+		Webhooks:               webhooks,
+		Source:                 "dac-1",
+		Cooldown:               types.NewDuration(time.Hour),
+		Timeout:                types.NewDuration(5 * time.Second),
+		SyncLagThreshold:       100,
+		MemberFailureThreshold: 2,
+	}
+}
+
+func TestNotifier_Payloads(t *testing.T) {
+	url, payloads := webhookServer(t)
+
+	alert := Alert{
+		Condition: ConditionDBUnavailable,
+		Severity:  SeverityCritical,
+		Summary:   "database is unavailable",
+		Details:   map[string]interface{}{"error": "connection refused"},
+	}
+
+	tests := []struct {
+		name     string
+		webhook  WebhookConfig
+		expected func(t *testing.T, payload map[string]interface{})
+	}{
+		{
+			name:    "generic",
+			webhook: WebhookConfig{URL: url, Kind: KindGeneric},
+			expected: func(t *testing.T, payload map[string]interface{}) {
+				t.Helper()
+				require.Equal(t, ConditionDBUnavailable, payload["condition"])
+				require.Equal(t, "critical", payload["severity"])
+				require.Equal(t, "dac-1", payload["source"])
+				require.Equal(t, map[string]interface{}{"error": "connection refused"}, payload["details"])
+			},
+		},
+		{
+			name:    "slack",
+			webhook: WebhookConfig{URL: url, Kind: KindSlack},
+			expected: func(t *testing.T, payload map[string]interface{}) {
+				t.Helper()
+				require.Equal(t, "[critical] dac-1: database is unavailable\n• error: connection refused", payload["text"])
+			},
+		},
+		{
+			name:    "pagerduty",
+			webhook: WebhookConfig{URL: url, Kind: KindPagerDuty, RoutingKey: "key"},
+			expected: func(t *testing.T, payload map[string]interface{}) {
+				t.Helper()
+				require.Equal(t, "key", payload["routing_key"])
+				require.Equal(t, "trigger", payload["event_action"])
+				require.Equal(t, "dac-1/db_unavailable", payload["dedup_key"])
+				event, ok := payload["payload"].(map[string]interface{})
+				require.True(t, ok)
+				require.Equal(t, "database is unavailable", event["summary"])
+				require.Equal(t, "critical", event["severity"])
+				require.Equal(t, "dac-1", event["source"])
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			New(testConfig(tt.webhook)).Notify(alert)
+			tt.expected(t, receive(t, payloads))
+		})
+	}
+}
+
+func TestNotifier_Cooldown(t *testing.T) {
+	url, payloads := webhookServer(t)
+	n := New(testConfig(WebhookConfig{URL: url, Kind: KindGeneric}))
+
+	n.Notify(Alert{Condition: ConditionSigningRejected, Subject: "0x1"})
+	receive(t, payloads)
+
+	// the same condition and subject is rate limited, another subject is not
+	n.Notify(Alert{Condition: ConditionSigningRejected, Subject: "0x1"})
+	n.Notify(Alert{Condition: ConditionSigningRejected, Subject: "0x2"})
+	require.Equal(t, "0x2", receive(t, payloads)["subject"])
+
+	select {
+	case payload := <-payloads:
+		t.Fatalf("unexpected alert posted: %v", payload)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestAlerts(t *testing.T) {
+	url, payloads := webhookServer(t)
+	Init(testConfig(WebhookConfig{URL: url, Kind: KindGeneric}))
+	t.Cleanup(func() { Init(Config{}) })
+
+	// within the threshold
+	SyncLag(1000, 1100)
+	// the member is reset before reaching the threshold
+	MemberResolveFailed("0x1", "http://member-1", errors.New("timeout"))
+	MemberResolved("0x1")
+	MemberResolveFailed("0x1", "http://member-1", errors.New("timeout"))
+
+	SyncLag(1000, 1101)
+	require.Equal(t, ConditionSyncLag, receive(t, payloads)["condition"])
+
+	MemberResolveFailed("0x1", "http://member-1", errors.New("timeout"))
+	payload := receive(t, payloads)
+	require.Equal(t, ConditionMemberFailures, payload["condition"])
+	require.Equal(t, "0x1", payload["subject"])
+}
@@ -4,11 +4,20 @@ import "time"
 
 // Exponential performs exponential backoff attempts on a given action
 func Exponential(action func() error, max uint, wait time.Duration) error {
+	return ExponentialWithCap(action, max, wait, 0)
+}
+
+// ExponentialWithCap performs exponential backoff attempts on a given action,
+// never waiting longer than maxWait between attempts (no cap if zero)
+func ExponentialWithCap(action func() error, max uint, wait, maxWait time.Duration) error {
 	var err error
 	for i := uint(0); i < max; i++ {
 		if err = action(); err == nil {
 			return nil
 		}
+		if maxWait > 0 && wait > maxWait {
+			wait = maxWait
+		}
 		time.Sleep(wait)
 		wait *= 2
 	}
@@ -44,3 +44,20 @@ func TestExponential(t *testing.T) {
 		require.True(t, elapsed >= 600*time.Millisecond)
 	})
 }
+
+func TestExponentialWithCap(t *testing.T) {
+	i := 0
+	t0 := time.Now()
+	err := ExponentialWithCap(func() error {
+		i++
+		return errors.New("bad")
+	}, 4, 50*time.Millisecond, 100*time.Millisecond)
+
+	elapsed := time.Since(t0)
+
+	require.Error(t, err)
+	require.Equal(t, i, 4)
+	// waits 50ms, 100ms, 100ms and 100ms instead of 50ms, 100ms, 200ms and 400ms
+	require.True(t, elapsed >= 350*time.Millisecond)
+	require.True(t, elapsed < 750*time.Millisecond)
+}
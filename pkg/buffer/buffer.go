@@ -0,0 +1,32 @@
+package buffer
+
+import (
+	"bytes"
+	"sync"
+)
+
+// maxPooledSize is the capacity above which a buffer is dropped rather than pooled, so that a single
+// huge payload does not stay allocated for the life of the process
+const maxPooledSize = 64 << 20
+
+var pool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// Get returns an empty buffer from the pool. It must be handed back with Put once its content,
+// and any slice of it, is no longer used.
+func Get() *bytes.Buffer {
+	return pool.Get().(*bytes.Buffer) //nolint:forcetypeassert
+}
+
+// Put resets the buffer and returns it to the pool
+func Put(b *bytes.Buffer) {
+	if b.Cap() > maxPooledSize {
+		return
+	}
+
+	b.Reset()
+	pool.Put(b)
+}
@@ -0,0 +1,25 @@
+package buffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuffer(t *testing.T) {
+	b := Get()
+	require.Zero(t, b.Len())
+
+	b.WriteString("value")
+	Put(b)
+
+	// the buffers are handed out empty, whether reused or not
+	require.Zero(t, Get().Len())
+
+	// the buffers too large to keep are not pooled
+	huge := Get()
+	huge.Grow(maxPooledSize + 1)
+	huge.WriteString("value")
+	Put(huge)
+	require.Equal(t, "value", huge.String())
+}
@@ -0,0 +1,88 @@
+package erasure
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/0xPolygon/cdk-data-availability/pkg/merkle"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/klauspost/reedsolomon"
+)
+
+// MaxShards is the maximum number of data and parity shards of a value
+const MaxShards = 256
+
+// Encode splits the value into dataShards shards of equal size, the last one padded with zeros,
+// followed by parityShards parity shards. Any dataShards of the shards rebuild the value.
+func Encode(value []byte, dataShards, parityShards int) ([][]byte, error) {
+	enc, err := reedsolomon.New(dataShards, parityShards)
+	if err != nil {
+		return nil, err
+	}
+
+	// an empty value is encoded as a single zero, the size tells it apart
+	if len(value) == 0 {
+		value = []byte{0}
+	}
+
+	shards, err := enc.Split(value)
+	if err != nil {
+		return nil, err
+	}
+	if err = enc.Encode(shards); err != nil {
+		return nil, err
+	}
+
+	return shards, nil
+}
+
+// Reconstruct rebuilds the value of the given size from its shards, the missing ones being nil
+func Reconstruct(shards [][]byte, dataShards, parityShards, size int) ([]byte, error) {
+	enc, err := reedsolomon.New(dataShards, parityShards)
+	if err != nil {
+		return nil, err
+	}
+	if err = enc.ReconstructData(shards); err != nil {
+		return nil, err
+	}
+
+	value := make([]byte, 0, size)
+	for _, shard := range shards[:dataShards] {
+		value = append(value, shard...)
+	}
+	if len(value) < size {
+		return nil, fmt.Errorf("shards of %d bytes are too short for a value of %d bytes", len(value), size)
+	}
+
+	return value[:size], nil
+}
+
+// Leaf returns the leaf of the shard at the given index in the Merkle tree of the shards of a value.
+// The index is part of the leaf, so a shard can not be passed off as another one.
+func Leaf(index int, shard []byte) common.Hash {
+	var prefix [4]byte
+	binary.BigEndian.PutUint32(prefix[:], uint32(index))
+
+	return crypto.Keccak256Hash(prefix[:], shard)
+}
+
+// Commit returns the Merkle root of the shards, and the proof of each shard
+func Commit(shards [][]byte) (common.Hash, [][]common.Hash) {
+	leaves := make([]common.Hash, len(shards))
+	for i, shard := range shards {
+		leaves[i] = Leaf(i, shard)
+	}
+
+	proofs := make([][]common.Hash, len(shards))
+	for i := range shards {
+		proofs[i] = merkle.Proof(leaves, i)
+	}
+
+	return merkle.Root(leaves), proofs
+}
+
+// Verify tells whether the shard at the given index is part of the shards of the given root
+func Verify(root common.Hash, index int, shard []byte, proof []common.Hash) bool {
+	return merkle.Verify(root, Leaf(index, shard), proof)
+}
@@ -0,0 +1,51 @@
+package erasure
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeReconstruct(t *testing.T) {
+	value := bytes.Repeat([]byte("batch data "), 100)
+
+	shards, err := Encode(value, 4, 2)
+	require.NoError(t, err)
+	require.Len(t, shards, 6)
+
+	// any two shards can be lost
+	shards[0], shards[3] = nil, nil
+
+	rebuilt, err := Reconstruct(shards, 4, 2, len(value))
+	require.NoError(t, err)
+	require.Equal(t, value, rebuilt)
+
+	// but not three
+	shards[0], shards[3], shards[5] = nil, nil, nil
+	_, err = Reconstruct(shards, 4, 2, len(value))
+	require.Error(t, err)
+}
+
+func TestEncodeEmpty(t *testing.T) {
+	shards, err := Encode(nil, 4, 2)
+	require.NoError(t, err)
+
+	rebuilt, err := Reconstruct(shards, 4, 2, 0)
+	require.NoError(t, err)
+	require.Empty(t, rebuilt)
+}
+
+func TestCommit(t *testing.T) {
+	shards, err := Encode([]byte("batch data"), 3, 2)
+	require.NoError(t, err)
+
+	root, proofs := Commit(shards)
+	for i, shard := range shards {
+		require.True(t, Verify(root, i, shard, proofs[i]))
+	}
+
+	// a shard verified at another index is rejected
+	require.False(t, Verify(root, 1, shards[0], proofs[0]))
+	require.False(t, Verify(root, 0, []byte("other"), proofs[0]))
+}
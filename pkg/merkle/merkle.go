@@ -0,0 +1,75 @@
+package merkle
+
+import (
+	"bytes"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Root returns the root of the Merkle tree of the given leaves. Each node is the keccak256
+// hash of its children sorted, as OpenZeppelin's MerkleProof expects, and the last node of
+// a level with an odd number of nodes is promoted to the next level. The root of a single
+// leaf is the leaf itself, and the root of no leaves is the zero hash.
+func Root(leaves []common.Hash) common.Hash {
+	if len(leaves) == 0 {
+		return common.Hash{}
+	}
+
+	level := append([]common.Hash{}, leaves...)
+	for len(level) > 1 {
+		level = nextLevel(level)
+	}
+
+	return level[0]
+}
+
+// Proof returns the siblings of the path from the leaf at the given index to the root
+func Proof(leaves []common.Hash, index int) []common.Hash {
+	var proof []common.Hash
+
+	level := append([]common.Hash{}, leaves...)
+	for len(level) > 1 {
+		sibling := index ^ 1
+		if sibling < len(level) {
+			proof = append(proof, level[sibling])
+		}
+		index /= 2
+		level = nextLevel(level)
+	}
+
+	return proof
+}
+
+// Verify tells whether the leaf is part of the tree of the given root
+func Verify(root, leaf common.Hash, proof []common.Hash) bool {
+	node := leaf
+	for _, sibling := range proof {
+		node = hashPair(node, sibling)
+	}
+
+	return node == root
+}
+
+// nextLevel returns the parent nodes of the nodes of a level
+func nextLevel(level []common.Hash) []common.Hash {
+	next := make([]common.Hash, 0, (len(level)+1)/2) //nolint:gomnd
+	for i := 0; i < len(level); i += 2 {
+		if i+1 == len(level) {
+			next = append(next, level[i])
+			continue
+		}
+		next = append(next, hashPair(level[i], level[i+1]))
+	}
+
+	return next
+}
+
+// hashPair returns the hash of two sibling nodes, sorted
+func hashPair(a, b common.Hash) common.Hash {
+	if bytes.Compare(a.Bytes(), b.Bytes()) > 0 {
+		a, b = b, a
+	}
+
+	return crypto.Keccak256Hash(a.Bytes(), b.Bytes())
+}
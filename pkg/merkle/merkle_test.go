@@ -0,0 +1,39 @@
+package merkle
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoot(t *testing.T) {
+	a, b, c := common.HexToHash("0x1"), common.HexToHash("0x2"), common.HexToHash("0x3")
+
+	require.Equal(t, common.Hash{}, Root(nil))
+	require.Equal(t, a, Root([]common.Hash{a}))
+
+	ab := crypto.Keccak256Hash(a.Bytes(), b.Bytes())
+	require.Equal(t, ab, Root([]common.Hash{a, b}))
+	require.Equal(t, ab, Root([]common.Hash{b, a}), "the pairs are sorted")
+
+	// the odd leaf is promoted
+	require.Equal(t, crypto.Keccak256Hash(c.Bytes(), ab.Bytes()), Root([]common.Hash{a, b, c}))
+}
+
+func TestProof(t *testing.T) {
+	for count := 1; count <= 9; count++ {
+		leaves := make([]common.Hash, count)
+		for i := range leaves {
+			leaves[i] = crypto.Keccak256Hash([]byte{byte(i)})
+		}
+		root := Root(leaves)
+
+		for i, leaf := range leaves {
+			proof := Proof(leaves, i)
+			require.True(t, Verify(root, leaf, proof), "leaf %d of %d", i, count)
+			require.False(t, Verify(root, common.HexToHash("0xdead"), proof))
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package replay
+
+import (
+	"container/heap"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	// ErrMissingTimestamp is returned for the requests without a timestamp when one is required
+	ErrMissingTimestamp = errors.New("the request has no timestamp")
+	// ErrStale is returned for the requests signed too long ago, or too far in the future
+	ErrStale = errors.New("the request timestamp is outside of the acceptance window")
+	// ErrReplayed is returned for the requests already accepted
+	ErrReplayed = errors.New("the request was already received")
+)
+
+// Guard rejects the replays of signed requests. A request is accepted once, within the window around
+// the timestamp it was signed with. The requests accepted are remembered by the hash of what was signed,
+// timestamp included, until their timestamp leaves the window.
+type Guard struct {
+	window   time.Duration
+	required bool
+	now      func() time.Time
+
+	lock sync.Mutex
+	// seen are the hashes of the requests accepted, with the time their timestamp leaves the window
+	seen map[common.Hash]time.Time
+	// expiries are the same requests ordered by the time they leave the window, so that the ones left are
+	// forgotten without walking all of them
+	expiries expiryQueue
+}
+
+// New returns a Guard accepting the timestamps within window of the current time. The requests without a
+// timestamp are accepted, and can be replayed, unless required is set.
+func New(window time.Duration, required bool) *Guard {
+	return &Guard{
+		window:   window,
+		required: required,
+		now:      time.Now,
+		seen:     make(map[common.Hash]time.Time),
+	}
+}
+
+// Check accepts the request whose signed hash is given, signed at the given unix timestamp, 0 if none
+func (g *Guard) Check(hash common.Hash, timestamp uint64) error {
+	if timestamp == 0 {
+		if g.required {
+			return ErrMissingTimestamp
+		}
+		return nil
+	}
+
+	now := g.now()
+	signedAt := time.Unix(int64(timestamp), 0)
+	if signedAt.Before(now.Add(-g.window)) || signedAt.After(now.Add(g.window)) {
+		return ErrStale
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	for len(g.expiries) > 0 && g.expiries[0].at.Before(now) {
+		delete(g.seen, heap.Pop(&g.expiries).(expiry).hash)
+	}
+
+	if _, ok := g.seen[hash]; ok {
+		return ErrReplayed
+	}
+	g.seen[hash] = signedAt.Add(g.window)
+	heap.Push(&g.expiries, expiry{hash: hash, at: signedAt.Add(g.window)})
+
+	return nil
+}
+
+// Release forgets the request whose signed hash is given, accepted by Check but not served, so that it can be
+// retried
+func (g *Guard) Release(hash common.Hash) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	// its expiry is left in the queue: accepted again, the request leaves the window at the same time
+	delete(g.seen, hash)
+}
+
+// expiry is the time a request accepted leaves the window
+type expiry struct {
+	hash common.Hash
+	at   time.Time
+}
+
+// expiryQueue is a min-heap of the expiries, the earliest first
+type expiryQueue []expiry
+
+func (q expiryQueue) Len() int           { return len(q) }
+func (q expiryQueue) Less(i, j int) bool { return q[i].at.Before(q[j].at) }
+func (q expiryQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *expiryQueue) Push(x interface{}) {
+	*q = append(*q, x.(expiry))
+}
+
+func (q *expiryQueue) Pop() interface{} {
+	old := *q
+	last := old[len(old)-1]
+	*q = old[:len(old)-1]
+
+	return last
+}
@@ -0,0 +1,78 @@
+package replay
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuard_Check(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	g := New(time.Minute, false)
+	g.now = func() time.Time { return now }
+
+	// the requests without a timestamp are accepted
+	require.NoError(t, g.Check(common.HexToHash("0x1"), 0))
+	require.NoError(t, g.Check(common.HexToHash("0x1"), 0))
+
+	require.NoError(t, g.Check(common.HexToHash("0x2"), uint64(now.Unix())))
+	require.ErrorIs(t, g.Check(common.HexToHash("0x2"), uint64(now.Unix())), ErrReplayed)
+
+	require.ErrorIs(t, g.Check(common.HexToHash("0x3"), uint64(now.Add(-2*time.Minute).Unix())), ErrStale)
+	require.ErrorIs(t, g.Check(common.HexToHash("0x3"), uint64(now.Add(2*time.Minute).Unix())), ErrStale)
+
+	// the requests are forgotten once their timestamp is out of the window, a replay being stale then
+	now = now.Add(2 * time.Minute)
+	require.NoError(t, g.Check(common.HexToHash("0x4"), uint64(now.Unix())))
+	require.Len(t, g.seen, 1)
+	require.Len(t, g.expiries, 1)
+	require.ErrorIs(t, g.Check(common.HexToHash("0x2"), uint64(now.Add(-2*time.Minute).Unix())), ErrStale)
+}
+
+func TestGuard_Expiries(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	g := New(time.Minute, false)
+	g.now = func() time.Time { return now }
+
+	// accepted out of the order of their timestamps, the requests are forgotten in that order
+	require.NoError(t, g.Check(common.HexToHash("0x1"), uint64(now.Add(30*time.Second).Unix())))
+	require.NoError(t, g.Check(common.HexToHash("0x2"), uint64(now.Add(-30*time.Second).Unix())))
+	require.NoError(t, g.Check(common.HexToHash("0x3"), uint64(now.Unix())))
+
+	now = now.Add(45 * time.Second)
+	require.NoError(t, g.Check(common.HexToHash("0x4"), uint64(now.Unix())))
+	require.NotContains(t, g.seen, common.HexToHash("0x2"))
+	require.Len(t, g.seen, 3)
+
+	now = now.Add(30 * time.Second)
+	require.NoError(t, g.Check(common.HexToHash("0x5"), uint64(now.Unix())))
+	require.Len(t, g.seen, 3)
+	require.Len(t, g.expiries, 3)
+	require.ErrorIs(t, g.Check(common.HexToHash("0x1"), uint64(now.Add(-45*time.Second).Unix())), ErrReplayed)
+}
+
+func TestGuard_Release(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	g := New(time.Minute, false)
+	g.now = func() time.Time { return now }
+
+	require.NoError(t, g.Check(common.HexToHash("0x1"), uint64(now.Unix())))
+	g.Release(common.HexToHash("0x1"))
+	require.NoError(t, g.Check(common.HexToHash("0x1"), uint64(now.Unix())))
+	require.ErrorIs(t, g.Check(common.HexToHash("0x1"), uint64(now.Unix())), ErrReplayed)
+
+	// released and accepted again, the request is forgotten once out of the window
+	now = now.Add(2 * time.Minute)
+	require.NoError(t, g.Check(common.HexToHash("0x2"), uint64(now.Unix())))
+	require.Len(t, g.seen, 1)
+	require.Len(t, g.expiries, 1)
+}
+
+func TestGuard_CheckRequired(t *testing.T) {
+	g := New(time.Minute, true)
+
+	require.ErrorIs(t, g.Check(common.HexToHash("0x1"), 0), ErrMissingTimestamp)
+	require.NoError(t, g.Check(common.HexToHash("0x1"), uint64(time.Now().Unix())))
+}
@@ -0,0 +1,9 @@
+package proxy
+
+// Config represents the configuration of the proxy the outbound traffic is routed through
+type Config struct {
+	// URL of the SOCKS5 proxy, e.g. socks5://127.0.0.1:9050 for a local Tor daemon. The host names
+	// are resolved by the proxy, so onion addresses can be used. Empty connects directly. It is a secret,
+	// as it can hold the credentials of the proxy.
+	URL string `mapstructure:"URL" secret:"true"`
+}
@@ -0,0 +1,85 @@
+package proxy
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+)
+
+// proxyURL is the URL of the proxy the outbound traffic is routed through, nil until Init is called
+var proxyURL atomic.Pointer[url.URL]
+
+// Init routes the outbound HTTP and websocket traffic of the node through the configured SOCKS5
+// proxy: the L1 node, the committee members, the trusted sequencer, the beacon API and the
+// webhooks, as they all go through the default transport. Nothing changes if no proxy is set.
+func Init(cfg Config) error {
+	if cfg.URL == "" {
+		return nil
+	}
+
+	u, err := Parse(cfg.URL)
+	if err != nil {
+		return err
+	}
+	proxyURL.Store(u)
+
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return fmt.Errorf("unexpected default transport %T", http.DefaultTransport)
+	}
+	transport.Proxy = Func()
+
+	return nil
+}
+
+// Parse parses the URL of a SOCKS5 proxy. The socks5h scheme is accepted as an alias of socks5,
+// the host names being resolved by the proxy with both.
+func Parse(rawURL string) (*url.URL, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+
+	switch u.Scheme {
+	case "socks5":
+	case "socks5h":
+		u.Scheme = "socks5"
+	default:
+		return nil, fmt.Errorf("proxy URL %q must use the socks5 or socks5h scheme", rawURL)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("proxy URL %q has no host", rawURL)
+	}
+
+	return u, nil
+}
+
+// Func returns the proxy function of the HTTP transports and websocket dialers. Requests to the
+// loopback addresses are not proxied, as the proxy would not reach the services of the host.
+// Without a proxy, the proxy of the environment is used, as by the default transport.
+func Func() func(*http.Request) (*url.URL, error) {
+	u := proxyURL.Load()
+	if u == nil {
+		return http.ProxyFromEnvironment
+	}
+
+	return func(req *http.Request) (*url.URL, error) {
+		if isLoopback(req.URL.Hostname()) {
+			return nil, nil
+		}
+
+		return u, nil
+	}
+}
+
+func isLoopback(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
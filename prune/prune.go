@@ -0,0 +1,247 @@
+package prune
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/synchronizer"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/0xPolygon/cdk-data-availability/webhook"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DefaultReason is the reason recorded in the tombstones when none is given
+const DefaultReason = "retention policy"
+
+// logger is the logger of the prune component
+var logger = log.WithComponent("prune")
+
+// ErrNoCriteria is returned when no retention criteria is given, as every value would be deleted
+var ErrNoCriteria = errors.New("no retention criteria given")
+
+// Criteria are the retention criteria of the values, a value is pruned when it matches all of them
+type Criteria struct {
+	// BeforeBatch prunes the values of the batches before it
+	BeforeBatch uint64
+	// BeforeBlock prunes the values of the batches sequenced before this L1 block
+	BeforeBlock uint64
+	// Before prunes the values of the batches sequenced on L1 before this time
+	Before time.Time
+	// VerifiedOnly prunes only the values of the batches verified on L1
+	VerifiedOnly bool
+}
+
+// Result describes the values pruned, or that would be pruned on a dry run
+type Result struct {
+	// BeforeBatch is the first batch whose values are kept
+	BeforeBatch uint64
+	// Values is the number of values pruned
+	Values uint64
+	// Size is the total size of the values pruned, in bytes
+	Size uint64
+	// PrunedAtBlock is the L1 block recorded in the tombstones of the values pruned
+	PrunedAtBlock uint64
+}
+
+// Pruner deletes the values of the old batches. The values not yet matched to a batch are never deleted.
+// A tombstone signed by the node is recorded for every value deleted.
+type Pruner struct {
+	db         db.DB
+	etherman   etherman.Etherman
+	privateKey *ecdsa.PrivateKey
+	blockRange uint64
+	pageSize   uint
+}
+
+// New returns a Pruner filtering the sequences blockRange L1 blocks at a time, and deleting the values
+// pageSize at a time. The tombstones are not signed when pk is nil, e.g. by a mirror.
+func New(db db.DB, em etherman.Etherman, pk *ecdsa.PrivateKey, blockRange uint64, pageSize uint) *Pruner {
+	return &Pruner{
+		db:         db,
+		etherman:   em,
+		privateKey: pk,
+		blockRange: blockRange,
+		pageSize:   pageSize,
+	}
+}
+
+// Prune deletes the values matching the criteria, recording the given reason in their tombstones, or only
+// counts them on a dry run. Each deleted page of values fires the data.pruned event.
+func (p *Pruner) Prune(ctx context.Context, criteria Criteria, reason string, dryRun bool) (*Result, error) {
+	beforeBatch, err := p.cutoff(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	count, size, err := p.db.CountOffChainDataBefore(ctx, beforeBatch)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{BeforeBatch: beforeBatch, Values: count, Size: size}
+	if dryRun || count == 0 {
+		return result, nil
+	}
+
+	head, err := p.etherman.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the latest L1 block: %w", err)
+	}
+	result.PrunedAtBlock = head.Number.Uint64()
+
+	var deleted uint64
+	for {
+		// the keys of the lowest batches, the ones before the cutoff being pruned
+		keys, err := p.db.GetOffChainDataKeys(ctx, 1, p.pageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed after deleting %d values: %w", deleted, err)
+		}
+
+		tombstones, err := p.tombstones(keys, beforeBatch, result.PrunedAtBlock, reason)
+		if err != nil {
+			return nil, err
+		}
+		if len(tombstones) == 0 {
+			break
+		}
+
+		if err = p.db.PruneOffChainData(ctx, tombstones); err != nil {
+			return nil, fmt.Errorf("failed after deleting %d values: %w", deleted, err)
+		}
+
+		pruned := make([]common.Hash, len(tombstones))
+		for i, tombstone := range tombstones {
+			pruned[i] = tombstone.Key
+		}
+
+		deleted += uint64(len(pruned))
+		webhook.DataPruned(pruned)
+		logger.Debugf("deleted %d values of the batches before %d", deleted, beforeBatch)
+
+		if len(tombstones) < len(keys) || uint(len(keys)) < p.pageSize {
+			break
+		}
+	}
+	result.Values = deleted
+
+	return result, nil
+}
+
+// tombstones returns the signed tombstones of the keys of the batches before the given one
+func (p *Pruner) tombstones(
+	keys []types.BatchKey,
+	beforeBatch, prunedAtBlock uint64,
+	reason string,
+) ([]types.Tombstone, error) {
+	now := time.Now().UTC()
+
+	tombstones := make([]types.Tombstone, 0, len(keys))
+	for _, key := range keys {
+		if key.Number >= beforeBatch {
+			break
+		}
+
+		tombstone := types.Tombstone{
+			Key:           key.Hash,
+			BatchNum:      types.ArgUint64(key.Number),
+			PrunedAtBlock: types.ArgUint64(prunedAtBlock),
+			Reason:        reason,
+			Timestamp:     now,
+		}
+		if p.privateKey != nil {
+			if err := tombstone.Sign(p.privateKey); err != nil {
+				return nil, fmt.Errorf("failed to sign the tombstone of %s: %w", key.Hash.Hex(), err)
+			}
+		}
+
+		tombstones = append(tombstones, tombstone)
+	}
+
+	return tombstones, nil
+}
+
+// cutoff returns the first batch whose values are kept according to the criteria
+func (p *Pruner) cutoff(ctx context.Context, criteria Criteria) (uint64, error) {
+	if criteria.BeforeBatch == 0 && criteria.BeforeBlock == 0 && criteria.Before.IsZero() && !criteria.VerifiedOnly {
+		return 0, ErrNoCriteria
+	}
+
+	cutoff := uint64(math.MaxUint64)
+	keep := func(batch uint64) {
+		if batch < cutoff {
+			cutoff = batch
+		}
+	}
+
+	if criteria.BeforeBatch != 0 {
+		keep(criteria.BeforeBatch)
+	}
+
+	beforeBlock := criteria.BeforeBlock
+	if !criteria.Before.IsZero() {
+		block, err := p.firstBlockAt(ctx, criteria.Before)
+		if err != nil {
+			return 0, err
+		}
+		if beforeBlock == 0 || block < beforeBlock {
+			beforeBlock = block
+		}
+	}
+	if beforeBlock != 0 {
+		batch, err := synchronizer.LastBatchSequencedBefore(ctx, p.etherman, beforeBlock, p.blockRange)
+		if err != nil {
+			return 0, err
+		}
+		keep(batch + 1)
+	}
+
+	if criteria.VerifiedOnly {
+		batch, err := p.etherman.LastVerifiedBatch(ctx)
+		if err != nil {
+			return 0, err
+		}
+		keep(batch + 1)
+	}
+
+	return cutoff, nil
+}
+
+// firstBlockAt returns the first L1 block mined at or after the given time
+func (p *Pruner) firstBlockAt(ctx context.Context, t time.Time) (uint64, error) {
+	latest, err := p.etherman.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := uint64(t.Unix())
+	if latest.Time < timestamp {
+		return latest.Number.Uint64() + 1, nil
+	}
+
+	// the first block whose timestamp is not before the given time, by binary search
+	low, high := uint64(0), latest.Number.Uint64()
+	for low < high {
+		mid := low + (high-low)/2
+
+		header, err := p.etherman.HeaderByNumber(ctx, new(big.Int).SetUint64(mid))
+		if err != nil {
+			return 0, err
+		}
+
+		if header.Time < timestamp {
+			low = mid + 1
+		} else {
+			high = mid
+		}
+	}
+
+	return low, nil
+}
@@ -0,0 +1,122 @@
+package prune
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/etherman/ethermantest"
+	"github.com/0xPolygon/cdk-data-availability/etherman/smartcontracts/etrog/polygonvalidium"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// sequences returns an iterator over the SequenceBatches events of the given last batches
+func sequences(t *testing.T, lastBatches ...uint64) *polygonvalidium.PolygonvalidiumSequenceBatchesIterator {
+	t.Helper()
+
+	logs := make([]ethTypes.Log, len(lastBatches))
+	for i, batch := range lastBatches {
+		logs[i] = ethermantest.Log(t, batch)
+	}
+
+	return ethermantest.Iterator(t, logs...)
+}
+
+func TestPruner_Prune(t *testing.T) {
+	t.Run("no criteria", func(t *testing.T) {
+		_, err := New(mocks.NewDB(t), mocks.NewEtherman(t), nil, 100, 2).Prune(context.Background(), Criteria{}, "", false)
+		require.ErrorIs(t, err, ErrNoCriteria)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		em := mocks.NewEtherman(t)
+		em.On("LastVerifiedBatch", mock.Anything).Return(uint64(7), nil)
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("CountOffChainDataBefore", mock.Anything, uint64(8)).Return(uint64(5), uint64(1000), nil)
+
+		result, err := New(dbMock, em, nil, 100, 2).Prune(context.Background(),
+			Criteria{BeforeBatch: 10, VerifiedOnly: true}, DefaultReason, true)
+		require.NoError(t, err)
+		require.Equal(t, &Result{BeforeBatch: 8, Values: 5, Size: 1000}, result)
+	})
+
+	t.Run("values sequenced before a block", func(t *testing.T) {
+		pk, err := crypto.GenerateKey()
+		require.NoError(t, err)
+
+		em := mocks.NewEtherman(t)
+		em.On("FilterSequenceBatches", ethermantest.BlockRange(150, 249), mock.Anything).Return(sequences(t), nil).Once()
+		em.On("FilterSequenceBatches", ethermantest.BlockRange(50, 149), mock.Anything).Return(sequences(t, 3, 4), nil).Once()
+		em.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&ethTypes.Header{Number: big.NewInt(300)}, nil)
+
+		keys := []types.BatchKey{
+			{Number: 1, Hash: common.HexToHash("0x1")},
+			{Number: 2, Hash: common.HexToHash("0x2")},
+			{Number: 4, Hash: common.HexToHash("0x3")},
+			{Number: 5, Hash: common.HexToHash("0x4")},
+		}
+
+		// the tombstones are signed, and recorded for the keys of the batches before the cutoff only
+		var pruned []types.Tombstone
+		prune := func(_ context.Context, tombstones []types.Tombstone) error {
+			for _, tombstone := range tombstones {
+				signer, err := tombstone.Signer()
+				require.NoError(t, err)
+				require.Equal(t, crypto.PubkeyToAddress(pk.PublicKey), signer)
+				require.Equal(t, types.ArgUint64(300), tombstone.PrunedAtBlock)
+				require.Equal(t, "gdpr request", tombstone.Reason)
+			}
+			pruned = append(pruned, tombstones...)
+			return nil
+		}
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("CountOffChainDataBefore", mock.Anything, uint64(5)).Return(uint64(3), uint64(300), nil)
+		dbMock.On("GetOffChainDataKeys", mock.Anything, uint64(1), uint(2)).Return(keys[:2], nil).Once()
+		dbMock.On("GetOffChainDataKeys", mock.Anything, uint64(1), uint(2)).Return(keys[2:], nil).Once()
+		dbMock.On("PruneOffChainData", mock.Anything, mock.Anything).Return(prune).Twice()
+
+		result, err := New(dbMock, em, pk, 100, 2).Prune(context.Background(),
+			Criteria{BeforeBlock: 250}, "gdpr request", false)
+		require.NoError(t, err)
+		require.Equal(t, &Result{BeforeBatch: 5, Values: 3, Size: 300, PrunedAtBlock: 300}, result)
+
+		require.Len(t, pruned, 3)
+		for i, tombstone := range pruned {
+			require.Equal(t, keys[i].Hash, tombstone.Key)
+			require.Equal(t, types.ArgUint64(keys[i].Number), tombstone.BatchNum)
+		}
+	})
+
+	t.Run("values sequenced before a date", func(t *testing.T) {
+		// a block every 12 seconds from the time 0
+		header := func(number uint64) *ethTypes.Header {
+			return &ethTypes.Header{Number: new(big.Int).SetUint64(number), Time: number * 12}
+		}
+
+		em := mocks.NewEtherman(t)
+		em.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(header(1000), nil)
+		em.On("HeaderByNumber", mock.Anything, mock.Anything).Return(
+			func(_ context.Context, number *big.Int) (*ethTypes.Header, error) {
+				return header(number.Uint64()), nil
+			})
+		em.On("FilterSequenceBatches", ethermantest.BlockRange(0, 99), mock.Anything).Return(sequences(t, 2), nil).Once()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("CountOffChainDataBefore", mock.Anything, uint64(3)).Return(uint64(0), uint64(0), nil)
+
+		// the block 100 is the first one at or after the time 1195
+		result, err := New(dbMock, em, nil, 100, 2).Prune(context.Background(),
+			Criteria{Before: time.Unix(1195, 0)}, DefaultReason, false)
+		require.NoError(t, err)
+		require.Equal(t, &Result{BeforeBatch: 3}, result)
+	})
+}
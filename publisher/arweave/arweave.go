@@ -0,0 +1,90 @@
+package arweave
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/0xPolygon/cdk-data-availability/publisher"
+	"github.com/0xPolygon/cdk-data-availability/types"
+)
+
+// Backend is the name of the Arweave backend
+const Backend = "arweave"
+
+// appName tags the data items uploaded by the node, so they can be found on Arweave
+const appName = "cdk-data-availability"
+
+// maxErrorSize bounds the error message read from the bundler
+const maxErrorSize = 512
+
+// Publisher uploads the values to Arweave for permanent storage, as data items signed
+// with its key and posted to a bundler. The reference of each value is the ID of its
+// data item, under which any Arweave gateway serves it.
+type Publisher struct {
+	cfg    publisher.ArweaveConfig
+	key    *ecdsa.PrivateKey
+	client *http.Client
+}
+
+// New returns a Publisher uploading the values to the bundler of the given config
+func New(cfg publisher.ArweaveConfig, key *ecdsa.PrivateKey) *Publisher {
+	return &Publisher{
+		cfg:    cfg,
+		key:    key,
+		client: &http.Client{},
+	}
+}
+
+// Name implements publisher.Publisher
+func (p *Publisher) Name() string {
+	return Backend
+}
+
+// Publish implements publisher.Publisher
+func (p *Publisher) Publish(ctx context.Context, data types.OffChainData) (string, error) {
+	item, err := NewDataItem(data.Value, []Tag{
+		{Name: "Content-Type", Value: "application/octet-stream"},
+		{Name: "App-Name", Value: appName},
+		{Name: "Data-Key", Value: data.Key.Hex()},
+		{Name: "Batch-Num", Value: strconv.FormatUint(data.BatchNum, 10)},
+	}, p.key)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.URL, bytes.NewReader(item.Bytes()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusCreated {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, maxErrorSize))
+		return "", fmt.Errorf("unexpected status code %d: %s", res.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	receipt := struct {
+		ID string `json:"id"`
+	}{}
+	if err = json.NewDecoder(res.Body).Decode(&receipt); err != nil {
+		return "", err
+	}
+	if receipt.ID != item.ID() {
+		return "", fmt.Errorf("bundler returned the ID %q instead of %q", receipt.ID, item.ID())
+	}
+
+	return receipt.ID, nil
+}
@@ -0,0 +1,66 @@
+package arweave
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/publisher"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublisher_Publish(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	data := types.OffChainData{Key: common.HexToHash("0x1"), Value: []byte("batch data"), BatchNum: 1}
+
+	bundler := func(t *testing.T, id func(item []byte) string) string {
+		t.Helper()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/v1/tx", r.URL.Path)
+			require.Equal(t, "application/octet-stream", r.Header.Get("Content-Type"))
+
+			item, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.Equal(t, data.Value, item[len(item)-len(data.Value):])
+
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+				"id":        id(item),
+				"timestamp": 1700000000000,
+			}))
+		}))
+		t.Cleanup(srv.Close)
+
+		return srv.URL + "/v1/tx"
+	}
+
+	t.Run("value uploaded", func(t *testing.T) {
+		url := bundler(t, func(item []byte) string {
+			// the ID is the hash of the signature, following the signature type
+			signature := item[2 : 2+signatureSize]
+			return (&DataItem{Signature: signature}).ID()
+		})
+
+		p := New(publisher.ArweaveConfig{Enabled: true, URL: url}, key)
+		require.Equal(t, Backend, p.Name())
+
+		id, err := p.Publish(context.Background(), data)
+		require.NoError(t, err)
+		require.Len(t, id, 43)
+	})
+
+	t.Run("unexpected ID returned", func(t *testing.T) {
+		url := bundler(t, func([]byte) string { return "unexpected" })
+
+		_, err := New(publisher.ArweaveConfig{Enabled: true, URL: url}, key).Publish(context.Background(), data)
+		require.ErrorContains(t, err, `bundler returned the ID "unexpected"`)
+	})
+}
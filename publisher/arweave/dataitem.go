@@ -0,0 +1,139 @@
+package arweave
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// signatureTypeEthereum is the ANS-104 signature type of the data items signed with
+// an Ethereum key, over the EIP-191 hash of the deep hash of the data item
+const signatureTypeEthereum = 3
+
+const (
+	// signatureSize is the size of an Ethereum signature, r, s and v
+	signatureSize = 65
+	// ownerSize is the size of an uncompressed secp256k1 public key
+	ownerSize = 65
+	// recoveryIDOffset is added to the recovery ID of the signature, as personal_sign does
+	recoveryIDOffset = 27
+)
+
+// Tag is a name and value indexed by Arweave along with a data item
+type Tag struct {
+	Name  string
+	Value string
+}
+
+// DataItem is an ANS-104 data item, the unit of data bundled into Arweave transactions
+// by the bundlers. It has no target nor anchor.
+type DataItem struct {
+	Signature []byte
+	Owner     []byte
+	Tags      []Tag
+	Data      []byte
+}
+
+// NewDataItem returns the data item of the given data and tags, signed with the key
+func NewDataItem(data []byte, tags []Tag, key *ecdsa.PrivateKey) (*DataItem, error) {
+	item := &DataItem{
+		Owner: crypto.FromECDSAPub(&key.PublicKey),
+		Tags:  tags,
+		Data:  data,
+	}
+
+	signature, err := crypto.Sign(accounts.TextHash(item.signatureData()), key)
+	if err != nil {
+		return nil, err
+	}
+	signature[crypto.RecoveryIDOffset] += recoveryIDOffset
+	item.Signature = signature
+
+	return item, nil
+}
+
+// ID returns the ID of the data item, under which it is retrieved from Arweave
+func (d *DataItem) ID() string {
+	id := sha256.Sum256(d.Signature)
+	return base64.RawURLEncoding.EncodeToString(id[:])
+}
+
+// Bytes returns the binary encoding of the data item
+func (d *DataItem) Bytes() []byte {
+	tags := encodeTags(d.Tags)
+
+	b := &bytes.Buffer{}
+	_ = binary.Write(b, binary.LittleEndian, uint16(signatureTypeEthereum))
+	b.Write(d.Signature)
+	b.Write(d.Owner)
+	// no target nor anchor
+	b.WriteByte(0)
+	b.WriteByte(0)
+	_ = binary.Write(b, binary.LittleEndian, uint64(len(d.Tags)))
+	_ = binary.Write(b, binary.LittleEndian, uint64(len(tags)))
+	b.Write(tags)
+	b.Write(d.Data)
+
+	return b.Bytes()
+}
+
+// signatureData returns the deep hash of the fields of the data item covered by the signature
+func (d *DataItem) signatureData() []byte {
+	return deepHash([][]byte{
+		[]byte("dataitem"),
+		[]byte("1"),
+		[]byte(strconv.Itoa(signatureTypeEthereum)),
+		d.Owner,
+		{}, // target
+		{}, // anchor
+		encodeTags(d.Tags),
+		d.Data,
+	})
+}
+
+// deepHash returns the Arweave deep hash of a list of blobs
+func deepHash(list [][]byte) []byte {
+	acc := sha384([]byte("list" + strconv.Itoa(len(list))))
+	for _, blob := range list {
+		tag := sha384([]byte("blob" + strconv.Itoa(len(blob))))
+		acc = sha384(append(acc, sha384(append(tag, sha384(blob)...))...))
+	}
+
+	return acc
+}
+
+func sha384(b []byte) []byte {
+	h := sha512.Sum384(b)
+	return h[:]
+}
+
+// encodeTags returns the Avro encoding of the tags, as an array of name and value records
+func encodeTags(tags []Tag) []byte {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	b := &bytes.Buffer{}
+	writeLong := func(n int64) {
+		buf := make([]byte, binary.MaxVarintLen64)
+		b.Write(buf[:binary.PutVarint(buf, n)])
+	}
+
+	writeLong(int64(len(tags)))
+	for _, tag := range tags {
+		writeLong(int64(len(tag.Name)))
+		b.WriteString(tag.Name)
+		writeLong(int64(len(tag.Value)))
+		b.WriteString(tag.Value)
+	}
+	writeLong(0)
+
+	return b.Bytes()
+}
@@ -0,0 +1,65 @@
+package arweave
+
+import (
+	"crypto/sha512"
+	"encoding/binary"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeTags(t *testing.T) {
+	require.Nil(t, encodeTags(nil))
+	require.Equal(t,
+		[]byte{0x04, 0x02, 'a', 0x04, 'b', 'c', 0x02, 'd', 0x00, 0x00},
+		encodeTags([]Tag{{Name: "a", Value: "bc"}, {Name: "d", Value: ""}}),
+	)
+}
+
+func TestDeepHash(t *testing.T) {
+	empty := sha512.Sum384([]byte("list0"))
+	require.Equal(t, empty[:], deepHash(nil))
+
+	list := sha512.Sum384([]byte("list1"))
+	tag := sha512.Sum384([]byte("blob3"))
+	blob := sha512.Sum384([]byte("abc"))
+	tagged := sha512.Sum384(append(tag[:], blob[:]...))
+	expected := sha512.Sum384(append(list[:], tagged[:]...))
+	require.Equal(t, expected[:], deepHash([][]byte{[]byte("abc")}))
+}
+
+func TestNewDataItem(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	tags := []Tag{{Name: "App-Name", Value: appName}}
+	item, err := NewDataItem([]byte("batch data"), tags, key)
+	require.NoError(t, err)
+
+	// the signature is a personal_sign signature of the deep hash, by the owner
+	require.Len(t, item.Signature, signatureSize)
+	require.GreaterOrEqual(t, item.Signature[crypto.RecoveryIDOffset], byte(recoveryIDOffset))
+	signature := append([]byte{}, item.Signature...)
+	signature[crypto.RecoveryIDOffset] -= recoveryIDOffset
+	pub, err := crypto.SigToPub(accounts.TextHash(item.signatureData()), signature)
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), crypto.PubkeyToAddress(*pub))
+
+	b := item.Bytes()
+	require.Equal(t, uint16(signatureTypeEthereum), binary.LittleEndian.Uint16(b[0:2]))
+	require.Equal(t, item.Signature, b[2:2+signatureSize])
+	require.Equal(t, crypto.FromECDSAPub(&key.PublicKey), b[2+signatureSize:2+signatureSize+ownerSize])
+
+	offset := 2 + signatureSize + ownerSize
+	require.Equal(t, []byte{0, 0}, b[offset:offset+2])
+	offset += 2
+	require.Equal(t, uint64(1), binary.LittleEndian.Uint64(b[offset:offset+8]))
+	tagsSize := binary.LittleEndian.Uint64(b[offset+8 : offset+16])
+	offset += 16
+	require.Equal(t, encodeTags(tags), b[offset:offset+int(tagsSize)])
+	require.Equal(t, []byte("batch data"), b[offset+int(tagsSize):])
+
+	require.Len(t, item.ID(), 43)
+}
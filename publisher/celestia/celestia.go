@@ -0,0 +1,143 @@
+package celestia
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/0xPolygon/cdk-data-availability/publisher"
+	"github.com/0xPolygon/cdk-data-availability/rpc"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Backend is the name of the Celestia backend
+const Backend = "celestia"
+
+const (
+	// namespaceSize is the size of a Celestia namespace, the version followed by the ID
+	namespaceSize = 29
+	// namespaceIDSize is the size of the user defined part of a version 0 namespace ID
+	namespaceIDSize = 10
+)
+
+// blob is a Celestia blob as encoded by the light node API, bytes being base64 encoded
+type blob struct {
+	Namespace    []byte `json:"namespace"`
+	Data         []byte `json:"data"`
+	ShareVersion uint32 `json:"share_version"`
+	Commitment   []byte `json:"commitment,omitempty"`
+}
+
+// txConfig are the options of the blob transaction
+type txConfig struct {
+	GasPrice      float64 `json:"gas_price,omitempty"`
+	IsGasPriceSet bool    `json:"is_gas_price_set,omitempty"`
+}
+
+// Publisher posts the values as blobs in the namespace of the rollup through a Celestia
+// light node. The reference of each value is the height of the block including it and
+// the commitment of the blob, e.g. 1234567:0x...
+type Publisher struct {
+	cfg       publisher.CelestiaConfig
+	namespace []byte
+	client    *http.Client
+}
+
+// New returns a Publisher posting the values through the light node of the given config
+func New(cfg publisher.CelestiaConfig) (*Publisher, error) {
+	namespace, err := Namespace(cfg.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Publisher{
+		cfg:       cfg,
+		namespace: namespace,
+		client:    &http.Client{},
+	}, nil
+}
+
+// Namespace returns the version 0 namespace of the given ID, in hex
+func Namespace(id string) ([]byte, error) {
+	b := common.FromHex(id)
+	if len(b) == 0 || len(b) > namespaceIDSize {
+		return nil, fmt.Errorf("namespace ID %q must be between 1 and %d bytes", id, namespaceIDSize)
+	}
+
+	namespace := make([]byte, namespaceSize)
+	copy(namespace[namespaceSize-len(b):], b)
+
+	return namespace, nil
+}
+
+// Name implements publisher.Publisher
+func (p *Publisher) Name() string {
+	return Backend
+}
+
+// Publish implements publisher.Publisher. The commitment is computed by the light node, so
+// it is read back from the blobs of the namespace at the height the value was included.
+func (p *Publisher) Publish(ctx context.Context, data types.OffChainData) (string, error) {
+	opts := txConfig{}
+	if p.cfg.GasPrice > 0 {
+		opts = txConfig{GasPrice: p.cfg.GasPrice, IsGasPriceSet: true}
+	}
+
+	var height uint64
+	err := p.call(ctx, &height, "blob.Submit", []blob{{Namespace: p.namespace, Data: data.Value}}, opts)
+	if err != nil {
+		return "", err
+	}
+
+	var blobs []blob
+	if err = p.call(ctx, &blobs, "blob.GetAll", height, [][]byte{p.namespace}); err != nil {
+		return "", err
+	}
+
+	for _, b := range blobs {
+		if bytes.Equal(b.Data, data.Value) {
+			return fmt.Sprintf("%d:0x%x", height, b.Commitment), nil
+		}
+	}
+
+	return "", fmt.Errorf("blob of %s not found at height %d", data.Key.Hex(), height)
+}
+
+// call calls a method of the light node API and decodes its result
+func (p *Publisher) call(ctx context.Context, result interface{}, method string, params ...interface{}) error {
+	req, err := rpc.BuildJsonHTTPRequest(ctx, strings.TrimSuffix(p.cfg.URL, "/"), method, params...)
+	if err != nil {
+		return err
+	}
+	if p.cfg.AuthToken != "" {
+		req.Header.Set("Authorization", "Bearer "+p.cfg.AuthToken)
+	}
+
+	httpRes, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer httpRes.Body.Close()
+
+	if httpRes.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: unexpected status code %d", method, httpRes.StatusCode)
+	}
+
+	var res rpc.Response
+	if err = json.NewDecoder(httpRes.Body).Decode(&res); err != nil {
+		return err
+	}
+	if res.Error != nil {
+		return fmt.Errorf("%s: %d %s", method, res.Error.Code, res.Error.Message)
+	}
+	if len(res.Result) == 0 {
+		return errors.New(method + ": empty result")
+	}
+
+	return json.Unmarshal(res.Result, result)
+}
@@ -0,0 +1,104 @@
+package celestia
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/publisher"
+	"github.com/0xPolygon/cdk-data-availability/rpc"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespace(t *testing.T) {
+	namespace, err := Namespace("0xcafe")
+	require.NoError(t, err)
+	require.Len(t, namespace, namespaceSize)
+	require.Equal(t, []byte{0xca, 0xfe}, namespace[namespaceSize-2:])
+	require.Equal(t, make([]byte, namespaceSize-2), namespace[:namespaceSize-2])
+
+	_, err = Namespace("")
+	require.Error(t, err)
+
+	_, err = Namespace("0x0102030405060708090a0b")
+	require.Error(t, err)
+}
+
+func TestPublisher_Publish(t *testing.T) {
+	data := types.OffChainData{Key: common.HexToHash("0x1"), Value: []byte("batch data"), BatchNum: 1}
+	commitment := []byte{0x0a, 0x0b}
+
+	node := func(t *testing.T, submitErr *rpc.ErrorObject) string {
+		t.Helper()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+			var req rpc.Request
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+			res := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+			switch req.Method {
+			case "blob.Submit":
+				var params []json.RawMessage
+				require.NoError(t, json.Unmarshal(req.Params, &params))
+				require.Len(t, params, 2)
+				require.JSONEq(t, `{"gas_price":0.002,"is_gas_price_set":true}`, string(params[1]))
+
+				var blobs []blob
+				require.NoError(t, json.Unmarshal(params[0], &blobs))
+				require.Len(t, blobs, 1)
+				require.Equal(t, data.Value, blobs[0].Data)
+
+				if submitErr != nil {
+					res["error"] = submitErr
+				} else {
+					res["result"] = 1234
+				}
+			case "blob.GetAll":
+				require.JSONEq(t, `[1234,["AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAyv4="]]`, string(req.Params))
+				res["result"] = []blob{
+					{Data: []byte("other data"), Commitment: []byte{0x01}},
+					{Data: data.Value, Commitment: commitment},
+				}
+			default:
+				t.Fatalf("unexpected method %s", req.Method)
+			}
+
+			require.NoError(t, json.NewEncoder(w).Encode(res))
+		}))
+		t.Cleanup(srv.Close)
+
+		return srv.URL
+	}
+
+	cfg := publisher.CelestiaConfig{Enabled: true, AuthToken: "secret", Namespace: "0xcafe", GasPrice: 0.002}
+
+	t.Run("blob included", func(t *testing.T) {
+		cfg := cfg
+		cfg.URL = node(t, nil)
+
+		p, err := New(cfg)
+		require.NoError(t, err)
+		require.Equal(t, Backend, p.Name())
+
+		reference, err := p.Publish(context.Background(), data)
+		require.NoError(t, err)
+		require.Equal(t, "1234:0x0a0b", reference)
+	})
+
+	t.Run("blob not submitted", func(t *testing.T) {
+		cfg := cfg
+		cfg.URL = node(t, &rpc.ErrorObject{Code: 1, Message: "insufficient funds"})
+
+		p, err := New(cfg)
+		require.NoError(t, err)
+
+		_, err = p.Publish(context.Background(), data)
+		require.ErrorContains(t, err, "blob.Submit: 1 insufficient funds")
+	})
+}
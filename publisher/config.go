@@ -0,0 +1,131 @@
+package publisher
+
+import "github.com/0xPolygon/cdk-data-availability/config/types"
+
+// Config represents the configuration of the publication of the stored values to
+// external storage backends, as an additional redundancy layer
+type Config struct {
+	// Interval is the time between two rounds publishing the pending values
+	Interval types.Duration `mapstructure:"Interval"`
+
+	// BatchSize is the maximum number of values published to a backend in a round
+	BatchSize uint `mapstructure:"BatchSize"`
+
+	// Timeout bounds the publication of each value
+	Timeout types.Duration `mapstructure:"Timeout"`
+
+	// IPFS is the configuration of the IPFS backend
+	IPFS IPFSConfig `mapstructure:"IPFS"`
+
+	// S3 is the configuration of the S3 backend
+	S3 S3Config `mapstructure:"S3"`
+
+	// Celestia is the configuration of the Celestia backend
+	Celestia CelestiaConfig `mapstructure:"Celestia"`
+
+	// EigenDA is the configuration of the EigenDA backend
+	EigenDA EigenDAConfig `mapstructure:"EigenDA"`
+
+	// Arweave is the configuration of the Arweave backend
+	Arweave ArweaveConfig `mapstructure:"Arweave"`
+}
+
+// Enabled tells whether the values are published to any backend
+func (c Config) Enabled() bool {
+	return c.IPFS.Enabled || c.S3.Enabled || c.Celestia.Enabled || c.EigenDA.Enabled || c.Arweave.Enabled
+}
+
+// IPFSConfig represents the configuration of the IPFS backend, pinning the values
+// to an IPFS node or cluster
+type IPFSConfig struct {
+	// Enabled publishes the values to IPFS
+	Enabled bool `mapstructure:"Enabled"`
+
+	// URL of the Kubo RPC API of the node, or of the IPFS Cluster proxy, e.g. http://localhost:5001
+	URL string `mapstructure:"URL"`
+
+	// Token is sent as a bearer token to the API, if set
+	Token string `mapstructure:"Token" secret:"true"`
+}
+
+// S3Config represents the configuration of the S3 backend, archiving the values to a bucket
+// of AWS S3 or of an S3 compatible service. The credentials are taken from the environment,
+// the shared AWS config files or the instance role.
+type S3Config struct {
+	// Enabled publishes the values to S3
+	Enabled bool `mapstructure:"Enabled"`
+
+	// Bucket the values are stored in
+	Bucket string `mapstructure:"Bucket"`
+
+	// Prefix of the object keys, the object of each value is named after its key
+	Prefix string `mapstructure:"Prefix"`
+
+	// Region of the bucket, taken from the AWS config if empty
+	Region string `mapstructure:"Region"`
+
+	// Endpoint of an S3 compatible service, e.g. http://localhost:9000 for MinIO
+	Endpoint string `mapstructure:"Endpoint"`
+
+	// UsePathStyle addresses the bucket in the path instead of the host name, as most S3 compatible services expect
+	UsePathStyle bool `mapstructure:"UsePathStyle"`
+
+	// ServerSideEncryption of the objects: AES256, aws:kms or empty for the bucket default
+	ServerSideEncryption string `mapstructure:"ServerSideEncryption"`
+
+	// KMSKeyID is the KMS key encrypting the objects when ServerSideEncryption is aws:kms
+	KMSKeyID string `mapstructure:"KMSKeyID"`
+
+	// StorageClass of the objects, e.g. STANDARD_IA, or empty for STANDARD
+	StorageClass string `mapstructure:"StorageClass"`
+
+	// TransitionDays is the age in days at which the objects are moved to TransitionStorageClass
+	// by a lifecycle rule of the bucket scoped to Prefix. No rule is set up when zero.
+	TransitionDays uint `mapstructure:"TransitionDays"`
+
+	// TransitionStorageClass is the storage class the objects are moved to, e.g. GLACIER
+	TransitionStorageClass string `mapstructure:"TransitionStorageClass"`
+}
+
+// CelestiaConfig represents the configuration of the Celestia backend, posting the values
+// as blobs through a Celestia light node
+type CelestiaConfig struct {
+	// Enabled publishes the values to Celestia
+	Enabled bool `mapstructure:"Enabled"`
+
+	// URL of the JSON-RPC API of the light node, e.g. http://localhost:26658
+	URL string `mapstructure:"URL"`
+
+	// AuthToken is the write token of the light node, sent as a bearer token
+	AuthToken string `mapstructure:"AuthToken" secret:"true"`
+
+	// Namespace is the ID of the version 0 namespace of the rollup blobs, up to 10 bytes in hex
+	Namespace string `mapstructure:"Namespace"`
+
+	// GasPrice of the blob transactions in utia, the light node estimates it if zero
+	GasPrice float64 `mapstructure:"GasPrice"`
+}
+
+// EigenDAConfig represents the configuration of the EigenDA backend, dispersing the values
+// through an EigenDA proxy
+type EigenDAConfig struct {
+	// Enabled publishes the values to EigenDA
+	Enabled bool `mapstructure:"Enabled"`
+
+	// URL of the EigenDA proxy, e.g. http://localhost:3100
+	URL string `mapstructure:"URL"`
+}
+
+// ArweaveConfig represents the configuration of the Arweave backend, uploading the values
+// as signed data items to a bundler, e.g. ArDrive Turbo or Irys, which posts them to Arweave
+type ArweaveConfig struct {
+	// Enabled publishes the values to Arweave
+	Enabled bool `mapstructure:"Enabled"`
+
+	// URL of the upload endpoint of the bundler, e.g. https://upload.ardrive.io/v1/tx
+	URL string `mapstructure:"URL"`
+
+	// PrivateKey signs the data items, the bundler charges the uploads to its address.
+	// It should not be the key of the committee member.
+	PrivateKey types.KeystoreFileConfig `mapstructure:"PrivateKey"`
+}
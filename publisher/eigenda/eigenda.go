@@ -0,0 +1,75 @@
+package eigenda
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/0xPolygon/cdk-data-availability/publisher"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Backend is the name of the EigenDA backend
+const Backend = "eigenda"
+
+// putPath is the EigenDA proxy endpoint dispersing a blob, returning its certificate
+const putPath = "/put?commitment_mode=standard"
+
+// maxErrorSize bounds the error message read from the proxy
+const maxErrorSize = 512
+
+// Publisher disperses the values to EigenDA through an EigenDA proxy, which waits for the
+// blob to be confirmed and returns its certificate. The reference of each value is the
+// certificate, hex encoded, which the proxy takes to retrieve the value back.
+type Publisher struct {
+	cfg    publisher.EigenDAConfig
+	client *http.Client
+}
+
+// New returns a Publisher dispersing the values through the proxy of the given config
+func New(cfg publisher.EigenDAConfig) *Publisher {
+	return &Publisher{
+		cfg:    cfg,
+		client: &http.Client{},
+	}
+}
+
+// Name implements publisher.Publisher
+func (p *Publisher) Name() string {
+	return Backend
+}
+
+// Publish implements publisher.Publisher
+func (p *Publisher) Publish(ctx context.Context, data types.OffChainData) (string, error) {
+	url := strings.TrimSuffix(p.cfg.URL, "/") + putPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data.Value))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, maxErrorSize))
+		return "", fmt.Errorf("unexpected status code %d: %s", res.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	cert, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", err
+	}
+	if len(cert) == 0 {
+		return "", fmt.Errorf("no certificate returned for %s", data.Key.Hex())
+	}
+
+	return hexutil.Encode(cert), nil
+}
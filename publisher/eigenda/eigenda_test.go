@@ -0,0 +1,50 @@
+package eigenda
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/publisher"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublisher_Publish(t *testing.T) {
+	data := types.OffChainData{Key: common.HexToHash("0x1"), Value: []byte("batch data"), BatchNum: 1}
+
+	t.Run("value dispersed", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPost, r.Method)
+			require.Equal(t, "/put", r.URL.Path)
+			require.Equal(t, "standard", r.URL.Query().Get("commitment_mode"))
+
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.Equal(t, data.Value, body)
+
+			_, _ = w.Write([]byte{0xce, 0x27})
+		}))
+		defer srv.Close()
+
+		p := New(publisher.EigenDAConfig{Enabled: true, URL: srv.URL})
+		require.Equal(t, Backend, p.Name())
+
+		cert, err := p.Publish(context.Background(), data)
+		require.NoError(t, err)
+		require.Equal(t, "0xce27", cert)
+	})
+
+	t.Run("dispersal failed", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "blob status FAILED", http.StatusInternalServerError)
+		}))
+		defer srv.Close()
+
+		_, err := New(publisher.EigenDAConfig{Enabled: true, URL: srv.URL}).Publish(context.Background(), data)
+		require.ErrorContains(t, err, "unexpected status code 500: blob status FAILED")
+	})
+}
@@ -0,0 +1,90 @@
+package ipfs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/0xPolygon/cdk-data-availability/publisher"
+	"github.com/0xPolygon/cdk-data-availability/types"
+)
+
+// Backend is the name of the IPFS backend
+const Backend = "ipfs"
+
+// addPath is the Kubo RPC API endpoint adding and pinning a file, addressed by its CIDv1
+const addPath = "/api/v0/add?pin=true&cid-version=1"
+
+// Publisher pins the values to an IPFS node, or to an IPFS Cluster through its
+// Kubo compatible proxy. The reference of each value is its CID.
+type Publisher struct {
+	cfg    publisher.IPFSConfig
+	client *http.Client
+}
+
+// New returns a Publisher pinning the values to the node of the given config
+func New(cfg publisher.IPFSConfig) *Publisher {
+	return &Publisher{
+		cfg:    cfg,
+		client: &http.Client{},
+	}
+}
+
+// Name implements publisher.Publisher
+func (p *Publisher) Name() string {
+	return Backend
+}
+
+// Publish implements publisher.Publisher, adding the value as a file named after its key
+func (p *Publisher) Publish(ctx context.Context, data types.OffChainData) (string, error) {
+	body := &bytes.Buffer{}
+	form := multipart.NewWriter(body)
+	file, err := form.CreateFormFile("file", data.Key.Hex())
+	if err != nil {
+		return "", err
+	}
+	if _, err = file.Write(data.Value); err != nil {
+		return "", err
+	}
+	if err = form.Close(); err != nil {
+		return "", err
+	}
+
+	url := strings.TrimSuffix(p.cfg.URL, "/") + addPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	if p.cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.cfg.Token)
+	}
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 512)) //nolint:gomnd
+		return "", fmt.Errorf("unexpected status code %d: %s", res.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	added := struct {
+		Hash string `json:"Hash"`
+	}{}
+	if err = json.NewDecoder(res.Body).Decode(&added); err != nil {
+		return "", err
+	}
+	if added.Hash == "" {
+		return "", fmt.Errorf("no CID returned for %s", data.Key.Hex())
+	}
+
+	return added.Hash, nil
+}
@@ -0,0 +1,59 @@
+package ipfs
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/publisher"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublisher_Publish(t *testing.T) {
+	data := types.OffChainData{Key: common.HexToHash("0x1"), Value: []byte("batch data"), BatchNum: 1}
+
+	t.Run("value pinned", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/api/v0/add", r.URL.Path)
+			require.Equal(t, "true", r.URL.Query().Get("pin"))
+			require.Equal(t, "1", r.URL.Query().Get("cid-version"))
+			require.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+			file, header, err := r.FormFile("file")
+			require.NoError(t, err)
+			require.Equal(t, data.Key.Hex(), header.Filename)
+			value, err := io.ReadAll(file)
+			require.NoError(t, err)
+			require.Equal(t, data.Value, value)
+
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]string{
+				"Name": header.Filename,
+				"Hash": "bafkreiexample",
+				"Size": "10",
+			}))
+		}))
+		defer srv.Close()
+
+		p := New(publisher.IPFSConfig{Enabled: true, URL: srv.URL + "/", Token: "secret"})
+		require.Equal(t, Backend, p.Name())
+
+		cid, err := p.Publish(context.Background(), data)
+		require.NoError(t, err)
+		require.Equal(t, "bafkreiexample", cid)
+	})
+
+	t.Run("error returned by the node", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "repo full", http.StatusInternalServerError)
+		}))
+		defer srv.Close()
+
+		_, err := New(publisher.IPFSConfig{Enabled: true, URL: srv.URL}).Publish(context.Background(), data)
+		require.ErrorContains(t, err, "unexpected status code 500: repo full")
+	})
+}
@@ -0,0 +1,121 @@
+package publisher
+
+import (
+	"context"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/metrics"
+	"github.com/0xPolygon/cdk-data-availability/reporter"
+	"github.com/0xPolygon/cdk-data-availability/types"
+)
+
+// logger is the logger of the publisher component
+var logger = log.WithComponent("publisher")
+
+// Publisher copies the values to an external storage backend
+type Publisher interface {
+	// Name identifies the backend, e.g. ipfs
+	Name() string
+	// Publish stores the value in the backend and returns the reference locating it there
+	Publish(ctx context.Context, data types.OffChainData) (string, error)
+}
+
+// Worker periodically publishes the values not yet stored in a backend, oldest batch
+// first, and records the reference of each value once published
+type Worker struct {
+	cfg       Config
+	db        db.DB
+	publisher Publisher
+	stop      chan struct{}
+}
+
+// NewWorker returns a Worker publishing the values to the given backend
+func NewWorker(cfg Config, db db.DB, publisher Publisher) *Worker {
+	return &Worker{
+		cfg:       cfg,
+		db:        db,
+		publisher: publisher,
+		stop:      make(chan struct{}),
+	}
+}
+
+// Start publishes the pending values every interval until the worker is stopped
+func (w *Worker) Start(ctx context.Context) {
+	defer reporter.Recover()
+
+	logger.Infof("starting to publish the values to %s", w.publisher.Name())
+	ticker := time.NewTicker(w.cfg.Interval.Duration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := w.PublishPending(ctx); err != nil {
+				logger.Errorf("failed to publish the values to %s: %v", w.publisher.Name(), err)
+			}
+		case <-ctx.Done():
+			return
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+// Stop stops the worker
+func (w *Worker) Stop() {
+	close(w.stop)
+}
+
+// PublishPending publishes up to a batch of pending values, and returns how many were published.
+// It stops on the first failure, so the values are published in order once the backend recovers.
+func (w *Worker) PublishPending(ctx context.Context) (int, error) {
+	backend := w.publisher.Name()
+
+	dbCtx, cancel := context.WithTimeout(ctx, w.cfg.Timeout.Duration)
+	pending, err := w.db.GetUnpublishedOffChainData(dbCtx, backend, w.cfg.BatchSize)
+	cancel()
+	if err != nil {
+		return 0, err
+	}
+
+	for i, data := range pending {
+		if err = w.Publish(ctx, data); err != nil {
+			logger.WithFields(
+				log.FieldKeyHash, data.Key.Hex(),
+				log.FieldBatchNumber, data.BatchNum,
+			).Warnf("failed to publish the value to %s: %v", backend, err)
+			return i, err
+		}
+	}
+
+	return len(pending), nil
+}
+
+// Name returns the name of the backend the worker publishes to
+func (w *Worker) Name() string {
+	return w.publisher.Name()
+}
+
+// Publish publishes a value and records its reference
+func (w *Worker) Publish(parentCtx context.Context, data types.OffChainData) error {
+	backend := w.publisher.Name()
+
+	ctx, cancel := context.WithTimeout(parentCtx, w.cfg.Timeout.Duration)
+	defer cancel()
+
+	start := time.Now()
+	reference, err := w.publisher.Publish(ctx, data)
+	metrics.PublishValue(backend, start, err)
+	if err != nil {
+		return err
+	}
+
+	return w.db.StorePublication(ctx, types.Publication{
+		Key:       data.Key,
+		Backend:   backend,
+		Reference: reference,
+		Timestamp: time.Now().UTC(),
+	})
+}
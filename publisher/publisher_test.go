@@ -0,0 +1,89 @@
+package publisher_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	cfgTypes "github.com/0xPolygon/cdk-data-availability/config/types"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/publisher"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePublisher returns the key of the values as their reference, or fails on the given key
+type fakePublisher struct {
+	failOn    common.Hash
+	published []common.Hash
+}
+
+func (p *fakePublisher) Name() string {
+	return "fake"
+}
+
+func (p *fakePublisher) Publish(_ context.Context, data types.OffChainData) (string, error) {
+	if data.Key == p.failOn {
+		return "", errors.New("backend unavailable")
+	}
+	p.published = append(p.published, data.Key)
+	return data.Key.Hex(), nil
+}
+
+func TestWorker_PublishPending(t *testing.T) {
+	cfg := publisher.Config{
+		Interval:  cfgTypes.NewDuration(time.Minute),
+		BatchSize: 10,
+		Timeout:   cfgTypes.NewDuration(5 * time.Second),
+	}
+	pending := []types.OffChainData{
+		{Key: common.HexToHash("0x1"), Value: []byte{1}, BatchNum: 1},
+		{Key: common.HexToHash("0x2"), Value: []byte{2}, BatchNum: 2},
+		{Key: common.HexToHash("0x3"), Value: []byte{3}, BatchNum: 3},
+	}
+
+	t.Run("every value published", func(t *testing.T) {
+		dbMock := mocks.NewDB(t)
+		dbMock.On("GetUnpublishedOffChainData", mock.Anything, "fake", uint(10)).Return(pending, nil).Once()
+		for _, data := range pending {
+			data := data
+			dbMock.On("StorePublication", mock.Anything, mock.MatchedBy(func(p types.Publication) bool {
+				return p.Key == data.Key && p.Backend == "fake" && p.Reference == data.Key.Hex()
+			})).Return(nil).Once()
+		}
+
+		fake := &fakePublisher{}
+		published, err := publisher.NewWorker(cfg, dbMock, fake).PublishPending(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 3, published)
+		require.Equal(t, []common.Hash{pending[0].Key, pending[1].Key, pending[2].Key}, fake.published)
+	})
+
+	t.Run("stops on the first failure", func(t *testing.T) {
+		dbMock := mocks.NewDB(t)
+		dbMock.On("GetUnpublishedOffChainData", mock.Anything, "fake", uint(10)).Return(pending, nil).Once()
+		dbMock.On("StorePublication", mock.Anything, mock.MatchedBy(func(p types.Publication) bool {
+			return p.Key == pending[0].Key
+		})).Return(nil).Once()
+
+		fake := &fakePublisher{failOn: pending[1].Key}
+		published, err := publisher.NewWorker(cfg, dbMock, fake).PublishPending(context.Background())
+		require.ErrorContains(t, err, "backend unavailable")
+		require.Equal(t, 1, published)
+		require.Equal(t, []common.Hash{pending[0].Key}, fake.published)
+	})
+
+	t.Run("pending values not loaded", func(t *testing.T) {
+		dbMock := mocks.NewDB(t)
+		dbMock.On("GetUnpublishedOffChainData", mock.Anything, "fake", uint(10)).
+			Return(nil, errors.New("test error")).Once()
+
+		fake := &fakePublisher{}
+		_, err := publisher.NewWorker(cfg, dbMock, fake).PublishPending(context.Background())
+		require.ErrorContains(t, err, "test error")
+		require.Empty(t, fake.published)
+	})
+}
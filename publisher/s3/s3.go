@@ -0,0 +1,140 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/0xPolygon/cdk-data-availability/publisher"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsConfig "github.com/aws/aws-sdk-go-v2/config"
+	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+)
+
+// Backend is the name of the S3 backend
+const Backend = "s3"
+
+// lifecycleRulePrefix prefixes the ID of the lifecycle rule managed by the node, followed by the object prefix
+const lifecycleRulePrefix = "cdk-data-availability"
+
+// Publisher archives the values to an S3 bucket, one object per value named after its key.
+// The reference of each value is the URI of its object, e.g. s3://bucket/prefix/0x...
+type Publisher struct {
+	cfg    publisher.S3Config
+	client *awss3.Client
+}
+
+// New returns a Publisher archiving the values to the bucket of the given config
+func New(ctx context.Context, cfg publisher.S3Config) (*Publisher, error) {
+	var opts []func(*awsConfig.LoadOptions) error
+	if cfg.Region != "" {
+		opts = append(opts, awsConfig.WithRegion(cfg.Region))
+	}
+
+	awsCfg, err := awsConfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client := awss3.NewFromConfig(awsCfg, func(o *awss3.Options) {
+		if cfg.Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.Endpoint)
+		}
+		o.UsePathStyle = cfg.UsePathStyle
+	})
+
+	return &Publisher{cfg: cfg, client: client}, nil
+}
+
+// Name implements publisher.Publisher
+func (p *Publisher) Name() string {
+	return Backend
+}
+
+// Publish implements publisher.Publisher
+func (p *Publisher) Publish(ctx context.Context, data types.OffChainData) (string, error) {
+	key := p.cfg.Prefix + data.Key.Hex()
+
+	input := &awss3.PutObjectInput{
+		Bucket:      aws.String(p.cfg.Bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(data.Value),
+		ContentType: aws.String("application/octet-stream"),
+		Metadata:    map[string]string{"batch-num": strconv.FormatUint(data.BatchNum, 10)},
+	}
+	if p.cfg.StorageClass != "" {
+		input.StorageClass = s3types.StorageClass(p.cfg.StorageClass)
+	}
+	if p.cfg.ServerSideEncryption != "" {
+		input.ServerSideEncryption = s3types.ServerSideEncryption(p.cfg.ServerSideEncryption)
+	}
+	if p.cfg.KMSKeyID != "" {
+		input.SSEKMSKeyId = aws.String(p.cfg.KMSKeyID)
+	}
+
+	if _, err := p.client.PutObject(ctx, input); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("s3://%s/%s", p.cfg.Bucket, key), nil
+}
+
+// EnsureLifecycle sets up the lifecycle rule moving the objects under the prefix to the
+// transition storage class, if configured. The other rules of the bucket are kept.
+func (p *Publisher) EnsureLifecycle(ctx context.Context) error {
+	if p.cfg.TransitionDays == 0 {
+		return nil
+	}
+
+	var rules []s3types.LifecycleRule
+	current, err := p.client.GetBucketLifecycleConfiguration(ctx, &awss3.GetBucketLifecycleConfigurationInput{
+		Bucket: aws.String(p.cfg.Bucket),
+	})
+	var apiErr smithy.APIError
+	switch {
+	case err == nil:
+		rules = current.Rules
+	case errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchLifecycleConfiguration":
+	default:
+		return err
+	}
+
+	id := lifecycleRulePrefix
+	if p.cfg.Prefix != "" {
+		id += "/" + strings.TrimSuffix(p.cfg.Prefix, "/")
+	}
+
+	rule := s3types.LifecycleRule{
+		ID:     aws.String(id),
+		Status: s3types.ExpirationStatusEnabled,
+		Filter: &s3types.LifecycleRuleFilterMemberPrefix{Value: p.cfg.Prefix},
+		Transitions: []s3types.Transition{{
+			Days:         aws.Int32(int32(p.cfg.TransitionDays)),
+			StorageClass: s3types.TransitionStorageClass(p.cfg.TransitionStorageClass),
+		}},
+	}
+
+	replaced := false
+	for i, r := range rules {
+		if aws.ToString(r.ID) == id {
+			rules[i] = rule
+			replaced = true
+		}
+	}
+	if !replaced {
+		rules = append(rules, rule)
+	}
+
+	_, err = p.client.PutBucketLifecycleConfiguration(ctx, &awss3.PutBucketLifecycleConfigurationInput{
+		Bucket:                 aws.String(p.cfg.Bucket),
+		LifecycleConfiguration: &s3types.BucketLifecycleConfiguration{Rules: rules},
+	})
+
+	return err
+}
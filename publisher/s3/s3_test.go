@@ -0,0 +1,140 @@
+package s3
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/publisher"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPublisher(t *testing.T, handler http.HandlerFunc, modify func(*publisher.S3Config)) *Publisher {
+	t.Helper()
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	t.Setenv("AWS_CONFIG_FILE", "/nonexistent")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", "/nonexistent")
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	cfg := publisher.S3Config{
+		Enabled:      true,
+		Bucket:       "archive",
+		Prefix:       "dac/",
+		Region:       "us-east-1",
+		Endpoint:     srv.URL,
+		UsePathStyle: true,
+	}
+	if modify != nil {
+		modify(&cfg)
+	}
+
+	p, err := New(context.Background(), cfg)
+	require.NoError(t, err)
+
+	return p
+}
+
+func TestPublisher_Publish(t *testing.T) {
+	data := types.OffChainData{Key: common.HexToHash("0x1"), Value: []byte("batch data"), BatchNum: 7}
+
+	t.Run("value archived", func(t *testing.T) {
+		p := newTestPublisher(t, func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPut, r.Method)
+			require.Equal(t, "/archive/dac/"+data.Key.Hex(), r.URL.Path)
+			require.Equal(t, "STANDARD_IA", r.Header.Get("X-Amz-Storage-Class"))
+			require.Equal(t, "aws:kms", r.Header.Get("X-Amz-Server-Side-Encryption"))
+			require.Equal(t, "key-id", r.Header.Get("X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id"))
+			require.Equal(t, "7", r.Header.Get("X-Amz-Meta-Batch-Num"))
+
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.Equal(t, data.Value, body)
+		}, func(cfg *publisher.S3Config) {
+			cfg.StorageClass = "STANDARD_IA"
+			cfg.ServerSideEncryption = "aws:kms"
+			cfg.KMSKeyID = "key-id"
+		})
+		require.Equal(t, Backend, p.Name())
+
+		reference, err := p.Publish(context.Background(), data)
+		require.NoError(t, err)
+		require.Equal(t, "s3://archive/dac/"+data.Key.Hex(), reference)
+	})
+
+	t.Run("error returned by the bucket", func(t *testing.T) {
+		p := newTestPublisher(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`<Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>`))
+		}, nil)
+
+		_, err := p.Publish(context.Background(), data)
+		require.ErrorContains(t, err, "AccessDenied")
+	})
+}
+
+func TestPublisher_EnsureLifecycle(t *testing.T) {
+	t.Run("rule added to the existing ones", func(t *testing.T) {
+		var put string
+		p := newTestPublisher(t, func(w http.ResponseWriter, r *http.Request) {
+			_, ok := r.URL.Query()["lifecycle"]
+			require.True(t, ok)
+
+			switch r.Method {
+			case http.MethodGet:
+				_, _ = w.Write([]byte(`<LifecycleConfiguration><Rule><ID>other</ID><Status>Enabled</Status>` +
+					`<Filter><Prefix>logs/</Prefix></Filter><Expiration><Days>30</Days></Expiration></Rule>` +
+					`</LifecycleConfiguration>`))
+			case http.MethodPut:
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				put = string(body)
+			}
+		}, func(cfg *publisher.S3Config) {
+			cfg.TransitionDays = 90
+			cfg.TransitionStorageClass = "GLACIER"
+		})
+
+		require.NoError(t, p.EnsureLifecycle(context.Background()))
+		require.Contains(t, put, "<ID>other</ID>")
+		require.Contains(t, put, "<ID>cdk-data-availability/dac</ID>")
+		require.Contains(t, put, "<Prefix>dac/</Prefix>")
+		require.Contains(t, put, "<Days>90</Days><StorageClass>GLACIER</StorageClass>")
+	})
+
+	t.Run("no lifecycle configured yet", func(t *testing.T) {
+		var put string
+		p := newTestPublisher(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`<Error><Code>NoSuchLifecycleConfiguration</Code></Error>`))
+				return
+			}
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			put = string(body)
+		}, func(cfg *publisher.S3Config) {
+			cfg.TransitionDays = 90
+			cfg.TransitionStorageClass = "GLACIER"
+		})
+
+		require.NoError(t, p.EnsureLifecycle(context.Background()))
+		require.Equal(t, 1, strings.Count(put, "<Rule>"))
+	})
+
+	t.Run("no rule without transition", func(t *testing.T) {
+		p := newTestPublisher(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Fatal("unexpected request")
+		}, nil)
+
+		require.NoError(t, p.EnsureLifecycle(context.Background()))
+	})
+}
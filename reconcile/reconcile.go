@@ -0,0 +1,340 @@
+package reconcile
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/metrics"
+	"github.com/0xPolygon/cdk-data-availability/notifier"
+	"github.com/0xPolygon/cdk-data-availability/reporter"
+	"github.com/0xPolygon/cdk-data-availability/synchronizer"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// blockTask is the sync task holding the next L1 block to reconcile
+	blockTask = "reconcile"
+	// auditTask is the sync task holding the ID of the next audit log entry to reconcile
+	auditTask = "reconcile_audit"
+
+	// pageSize is the number of audit log entries read at a time
+	pageSize = 1000
+	// signatureLen is the length of each signature in the dataAvailabilityMessage
+	signatureLen = 65
+)
+
+// logger is the logger of the reconciliation component
+var logger = log.WithComponent("reconcile")
+
+// pendingSequence is a sequence signed by the node that has not landed on L1 yet
+type pendingSequence struct {
+	entry types.SignAuditEntry
+	// block is the first L1 block the sequence may have landed in
+	block uint64
+}
+
+// Reconciler periodically matches the sequences signed by the node, from its audit log, against the
+// sequences landing on L1. A signed sequence that does not land within the grace period means the
+// sequencer dropped it or changed its hashes before sequencing it. A sequence landing with a
+// signature of the node that is not in its audit log means the key signed outside of the node.
+type Reconciler struct {
+	cfg      config.ReconcileConfig
+	db       db.DB
+	etherman etherman.Etherman
+	self     common.Address
+	pending  map[common.Hash]pendingSequence
+	stop     chan struct{}
+}
+
+// New returns a Reconciler of the sequences signed with the key of the given address
+func New(cfg config.ReconcileConfig, db db.DB, em etherman.Etherman, self common.Address) *Reconciler {
+	return &Reconciler{
+		cfg:      cfg,
+		db:       db,
+		etherman: em,
+		self:     self,
+		pending:  make(map[common.Hash]pendingSequence),
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start reconciles the signed sequences every interval until the reconciler is stopped
+func (r *Reconciler) Start(ctx context.Context) {
+	defer reporter.Recover()
+
+	logger.Infof("starting to reconcile the sequences signed by %s", r.self.Hex())
+	ticker := time.NewTicker(r.cfg.Interval.Duration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := r.Reconcile(ctx); err != nil {
+				logger.Errorf("failed to reconcile the signed sequences: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		case <-r.stop:
+			return
+		}
+	}
+}
+
+// Stop stops the reconciler
+func (r *Reconciler) Stop() {
+	close(r.stop)
+}
+
+// Reconcile matches the sequences signed since the previous pass against the sequences of the blocks
+// processed by the synchronizer since then, and reports the signed sequences past their grace period
+func (r *Reconciler) Reconcile(ctx context.Context) error {
+	// the head is read first, the sequences signed from now on landing after it
+	head, err := r.db.GetLastProcessedBlock(ctx, string(synchronizer.L1SyncTask))
+	if err != nil {
+		return fmt.Errorf("failed to get the last block processed by the synchronizer: %w", err)
+	}
+
+	fromBlock, fromID, err := r.cursors(ctx, head)
+	if err != nil {
+		return err
+	}
+
+	nextID, err := r.loadSigned(ctx, fromID, fromBlock)
+	if err != nil {
+		return err
+	}
+
+	if head >= fromBlock {
+		if err = r.scan(ctx, fromBlock, head); err != nil {
+			return err
+		}
+		fromBlock = head + 1
+	}
+
+	r.expire(time.Now())
+
+	// the pending sequences are loaded again from the audit log and their blocks scanned again on restart
+	for _, sequence := range r.pending {
+		if sequence.entry.ID < nextID {
+			nextID = sequence.entry.ID
+		}
+		if sequence.block < fromBlock {
+			fromBlock = sequence.block
+		}
+	}
+
+	if err = r.db.StoreLastProcessedBlock(ctx, nextID, auditTask); err != nil {
+		return fmt.Errorf("failed to store the audit log cursor: %w", err)
+	}
+	if err = r.db.StoreLastProcessedBlock(ctx, fromBlock, blockTask); err != nil {
+		return fmt.Errorf("failed to store the block cursor: %w", err)
+	}
+
+	return nil
+}
+
+// cursors returns the next block and audit log entry to reconcile. On the first pass, the reconciliation
+// starts from the current block and the end of the audit log, the past sequences not being reconciled.
+func (r *Reconciler) cursors(ctx context.Context, head uint64) (uint64, uint64, error) {
+	fromBlock, err := r.db.GetLastProcessedBlock(ctx, blockTask)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, 0, fmt.Errorf("failed to get the block cursor: %w", err)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		fromBlock = head + 1
+	}
+
+	fromID, err := r.db.GetLastProcessedBlock(ctx, auditTask)
+	if err == nil {
+		return fromBlock, fromID, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return 0, 0, fmt.Errorf("failed to get the audit log cursor: %w", err)
+	}
+
+	for fromID = 1; ; {
+		entries, err := r.db.ListSignAuditEntries(ctx, fromID, pageSize)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to list the audit log: %w", err)
+		}
+		if len(entries) == 0 {
+			return fromBlock, fromID, nil
+		}
+		fromID = entries[len(entries)-1].ID + 1
+	}
+}
+
+// loadSigned adds the sequences signed from the given audit log entry as pending from the given block,
+// and returns the ID following the last entry read
+func (r *Reconciler) loadSigned(ctx context.Context, fromID, fromBlock uint64) (uint64, error) {
+	for {
+		entries, err := r.db.ListSignAuditEntries(ctx, fromID, pageSize)
+		if err != nil {
+			return 0, fmt.Errorf("failed to list the audit log: %w", err)
+		}
+		if len(entries) == 0 {
+			return fromID, nil
+		}
+
+		for _, entry := range entries {
+			if entry.Decision != types.SignDecisionSigned {
+				continue
+			}
+			if _, ok := r.pending[entry.SequenceHash]; !ok {
+				r.pending[entry.SequenceHash] = pendingSequence{entry: entry, block: fromBlock}
+			}
+		}
+		fromID = entries[len(entries)-1].ID + 1
+	}
+}
+
+// scan matches the sequences landed in the given blocks against the pending ones
+func (r *Reconciler) scan(ctx context.Context, fromBlock, toBlock uint64) error {
+	committee, err := r.etherman.GetCurrentDataCommittee()
+	if err != nil {
+		return fmt.Errorf("failed to get the data committee: %w", err)
+	}
+
+	for start := fromBlock; start <= toBlock; start += r.cfg.BlockRange {
+		end := start + r.cfg.BlockRange - 1
+		if end > toBlock {
+			end = toBlock
+		}
+		logger.Debugf("reconciling the sequences of the blocks %d to %d", start, end)
+
+		iter, err := r.etherman.FilterSequenceBatches(&bind.FilterOpts{Context: ctx, Start: start, End: &end}, nil)
+		if err != nil {
+			return fmt.Errorf("failed to filter the sequences of the blocks %d to %d: %w", start, end, err)
+		}
+
+		for iter.Next() {
+			if err = r.match(ctx, iter.Event.Raw.TxHash, iter.Event.Raw.BlockNumber, committee); err != nil {
+				_ = iter.Close()
+				return err
+			}
+		}
+		if err = iter.Error(); err != nil {
+			_ = iter.Close()
+			return fmt.Errorf("failed to iterate the sequences of the blocks %d to %d: %w", start, end, err)
+		}
+		_ = iter.Close()
+	}
+
+	return nil
+}
+
+// match reconciles the sequence landed with the given transaction
+func (r *Reconciler) match(
+	ctx context.Context,
+	txHash common.Hash,
+	block uint64,
+	committee *etherman.DataCommittee,
+) error {
+	tx, _, err := r.etherman.GetTx(ctx, txHash)
+	if err != nil {
+		return fmt.Errorf("failed to get the sequence %s: %w", txHash.Hex(), err)
+	}
+	if len(tx.BlobHashes()) > 0 {
+		// the data of the blob sequences is not signed by the committee
+		return nil
+	}
+
+	keys, err := synchronizer.UnpackTxData(tx.Data())
+	if errors.Is(err, synchronizer.ErrMalformedSequence) || errors.Is(err, synchronizer.ErrUnknownMethod) {
+		skipMalformed(txHash, block, err)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to unpack the sequence %s: %w", txHash.Hex(), err)
+	}
+	hash := types.HashToSignFromKeys(keys)
+
+	if _, ok := r.pending[hash]; ok {
+		delete(r.pending, hash)
+		metrics.SequenceReconciled(metrics.ReconcileLanded)
+		return nil
+	}
+
+	message, err := synchronizer.UnpackDataAvailabilityMessage(tx.Data())
+	if errors.Is(err, synchronizer.ErrMalformedSequence) || errors.Is(err, synchronizer.ErrUnknownMethod) {
+		skipMalformed(txHash, block, err)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to unpack the signatures of the sequence %s: %w", txHash.Hex(), err)
+	}
+	if !r.signedBySelf(hash, message, committee.RequiredSignatures) {
+		return nil
+	}
+
+	// the sequence may have been reconciled before a restart, or expired
+	signed, err := r.db.HasSignedSequence(ctx, hash)
+	if err != nil {
+		return fmt.Errorf("failed to look up the sequence %s in the audit log: %w", hash.Hex(), err)
+	}
+	if signed {
+		return nil
+	}
+
+	logger.WithFields(
+		log.FieldTxHash, txHash.Hex(),
+		log.FieldBlockNumber, block,
+	).Errorf("sequence %s landed with a signature of the node not in its audit log", hash.Hex())
+	metrics.SequenceReconciled(metrics.ReconcileUnrecorded)
+	notifier.UnrecordedSignature(hash.Hex(), txHash.Hex(), block)
+
+	return nil
+}
+
+// skipMalformed skips a sequence that can not be decoded: matching it again would fail the same way and stall the
+// reconciliation at its block, while the synchronizer stalls on the sequences of an unknown method to store their data
+func skipMalformed(txHash common.Hash, block uint64, err error) {
+	logger.WithFields(
+		log.FieldTxHash, txHash.Hex(),
+		log.FieldBlockNumber, block,
+	).Warnf("skipping the sequence: %v", err)
+	metrics.SequenceReconciled(metrics.ReconcileMalformed)
+}
+
+// signedBySelf returns whether one of the signatures of the dataAvailabilityMessage is of the node
+func (r *Reconciler) signedBySelf(hash common.Hash, message []byte, required uint64) bool {
+	for i := uint64(0); i < required && (i+1)*signatureLen <= uint64(len(message)); i++ {
+		sig := make([]byte, signatureLen)
+		copy(sig, message[i*signatureLen:(i+1)*signatureLen])
+		sig[64] -= 27
+
+		pubKey, err := crypto.SigToPub(hash.Bytes(), sig)
+		if err != nil {
+			continue
+		}
+		if crypto.PubkeyToAddress(*pubKey) == r.self {
+			return true
+		}
+	}
+
+	return false
+}
+
+// expire reports and drops the pending sequences signed before the grace period
+func (r *Reconciler) expire(now time.Time) {
+	for hash, sequence := range r.pending {
+		if sequence.entry.Timestamp.Add(r.cfg.Grace.Duration).After(now) {
+			continue
+		}
+
+		logger.Warnf("sequence %s signed at %s did not land on L1", hash.Hex(), sequence.entry.Timestamp)
+		metrics.SequenceReconciled(metrics.ReconcileNotLanded)
+		notifier.SequenceNotLanded(hash.Hex(), sequence.entry.BatchCount, sequence.entry.Timestamp)
+		delete(r.pending, hash)
+	}
+}
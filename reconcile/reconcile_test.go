@@ -0,0 +1,96 @@
+package reconcile
+
+import (
+	"context"
+	"database/sql"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/config"
+	cfgTypes "github.com/0xPolygon/cdk-data-availability/config/types"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/etherman/ethermantest"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/synchronizer"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconciler_Reconcile(t *testing.T) {
+	self, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	other, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	landedHash, landedLog, landedTx := ethermantest.SignedSequence(t, 1, self, []byte("landed"))
+	unrecordedHash, unrecordedLog, unrecordedTx := ethermantest.SignedSequence(t, 1, self, []byte("unrecorded"))
+	_, otherLog, otherTx := ethermantest.SignedSequence(t, 1, other, []byte("other"))
+	// a sequence sent through another entrypoint is skipped
+	malformedTx := ethTypes.NewTx(&ethTypes.LegacyTx{GasPrice: big.NewInt(1), Data: []byte{1, 2, 3, 4, 5}})
+	malformedLog := otherLog
+	malformedLog.TxHash = malformedTx.Hash()
+
+	iter := ethermantest.Iterator(t, landedLog, malformedLog, unrecordedLog, otherLog)
+
+	em := mocks.NewEtherman(t)
+	em.On("GetCurrentDataCommittee").Return(&etherman.DataCommittee{RequiredSignatures: 1}, nil)
+	em.On("FilterSequenceBatches", ethermantest.BlockRange(100, 150), mock.Anything).Return(iter, nil).Once()
+	em.On("GetTx", mock.Anything, landedTx.Hash()).Return(landedTx, false, nil)
+	em.On("GetTx", mock.Anything, unrecordedTx.Hash()).Return(unrecordedTx, false, nil)
+	em.On("GetTx", mock.Anything, otherTx.Hash()).Return(otherTx, false, nil)
+	em.On("GetTx", mock.Anything, malformedTx.Hash()).Return(malformedTx, false, nil)
+
+	now := time.Now()
+	dbMock := mocks.NewDB(t)
+	dbMock.On("GetLastProcessedBlock", mock.Anything, string(synchronizer.L1SyncTask)).Return(uint64(150), nil)
+	dbMock.On("GetLastProcessedBlock", mock.Anything, blockTask).Return(uint64(100), nil)
+	dbMock.On("GetLastProcessedBlock", mock.Anything, auditTask).Return(uint64(5), nil)
+	dbMock.On("ListSignAuditEntries", mock.Anything, uint64(5), uint(pageSize)).Return([]types.SignAuditEntry{
+		{ID: 5, SequenceHash: landedHash, Decision: types.SignDecisionSigned, Timestamp: now},
+		{ID: 6, SequenceHash: common.HexToHash("0x6"), Decision: types.SignDecisionSigned, Timestamp: now.Add(-2 * time.Hour)},
+		{ID: 7, SequenceHash: unrecordedHash, Decision: types.SignDecisionUnauthorized, Timestamp: now},
+		{ID: 8, SequenceHash: common.HexToHash("0x8"), Decision: types.SignDecisionSigned, Timestamp: now},
+	}, nil)
+	dbMock.On("ListSignAuditEntries", mock.Anything, uint64(9), uint(pageSize)).Return([]types.SignAuditEntry{}, nil)
+	dbMock.On("HasSignedSequence", mock.Anything, unrecordedHash).Return(false, nil).Once()
+	// the sequence still pending is loaded again, and the blocks since it was signed scanned again
+	dbMock.On("StoreLastProcessedBlock", mock.Anything, uint64(8), auditTask).Return(nil).Once()
+	dbMock.On("StoreLastProcessedBlock", mock.Anything, uint64(100), blockTask).Return(nil).Once()
+
+	cfg := config.ReconcileConfig{
+		Grace:      cfgTypes.Duration{Duration: time.Hour},
+		BlockRange: 100,
+	}
+	r := New(cfg, dbMock, em, crypto.PubkeyToAddress(self.PublicKey))
+	require.NoError(t, r.Reconcile(context.Background()))
+
+	require.Len(t, r.pending, 1)
+	require.Contains(t, r.pending, common.HexToHash("0x8"))
+}
+
+func TestReconciler_FirstPass(t *testing.T) {
+	dbMock := mocks.NewDB(t)
+	dbMock.On("GetLastProcessedBlock", mock.Anything, string(synchronizer.L1SyncTask)).Return(uint64(150), nil)
+	dbMock.On("GetLastProcessedBlock", mock.Anything, blockTask).Return(uint64(0), sql.ErrNoRows)
+	dbMock.On("GetLastProcessedBlock", mock.Anything, auditTask).Return(uint64(0), sql.ErrNoRows)
+	dbMock.On("ListSignAuditEntries", mock.Anything, uint64(1), uint(pageSize)).Return([]types.SignAuditEntry{
+		{ID: 1, SequenceHash: common.HexToHash("0x1"), Decision: types.SignDecisionSigned, Timestamp: time.Now()},
+	}, nil).Once()
+	dbMock.On("ListSignAuditEntries", mock.Anything, uint64(2), uint(pageSize)).Return([]types.SignAuditEntry{}, nil)
+	// the past sequences are not reconciled
+	dbMock.On("StoreLastProcessedBlock", mock.Anything, uint64(2), auditTask).Return(nil).Once()
+	dbMock.On("StoreLastProcessedBlock", mock.Anything, uint64(151), blockTask).Return(nil).Once()
+
+	cfg := config.ReconcileConfig{
+		Grace:      cfgTypes.Duration{Duration: time.Hour},
+		BlockRange: 100,
+	}
+	r := New(cfg, dbMock, mocks.NewEtherman(t), common.HexToAddress("0x1"))
+	require.NoError(t, r.Reconcile(context.Background()))
+	require.Empty(t, r.pending)
+}
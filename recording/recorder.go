@@ -0,0 +1,140 @@
+package recording
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/etherman/smartcontracts/etrog/polygonvalidium"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/synchronizer"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// logger is the logger of the recording component
+var logger = log.WithComponent("recording")
+
+// Entry is an entry of a recording: a SequenceBatches log read from L1, a transaction of a sequence read from
+// L1 in its binary encoding, or a reorganization of L1. The entries are recorded in the order the synchronizer
+// consumed them, each log and transaction once.
+type Entry struct {
+	Log   *ethTypes.Log            `json:"log,omitempty"`
+	Tx    hexutil.Bytes            `json:"tx,omitempty"`
+	Reorg *synchronizer.BlockReorg `json:"reorg,omitempty"`
+}
+
+// logID identifies a log, the logs of a block reorganized away being recorded again under their new block
+type logID struct {
+	block common.Hash
+	index uint
+}
+
+// Recorder is the etherman of the synchronizer recording what it consumes from L1, the SequenceBatches logs
+// it filters and the transactions of the sequences it reads, to the writer as JSON lines of entries. The other
+// calls are passed through unrecorded. Failing to record is logged, the synchronizer going on.
+type Recorder struct {
+	etherman.Etherman
+
+	lock    sync.Mutex
+	encoder *json.Encoder
+	logs    map[logID]struct{}
+	txs     map[common.Hash]struct{}
+}
+
+// NewRecorder returns the recorder of what is consumed from the etherman to the writer
+func NewRecorder(em etherman.Etherman, w io.Writer) *Recorder {
+	return &Recorder{
+		Etherman: em,
+		encoder:  json.NewEncoder(w),
+		logs:     make(map[logID]struct{}),
+		txs:      make(map[common.Hash]struct{}),
+	}
+}
+
+// FilterSequenceBatches returns the SequenceBatches events of the etherman once their logs are recorded
+func (r *Recorder) FilterSequenceBatches(
+	opts *bind.FilterOpts,
+	numBatch []uint64,
+) (*polygonvalidium.PolygonvalidiumSequenceBatchesIterator, error) {
+	iter, err := r.Etherman.FilterSequenceBatches(opts, numBatch)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	var logs []ethTypes.Log
+	for iter.Next() {
+		logs = append(logs, iter.Event.Raw)
+	}
+	if err = iter.Error(); err != nil {
+		return nil, err
+	}
+
+	r.lock.Lock()
+	for i := range logs {
+		id := logID{block: logs[i].BlockHash, index: logs[i].Index}
+		if _, ok := r.logs[id]; !ok {
+			r.logs[id] = struct{}{}
+			r.record(Entry{Log: &logs[i]})
+		}
+	}
+	r.lock.Unlock()
+
+	// the events are read again from the logs recorded, the iterator of the etherman being consumed
+	return etherman.FilterSequenceBatchesFromLogs(logs, opts, numBatch)
+}
+
+// GetTx returns the transaction of the etherman once recorded
+func (r *Recorder) GetTx(ctx context.Context, txHash common.Hash) (*ethTypes.Transaction, bool, error) {
+	tx, pending, err := r.Etherman.GetTx(ctx, txHash)
+	if err != nil || pending {
+		return tx, pending, err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, ok := r.txs[txHash]; !ok {
+		raw, err := tx.MarshalBinary()
+		if err != nil {
+			logger.Errorf("failed to encode the transaction %s: %v", txHash.Hex(), err)
+			return tx, pending, nil
+		}
+		r.txs[txHash] = struct{}{}
+		r.record(Entry{Tx: raw})
+	}
+
+	return tx, pending, nil
+}
+
+// Reorgs returns the reorganizations received from the channel once recorded, the channel returned being closed
+// when it is
+func (r *Recorder) Reorgs(reorgs <-chan synchronizer.BlockReorg) <-chan synchronizer.BlockReorg {
+	recorded := make(chan synchronizer.BlockReorg)
+	go func() {
+		defer close(recorded)
+
+		for reorg := range reorgs {
+			reorg := reorg
+			r.lock.Lock()
+			r.record(Entry{Reorg: &reorg})
+			r.lock.Unlock()
+
+			recorded <- reorg
+		}
+	}()
+
+	return recorded
+}
+
+// record writes the entry. The lock must be held.
+func (r *Recorder) record(entry Entry) {
+	if err := r.encoder.Encode(entry); err != nil {
+		logger.Errorf("failed to record the L1 events: %v", err)
+	}
+}
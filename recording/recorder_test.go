@@ -0,0 +1,82 @@
+package recording
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/devnet"
+	"github.com/0xPolygon/cdk-data-availability/synchronizer"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+)
+
+// readEntries returns the entries recorded to the buffer
+func readEntries(t *testing.T, buf *bytes.Buffer) []Entry {
+	t.Helper()
+
+	var entries []Entry
+	decoder := json.NewDecoder(bytes.NewReader(buf.Bytes()))
+	for decoder.More() {
+		var entry Entry
+		require.NoError(t, decoder.Decode(&entry))
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestRecorder(t *testing.T) {
+	ctx := context.Background()
+	c, err := config.Default()
+	require.NoError(t, err)
+
+	chain := devnet.NewChain(c.L1, common.Address{})
+	tx, err := chain.SequenceBatches([]common.Hash{common.HexToHash("0x1"), common.HexToHash("0x2")}, nil)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	recorder := NewRecorder(chain, &buf)
+
+	// the events are read as from the etherman, each log being recorded once
+	for i := 0; i < 2; i++ {
+		iter, err := recorder.FilterSequenceBatches(&bind.FilterOpts{Context: ctx}, nil)
+		require.NoError(t, err)
+		require.True(t, iter.Next())
+		require.Equal(t, uint64(2), iter.Event.NumBatch)
+		require.Equal(t, tx.Hash(), iter.Event.Raw.TxHash)
+		require.False(t, iter.Next())
+		require.NoError(t, iter.Error())
+	}
+	for i := 0; i < 2; i++ {
+		found, _, err := recorder.GetTx(ctx, tx.Hash())
+		require.NoError(t, err)
+		require.Equal(t, tx.Hash(), found.Hash())
+	}
+
+	reorgs := make(chan synchronizer.BlockReorg)
+	recorded := recorder.Reorgs(reorgs)
+	reorg := synchronizer.BlockReorg{Number: 1, Hash: ethgo.Hash{1}}
+	reorgs <- reorg
+	require.Equal(t, reorg, <-recorded)
+	close(reorgs)
+	_, ok := <-recorded
+	require.False(t, ok)
+
+	entries := readEntries(t, &buf)
+	require.Len(t, entries, 3)
+
+	require.NotNil(t, entries[0].Log)
+	require.Equal(t, tx.Hash(), entries[0].Log.TxHash)
+	require.Equal(t, uint64(1), entries[0].Log.BlockNumber)
+
+	decoded := new(ethTypes.Transaction)
+	require.NoError(t, decoded.UnmarshalBinary(entries[1].Tx))
+	require.Equal(t, tx.Hash(), decoded.Hash())
+
+	require.Equal(t, &reorg, entries[2].Reorg)
+}
@@ -0,0 +1,278 @@
+package recording
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/devnet"
+	"github.com/0xPolygon/cdk-data-availability/synchronizer"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/umbracle/ethgo"
+)
+
+// Stats are the counts of the entries of a recording
+type Stats struct {
+	Logs         int `json:"logs"`
+	Transactions int `json:"transactions"`
+	Reorgs       int `json:"reorgs"`
+	// Skipped is the number of logs whose transaction was never read, which can not be replayed
+	Skipped int `json:"skipped"`
+}
+
+// Replayer replays a recording on a simulated L1, for a fresh node to synchronize the events recorded. The
+// blocks are numbered from 1 for the first block recorded, the blocks before it not being simulated, and the
+// hashes of the blocks differ from the recorded ones. The SequenceBatches events are mined as recorded, with
+// their transactions, and the reorganizations rewind the simulated L1 and are sent as the ReorgDetector does.
+// A log of a block before the latest one rewinds it as well, L1 having reorganized without it being recorded.
+type Replayer struct {
+	chain *devnet.Chain
+	// entries are the logs and the reorganizations to replay, in order
+	entries []Entry
+	txs     map[common.Hash]*ethTypes.Transaction
+	// offset is subtracted from the recorded block numbers
+	offset uint64
+	reorgs chan synchronizer.BlockReorg
+	stats  Stats
+	next   int
+	// blocks are the recorded hashes of the blocks the logs replayed so far were included in, by number
+	blocks map[uint64]common.Hash
+}
+
+// NewReplayer reads the recording for it to be replayed on a simulated L1 with the contracts of the L1 config
+func NewReplayer(r io.Reader, l1 config.L1Config) (*Replayer, error) {
+	replayer := &Replayer{
+		chain:  devnet.NewChain(l1, common.Address{}),
+		txs:    make(map[common.Hash]*ethTypes.Transaction),
+		reorgs: make(chan synchronizer.BlockReorg),
+		blocks: make(map[uint64]common.Hash),
+	}
+
+	var (
+		decoder = json.NewDecoder(r)
+		first   uint64
+		entries []Entry
+	)
+	for i := 1; ; i++ {
+		var entry Entry
+		if err := decoder.Decode(&entry); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("invalid entry %d: %w", i, err)
+		}
+
+		var number uint64
+		switch {
+		case entry.Log != nil:
+			number = entry.Log.BlockNumber
+			replayer.stats.Logs++
+		case entry.Reorg != nil:
+			number = entry.Reorg.Number
+			replayer.stats.Reorgs++
+		case len(entry.Tx) > 0:
+			tx := new(ethTypes.Transaction)
+			if err := tx.UnmarshalBinary(entry.Tx); err != nil {
+				return nil, fmt.Errorf("invalid transaction of the entry %d: %w", i, err)
+			}
+			replayer.txs[tx.Hash()] = tx
+			replayer.stats.Transactions++
+			continue
+		default:
+			return nil, fmt.Errorf("the entry %d is empty", i)
+		}
+
+		if first == 0 || number < first {
+			first = number
+		}
+		entries = append(entries, entry)
+	}
+
+	// the transactions are read after their logs, the entries are only checked once all are read
+	for _, entry := range entries {
+		if entry.Log != nil {
+			if _, ok := replayer.txs[entry.Log.TxHash]; !ok {
+				logger.Warnf("skipping the log of the block %d, its transaction %s was never read",
+					entry.Log.BlockNumber, entry.Log.TxHash.Hex())
+				replayer.stats.Skipped++
+				continue
+			}
+		}
+		replayer.entries = append(replayer.entries, entry)
+	}
+	if first > 0 {
+		replayer.offset = first - 1
+	}
+
+	return replayer, nil
+}
+
+// Chain returns the simulated L1 the recording is replayed on
+func (r *Replayer) Chain() *devnet.Chain {
+	return r.chain
+}
+
+// Reorgs returns the channel the reorganizations are sent on, as the ReorgDetector does
+func (r *Replayer) Reorgs() <-chan synchronizer.BlockReorg {
+	return r.reorgs
+}
+
+// Stats returns the counts of the entries of the recording
+func (r *Replayer) Stats() Stats {
+	return r.stats
+}
+
+// Step replays the next entry, waiting for a reorganization to be received. It returns false once all the
+// entries are replayed.
+func (r *Replayer) Step(ctx context.Context) (bool, error) {
+	if r.next >= len(r.entries) {
+		return false, nil
+	}
+	entry := r.entries[r.next]
+	r.next++
+
+	if entry.Reorg != nil {
+		return true, r.reorg(ctx, entry.Reorg.Number-r.offset)
+	}
+
+	number := entry.Log.BlockNumber - r.offset
+	head, err := r.head(ctx)
+	if err != nil {
+		return true, err
+	}
+	if hash, ok := r.blocks[number]; number < head || (ok && hash != entry.Log.BlockHash) {
+		if err = r.rewind(head - number + 1); err != nil {
+			return true, err
+		}
+	}
+
+	if _, err = r.chain.Include(number, r.txs[entry.Log.TxHash], *entry.Log); err != nil {
+		return true, err
+	}
+	r.blocks[number] = entry.Log.BlockHash
+
+	return true, nil
+}
+
+// reorg rewinds the chain to the block of the number, or mines up to it, then sends the reorganization
+func (r *Replayer) reorg(ctx context.Context, number uint64) error {
+	head, err := r.head(ctx)
+	if err != nil {
+		return err
+	}
+	if number < head {
+		if err = r.rewind(head - number); err != nil {
+			return err
+		}
+	}
+	for ; head < number; head++ {
+		r.chain.Mine()
+	}
+
+	header, err := r.chain.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case r.reorgs <- synchronizer.BlockReorg{Number: number, Hash: ethgo.Hash(header.Hash())}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// rewind drops the depth latest blocks of the chain
+func (r *Replayer) rewind(depth uint64) error {
+	header, err := r.chain.Reorg(depth)
+	if err != nil {
+		return err
+	}
+
+	for number := range r.blocks {
+		if number > header.Number.Uint64() {
+			delete(r.blocks, number)
+		}
+	}
+	return nil
+}
+
+// Head returns the number of the latest block of the chain, as recorded
+func (r *Replayer) Head(ctx context.Context) (uint64, error) {
+	head, err := r.head(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return head + r.offset, nil
+}
+
+// head returns the number of the latest block of the chain
+func (r *Replayer) head(ctx context.Context) (uint64, error) {
+	header, err := r.chain.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return header.Number.Uint64(), nil
+}
+
+// Run replays the entries in order, waiting after each for the synchronizer storing to the database to have
+// processed the chain up to its latest block, checked every interval, so that the synchronizer consumes the
+// events one at a time whatever its timing. It fails if an entry is not processed within the timeout.
+func (r *Replayer) Run(ctx context.Context, storage db.DB, interval, timeout time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		more, err := r.Step(ctx)
+		if err != nil || !more {
+			return err
+		}
+
+		head, err := r.head(ctx)
+		if err != nil {
+			return err
+		}
+		if err = r.wait(ctx, storage, ticker, head, timeout); err != nil {
+			return err
+		}
+	}
+}
+
+// wait waits for the synchronizer to have processed the chain up to the block of the number
+func (r *Replayer) wait(
+	parentCtx context.Context,
+	storage db.DB,
+	ticker *time.Ticker,
+	number uint64,
+	timeout time.Duration,
+) error {
+	ctx, cancel := context.WithTimeout(parentCtx, timeout)
+	defer cancel()
+
+	for {
+		processed, err := storage.GetLastProcessedBlock(ctx, string(synchronizer.L1SyncTask))
+		if err != nil {
+			return err
+		}
+		if processed == number {
+			return nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			if parentCtx.Err() != nil {
+				return parentCtx.Err()
+			}
+			return fmt.Errorf("the synchronizer did not process the block %d within %v, it is at the block %d",
+				number+r.offset, timeout, processed+r.offset)
+		}
+	}
+}
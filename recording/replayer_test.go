@@ -0,0 +1,231 @@
+package recording
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/client"
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/devnet"
+	"github.com/0xPolygon/cdk-data-availability/simulator"
+	"github.com/0xPolygon/cdk-data-availability/synchronizer"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+// sequence returns the log and the encoded transaction of a sequence of the keys
+func sequence(t *testing.T, keys ...common.Hash) (ethTypes.Log, []byte) {
+	t.Helper()
+
+	c, err := config.Default()
+	require.NoError(t, err)
+	chain := devnet.NewChain(c.L1, common.Address{})
+	tx, err := chain.SequenceBatches(keys, nil)
+	require.NoError(t, err)
+	receipt, err := chain.TransactionReceipt(context.Background(), tx.Hash())
+	require.NoError(t, err)
+	raw, err := tx.MarshalBinary()
+	require.NoError(t, err)
+
+	return *receipt.Logs[0], raw
+}
+
+// at returns the log as emitted in the block of the number and hash
+func at(log ethTypes.Log, number uint64, hash common.Hash) *ethTypes.Log {
+	log.BlockNumber = number
+	log.BlockHash = hash
+	return &log
+}
+
+// encode returns the recording of the entries
+func encode(t *testing.T, entries ...Entry) *bytes.Buffer {
+	t.Helper()
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, entry := range entries {
+		require.NoError(t, encoder.Encode(entry))
+	}
+	return &buf
+}
+
+// sequenced returns the numbers of the last batches of the SequenceBatches events of the chain, with their blocks
+func sequenced(t *testing.T, chain *devnet.Chain) [][2]uint64 {
+	t.Helper()
+
+	iter, err := chain.FilterSequenceBatches(&bind.FilterOpts{Context: context.Background()}, nil)
+	require.NoError(t, err)
+	var events [][2]uint64
+	for iter.Next() {
+		events = append(events, [2]uint64{iter.Event.Raw.BlockNumber, iter.Event.NumBatch})
+	}
+	return events
+}
+
+func TestNewReplayer(t *testing.T) {
+	t.Parallel()
+
+	c, err := config.Default()
+	require.NoError(t, err)
+
+	_, err = NewReplayer(strings.NewReader("{}\n"), c.L1)
+	require.EqualError(t, err, "the entry 1 is empty")
+	_, err = NewReplayer(strings.NewReader(`{"tx":"0x01"}`), c.L1)
+	require.ErrorContains(t, err, "invalid transaction of the entry 1")
+	_, err = NewReplayer(strings.NewReader(`{"reorg":{"Number":1}}}`), c.L1)
+	require.ErrorContains(t, err, "invalid entry 2")
+}
+
+func TestReplayer_Step(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c, err := config.Default()
+	require.NoError(t, err)
+
+	first, firstTx := sequence(t, common.HexToHash("0x1"))
+	second, secondTx := sequence(t, common.HexToHash("0x2"), common.HexToHash("0x3"))
+	third, _ := sequence(t, common.HexToHash("0x4"))
+	fork, forkTx := sequence(t, common.HexToHash("0x5"))
+	replayed := func(log ethTypes.Log, number uint64) *ethTypes.Log {
+		log.Topics = append([]common.Hash(nil), log.Topics...)
+		log.Topics[1] = common.BigToHash(new(big.Int).SetUint64(number))
+		return &log
+	}
+
+	replayer, err := NewReplayer(encode(t,
+		Entry{Log: at(first, 100, common.Hash{1})},
+		Entry{Log: at(*replayed(second, 3), 103, common.Hash{2})},
+		Entry{Tx: firstTx},
+		Entry{Tx: secondTx},
+		// the transaction of the log was never read
+		Entry{Log: at(third, 104, common.Hash{3})},
+		Entry{Reorg: &synchronizer.BlockReorg{Number: 102}},
+		// the block 103 of the fork, then a fork of the block 101 never notified
+		Entry{Log: at(*replayed(fork, 2), 103, common.Hash{4})},
+		Entry{Log: at(*replayed(fork, 2), 101, common.Hash{5})},
+		Entry{Tx: forkTx},
+	), c.L1)
+	require.NoError(t, err)
+	require.Equal(t, Stats{Logs: 5, Transactions: 3, Reorgs: 1, Skipped: 1}, replayer.Stats())
+
+	reorgs := make(chan synchronizer.BlockReorg, 1)
+	go func() {
+		for reorg := range replayer.Reorgs() {
+			reorgs <- reorg
+		}
+	}()
+
+	// the block 100 is the first block of the chain
+	more, err := replayer.Step(ctx)
+	require.NoError(t, err)
+	require.True(t, more)
+	_, err = replayer.Step(ctx)
+	require.NoError(t, err)
+	require.Equal(t, [][2]uint64{{1, 1}, {4, 3}}, sequenced(t, replayer.Chain()))
+	head, err := replayer.Head(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(103), head)
+
+	_, err = replayer.Step(ctx)
+	require.NoError(t, err)
+	reorg := <-reorgs
+	require.Equal(t, uint64(3), reorg.Number)
+	require.Equal(t, [][2]uint64{{1, 1}}, sequenced(t, replayer.Chain()))
+
+	_, err = replayer.Step(ctx)
+	require.NoError(t, err)
+	require.Equal(t, [][2]uint64{{1, 1}, {4, 2}}, sequenced(t, replayer.Chain()))
+
+	_, err = replayer.Step(ctx)
+	require.NoError(t, err)
+	require.Equal(t, [][2]uint64{{1, 1}, {2, 2}}, sequenced(t, replayer.Chain()))
+	head, err = replayer.Head(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(101), head)
+
+	more, err = replayer.Step(ctx)
+	require.NoError(t, err)
+	require.False(t, more)
+}
+
+// unresolved returns the batches stored as unresolved
+func unresolved(t *testing.T, storage db.DB) []types.BatchKey {
+	t.Helper()
+
+	keys, err := storage.GetUnresolvedBatchKeys(context.Background(), math.MaxUint32)
+	require.NoError(t, err)
+	return keys
+}
+
+// replay replays the recording against a fresh synchronizer, returning the batches it stored as unresolved
+func replay(t *testing.T, c *config.Config, recording []byte) []types.BatchKey {
+	t.Helper()
+
+	replayer, err := NewReplayer(bytes.NewReader(recording), c.L1)
+	require.NoError(t, err)
+
+	storage := db.NewMemory(0)
+	batchSynchronizer, err := synchronizer.NewBatchSynchronizer(c.L1, c.Timeouts, c.Limits, common.Address{},
+		storage, replayer.Reorgs(), replayer.Chain(), nil, nil, client.NewFactory())
+	require.NoError(t, err)
+	batchSynchronizer.StartEvents(context.Background())
+	defer batchSynchronizer.Stop()
+
+	require.NoError(t, replayer.Run(context.Background(), storage, time.Millisecond, 10*time.Second))
+	return unresolved(t, storage)
+}
+
+func TestReplayer_Run(t *testing.T) {
+	ctx := context.Background()
+	c, err := config.Default()
+	require.NoError(t, err)
+	c.L1.RetryPeriod.Duration = 5 * time.Millisecond
+
+	sim, err := simulator.New(simulator.Config{
+		MinBatches: 1, MaxBatches: 4, MinBatchSize: 8, MaxBatchSize: 64, ReorgEvery: 4, ReorgDepth: 2, Seed: 1,
+	}, c.L1)
+	require.NoError(t, err)
+
+	// the events the synchronizer consumes while the simulator sequences are recorded
+	var buf bytes.Buffer
+	recorder := NewRecorder(sim.Chain(), &buf)
+	storage := db.NewMemory(0)
+	batchSynchronizer, err := synchronizer.NewBatchSynchronizer(c.L1, c.Timeouts, c.Limits, common.Address{},
+		storage, recorder.Reorgs(sim.Reorgs()), recorder, sim, nil, client.NewFactory())
+	require.NoError(t, err)
+	batchSynchronizer.StartEvents(ctx)
+
+	for i := 0; i < 20; i++ {
+		require.NoError(t, sim.Step(ctx))
+		head, err := sim.Head(ctx)
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			processed, err := storage.GetLastProcessedBlock(ctx, string(synchronizer.L1SyncTask))
+			return err == nil && processed == head
+		}, 10*time.Second, time.Millisecond)
+	}
+	batchSynchronizer.Stop()
+	require.Equal(t, 4, sim.Stats().Reorgs)
+
+	recorded := unresolved(t, storage)
+	expected, err := sim.Expected(ctx)
+	require.NoError(t, err)
+	for _, data := range expected {
+		require.Contains(t, recorded, types.BatchKey{Number: data.BatchNum, Hash: data.Key})
+	}
+
+	// a fresh node stores the same batches as the node recorded, whatever the run
+	require.Equal(t, recorded, replay(t, c, buf.Bytes()))
+	require.Equal(t, recorded, replay(t, c, buf.Bytes()))
+}
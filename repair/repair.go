@@ -0,0 +1,202 @@
+package repair
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/audit"
+	"github.com/0xPolygon/cdk-data-availability/client"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/synchronizer"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// sourceSequencer is the source of the values recovered from the trusted sequencer, the other values being
+// recovered from the member of the address
+const sourceSequencer = "sequencer"
+
+// logger is the logger of the repair component
+var logger = log.WithComponent("repair")
+
+// Failure is an entry that could not be repaired
+type Failure struct {
+	audit.Entry
+	Error string `json:"error"`
+}
+
+// Result is the outcome of a repair
+type Result struct {
+	// Repaired are the entries whose value was recovered and stored, with the source it was recovered from
+	Repaired []Repaired `json:"repaired"`
+	// Failed are the entries no source had the value of
+	Failed []Failure `json:"failed"`
+}
+
+// Repaired is an entry whose value was recovered
+type Repaired struct {
+	audit.Entry
+	Source string `json:"source"`
+}
+
+// Repairer recovers the missing and corrupt values reported by an audit
+type Repairer struct {
+	db        db.DB
+	etherman  etherman.Etherman
+	sequencer synchronizer.SequencerTracker
+	factory   client.Factory
+	self      common.Address
+	timeout   time.Duration
+	pageSize  uint
+	// maxValueSize bounds the size of the values recovered, 0 for no limit
+	maxValueSize uint64
+}
+
+// New returns a Repairer recovering the values from the trusted sequencer, then from the members of the
+// committee other than self, each queried with the given timeout, and storing them pageSize at a time. The values
+// larger than maxValueSize bytes are refused.
+func New(
+	db db.DB,
+	em etherman.Etherman,
+	sequencer synchronizer.SequencerTracker,
+	factory client.Factory,
+	self common.Address,
+	timeout time.Duration,
+	pageSize uint,
+	maxValueSize uint64,
+) *Repairer {
+	return &Repairer{
+		db:        db,
+		etherman:  em,
+		sequencer: sequencer,
+		factory:   factory,
+		self:      self,
+		timeout:   timeout,
+		pageSize:  pageSize,
+
+		maxValueSize: maxValueSize,
+	}
+}
+
+// Repair recovers the corrupt and missing values of the report and stores them, the corrupt values being
+// overwritten. The entries that could not be recovered are returned in the result, an error is only
+// returned when the committee could not be read or the values could not be stored.
+func (r *Repairer) Repair(ctx context.Context, report *audit.Report) (*Result, error) {
+	members, err := r.etherman.GetCurrentDataCommitteeMembers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the committee: %w", err)
+	}
+
+	result := &Result{}
+	page := make([]types.OffChainData, 0, r.pageSize)
+	provenance := make([]types.Provenance, 0, r.pageSize)
+	flush := func() error {
+		if len(page) == 0 {
+			return nil
+		}
+		if err := r.db.StoreOffChainData(ctx, page); err != nil {
+			return fmt.Errorf("failed to store the recovered values: %w", err)
+		}
+		if err := r.db.StoreProvenance(ctx, provenance); err != nil {
+			return fmt.Errorf("failed to store the provenance of the recovered values: %w", err)
+		}
+		page = make([]types.OffChainData, 0, r.pageSize)
+		provenance = make([]types.Provenance, 0, r.pageSize)
+		return nil
+	}
+
+	seen := make(map[common.Hash]bool)
+	for _, entry := range append(append([]audit.Entry{}, report.Corrupt...), report.Missing...) {
+		if seen[entry.Key] {
+			continue
+		}
+		seen[entry.Key] = true
+
+		value, source, err := r.recover(ctx, entry, members)
+		if err != nil {
+			logger.WithFields(log.FieldBatchNumber, uint64(entry.BatchNum), log.FieldKeyHash, entry.Key.Hex()).
+				Warnf("failed to repair: %v", err)
+			result.Failed = append(result.Failed, Failure{Entry: entry, Error: err.Error()})
+			continue
+		}
+
+		data := types.OffChainData{Key: entry.Key, Value: value, BatchNum: uint64(entry.BatchNum)}
+		page = append(page, data)
+		if source == sourceSequencer {
+			provenance = append(provenance, types.NewProvenance([]types.OffChainData{data},
+				types.ProvenanceSequencer, "")...)
+		} else {
+			provenance = append(provenance, types.NewProvenance([]types.OffChainData{data},
+				types.ProvenanceMember, source)...)
+		}
+		result.Repaired = append(result.Repaired, Repaired{Entry: entry, Source: source})
+		if uint(len(page)) == r.pageSize {
+			if err = flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err = flush(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// recover returns the value of the entry and where it was recovered from, checking it against its key
+func (r *Repairer) recover(
+	ctx context.Context,
+	entry audit.Entry,
+	members []etherman.DataCommitteeMember,
+) ([]byte, string, error) {
+	if r.sequencer != nil && entry.BatchNum > 0 {
+		queryCtx, cancel := context.WithTimeout(ctx, r.timeout)
+		batch, err := r.sequencer.GetSequenceBatch(queryCtx, uint64(entry.BatchNum))
+		cancel()
+		switch {
+		case err != nil:
+			logger.WithFields(log.FieldKeyHash, entry.Key.Hex()).Debugf("not recovered from the sequencer: %v", err)
+		case types.CheckValueSize(batch.BatchL2Data, r.maxValueSize) != nil:
+			logger.WithFields(log.FieldKeyHash, entry.Key.Hex()).Debug("the sequencer gave too much data for the key")
+		case crypto.Keccak256Hash(batch.BatchL2Data) != entry.Key:
+			logger.WithFields(log.FieldKeyHash, entry.Key.Hex()).Debug("the sequencer gave wrong data for the key")
+		default:
+			return batch.BatchL2Data, sourceSequencer, nil
+		}
+	}
+
+	for _, member := range members {
+		if member.URL == "" || member.Addr == (common.Address{}) || member.Addr == r.self {
+			continue
+		}
+
+		queryCtx, cancel := context.WithTimeout(ctx, r.timeout)
+		value, err := r.factory.New(member.URL).GetOffChainData(queryCtx, entry.Key)
+		cancel()
+		if err != nil {
+			logger.WithFields(log.FieldKeyHash, entry.Key.Hex(), log.FieldMemberAddr, member.Addr.Hex()).
+				Debugf("not recovered from the member: %v", err)
+			continue
+		}
+		if err = types.CheckValueSize(value, r.maxValueSize); err != nil {
+			logger.WithFields(log.FieldKeyHash, entry.Key.Hex(), log.FieldMemberAddr, member.Addr.Hex()).
+				Debugf("the member gave a value too large for the key: %v", err)
+			continue
+		}
+		if crypto.Keccak256Hash(value) != entry.Key {
+			logger.WithFields(log.FieldKeyHash, entry.Key.Hex(), log.FieldMemberAddr, member.Addr.Hex()).
+				Debug("the member gave wrong data for the key")
+			continue
+		}
+
+		return value, member.Addr.Hex(), nil
+	}
+
+	return nil, "", errors.New("no source has the value of the key")
+}
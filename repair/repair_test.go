@@ -0,0 +1,90 @@
+package repair
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/audit"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/sequencer"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepairer_Repair(t *testing.T) {
+	t.Parallel()
+
+	var (
+		self   = common.HexToAddress("0x1")
+		member = common.HexToAddress("0x2")
+
+		fromSequencer = []byte("from the sequencer")
+		fromMember    = []byte("from the member")
+		lost          = []byte("lost")
+		large         = []byte("larger than the maximum size of the values")
+	)
+	entry := func(value []byte, batchNum uint64) audit.Entry {
+		return audit.Entry{Key: crypto.Keccak256Hash(value), BatchNum: types.ArgUint64(batchNum)}
+	}
+
+	report := &audit.Report{
+		Corrupt: []audit.Entry{entry(fromSequencer, 1)},
+		Missing: []audit.Entry{entry(fromMember, 2), entry(lost, 3), entry(large, 4)},
+	}
+
+	em := mocks.NewEtherman(t)
+	em.On("GetCurrentDataCommitteeMembers").Return([]etherman.DataCommitteeMember{
+		{Addr: self, URL: "http://self"},
+		{Addr: member, URL: "http://member"},
+	}, nil)
+
+	seq := mocks.NewSequencerTracker(t)
+	seq.On("GetSequenceBatch", mock.Anything, uint64(1)).
+		Return(&sequencer.SeqBatch{BatchL2Data: fromSequencer}, nil)
+	seq.On("GetSequenceBatch", mock.Anything, uint64(2)).
+		Return(nil, errors.New("not found"))
+	// the sequencer giving a value not matching the key is not trusted
+	seq.On("GetSequenceBatch", mock.Anything, uint64(3)).
+		Return(&sequencer.SeqBatch{BatchL2Data: []byte("other")}, nil)
+	// neither are the values too large, even matching the key
+	seq.On("GetSequenceBatch", mock.Anything, uint64(4)).
+		Return(&sequencer.SeqBatch{BatchL2Data: large}, nil)
+
+	memberClient := mocks.NewClient(t)
+	memberClient.On("GetOffChainData", mock.Anything, crypto.Keccak256Hash(fromMember)).Return(fromMember, nil)
+	memberClient.On("GetOffChainData", mock.Anything, crypto.Keccak256Hash(lost)).
+		Return(nil, errors.New("not found"))
+	memberClient.On("GetOffChainData", mock.Anything, crypto.Keccak256Hash(large)).Return(large, nil)
+
+	factory := mocks.NewClientFactory(t)
+	factory.On("New", "http://member").Return(memberClient)
+
+	dbMock := mocks.NewDB(t)
+	dbMock.On("StoreOffChainData", mock.Anything, []types.OffChainData{
+		{Key: crypto.Keccak256Hash(fromSequencer), Value: fromSequencer, BatchNum: 1},
+		{Key: crypto.Keccak256Hash(fromMember), Value: fromMember, BatchNum: 2},
+	}).Return(nil).Once()
+	dbMock.On("StoreProvenance", mock.Anything, mock.MatchedBy(func(provenance []types.Provenance) bool {
+		return len(provenance) == 2 &&
+			provenance[0].Key == crypto.Keccak256Hash(fromSequencer) &&
+			provenance[0].Source == types.ProvenanceSequencer &&
+			provenance[1].Key == crypto.Keccak256Hash(fromMember) &&
+			provenance[1].Source == types.ProvenanceMember && provenance[1].Origin == member.Hex()
+	})).Return(nil).Once()
+
+	result, err := New(dbMock, em, seq, factory, self, time.Second, 10, 32).Repair(context.Background(), report)
+	require.NoError(t, err)
+	require.Equal(t, []Repaired{
+		{Entry: entry(fromSequencer, 1), Source: "sequencer"},
+		{Entry: entry(fromMember, 2), Source: member.Hex()},
+	}, result.Repaired)
+	require.Len(t, result.Failed, 2)
+	require.Equal(t, entry(lost, 3), result.Failed[0].Entry)
+	require.Equal(t, entry(large, 4), result.Failed[1].Entry)
+}
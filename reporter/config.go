@@ -0,0 +1,13 @@
+package reporter
+
+// Config represents the configuration of the error reporting
+type Config struct {
+	// DSN of the Sentry project the errors are reported to, errors are not reported when empty
+	DSN string `mapstructure:"DSN" secret:"true"`
+
+	// Environment the errors are reported for, e.g. mainnet or testnet
+	Environment string `mapstructure:"Environment"`
+
+	// SampleRate is the ratio of the errors reported, from 0 to 1
+	SampleRate float64 `mapstructure:"SampleRate"`
+}
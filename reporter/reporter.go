@@ -0,0 +1,164 @@
+package reporter
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/metrics"
+	"go.uber.org/zap/zapcore"
+)
+
+// flushTimeout bounds the time to send the pending reports before the node stops
+const flushTimeout = 5 * time.Second
+
+// Reporter reports the errors and panics of the node to an error tracking service
+type Reporter interface {
+	// CaptureError reports an error along with its context, e.g. the component and the key hash
+	CaptureError(err error, fields map[string]interface{})
+	// CapturePanic reports a recovered panic along with the stack of the panicking goroutine
+	CapturePanic(recovered interface{}, stack []byte)
+	// Flush waits until the pending reports are sent or the timeout expires
+	Flush(timeout time.Duration) bool
+}
+
+// nopReporter discards every report
+type nopReporter struct{}
+
+func (nopReporter) CaptureError(error, map[string]interface{}) {}
+func (nopReporter) CapturePanic(interface{}, []byte)           {}
+func (nopReporter) Flush(time.Duration) bool                   { return true }
+
+// holder allows storing the different Reporter implementations in an atomic.Value
+type holder struct {
+	Reporter
+}
+
+// addCore adds the core reporting the logged errors once, whatever the number of reporters set
+var addCore sync.Once
+
+// current is the Reporter the errors are reported to, nothing is reported until one is set
+var current atomic.Value
+
+func init() {
+	current.Store(holder{nopReporter{}})
+}
+
+// Init reports the errors to Sentry when a DSN is configured. Besides the panics, every
+// entry logged at error level or above is reported along with its fields.
+func Init(cfg Config) error {
+	if cfg.DSN == "" {
+		return nil
+	}
+
+	r, err := newSentryReporter(cfg)
+	if err != nil {
+		return err
+	}
+
+	Register(r)
+	return nil
+}
+
+// Register sets the Reporter the errors and panics are reported to, and reports
+// the entries logged at error level or above through it
+func Register(r Reporter) {
+	current.Store(holder{r})
+	addCore.Do(func() {
+		log.AddCore(&core{LevelEnabler: zapcore.ErrorLevel})
+	})
+}
+
+// Current returns the Reporter the errors and panics are reported to
+func Current() Reporter {
+	return current.Load().(holder).Reporter //nolint:forcetypeassert
+}
+
+// Flush sends the pending reports, it must be called before the node stops
+func Flush() {
+	Current().Flush(flushTimeout)
+}
+
+// Recover reports the panic in progress and panics again, so the panic is not lost
+// if the process crashes. It must be deferred at the start of the goroutines.
+func Recover() {
+	if recovered := recover(); recovered != nil {
+		Current().CapturePanic(recovered, debug.Stack())
+		Flush()
+		panic(recovered)
+	}
+}
+
+// ErrPanic is the error a panic recovered by Isolate is turned into
+var ErrPanic = errors.New("panic")
+
+// Isolate reports the panic in progress and turns it into an error set to err, so the panic fails a single
+// request or event of the component instead of stopping the node. It must be deferred by the function
+// returning err.
+func Isolate(component string, err *error) {
+	if recovered := recover(); recovered != nil {
+		Current().CapturePanic(recovered, debug.Stack())
+		metrics.PanicRecovered(component)
+		*err = fmt.Errorf("%w: %v", ErrPanic, recovered)
+	}
+}
+
+// core reports the log entries it is enabled for, along with their fields
+type core struct {
+	zapcore.LevelEnabler
+	fields []zapcore.Field
+}
+
+// With implements zapcore.Core
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	return &core{
+		LevelEnabler: c.LevelEnabler,
+		fields:       append(append([]zapcore.Field{}, c.fields...), fields...),
+	}
+}
+
+// Check implements zapcore.Core
+func (c *core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+
+	return ce
+}
+
+// Write implements zapcore.Core
+func (c *core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, field := range c.fields {
+		field.AddTo(enc)
+	}
+	for _, field := range fields {
+		field.AddTo(enc)
+	}
+
+	enc.Fields["level"] = ent.Level.String()
+	if ent.Caller.Defined {
+		enc.Fields["caller"] = ent.Caller.TrimmedPath()
+	}
+
+	Current().CaptureError(errors.New(ent.Message), enc.Fields)
+	// the process exits right after panic and fatal entries, so send them now
+	if ent.Level > zapcore.ErrorLevel {
+		return c.Sync()
+	}
+
+	return nil
+}
+
+// Sync implements zapcore.Core
+func (c *core) Sync() error {
+	if !Current().Flush(flushTimeout) {
+		return fmt.Errorf("timed out sending the error reports")
+	}
+
+	return nil
+}
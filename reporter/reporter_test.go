@@ -0,0 +1,104 @@
+package reporter
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeReporter records the reports
+type fakeReporter struct {
+	mu      sync.Mutex
+	errors  []map[string]interface{}
+	panics  []interface{}
+	flushed int
+}
+
+func (r *fakeReporter) CaptureError(err error, fields map[string]interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fields["error"] = err.Error()
+	r.errors = append(r.errors, fields)
+}
+
+func (r *fakeReporter) CapturePanic(recovered interface{}, _ []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.panics = append(r.panics, recovered)
+}
+
+func (r *fakeReporter) Flush(time.Duration) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.flushed++
+	return true
+}
+
+func TestReporter_CapturesLoggedErrors(t *testing.T) {
+	r := &fakeReporter{}
+	Register(r)
+	t.Cleanup(func() { current.Store(holder{nopReporter{}}) })
+
+	logger := log.WithComponent("reporter-test")
+	logger.Info("not reported")
+	logger.WithFields(log.FieldBatchNumber, 7).Errorf("failed to resolve %s", "0x01")
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	require.Len(t, r.errors, 1)
+	require.Equal(t, "failed to resolve 0x01", r.errors[0]["error"])
+	require.Equal(t, "reporter-test", r.errors[0][log.FieldComponent])
+	require.EqualValues(t, 7, r.errors[0][log.FieldBatchNumber])
+	require.Equal(t, "error", r.errors[0]["level"])
+}
+
+func TestReporter_Recover(t *testing.T) {
+	r := &fakeReporter{}
+	Register(r)
+	t.Cleanup(func() { current.Store(holder{nopReporter{}}) })
+
+	require.PanicsWithValue(t, "boom", func() {
+		defer Recover()
+		panic("boom")
+	})
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	require.Equal(t, []interface{}{"boom"}, r.panics)
+	require.Equal(t, 1, r.flushed)
+}
+
+func TestReporter_Isolate(t *testing.T) {
+	r := &fakeReporter{}
+	Register(r)
+	t.Cleanup(func() { current.Store(holder{nopReporter{}}) })
+
+	isolated := func() (err error) {
+		defer Isolate("reporter-test", &err)
+		panic("boom")
+	}
+
+	err := isolated()
+	require.ErrorIs(t, err, ErrPanic)
+	require.EqualError(t, err, "panic: boom")
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	require.Equal(t, []interface{}{"boom"}, r.panics)
+	require.Equal(t, 0, r.flushed)
+
+	// the error is left as is without a panic
+	require.NoError(t, func() (err error) {
+		defer Isolate("reporter-test", &err)
+		return nil
+	}())
+}
@@ -0,0 +1,78 @@
+package reporter
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	dataavailability "github.com/0xPolygon/cdk-data-availability"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/getsentry/sentry-go"
+)
+
+// tagFields are the fields reported as Sentry tags, which can be searched and
+// aggregated, the rest of them are reported as context of the error
+var tagFields = map[string]bool{
+	log.FieldComponent:  true,
+	log.FieldMethod:     true,
+	log.FieldMemberAddr: true,
+	"level":             true,
+}
+
+// sentryReporter reports the errors and panics to Sentry
+type sentryReporter struct {
+	hub *sentry.Hub
+}
+
+// newSentryReporter returns a Reporter sending the errors to the Sentry project of the DSN
+func newSentryReporter(cfg Config) (*sentryReporter, error) {
+	client, err := sentry.NewClient(sentry.ClientOptions{
+		Dsn:         cfg.DSN,
+		Environment: cfg.Environment,
+		Release:     dataavailability.Version,
+		SampleRate:  cfg.SampleRate,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &sentryReporter{hub: sentry.NewHub(client, sentry.NewScope())}, nil
+}
+
+// CaptureError implements Reporter
+func (r *sentryReporter) CaptureError(err error, fields map[string]interface{}) {
+	r.hub.WithScope(func(scope *sentry.Scope) {
+		context := make(map[string]interface{})
+		for key, value := range fields {
+			if tagFields[key] {
+				scope.SetTag(key, fmt.Sprint(value))
+			} else {
+				context[key] = value
+			}
+		}
+		if len(context) > 0 {
+			scope.SetContext("fields", context)
+		}
+
+		r.hub.CaptureException(err)
+	})
+}
+
+// CapturePanic implements Reporter
+func (r *sentryReporter) CapturePanic(recovered interface{}, stack []byte) {
+	r.hub.WithScope(func(scope *sentry.Scope) {
+		scope.SetLevel(sentry.LevelFatal)
+		scope.SetContext("panic", map[string]interface{}{"stack": string(stack)})
+
+		err, ok := recovered.(error)
+		if !ok {
+			err = errors.New(fmt.Sprint(recovered))
+		}
+		r.hub.CaptureException(err)
+	})
+}
+
+// Flush implements Reporter
+func (r *sentryReporter) Flush(timeout time.Duration) bool {
+	return r.hub.Flush(timeout)
+}
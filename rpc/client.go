@@ -4,10 +4,33 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/fault"
+	"github.com/0xPolygon/cdk-data-availability/metrics"
+	"github.com/0xPolygon/cdk-data-availability/pkg/buffer"
+	"github.com/0xPolygon/cdk-data-availability/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// ErrResponseTooLarge is returned for the responses larger than the maximum set with SetMaxResponseSize
+var ErrResponseTooLarge = errors.New("response too large")
+
+// maxResponseSize is the maximum size of the responses read, 0 for no limit
+var maxResponseSize atomic.Uint64
+
+// SetMaxResponseSize bounds the size of the responses read by the client, 0 for no limit
+func SetMaxResponseSize(size uint64) {
+	maxResponseSize.Store(size)
+}
+
 // JSONRPCCall calls JSONRPCCallWithContext with the default context
 func JSONRPCCall(url, method string, params ...interface{}) (Response, error) {
 	return JSONRPCCallWithContext(context.Background(), url, method, params...)
@@ -17,6 +40,31 @@ func JSONRPCCall(url, method string, params ...interface{}) (Response, error) {
 // the provided method and parameters, which is compatible with the Ethereum
 // JSON RPC Server.
 func JSONRPCCallWithContext(ctx context.Context, url, method string, parameters ...interface{}) (Response, error) {
+	ctx, span := tracing.Start(ctx, method, trace.SpanKindClient,
+		attribute.String("rpc.system", "jsonrpc"),
+		attribute.String("rpc.method", method),
+	)
+
+	start := time.Now()
+	res, err := jsonRPCCall(ctx, url, method, parameters...)
+	metrics.ClientRequest(method, start, err != nil || res.Error != nil)
+
+	spanErr := err
+	if spanErr == nil && res.Error != nil {
+		span.SetAttributes(attribute.Int("rpc.jsonrpc.error_code", res.Error.Code))
+		spanErr = errors.New(res.Error.Message)
+	}
+	tracing.End(span, spanErr)
+
+	return res, err
+}
+
+func jsonRPCCall(ctx context.Context, url, method string, parameters ...interface{}) (Response, error) {
+	point := "client." + method
+	if err := fault.Before(ctx, point); err != nil {
+		return Response{}, err
+	}
+
 	httpReq, err := BuildJsonHTTPRequest(ctx, url, method, parameters...)
 	if err != nil {
 		return Response{}, err
@@ -35,14 +83,54 @@ func JSONRPCCallWithContext(ctx context.Context, url, method string, parameters
 		return Response{}, fmt.Errorf("invalid status code, expected: %v, found: %v", http.StatusOK, httpRes.StatusCode)
 	}
 
+	var body io.Reader = httpRes.Body
+	if max := maxResponseSize.Load(); max > 0 {
+		body = &limitedReader{r: httpRes.Body, remaining: max}
+	}
+
+	// the response is read into a pooled buffer, the result being copied out of it when decoded
+	buf := buffer.Get()
+	defer buffer.Put(buf)
+	if _, err = buf.ReadFrom(body); err != nil {
+		return Response{}, err
+	}
+
 	var res Response
-	if err = json.NewDecoder(httpRes.Body).Decode(&res); err != nil {
+	if err = json.Unmarshal(buf.Bytes(), &res); err != nil {
+		return Response{}, err
+	}
+
+	// the request was served, only the response is lost
+	if err = fault.After(point, nil); err != nil {
 		return Response{}, err
 	}
 
 	return res, nil
 }
 
+// limitedReader reads at most remaining bytes, failing with ErrResponseTooLarge past them
+type limitedReader struct {
+	r         io.Reader
+	remaining uint64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.remaining == 0 {
+		// the response may end right at the limit
+		if n, _ := l.r.Read(make([]byte, 1)); n > 0 {
+			return 0, ErrResponseTooLarge
+		}
+		return 0, io.EOF
+	}
+	if uint64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+
+	n, err := l.r.Read(p)
+	l.remaining -= uint64(n)
+	return n, err
+}
+
 // BuildJsonHTTPRequest creates JSON RPC http request using provided url, method and parameters
 func BuildJsonHTTPRequest(ctx context.Context, url, method string, parameters ...interface{}) (*http.Request, error) {
 	params, err := json.Marshal(parameters)
@@ -73,6 +161,8 @@ func BuildJsonHttpRequestWithBody(ctx context.Context, url string, reqBody []byt
 	}
 
 	httpReq.Header.Add("Content-type", "application/json")
+	// propagate the trace context so the callee continues the trace
+	tracing.Inject(ctx, propagation.HeaderCarrier(httpReq.Header))
 
 	return httpReq, nil
 }
@@ -5,10 +5,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/0xPolygon/cdk-data-availability/fault"
 	"github.com/stretchr/testify/require"
 )
 
@@ -66,3 +69,43 @@ func Test_JSONRPCCallWithContext(t *testing.T) {
 		})
 	}
 }
+
+// not parallel, the faults being injected into the whole process
+func Test_JSONRPCCallWithContext_Faults(t *testing.T) {
+	served := 0
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		served++
+		_, err := fmt.Fprint(w, `{"result":"test"}`)
+		require.NoError(t, err)
+	}))
+	defer svr.Close()
+
+	fault.Init(fault.Config{Enabled: true, Rules: []fault.Rule{
+		{Point: "client.failed", ErrorRate: 1},
+		{Point: "client.dropped", DropRate: 1},
+	}})
+	t.Cleanup(func() { fault.Init(fault.Config{}) })
+
+	_, err := JSONRPCCallWithContext(context.Background(), svr.URL, "failed")
+	require.ErrorIs(t, err, fault.ErrInjected)
+	require.Equal(t, 0, served)
+
+	// the request is served, its response being lost
+	_, err = JSONRPCCallWithContext(context.Background(), svr.URL, "dropped")
+	require.ErrorIs(t, err, fault.ErrDropped)
+	require.Equal(t, 1, served)
+
+	_, err = JSONRPCCallWithContext(context.Background(), svr.URL, "test")
+	require.NoError(t, err)
+}
+
+func Test_LimitedReader(t *testing.T) {
+	t.Parallel()
+
+	data, err := io.ReadAll(&limitedReader{r: strings.NewReader("response"), remaining: 8})
+	require.NoError(t, err)
+	require.Equal(t, "response", string(data))
+
+	_, err = io.ReadAll(&limitedReader{r: strings.NewReader("response"), remaining: 7})
+	require.ErrorIs(t, err, ErrResponseTooLarge)
+}
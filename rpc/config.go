@@ -14,6 +14,15 @@ type Config struct {
 	// check net/http.server.ReadTimeout and net/http.server.ReadHeaderTimeout
 	ReadTimeout types.Duration `mapstructure:"ReadTimeout"`
 
+	// ReadHeaderTimeout is the time allowed to read the headers of a request, bounding the clients sending them
+	// slowly to hold the connections open, ReadTimeout when zero
+	// check net/http.server.ReadHeaderTimeout
+	ReadHeaderTimeout types.Duration `mapstructure:"ReadHeaderTimeout"`
+
+	// IdleTimeout is how long an idle keep-alive connection is kept open, ReadTimeout when zero
+	// check net/http.server.IdleTimeout
+	IdleTimeout types.Duration `mapstructure:"IdleTimeout"`
+
 	// WriteTimeout is the HTTP server write timeout
 	// check net/http.server.WriteTimeout
 	WriteTimeout types.Duration `mapstructure:"WriteTimeout"`
@@ -21,4 +30,52 @@ type Config struct {
 	// MaxRequestsPerIPAndSecond defines how much requests a single IP can
 	// send within a single second
 	MaxRequestsPerIPAndSecond float64 `mapstructure:"MaxRequestsPerIPAndSecond"`
+
+	// MaxConns is the maximum number of connections open at once, the others being closed when accepted,
+	// 0 for no limit
+	MaxConns int `mapstructure:"MaxConns"`
+
+	// MaxConnsPerIP is the maximum number of connections open at once from an IP, 0 for no limit
+	MaxConnsPerIP int `mapstructure:"MaxConnsPerIP"`
+
+	// MaxConcurrentRequestsPerIP is the maximum number of requests handled at once for an IP, the others
+	// being answered with 429 Too Many Requests, 0 for no limit
+	MaxConcurrentRequestsPerIP int `mapstructure:"MaxConcurrentRequestsPerIP"`
+
+	// MaxConcurrentRequests is the maximum number of requests to the services without priority handled at once,
+	// the others being answered with 503 Service Unavailable and a Retry-After hint, 0 for no limit. The requests
+	// to the Overload.PriorityServices are always handled.
+	MaxConcurrentRequests int `mapstructure:"MaxConcurrentRequests"`
+
+	// MaxMessageSize is the maximum size in bytes of a request read by the server, and of a response read by
+	// the client from the sequencer and the other members, 0 for no limit
+	MaxMessageSize uint64 `mapstructure:"MaxMessageSize"`
+
+	// ReusePort binds the listener with SO_REUSEPORT, so that the new process of an upgrade can listen on
+	// the same port before the old one is stopped. A socket passed by systemd socket activation is always used.
+	ReusePort bool `mapstructure:"ReusePort"`
+
+	// Overload rejects the requests of low priority while the node is overloaded
+	Overload OverloadConfig `mapstructure:"Overload"`
+}
+
+// OverloadConfig is the configuration of the load shedding. While one of its limits is reached, the requests
+// to the methods of the services not prioritized are answered with 503 Service Unavailable and a Retry-After
+// hint, leaving the resources of the node to the signing of the sequences and its health checks.
+type OverloadConfig struct {
+	// MaxInFlight is the number of requests handled at once from which the node is overloaded, 0 for no limit
+	MaxInFlight int `mapstructure:"MaxInFlight"`
+
+	// MaxDBConnsInUse is the number of connections of the database pool in use from which the node is
+	// overloaded, 0 for no limit
+	MaxDBConnsInUse int `mapstructure:"MaxDBConnsInUse"`
+
+	// MaxHeapSize is the size in bytes of the heap from which the node is overloaded, 0 for no limit
+	MaxHeapSize uint64 `mapstructure:"MaxHeapSize"`
+
+	// RetryAfter is the delay after which the clients of the rejected requests are told to retry
+	RetryAfter types.Duration `mapstructure:"RetryAfter"`
+
+	// PriorityServices are the services whose requests are never rejected, e.g. datacom and status
+	PriorityServices []string `mapstructure:"PriorityServices"`
 }
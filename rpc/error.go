@@ -22,6 +22,8 @@ const (
 	ParserErrorCode = -32700
 	// AccessDeniedCode error code when requests are denied
 	AccessDeniedCode = -32800
+	// OverloadedErrorCode error code for the requests rejected while the node is overloaded
+	OverloadedErrorCode = -32005
 )
 
 var (
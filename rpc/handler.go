@@ -1,16 +1,24 @@
 package rpc
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
 	"strings"
 	"sync"
+	"time"
 	"unicode"
 
 	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/metrics"
+	"github.com/0xPolygon/cdk-data-availability/reporter"
+	"github.com/0xPolygon/cdk-data-availability/tracing"
 	"github.com/gorilla/websocket"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -39,6 +47,15 @@ type handleRequest struct {
 	HttpRequest *http.Request
 }
 
+// context returns the context of the http request, carrying the trace context of the caller if any
+func (r handleRequest) context() context.Context {
+	if r.HttpRequest != nil {
+		return r.HttpRequest.Context()
+	}
+
+	return context.Background()
+}
+
 // Handler manage services to handle jsonrpc requests
 //
 // Services are public structures containing public methods
@@ -58,6 +75,7 @@ type handleRequest struct {
 // the public methods must follow the conventions:
 // - return interface{}, rpcError
 // - if the method depend on a Web Socket connection, it must be the first parameters as f(*websocket.Conn)
+// - if the method needs the context of the request, it must be the first parameter as f(context.Context)
 // - parameter types must match the type of the data provided for the method
 //
 // check the `eth.go` file for more example on how the methods are implemented
@@ -77,10 +95,18 @@ var (
 	connectionCounterMutex sync.Mutex
 )
 
+// OpenConnections returns the number of requests being handled
+func OpenConnections() int {
+	connectionCounterMutex.Lock()
+	defer connectionCounterMutex.Unlock()
+
+	return connectionCounter
+}
+
 // Handle is the function that knows which and how a function should
 // be executed when a JSON RPC request is received
 func (h *Handler) Handle(req handleRequest) Response {
-	log := log.WithFields("method", req.Method, "requestId", req.ID)
+	log := logger.WithFields(log.FieldMethod, req.Method, log.FieldRequestID, req.ID)
 	connectionCounterMutex.Lock()
 	connectionCounter++
 	connectionCounterMutex.Unlock()
@@ -98,7 +124,58 @@ func (h *Handler) Handle(req handleRequest) Response {
 		return NewResponse(req.Request, nil, err)
 	}
 
+	ctx, span := tracing.Start(req.context(), req.Method, trace.SpanKindServer,
+		attribute.String("rpc.system", "jsonrpc"),
+		attribute.String("rpc.method", req.Method),
+	)
+
+	start := time.Now()
+	response := h.isolatedCall(ctx, req, service, fd, log)
+	metrics.RPCRequest(req.Method, start, response.Error != nil)
+
+	var spanErr error
+	if response.Error != nil {
+		span.SetAttributes(attribute.Int("rpc.jsonrpc.error_code", response.Error.Code))
+		spanErr = errors.New(response.Error.Message)
+	}
+	tracing.End(span, spanErr)
+
+	return response
+}
+
+// isolatedCall calls the function of the service handling the request, answering an internal error when
+// it panics so a single request can not stop the node
+func (h *Handler) isolatedCall(
+	ctx context.Context,
+	req handleRequest,
+	service *serviceData,
+	fd *funcData,
+	log *log.Logger,
+) (response Response) {
+	var err error
+	defer func() {
+		if err != nil {
+			log.Warnf("failed call: %v", err)
+			response = NewResponse(req.Request, nil, NewRPCError(DefaultErrorCode, "internal error"))
+		}
+	}()
+	defer reporter.Isolate("rpc", &err)
+
+	return h.call(ctx, req, service, fd, log)
+}
+
+// call executes the function of the service handling the request
+func (h *Handler) call(
+	ctx context.Context,
+	req handleRequest,
+	service *serviceData,
+	fd *funcData,
+	log *log.Logger,
+) Response {
 	inArgsOffset := 0
+	// the context is not counted in the arguments allowed to the request, unlike the
+	// websocket connection and the http request
+	maxArgs := fd.numParams()
 	inArgs := make([]reflect.Value, fd.inNum)
 	inArgs[0] = service.sv
 
@@ -106,9 +183,11 @@ func (h *Handler) Handle(req handleRequest) Response {
 	funcHasMoreThanOneInputParams := len(fd.reqt) > 1
 	firstFuncParamIsWebSocketConn := false
 	firstFuncParamIsHttpRequest := false
+	firstFuncParamIsContext := false
 	if funcHasMoreThanOneInputParams {
 		firstFuncParamIsWebSocketConn = fd.reqt[1].AssignableTo(reflect.TypeOf(&websocket.Conn{}))
 		firstFuncParamIsHttpRequest = fd.reqt[1].AssignableTo(reflect.TypeOf(&http.Request{}))
+		firstFuncParamIsContext = fd.reqt[1] == contextType
 	}
 	if requestHasWebSocketConn && firstFuncParamIsWebSocketConn {
 		inArgs[1] = reflect.ValueOf(req.wsConn)
@@ -118,15 +197,19 @@ func (h *Handler) Handle(req handleRequest) Response {
 		// we will need to modify this code to properly handle it
 		inArgs[1] = reflect.ValueOf(req.HttpRequest)
 		inArgsOffset++
+	} else if firstFuncParamIsContext {
+		inArgs[1] = reflect.ValueOf(ctx)
+		inArgsOffset++
+		maxArgs--
 	}
 
 	// check params passed by request match function params
 	var testStruct []interface{}
-	if err := json.Unmarshal(req.Params, &testStruct); err == nil && len(testStruct) > fd.numParams() {
+	if err := json.Unmarshal(req.Params, &testStruct); err == nil && len(testStruct) > maxArgs {
 		return NewResponse(
 			req.Request,
 			nil,
-			NewRPCError(InvalidParamsErrorCode, fmt.Sprintf("too many arguments, want at most %d", fd.numParams())),
+			NewRPCError(InvalidParamsErrorCode, fmt.Sprintf("too many arguments, want at most %d", maxArgs)),
 		)
 	}
 
@@ -162,7 +245,7 @@ func (h *Handler) Handle(req handleRequest) Response {
 
 // HandleWs handle websocket requests
 func (h *Handler) HandleWs(reqBody []byte, wsConn *websocket.Conn, httpReq *http.Request) ([]byte, error) {
-	log.Debugf("WS message received: %v", string(reqBody))
+	logger.Debugf("WS message received: %v", string(reqBody))
 	var req Request
 	if err := json.Unmarshal(reqBody, &req); err != nil {
 		return NewResponse(req, nil, NewRPCError(InvalidRequestErrorCode, errInvalidJSONReq.Error())).Bytes()
@@ -228,7 +311,7 @@ func (h *Handler) getFnHandler(req Request) (*serviceData, *funcData, Error) {
 
 	service, ok := h.serviceMap[serviceName]
 	if !ok {
-		log.Infof("Method %s not found", req.Method)
+		logger.Infof("Method %s not found", req.Method)
 		return nil, nil, NewRPCError(NotFoundErrorCode, methodNotFoundErrorMessage)
 	}
 	fd, ok := service.funcMap[funcName]
@@ -274,7 +357,10 @@ func validateFunc(funcName string, fv reflect.Value, isMethod bool) (inNum int,
 	return
 }
 
-var rpcErrType = reflect.TypeOf((*Error)(nil)).Elem()
+var (
+	rpcErrType  = reflect.TypeOf((*Error)(nil)).Elem()
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+)
 
 func isRPCErrorType(t reflect.Type) bool {
 	return t.Implements(rpcErrType)
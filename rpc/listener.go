@@ -0,0 +1,60 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+)
+
+// listenFDsStart is the first file descriptor passed to a socket activated process, see sd_listen_fds(3)
+const listenFDsStart = 3
+
+// listen returns the listener of the server. When the node is socket activated by systemd, the socket it
+// passes is used, so that the connections are queued by the kernel while the node restarts. Otherwise a
+// socket is bound to the configured address, shared with the other processes bound to it with ReusePort,
+// so that the new binary of an upgrade can accept connections before the old one is stopped.
+func listen(cfg Config) (net.Listener, error) {
+	lis, err := activatedListener()
+	if err != nil || lis != nil {
+		return lis, err
+	}
+
+	lc := net.ListenConfig{}
+	if cfg.ReusePort {
+		lc.Control = reusePort
+	}
+
+	return lc.Listen(context.Background(), "tcp", fmt.Sprintf("%s:%d", cfg.Host, cfg.Port))
+}
+
+// activatedListener returns the first socket passed by systemd, or nil when the process was not socket activated
+func activatedListener() (net.Listener, error) {
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		return nil, nil //nolint:nilnil
+	}
+	fds, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || fds < 1 {
+		return nil, nil //nolint:nilnil
+	}
+
+	// the sockets are not passed on to the processes started by the node
+	for _, name := range []string{"LISTEN_PID", "LISTEN_FDS", "LISTEN_FDNAMES"} {
+		if err = os.Unsetenv(name); err != nil {
+			return nil, err
+		}
+	}
+
+	f := os.NewFile(listenFDsStart, "LISTEN_FD_"+strconv.Itoa(listenFDsStart))
+	defer f.Close()
+
+	lis, err := net.FileListener(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to use the socket passed by systemd: %w", err)
+	}
+	logger.Info("using the socket passed by systemd")
+
+	return lis, nil
+}
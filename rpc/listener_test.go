@@ -0,0 +1,41 @@
+package rpc
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_listen(t *testing.T) {
+	t.Run("reuse port", func(t *testing.T) {
+		first, err := listen(Config{Host: "127.0.0.1", ReusePort: true})
+		require.NoError(t, err)
+		defer first.Close()
+
+		// the new process of an upgrade binds the port of the old one
+		port := first.Addr().(*net.TCPAddr).Port //nolint:forcetypeassert
+		second, err := listen(Config{Host: "127.0.0.1", Port: port, ReusePort: true})
+		require.NoError(t, err)
+		require.NoError(t, second.Close())
+	})
+
+	t.Run("port in use", func(t *testing.T) {
+		first, err := listen(Config{Host: "127.0.0.1"})
+		require.NoError(t, err)
+		defer first.Close()
+
+		port := first.Addr().(*net.TCPAddr).Port //nolint:forcetypeassert
+		_, err = listen(Config{Host: "127.0.0.1", Port: port})
+		require.Error(t, err)
+	})
+
+	t.Run("not socket activated", func(t *testing.T) {
+		t.Setenv("LISTEN_PID", "1")
+		t.Setenv("LISTEN_FDS", "1")
+
+		lis, err := activatedListener()
+		require.NoError(t, err)
+		require.Nil(t, lis)
+	})
+}
@@ -0,0 +1,126 @@
+package rpc
+
+import (
+	"database/sql"
+	"math"
+	"net/http"
+	"runtime/metrics"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	dacmetrics "github.com/0xPolygon/cdk-data-availability/metrics"
+)
+
+// heapObjectsMetric is the runtime metric of the bytes of the heap occupied by objects, read without stopping
+// the world unlike runtime.ReadMemStats
+const heapObjectsMetric = "/memory/classes/heap/objects:bytes"
+
+// errOverloaded is the error of the requests rejected while the node is overloaded
+var errOverloaded = NewRPCError(OverloadedErrorCode, "the node is overloaded, retry later")
+
+// overload decides whether a request is rejected, from the requests in flight, the connections in use of
+// the database pool and the size of the heap
+type overload struct {
+	dbStats  func() sql.DBStats
+	settings atomic.Pointer[overloadSettings]
+}
+
+// overloadSettings are the limits of the overload, replaced as a whole when reloaded
+type overloadSettings struct {
+	cfg      OverloadConfig
+	priority map[string]bool
+	// slots holds a place for each request of low priority handled, nil for no limit
+	slots chan struct{}
+}
+
+// newOverload returns the overload of the config, handling at most maxConcurrentRequests requests of low
+// priority at once, 0 for no limit
+func newOverload(cfg OverloadConfig, maxConcurrentRequests int) *overload {
+	o := &overload{}
+	o.set(cfg, maxConcurrentRequests)
+	return o
+}
+
+// set changes the limits of the overload. The requests handled keep their place among the requests handled at
+// once, unless the maximum changes: the requests handled meanwhile are then not counted against the new one.
+func (o *overload) set(cfg OverloadConfig, maxConcurrentRequests int) {
+	priority := make(map[string]bool, len(cfg.PriorityServices))
+	for _, service := range cfg.PriorityServices {
+		priority[service] = true
+	}
+
+	settings := &overloadSettings{cfg: cfg, priority: priority}
+	if current := o.settings.Load(); current != nil && cap(current.slots) == maxConcurrentRequests {
+		settings.slots = current.slots
+	} else if maxConcurrentRequests > 0 {
+		settings.slots = make(chan struct{}, maxConcurrentRequests)
+	}
+	o.settings.Store(settings)
+}
+
+// reason returns why the node is overloaded, empty when it is not
+func (o *overload) reason() string {
+	cfg := o.settings.Load().cfg
+	if cfg.MaxInFlight > 0 && OpenConnections() >= cfg.MaxInFlight {
+		return dacmetrics.ThrottleOverloadInFlight
+	}
+	if cfg.MaxDBConnsInUse > 0 && o.dbStats != nil && o.dbStats().InUse >= cfg.MaxDBConnsInUse {
+		return dacmetrics.ThrottleOverloadDBPool
+	}
+	if cfg.MaxHeapSize > 0 && heapSize() >= cfg.MaxHeapSize {
+		return dacmetrics.ThrottleOverloadMemory
+	}
+
+	return ""
+}
+
+// admit returns whether the request to the method is handled, always for the methods of a priority service,
+// with the function releasing its place among the requests handled at once
+func (o *overload) admit(method string) (func(), bool) {
+	settings := o.settings.Load()
+	service, _, _ := strings.Cut(method, "_")
+	if settings.priority[service] {
+		return release, true
+	}
+
+	if reason := o.reason(); reason != "" {
+		dacmetrics.RPCThrottled(reason)
+		return nil, false
+	}
+	slots := settings.slots
+	if slots == nil {
+		return release, true
+	}
+
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, true
+	default:
+		dacmetrics.RPCThrottled(dacmetrics.ThrottleRequests)
+		return nil, false
+	}
+}
+
+// release releases nothing, for the requests not counted among the requests handled at once
+func release() {}
+
+// retryAfter sets the Retry-After header of the response, in whole seconds
+func (o *overload) retryAfter(w http.ResponseWriter) {
+	seconds := int(math.Ceil(o.settings.Load().cfg.RetryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+}
+
+// heapSize returns the bytes of the heap occupied by objects
+func heapSize() uint64 {
+	sample := []metrics.Sample{{Name: heapObjectsMetric}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+
+	return sample[0].Value.Uint64()
+}
@@ -0,0 +1,197 @@
+package rpc
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/config/types"
+	"github.com/0xPolygon/cdk-data-availability/metrics"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ServerOverload(t *testing.T) {
+	server := NewServer(Config{Overload: OverloadConfig{
+		MaxDBConnsInUse:  10,
+		RetryAfter:       types.NewDuration(1500 * time.Millisecond),
+		PriorityServices: []string{"priority"},
+	}}, []Service{
+		{Name: "greeter", Service: &greeterService{}},
+		{Name: "priority", Service: &greeterService{}},
+	})
+	inUse := 9
+	server.WatchDBPool(func() sql.DBStats { return sql.DBStats{InUse: inUse} })
+
+	call := func(t *testing.T, body interface{}) *httptest.ResponseRecorder {
+		t.Helper()
+
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		req, err := BuildJsonHttpRequestWithBody(context.Background(), "http://localhost", data)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		server.handle(recorder, req)
+		return recorder
+	}
+	request := func(id int, method string) Request {
+		return Request{JSONRPC: "2.0", ID: id, Method: method, Params: json.RawMessage(`["John Doe"]`)}
+	}
+
+	// below the limit every request is handled
+	recorder := call(t, request(1, "greeter_handleReq"))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Empty(t, recorder.Header().Get("Retry-After"))
+
+	inUse = 10
+
+	t.Run("sheds the requests of low priority", func(t *testing.T) {
+		recorder := call(t, request(1, "greeter_handleReq"))
+		require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+		require.Equal(t, "2", recorder.Header().Get("Retry-After"))
+
+		var resp Response
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+		require.NotNil(t, resp.Error)
+		require.Equal(t, OverloadedErrorCode, resp.Error.Code)
+	})
+
+	t.Run("handles the requests of a priority service", func(t *testing.T) {
+		recorder := call(t, request(1, "priority_handleReq"))
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		var resp Response
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+		require.Nil(t, resp.Error)
+		require.Equal(t, `"Hello, John Doe!"`, string(resp.Result))
+	})
+
+	t.Run("sheds the requests of low priority of a batch", func(t *testing.T) {
+		recorder := call(t, []Request{request(1, "greeter_handleReq"), request(2, "priority_handleReq")})
+		require.Equal(t, http.StatusOK, recorder.Code)
+		require.Equal(t, "2", recorder.Header().Get("Retry-After"))
+
+		var resp []Response
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+		require.Len(t, resp, 2)
+		require.NotNil(t, resp[0].Error)
+		require.Equal(t, OverloadedErrorCode, resp[0].Error.Code)
+		require.Nil(t, resp[1].Error)
+
+		recorder = call(t, []Request{request(1, "greeter_handleReq"), request(2, "greeter_handleReq")})
+		require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	})
+}
+
+func Test_OverloadReason(t *testing.T) {
+	o := newOverload(OverloadConfig{MaxInFlight: 2}, 0)
+	require.Empty(t, o.reason())
+
+	connectionCounterMutex.Lock()
+	connectionCounter += 2
+	connectionCounterMutex.Unlock()
+	defer func() {
+		connectionCounterMutex.Lock()
+		connectionCounter -= 2
+		connectionCounterMutex.Unlock()
+	}()
+	require.Equal(t, metrics.ThrottleOverloadInFlight, o.reason())
+
+	// the heap is never empty
+	o = newOverload(OverloadConfig{MaxHeapSize: 1}, 0)
+	require.Equal(t, metrics.ThrottleOverloadMemory, o.reason())
+
+	// no limit
+	o = newOverload(OverloadConfig{}, 0)
+	require.Empty(t, o.reason())
+	_, ok := o.admit("greeter_handleReq")
+	require.True(t, ok)
+}
+
+func Test_OverloadSet(t *testing.T) {
+	o := newOverload(OverloadConfig{}, 1)
+	release, ok := o.admit("greeter_handleReq")
+	require.True(t, ok)
+	_, ok = o.admit("greeter_handleReq")
+	require.False(t, ok)
+
+	// the requests handled keep their place while the maximum is unchanged
+	o.set(OverloadConfig{}, 1)
+	_, ok = o.admit("greeter_handleReq")
+	require.False(t, ok)
+	release()
+
+	o.set(OverloadConfig{}, 2)
+	_, ok = o.admit("greeter_handleReq")
+	require.True(t, ok)
+	_, ok = o.admit("greeter_handleReq")
+	require.True(t, ok)
+
+	o.set(OverloadConfig{MaxHeapSize: 1, PriorityServices: []string{"status"}}, 0)
+	require.Equal(t, metrics.ThrottleOverloadMemory, o.reason())
+	_, ok = o.admit("greeter_handleReq")
+	require.False(t, ok)
+	_, ok = o.admit("status_getStatus")
+	require.True(t, ok)
+}
+
+// blockingService handles its requests once released
+type blockingService struct {
+	handling chan struct{}
+	release  chan struct{}
+}
+
+// Mock implementation of a service method blocking until released
+func (s *blockingService) Wait(_ string) (interface{}, Error) {
+	s.handling <- struct{}{}
+	<-s.release
+	return "done", nil
+}
+
+func Test_ServerMaxConcurrentRequests(t *testing.T) {
+	blocking := &blockingService{handling: make(chan struct{}), release: make(chan struct{})}
+	server := NewServer(Config{
+		MaxConcurrentRequests: 1,
+		Overload: OverloadConfig{
+			RetryAfter:       types.NewDuration(3 * time.Second),
+			PriorityServices: []string{"datacom"},
+		},
+	}, []Service{
+		{Name: "blocking", Service: blocking},
+		{Name: "greeter", Service: &greeterService{}},
+		{Name: "datacom", Service: &greeterService{}},
+	})
+
+	call := func(method string) *httptest.ResponseRecorder {
+		req, err := BuildJsonHTTPRequest(context.Background(), "http://localhost", method, "John Doe")
+		require.NoError(t, err)
+		recorder := httptest.NewRecorder()
+		server.handle(recorder, req)
+		return recorder
+	}
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- call("blocking_wait") }()
+	<-blocking.handling
+
+	// the requests of low priority are refused while the cap is reached, the priority ones handled
+	recorder := call("greeter_handleReq")
+	require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	require.Equal(t, "3", recorder.Header().Get("Retry-After"))
+
+	recorder = call("datacom_handleReq")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var resp Response
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+	require.Nil(t, resp.Error)
+
+	close(blocking.release)
+	require.Equal(t, http.StatusOK, (<-done).Code)
+
+	// the place of a handled request is released
+	require.Equal(t, http.StatusOK, call("greeter_handleReq").Code)
+}
@@ -0,0 +1,22 @@
+//go:build linux || darwin || freebsd
+
+package rpc
+
+import (
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// reusePort sets SO_REUSEPORT on the socket before it is bound, allowing several processes to listen on the
+// same address, the kernel spreading the connections between them
+func reusePort(_, _ string, c syscall.RawConn) error {
+	var sockErr error
+	if err := c.Control(func(fd uintptr) {
+		sockErr = unix.SetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_REUSEPORT, 1)
+	}); err != nil {
+		return err
+	}
+
+	return sockErr
+}
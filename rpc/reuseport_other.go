@@ -0,0 +1,13 @@
+//go:build !linux && !darwin && !freebsd
+
+package rpc
+
+import (
+	"errors"
+	"syscall"
+)
+
+// reusePort fails, SO_REUSEPORT not being supported on this platform
+func reusePort(_, _ string, _ syscall.RawConn) error {
+	return errors.New("RPC.ReusePort is not supported on this platform")
+}
@@ -3,23 +3,32 @@ package rpc
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
-	"net"
 	"net/http"
 	"time"
 
 	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/pkg/buffer"
+	"github.com/0xPolygon/cdk-data-availability/tracing"
 	"github.com/didip/tollbooth/v6"
+	"github.com/didip/tollbooth/v6/limiter"
+	"go.opentelemetry.io/otel/propagation"
 )
 
+// logger is the logger of the rpc component
+var logger = log.WithComponent("rpc")
+
 // Server is an API backend to handle RPC requests
 type Server struct {
-	config  Config
-	handler *Handler
-	srv     *http.Server
+	config   Config
+	handler  *Handler
+	limiter  *limiter.Limiter
+	overload *overload
+	srv      *http.Server
 }
 
 // Service implementation of a service an it's name
@@ -40,8 +49,10 @@ func NewServer(
 	}
 
 	srv := &Server{
-		config:  cfg,
-		handler: handler,
+		config:   cfg,
+		handler:  handler,
+		limiter:  tollbooth.NewLimiter(cfg.MaxRequestsPerIPAndSecond, nil),
+		overload: newOverload(cfg.Overload, cfg.MaxConcurrentRequests),
 	}
 	return srv
 }
@@ -57,32 +68,42 @@ func (s *Server) startHTTP() error {
 		return fmt.Errorf("server already started")
 	}
 
-	address := fmt.Sprintf("%s:%d", s.config.Host, s.config.Port)
-
-	lis, err := net.Listen("tcp", address)
+	lis, err := listen(s.config)
 	if err != nil {
-		log.Errorf("failed to create tcp listener: %v", err)
+		logger.Errorf("failed to create tcp listener: %v", err)
 		return err
 	}
 
+	lis = limitConns(lis, s.config.MaxConns, s.config.MaxConnsPerIP)
+
 	mux := http.NewServeMux()
 
-	lmt := tollbooth.NewLimiter(s.config.MaxRequestsPerIPAndSecond, nil)
-	mux.Handle("/", tollbooth.LimitFuncHandler(lmt, s.handle))
+	mux.Handle("/", limitRequestsPerIP(tollbooth.LimitFuncHandler(s.limiter, s.handle),
+		s.config.MaxConcurrentRequestsPerIP))
+
+	readHeaderTimeout := s.config.ReadHeaderTimeout.Duration
+	if readHeaderTimeout == 0 {
+		readHeaderTimeout = s.config.ReadTimeout.Duration
+	}
+	idleTimeout := s.config.IdleTimeout.Duration
+	if idleTimeout == 0 {
+		idleTimeout = s.config.ReadTimeout.Duration
+	}
 
 	s.srv = &http.Server{
 		Handler:           mux,
-		ReadHeaderTimeout: s.config.ReadTimeout.Duration,
+		ReadHeaderTimeout: readHeaderTimeout,
 		ReadTimeout:       s.config.ReadTimeout.Duration,
 		WriteTimeout:      s.config.WriteTimeout.Duration,
+		IdleTimeout:       idleTimeout,
 	}
-	log.Infof("http server started: %s", address)
+	logger.Infof("http server started: %s", lis.Addr())
 	if err := s.srv.Serve(lis); err != nil {
 		if err == http.ErrServerClosed {
-			log.Infof("http server stopped")
+			logger.Infof("http server stopped")
 			return nil
 		}
-		log.Errorf("closed http connection: %v", err)
+		logger.Errorf("closed http connection: %v", err)
 		return err
 	}
 	return nil
@@ -104,6 +125,23 @@ func (s *Server) Stop() error {
 	return nil
 }
 
+// SetMaxRequestsPerIPAndSecond changes the rate limit of the server at runtime
+func (s *Server) SetMaxRequestsPerIPAndSecond(max float64) {
+	s.limiter.SetMax(max)
+}
+
+// SetOverload changes the maximum of requests of low priority handled at once and the limits of the load
+// shedding at runtime
+func (s *Server) SetOverload(cfg OverloadConfig, maxConcurrentRequests int) {
+	s.overload.set(cfg, maxConcurrentRequests)
+}
+
+// WatchDBPool makes the server shed the requests of low priority while the connections in use of the database
+// pool reach RPC.Overload.MaxDBConnsInUse, it must be called before starting the server
+func (s *Server) WatchDBPool(stats func() sql.DBStats) {
+	s.overload.dbStats = stats
+}
+
 func (s *Server) handle(w http.ResponseWriter, req *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
@@ -122,7 +160,7 @@ func (s *Server) handle(w http.ResponseWriter, req *http.Request) {
 		// TODO(pg): need to count it in the metrics?
 		_, err := w.Write([]byte("zkEVM JSON RPC Server"))
 		if err != nil {
-			log.Error(err)
+			logger.Error(err)
 		}
 		return
 	}
@@ -133,11 +171,21 @@ func (s *Server) handle(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	data, err := io.ReadAll(req.Body)
-	if err != nil {
+	// continue the trace of the caller, if it was propagated
+	req = req.WithContext(tracing.Extract(req.Context(), propagation.HeaderCarrier(req.Header)))
+
+	body := req.Body
+	if s.config.MaxMessageSize > 0 {
+		body = http.MaxBytesReader(w, req.Body, int64(s.config.MaxMessageSize))
+	}
+	// the request is read into a pooled buffer, released once answered as the parsed request copies it
+	buf := buffer.Get()
+	defer buffer.Put(buf)
+	if _, err := buf.ReadFrom(body); err != nil {
 		s.handleInvalidRequest(w, err)
 		return
 	}
+	data := buf.Bytes()
 
 	single, err := s.isSingleRequest(data)
 	if err != nil {
@@ -171,21 +219,23 @@ func (s *Server) handleSingleRequest(httpRequest *http.Request, w http.ResponseW
 		handleError(w, err)
 		return 0
 	}
-	req := handleRequest{Request: request, HttpRequest: httpRequest}
-	response := s.handler.Handle(req)
-
-	respBytes, err := json.Marshal(response)
-	if err != nil {
-		handleError(w, err)
-		return 0
+	var response Response
+	if release, ok := s.overload.admit(request.Method); !ok {
+		response = NewResponse(request, nil, errOverloaded)
+		s.overload.retryAfter(w)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	} else {
+		req := handleRequest{Request: request, HttpRequest: httpRequest}
+		response = s.handler.Handle(req)
+		release()
 	}
 
-	_, err = w.Write(respBytes)
+	respLen, err := writeJSON(w, response)
 	if err != nil {
 		handleError(w, err)
 		return 0
 	}
-	return len(respBytes)
+	return respLen
 }
 
 func (s *Server) handleBatchRequest(httpRequest *http.Request, w http.ResponseWriter, data []byte) int {
@@ -197,19 +247,46 @@ func (s *Server) handleBatchRequest(httpRequest *http.Request, w http.ResponseWr
 
 	responses := make([]Response, 0, len(requests))
 
+	// the requests of low priority of the batch are rejected, the others handled
+	var shed int
 	for _, request := range requests {
+		release, ok := s.overload.admit(request.Method)
+		if !ok {
+			responses = append(responses, NewResponse(request, nil, errOverloaded))
+			shed++
+			continue
+		}
 		req := handleRequest{Request: request, HttpRequest: httpRequest}
 		response := s.handler.Handle(req)
+		release()
 		responses = append(responses, response)
 	}
+	if shed > 0 {
+		s.overload.retryAfter(w)
+		if shed == len(requests) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}
 
-	respBytes, _ := json.Marshal(responses)
-	_, err = w.Write(respBytes)
+	respLen, err := writeJSON(w, responses)
 	if err != nil {
-		log.Error(err)
+		logger.Error(err)
 		return 0
 	}
-	return len(respBytes)
+	return respLen
+}
+
+// writeJSON writes the JSON encoding of v, encoded in a pooled buffer, and returns the number of bytes written
+func writeJSON(w io.Writer, v interface{}) (int, error) {
+	buf := buffer.Get()
+	defer buffer.Put(buf)
+
+	if err := json.NewEncoder(buf).Encode(v); err != nil {
+		return 0, err
+	}
+
+	// the encoder terminates the value with a newline
+	return w.Write(bytes.TrimSuffix(buf.Bytes(), []byte{'\n'}))
 }
 
 func (s *Server) parseRequest(data []byte) (Request, error) {
@@ -237,11 +314,11 @@ func (s *Server) handleInvalidRequest(w http.ResponseWriter, err error) {
 }
 
 func handleError(w http.ResponseWriter, err error) {
-	log.Error(err)
+	logger.Error(err)
 	w.WriteHeader(http.StatusInternalServerError)
 	_, err = w.Write([]byte(err.Error()))
 	if err != nil {
-		log.Error(err)
+		logger.Error(err)
 	}
 }
 
@@ -253,15 +330,15 @@ func RPCErrorResponse(code int, message string, err error) (interface{}, Error)
 // RPCErrorResponseWithData formats error to be returned through RPC
 func RPCErrorResponseWithData(code int, message string, data *[]byte, err error) (interface{}, Error) {
 	if err != nil {
-		log.Errorf("%v: %v", message, err.Error())
+		logger.Errorf("%v: %v", message, err.Error())
 	} else {
-		log.Error(message)
+		logger.Error(message)
 	}
 	return nil, NewRPCErrorWithData(code, message, data)
 }
 
 func combinedLog(r *http.Request, start time.Time, httpStatus, dataLen int) {
-	log.Infof("%s - - %s \"%s %s %s\" %d %d \"%s\" \"%s\"",
+	logger.Infof("%s - - %s \"%s %s %s\" %d %d \"%s\" \"%s\"",
 		r.RemoteAddr,
 		start.Format("[02/Jan/2006:15:04:05 -0700]"),
 		r.Method,
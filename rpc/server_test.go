@@ -7,10 +7,16 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/0xPolygon/cdk-data-availability/tracing"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func Test_ServerHandleRequest(t *testing.T) {
@@ -138,6 +144,49 @@ func Test_ServerHandleRequest(t *testing.T) {
 		require.Equal(t, expectedResponse, respRecorder.Body.String())
 	})
 
+	t.Run("continues the trace of the caller", func(t *testing.T) {
+		recorder := tracetest.NewSpanRecorder()
+		previous := otel.GetTracerProvider()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+		defer otel.SetTracerProvider(previous)
+		_, err := tracing.Init(context.Background(), tracing.Config{})
+		require.NoError(t, err)
+
+		ctx, span := tracing.Start(context.Background(), "caller", trace.SpanKindClient)
+		defer span.End()
+
+		req, err := BuildJsonHTTPRequest(ctx, url, "greeter_traceID")
+		require.NoError(t, err)
+
+		respRecorder := httptest.NewRecorder()
+		server.handle(respRecorder, req)
+
+		var resp Response
+		require.NoError(t, json.Unmarshal(respRecorder.Body.Bytes(), &resp))
+		require.Equal(t, fmt.Sprintf(`"%s"`, span.SpanContext().TraceID()), string(resp.Result))
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		require.Equal(t, "greeter_traceID", spans[0].Name())
+		require.Equal(t, span.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	})
+
+	t.Run("panicking method (error is returned)", func(t *testing.T) {
+		req, err := BuildJsonHTTPRequest(context.Background(), url, "greeter_panic")
+		require.NoError(t, err)
+
+		respRecorder := httptest.NewRecorder()
+		server.handle(respRecorder, req)
+
+		// the panic fails the request only
+		require.Equal(t, http.StatusOK, respRecorder.Code)
+		var resp Response
+		require.NoError(t, json.Unmarshal(respRecorder.Body.Bytes(), &resp))
+		require.NotNil(t, resp.Error)
+		require.Equal(t, DefaultErrorCode, resp.Error.Code)
+		require.Equal(t, "internal error", resp.Error.Message)
+	})
+
 	t.Run("PUT method request (error is returned)", func(t *testing.T) {
 		expectedErr := fmt.Sprintf("method %s not allowed", http.MethodPut)
 
@@ -155,9 +204,77 @@ func Test_ServerHandleRequest(t *testing.T) {
 	})
 }
 
+func Test_ServerMaxMessageSize(t *testing.T) {
+	server := NewServer(Config{MaxMessageSize: 128}, []Service{{Name: "greeter", Service: &greeterService{}}})
+
+	req, err := BuildJsonHTTPRequest(context.Background(), "http://localhost", "greeter_handleReq", "John Doe")
+	require.NoError(t, err)
+	respRecorder := httptest.NewRecorder()
+	server.handle(respRecorder, req)
+	require.Equal(t, http.StatusOK, respRecorder.Result().StatusCode)
+
+	req, err = BuildJsonHTTPRequest(context.Background(), "http://localhost", "greeter_handleReq",
+		strings.Repeat("a", 128))
+	require.NoError(t, err)
+	respRecorder = httptest.NewRecorder()
+	server.handle(respRecorder, req)
+	require.Equal(t, http.StatusInternalServerError, respRecorder.Result().StatusCode)
+	require.Contains(t, respRecorder.Body.String(), "request body too large")
+}
+
+func Test_ServerTooManyArguments(t *testing.T) {
+	server := NewServer(Config{}, []Service{{Name: "greeter", Service: &greeterService{}}})
+
+	call := func(method string, params ...interface{}) *ErrorObject {
+		req, err := BuildJsonHTTPRequest(context.Background(), "http://localhost", method, params...)
+		require.NoError(t, err)
+		respRecorder := httptest.NewRecorder()
+		server.handle(respRecorder, req)
+
+		var resp Response
+		require.NoError(t, json.Unmarshal(respRecorder.Body.Bytes(), &resp))
+		return resp.Error
+	}
+
+	require.Nil(t, call("greeter_handleReq", "John Doe"))
+	require.Equal(t, "too many arguments, want at most 1", call("greeter_handleReq", "John", "Doe").Message)
+
+	// the http request is counted in the arguments allowed, as it always was
+	require.Nil(t, call("greeter_echo", "John Doe"))
+	require.Nil(t, call("greeter_echo", "John", "Doe"))
+	require.Equal(t, "too many arguments, want at most 2", call("greeter_echo", "John", "Doe", "Jr").Message)
+
+	// the context is not
+	require.Nil(t, call("greeter_traceID"))
+	require.Equal(t, "too many arguments, want at most 0", call("greeter_traceID", "John Doe").Message)
+}
+
 type greeterService struct{}
 
 // Mock implementation of a service method
 func (s *greeterService) HandleReq(name string) (interface{}, Error) {
 	return fmt.Sprintf("Hello, %s!", name), nil
 }
+
+// Mock implementation of a service method using the http request
+func (s *greeterService) Echo(_ *http.Request, name string) (interface{}, Error) {
+	return name, nil
+}
+
+// Mock implementation of a service method using the context of the request
+func (s *greeterService) TraceID(ctx context.Context) (interface{}, Error) {
+	return trace.SpanContextFromContext(ctx).TraceID().String(), nil
+}
+
+// Mock implementation of a service method panicking
+func (s *greeterService) Panic() (interface{}, Error) {
+	panic("boom")
+}
+
+func Test_ServerSetMaxRequestsPerIPAndSecond(t *testing.T) {
+	server := NewServer(Config{MaxRequestsPerIPAndSecond: 10}, nil)
+	require.Equal(t, float64(10), server.limiter.GetMax())
+
+	server.SetMaxRequestsPerIPAndSecond(20)
+	require.Equal(t, float64(20), server.limiter.GetMax())
+}
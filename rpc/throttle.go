@@ -0,0 +1,161 @@
+package rpc
+
+import (
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/0xPolygon/cdk-data-availability/metrics"
+)
+
+// remoteIP returns the IP of the address of a peer, the address itself when it has no port
+func remoteIP(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+
+	return host
+}
+
+// ipCounter counts the connections or requests in progress by IP
+type ipCounter struct {
+	lock   sync.Mutex
+	counts map[string]int
+}
+
+func newIPCounter() *ipCounter {
+	return &ipCounter{counts: make(map[string]int)}
+}
+
+// acquire counts one more for the IP, unless max are already counted for it
+func (c *ipCounter) acquire(ip string, max int) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.counts[ip] >= max {
+		return false
+	}
+	c.counts[ip]++
+
+	return true
+}
+
+// release counts one less for the IP
+func (c *ipCounter) release(ip string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.counts[ip] <= 1 {
+		delete(c.counts, ip)
+		return
+	}
+	c.counts[ip]--
+}
+
+// limitListener closes the connections accepted beyond the maximum open at once, overall and by IP
+type limitListener struct {
+	net.Listener
+	maxConns      int
+	maxConnsPerIP int
+
+	lock  sync.Mutex
+	open  int
+	perIP *ipCounter
+}
+
+// limitConns returns the listener limiting the connections accepted by lis, lis itself when there is no limit
+func limitConns(lis net.Listener, maxConns, maxConnsPerIP int) net.Listener {
+	if maxConns <= 0 && maxConnsPerIP <= 0 {
+		return lis
+	}
+
+	return &limitListener{
+		Listener:      lis,
+		maxConns:      maxConns,
+		maxConnsPerIP: maxConnsPerIP,
+		perIP:         newIPCounter(),
+	}
+}
+
+// Accept returns the next connection within the limits, closing the ones beyond them
+func (l *limitListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+
+		if limited, ok := l.admit(conn); ok {
+			return limited, nil
+		}
+		_ = conn.Close()
+	}
+}
+
+// admit returns the connection counted against the limits, or false when it is beyond them
+func (l *limitListener) admit(conn net.Conn) (net.Conn, bool) {
+	l.lock.Lock()
+	if l.maxConns > 0 && l.open >= l.maxConns {
+		l.lock.Unlock()
+		metrics.RPCThrottled(metrics.ThrottleConnections)
+		return nil, false
+	}
+	l.open++
+	l.lock.Unlock()
+
+	ip := remoteIP(conn.RemoteAddr().String())
+	if l.maxConnsPerIP > 0 && !l.perIP.acquire(ip, l.maxConnsPerIP) {
+		l.done("")
+		metrics.RPCThrottled(metrics.ThrottleConnectionsPerIP)
+		return nil, false
+	}
+
+	return &limitedConn{Conn: conn, done: func() { l.done(ip) }}, true
+}
+
+// done releases a connection of the IP, only counted overall when ip is empty
+func (l *limitListener) done(ip string) {
+	l.lock.Lock()
+	l.open--
+	l.lock.Unlock()
+
+	if ip != "" && l.maxConnsPerIP > 0 {
+		l.perIP.release(ip)
+	}
+}
+
+// limitedConn releases its place in the limits once closed
+type limitedConn struct {
+	net.Conn
+	once sync.Once
+	done func()
+}
+
+// Close closes the connection
+func (c *limitedConn) Close() error {
+	err := c.Conn.Close()
+	c.once.Do(c.done)
+	return err
+}
+
+// limitRequestsPerIP answers 429 Too Many Requests to the requests beyond the maximum handled at once
+// for their IP
+func limitRequestsPerIP(next http.Handler, max int) http.Handler {
+	if max <= 0 {
+		return next
+	}
+
+	inFlight := newIPCounter()
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ip := remoteIP(req.RemoteAddr)
+		if !inFlight.acquire(ip, max) {
+			metrics.RPCThrottled(metrics.ThrottleRequestsPerIP)
+			http.Error(w, "too many concurrent requests", http.StatusTooManyRequests)
+			return
+		}
+		defer inFlight.release(ip)
+
+		next.ServeHTTP(w, req)
+	})
+}
@@ -0,0 +1,126 @@
+package rpc
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_limitConns(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxConns      int
+		maxConnsPerIP int
+	}{
+		{
+			name:     "max connections",
+			maxConns: 1,
+		},
+		{
+			name:          "max connections per IP",
+			maxConnsPerIP: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			inner, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+
+			lis := limitConns(inner, tt.maxConns, tt.maxConnsPerIP)
+			defer lis.Close()
+
+			accepted := make(chan net.Conn)
+			go func() {
+				for {
+					conn, err := lis.Accept()
+					if err != nil {
+						close(accepted)
+						return
+					}
+					accepted <- conn
+				}
+			}()
+
+			first, err := net.Dial("tcp", inner.Addr().String())
+			require.NoError(t, err)
+			defer first.Close()
+			firstServer := <-accepted
+
+			// the connection beyond the limit is closed by the server
+			second, err := net.Dial("tcp", inner.Addr().String())
+			require.NoError(t, err)
+			defer second.Close()
+			require.NoError(t, second.SetReadDeadline(time.Now().Add(5*time.Second)))
+			_, err = second.Read(make([]byte, 1))
+			require.Error(t, err)
+			require.False(t, errors.Is(err, os.ErrDeadlineExceeded))
+
+			// the place of a closed connection is released
+			require.NoError(t, firstServer.Close())
+			_ = firstServer.Close()
+			third, err := net.Dial("tcp", inner.Addr().String())
+			require.NoError(t, err)
+			defer third.Close()
+
+			select {
+			case conn := <-accepted:
+				require.NoError(t, conn.Close())
+			case <-time.After(5 * time.Second):
+				t.Fatal("connection not accepted")
+			}
+		})
+	}
+
+	t.Run("no limit", func(t *testing.T) {
+		inner, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer inner.Close()
+
+		require.Equal(t, inner, limitConns(inner, 0, 0))
+	})
+}
+
+func Test_limitRequestsPerIP(t *testing.T) {
+	release := make(chan struct{})
+	handling := make(chan struct{}, 1)
+	handler := limitRequestsPerIP(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		handling <- struct{}{}
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}), 1)
+
+	request := func(remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.RemoteAddr = remoteAddr
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- request("10.0.0.1:1000") }()
+	<-handling
+
+	// another request of the same IP is refused while the first one is handled
+	require.Equal(t, http.StatusTooManyRequests, request("10.0.0.1:1001").Code)
+
+	// the requests of other IPs are not limited
+	go func() { done <- request("10.0.0.2:1000") }()
+	<-handling
+
+	close(release)
+	require.Equal(t, http.StatusOK, (<-done).Code)
+	require.Equal(t, http.StatusOK, (<-done).Code)
+
+	// the place of a handled request is released
+	require.Equal(t, http.StatusOK, request("10.0.0.1:1001").Code)
+}
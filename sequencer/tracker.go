@@ -2,8 +2,10 @@ package sequencer
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/0xPolygon/cdk-data-availability/config"
@@ -11,21 +13,30 @@ import (
 	"github.com/0xPolygon/cdk-data-availability/etherman/smartcontracts/etrog/polygonvalidium"
 	"github.com/0xPolygon/cdk-data-availability/log"
 	"github.com/0xPolygon/cdk-data-availability/pkg/backoff"
+	"github.com/0xPolygon/cdk-data-availability/reporter"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/event"
 )
 
+// logger is the logger of the sequencer component
+var logger = log.WithComponent("sequencer")
+
 const (
-	// maxConnectionRetries is the maximum number of retries to connect to the RPC node before failing.
-	maxConnectionRetries = 5
+	// defaultConnectionRetries is the default maximum number of retries to connect to the RPC node before failing.
+	defaultConnectionRetries = 5
 )
 
+// ErrNoURL is returned when the trusted sequencer has no URL set on L1 to fetch the batches from
+var ErrNoURL = errors.New("the trusted sequencer has no URL")
+
 // Tracker watches the contract for relevant changes to the sequencer
 type Tracker struct {
 	em           etherman.Etherman
 	stop         chan struct{}
 	timeout      time.Duration
 	retry        time.Duration
+	maxRetries   uint
+	maxBackoff   time.Duration
 	addr         common.Address
 	url          string
 	trackChanges bool
@@ -34,26 +45,41 @@ type Tracker struct {
 	wg           sync.WaitGroup
 	lock         sync.Mutex
 	startOnce    sync.Once
+	// subscriptions is the number of L1 event subscriptions currently open
+	subscriptions int32
 }
 
 // NewTracker creates a new Tracker
-func NewTracker(cfg config.L1Config, em etherman.Etherman) *Tracker {
+func NewTracker(cfg config.L1Config, timeouts config.TimeoutsConfig, em etherman.Etherman) *Tracker {
 	pollInterval := time.Minute
 	if cfg.TrackSequencerPollInterval.Seconds() > 0 {
 		pollInterval = cfg.TrackSequencerPollInterval.Duration
 	}
 
+	maxRetries := uint(defaultConnectionRetries)
+	if timeouts.L1SubscriptionRetries > 0 {
+		maxRetries = timeouts.L1SubscriptionRetries
+	}
+
 	return &Tracker{
 		em:           em,
 		stop:         make(chan struct{}),
 		timeout:      cfg.Timeout.Duration,
 		retry:        cfg.RetryPeriod.Duration,
+		maxRetries:   maxRetries,
+		maxBackoff:   timeouts.MaxBackoff.Duration,
 		trackChanges: cfg.TrackSequencer,
 		usePolling:   strings.HasPrefix(cfg.RpcURL, "http"), // If http(s), use polling instead of sockets
 		pollInterval: pollInterval,
 	}
 }
 
+// Subscriptions returns the number of L1 event subscriptions currently open to track the
+// sequencer changes, none when they are polled
+func (st *Tracker) Subscriptions() int {
+	return int(atomic.LoadInt32(&st.subscriptions))
+}
+
 // GetAddr returns the last known address of the Sequencer
 func (st *Tracker) GetAddr() common.Address {
 	st.lock.Lock()
@@ -88,24 +114,24 @@ func (st *Tracker) Start(parentCtx context.Context) {
 
 		addr, err := st.em.TrustedSequencer(ctx)
 		if err != nil {
-			log.Fatalf("failed to get sequencer addr: %v", err)
+			logger.Fatalf("failed to get sequencer addr: %v", err)
 			return
 		}
 
-		log.Infof("current sequencer addr: %s", addr.Hex())
+		logger.Infof("current sequencer addr: %s", addr.Hex())
 		st.setAddr(addr)
 
 		url, err := st.em.TrustedSequencerURL(ctx)
 		if err != nil {
-			log.Fatalf("failed to get sequencer addr: %v", err)
+			logger.Fatalf("failed to get sequencer addr: %v", err)
 			return
 		}
 
-		log.Infof("current sequencer url: %s", url)
+		logger.WithFields(log.FieldMemberURL, url).Info("current sequencer url")
 		st.setUrl(url)
 
 		if st.trackChanges {
-			log.Info("sequencer tracking enabled")
+			logger.Info("sequencer tracking enabled")
 
 			go st.trackAddrChanges(parentCtx)
 			go st.trackUrlChanges(parentCtx)
@@ -114,6 +140,8 @@ func (st *Tracker) Start(parentCtx context.Context) {
 }
 
 func (st *Tracker) trackAddrChanges(ctx context.Context) {
+	defer reporter.Recover()
+
 	addrChan := make(chan common.Address, 1)
 
 	if st.usePolling {
@@ -126,12 +154,12 @@ func (st *Tracker) trackAddrChanges(ctx context.Context) {
 		select {
 		case addr := <-addrChan:
 			if st.GetAddr().Cmp(addr) != 0 {
-				log.Infof("new trusted sequencer address: %v", addr)
+				logger.Infof("new trusted sequencer address: %v", addr)
 				st.setAddr(addr)
 			}
 		case <-ctx.Done():
 			if ctx.Err() != nil && ctx.Err() != context.DeadlineExceeded {
-				log.Warnf("context cancelled: %v", ctx.Err())
+				logger.Warnf("context cancelled: %v", ctx.Err())
 			}
 		case <-st.stop:
 			return
@@ -140,6 +168,8 @@ func (st *Tracker) trackAddrChanges(ctx context.Context) {
 }
 
 func (st *Tracker) subscribeOnAddrChanges(ctx context.Context, addrChan chan<- common.Address) {
+	defer reporter.Recover()
+
 	st.wg.Add(1)
 	defer st.wg.Done()
 
@@ -149,18 +179,20 @@ func (st *Tracker) subscribeOnAddrChanges(ctx context.Context, addrChan chan<- c
 	var sub event.Subscription
 
 	initSubscription := func() {
-		if err := backoff.Exponential(func() (err error) {
+		if err := backoff.ExponentialWithCap(func() (err error) {
 			if sub, err = st.em.WatchSetTrustedSequencer(ctx, events); err != nil {
-				log.Errorf("error subscribing to trusted sequencer event, retrying: %v", err)
+				logger.Errorf("error subscribing to trusted sequencer event, retrying: %v", err)
 			}
 
 			return err
-		}, maxConnectionRetries, st.retry); err != nil {
-			log.Fatalf("failed subscribing to trusted sequencer event: %v. Check ws(s) availability.", err)
+		}, st.maxRetries, st.retry, st.maxBackoff); err != nil {
+			logger.Fatalf("failed subscribing to trusted sequencer event: %v. Check ws(s) availability.", err)
 		}
 	}
 
 	initSubscription()
+	atomic.AddInt32(&st.subscriptions, 1)
+	defer atomic.AddInt32(&st.subscriptions, -1)
 
 	for {
 		select {
@@ -169,7 +201,7 @@ func (st *Tracker) subscribeOnAddrChanges(ctx context.Context, addrChan chan<- c
 		case <-ctx.Done():
 			return
 		case err := <-sub.Err():
-			log.Warnf("subscription error, resubscribing: %v", err)
+			logger.Warnf("subscription error, resubscribing: %v", err)
 			initSubscription()
 		case <-st.stop:
 			if sub != nil {
@@ -181,6 +213,8 @@ func (st *Tracker) subscribeOnAddrChanges(ctx context.Context, addrChan chan<- c
 }
 
 func (st *Tracker) pollAddrChanges(ctx context.Context, addrChan chan<- common.Address) {
+	defer reporter.Recover()
+
 	st.wg.Add(1)
 	defer st.wg.Done()
 
@@ -190,7 +224,7 @@ func (st *Tracker) pollAddrChanges(ctx context.Context, addrChan chan<- common.A
 		case <-ticker.C:
 			addr, err := st.em.TrustedSequencer(ctx)
 			if err != nil {
-				log.Errorf("failed to get sequencer addr: %v", err)
+				logger.Errorf("failed to get sequencer addr: %v", err)
 				break
 			}
 
@@ -208,6 +242,8 @@ func (st *Tracker) pollAddrChanges(ctx context.Context, addrChan chan<- common.A
 }
 
 func (st *Tracker) trackUrlChanges(ctx context.Context) {
+	defer reporter.Recover()
+
 	urlChan := make(chan string, 1)
 
 	if st.usePolling {
@@ -220,12 +256,12 @@ func (st *Tracker) trackUrlChanges(ctx context.Context) {
 		select {
 		case url := <-urlChan:
 			if st.GetUrl() != url {
-				log.Infof("new trusted sequencer url: %v", url)
+				logger.WithFields(log.FieldMemberURL, url).Info("new trusted sequencer url")
 				st.setUrl(url)
 			}
 		case <-ctx.Done():
 			if ctx.Err() != nil && ctx.Err() != context.DeadlineExceeded {
-				log.Warnf("context cancelled: %v", ctx.Err())
+				logger.Warnf("context cancelled: %v", ctx.Err())
 			}
 		case <-st.stop:
 			return
@@ -234,6 +270,8 @@ func (st *Tracker) trackUrlChanges(ctx context.Context) {
 }
 
 func (st *Tracker) subscribeOnUrlChanges(ctx context.Context, urlChan chan<- string) {
+	defer reporter.Recover()
+
 	st.wg.Add(1)
 	defer st.wg.Done()
 
@@ -243,18 +281,20 @@ func (st *Tracker) subscribeOnUrlChanges(ctx context.Context, urlChan chan<- str
 	var sub event.Subscription
 
 	initSubscription := func() {
-		if err := backoff.Exponential(func() (err error) {
+		if err := backoff.ExponentialWithCap(func() (err error) {
 			if sub, err = st.em.WatchSetTrustedSequencerURL(ctx, events); err != nil {
-				log.Errorf("error subscribing to trusted sequencer URL event, retrying: %v", err)
+				logger.Errorf("error subscribing to trusted sequencer URL event, retrying: %v", err)
 			}
 
 			return err
-		}, maxConnectionRetries, st.retry); err != nil {
-			log.Fatalf("failed subscribing to trusted sequencer URL event: %v. Check ws(s) availability.", err)
+		}, st.maxRetries, st.retry, st.maxBackoff); err != nil {
+			logger.Fatalf("failed subscribing to trusted sequencer URL event: %v. Check ws(s) availability.", err)
 		}
 	}
 
 	initSubscription()
+	atomic.AddInt32(&st.subscriptions, 1)
+	defer atomic.AddInt32(&st.subscriptions, -1)
 
 	for {
 		select {
@@ -263,7 +303,7 @@ func (st *Tracker) subscribeOnUrlChanges(ctx context.Context, urlChan chan<- str
 		case <-ctx.Done():
 			return
 		case err := <-sub.Err():
-			log.Warnf("subscription error, resubscribing: %v", err)
+			logger.Warnf("subscription error, resubscribing: %v", err)
 			initSubscription()
 		case <-st.stop:
 			if sub != nil {
@@ -275,6 +315,8 @@ func (st *Tracker) subscribeOnUrlChanges(ctx context.Context, urlChan chan<- str
 }
 
 func (st *Tracker) pollUrlChanges(ctx context.Context, urlChan chan<- string) {
+	defer reporter.Recover()
+
 	st.wg.Add(1)
 	defer st.wg.Done()
 
@@ -284,7 +326,7 @@ func (st *Tracker) pollUrlChanges(ctx context.Context, urlChan chan<- string) {
 		case <-ticker.C:
 			url, err := st.em.TrustedSequencerURL(ctx)
 			if err != nil {
-				log.Errorf("failed to get sequencer URL: %v", err)
+				logger.Errorf("failed to get sequencer URL: %v", err)
 				break
 			}
 
@@ -301,9 +343,14 @@ func (st *Tracker) pollUrlChanges(ctx context.Context, urlChan chan<- string) {
 	}
 }
 
-// GetSequenceBatch returns sequence batch for given batch number
+// GetSequenceBatch returns sequence batch for given batch number, ErrNoURL when the sequencer has no URL
 func (st *Tracker) GetSequenceBatch(ctx context.Context, batchNum uint64) (*SeqBatch, error) {
-	return GetData(ctx, st.GetUrl(), batchNum)
+	url := st.GetUrl()
+	if url == "" {
+		return nil, ErrNoURL
+	}
+
+	return GetData(ctx, url, batchNum)
 }
 
 // Stop stops the SequencerTracker
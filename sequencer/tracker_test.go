@@ -66,7 +66,7 @@ func TestTracker(t *testing.T) {
 			Timeout:        types.NewDuration(time.Second * 10),
 			RetryPeriod:    types.NewDuration(time.Millisecond),
 			TrackSequencer: true,
-		}, etherman)
+		}, config.TimeoutsConfig{}, etherman)
 
 		require.Equal(t, common.Address{}, tracker.GetAddr())
 		require.Empty(t, tracker.GetUrl())
@@ -117,7 +117,7 @@ func TestTracker(t *testing.T) {
 			RetryPeriod:                types.NewDuration(time.Millisecond),
 			TrackSequencerPollInterval: types.NewDuration(time.Second),
 			TrackSequencer:             true,
-		}, etherman)
+		}, config.TimeoutsConfig{}, etherman)
 
 		require.Equal(t, common.Address{}, tracker.GetAddr())
 		require.Empty(t, tracker.GetUrl())
@@ -148,7 +148,7 @@ func TestTracker(t *testing.T) {
 		tracker := sequencer.NewTracker(config.L1Config{
 			Timeout:     types.NewDuration(time.Second * 10),
 			RetryPeriod: types.NewDuration(time.Millisecond),
-		}, etherman)
+		}, config.TimeoutsConfig{}, etherman)
 
 		require.Equal(t, common.Address{}, tracker.GetAddr())
 		require.Empty(t, tracker.GetUrl())
@@ -164,6 +164,20 @@ func TestTracker(t *testing.T) {
 	})
 }
 
+func TestTracker_GetSequenceBatchWithoutURL(t *testing.T) {
+	etherman := mocks.NewEtherman(t)
+	etherman.On("TrustedSequencer", mock.Anything).Return(common.BytesToAddress([]byte("sequencer")), nil)
+	etherman.On("TrustedSequencerURL", mock.Anything).Return("", nil)
+
+	tracker := sequencer.NewTracker(config.L1Config{Timeout: types.Duration{Duration: time.Second}},
+		config.TimeoutsConfig{}, etherman)
+	tracker.Start(context.Background())
+	defer tracker.Stop()
+
+	_, err := tracker.GetSequenceBatch(context.Background(), 1)
+	require.ErrorIs(t, err, sequencer.ErrNoURL)
+}
+
 func eventually(t *testing.T, num int, f func() bool) {
 	t.Helper()
 
@@ -0,0 +1,221 @@
+package admin
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"runtime"
+	"sort"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/rpc"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// APIADMIN is the namespace of the admin service
+	APIADMIN = "admin"
+
+	// rootComponent is the name used to refer to the root logger
+	rootComponent = "root"
+
+	// maxSignAuditEntries is the maximum number of audit log records returned at once
+	maxSignAuditEntries = 1000
+
+	// maxCommitteeChanges is the maximum number of committee changes returned at once
+	maxCommitteeChanges = 1000
+)
+
+// DBPool exposes the statistics of the database connection pool, e.g. *sqlx.DB
+type DBPool interface {
+	Stats() sql.DBStats
+}
+
+// Committee exposes the committee members cached by the synchronizer
+type Committee interface {
+	Committee() []etherman.DataCommitteeMember
+	CommitteeCacheStats() (hits, misses uint64)
+}
+
+// Subscriptions returns the number of subscriptions open by a component
+type Subscriptions func() int
+
+// Endpoints contains implementations for the "admin" RPC endpoints, used to
+// operate the node at runtime
+type Endpoints struct {
+	db            db.DB
+	pool          DBPool
+	committee     Committee
+	subscriptions map[string]Subscriptions
+}
+
+// NewEndpoints returns Endpoints. The pool, the committee and the subscriptions are only
+// used by the introspection endpoints, and can be left nil.
+func NewEndpoints(
+	db db.DB,
+	pool DBPool,
+	committee Committee,
+	subscriptions map[string]Subscriptions,
+) *Endpoints {
+	return &Endpoints{
+		db:            db,
+		pool:          pool,
+		committee:     committee,
+		subscriptions: subscriptions,
+	}
+}
+
+// GetLogLevels returns the log level of the root logger and of every component
+func (a *Endpoints) GetLogLevels() (interface{}, rpc.Error) {
+	return log.Levels(), nil
+}
+
+// SetLogLevel changes the log level of a component (e.g. synchronizer), or the root
+// level when the component is "root". An empty level makes the component log at the
+// root level again.
+func (a *Endpoints) SetLogLevel(component, level string) (interface{}, rpc.Error) {
+	var err error
+	if component == rootComponent {
+		err = log.SetLevel(level)
+	} else {
+		err = log.SetComponentLevel(component, level)
+	}
+	if err != nil {
+		return nil, rpc.NewRPCError(rpc.InvalidParamsErrorCode, fmt.Sprintf("invalid log level %q", level))
+	}
+
+	log.Infof("log level of %s set to %q", component, level)
+	return log.Levels(), nil
+}
+
+// ListSignAudit exports the audit log of the requests to sign a sequence, oldest first, starting
+// at the record with the given ID. At most limit records are returned (1000 if zero or above),
+// the next page starts at the ID following the last record returned.
+func (a *Endpoints) ListSignAudit(ctx context.Context, fromID, limit uint64) (interface{}, rpc.Error) {
+	if limit == 0 || limit > maxSignAuditEntries {
+		limit = maxSignAuditEntries
+	}
+
+	entries, err := a.db.ListSignAuditEntries(ctx, fromID, uint(limit))
+	if err != nil {
+		log.Errorf("failed to list the sign audit log: %v", err)
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to list the sign audit log")
+	}
+
+	return entries, nil
+}
+
+// ListCommitteeChanges returns the changes of the committee members observed on L1, oldest first,
+// starting at the record with the given ID. At most limit records are returned (1000 if zero or above),
+// the next page starts at the ID following the last record returned.
+func (a *Endpoints) ListCommitteeChanges(ctx context.Context, fromID, limit uint64) (interface{}, rpc.Error) {
+	if limit == 0 || limit > maxCommitteeChanges {
+		limit = maxCommitteeChanges
+	}
+
+	changes, err := a.db.ListCommitteeChanges(ctx, fromID, uint(limit))
+	if err != nil {
+		log.Errorf("failed to list the committee changes: %v", err)
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to list the committee changes")
+	}
+
+	return changes, nil
+}
+
+// DBPoolInfo are the statistics of the database connection pool
+type DBPoolInfo struct {
+	MaxOpenConnections int    `json:"max_open_connections"`
+	OpenConnections    int    `json:"open_connections"`
+	InUse              int    `json:"in_use"`
+	Idle               int    `json:"idle"`
+	WaitCount          int64  `json:"wait_count"`
+	WaitDuration       string `json:"wait_duration"`
+}
+
+// CacheInfo are the statistics of a cache of the node
+type CacheInfo struct {
+	Size    int     `json:"size"`
+	Hits    uint64  `json:"hits"`
+	Misses  uint64  `json:"misses"`
+	HitRate float64 `json:"hit_rate"`
+}
+
+// RuntimeInfo is the state of a running node, as returned by admin_getRuntimeInfo
+type RuntimeInfo struct {
+	Goroutines      int                  `json:"goroutines"`
+	OpenConnections int                  `json:"open_connections"`
+	DBPool          *DBPoolInfo          `json:"db_pool,omitempty"`
+	Caches          map[string]CacheInfo `json:"caches"`
+	Subscriptions   map[string]int       `json:"subscriptions"`
+}
+
+// GetRuntimeInfo returns the number of goroutines, the statistics of the database connection
+// pool and of the caches, and the subscriptions open by each component
+func (a *Endpoints) GetRuntimeInfo() (interface{}, rpc.Error) {
+	info := RuntimeInfo{
+		Goroutines:      runtime.NumGoroutine(),
+		OpenConnections: rpc.OpenConnections(),
+		Caches:          make(map[string]CacheInfo),
+		Subscriptions:   make(map[string]int, len(a.subscriptions)),
+	}
+
+	if a.pool != nil {
+		stats := a.pool.Stats()
+		info.DBPool = &DBPoolInfo{
+			MaxOpenConnections: stats.MaxOpenConnections,
+			OpenConnections:    stats.OpenConnections,
+			InUse:              stats.InUse,
+			Idle:               stats.Idle,
+			WaitCount:          stats.WaitCount,
+			WaitDuration:       stats.WaitDuration.String(),
+		}
+	}
+
+	if a.committee != nil {
+		hits, misses := a.committee.CommitteeCacheStats()
+		info.Caches["committee"] = newCacheInfo(len(a.committee.Committee()), hits, misses)
+	}
+
+	for component, subscriptions := range a.subscriptions {
+		info.Subscriptions[component] = subscriptions()
+	}
+
+	return info, nil
+}
+
+// CommitteeMember is a committee member cached by the synchronizer
+type CommitteeMember struct {
+	Addr common.Address `json:"addr"`
+	URL  string         `json:"url"`
+}
+
+// GetCommittee returns the committee members cached by the synchronizer to resolve the
+// missing batches, the members failing to return data are evicted until it is resolved again
+func (a *Endpoints) GetCommittee() (interface{}, rpc.Error) {
+	if a.committee == nil {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "the committee is not available")
+	}
+
+	cached := a.committee.Committee()
+	members := make([]CommitteeMember, 0, len(cached))
+	for _, m := range cached {
+		members = append(members, CommitteeMember{Addr: m.Addr, URL: m.URL})
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return bytes.Compare(members[i].Addr.Bytes(), members[j].Addr.Bytes()) < 0
+	})
+
+	return members, nil
+}
+
+func newCacheInfo(size int, hits, misses uint64) CacheInfo {
+	info := CacheInfo{Size: size, Hits: hits, Misses: misses}
+	if total := hits + misses; total > 0 {
+		info.HitRate = float64(hits) / float64(total)
+	}
+
+	return info
+}
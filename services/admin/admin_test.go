@@ -0,0 +1,240 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpoints_SetLogLevel(t *testing.T) {
+	log.WithComponent("admin-test")
+
+	tests := []struct {
+		name           string
+		component      string
+		level          string
+		expectedLevels map[string]string
+		expectedError  string
+	}{
+		{
+			name:      "set root level",
+			component: "root",
+			level:     "warn",
+			expectedLevels: map[string]string{
+				"root":       "warn",
+				"admin-test": "warn",
+			},
+		},
+		{
+			name:      "set component level",
+			component: "admin-test",
+			level:     "debug",
+			expectedLevels: map[string]string{
+				"root":       "warn",
+				"admin-test": "debug",
+			},
+		},
+		{
+			name:      "reset component level",
+			component: "admin-test",
+			level:     "",
+			expectedLevels: map[string]string{
+				"root":       "warn",
+				"admin-test": "warn",
+			},
+		},
+		{
+			name:          "invalid level",
+			component:     "admin-test",
+			level:         "verbose",
+			expectedError: `invalid log level "verbose"`,
+		},
+	}
+
+	a := NewEndpoints(nil, nil, nil, nil)
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := a.SetLogLevel(tt.component, tt.level)
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+
+			levels, err := a.GetLogLevels()
+			require.NoError(t, err)
+			require.Subset(t, levels, tt.expectedLevels)
+		})
+	}
+}
+
+func TestEndpoints_ListSignAudit(t *testing.T) {
+	entries := []types.SignAuditEntry{{
+		ID:           7,
+		Requester:    common.HexToAddress("0x1"),
+		SequenceHash: common.HexToHash("0x2"),
+		BatchCount:   2,
+		Decision:     types.SignDecisionSigned,
+		Signature:    []byte{1, 2, 3},
+	}}
+
+	tests := []struct {
+		name          string
+		fromID        uint64
+		limit         uint64
+		expectedLimit uint
+		returnErr     error
+		expectedError string
+	}{
+		{
+			name:          "records returned",
+			fromID:        7,
+			limit:         10,
+			expectedLimit: 10,
+		},
+		{
+			name:          "limit capped",
+			limit:         5000,
+			expectedLimit: maxSignAuditEntries,
+		},
+		{
+			name:          "default limit",
+			expectedLimit: maxSignAuditEntries,
+		},
+		{
+			name:          "db error",
+			expectedLimit: maxSignAuditEntries,
+			returnErr:     errors.New("test error"),
+			expectedError: "failed to list the sign audit log",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			dbMock := mocks.NewDB(t)
+			dbMock.On("ListSignAuditEntries", mock.Anything, tt.fromID, tt.expectedLimit).
+				Return(entries, tt.returnErr).Once()
+
+			actual, err := NewEndpoints(dbMock, nil, nil, nil).ListSignAudit(context.Background(), tt.fromID, tt.limit)
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, entries, actual)
+		})
+	}
+}
+
+func TestEndpoints_ListCommitteeChanges(t *testing.T) {
+	changes := []types.CommitteeChange{
+		{ID: 1, BlockNumber: 10, Kind: types.CommitteeMemberAdded, Member: common.HexToAddress("0x1")},
+	}
+
+	dbMock := mocks.NewDB(t)
+	dbMock.On("ListCommitteeChanges", mock.Anything, uint64(1), uint(maxCommitteeChanges)).
+		Return(changes, nil).Once()
+	dbMock.On("ListCommitteeChanges", mock.Anything, uint64(2), uint(10)).
+		Return(nil, errors.New("test error")).Once()
+
+	a := NewEndpoints(dbMock, nil, nil, nil)
+
+	actual, err := a.ListCommitteeChanges(context.Background(), 1, 0)
+	require.NoError(t, err)
+	require.Equal(t, changes, actual)
+
+	_, err = a.ListCommitteeChanges(context.Background(), 2, 10)
+	require.EqualError(t, err, "failed to list the committee changes")
+}
+
+type fakePool sql.DBStats
+
+func (p fakePool) Stats() sql.DBStats {
+	return sql.DBStats(p)
+}
+
+type fakeCommittee struct {
+	members      []etherman.DataCommitteeMember
+	hits, misses uint64
+}
+
+func (c fakeCommittee) Committee() []etherman.DataCommitteeMember {
+	return c.members
+}
+
+func (c fakeCommittee) CommitteeCacheStats() (uint64, uint64) {
+	return c.hits, c.misses
+}
+
+func TestEndpoints_GetRuntimeInfo(t *testing.T) {
+	committee := fakeCommittee{
+		members: []etherman.DataCommitteeMember{
+			{Addr: common.HexToAddress("0x02"), URL: "http://member-2"},
+			{Addr: common.HexToAddress("0x01"), URL: "http://member-1"},
+		},
+		hits:   3,
+		misses: 1,
+	}
+	pool := fakePool{MaxOpenConnections: 10, OpenConnections: 4, InUse: 1, Idle: 3, WaitDuration: time.Second}
+
+	a := NewEndpoints(nil, pool, committee, map[string]Subscriptions{
+		"sequencer": func() int { return 2 },
+	})
+
+	result, rpcErr := a.GetRuntimeInfo()
+	require.Nil(t, rpcErr)
+
+	info, ok := result.(RuntimeInfo)
+	require.True(t, ok)
+	require.Positive(t, info.Goroutines)
+	require.Equal(t, &DBPoolInfo{
+		MaxOpenConnections: 10,
+		OpenConnections:    4,
+		InUse:              1,
+		Idle:               3,
+		WaitDuration:       "1s",
+	}, info.DBPool)
+	require.Equal(t, map[string]CacheInfo{
+		"committee": {Size: 2, Hits: 3, Misses: 1, HitRate: 0.75},
+	}, info.Caches)
+	require.Equal(t, map[string]int{"sequencer": 2}, info.Subscriptions)
+
+	result, rpcErr = a.GetCommittee()
+	require.Nil(t, rpcErr)
+	require.Equal(t, []CommitteeMember{
+		{Addr: common.HexToAddress("0x01"), URL: "http://member-1"},
+		{Addr: common.HexToAddress("0x02"), URL: "http://member-2"},
+	}, result)
+}
+
+func TestEndpoints_GetRuntimeInfoWithoutSources(t *testing.T) {
+	a := NewEndpoints(nil, nil, nil, nil)
+
+	result, rpcErr := a.GetRuntimeInfo()
+	require.Nil(t, rpcErr)
+
+	info, ok := result.(RuntimeInfo)
+	require.True(t, ok)
+	require.Nil(t, info.DBPool)
+	require.Empty(t, info.Caches)
+
+	_, rpcErr = a.GetCommittee()
+	require.EqualError(t, rpcErr, "the committee is not available")
+}
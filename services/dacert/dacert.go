@@ -0,0 +1,97 @@
+package dacert
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/rpc"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// logger is the logger of the dacert service
+var logger = log.WithComponent("dacert")
+
+// APIDACERT is the namespace of the dacert service
+const APIDACERT = "dacert"
+
+// Endpoints contains implementations for the "dacert" RPC endpoints
+type Endpoints struct {
+	db         db.DB
+	privateKey *ecdsa.PrivateKey
+	etherman   etherman.Etherman
+}
+
+// NewEndpoints returns Endpoints, the availability is not signed when pk is nil, e.g. by a mirror
+func NewEndpoints(db db.DB, pk *ecdsa.PrivateKey, em etherman.Etherman) *Endpoints {
+	return &Endpoints{
+		db:         db,
+		privateKey: pk,
+		etherman:   em,
+	}
+}
+
+// SignAvailability returns the signature of the node attesting it holds the data of the given hash,
+// to be assembled into an availability certificate by the coordinator
+func (d *Endpoints) SignAvailability(ctx context.Context, hash types.ArgHash) (interface{}, rpc.Error) {
+	if d.privateKey == nil {
+		return "0x0", rpc.NewRPCError(rpc.DefaultErrorCode, "the node is a mirror, it does not sign")
+	}
+
+	if _, err := d.db.GetOffChainData(ctx, hash.Hash()); err != nil {
+		if errors.Is(err, db.ErrStateNotSynchronized) {
+			return "0x0", rpc.NewRPCError(rpc.DefaultErrorCode, "the data is not available")
+		}
+
+		logger.WithFields(log.FieldKeyHash, hash.Hash().Hex()).
+			Errorf("failed to get the offchain requested data from the DB: %v", err)
+		return "0x0", rpc.NewRPCError(rpc.DefaultErrorCode, "failed to get the requested data")
+	}
+
+	signature, err := types.SignAvailability(hash.Hash(), d.privateKey)
+	if err != nil {
+		return "0x0", rpc.NewRPCError(rpc.DefaultErrorCode, "failed to sign: %v", err)
+	}
+
+	return types.ArgBytes(signature), nil
+}
+
+// GetCertificate returns the availability certificate of the data of the given hash,
+// as assembled by the coordinator
+func (d *Endpoints) GetCertificate(ctx context.Context, hash types.ArgHash) (interface{}, rpc.Error) {
+	certificate, err := d.db.GetCertificate(ctx, hash.Hash())
+	if errors.Is(err, db.ErrStateNotSynchronized) {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "the data is not certified")
+	}
+	if err != nil {
+		logger.WithFields(log.FieldKeyHash, hash.Hash().Hex()).
+			Errorf("failed to get the certificate from the DB: %v", err)
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to get the certificate")
+	}
+
+	return certificate, nil
+}
+
+// VerifyCertificate checks the certificate is signed by enough members of the current committee on L1
+func (d *Endpoints) VerifyCertificate(certificate types.Certificate) (interface{}, rpc.Error) {
+	committee, err := d.etherman.GetCurrentDataCommittee()
+	if err != nil {
+		logger.Errorf("failed to get the committee: %v", err)
+		return false, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to get the committee")
+	}
+
+	members := make([]common.Address, len(committee.Members))
+	for i, member := range committee.Members {
+		members[i] = member.Addr
+	}
+
+	if err = certificate.Verify(members, committee.RequiredSignatures); err != nil {
+		return false, rpc.NewRPCError(rpc.InvalidRequestErrorCode, "invalid certificate: %v", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,130 @@
+package dacert
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpoints_SignAvailability(t *testing.T) {
+	t.Parallel()
+
+	pk, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	dataHash := crypto.Keccak256Hash([]byte("batch data"))
+
+	tests := []struct {
+		name  string
+		dbErr error
+		err   string
+	}{
+		{
+			name: "data held",
+		},
+		{
+			name:  "data not held",
+			dbErr: db.ErrStateNotSynchronized,
+			err:   "the data is not available",
+		},
+		{
+			name:  "db returns error",
+			dbErr: errors.New("test error"),
+			err:   "failed to get the requested data",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dbMock := mocks.NewDB(t)
+			dbMock.On("GetOffChainData", context.Background(), dataHash).
+				Return(&types.OffChainData{Key: dataHash, Value: []byte("batch data")}, tt.dbErr)
+
+			d := NewEndpoints(dbMock, pk, nil)
+
+			result, rpcErr := d.SignAvailability(context.Background(), types.ArgHash(dataHash))
+			if tt.err != "" {
+				require.EqualError(t, rpcErr, tt.err)
+				return
+			}
+
+			require.Nil(t, rpcErr)
+			signer, err := types.AvailabilitySigner(dataHash, result.(types.ArgBytes))
+			require.NoError(t, err)
+			require.Equal(t, crypto.PubkeyToAddress(pk.PublicKey), signer)
+		})
+	}
+}
+
+func TestEndpoints_SignAvailability_Mirror(t *testing.T) {
+	t.Parallel()
+
+	d := NewEndpoints(mocks.NewDB(t), nil, nil)
+
+	_, rpcErr := d.SignAvailability(context.Background(), types.ArgHash(common.HexToHash("0x1")))
+	require.EqualError(t, rpcErr, "the node is a mirror, it does not sign")
+}
+
+func TestEndpoints_GetCertificate(t *testing.T) {
+	t.Parallel()
+
+	dataHash := common.HexToHash("0x1")
+	certificate := &types.Certificate{DataHash: dataHash, Threshold: 1}
+
+	dbMock := mocks.NewDB(t)
+	dbMock.On("GetCertificate", context.Background(), dataHash).Return(certificate, nil).Once()
+	dbMock.On("GetCertificate", context.Background(), dataHash).Return(nil, db.ErrStateNotSynchronized).Once()
+
+	d := NewEndpoints(dbMock, nil, nil)
+
+	result, rpcErr := d.GetCertificate(context.Background(), types.ArgHash(dataHash))
+	require.Nil(t, rpcErr)
+	require.Equal(t, certificate, result)
+
+	_, rpcErr = d.GetCertificate(context.Background(), types.ArgHash(dataHash))
+	require.EqualError(t, rpcErr, "the data is not certified")
+}
+
+func TestEndpoints_VerifyCertificate(t *testing.T) {
+	t.Parallel()
+
+	dataHash := crypto.Keccak256Hash([]byte("batch data"))
+
+	keys := make([]*ecdsa.PrivateKey, 3)
+	committee := &etherman.DataCommittee{RequiredSignatures: 2}
+	signatures := make([]types.ArgBytes, len(keys))
+	for i := range keys {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		keys[i] = key
+		committee.Members = append(committee.Members, etherman.DataCommitteeMember{
+			Addr: crypto.PubkeyToAddress(key.PublicKey),
+		})
+		signatures[i], err = types.SignAvailability(dataHash, key)
+		require.NoError(t, err)
+	}
+
+	em := mocks.NewEtherman(t)
+	em.On("GetCurrentDataCommittee").Return(committee, nil)
+
+	d := NewEndpoints(nil, nil, em)
+
+	result, rpcErr := d.VerifyCertificate(types.Certificate{DataHash: dataHash, Signatures: signatures[:2]})
+	require.Nil(t, rpcErr)
+	require.Equal(t, true, result)
+
+	_, rpcErr = d.VerifyCertificate(types.Certificate{DataHash: dataHash, Signatures: signatures[:1]})
+	require.EqualError(t, rpcErr, "invalid certificate: 1 signatures, 2 required")
+}
@@ -0,0 +1,101 @@
+package das
+
+import (
+	"context"
+	"errors"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/rpc"
+	"github.com/0xPolygon/cdk-data-availability/types"
+)
+
+// logger is the logger of the das service
+var logger = log.WithComponent("das")
+
+const (
+	// APIDAS is the namespace of the data availability sampling service
+	APIDAS = "das"
+
+	// maxSamples is the maximum number of shards sampled in a request
+	maxSamples = 64
+)
+
+// Endpoints contains implementations for the "das" RPC endpoints, serving the erasure coded shards
+// of the stored values so that light clients can sample the availability of the data
+type Endpoints struct {
+	db db.DB
+}
+
+// NewEndpoints returns Endpoints
+func NewEndpoints(db db.DB) *Endpoints {
+	return &Endpoints{
+		db: db,
+	}
+}
+
+// GetCommitment returns the commitment to the shards of the value of the given hash, which tells the
+// Merkle root of the shards and which of them the member holds
+func (e *Endpoints) GetCommitment(ctx context.Context, hash types.ArgHash) (interface{}, rpc.Error) {
+	commitment, err := e.getCommitment(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return commitment, nil
+}
+
+// SampleShards returns the shards of the given indices of the value of the given hash, along with
+// their Merkle proofs against the root of the commitment
+func (e *Endpoints) SampleShards(
+	ctx context.Context,
+	hash types.ArgHash,
+	indices []types.ArgUint64,
+) (interface{}, rpc.Error) {
+	if len(indices) == 0 {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "no shard requested")
+	}
+	if len(indices) > maxSamples {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode,
+			"too many shards requested, at most %d are allowed", maxSamples)
+	}
+
+	commitment, rpcErr := e.getCommitment(ctx, hash)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	list := make([]uint, len(indices))
+	for i, index := range indices {
+		if uint64(index) >= uint64(commitment.DataShards+commitment.ParityShards) {
+			return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "shard %d out of range", index)
+		}
+		if uint(index)%commitment.Members != commitment.MemberIndex {
+			return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "the shard %d is not held by this member", index)
+		}
+		list[i] = uint(index)
+	}
+
+	shards, err := e.db.GetShards(ctx, hash.Hash(), list)
+	if err != nil {
+		logger.WithFields(log.FieldKeyHash, hash.Hash().Hex()).Errorf("failed to get the shards from the DB: %v", err)
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to get the requested shards")
+	}
+
+	return shards, nil
+}
+
+// getCommitment returns the commitment to the shards of the value of the given hash
+func (e *Endpoints) getCommitment(ctx context.Context, hash types.ArgHash) (*types.ShardCommitment, rpc.Error) {
+	commitment, err := e.db.GetShardCommitment(ctx, hash.Hash())
+	if errors.Is(err, db.ErrStateNotSynchronized) {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "the data is not sharded")
+	}
+	if err != nil {
+		logger.WithFields(log.FieldKeyHash, hash.Hash().Hex()).
+			Errorf("failed to get the shard commitment from the DB: %v", err)
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to get the requested commitment")
+	}
+
+	return commitment, nil
+}
@@ -0,0 +1,129 @@
+package das
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpoints_GetCommitment(t *testing.T) {
+	t.Parallel()
+
+	key := common.HexToHash("0x1")
+
+	tests := []struct {
+		name       string
+		commitment *types.ShardCommitment
+		dbErr      error
+		err        string
+	}{
+		{
+			name:       "commitment",
+			commitment: &types.ShardCommitment{Key: key, DataShards: 2, ParityShards: 2, Members: 1},
+		},
+		{
+			name:  "data not sharded",
+			dbErr: db.ErrStateNotSynchronized,
+			err:   "the data is not sharded",
+		},
+		{
+			name:  "db returns error",
+			dbErr: errors.New("test error"),
+			err:   "failed to get the requested commitment",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dbMock := mocks.NewDB(t)
+			dbMock.On("GetShardCommitment", mock.Anything, key).Return(tt.commitment, tt.dbErr).Once()
+
+			actual, err := NewEndpoints(dbMock).GetCommitment(context.Background(), types.ArgHash(key))
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.commitment, actual)
+		})
+	}
+}
+
+func TestEndpoints_SampleShards(t *testing.T) {
+	t.Parallel()
+
+	key := common.HexToHash("0x1")
+	// the second member of two holds the odd shards
+	commitment := &types.ShardCommitment{Key: key, DataShards: 4, ParityShards: 4, MemberIndex: 1, Members: 2}
+
+	tests := []struct {
+		name    string
+		indices []types.ArgUint64
+		shards  []types.Shard
+		dbErr   error
+		err     string
+	}{
+		{
+			name:    "shards held",
+			indices: []types.ArgUint64{1, 7},
+			shards:  []types.Shard{{Key: key, Index: 1}, {Key: key, Index: 7}},
+		},
+		{
+			name:    "shard not held",
+			indices: []types.ArgUint64{1, 2},
+			err:     "the shard 2 is not held by this member",
+		},
+		{
+			name:    "shard out of range",
+			indices: []types.ArgUint64{9},
+			err:     "shard 9 out of range",
+		},
+		{
+			name:    "db returns error",
+			indices: []types.ArgUint64{3},
+			dbErr:   errors.New("test error"),
+			err:     "failed to get the requested shards",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dbMock := mocks.NewDB(t)
+			dbMock.On("GetShardCommitment", mock.Anything, key).Return(commitment, nil).Once()
+			if tt.shards != nil || tt.dbErr != nil {
+				dbMock.On("GetShards", mock.Anything, key, mock.Anything).Return(tt.shards, tt.dbErr).Once()
+			}
+
+			actual, err := NewEndpoints(dbMock).SampleShards(context.Background(), types.ArgHash(key), tt.indices)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.shards, actual)
+		})
+	}
+
+	t.Run("too many shards requested", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewEndpoints(mocks.NewDB(t)).
+			SampleShards(context.Background(), types.ArgHash(key), make([]types.ArgUint64, maxSamples+1))
+		require.EqualError(t, err, "too many shards requested, at most 64 are allowed")
+	})
+}
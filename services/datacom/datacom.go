@@ -4,13 +4,26 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"fmt"
+	"time"
 
+	"github.com/0xPolygon/cdk-data-availability/config"
 	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/gossip"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/metrics"
+	"github.com/0xPolygon/cdk-data-availability/notifier"
+	"github.com/0xPolygon/cdk-data-availability/pkg/replay"
+	"github.com/0xPolygon/cdk-data-availability/publisher"
 	"github.com/0xPolygon/cdk-data-availability/rpc"
 	"github.com/0xPolygon/cdk-data-availability/sequencer"
 	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/0xPolygon/cdk-data-availability/webhook"
+	"github.com/ethereum/go-ethereum/common"
 )
 
+// logger is the logger of the datacom service
+var logger = log.WithComponent("datacom")
+
 // APIDATACOM is the namespace of the datacom service
 const APIDATACOM = "datacom"
 
@@ -19,42 +32,178 @@ type Endpoints struct {
 	db               db.DB
 	privateKey       *ecdsa.PrivateKey
 	sequencerTracker *sequencer.Tracker
+	replay           *replay.Guard
+	limits           config.LimitsConfig
+	durability       config.DurabilityConfig
+	archivers        []*publisher.Worker
 }
 
-// NewEndpoints returns Endpoints
-func NewEndpoints(db db.DB, pk *ecdsa.PrivateKey, st *sequencer.Tracker) *Endpoints {
+// NewEndpoints returns Endpoints. The archivers are the workers publishing to the enabled backends,
+// the data of a sequence being published to each before signing it at the archived durability level.
+func NewEndpoints(
+	db db.DB,
+	pk *ecdsa.PrivateKey,
+	st *sequencer.Tracker,
+	guard *replay.Guard,
+	limits config.LimitsConfig,
+	durability config.DurabilityConfig,
+	archivers []*publisher.Worker,
+) *Endpoints {
 	return &Endpoints{
 		db:               db,
 		privateKey:       pk,
 		sequencerTracker: st,
+		replay:           guard,
+		limits:           limits,
+		durability:       durability,
+		archivers:        archivers,
 	}
 }
 
-// SignSequence generates the accumulated input hash aka accInputHash of the sequence and sign it.
-// After storing the data that will be sent hashed to the contract, it returns the signature.
-// This endpoint is only accessible to the sequencer
-func (d *Endpoints) SignSequence(signedSequence types.SignedSequence) (interface{}, rpc.Error) {
+// SignSequence stores the data of a sequence of the trusted sequencer and returns its signature of the sequence.
+// The signature is only returned once the data has reached the configured durability level and the request has
+// been recorded in the audit log, a request being signed once within the replay window.
+func (d *Endpoints) SignSequence(ctx context.Context, signedSequence types.SignedSequence) (interface{}, rpc.Error) {
+	entry := types.SignAuditEntry{
+		SequenceHash: common.BytesToHash(signedSequence.Sequence.HashToSign()),
+		BatchCount:   uint64(len(signedSequence.Sequence)),
+		Decision:     types.SignDecisionFailed,
+		Timestamp:    time.Now().UTC(),
+	}
+
 	// Verify that the request comes from the sequencer
 	sender, err := signedSequence.Signer()
 	if err != nil {
+		metrics.SignSequence(metrics.ResultError)
+		_ = d.audit(ctx, entry)
 		return "0x0", rpc.NewRPCError(rpc.DefaultErrorCode, "failed to verify sender")
 	}
+	entry.Requester = sender
 
-	if sender != d.sequencerTracker.GetAddr() {
+	// Until the trusted sequencer is read from L1 no request can be authorized
+	trusted := d.sequencerTracker.GetAddr()
+	if trusted == (common.Address{}) {
+		metrics.SignSequence(metrics.ResultError)
+		_ = d.audit(ctx, entry)
+		return "0x0", rpc.NewRPCError(rpc.DefaultErrorCode, "the trusted sequencer is not known yet")
+	}
+
+	if sender != trusted {
+		metrics.SignSequence(metrics.SignResultUnauthorized)
+		entry.Decision = types.SignDecisionUnauthorized
+		_ = d.audit(ctx, entry)
+		notifier.SigningRejected(sender.Hex(), "not the trusted sequencer")
 		return "0x0", rpc.NewRPCError(rpc.DefaultErrorCode, "unauthorized")
 	}
 
+	// Refuse the requests replayed, after the sender is known so that others can't fill the cache
+	requestHash := common.BytesToHash(signedSequence.RequestHash())
+	if err = d.replay.Check(requestHash, uint64(signedSequence.Timestamp)); err != nil {
+		metrics.SignSequence(metrics.SignResultReplayed)
+		entry.Decision = types.SignDecisionReplayed
+		_ = d.audit(ctx, entry)
+		notifier.SigningRejected(sender.Hex(), err.Error())
+		return "0x0", rpc.NewRPCError(rpc.DefaultErrorCode, "replayed request: %v", err)
+	}
+	// the request can be retried until it is signed, concurrent replays being refused meanwhile
+	signed := false
+	defer func() {
+		if !signed {
+			d.replay.Release(requestHash)
+		}
+	}()
+
+	// Refuse to store more data than allowed
+	if err = signedSequence.Sequence.CheckSize(d.limits.MaxValueSize, d.limits.MaxSequenceSize); err != nil {
+		metrics.SignSequence(metrics.ResultError)
+		_ = d.audit(ctx, entry)
+		return "0x0", rpc.NewRPCError(rpc.DefaultErrorCode, "sequence too large: %v", err)
+	}
+
 	// Store off-chain data by hash (hash(L2Data): L2Data)
-	if err = d.db.StoreOffChainData(context.Background(), signedSequence.Sequence.OffChainData()); err != nil {
+	data := signedSequence.Sequence.OffChainData()
+	if err = d.store(ctx, data); err != nil {
+		metrics.SignSequence(metrics.ResultError)
+		_ = d.audit(ctx, entry)
 		return "0x0", rpc.NewRPCError(rpc.DefaultErrorCode,
 			fmt.Errorf("failed to store offchain data. Error: %w", err).Error())
 	}
+	provenance := types.NewProvenance(data, types.ProvenanceSequencerPush, sender.Hex())
+	if err = d.db.StoreProvenance(ctx, provenance); err != nil {
+		metrics.SignSequence(metrics.ResultError)
+		_ = d.audit(ctx, entry)
+		return "0x0", rpc.NewRPCError(rpc.DefaultErrorCode,
+			fmt.Errorf("failed to store the provenance of the offchain data. Error: %w", err).Error())
+	}
+	announce(data)
+	webhook.DataStored(data)
 
 	// Sign
 	signedSequenceByMe, err := signedSequence.Sequence.Sign(d.privateKey)
 	if err != nil {
+		metrics.SignSequence(metrics.ResultError)
+		_ = d.audit(ctx, entry)
 		return "0x0", rpc.NewRPCError(rpc.DefaultErrorCode, fmt.Errorf("failed to sign. Error: %w", err).Error())
 	}
 
+	entry.Decision = types.SignDecisionSigned
+	entry.Signature = signedSequenceByMe.Signature
+	if err = d.audit(ctx, entry); err != nil {
+		metrics.SignSequence(metrics.ResultError)
+		return "0x0", rpc.NewRPCError(rpc.DefaultErrorCode, "failed to record the signature in the audit log")
+	}
+
+	signed = true
+	metrics.SignSequence(metrics.SignResultSigned)
+	webhook.SequenceSigned(entry)
 	return signedSequenceByMe.Signature, nil
 }
+
+// store stores the data as durably as the configured level requires
+func (d *Endpoints) store(ctx context.Context, data []types.OffChainData) error {
+	var err error
+	switch d.durability.Level {
+	case config.DurabilityFlushed, config.DurabilityArchived:
+		err = d.db.StoreOffChainDataDurably(ctx, data, db.SynchronousCommitLocal)
+	case config.DurabilityReplicated:
+		err = d.db.StoreOffChainDataDurably(ctx, data, db.SynchronousCommitRemoteApply)
+	default:
+		err = d.db.StoreOffChainData(ctx, data)
+	}
+	if err != nil || d.durability.Level != config.DurabilityArchived {
+		return err
+	}
+
+	// the publications are recorded, so the workers don't publish the values again
+	for _, archiver := range d.archivers {
+		for _, value := range data {
+			if err = archiver.Publish(ctx, value); err != nil {
+				return fmt.Errorf("failed to publish %s to %s: %w", value.Key.Hex(), archiver.Name(), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// announce announces the keys of the stored data to the other committee members
+func announce(data []types.OffChainData) {
+	keys := make([]common.Hash, len(data))
+	for i, d := range data {
+		keys[i] = d.Key
+	}
+	gossip.Announce(keys)
+}
+
+// audit appends the entry to the audit log, logging the error if it could not be stored.
+// Rejected requests are answered even if they could not be recorded.
+func (d *Endpoints) audit(ctx context.Context, entry types.SignAuditEntry) error {
+	err := d.db.StoreSignAuditEntry(ctx, entry)
+	if err != nil {
+		logger.WithFields(log.FieldKeyHash, entry.SequenceHash.Hex()).
+			Errorf("failed to store the %s request of %s in the audit log: %v",
+				entry.Decision, entry.Requester.Hex(), err)
+	}
+
+	return err
+}
@@ -9,7 +9,10 @@ import (
 
 	"github.com/0xPolygon/cdk-data-availability/config"
 	cfgTypes "github.com/0xPolygon/cdk-data-availability/config/types"
+	"github.com/0xPolygon/cdk-data-availability/db"
 	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/pkg/replay"
+	"github.com/0xPolygon/cdk-data-availability/publisher"
 	"github.com/0xPolygon/cdk-data-availability/sequencer"
 	"github.com/0xPolygon/cdk-data-availability/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -23,8 +26,11 @@ func TestDataCom_SignSequence(t *testing.T) {
 
 	type testConfig struct {
 		storeOffChainDataReturns []interface{}
+		auditDecision            types.SignDecision
+		storeSignAuditReturns    error
 		sender                   *ecdsa.PrivateKey
 		signer                   *ecdsa.PrivateKey
+		maxValueSize             uint64
 		expectedError            string
 	}
 
@@ -54,6 +60,20 @@ func TestDataCom_SignSequence(t *testing.T) {
 			dbMock.On("StoreOffChainData", mock.Anything, sequence.OffChainData()).Return(
 				cfg.storeOffChainDataReturns...).Once()
 		}
+		if len(cfg.storeOffChainDataReturns) > 0 && cfg.storeOffChainDataReturns[0] == nil {
+			dbMock.On("StoreProvenance", mock.Anything, mock.MatchedBy(func(provenance []types.Provenance) bool {
+				return len(provenance) == len(sequence) &&
+					provenance[0].Source == types.ProvenanceSequencerPush &&
+					provenance[0].Origin == crypto.PubkeyToAddress(otherPrivateKey.PublicKey).Hex()
+			})).Return(nil).Once()
+		}
+
+		dbMock.On("StoreSignAuditEntry", mock.Anything, mock.MatchedBy(func(e types.SignAuditEntry) bool {
+			return e.Decision == cfg.auditDecision &&
+				e.SequenceHash == common.BytesToHash(sequence.HashToSign()) &&
+				e.BatchCount == uint64(len(sequence)) &&
+				(e.Decision == types.SignDecisionSigned) == (len(e.Signature) > 0)
+		})).Return(cfg.storeSignAuditReturns).Once()
 
 		ethermanMock := mocks.NewEtherman(t)
 
@@ -63,7 +83,7 @@ func TestDataCom_SignSequence(t *testing.T) {
 		sqr := sequencer.NewTracker(config.L1Config{
 			Timeout:     cfgTypes.Duration{Duration: time.Minute},
 			RetryPeriod: cfgTypes.Duration{Duration: time.Second},
-		}, ethermanMock)
+		}, config.TimeoutsConfig{}, ethermanMock)
 
 		sqr.Start(context.Background())
 
@@ -81,9 +101,15 @@ func TestDataCom_SignSequence(t *testing.T) {
 			signer = cfg.signer
 		}
 
-		dce := NewEndpoints(dbMock, signer, sqr)
+		limits := config.LimitsConfig{MaxValueSize: 1 << 20, MaxSequenceSize: 1 << 20}
+		if cfg.maxValueSize > 0 {
+			limits.MaxValueSize = cfg.maxValueSize
+		}
 
-		sig, err := dce.SignSequence(*signedSequence)
+		dce := NewEndpoints(dbMock, signer, sqr, replay.New(time.Minute, false), limits,
+			config.DurabilityConfig{Level: config.DurabilityCommitted}, nil)
+
+		sig, err := dce.SignSequence(context.Background(), *signedSequence)
 		if cfg.expectedError != "" {
 			require.ErrorContains(t, err, cfg.expectedError)
 		} else {
@@ -101,6 +127,7 @@ func TestDataCom_SignSequence(t *testing.T) {
 		t.Parallel()
 
 		testFn(t, testConfig{
+			auditDecision: types.SignDecisionFailed,
 			expectedError: "failed to verify sender",
 		})
 	})
@@ -110,6 +137,7 @@ func TestDataCom_SignSequence(t *testing.T) {
 
 		testFn(t, testConfig{
 			sender:        privateKey,
+			auditDecision: types.SignDecisionUnauthorized,
 			expectedError: "unauthorized",
 		})
 	})
@@ -119,15 +147,28 @@ func TestDataCom_SignSequence(t *testing.T) {
 
 		testFn(t, testConfig{
 			sender:        privateKey,
+			auditDecision: types.SignDecisionUnauthorized,
 			expectedError: "unauthorized",
 		})
 	})
 
+	t.Run("Sequence too large", func(t *testing.T) {
+		t.Parallel()
+
+		testFn(t, testConfig{
+			sender:        otherPrivateKey,
+			maxValueSize:  1,
+			auditDecision: types.SignDecisionFailed,
+			expectedError: "sequence too large",
+		})
+	})
+
 	t.Run("Fail to store off chain data", func(t *testing.T) {
 		t.Parallel()
 
 		testFn(t, testConfig{
 			sender:                   otherPrivateKey,
+			auditDecision:            types.SignDecisionFailed,
 			expectedError:            "failed to store offchain data",
 			storeOffChainDataReturns: []interface{}{errors.New("error")},
 		})
@@ -145,16 +186,284 @@ func TestDataCom_SignSequence(t *testing.T) {
 			sender:                   otherPrivateKey,
 			signer:                   key,
 			storeOffChainDataReturns: []interface{}{nil},
+			auditDecision:            types.SignDecisionFailed,
 			expectedError:            "failed to sign",
 		})
 	})
 
+	t.Run("Fail to record the signature in the audit log", func(t *testing.T) {
+		t.Parallel()
+
+		testFn(t, testConfig{
+			sender:                   otherPrivateKey,
+			storeOffChainDataReturns: []interface{}{nil},
+			auditDecision:            types.SignDecisionSigned,
+			storeSignAuditReturns:    errors.New("error"),
+			expectedError:            "failed to record the signature in the audit log",
+		})
+	})
+
 	t.Run("Happy path - sequence signed", func(t *testing.T) {
 		t.Parallel()
 
 		testFn(t, testConfig{
 			sender:                   otherPrivateKey,
 			storeOffChainDataReturns: []interface{}{nil},
+			auditDecision:            types.SignDecisionSigned,
 		})
 	})
 }
+
+func TestDataCom_SignSequenceUnknownSequencer(t *testing.T) {
+	t.Parallel()
+
+	sequence := types.Sequence{types.ArgBytes([]byte{0, 1})}
+
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	signedSequence, err := sequence.Sign(privateKey)
+	require.NoError(t, err)
+
+	dbMock := mocks.NewDB(t)
+	dbMock.On("StoreSignAuditEntry", mock.Anything, mock.MatchedBy(func(e types.SignAuditEntry) bool {
+		return e.Decision == types.SignDecisionFailed
+	})).Return(nil).Once()
+
+	// the tracker is not started, the trusted sequencer not being read from L1 yet
+	sqr := sequencer.NewTracker(config.L1Config{
+		Timeout:     cfgTypes.Duration{Duration: time.Minute},
+		RetryPeriod: cfgTypes.Duration{Duration: time.Second},
+	}, config.TimeoutsConfig{}, mocks.NewEtherman(t))
+
+	dce := NewEndpoints(dbMock, privateKey, sqr, replay.New(time.Minute, false), config.LimitsConfig{},
+		config.DurabilityConfig{}, nil)
+	_, rpcErr := dce.SignSequence(context.Background(), *signedSequence)
+	require.ErrorContains(t, rpcErr, "the trusted sequencer is not known yet")
+}
+
+func TestDataCom_SignSequenceReplayed(t *testing.T) {
+	t.Parallel()
+
+	sequence := types.Sequence{types.ArgBytes([]byte{0, 1})}
+
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sequencerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	signedSequence, err := sequence.SignRequest(sequencerKey, uint64(time.Now().Unix()))
+	require.NoError(t, err)
+
+	dbMock := mocks.NewDB(t)
+	dbMock.On("StoreOffChainData", mock.Anything, sequence.OffChainData()).Return(nil).Once()
+	dbMock.On("StoreProvenance", mock.Anything, mock.Anything).Return(nil).Once()
+	dbMock.On("StoreSignAuditEntry", mock.Anything, mock.MatchedBy(func(e types.SignAuditEntry) bool {
+		return e.Decision == types.SignDecisionSigned
+	})).Return(nil).Once()
+	dbMock.On("StoreSignAuditEntry", mock.Anything, mock.MatchedBy(func(e types.SignAuditEntry) bool {
+		return e.Decision == types.SignDecisionReplayed
+	})).Return(nil).Once()
+
+	ethermanMock := mocks.NewEtherman(t)
+	ethermanMock.On("TrustedSequencer", mock.Anything).Return(crypto.PubkeyToAddress(sequencerKey.PublicKey), nil).Once()
+	ethermanMock.On("TrustedSequencerURL", mock.Anything).Return("http://some-url", nil).Once()
+
+	sqr := sequencer.NewTracker(config.L1Config{
+		Timeout:     cfgTypes.Duration{Duration: time.Minute},
+		RetryPeriod: cfgTypes.Duration{Duration: time.Second},
+	}, config.TimeoutsConfig{}, ethermanMock)
+	sqr.Start(context.Background())
+	defer sqr.Stop()
+
+	dce := NewEndpoints(dbMock, privateKey, sqr, replay.New(time.Minute, true),
+		config.LimitsConfig{MaxValueSize: 1 << 20, MaxSequenceSize: 1 << 20}, config.DurabilityConfig{}, nil)
+
+	sig, rpcErr := dce.SignSequence(context.Background(), *signedSequence)
+	require.Nil(t, rpcErr)
+	require.NotEmpty(t, sig)
+
+	// the node signature is over the accumulated input hash only, as verified on L1
+	signedByMe, err := sequence.Sign(privateKey)
+	require.NoError(t, err)
+	require.Equal(t, signedByMe.Signature, sig)
+
+	_, rpcErr = dce.SignSequence(context.Background(), *signedSequence)
+	require.ErrorContains(t, rpcErr, "replayed request")
+}
+
+func TestDataCom_SignSequenceRetried(t *testing.T) {
+	t.Parallel()
+
+	sequence := types.Sequence{types.ArgBytes([]byte{0, 1})}
+
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sequencerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	signedSequence, err := sequence.SignRequest(sequencerKey, uint64(time.Now().Unix()))
+	require.NoError(t, err)
+
+	dbMock := mocks.NewDB(t)
+	dbMock.On("StoreOffChainData", mock.Anything, sequence.OffChainData()).Return(errors.New("error")).Once()
+	dbMock.On("StoreOffChainData", mock.Anything, sequence.OffChainData()).Return(nil).Once()
+	dbMock.On("StoreProvenance", mock.Anything, mock.Anything).Return(nil).Once()
+	dbMock.On("StoreSignAuditEntry", mock.Anything, mock.MatchedBy(func(e types.SignAuditEntry) bool {
+		return e.Decision == types.SignDecisionFailed
+	})).Return(nil).Once()
+	dbMock.On("StoreSignAuditEntry", mock.Anything, mock.MatchedBy(func(e types.SignAuditEntry) bool {
+		return e.Decision == types.SignDecisionSigned
+	})).Return(nil).Once()
+	dbMock.On("StoreSignAuditEntry", mock.Anything, mock.MatchedBy(func(e types.SignAuditEntry) bool {
+		return e.Decision == types.SignDecisionReplayed
+	})).Return(nil).Once()
+
+	ethermanMock := mocks.NewEtherman(t)
+	ethermanMock.On("TrustedSequencer", mock.Anything).Return(crypto.PubkeyToAddress(sequencerKey.PublicKey), nil).Once()
+	ethermanMock.On("TrustedSequencerURL", mock.Anything).Return("http://some-url", nil).Once()
+
+	sqr := sequencer.NewTracker(config.L1Config{
+		Timeout:     cfgTypes.Duration{Duration: time.Minute},
+		RetryPeriod: cfgTypes.Duration{Duration: time.Second},
+	}, config.TimeoutsConfig{}, ethermanMock)
+	sqr.Start(context.Background())
+	defer sqr.Stop()
+
+	dce := NewEndpoints(dbMock, privateKey, sqr, replay.New(time.Minute, true),
+		config.LimitsConfig{MaxValueSize: 1 << 20, MaxSequenceSize: 1 << 20}, config.DurabilityConfig{}, nil)
+
+	// the request failed to be stored is signed once retried
+	_, rpcErr := dce.SignSequence(context.Background(), *signedSequence)
+	require.ErrorContains(t, rpcErr, "failed to store offchain data")
+
+	sig, rpcErr := dce.SignSequence(context.Background(), *signedSequence)
+	require.Nil(t, rpcErr)
+	require.NotEmpty(t, sig)
+
+	_, rpcErr = dce.SignSequence(context.Background(), *signedSequence)
+	require.ErrorContains(t, rpcErr, "replayed request")
+}
+
+// archive is a publisher backend failing the publications when err is set
+type archive struct {
+	err error
+}
+
+func (a *archive) Name() string {
+	return "archive"
+}
+
+func (a *archive) Publish(context.Context, types.OffChainData) (string, error) {
+	return "ref", a.err
+}
+
+func TestDataCom_SignSequenceDurability(t *testing.T) {
+	t.Parallel()
+
+	sequence := types.Sequence{types.ArgBytes([]byte{0, 1})}
+
+	testTable := []struct {
+		name              string
+		level             string
+		synchronousCommit string
+		storeErr          error
+		publishErr        error
+		expectedError     string
+	}{
+		{
+			name:              "flushed",
+			level:             config.DurabilityFlushed,
+			synchronousCommit: db.SynchronousCommitLocal,
+		},
+		{
+			name:              "replicated",
+			level:             config.DurabilityReplicated,
+			synchronousCommit: db.SynchronousCommitRemoteApply,
+		},
+		{
+			name:              "not replicated",
+			level:             config.DurabilityReplicated,
+			synchronousCommit: db.SynchronousCommitRemoteApply,
+			storeErr:          errors.New("canceling the wait for synchronous replication"),
+			expectedError:     "failed to store offchain data",
+		},
+		{
+			name:              "archived",
+			level:             config.DurabilityArchived,
+			synchronousCommit: db.SynchronousCommitLocal,
+		},
+		{
+			name:              "not archived",
+			level:             config.DurabilityArchived,
+			synchronousCommit: db.SynchronousCommitLocal,
+			publishErr:        errors.New("bucket unavailable"),
+			expectedError:     "failed to publish",
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			privateKey, err := crypto.GenerateKey()
+			require.NoError(t, err)
+			sequencerKey, err := crypto.GenerateKey()
+			require.NoError(t, err)
+
+			signedSequence, err := sequence.Sign(sequencerKey)
+			require.NoError(t, err)
+
+			dbMock := mocks.NewDB(t)
+			dbMock.On("StoreOffChainDataDurably", mock.Anything, sequence.OffChainData(), tt.synchronousCommit).
+				Return(tt.storeErr).Once()
+			decision := types.SignDecisionFailed
+			if tt.expectedError == "" {
+				decision = types.SignDecisionSigned
+				dbMock.On("StoreProvenance", mock.Anything, mock.Anything).Return(nil).Once()
+			}
+			if tt.level == config.DurabilityArchived && tt.publishErr == nil {
+				dbMock.On("StorePublication", mock.Anything, mock.MatchedBy(func(p types.Publication) bool {
+					return p.Key == sequence.OffChainData()[0].Key && p.Backend == "archive" && p.Reference == "ref"
+				})).Return(nil).Once()
+			}
+			dbMock.On("StoreSignAuditEntry", mock.Anything, mock.MatchedBy(func(e types.SignAuditEntry) bool {
+				return e.Decision == decision
+			})).Return(nil).Once()
+
+			ethermanMock := mocks.NewEtherman(t)
+			ethermanMock.On("TrustedSequencer", mock.Anything).
+				Return(crypto.PubkeyToAddress(sequencerKey.PublicKey), nil).Once()
+			ethermanMock.On("TrustedSequencerURL", mock.Anything).Return("http://some-url", nil).Once()
+
+			sqr := sequencer.NewTracker(config.L1Config{
+				Timeout:     cfgTypes.Duration{Duration: time.Minute},
+				RetryPeriod: cfgTypes.Duration{Duration: time.Second},
+			}, config.TimeoutsConfig{}, ethermanMock)
+			sqr.Start(context.Background())
+			defer sqr.Stop()
+
+			var archivers []*publisher.Worker
+			if tt.level == config.DurabilityArchived {
+				archivers = append(archivers, publisher.NewWorker(publisher.Config{
+					Timeout: cfgTypes.NewDuration(time.Second),
+				}, dbMock, &archive{err: tt.publishErr}))
+			}
+
+			dce := NewEndpoints(dbMock, privateKey, sqr, replay.New(time.Minute, false),
+				config.LimitsConfig{MaxValueSize: 1 << 20, MaxSequenceSize: 1 << 20},
+				config.DurabilityConfig{Level: tt.level}, archivers)
+
+			sig, rpcErr := dce.SignSequence(context.Background(), *signedSequence)
+			if tt.expectedError != "" {
+				require.ErrorContains(t, rpcErr, tt.expectedError)
+				require.Equal(t, "0x0", sig)
+			} else {
+				require.Nil(t, rpcErr)
+				require.NotEmpty(t, sig)
+			}
+		})
+	}
+}
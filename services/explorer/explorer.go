@@ -0,0 +1,89 @@
+package explorer
+
+import (
+	"context"
+	"errors"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/rpc"
+	"github.com/0xPolygon/cdk-data-availability/types"
+)
+
+// logger is the logger of the explorer service
+var logger = log.WithComponent("explorer")
+
+const (
+	// APIEXPLORER is the namespace of the explorer service
+	APIEXPLORER = "explorer"
+
+	// maxBatchValues is the maximum number of values returned for a batch
+	maxBatchValues = 100
+)
+
+// Endpoints contains implementations for the "explorer" RPC endpoints, returning the stored values
+// decoded so that block explorers of validium chains can show the contents of the batches
+type Endpoints struct {
+	db db.DB
+}
+
+// NewEndpoints returns Endpoints
+func NewEndpoints(db db.DB) *Endpoints {
+	return &Endpoints{
+		db: db,
+	}
+}
+
+// GetDecodedData returns the value of the given hash along with its decoded L2 blocks and transactions
+func (e *Endpoints) GetDecodedData(ctx context.Context, hash types.ArgHash) (interface{}, rpc.Error) {
+	data, err := e.db.GetOffChainData(ctx, hash.Hash())
+	if errors.Is(err, db.ErrStateNotSynchronized) {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "the data is not available")
+	}
+	if err != nil {
+		logger.WithFields(log.FieldKeyHash, hash.Hash().Hex()).
+			Errorf("failed to get the offchain requested data from the DB: %v", err)
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to get the requested data")
+	}
+
+	decoded, err := decode(*data)
+	if err != nil {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "the data is not the L2 data of a batch: %v", err)
+	}
+
+	return decoded, nil
+}
+
+// GetBatch returns the values stored for the given batch number, decoded
+func (e *Endpoints) GetBatch(ctx context.Context, number types.ArgUint64) (interface{}, rpc.Error) {
+	list, err := e.db.ListOffChainDataByBatches(ctx, uint64(number), uint64(number), maxBatchValues)
+	if err != nil {
+		logger.Errorf("failed to list the offchain data of the batch %d from the DB: %v", number, err)
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to get the requested data")
+	}
+
+	values := make([]*types.DecodedOffChainData, len(list))
+	for i, data := range list {
+		if values[i], err = decode(data); err != nil {
+			return nil, rpc.NewRPCError(rpc.DefaultErrorCode,
+				"the data %s is not the L2 data of a batch: %v", data.Key.Hex(), err)
+		}
+	}
+
+	return values, nil
+}
+
+// decode decodes the L2 data of the stored value
+func decode(data types.OffChainData) (*types.DecodedOffChainData, error) {
+	contents, err := types.DecodeBatch(data.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.DecodedOffChainData{
+		Key:           data.Key,
+		BatchNum:      types.ArgUint64(data.BatchNum),
+		Size:          len(data.Value),
+		BatchContents: *contents,
+	}, nil
+}
@@ -0,0 +1,100 @@
+package explorer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// emptyBlock is the L2 data of a batch with a single L2 block without transactions
+var emptyBlock = []byte{0x0b, 0, 0, 0, 2, 0, 0, 0, 1}
+
+func TestEndpoints_GetDecodedData(t *testing.T) {
+	t.Parallel()
+
+	key := common.HexToHash("0x1")
+
+	tests := []struct {
+		name     string
+		data     *types.OffChainData
+		dbErr    error
+		expected interface{}
+		err      string
+	}{
+		{
+			name: "decoded data",
+			data: &types.OffChainData{Key: key, Value: emptyBlock, BatchNum: 4},
+			expected: &types.DecodedOffChainData{
+				Key:      key,
+				BatchNum: 4,
+				Size:     len(emptyBlock),
+				BatchContents: types.BatchContents{
+					Blocks: []types.L2Block{
+						{DeltaTimestamp: 2, L1InfoTreeIndex: 1, Transactions: []types.L2Transaction{}},
+					},
+				},
+			},
+		},
+		{
+			name:  "data not available",
+			dbErr: db.ErrStateNotSynchronized,
+			err:   "the data is not available",
+		},
+		{
+			name:  "db returns error",
+			dbErr: errors.New("test error"),
+			err:   "failed to get the requested data",
+		},
+		{
+			name: "not the L2 data of a batch",
+			data: &types.OffChainData{Key: key, Value: []byte("not a batch")},
+			err:  "the data is not the L2 data of a batch: invalid transaction at byte 0: not an RLP list",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dbMock := mocks.NewDB(t)
+			dbMock.On("GetOffChainData", mock.Anything, key).Return(tt.data, tt.dbErr).Once()
+
+			actual, err := NewEndpoints(dbMock).GetDecodedData(context.Background(), types.ArgHash(key))
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestEndpoints_GetBatch(t *testing.T) {
+	t.Parallel()
+
+	dbMock := mocks.NewDB(t)
+	dbMock.On("ListOffChainDataByBatches", mock.Anything, uint64(4), uint64(4), uint(maxBatchValues)).
+		Return([]types.OffChainData{
+			{Key: common.HexToHash("0x1"), Value: emptyBlock, BatchNum: 4},
+			{Key: common.HexToHash("0x2"), Value: nil, BatchNum: 4},
+		}, nil).Once()
+
+	actual, err := NewEndpoints(dbMock).GetBatch(context.Background(), 4)
+	require.NoError(t, err)
+
+	values, ok := actual.([]*types.DecodedOffChainData)
+	require.True(t, ok)
+	require.Len(t, values, 2)
+	require.Len(t, values[0].Blocks, 1)
+	require.Empty(t, values[1].Blocks)
+}
@@ -5,43 +5,65 @@ import (
 	"time"
 
 	dataavailability "github.com/0xPolygon/cdk-data-availability"
+	"github.com/0xPolygon/cdk-data-availability/crosscheck"
 	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/diagnostics"
+	"github.com/0xPolygon/cdk-data-availability/health"
 	"github.com/0xPolygon/cdk-data-availability/log"
 	"github.com/0xPolygon/cdk-data-availability/rpc"
 	"github.com/0xPolygon/cdk-data-availability/synchronizer"
 	"github.com/0xPolygon/cdk-data-availability/types"
 )
 
+// logger is the logger of the status service
+var logger = log.WithComponent("status")
+
 // APISTATUS is the namespace of the status service
 const APISTATUS = "status"
 
 // Endpoints contains implementations for the "status" RPC endpoints
 type Endpoints struct {
-	db        db.DB
-	startTime time.Time
+	db          db.DB
+	checker     *health.Checker
+	diagnostics *diagnostics.Runner
+	crossCheck  *crosscheck.Verifier
+	startTime   time.Time
 }
 
-// NewEndpoints returns Endpoints
-func NewEndpoints(db db.DB) *Endpoints {
+// NewEndpoints returns Endpoints, reporting the readiness of the dependencies verified by the checker,
+// the results of the self-diagnostics and of the cross-check of the stored keys, which are disabled when
+// the runner or the verifier are nil
+func NewEndpoints(
+	db db.DB,
+	checker *health.Checker,
+	diagnostics *diagnostics.Runner,
+	crossCheck *crosscheck.Verifier,
+) *Endpoints {
+	if checker == nil {
+		checker = health.NewChecker(0)
+	}
+
 	return &Endpoints{
-		db:        db,
-		startTime: time.Now(),
+		db:          db,
+		checker:     checker,
+		diagnostics: diagnostics,
+		crossCheck:  crossCheck,
+		startTime:   time.Now(),
 	}
 }
 
 // GetStatus returns the status of the service
-func (s *Endpoints) GetStatus() (interface{}, rpc.Error) {
-	ctx := context.Background()
+func (s *Endpoints) GetStatus(ctx context.Context) (interface{}, rpc.Error) {
 	uptime := time.Since(s.startTime).String()
 
 	rowCount, err := s.db.CountOffchainData(ctx)
 	if err != nil {
-		log.Errorf("failed to get the key count from the offchain_data table: %v", err)
+		logger.Errorf("failed to get the key count from the offchain_data table: %v", err)
 	}
 
 	backfillProgress, err := s.db.GetLastProcessedBlock(ctx, string(synchronizer.L1SyncTask))
 	if err != nil {
-		log.Errorf("failed to get last block processed by the synchronizer: %v", err)
+		logger.Errorf("failed to get last block processed by the synchronizer: %v", err)
 	}
 
 	return types.DACStatus{
@@ -51,3 +73,46 @@ func (s *Endpoints) GetStatus() (interface{}, rpc.Error) {
 		BackfillProgress: backfillProgress,
 	}, nil
 }
+
+// GetReadiness verifies each dependency of the node independently (L1 node, contracts, database
+// and signer), and returns whether the node is ready along with the status of every dependency
+func (s *Endpoints) GetReadiness(ctx context.Context) (interface{}, rpc.Error) {
+	report := s.checker.Run(ctx)
+	if !report.Ready {
+		for _, component := range report.Components {
+			if component.Status != health.StatusReady {
+				logger.Warnf("%s not ready: %s", component.Name, component.Error)
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// GetDiagnostics returns the report of the last self-diagnostics run
+func (s *Endpoints) GetDiagnostics() (interface{}, rpc.Error) {
+	if s.diagnostics == nil {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "self-diagnostics are disabled")
+	}
+
+	report, ok := s.diagnostics.Last()
+	if !ok {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "self-diagnostics not run yet")
+	}
+
+	return report, nil
+}
+
+// GetCrossCheck returns the report of the last cross-check of the stored keys against L1
+func (s *Endpoints) GetCrossCheck() (interface{}, rpc.Error) {
+	if s.crossCheck == nil {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "cross-check is disabled")
+	}
+
+	report, ok := s.crossCheck.Last()
+	if !ok {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "cross-check not run yet")
+	}
+
+	return report, nil
+}
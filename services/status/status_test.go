@@ -1,9 +1,15 @@
 package status
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/crosscheck"
+	"github.com/0xPolygon/cdk-data-availability/diagnostics"
+	"github.com/0xPolygon/cdk-data-availability/health"
 	"github.com/0xPolygon/cdk-data-availability/mocks"
 	"github.com/0xPolygon/cdk-data-availability/types"
 	"github.com/stretchr/testify/mock"
@@ -52,9 +58,9 @@ func TestEndpoints_GetStatus(t *testing.T) {
 			dbMock.On("GetLastProcessedBlock", mock.Anything, mock.Anything).
 				Return(tt.getLastProcessedBlock, tt.getLastProcessedBlockErr)
 
-			statusEndpoints := NewEndpoints(dbMock)
+			statusEndpoints := NewEndpoints(dbMock, nil, nil, nil)
 
-			actual, err := statusEndpoints.GetStatus()
+			actual, err := statusEndpoints.GetStatus(context.Background())
 
 			if tt.expectedError != nil {
 				require.Error(t, err)
@@ -73,3 +79,45 @@ func TestEndpoints_GetStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestEndpoints_GetReadiness(t *testing.T) {
+	t.Parallel()
+
+	checker := health.NewChecker(time.Second,
+		health.Check{Name: "db", Run: func(context.Context) error { return nil }},
+		health.Check{Name: "l1", Run: func(context.Context) error { return errors.New("connection refused") }},
+	)
+
+	actual, err := NewEndpoints(mocks.NewDB(t), checker, nil, nil).GetReadiness(context.Background())
+	require.NoError(t, err)
+
+	report, ok := actual.(health.Report)
+	require.True(t, ok, "actual is not of type health.Report")
+	require.False(t, report.Ready)
+	require.Len(t, report.Components, 2)
+	require.Equal(t, health.StatusReady, report.Components[0].Status)
+	require.Equal(t, health.StatusNotReady, report.Components[1].Status)
+	require.Equal(t, "connection refused", report.Components[1].Error)
+}
+
+func TestEndpoints_GetDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewEndpoints(mocks.NewDB(t), nil, nil, nil).GetDiagnostics()
+	require.EqualError(t, err, "self-diagnostics are disabled")
+
+	runner := diagnostics.New(diagnostics.Config{}, mocks.NewDB(t), "http://127.0.0.1:0", nil)
+	_, err = NewEndpoints(mocks.NewDB(t), nil, runner, nil).GetDiagnostics()
+	require.EqualError(t, err, "self-diagnostics not run yet")
+}
+
+func TestEndpoints_GetCrossCheck(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewEndpoints(mocks.NewDB(t), nil, nil, nil).GetCrossCheck()
+	require.EqualError(t, err, "cross-check is disabled")
+
+	verifier := crosscheck.New(config.CrossCheckConfig{}, config.L1Config{}, mocks.NewDB(t), mocks.NewEtherman(t), nil)
+	_, err = NewEndpoints(mocks.NewDB(t), nil, nil, verifier).GetCrossCheck()
+	require.EqualError(t, err, "cross-check not run yet")
+}
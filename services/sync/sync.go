@@ -2,14 +2,20 @@ package sync
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"errors"
 
 	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/gossip"
 	"github.com/0xPolygon/cdk-data-availability/log"
 	"github.com/0xPolygon/cdk-data-availability/rpc"
 	"github.com/0xPolygon/cdk-data-availability/types"
 	"github.com/ethereum/go-ethereum/common"
 )
 
+// logger is the logger of the sync service
+var logger = log.WithComponent("sync")
+
 const (
 	// APISYNC  is the namespace of the sync service
 	APISYNC = "sync"
@@ -20,31 +26,59 @@ const (
 
 // Endpoints contains implementations for the "zkevm" RPC endpoints
 type Endpoints struct {
-	db db.DB
+	db         db.DB
+	privateKey *ecdsa.PrivateKey
 }
 
-// NewEndpoints returns Endpoints
-func NewEndpoints(db db.DB) *Endpoints {
+// NewEndpoints returns Endpoints, the data served is not signed when pk is nil, e.g. by a mirror
+func NewEndpoints(db db.DB, pk *ecdsa.PrivateKey) *Endpoints {
 	return &Endpoints{
-		db: db,
+		db:         db,
+		privateKey: pk,
 	}
 }
 
 // GetOffChainData returns the image of the given hash
-func (z *Endpoints) GetOffChainData(hash types.ArgHash) (interface{}, rpc.Error) {
-	data, err := z.db.GetOffChainData(context.Background(), hash.Hash())
+func (z *Endpoints) GetOffChainData(ctx context.Context, hash types.ArgHash) (interface{}, rpc.Error) {
+	data, err := z.db.GetOffChainData(ctx, hash.Hash())
 	if err != nil {
-		log.Errorf("failed to get the offchain requested data from the DB: %v", err)
+		logger.WithFields(log.FieldKeyHash, hash.Hash().Hex()).
+			Errorf("failed to get the offchain requested data from the DB: %v", err)
 		return "0x0", rpc.NewRPCError(rpc.DefaultErrorCode, "failed to get the requested data")
 	}
 
 	return types.ArgBytes(data.Value), nil
 }
 
+// GetSignedOffChainData returns the image of the given hash along with the signature of the node over the
+// hash, which binds the node to the data it serves to the other members
+func (z *Endpoints) GetSignedOffChainData(ctx context.Context, hash types.ArgHash) (interface{}, rpc.Error) {
+	if z.privateKey == nil {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "the node is a mirror, it does not sign")
+	}
+
+	data, err := z.db.GetOffChainData(ctx, hash.Hash())
+	if errors.Is(err, db.ErrStateNotSynchronized) {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "the data is not available")
+	}
+	if err != nil {
+		logger.WithFields(log.FieldKeyHash, hash.Hash().Hex()).
+			Errorf("failed to get the offchain requested data from the DB: %v", err)
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to get the requested data")
+	}
+
+	signature, err := types.SignServed(hash.Hash(), z.privateKey)
+	if err != nil {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to sign: %v", err)
+	}
+
+	return types.SignedOffChainData{Value: data.Value, Signature: signature}, nil
+}
+
 // ListOffChainData returns the list of images of the given hashes
-func (z *Endpoints) ListOffChainData(hashes []types.ArgHash) (interface{}, rpc.Error) {
+func (z *Endpoints) ListOffChainData(ctx context.Context, hashes []types.ArgHash) (interface{}, rpc.Error) {
 	if len(hashes) > maxListHashes {
-		log.Errorf("too many hashes requested in ListOffChainData: %d", len(hashes))
+		logger.Errorf("too many hashes requested in ListOffChainData: %d", len(hashes))
 		return "0x0", rpc.NewRPCError(rpc.InvalidRequestErrorCode, "too many hashes requested")
 	}
 
@@ -53,9 +87,9 @@ func (z *Endpoints) ListOffChainData(hashes []types.ArgHash) (interface{}, rpc.E
 		keys[i] = hash.Hash()
 	}
 
-	list, err := z.db.ListOffChainData(context.Background(), keys)
+	list, err := z.db.ListOffChainData(ctx, keys)
 	if err != nil {
-		log.Errorf("failed to list the requested data from the DB: %v", err)
+		logger.Errorf("failed to list the requested data from the DB: %v", err)
 		return "0x0", rpc.NewRPCError(rpc.DefaultErrorCode, "failed to list the requested data")
 	}
 
@@ -66,3 +100,127 @@ func (z *Endpoints) ListOffChainData(hashes []types.ArgHash) (interface{}, rpc.E
 
 	return listMap, nil
 }
+
+// ListOffChainDataPage returns up to limit stored values whose key follows the given key, ordered by
+// key. Every value is listed by requesting the page after the last key of the previous one, starting
+// from the zero hash, until a page is shorter than the limit.
+func (z *Endpoints) ListOffChainDataPage(
+	ctx context.Context,
+	after types.ArgHash,
+	limit types.ArgUint64,
+) (interface{}, rpc.Error) {
+	if limit == 0 || limit > maxListHashes {
+		return nil, rpc.NewRPCError(rpc.InvalidRequestErrorCode, "the limit must be between 1 and %d", maxListHashes)
+	}
+
+	list, err := z.db.ListOffChainDataPage(ctx, after.Hash(), uint(limit))
+	if err != nil {
+		logger.Errorf("failed to list the data after %s from the DB: %v", after.Hash().Hex(), err)
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to list the requested data")
+	}
+
+	entries := make([]types.OffChainDataEntry, len(list))
+	for i, data := range list {
+		entries[i] = types.OffChainDataEntry{
+			Key:      data.Key,
+			Value:    data.Value,
+			BatchNum: types.ArgUint64(data.BatchNum),
+		}
+	}
+
+	return entries, nil
+}
+
+// GetPublication returns the reference of the value of the given hash in an external storage
+// backend, e.g. the certificate of the value dispersed to EigenDA
+func (z *Endpoints) GetPublication(ctx context.Context, hash types.ArgHash, backend string) (interface{}, rpc.Error) {
+	publication, err := z.db.GetPublication(ctx, hash.Hash(), backend)
+	if errors.Is(err, db.ErrStateNotSynchronized) {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "the data is not published to "+backend)
+	}
+	if err != nil {
+		logger.WithFields(log.FieldKeyHash, hash.Hash().Hex()).
+			Errorf("failed to get the publication from the DB: %v", err)
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to get the publication")
+	}
+
+	return publication, nil
+}
+
+// GetServedBy returns the committee member the value of the given hash was fetched from, along with
+// its signature over the value when the member signed it
+func (z *Endpoints) GetServedBy(ctx context.Context, hash types.ArgHash) (interface{}, rpc.Error) {
+	served, err := z.db.GetServedValue(ctx, hash.Hash())
+	if errors.Is(err, db.ErrStateNotSynchronized) {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "the data was not fetched from a member")
+	}
+	if err != nil {
+		logger.WithFields(log.FieldKeyHash, hash.Hash().Hex()).
+			Errorf("failed to get the member the data was served by from the DB: %v", err)
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to get the member the data was served by")
+	}
+
+	return served, nil
+}
+
+// GetProvenance returns how the value of the given hash was obtained: pushed by the trusted sequencer,
+// resolved from the sequencer or a member, read from L1, imported or restored, with the time of each
+func (z *Endpoints) GetProvenance(ctx context.Context, hash types.ArgHash) (interface{}, rpc.Error) {
+	provenance, err := z.db.GetProvenance(ctx, hash.Hash())
+	if err != nil {
+		logger.WithFields(log.FieldKeyHash, hash.Hash().Hex()).
+			Errorf("failed to get the provenance of the data from the DB: %v", err)
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to get the provenance of the data")
+	}
+	if len(provenance) == 0 {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "no provenance recorded for the data")
+	}
+
+	return provenance, nil
+}
+
+// GetTombstone returns the tombstone of the value of the given hash, signed by the node when it pruned the
+// value per its retention policy
+func (z *Endpoints) GetTombstone(ctx context.Context, hash types.ArgHash) (interface{}, rpc.Error) {
+	tombstones, err := z.db.GetTombstones(ctx, []common.Hash{hash.Hash()})
+	if err != nil {
+		logger.WithFields(log.FieldKeyHash, hash.Hash().Hex()).
+			Errorf("failed to get the tombstone from the DB: %v", err)
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to get the tombstone")
+	}
+	if len(tombstones) == 0 {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "the data was not pruned")
+	}
+
+	return tombstones[0], nil
+}
+
+// ChallengeCustody answers a custody challenge of another member with keccak256(value || nonce), proving
+// the node holds the value of the given hash
+func (z *Endpoints) ChallengeCustody(
+	ctx context.Context,
+	hash types.ArgHash,
+	nonce types.ArgHash,
+) (interface{}, rpc.Error) {
+	data, err := z.db.GetOffChainData(ctx, hash.Hash())
+	if errors.Is(err, db.ErrStateNotSynchronized) {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "the data is not available")
+	}
+	if err != nil {
+		logger.WithFields(log.FieldKeyHash, hash.Hash().Hex()).
+			Errorf("failed to get the offchain requested data from the DB: %v", err)
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to get the requested data")
+	}
+
+	return types.ArgHash(types.CustodyProof(data.Value, nonce.Hash())), nil
+}
+
+// AnnounceKeys receives the keys of the values newly stored by another committee member,
+// which are fetched from it if missing
+func (z *Endpoints) AnnounceKeys(announcement types.KeyAnnouncement) (interface{}, rpc.Error) {
+	if err := gossip.Receive(announcement); err != nil {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "announcement rejected: %v", err)
+	}
+
+	return nil, nil
+}
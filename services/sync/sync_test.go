@@ -5,9 +5,12 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/0xPolygon/cdk-data-availability/db"
 	"github.com/0xPolygon/cdk-data-availability/mocks"
 	"github.com/0xPolygon/cdk-data-availability/types"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -57,7 +60,7 @@ func TestEndpoints_GetOffChainData(t *testing.T) {
 
 			z := &Endpoints{db: dbMock}
 
-			got, err := z.GetOffChainData(tt.hash)
+			got, err := z.GetOffChainData(context.Background(), tt.hash)
 			if tt.err != nil {
 				require.Error(t, err)
 				require.EqualError(t, tt.err, err.Error())
@@ -120,7 +123,7 @@ func TestSyncEndpoints_ListOffChainData(t *testing.T) {
 
 			z := &Endpoints{db: dbMock}
 
-			got, err := z.ListOffChainData(tt.hashes)
+			got, err := z.ListOffChainData(context.Background(), tt.hashes)
 			if tt.err != nil {
 				require.Error(t, err)
 				require.EqualError(t, tt.err, err.Error())
@@ -137,3 +140,387 @@ func TestSyncEndpoints_ListOffChainData(t *testing.T) {
 		})
 	}
 }
+
+func TestEndpoints_ListOffChainDataPage(t *testing.T) {
+	t.Parallel()
+
+	after := common.HexToHash("0x1")
+
+	t.Run("page of values", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("ListOffChainDataPage", mock.Anything, after, uint(2)).
+			Return([]types.OffChainData{{Key: common.HexToHash("0x2"), Value: []byte{1}, BatchNum: 7}}, nil).Once()
+
+		got, err := NewEndpoints(dbMock, nil).ListOffChainDataPage(context.Background(), types.ArgHash(after), 2)
+		require.NoError(t, err)
+		require.Equal(t, []types.OffChainDataEntry{{Key: common.HexToHash("0x2"), Value: []byte{1}, BatchNum: 7}}, got)
+	})
+
+	t.Run("limit out of range", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewEndpoints(mocks.NewDB(t), nil).ListOffChainDataPage(context.Background(), types.ArgHash(after), 0)
+		require.EqualError(t, err, "the limit must be between 1 and 100")
+	})
+
+	t.Run("db returns error", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("ListOffChainDataPage", mock.Anything, after, uint(2)).Return(nil, errors.New("test error")).Once()
+
+		_, err := NewEndpoints(dbMock, nil).ListOffChainDataPage(context.Background(), types.ArgHash(after), 2)
+		require.EqualError(t, err, "failed to list the requested data")
+	})
+}
+
+func TestEndpoints_GetPublication(t *testing.T) {
+	t.Parallel()
+
+	publication := &types.Publication{
+		Key:       common.HexToHash("0x1"),
+		Backend:   "eigenda",
+		Reference: "0xce27",
+	}
+
+	tests := []struct {
+		name  string
+		dbErr error
+		err   error
+	}{
+		{
+			name: "successfully got publication",
+		},
+		{
+			name:  "data not published",
+			dbErr: db.ErrStateNotSynchronized,
+			err:   errors.New("the data is not published to eigenda"),
+		},
+		{
+			name:  "db returns error",
+			dbErr: errors.New("test error"),
+			err:   errors.New("failed to get the publication"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dbMock := mocks.NewDB(t)
+
+			if tt.dbErr != nil {
+				dbMock.On("GetPublication", context.Background(), publication.Key, "eigenda").
+					Return(nil, tt.dbErr)
+			} else {
+				dbMock.On("GetPublication", context.Background(), publication.Key, "eigenda").
+					Return(publication, nil)
+			}
+
+			z := &Endpoints{db: dbMock}
+
+			got, err := z.GetPublication(context.Background(), types.ArgHash(publication.Key), "eigenda")
+			if tt.err != nil {
+				require.Error(t, err)
+				require.EqualError(t, tt.err, err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, publication, got)
+			}
+		})
+	}
+}
+
+func TestEndpoints_GetSignedOffChainData(t *testing.T) {
+	t.Parallel()
+
+	value := []byte("value")
+	key := crypto.Keccak256Hash(value)
+
+	pk, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		mirror bool
+		dbErr  error
+		err    error
+	}{
+		{
+			name: "successfully got signed data",
+		},
+		{
+			name:   "mirror refusing to sign",
+			mirror: true,
+			err:    errors.New("the node is a mirror, it does not sign"),
+		},
+		{
+			name:  "data not available",
+			dbErr: db.ErrStateNotSynchronized,
+			err:   errors.New("the data is not available"),
+		},
+		{
+			name:  "db returns error",
+			dbErr: errors.New("test error"),
+			err:   errors.New("failed to get the requested data"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dbMock := mocks.NewDB(t)
+			z := NewEndpoints(dbMock, pk)
+			if tt.mirror {
+				z = NewEndpoints(dbMock, nil)
+			} else if tt.dbErr != nil {
+				dbMock.On("GetOffChainData", context.Background(), key).Return(nil, tt.dbErr)
+			} else {
+				dbMock.On("GetOffChainData", context.Background(), key).
+					Return(&types.OffChainData{Key: key, Value: value}, nil)
+			}
+
+			got, err := z.GetSignedOffChainData(context.Background(), types.ArgHash(key))
+			if tt.err != nil {
+				require.EqualError(t, tt.err, err.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			signed, ok := got.(types.SignedOffChainData)
+			require.True(t, ok)
+			require.Equal(t, types.ArgBytes(value), signed.Value)
+
+			signer, signerErr := types.ServedSigner(key, signed.Signature)
+			require.NoError(t, signerErr)
+			require.Equal(t, crypto.PubkeyToAddress(pk.PublicKey), signer)
+		})
+	}
+}
+
+func TestEndpoints_GetServedBy(t *testing.T) {
+	t.Parallel()
+
+	served := &types.ServedValue{
+		Key:       common.HexToHash("0x1"),
+		Member:    common.HexToAddress("0x2"),
+		Signature: []byte{1, 2, 3},
+	}
+
+	tests := []struct {
+		name  string
+		dbErr error
+		err   error
+	}{
+		{
+			name: "successfully got the member",
+		},
+		{
+			name:  "data not fetched from a member",
+			dbErr: db.ErrStateNotSynchronized,
+			err:   errors.New("the data was not fetched from a member"),
+		},
+		{
+			name:  "db returns error",
+			dbErr: errors.New("test error"),
+			err:   errors.New("failed to get the member the data was served by"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dbMock := mocks.NewDB(t)
+			if tt.dbErr != nil {
+				dbMock.On("GetServedValue", context.Background(), served.Key).Return(nil, tt.dbErr)
+			} else {
+				dbMock.On("GetServedValue", context.Background(), served.Key).Return(served, nil)
+			}
+
+			z := &Endpoints{db: dbMock}
+
+			got, err := z.GetServedBy(context.Background(), types.ArgHash(served.Key))
+			if tt.err != nil {
+				require.EqualError(t, tt.err, err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, served, got)
+			}
+		})
+	}
+}
+
+func TestEndpoints_GetTombstone(t *testing.T) {
+	t.Parallel()
+
+	tombstone := types.Tombstone{
+		Key:           common.HexToHash("0x1"),
+		BatchNum:      5,
+		PrunedAtBlock: 1000,
+		Reason:        "retention policy",
+	}
+
+	tests := []struct {
+		name       string
+		tombstones []types.Tombstone
+		dbErr      error
+		err        error
+	}{
+		{
+			name:       "successfully got the tombstone",
+			tombstones: []types.Tombstone{tombstone},
+		},
+		{
+			name:       "data not pruned",
+			tombstones: []types.Tombstone{},
+			err:        errors.New("the data was not pruned"),
+		},
+		{
+			name:  "db returns error",
+			dbErr: errors.New("test error"),
+			err:   errors.New("failed to get the tombstone"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dbMock := mocks.NewDB(t)
+			dbMock.On("GetTombstones", context.Background(), []common.Hash{tombstone.Key}).
+				Return(tt.tombstones, tt.dbErr)
+
+			z := &Endpoints{db: dbMock}
+
+			got, err := z.GetTombstone(context.Background(), types.ArgHash(tombstone.Key))
+			if tt.err != nil {
+				require.EqualError(t, tt.err, err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tombstone, got)
+			}
+		})
+	}
+}
+
+func TestEndpoints_GetProvenance(t *testing.T) {
+	t.Parallel()
+
+	key := common.HexToHash("0x1")
+	provenance := []types.Provenance{
+		{Key: key, Source: types.ProvenanceSequencer},
+		{Key: key, Source: types.ProvenanceGossip, Origin: common.HexToAddress("0x2").Hex()},
+	}
+
+	tests := []struct {
+		name       string
+		provenance []types.Provenance
+		dbErr      error
+		err        error
+	}{
+		{
+			name:       "successfully got the provenance",
+			provenance: provenance,
+		},
+		{
+			name:       "no provenance recorded",
+			provenance: []types.Provenance{},
+			err:        errors.New("no provenance recorded for the data"),
+		},
+		{
+			name:  "db returns error",
+			dbErr: errors.New("test error"),
+			err:   errors.New("failed to get the provenance of the data"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dbMock := mocks.NewDB(t)
+			dbMock.On("GetProvenance", context.Background(), key).Return(tt.provenance, tt.dbErr)
+
+			z := &Endpoints{db: dbMock}
+
+			got, err := z.GetProvenance(context.Background(), types.ArgHash(key))
+			if tt.err != nil {
+				require.EqualError(t, tt.err, err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, provenance, got)
+			}
+		})
+	}
+}
+
+func TestEndpoints_ChallengeCustody(t *testing.T) {
+	t.Parallel()
+
+	value := []byte("value")
+	key := common.BytesToHash(value)
+	nonce := common.HexToHash("0x1234")
+
+	tests := []struct {
+		name  string
+		dbErr error
+		err   error
+	}{
+		{
+			name: "successfully answered the challenge",
+		},
+		{
+			name:  "data not available",
+			dbErr: db.ErrStateNotSynchronized,
+			err:   errors.New("the data is not available"),
+		},
+		{
+			name:  "db returns error",
+			dbErr: errors.New("test error"),
+			err:   errors.New("failed to get the requested data"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dbMock := mocks.NewDB(t)
+			if tt.dbErr != nil {
+				dbMock.On("GetOffChainData", context.Background(), key).Return(nil, tt.dbErr)
+			} else {
+				dbMock.On("GetOffChainData", context.Background(), key).
+					Return(&types.OffChainData{Key: key, Value: value}, nil)
+			}
+
+			z := &Endpoints{db: dbMock}
+
+			got, err := z.ChallengeCustody(context.Background(), types.ArgHash(key), types.ArgHash(nonce))
+			if tt.err != nil {
+				require.EqualError(t, tt.err, err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, types.ArgHash(types.CustodyProof(value, nonce)), got)
+			}
+		})
+	}
+}
+
+func TestEndpoints_AnnounceKeys(t *testing.T) {
+	t.Parallel()
+
+	z := &Endpoints{db: mocks.NewDB(t)}
+
+	_, err := z.AnnounceKeys(types.KeyAnnouncement{Keys: []common.Hash{common.HexToHash("0x1")}})
+	require.EqualError(t, err, "announcement rejected: gossip is disabled")
+}
@@ -0,0 +1,155 @@
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/metrics"
+	"github.com/0xPolygon/cdk-data-availability/pkg/erasure"
+	"github.com/0xPolygon/cdk-data-availability/reporter"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// logger is the logger of the sharding component
+var logger = log.WithComponent("sharding")
+
+// Sharder erasure codes the stored values into shards. Every value gets DataShards + ParityShards
+// shards committed to by a Merkle root, and the member stores the shards assigned to its position
+// in the committee registered on L1: the shards whose index modulo the size of the committee is
+// the position of the member. A node outside the committee stores every shard.
+type Sharder struct {
+	cfg      config.ShardingConfig
+	self     common.Address
+	db       db.DB
+	etherman etherman.Etherman
+	stop     chan struct{}
+}
+
+// New returns a Sharder storing the shards assigned to the member self
+func New(cfg config.ShardingConfig, self common.Address, db db.DB, em etherman.Etherman) *Sharder {
+	return &Sharder{
+		cfg:      cfg,
+		self:     self,
+		db:       db,
+		etherman: em,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start shards the new values every interval until the sharder is stopped
+func (s *Sharder) Start(ctx context.Context) {
+	defer reporter.Recover()
+
+	logger.Info("starting to erasure code the stored values")
+	ticker := time.NewTicker(s.cfg.Interval.Duration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := s.ShardPending(ctx); err != nil {
+				logger.Errorf("failed to shard the stored values: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// Stop stops the sharder
+func (s *Sharder) Stop() {
+	close(s.stop)
+}
+
+// ShardPending shards up to a batch of values not yet sharded, and returns how many were sharded
+func (s *Sharder) ShardPending(ctx context.Context) (int, error) {
+	dbCtx, cancel := context.WithTimeout(ctx, s.cfg.Timeout.Duration)
+	list, err := s.db.GetUnshardedOffChainData(dbCtx, s.cfg.BatchSize)
+	cancel()
+	if err != nil {
+		return 0, err
+	}
+	if len(list) == 0 {
+		return 0, nil
+	}
+
+	memberIndex, members, err := s.assignment()
+	if err != nil {
+		return 0, err
+	}
+
+	for i, data := range list {
+		if err = s.shard(ctx, data, memberIndex, members); err != nil {
+			logger.WithFields(log.FieldKeyHash, data.Key.Hex()).Errorf("failed to shard the value: %v", err)
+			return i, err
+		}
+	}
+
+	return len(list), nil
+}
+
+// assignment returns the position of the member in the committee and the size of the committee,
+// a node outside the committee holds every shard
+func (s *Sharder) assignment() (uint, uint, error) {
+	committee, err := s.etherman.GetCurrentDataCommittee()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get the committee: %w", err)
+	}
+
+	for i, member := range committee.Members {
+		if member.Addr == s.self {
+			return uint(i), uint(len(committee.Members)), nil
+		}
+	}
+
+	return 0, 1, nil
+}
+
+// shard encodes a value and stores the commitment and the shards held, recording the result
+func (s *Sharder) shard(parentCtx context.Context, data types.OffChainData, memberIndex, members uint) error {
+	ctx, cancel := context.WithTimeout(parentCtx, s.cfg.Timeout.Duration)
+	defer cancel()
+
+	shards, err := erasure.Encode(data.Value, int(s.cfg.DataShards), int(s.cfg.ParityShards))
+	if err != nil {
+		metrics.ShardedValue(err)
+		return err
+	}
+
+	root, proofs := erasure.Commit(shards)
+	held := make([]types.Shard, 0, len(shards)/int(members)+1)
+	for i := range shards {
+		if uint(i)%members != memberIndex {
+			continue
+		}
+
+		held = append(held, types.Shard{
+			Key:   data.Key,
+			Index: uint(i),
+			Data:  shards[i],
+			Proof: proofs[i],
+		})
+	}
+
+	err = s.db.StoreShards(ctx, types.ShardCommitment{
+		Key:          data.Key,
+		Root:         root,
+		DataShards:   s.cfg.DataShards,
+		ParityShards: s.cfg.ParityShards,
+		Size:         uint64(len(data.Value)),
+		MemberIndex:  memberIndex,
+		Members:      members,
+		Timestamp:    time.Now().UTC(),
+	}, held)
+	metrics.ShardedValue(err)
+
+	return err
+}
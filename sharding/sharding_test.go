@@ -0,0 +1,116 @@
+package sharding
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/config"
+	cfgTypes "github.com/0xPolygon/cdk-data-availability/config/types"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/pkg/erasure"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig() config.ShardingConfig {
+	return config.ShardingConfig{
+		Enabled:      true,
+		DataShards:   2,
+		ParityShards: 2,
+		Interval:     cfgTypes.NewDuration(time.Minute),
+		BatchSize:    10,
+		Timeout:      cfgTypes.NewDuration(5 * time.Second),
+	}
+}
+
+func TestSharder_ShardPending(t *testing.T) {
+	t.Parallel()
+
+	self := common.HexToAddress("0x2")
+	data := types.OffChainData{Key: common.HexToHash("0x1"), Value: []byte("batch data"), BatchNum: 5}
+	committee := &etherman.DataCommittee{Members: []etherman.DataCommitteeMember{
+		{Addr: common.HexToAddress("0x1")},
+		{Addr: self},
+		{Addr: common.HexToAddress("0x3")},
+	}}
+
+	t.Run("member of the committee", func(t *testing.T) {
+		t.Parallel()
+
+		em := mocks.NewEtherman(t)
+		em.On("GetCurrentDataCommittee").Return(committee, nil)
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("GetUnshardedOffChainData", mock.Anything, uint(10)).Return([]types.OffChainData{data}, nil)
+		dbMock.On("StoreShards", mock.Anything,
+			mock.MatchedBy(func(commitment types.ShardCommitment) bool {
+				return commitment.Key == data.Key && commitment.Size == uint64(len(data.Value)) &&
+					commitment.MemberIndex == 1 && commitment.Members == 3
+			}),
+			mock.MatchedBy(func(shards []types.Shard) bool {
+				// the second member of three holds the second of the four shards only
+				return len(shards) == 1 && shards[0].Index == 1
+			}),
+		).Run(func(args mock.Arguments) {
+			commitment := args.Get(1).(types.ShardCommitment)
+			shard := args.Get(2).([]types.Shard)[0]
+			require.True(t, erasure.Verify(commitment.Root, int(shard.Index), shard.Data, shard.Proof))
+		}).Return(nil)
+
+		count, err := New(testConfig(), self, dbMock, em).ShardPending(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+	})
+
+	t.Run("outside of the committee", func(t *testing.T) {
+		t.Parallel()
+
+		em := mocks.NewEtherman(t)
+		em.On("GetCurrentDataCommittee").Return(committee, nil)
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("GetUnshardedOffChainData", mock.Anything, uint(10)).Return([]types.OffChainData{data}, nil)
+		dbMock.On("StoreShards", mock.Anything,
+			mock.MatchedBy(func(commitment types.ShardCommitment) bool {
+				return commitment.MemberIndex == 0 && commitment.Members == 1
+			}),
+			mock.MatchedBy(func(shards []types.Shard) bool { return len(shards) == 4 }),
+		).Return(nil)
+
+		count, err := New(testConfig(), common.Address{}, dbMock, em).ShardPending(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+	})
+
+	t.Run("failure to store", func(t *testing.T) {
+		t.Parallel()
+
+		em := mocks.NewEtherman(t)
+		em.On("GetCurrentDataCommittee").Return(committee, nil)
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("GetUnshardedOffChainData", mock.Anything, uint(10)).
+			Return([]types.OffChainData{data, data}, nil)
+		dbMock.On("StoreShards", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test")).Once()
+
+		count, err := New(testConfig(), self, dbMock, em).ShardPending(context.Background())
+		require.ErrorContains(t, err, "test")
+		require.Equal(t, 0, count)
+	})
+
+	t.Run("nothing to shard", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("GetUnshardedOffChainData", mock.Anything, uint(10)).Return([]types.OffChainData{}, nil)
+
+		count, err := New(testConfig(), self, dbMock, mocks.NewEtherman(t)).ShardPending(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 0, count)
+	})
+}
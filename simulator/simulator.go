@@ -0,0 +1,272 @@
+package simulator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/devnet"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/reporter"
+	"github.com/0xPolygon/cdk-data-availability/sequencer"
+	"github.com/0xPolygon/cdk-data-availability/synchronizer"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/umbracle/ethgo"
+)
+
+// logger is the logger of the simulator component
+var logger = log.WithComponent("simulator")
+
+// ErrBatchNotFound is returned for the batches not sequenced by the simulator
+var ErrBatchNotFound = errors.New("batch not found")
+
+// Config is the configuration of the synthetic events
+type Config struct {
+	// Rate is the number of sequences per second sent by Start
+	Rate float64
+
+	// MinBatches and MaxBatches bound the number of batches of each sequence
+	MinBatches int
+	MaxBatches int
+
+	// MinBatchSize and MaxBatchSize bound the size in bytes of the data of each batch
+	MinBatchSize int
+	MaxBatchSize int
+
+	// ReorgEvery is the number of sequences after which the L1 reorganizes, 0 for no reorganization
+	ReorgEvery int
+	// ReorgDepth is the number of blocks dropped by each reorganization
+	ReorgDepth uint64
+
+	// Seed seeds the generation of the batches, the same for the same seed so that the runs are reproducible
+	Seed int64
+}
+
+// Stats are the counts of what the simulator generated
+type Stats struct {
+	Sequences int
+	Batches   int
+	Bytes     int
+	Reorgs    int
+}
+
+// Simulator generates SequenceBatches events of synthetic batches on a simulated L1, to test the load and the
+// correctness of the synchronization without an L1 node. The BatchSynchronizer is fed through its interfaces:
+// Chain is its etherman, the simulator is its sequencer tracker serving the data of the batches, and Reorgs
+// its reorganizations, which must be consumed when they are injected.
+type Simulator struct {
+	cfg    Config
+	chain  *devnet.Chain
+	random *rand.Rand
+	reorgs chan synchronizer.BlockReorg
+	stop   chan struct{}
+
+	lock sync.RWMutex
+	// batches are the data of the batches by number, the latest sequenced under each number
+	batches map[uint64][]byte
+	stats   Stats
+}
+
+// New returns a simulator sequencing on a simulated L1 with the contracts of the L1 config
+func New(cfg Config, l1 config.L1Config) (*Simulator, error) {
+	switch {
+	case cfg.MinBatches <= 0 || cfg.MaxBatches < cfg.MinBatches:
+		return nil, fmt.Errorf("invalid number of batches per sequence, from %d to %d",
+			cfg.MinBatches, cfg.MaxBatches)
+	case cfg.MinBatchSize <= 0 || cfg.MaxBatchSize < cfg.MinBatchSize:
+		return nil, fmt.Errorf("invalid batch size, from %d to %d", cfg.MinBatchSize, cfg.MaxBatchSize)
+	case cfg.ReorgEvery > 0 && cfg.ReorgDepth == 0:
+		return nil, errors.New("the reorganizations must drop at least one block")
+	}
+
+	return &Simulator{
+		cfg:   cfg,
+		chain: devnet.NewChain(l1, common.Address{}),
+		// not a secret, the batches only need to be reproducible
+		random:  rand.New(rand.NewSource(cfg.Seed)), //nolint:gosec
+		reorgs:  make(chan synchronizer.BlockReorg),
+		stop:    make(chan struct{}),
+		batches: make(map[uint64][]byte),
+	}, nil
+}
+
+// Chain returns the simulated L1 the events are generated on
+func (s *Simulator) Chain() *devnet.Chain {
+	return s.chain
+}
+
+// Reorgs returns the channel the reorganizations are sent on, as the ReorgDetector does
+func (s *Simulator) Reorgs() <-chan synchronizer.BlockReorg {
+	return s.reorgs
+}
+
+// Stats returns the counts of what was generated so far
+func (s *Simulator) Stats() Stats {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.stats
+}
+
+// GetSequenceBatch returns the data of the batch, serving the batches as the trusted sequencer does
+func (s *Simulator) GetSequenceBatch(_ context.Context, batchNum uint64) (*sequencer.SeqBatch, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	data, ok := s.batches[batchNum]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrBatchNotFound, batchNum)
+	}
+
+	return &sequencer.SeqBatch{Number: types.ArgUint64(batchNum), BatchL2Data: data}, nil
+}
+
+// Step sequences a sequence of synthetic batches, after reorganizing the L1 when one is due
+func (s *Simulator) Step(ctx context.Context) error {
+	s.lock.RLock()
+	due := s.cfg.ReorgEvery > 0 && s.stats.Sequences > 0 && s.stats.Sequences%s.cfg.ReorgEvery == 0
+	s.lock.RUnlock()
+
+	// the first blocks are kept, the chain not being rewound past its genesis
+	if head, err := s.Head(ctx); err != nil {
+		return err
+	} else if due && head > s.cfg.ReorgDepth {
+		if err = s.Reorg(ctx, s.cfg.ReorgDepth); err != nil {
+			return err
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	lastBatch, err := s.chain.LastVerifiedBatch(ctx)
+	if err != nil {
+		return err
+	}
+
+	count := s.cfg.MinBatches + s.random.Intn(s.cfg.MaxBatches-s.cfg.MinBatches+1)
+	keys := make([]common.Hash, count)
+	for i := range keys {
+		data := make([]byte, s.cfg.MinBatchSize+s.random.Intn(s.cfg.MaxBatchSize-s.cfg.MinBatchSize+1))
+		_, _ = s.random.Read(data)
+
+		keys[i] = crypto.Keccak256Hash(data)
+		s.batches[lastBatch+uint64(i)+1] = data
+		s.stats.Bytes += len(data)
+	}
+
+	if _, err = s.chain.SequenceBatches(keys, nil); err != nil {
+		return err
+	}
+	s.stats.Sequences++
+	s.stats.Batches += count
+
+	return nil
+}
+
+// Reorg drops the depth latest blocks of the L1 and sends the reorganization, waiting for it to be received
+func (s *Simulator) Reorg(ctx context.Context, depth uint64) error {
+	s.lock.Lock()
+	head, err := s.chain.Reorg(depth)
+	if err == nil {
+		s.stats.Reorgs++
+	}
+	s.lock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("L1 reorganized to the block %d", head.Number.Uint64())
+	select {
+	case s.reorgs <- synchronizer.BlockReorg{Number: head.Number.Uint64(), Hash: ethgo.Hash(head.Hash())}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Start sequences at the rate of the config until the simulator is stopped
+func (s *Simulator) Start(ctx context.Context) {
+	defer reporter.Recover()
+
+	if s.cfg.Rate <= 0 {
+		logger.Errorf("the rate of %v sequences per second is not positive, nothing is sequenced", s.cfg.Rate)
+		return
+	}
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / s.cfg.Rate))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.Step(ctx); err != nil && ctx.Err() == nil {
+				logger.Errorf("failed to sequence the synthetic batches: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// Stop stops the simulator
+func (s *Simulator) Stop() {
+	close(s.stop)
+}
+
+// Expected returns the data of the batches sequenced on the current chain, which the synchronizer must have
+// stored once caught up with it
+func (s *Simulator) Expected(ctx context.Context) ([]types.OffChainData, error) {
+	iter, err := s.chain.FilterSequenceBatches(&bind.FilterOpts{Context: ctx}, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var expected []types.OffChainData
+	for iter.Next() {
+		tx, _, err := s.chain.GetTx(ctx, iter.Event.Raw.TxHash)
+		if err != nil {
+			return nil, err
+		}
+		keys, err := synchronizer.UnpackTxData(tx.Data())
+		if err != nil {
+			return nil, err
+		}
+		batchKeys, err := synchronizer.SequenceBatchKeys(iter.Event.NumBatch, keys)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range batchKeys {
+			expected = append(expected, types.OffChainData{
+				Key:      key.Hash,
+				Value:    s.batches[key.Number],
+				BatchNum: key.Number,
+			})
+		}
+	}
+
+	return expected, iter.Error()
+}
+
+// Head returns the number of the latest block of the L1
+func (s *Simulator) Head(ctx context.Context) (uint64, error) {
+	header, err := s.chain.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return header.Number.Uint64(), nil
+}
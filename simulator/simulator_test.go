@@ -0,0 +1,161 @@
+package simulator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/client"
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/synchronizer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSimulator(t *testing.T, cfg Config) *Simulator {
+	t.Helper()
+
+	c, err := config.Default()
+	require.NoError(t, err)
+
+	sim, err := New(cfg, c.L1)
+	require.NoError(t, err)
+
+	return sim
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(Config{MinBatches: 2, MaxBatches: 1, MinBatchSize: 1, MaxBatchSize: 1}, config.L1Config{})
+	require.EqualError(t, err, "invalid number of batches per sequence, from 2 to 1")
+	_, err = New(Config{MinBatches: 1, MaxBatches: 1}, config.L1Config{})
+	require.EqualError(t, err, "invalid batch size, from 0 to 0")
+	_, err = New(Config{MinBatches: 1, MaxBatches: 1, MinBatchSize: 1, MaxBatchSize: 1, ReorgEvery: 1},
+		config.L1Config{})
+	require.EqualError(t, err, "the reorganizations must drop at least one block")
+}
+
+func TestSimulator_Step(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cfg := Config{MinBatches: 1, MaxBatches: 3, MinBatchSize: 8, MaxBatchSize: 64, Seed: 1}
+
+	sim := newTestSimulator(t, cfg)
+	for i := 0; i < 5; i++ {
+		require.NoError(t, sim.Step(ctx))
+	}
+
+	stats := sim.Stats()
+	require.Equal(t, 5, stats.Sequences)
+	require.Zero(t, stats.Reorgs)
+
+	expected, err := sim.Expected(ctx)
+	require.NoError(t, err)
+	require.Len(t, expected, stats.Batches)
+	for i, data := range expected {
+		require.Equal(t, uint64(i+1), data.BatchNum)
+		require.Equal(t, data.Key, crypto.Keccak256Hash(data.Value))
+		require.GreaterOrEqual(t, len(data.Value), cfg.MinBatchSize)
+		require.LessOrEqual(t, len(data.Value), cfg.MaxBatchSize)
+
+		batch, err := sim.GetSequenceBatch(ctx, data.BatchNum)
+		require.NoError(t, err)
+		require.Equal(t, data.Value, []byte(batch.BatchL2Data))
+	}
+
+	_, err = sim.GetSequenceBatch(ctx, uint64(stats.Batches+1))
+	require.ErrorIs(t, err, ErrBatchNotFound)
+
+	// the same seed generates the same batches
+	again := newTestSimulator(t, cfg)
+	for i := 0; i < 5; i++ {
+		require.NoError(t, again.Step(ctx))
+	}
+	expectedAgain, err := again.Expected(ctx)
+	require.NoError(t, err)
+	require.Equal(t, expected, expectedAgain)
+}
+
+func TestSimulator_Reorg(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	sim := newTestSimulator(t, Config{
+		MinBatches: 2, MaxBatches: 2, MinBatchSize: 8, MaxBatchSize: 8, ReorgEvery: 3, ReorgDepth: 2, Seed: 1,
+	})
+
+	reorgs := make(chan synchronizer.BlockReorg, 1)
+	go func() {
+		for reorg := range sim.Reorgs() {
+			reorgs <- reorg
+		}
+	}()
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, sim.Step(ctx))
+	}
+	dropped, err := sim.Expected(ctx)
+	require.NoError(t, err)
+
+	// the 2 last sequences are dropped before the fourth one, which sequences the batches 3 and 4 again
+	require.NoError(t, sim.Step(ctx))
+	reorg := <-reorgs
+	require.Equal(t, uint64(1), reorg.Number)
+	require.Equal(t, 1, sim.Stats().Reorgs)
+
+	expected, err := sim.Expected(ctx)
+	require.NoError(t, err)
+	require.Len(t, expected, 4)
+	require.Equal(t, dropped[:2], expected[:2])
+	require.Equal(t, uint64(3), expected[2].BatchNum)
+	require.NotEqual(t, dropped[2].Key, expected[2].Key)
+
+	// the reorganization is not received by the synchronizer of an idle simulator
+	idle := newTestSimulator(t, Config{MinBatches: 1, MaxBatches: 1, MinBatchSize: 8, MaxBatchSize: 8})
+	idle.Chain().Mine()
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	require.True(t, errors.Is(idle.Reorg(canceled, 1), context.Canceled))
+}
+
+func TestSimulator_Synchronizer(t *testing.T) {
+	ctx := context.Background()
+	c, err := config.Default()
+	require.NoError(t, err)
+	c.L1.RetryPeriod.Duration = 10 * time.Millisecond
+
+	sim, err := New(Config{
+		MinBatches: 1, MaxBatches: 8, MinBatchSize: 32, MaxBatchSize: 1024, ReorgEvery: 10, ReorgDepth: 3, Seed: 1,
+	}, c.L1)
+	require.NoError(t, err)
+
+	storage := db.NewMemory(0)
+	batchSynchronizer, err := synchronizer.NewBatchSynchronizer(c.L1, c.Timeouts, c.Limits, common.Address{}, storage,
+		sim.Reorgs(), sim.Chain(), sim, nil, client.NewFactory())
+	require.NoError(t, err)
+	batchSynchronizer.Start(ctx)
+	defer batchSynchronizer.Stop()
+
+	for i := 0; i < 50; i++ {
+		require.NoError(t, sim.Step(ctx))
+	}
+	require.Equal(t, 4, sim.Stats().Reorgs)
+
+	// every batch of the chain is stored once the synchronizer caught up, whatever the reorganizations
+	expected, err := sim.Expected(ctx)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		for _, data := range expected {
+			stored, err := storage.GetOffChainData(ctx, data.Key)
+			if err != nil || string(stored.Value) != string(data.Value) {
+				return false
+			}
+		}
+		return true
+	}, 10*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,329 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// Version is the version of the format of the snapshots
+	Version = 1
+
+	// manifestName is the name of the manifest in the snapshot archive
+	manifestName = "manifest.json"
+	// dataName is the name of the data in the snapshot archive, JSON records compressed with gzip
+	dataName = "data.jsonl.gz"
+)
+
+// Manifest describes a snapshot, it is the first entry of the snapshot archive
+type Manifest struct {
+	Version uint `json:"version"`
+	// SchemaVersion is the last migration applied to the database the snapshot was created from
+	SchemaVersion          string         `json:"schemaVersion"`
+	PolygonValidiumAddress common.Address `json:"polygonValidiumAddress"`
+	// LastProcessedBlocks are the last blocks processed by the synchronizer tasks
+	LastProcessedBlocks map[string]uint64 `json:"lastProcessedBlocks"`
+	Values              uint64            `json:"values"`
+	UnresolvedKeys      uint64            `json:"unresolvedKeys"`
+	// DataSHA256 is the SHA-256 checksum of the data entry of the archive
+	DataSHA256 string    `json:"dataSha256"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// record is a line of the data of a snapshot, a value or an unresolved batch key
+type record struct {
+	Key        common.Hash     `json:"key"`
+	BatchNum   types.ArgUint64 `json:"batchNum"`
+	Value      types.ArgBytes  `json:"value,omitempty"`
+	Unresolved bool            `json:"unresolved,omitempty"`
+}
+
+// Source is a consistent view of the state of a node, as db.Snapshot
+type Source interface {
+	SchemaVersion(ctx context.Context) (string, error)
+	LastProcessedBlocks(ctx context.Context) (map[string]uint64, error)
+	UnresolvedBatchKeys(ctx context.Context) ([]types.BatchKey, error)
+	OffChainDataPage(ctx context.Context, afterKey common.Hash, limit uint) ([]types.OffChainData, error)
+}
+
+// Sink stores the state of a node, as db.Restore
+type Sink interface {
+	StoreOffChainData(ctx context.Context, od []types.OffChainData) error
+	StoreProvenance(ctx context.Context, provenance []types.Provenance) error
+	StoreUnresolvedBatchKeys(ctx context.Context, bks []types.BatchKey) error
+	StoreLastProcessedBlocks(ctx context.Context, blocks map[string]uint64) error
+}
+
+// Create writes a snapshot of the given source as a tar archive: the manifest, followed by the data.
+// The data is first written to a temporary file, as its checksum is part of the manifest.
+func Create(
+	ctx context.Context,
+	src Source,
+	w io.Writer,
+	polygonValidium common.Address,
+	pageSize uint,
+) (*Manifest, error) {
+	manifest := &Manifest{
+		Version:                Version,
+		PolygonValidiumAddress: polygonValidium,
+		CreatedAt:              time.Now().UTC(),
+	}
+
+	var err error
+	if manifest.SchemaVersion, err = src.SchemaVersion(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get the schema version: %w", err)
+	}
+	if manifest.LastProcessedBlocks, err = src.LastProcessedBlocks(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get the last processed blocks: %w", err)
+	}
+
+	data, err := os.CreateTemp("", "snapshot-*.jsonl.gz")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		data.Close()
+		os.Remove(data.Name())
+	}()
+
+	hash := sha256.New()
+	zw := gzip.NewWriter(io.MultiWriter(data, hash))
+	if err = writeData(ctx, src, zw, manifest, pageSize); err != nil {
+		return nil, err
+	}
+	if err = zw.Close(); err != nil {
+		return nil, err
+	}
+	manifest.DataSHA256 = hex.EncodeToString(hash.Sum(nil))
+
+	size, err := data.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = data.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	encoded, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	tw := tar.NewWriter(w)
+	if err = tw.WriteHeader(&tar.Header{
+		Name:    manifestName,
+		Mode:    0600, //nolint:gomnd
+		Size:    int64(len(encoded)),
+		ModTime: manifest.CreatedAt,
+	}); err != nil {
+		return nil, err
+	}
+	if _, err = tw.Write(encoded); err != nil {
+		return nil, err
+	}
+	if err = tw.WriteHeader(&tar.Header{
+		Name:    dataName,
+		Mode:    0600, //nolint:gomnd
+		Size:    size,
+		ModTime: manifest.CreatedAt,
+	}); err != nil {
+		return nil, err
+	}
+	if _, err = io.Copy(tw, data); err != nil {
+		return nil, err
+	}
+
+	return manifest, tw.Close()
+}
+
+// writeData writes the unresolved batch keys and the values of the source, counting them in the manifest
+func writeData(ctx context.Context, src Source, w io.Writer, manifest *Manifest, pageSize uint) error {
+	enc := json.NewEncoder(w)
+
+	bks, err := src.UnresolvedBatchKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the unresolved batch keys: %w", err)
+	}
+	for _, bk := range bks {
+		if err = enc.Encode(record{Key: bk.Hash, BatchNum: types.ArgUint64(bk.Number), Unresolved: true}); err != nil {
+			return err
+		}
+	}
+	manifest.UnresolvedKeys = uint64(len(bks))
+
+	var after common.Hash
+	for {
+		page, err := src.OffChainDataPage(ctx, after, pageSize)
+		if err != nil {
+			return fmt.Errorf("failed to list the values after %s: %w", after.Hex(), err)
+		}
+
+		for _, data := range page {
+			rec := record{Key: data.Key, BatchNum: types.ArgUint64(data.BatchNum), Value: data.Value}
+			if err = enc.Encode(rec); err != nil {
+				return err
+			}
+		}
+		manifest.Values += uint64(len(page))
+
+		if uint(len(page)) < pageSize {
+			return nil
+		}
+		after = page[len(page)-1].Key
+	}
+}
+
+// Restore reads a snapshot archive of the chain of the given PolygonValidium contract into the sink,
+// and returns its manifest. The snapshot must not come from a schema more recent than the given one.
+// The sink is expected to be transactional: the checksum of the data is only known once it is all
+// stored, and an error is returned for a snapshot not matching it, to be rolled back.
+func Restore(
+	ctx context.Context,
+	r io.Reader,
+	sink Sink,
+	polygonValidium common.Address,
+	schemaVersion string,
+	pageSize uint,
+) (*Manifest, error) {
+	tr := tar.NewReader(r)
+
+	header, err := tr.Next()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the snapshot: %w", err)
+	}
+	if header.Name != manifestName {
+		return nil, fmt.Errorf("the snapshot starts with %s instead of its manifest", header.Name)
+	}
+
+	var manifest Manifest
+	if err = json.NewDecoder(tr).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("failed to read the manifest: %w", err)
+	}
+	if manifest.Version != Version {
+		return nil, fmt.Errorf("unsupported snapshot version %d", manifest.Version)
+	}
+	if manifest.PolygonValidiumAddress != polygonValidium {
+		return nil, fmt.Errorf("the snapshot is of the PolygonValidium contract %s, not %s",
+			manifest.PolygonValidiumAddress.Hex(), polygonValidium.Hex())
+	}
+	if manifest.SchemaVersion > schemaVersion {
+		return nil, fmt.Errorf("the snapshot of the schema %s is more recent than the database schema %s",
+			manifest.SchemaVersion, schemaVersion)
+	}
+
+	if header, err = tr.Next(); err != nil {
+		return nil, fmt.Errorf("failed to read the snapshot: %w", err)
+	}
+	if header.Name != dataName {
+		return nil, fmt.Errorf("unexpected entry %s in the snapshot", header.Name)
+	}
+
+	hash := sha256.New()
+	data := io.TeeReader(tr, hash)
+	zr, err := gzip.NewReader(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// the restored values are recorded as obtained from the snapshot identified by the checksum of its data
+	values, unresolved, err := readData(ctx, zr, sink, manifest.DataSHA256, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	// the trailing bytes of the data are part of the checksum
+	if _, err = io.Copy(io.Discard, data); err != nil {
+		return nil, err
+	}
+
+	if checksum := hex.EncodeToString(hash.Sum(nil)); checksum != manifest.DataSHA256 {
+		return nil, fmt.Errorf("the checksum %s of the data does not match the manifest %s",
+			checksum, manifest.DataSHA256)
+	}
+	if values != manifest.Values || unresolved != manifest.UnresolvedKeys {
+		return nil, fmt.Errorf("the snapshot holds %d values and %d unresolved keys, the manifest %d and %d",
+			values, unresolved, manifest.Values, manifest.UnresolvedKeys)
+	}
+
+	if err = sink.StoreLastProcessedBlocks(ctx, manifest.LastProcessedBlocks); err != nil {
+		return nil, fmt.Errorf("failed to store the last processed blocks: %w", err)
+	}
+
+	return &manifest, nil
+}
+
+// readData stores the records of the data pageSize at a time, checking every value against its key,
+// and returns the number of values and of unresolved keys stored
+func readData(ctx context.Context, r io.Reader, sink Sink, origin string, pageSize uint) (uint64, uint64, error) {
+	var (
+		values, unresolved uint64
+		page               = make([]types.OffChainData, 0, pageSize)
+		bks                []types.BatchKey
+	)
+
+	flush := func() error {
+		if len(page) == 0 {
+			return nil
+		}
+		if err := sink.StoreOffChainData(ctx, page); err != nil {
+			return fmt.Errorf("failed to store the values: %w", err)
+		}
+		if err := sink.StoreProvenance(ctx, types.NewProvenance(page, types.ProvenanceSnapshot, origin)); err != nil {
+			return fmt.Errorf("failed to store the provenance of the values: %w", err)
+		}
+		values += uint64(len(page))
+		page = make([]types.OffChainData, 0, pageSize)
+		return nil
+	}
+
+	dec := json.NewDecoder(r)
+	for {
+		var rec record
+		err := dec.Decode(&rec)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return values, unresolved, fmt.Errorf("failed to read the data: %w", err)
+		}
+
+		if rec.Unresolved {
+			bks = append(bks, types.BatchKey{Number: uint64(rec.BatchNum), Hash: rec.Key})
+			continue
+		}
+
+		if crypto.Keccak256Hash(rec.Value) != rec.Key {
+			return values, unresolved, fmt.Errorf("the value %s does not match its key", rec.Key.Hex())
+		}
+		page = append(page, types.OffChainData{Key: rec.Key, Value: rec.Value, BatchNum: uint64(rec.BatchNum)})
+		if uint(len(page)) == pageSize {
+			if err = flush(); err != nil {
+				return values, unresolved, err
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return values, unresolved, err
+	}
+	if len(bks) > 0 {
+		if err := sink.StoreUnresolvedBatchKeys(ctx, bks); err != nil {
+			return values, unresolved, fmt.Errorf("failed to store the unresolved batch keys: %w", err)
+		}
+		unresolved = uint64(len(bks))
+	}
+
+	return values, unresolved, nil
+}
@@ -0,0 +1,171 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"sort"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// memory is an in-memory Source and Sink
+type memory struct {
+	schemaVersion string
+	blocks        map[string]uint64
+	unresolved    []types.BatchKey
+	values        []types.OffChainData
+	provenance    []types.Provenance
+}
+
+func (m *memory) SchemaVersion(context.Context) (string, error) {
+	return m.schemaVersion, nil
+}
+
+func (m *memory) LastProcessedBlocks(context.Context) (map[string]uint64, error) {
+	return m.blocks, nil
+}
+
+func (m *memory) UnresolvedBatchKeys(context.Context) ([]types.BatchKey, error) {
+	return m.unresolved, nil
+}
+
+func (m *memory) OffChainDataPage(_ context.Context, afterKey common.Hash, limit uint) ([]types.OffChainData, error) {
+	page := make([]types.OffChainData, 0, limit)
+	for _, data := range m.values {
+		if bytes.Compare(data.Key.Bytes(), afterKey.Bytes()) > 0 && uint(len(page)) < limit {
+			page = append(page, data)
+		}
+	}
+	return page, nil
+}
+
+func (m *memory) StoreOffChainData(_ context.Context, od []types.OffChainData) error {
+	m.values = append(m.values, od...)
+	return nil
+}
+
+func (m *memory) StoreProvenance(_ context.Context, provenance []types.Provenance) error {
+	m.provenance = append(m.provenance, provenance...)
+	return nil
+}
+
+func (m *memory) StoreUnresolvedBatchKeys(_ context.Context, bks []types.BatchKey) error {
+	m.unresolved = append(m.unresolved, bks...)
+	return nil
+}
+
+func (m *memory) StoreLastProcessedBlocks(_ context.Context, blocks map[string]uint64) error {
+	m.blocks = blocks
+	return nil
+}
+
+// newSource returns a source of the given values, ordered by key
+func newSource(values ...string) *memory {
+	src := &memory{
+		schemaVersion: "0012.sql",
+		blocks:        map[string]uint64{"L1": 100},
+		unresolved:    []types.BatchKey{{Number: 9, Hash: common.HexToHash("0x9")}},
+	}
+	for i, value := range values {
+		src.values = append(src.values, types.OffChainData{
+			Key:      crypto.Keccak256Hash([]byte(value)),
+			Value:    []byte(value),
+			BatchNum: uint64(i + 1),
+		})
+	}
+	sort.Slice(src.values, func(i, j int) bool {
+		return bytes.Compare(src.values[i].Key.Bytes(), src.values[j].Key.Bytes()) < 0
+	})
+
+	return src
+}
+
+// tamper returns the snapshot with the last byte of its data changed
+func tamper(t *testing.T, snapshot []byte) []byte {
+	t.Helper()
+
+	var out bytes.Buffer
+	tr, tw := tar.NewReader(bytes.NewReader(snapshot)), tar.NewWriter(&out)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		if header.Name == dataName {
+			content[len(content)-1] ^= 0xff
+		}
+
+		require.NoError(t, tw.WriteHeader(header))
+		_, err = tw.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+
+	return out.Bytes()
+}
+
+func TestSnapshot(t *testing.T) {
+	t.Parallel()
+
+	validium := common.HexToAddress("0x1")
+	src := newSource("first", "second", "third")
+
+	var buf bytes.Buffer
+	manifest, err := Create(context.Background(), src, &buf, validium, 2)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), manifest.Values)
+	require.Equal(t, uint64(1), manifest.UnresolvedKeys)
+	snapshot := buf.Bytes()
+
+	t.Run("restored", func(t *testing.T) {
+		t.Parallel()
+
+		sink := &memory{}
+		restored, err := Restore(context.Background(), bytes.NewReader(snapshot), sink, validium, "0013.sql", 2)
+		require.NoError(t, err)
+		require.Equal(t, manifest.DataSHA256, restored.DataSHA256)
+		require.Equal(t, src.values, sink.values)
+		require.Equal(t, src.unresolved, sink.unresolved)
+		require.Equal(t, src.blocks, sink.blocks)
+
+		// the values are recorded as restored from the snapshot
+		require.Len(t, sink.provenance, len(src.values))
+		for i, provenance := range sink.provenance {
+			require.Equal(t, src.values[i].Key, provenance.Key)
+			require.Equal(t, types.ProvenanceSnapshot, provenance.Source)
+			require.Equal(t, manifest.DataSHA256, provenance.Origin)
+		}
+	})
+
+	t.Run("tampered data", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Restore(context.Background(), bytes.NewReader(tamper(t, snapshot)), &memory{}, validium, "0012.sql", 2)
+		require.Error(t, err)
+	})
+
+	t.Run("snapshot of another chain", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Restore(context.Background(), bytes.NewReader(snapshot), &memory{},
+			common.HexToAddress("0x2"), "0012.sql", 2)
+		require.ErrorContains(t, err, "the snapshot is of the PolygonValidium contract")
+	})
+
+	t.Run("snapshot of a more recent schema", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Restore(context.Background(), bytes.NewReader(snapshot), &memory{}, validium, "0011.sql", 2)
+		require.ErrorContains(t, err, "is more recent than the database schema 0011.sql")
+	})
+}
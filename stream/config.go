@@ -0,0 +1,65 @@
+package stream
+
+import "github.com/0xPolygon/cdk-data-availability/config/types"
+
+// Config represents the configuration of the stream of the events of the stored values,
+// consumed by indexers and analytics pipelines
+type Config struct {
+	// Interval is the time between two rounds streaming the pending events
+	Interval types.Duration `mapstructure:"Interval"`
+
+	// BatchSize is the maximum number of events streamed in a round
+	BatchSize uint `mapstructure:"BatchSize"`
+
+	// Timeout bounds the delivery of each round of events
+	Timeout types.Duration `mapstructure:"Timeout"`
+
+	// Kafka is the configuration of the Kafka producer
+	Kafka KafkaConfig `mapstructure:"Kafka"`
+
+	// NATS is the configuration of the NATS JetStream producer
+	NATS NATSConfig `mapstructure:"NATS"`
+}
+
+// Enabled tells whether the events are streamed to any broker
+func (c Config) Enabled() bool {
+	return c.Kafka.Enabled || c.NATS.Enabled
+}
+
+// KafkaConfig represents the configuration of the Kafka producer. The events are keyed
+// by the key of the value, so the events of a value land in the same partition.
+type KafkaConfig struct {
+	// Enabled streams the events to Kafka
+	Enabled bool `mapstructure:"Enabled"`
+
+	// Brokers are the addresses of the bootstrap brokers, e.g. ["localhost:9092"]
+	Brokers []string `mapstructure:"Brokers"`
+
+	// Topic the events are written to
+	Topic string `mapstructure:"Topic"`
+
+	// TLS connects to the brokers over TLS
+	TLS bool `mapstructure:"TLS"`
+
+	// Username authenticates to the brokers with SASL/PLAIN, if set
+	Username string `mapstructure:"Username"`
+
+	// Password of the SASL/PLAIN user
+	Password string `mapstructure:"Password" secret:"true"`
+}
+
+// NATSConfig represents the configuration of the NATS JetStream producer. The stream capturing
+// the subject must exist, its duplicate window discards the events delivered more than once.
+type NATSConfig struct {
+	// Enabled streams the events to NATS JetStream
+	Enabled bool `mapstructure:"Enabled"`
+
+	// URL of the NATS server, e.g. nats://localhost:4222
+	URL string `mapstructure:"URL"`
+
+	// Subject the events are published to
+	Subject string `mapstructure:"Subject"`
+
+	// Token authenticates to the server, if set
+	Token string `mapstructure:"Token" secret:"true"`
+}
@@ -0,0 +1,83 @@
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"strconv"
+
+	"github.com/0xPolygon/cdk-data-availability/stream"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl/plain"
+)
+
+// Name is the name of the Kafka producer
+const Name = "kafka"
+
+// Producer writes the events to a Kafka topic, keyed by the key of the value
+type Producer struct {
+	writer *kafka.Writer
+}
+
+// New returns a Producer writing to the configured topic
+func New(cfg stream.KafkaConfig) *Producer {
+	transport := &kafka.Transport{}
+	if cfg.TLS {
+		transport.TLS = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	if cfg.Username != "" {
+		transport.SASL = plain.Mechanism{Username: cfg.Username, Password: cfg.Password}
+	}
+
+	return &Producer{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(cfg.Brokers...),
+			Topic:        cfg.Topic,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+			Transport:    transport,
+		},
+	}
+}
+
+// Name returns the name of the producer
+func (p *Producer) Name() string {
+	return Name
+}
+
+// Send writes the events and waits for all the in-sync replicas to acknowledge them
+func (p *Producer) Send(ctx context.Context, events []types.StoredDataEvent) error {
+	msgs, err := messages(events)
+	if err != nil {
+		return err
+	}
+
+	return p.writer.WriteMessages(ctx, msgs...)
+}
+
+// Close flushes and closes the writer
+func (p *Producer) Close() error {
+	return p.writer.Close()
+}
+
+// messages returns the Kafka messages of the events, the ID header identifies the duplicates
+func messages(events []types.StoredDataEvent) ([]kafka.Message, error) {
+	msgs := make([]kafka.Message, len(events))
+	for i, event := range events {
+		value, err := json.Marshal(event)
+		if err != nil {
+			return nil, err
+		}
+
+		msgs[i] = kafka.Message{
+			Key:   event.Key.Bytes(),
+			Value: value,
+			Headers: []kafka.Header{
+				{Key: "id", Value: []byte(strconv.FormatUint(event.ID, 10))},
+			},
+		}
+	}
+
+	return msgs, nil
+}
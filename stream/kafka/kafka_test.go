@@ -0,0 +1,36 @@
+package kafka
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessages(t *testing.T) {
+	event := types.StoredDataEvent{
+		ID:       42,
+		Key:      common.HexToHash("0x1"),
+		BatchNum: 7,
+		Size:     120,
+		StoredAt: time.Unix(1700000000, 0).UTC(),
+	}
+
+	msgs, err := messages([]types.StoredDataEvent{event})
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+
+	require.Equal(t, event.Key.Bytes(), msgs[0].Key)
+	require.Equal(t, "id", msgs[0].Headers[0].Key)
+	require.Equal(t, "42", string(msgs[0].Headers[0].Value))
+
+	var decoded types.StoredDataEvent
+	require.NoError(t, json.Unmarshal(msgs[0].Value, &decoded))
+	require.Equal(t, event, decoded)
+	require.JSONEq(t,
+		`{"id":42,"key":"`+event.Key.Hex()+`","batchNum":7,"size":120,"storedAt":"2023-11-14T22:13:20Z"}`,
+		string(msgs[0].Value))
+}
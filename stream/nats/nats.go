@@ -0,0 +1,82 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/0xPolygon/cdk-data-availability/stream"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/nats-io/nats.go"
+)
+
+// Name is the name of the NATS JetStream producer
+const Name = "nats"
+
+// Producer publishes the events to a subject captured by a JetStream stream
+type Producer struct {
+	subject string
+	conn    *nats.Conn
+	js      nats.JetStreamContext
+}
+
+// New returns a Producer connected to the configured server. The connection is retried
+// in the background if the server is not reachable yet.
+func New(cfg stream.NATSConfig) (*Producer, error) {
+	opts := []nats.Option{
+		nats.Name("cdk-data-availability"),
+		nats.RetryOnFailedConnect(true),
+		nats.MaxReconnects(-1),
+	}
+	if cfg.Token != "" {
+		opts = append(opts, nats.Token(cfg.Token))
+	}
+
+	conn, err := nats.Connect(cfg.URL, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	js, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return &Producer{
+		subject: cfg.Subject,
+		conn:    conn,
+		js:      js,
+	}, nil
+}
+
+// Name returns the name of the producer
+func (p *Producer) Name() string {
+	return Name
+}
+
+// Send publishes the events one by one, waiting for the stream to acknowledge each of them
+func (p *Producer) Send(ctx context.Context, events []types.StoredDataEvent) error {
+	for _, event := range events {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		if _, err = p.js.Publish(p.subject, data, nats.MsgId(msgID(event)), nats.Context(ctx)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close drains and closes the connection
+func (p *Producer) Close() error {
+	return p.conn.Drain()
+}
+
+// msgID is the JetStream message ID of an event, deduplicated within the duplicate window of the stream
+func msgID(event types.StoredDataEvent) string {
+	return strconv.FormatUint(event.ID, 10)
+}
@@ -0,0 +1,107 @@
+package nats
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/stream"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// published is a message published to the fake server
+type published struct {
+	subject string
+	headers string
+	payload string
+}
+
+// fakeServer speaks enough of the NATS protocol to acknowledge the JetStream publications
+func fakeServer(t *testing.T, msgs chan<- published) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		fmt.Fprint(conn, `INFO {"server_id":"fake","version":"2.10.0","proto":1,"headers":true,"max_payload":1048576}`+"\r\n")
+
+		seq := 0
+		sids := make(map[string]string)
+		r := bufio.NewReader(conn)
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			fields := strings.Fields(line)
+			if len(fields) == 0 {
+				continue
+			}
+
+			switch fields[0] {
+			case "PING":
+				fmt.Fprint(conn, "PONG\r\n")
+			case "SUB":
+				// SUB <subject> <sid>
+				sids[strings.TrimSuffix(fields[1], "*")] = fields[2]
+			case "HPUB":
+				// HPUB <subject> <reply> <header size> <total size>
+				headerSize, _ := strconv.Atoi(fields[3])
+				totalSize, _ := strconv.Atoi(fields[4])
+				body := make([]byte, totalSize+2)
+				if _, err = io.ReadFull(r, body); err != nil {
+					return
+				}
+				msgs <- published{
+					subject: fields[1],
+					headers: string(body[:headerSize]),
+					payload: string(body[headerSize:totalSize]),
+				}
+
+				seq++
+				ack := fmt.Sprintf(`{"stream":"DA","seq":%d}`, seq)
+				reply := fields[2]
+				sid := sids[reply[:strings.LastIndex(reply, ".")+1]]
+				fmt.Fprintf(conn, "MSG %s %s %d\r\n%s\r\n", reply, sid, len(ack), ack)
+			}
+		}
+	}()
+
+	return "nats://" + listener.Addr().String()
+}
+
+func TestProducer_Send(t *testing.T) {
+	msgs := make(chan published, 1)
+	url := fakeServer(t, msgs)
+
+	p, err := New(stream.NATSConfig{Enabled: true, URL: url, Subject: "da.stored"})
+	require.NoError(t, err)
+	defer p.Close()
+
+	event := types.StoredDataEvent{ID: 42, Key: common.HexToHash("0x1"), BatchNum: 7, Size: 120}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, p.Send(ctx, []types.StoredDataEvent{event}))
+
+	msg := <-msgs
+	require.Equal(t, "da.stored", msg.subject)
+	require.Contains(t, msg.headers, "Nats-Msg-Id: 42")
+	require.Contains(t, msg.payload, `"key":"`+event.Key.Hex()+`"`)
+}
@@ -0,0 +1,101 @@
+package stream
+
+import (
+	"context"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/metrics"
+	"github.com/0xPolygon/cdk-data-availability/reporter"
+	"github.com/0xPolygon/cdk-data-availability/types"
+)
+
+// logger is the logger of the stream component
+var logger = log.WithComponent("stream")
+
+// Producer delivers the events of the stored values to a message broker
+type Producer interface {
+	// Name identifies the broker, e.g. kafka
+	Name() string
+	// Send delivers the events in order, returning once the broker acknowledged all of them
+	Send(ctx context.Context, events []types.StoredDataEvent) error
+	// Close releases the connections to the broker
+	Close() error
+}
+
+// Worker periodically streams the events of the transactional outbox, written along with the
+// stored values, and deletes them once delivered. The events are delivered at least once.
+type Worker struct {
+	cfg      Config
+	db       db.DB
+	producer Producer
+	stop     chan struct{}
+}
+
+// NewWorker returns a Worker streaming the events to the given broker
+func NewWorker(cfg Config, db db.DB, producer Producer) *Worker {
+	return &Worker{
+		cfg:      cfg,
+		db:       db,
+		producer: producer,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start streams the pending events every interval until the worker is stopped
+func (w *Worker) Start(ctx context.Context) {
+	defer reporter.Recover()
+	defer func() {
+		if err := w.producer.Close(); err != nil {
+			logger.Warnf("failed to close the %s producer: %v", w.producer.Name(), err)
+		}
+	}()
+
+	logger.Infof("starting to stream the events to %s", w.producer.Name())
+	ticker := time.NewTicker(w.cfg.Interval.Duration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := w.SendPending(ctx); err != nil {
+				logger.Errorf("failed to stream the events to %s: %v", w.producer.Name(), err)
+			}
+		case <-ctx.Done():
+			return
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+// Stop stops the worker
+func (w *Worker) Stop() {
+	close(w.stop)
+}
+
+// SendPending streams up to a batch of pending events, and returns how many were delivered
+func (w *Worker) SendPending(parentCtx context.Context) (int, error) {
+	ctx, cancel := context.WithTimeout(parentCtx, w.cfg.Timeout.Duration)
+	defer cancel()
+
+	events, err := w.db.GetOutboxEvents(ctx, w.cfg.BatchSize)
+	if err != nil || len(events) == 0 {
+		return 0, err
+	}
+
+	err = w.producer.Send(ctx, events)
+	metrics.StreamEvents(w.producer.Name(), len(events), err)
+	if err != nil {
+		return 0, err
+	}
+
+	ids := make([]uint64, len(events))
+	for i, event := range events {
+		ids[i] = event.ID
+	}
+
+	// the events are streamed again if they could not be deleted
+	return len(events), w.db.DeleteOutboxEvents(ctx, ids)
+}
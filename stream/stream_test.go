@@ -0,0 +1,81 @@
+package stream_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	cfgTypes "github.com/0xPolygon/cdk-data-availability/config/types"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/stream"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProducer records the events sent, or fails if err is set
+type fakeProducer struct {
+	err  error
+	sent []types.StoredDataEvent
+}
+
+func (p *fakeProducer) Name() string {
+	return "fake"
+}
+
+func (p *fakeProducer) Send(_ context.Context, events []types.StoredDataEvent) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.sent = append(p.sent, events...)
+	return nil
+}
+
+func (p *fakeProducer) Close() error {
+	return nil
+}
+
+func TestWorker_SendPending(t *testing.T) {
+	cfg := stream.Config{
+		Interval:  cfgTypes.NewDuration(time.Second),
+		BatchSize: 10,
+		Timeout:   cfgTypes.NewDuration(5 * time.Second),
+	}
+	pending := []types.StoredDataEvent{
+		{ID: 4, Key: common.HexToHash("0x1"), BatchNum: 1, Size: 10},
+		{ID: 9, Key: common.HexToHash("0x2"), BatchNum: 2, Size: 20},
+	}
+
+	t.Run("events delivered and deleted", func(t *testing.T) {
+		dbMock := mocks.NewDB(t)
+		dbMock.On("GetOutboxEvents", mock.Anything, uint(10)).Return(pending, nil).Once()
+		dbMock.On("DeleteOutboxEvents", mock.Anything, []uint64{4, 9}).Return(nil).Once()
+
+		fake := &fakeProducer{}
+		sent, err := stream.NewWorker(cfg, dbMock, fake).SendPending(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 2, sent)
+		require.Equal(t, pending, fake.sent)
+	})
+
+	t.Run("events kept when not delivered", func(t *testing.T) {
+		dbMock := mocks.NewDB(t)
+		dbMock.On("GetOutboxEvents", mock.Anything, uint(10)).Return(pending, nil).Once()
+
+		sent, err := stream.NewWorker(cfg, dbMock, &fakeProducer{err: errors.New("broker unavailable")}).
+			SendPending(context.Background())
+		require.ErrorContains(t, err, "broker unavailable")
+		require.Equal(t, 0, sent)
+	})
+
+	t.Run("no pending events", func(t *testing.T) {
+		dbMock := mocks.NewDB(t)
+		dbMock.On("GetOutboxEvents", mock.Anything, uint(10)).Return([]types.StoredDataEvent{}, nil).Once()
+
+		sent, err := stream.NewWorker(cfg, dbMock, &fakeProducer{}).SendPending(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 0, sent)
+	})
+}
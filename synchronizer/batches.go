@@ -2,10 +2,13 @@ package synchronizer
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/big"
 	"math/rand"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/0xPolygon/cdk-data-availability/client"
@@ -13,15 +16,28 @@ import (
 	"github.com/0xPolygon/cdk-data-availability/db"
 	"github.com/0xPolygon/cdk-data-availability/etherman"
 	"github.com/0xPolygon/cdk-data-availability/etherman/smartcontracts/etrog/polygonvalidium"
+	"github.com/0xPolygon/cdk-data-availability/fault"
+	"github.com/0xPolygon/cdk-data-availability/gossip"
 	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/metrics"
+	"github.com/0xPolygon/cdk-data-availability/notifier"
+	"github.com/0xPolygon/cdk-data-availability/reporter"
 	"github.com/0xPolygon/cdk-data-availability/rpc"
 	"github.com/0xPolygon/cdk-data-availability/sequencer"
+	"github.com/0xPolygon/cdk-data-availability/tracing"
 	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/0xPolygon/cdk-data-availability/webhook"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// logger is the logger of the synchronizer component
+var logger = log.WithComponent("synchronizer")
+
 const defaultBlockBatchSize = 32
 
 // SequencerTracker is an interface that defines functions that a sequencer tracker must implement
@@ -29,48 +45,85 @@ type SequencerTracker interface {
 	GetSequenceBatch(ctx context.Context, batchNum uint64) (*sequencer.SeqBatch, error)
 }
 
+// BlobFetcher is an interface that defines functions that a fetcher of the blobs of L1 blocks must implement
+type BlobFetcher interface {
+	GetBlobs(ctx context.Context, timestamp uint64, hashes []common.Hash) ([]kzg4844.Blob, error)
+}
+
 // BatchSynchronizer watches for number events, checks if they are
 // "locally" stored, then retrieves and stores missing data
 type BatchSynchronizer struct {
 	client           etherman.Etherman
 	stop             chan struct{}
+	settingsLock     sync.RWMutex
 	retry            time.Duration
 	rpcTimeout       time.Duration
+	dbTimeout        time.Duration
+	committeeTimeout time.Duration
 	blockBatchSize   uint
-	self             common.Address
-	db               db.DB
-	committee        *CommitteeMapSafe
-	syncLock         sync.Mutex
-	reorgs           <-chan BlockReorg
-	sequencer        SequencerTracker
-	rpcClientFactory client.Factory
+	eventConcurrency uint
+	// resolveConcurrency is the number of missing batches resolved at once
+	resolveConcurrency uint
+	self               common.Address
+	db                 db.DB
+	committee          *CommitteeMapSafe
+	committeeHits      atomic.Uint64
+	committeeMisses    atomic.Uint64
+	syncLock           sync.Mutex
+	reorgs             <-chan BlockReorg
+	sequencer          SequencerTracker
+	blobs              BlobFetcher
+	rpcClientFactory   client.Factory
+	// maxValueSize is the maximum size of the data of a batch resolved, 0 for no limit
+	maxValueSize uint64
+	// storeChunkSize is the size of the data resolved after which it is stored, 0 for no limit
+	storeChunkSize uint64
 }
 
 // NewBatchSynchronizer creates the BatchSynchronizer
 func NewBatchSynchronizer(
 	cfg config.L1Config,
+	timeouts config.TimeoutsConfig,
+	limits config.LimitsConfig,
 	self common.Address,
 	db db.DB,
 	reorgs <-chan BlockReorg,
 	ethClient etherman.Etherman,
 	sequencer SequencerTracker,
+	blobs BlobFetcher,
 	rpcClientFactory client.Factory,
 ) (*BatchSynchronizer, error) {
 	if cfg.BlockBatchSize == 0 {
-		log.Infof("block number size is not set, setting to default %d", defaultBlockBatchSize)
+		logger.Infof("block number size is not set, setting to default %d", defaultBlockBatchSize)
 		cfg.BlockBatchSize = defaultBlockBatchSize
 	}
+	dbTimeout := timeouts.DBOperation.Duration
+	if dbTimeout <= 0 {
+		dbTimeout = defaultDBTimeout
+	}
+	committeeTimeout := timeouts.CommitteeRPC.Duration
+	if committeeTimeout <= 0 {
+		committeeTimeout = cfg.Timeout.Duration
+	}
 	synchronizer := &BatchSynchronizer{
-		client:           ethClient,
-		stop:             make(chan struct{}),
-		retry:            cfg.RetryPeriod.Duration,
-		rpcTimeout:       cfg.Timeout.Duration,
-		blockBatchSize:   cfg.BlockBatchSize,
-		self:             self,
-		db:               db,
-		reorgs:           reorgs,
-		sequencer:        sequencer,
-		rpcClientFactory: rpcClientFactory,
+		client:             ethClient,
+		stop:               make(chan struct{}),
+		retry:              cfg.RetryPeriod.Duration,
+		rpcTimeout:         cfg.Timeout.Duration,
+		dbTimeout:          dbTimeout,
+		committeeTimeout:   committeeTimeout,
+		blockBatchSize:     cfg.BlockBatchSize,
+		eventConcurrency:   cfg.EventConcurrency,
+		resolveConcurrency: cfg.ResolveConcurrency,
+		self:               self,
+		db:                 db,
+		committee:          NewCommitteeMapSafe(),
+		reorgs:             reorgs,
+		sequencer:          sequencer,
+		blobs:              blobs,
+		rpcClientFactory:   rpcClientFactory,
+		maxValueSize:       limits.MaxValueSize,
+		storeChunkSize:     limits.StoreChunkSize,
 	}
 	return synchronizer, synchronizer.resolveCommittee()
 }
@@ -88,15 +141,89 @@ func (bs *BatchSynchronizer) resolveCommittee() error {
 		}
 	}
 
-	bs.committee = NewCommitteeMapSafe()
+	// the map is only resolved when empty, it is kept so it can be inspected concurrently
 	bs.committee.StoreBatch(filteredMembers)
 	return nil
 }
 
+// Committee returns the committee members cached to resolve the missing batches
+func (bs *BatchSynchronizer) Committee() []etherman.DataCommitteeMember {
+	return bs.committee.AsSlice()
+}
+
+// CommitteeCacheStats returns the number of times the cached committee was used to resolve
+// a batch (hits), and the number of times it had to be resolved again from L1 (misses)
+func (bs *BatchSynchronizer) CommitteeCacheStats() (hits, misses uint64) {
+	return bs.committeeHits.Load(), bs.committeeMisses.Load()
+}
+
+// Reload applies the settings of the given config that are safe to change while running
+func (bs *BatchSynchronizer) Reload(cfg config.L1Config) {
+	bs.settingsLock.Lock()
+	defer bs.settingsLock.Unlock()
+
+	if cfg.RetryPeriod.Duration > 0 && cfg.RetryPeriod.Duration != bs.retry {
+		logger.Infof("synchronizer retry period changed from %v to %v", bs.retry, cfg.RetryPeriod.Duration)
+		bs.retry = cfg.RetryPeriod.Duration
+	}
+
+	if cfg.BlockBatchSize > 0 && cfg.BlockBatchSize != bs.blockBatchSize {
+		logger.Infof("synchronizer block batch size changed from %d to %d", bs.blockBatchSize, cfg.BlockBatchSize)
+		bs.blockBatchSize = cfg.BlockBatchSize
+	}
+
+	if cfg.EventConcurrency > 0 && cfg.EventConcurrency != bs.eventConcurrency {
+		logger.Infof("synchronizer event concurrency changed from %d to %d", bs.eventConcurrency, cfg.EventConcurrency)
+		bs.eventConcurrency = cfg.EventConcurrency
+	}
+
+	if cfg.ResolveConcurrency > 0 && cfg.ResolveConcurrency != bs.resolveConcurrency {
+		logger.Infof("synchronizer resolve concurrency changed from %d to %d",
+			bs.resolveConcurrency, cfg.ResolveConcurrency)
+		bs.resolveConcurrency = cfg.ResolveConcurrency
+	}
+}
+
+func (bs *BatchSynchronizer) retryPeriod() time.Duration {
+	bs.settingsLock.RLock()
+	defer bs.settingsLock.RUnlock()
+	return bs.retry
+}
+
+func (bs *BatchSynchronizer) batchSize() uint {
+	bs.settingsLock.RLock()
+	defer bs.settingsLock.RUnlock()
+	return bs.blockBatchSize
+}
+
+func (bs *BatchSynchronizer) concurrency() uint {
+	bs.settingsLock.RLock()
+	defer bs.settingsLock.RUnlock()
+	if bs.eventConcurrency == 0 {
+		return 1
+	}
+	return bs.eventConcurrency
+}
+
+func (bs *BatchSynchronizer) resolves() uint {
+	bs.settingsLock.RLock()
+	defer bs.settingsLock.RUnlock()
+	if bs.resolveConcurrency == 0 {
+		return 1
+	}
+	return bs.resolveConcurrency
+}
+
 // Start starts the synchronizer
 func (bs *BatchSynchronizer) Start(ctx context.Context) {
-	log.Infof("starting batch synchronizer, DAC addr: %v", bs.self)
+	logger.Infof("starting batch synchronizer, DAC addr: %v", bs.self)
 	go bs.processUnresolvedBatches(ctx)
+	bs.StartEvents(ctx)
+}
+
+// StartEvents starts the synchronization of the SequenceBatches events and of the reorganizations only, the
+// missing batches being stored as unresolved and never resolved, as when replaying a recording of L1
+func (bs *BatchSynchronizer) StartEvents(ctx context.Context) {
 	go bs.produceEvents(ctx)
 	go bs.handleReorgs(ctx)
 }
@@ -107,15 +234,17 @@ func (bs *BatchSynchronizer) Stop() {
 }
 
 func (bs *BatchSynchronizer) handleReorgs(ctx context.Context) {
-	log.Info("starting reorgs handler")
+	defer reporter.Recover()
+
+	logger.Info("starting reorgs handler")
 	for {
 		select {
 		case r := <-bs.reorgs:
 			bs.syncLock.Lock()
 
-			latest, err := getStartBlock(ctx, bs.db, L1SyncTask)
+			latest, err := getStartBlock(ctx, bs.db, bs.dbTimeout, L1SyncTask)
 			if err != nil {
-				log.Errorf("could not determine latest processed block: %v", err)
+				logger.Errorf("could not determine latest processed block: %v", err)
 				bs.syncLock.Unlock()
 
 				continue
@@ -127,8 +256,8 @@ func (bs *BatchSynchronizer) handleReorgs(ctx context.Context) {
 				continue
 			}
 
-			if err = setStartBlock(ctx, bs.db, r.Number, L1SyncTask); err != nil {
-				log.Errorf("failed to store new start block to %d: %v", r.Number, err)
+			if err = setStartBlock(ctx, bs.db, bs.dbTimeout, r.Number, L1SyncTask); err != nil {
+				logger.WithFields(log.FieldBlockNumber, r.Number).Errorf("failed to store new start block: %v", err)
 			}
 
 			bs.syncLock.Unlock()
@@ -139,13 +268,15 @@ func (bs *BatchSynchronizer) handleReorgs(ctx context.Context) {
 }
 
 func (bs *BatchSynchronizer) produceEvents(ctx context.Context) {
-	log.Info("starting event producer")
+	defer reporter.Recover()
+
+	logger.Info("starting event producer")
 	for {
-		delay := time.NewTimer(bs.retry)
+		delay := time.NewTimer(bs.retryPeriod())
 		select {
 		case <-delay.C:
 			if err := bs.filterEvents(ctx); err != nil {
-				log.Errorf("error filtering events: %v", err)
+				logger.Errorf("error filtering events: %v", err)
 			}
 		case <-bs.stop:
 			return
@@ -154,24 +285,35 @@ func (bs *BatchSynchronizer) produceEvents(ctx context.Context) {
 }
 
 // Start an iterator from last block processed, picking off SequenceBatches events
-func (bs *BatchSynchronizer) filterEvents(ctx context.Context) error {
+func (bs *BatchSynchronizer) filterEvents(parentCtx context.Context) (err error) {
 	bs.syncLock.Lock()
 	defer bs.syncLock.Unlock()
 
-	start, err := getStartBlock(ctx, bs.db, L1SyncTask)
+	ctx, span := tracing.Start(parentCtx, "synchronizer.filterEvents", trace.SpanKindInternal)
+	defer func() { tracing.End(span, err) }()
+
+	// an injected fault fails the filtering as an unreachable L1 node would
+	if err = fault.Before(ctx, "synchronizer.filterEvents"); err != nil {
+		return err
+	}
+
+	start, err := getStartBlock(ctx, bs.db, bs.dbTimeout, L1SyncTask)
 	if err != nil {
 		return err
 	}
 
-	end := start + uint64(bs.blockBatchSize)
+	end := start + uint64(bs.batchSize())
 
 	// get the latest block number
 	header, err := bs.client.HeaderByNumber(ctx, nil)
 	if err != nil {
-		log.Errorf("failed to determine latest block number: %v", err)
+		logger.Errorf("failed to determine latest block number: %v", err)
 		return err
 	}
 
+	metrics.L1Head(header.Number.Uint64())
+	notifier.SyncLag(start, header.Number.Uint64())
+
 	// we don't want to scan beyond latest block
 	if end > header.Number.Uint64() {
 		end = header.Number.Uint64()
@@ -184,7 +326,7 @@ func (bs *BatchSynchronizer) filterEvents(ctx context.Context) error {
 			End:     &end,
 		}, nil)
 	if err != nil {
-		log.Errorf("failed to create SequenceBatches event iterator: %v", err)
+		logger.Errorf("failed to create SequenceBatches event iterator: %v", err)
 		return err
 	}
 
@@ -199,7 +341,7 @@ func (bs *BatchSynchronizer) filterEvents(ctx context.Context) error {
 	}
 
 	if err = iter.Close(); err != nil {
-		log.Errorf("failed to close SequenceBatches event iterator: %v", err)
+		logger.Errorf("failed to close SequenceBatches event iterator: %v", err)
 	}
 
 	// Sort events by block number ascending
@@ -207,56 +349,221 @@ func (bs *BatchSynchronizer) filterEvents(ctx context.Context) error {
 		return events[i].Raw.BlockNumber < events[j].Raw.BlockNumber
 	})
 
+	// Fetch the transactions of the events concurrently, overlapping the L1 requests, while storing
+	// the events in block order so that the start block never moves past an event not stored
+	fetchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	fetched := bs.fetchEvents(fetchCtx, events)
+
 	// Handle events
-	for _, event := range events {
-		if err = bs.handleEvent(ctx, event); err != nil {
-			log.Errorf("failed to handle event: %v", err)
-			return setStartBlock(ctx, bs.db, event.Raw.BlockNumber-1, L1SyncTask)
+	for i, event := range events {
+		eventLogger := logger.WithFields(
+			log.FieldBlockNumber, event.Raw.BlockNumber,
+			log.FieldTxHash, event.Raw.TxHash.Hex(),
+			log.FieldBatchNumber, event.NumBatch,
+		)
+
+		result := <-fetched[i]
+		err = result.err
+		if err == nil {
+			err = bs.storeEvent(ctx, event, result.data)
+		}
+		if errors.Is(err, ErrMalformedSequence) {
+			// handling the event again would fail the same way and stall the synchronizer
+			eventLogger.Errorf("skipping the event: %v", err)
+			metrics.SequenceEventSkipped()
+			continue
+		}
+		if errors.Is(err, ErrUnknownMethod) {
+			// the data of the sequence is stored once the node is updated to decode its transaction
+			notifier.UnknownSequenceMethod(event.Raw.TxHash.Hex(), event.Raw.BlockNumber, err)
+		}
+		metrics.SequenceEvent(err)
+		if err != nil {
+			eventLogger.Errorf("failed to handle event: %v", err)
+			return setStartBlock(ctx, bs.db, bs.dbTimeout, event.Raw.BlockNumber-1, L1SyncTask)
 		}
 	}
 
-	return setStartBlock(ctx, bs.db, end, L1SyncTask)
+	return setStartBlock(ctx, bs.db, bs.dbTimeout, end, L1SyncTask)
+}
+
+// sequencedData is what the transaction of a SequenceBatches event sequences: the keys of the batches for
+// the committee to resolve, or the data of its blobs
+type sequencedData struct {
+	batchKeys []types.BatchKey
+	blobs     []types.OffChainData
+}
+
+// fetchedEvent is the result of fetching the data sequenced by an event
+type fetchedEvent struct {
+	data *sequencedData
+	err  error
+}
+
+// fetchEvents fetches the data sequenced by the events, up to the configured number at once. The result
+// of each event is sent to the channel at its index, in whatever order they complete.
+func (bs *BatchSynchronizer) fetchEvents(
+	ctx context.Context,
+	events []*polygonvalidium.PolygonvalidiumSequenceBatches,
+) []chan fetchedEvent {
+	results := make([]chan fetchedEvent, len(events))
+	for i := range results {
+		results[i] = make(chan fetchedEvent, 1)
+	}
+
+	slots := make(chan struct{}, bs.concurrency())
+	go func() {
+		for i, event := range events {
+			slots <- struct{}{}
+			go func(event *polygonvalidium.PolygonvalidiumSequenceBatches, result chan<- fetchedEvent) {
+				defer func() { <-slots }()
+
+				data, err := bs.fetchEvent(ctx, event)
+				result <- fetchedEvent{data: data, err: err}
+			}(event, results[i])
+		}
+	}()
+
+	return results
 }
 
+// handleEvent fetches the data sequenced by the event and stores it
 func (bs *BatchSynchronizer) handleEvent(
-	parentCtx context.Context,
+	ctx context.Context,
 	event *polygonvalidium.PolygonvalidiumSequenceBatches,
 ) error {
+	data, err := bs.fetchEvent(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	return bs.storeEvent(ctx, event, data)
+}
+
+// fetchEvent reads the transaction of the event from L1, returning the data it sequences
+func (bs *BatchSynchronizer) fetchEvent(
+	parentCtx context.Context,
+	event *polygonvalidium.PolygonvalidiumSequenceBatches,
+) (_ *sequencedData, err error) {
+	// a panic fails the event, which is handled again from its block, instead of stopping the node
+	defer reporter.Isolate("synchronizer", &err)
+
 	ctx, cancel := context.WithTimeout(parentCtx, bs.rpcTimeout)
 	defer cancel()
 
 	tx, _, err := bs.client.GetTx(ctx, event.Raw.TxHash)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	// the batches of a sequence carrying blobs are committed to by the blobs instead of the committee
+	if hashes := tx.BlobHashes(); len(hashes) > 0 {
+		blobs, err := bs.fetchBlobs(ctx, event, hashes)
+		if err != nil {
+			return nil, err
+		}
+		return &sequencedData{blobs: blobs}, nil
 	}
 
 	keys, err := UnpackTxData(tx.Data())
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// The event has the _last_ batch number & list of hashes. Each hash is
 	// in order, so the batch number can be computed from position in array
-	var batchKeys []types.BatchKey
-	for i, j := 0, len(keys)-1; i < len(keys); i, j = i+1, j-1 {
-		batchKeys = append(batchKeys, types.BatchKey{
-			Number: event.NumBatch - uint64(i),
-			Hash:   keys[j],
-		})
+	batchKeys, err := SequenceBatchKeys(event.NumBatch, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sequencedData{batchKeys: batchKeys}, nil
+}
+
+// storeEvent stores the data sequenced by the event
+func (bs *BatchSynchronizer) storeEvent(
+	ctx context.Context,
+	event *polygonvalidium.PolygonvalidiumSequenceBatches,
+	data *sequencedData,
+) (err error) {
+	defer reporter.Isolate("synchronizer", &err)
+
+	if data.blobs != nil {
+		return bs.storeBlobs(ctx, event, data.blobs)
 	}
 
 	// Store batch keys. Already handled batch keys are going to be ignored based on the DB logic.
-	return storeUnresolvedBatchKeys(ctx, bs.db, batchKeys)
+	return storeUnresolvedBatchKeys(ctx, bs.db, bs.dbTimeout, data.batchKeys)
+}
+
+// fetchBlobs returns the blobs of a sequence once verified against their KZG commitments. The blobs
+// hold the L2 data of the batches of the sequence, so they are stored under the number of its last batch.
+func (bs *BatchSynchronizer) fetchBlobs(
+	ctx context.Context,
+	event *polygonvalidium.PolygonvalidiumSequenceBatches,
+	hashes []common.Hash,
+) ([]types.OffChainData, error) {
+	if bs.blobs == nil {
+		return nil, fmt.Errorf("the sequence carries %d blobs but no beacon API is configured", len(hashes))
+	}
+
+	header, err := bs.client.HeaderByNumber(ctx, new(big.Int).SetUint64(event.Raw.BlockNumber))
+	if err != nil {
+		return nil, err
+	}
+
+	blobs, err := bs.blobs.GetBlobs(ctx, header.Time, hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]types.OffChainData, len(blobs))
+	for i := range blobs {
+		value := make([]byte, len(blobs[i]))
+		copy(value, blobs[i][:])
+
+		data[i] = types.OffChainData{
+			Key:      crypto.Keccak256Hash(value),
+			Value:    value,
+			BatchNum: event.NumBatch,
+		}
+	}
+
+	return data, nil
+}
+
+// storeBlobs stores the blobs of a sequence like the data of the committee
+func (bs *BatchSynchronizer) storeBlobs(
+	ctx context.Context,
+	event *polygonvalidium.PolygonvalidiumSequenceBatches,
+	data []types.OffChainData,
+) error {
+	if err := storeOffchainData(ctx, bs.db, bs.dbTimeout, data); err != nil {
+		return err
+	}
+	provenance := types.NewProvenance(data, types.ProvenanceL1, event.Raw.TxHash.Hex())
+	if err := storeProvenance(ctx, bs.db, bs.dbTimeout, provenance); err != nil {
+		return err
+	}
+	for range data {
+		metrics.BatchResolved(metrics.SourceBlob)
+	}
+	webhook.DataStored(data)
+
+	return nil
 }
 
 func (bs *BatchSynchronizer) processUnresolvedBatches(ctx context.Context) {
-	log.Info("starting handling unresolved batches")
+	defer reporter.Recover()
+
+	logger.Info("starting handling unresolved batches")
 	for {
-		delay := time.NewTimer(bs.retry)
+		delay := time.NewTimer(bs.retryPeriod())
 		select {
 		case <-delay.C:
 			if err := bs.handleUnresolvedBatches(ctx); err != nil {
-				log.Error(err)
+				logger.Error(err)
 			}
 		case <-bs.stop:
 			return
@@ -265,13 +572,17 @@ func (bs *BatchSynchronizer) processUnresolvedBatches(ctx context.Context) {
 }
 
 // handleUnresolvedBatches handles unresolved batches that were collected by the event consumer
-func (bs *BatchSynchronizer) handleUnresolvedBatches(ctx context.Context) error {
+func (bs *BatchSynchronizer) handleUnresolvedBatches(ctx context.Context) (err error) {
+	defer reporter.Isolate("synchronizer", &err)
+
 	// Get unresolved batches
-	batchKeys, err := getUnresolvedBatchKeys(ctx, bs.db)
+	batchKeys, err := getUnresolvedBatchKeys(ctx, bs.db, bs.dbTimeout)
 	if err != nil {
 		return fmt.Errorf("failed to get unresolved batch keys: %v", err)
 	}
 
+	metrics.UnresolvedBatches(len(batchKeys))
+
 	if len(batchKeys) == 0 {
 		return nil
 	}
@@ -285,14 +596,14 @@ func (bs *BatchSynchronizer) handleUnresolvedBatches(ctx context.Context) error
 	}
 
 	// Get the existing offchain data by the given list of keys
-	existingOffchainData, err := listOffchainData(ctx, bs.db, keys)
+	existingOffchainData, err := listOffchainData(ctx, bs.db, bs.dbTimeout, keys)
 	if err != nil {
 		return fmt.Errorf("failed to list offchain data: %v", err)
 	}
 
-	// Resolve the unresolved data
-	data := make([]types.OffChainData, 0)
-	resolved := make([]types.BatchKey, 0)
+	// The data is stored by chunks as it is resolved, bounding the memory held. Each batch is only marked
+	// as resolved once its data is stored, so the batches not stored when interrupted are resolved again.
+	chunk := &resolvedChunk{}
 
 	// Go over existing keys and mark them as resolved if they exist.
 	// Update the batch number if it is zero.
@@ -300,66 +611,199 @@ func (bs *BatchSynchronizer) handleUnresolvedBatches(ctx context.Context) error
 		batchKey, ok := hashToKeys[extData.Key]
 		if !ok {
 			// This should not happen, but log it just in case
-			log.Errorf("unexpected key %s in the offchain data", extData.Key.Hex())
+			logger.WithFields(log.FieldKeyHash, extData.Key.Hex()).Error("unexpected key in the offchain data")
 			continue
 		}
 
 		// If the batch number is zero, update it
 		if extData.BatchNum == 0 {
 			extData.BatchNum = batchKey.Number
-			data = append(data, extData)
+			chunk.data = append(chunk.data, extData)
+			chunk.size += uint64(len(extData.Value))
 		}
 
 		// Mark the batch as resolved
-		resolved = append(resolved, batchKey)
+		chunk.resolved = append(chunk.resolved, batchKey)
 
 		// Remove the key from the map
 		delete(hashToKeys, extData.Key)
+
+		if err = bs.storeChunk(ctx, chunk, false); err != nil {
+			return err
+		}
 	}
 
-	// Resolve the remaining unresolved data
-	for _, key := range hashToKeys {
-		value, err := bs.resolve(ctx, key)
-		if err != nil {
-			log.Errorf("failed to resolve batch %s: %v", key.Hash.Hex(), err)
+	// Resolve the remaining unresolved data, the resolves still running are canceled if storing fails
+	resolveCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for result := range bs.resolveBatches(resolveCtx, hashToKeys) {
+		key, value, servedBy := result.key, result.value, result.servedBy
+		if result.err != nil {
+			logger.WithFields(log.FieldBatchNumber, key.Number, log.FieldKeyHash, key.Hash.Hex()).
+				Errorf("failed to resolve batch: %v", result.err)
+			metrics.BatchFailed()
 			continue
 		}
 
-		resolved = append(resolved, key)
-		data = append(data, *value)
+		chunk.resolved = append(chunk.resolved, key)
+		chunk.data = append(chunk.data, *value)
+		chunk.fetched = append(chunk.fetched, key.Hash)
+		chunk.size += uint64(len(value.Value))
+		if servedBy != nil {
+			chunk.served = append(chunk.served, *servedBy)
+			chunk.provenance = append(chunk.provenance, types.NewProvenance(
+				[]types.OffChainData{*value}, types.ProvenanceMember, servedBy.Member.Hex())...)
+		} else {
+			chunk.provenance = append(chunk.provenance, types.NewProvenance(
+				[]types.OffChainData{*value}, types.ProvenanceSequencer, "")...)
+		}
+
+		if err = bs.storeChunk(ctx, chunk, false); err != nil {
+			return err
+		}
+	}
+
+	return bs.storeChunk(ctx, chunk, true)
+}
+
+// resolvedBatch is the result of resolving a batch
+type resolvedBatch struct {
+	key      types.BatchKey
+	value    *types.OffChainData
+	servedBy *types.ServedValue
+	err      error
+}
+
+// resolveBatches resolves the batches, up to the configured number at once, and sends their results to the
+// channel in whatever order they complete. The channel is closed once all are resolved, or the context done.
+func (bs *BatchSynchronizer) resolveBatches(
+	ctx context.Context,
+	keys map[common.Hash]types.BatchKey,
+) <-chan resolvedBatch {
+	results := make(chan resolvedBatch)
+
+	slots := make(chan struct{}, bs.resolves())
+	go func() {
+		var wg sync.WaitGroup
+		defer func() {
+			wg.Wait()
+			close(results)
+		}()
+
+		for _, key := range keys {
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+
+			wg.Add(1)
+			go func(key types.BatchKey) {
+				defer func() {
+					<-slots
+					wg.Done()
+				}()
+
+				value, servedBy, err := bs.resolve(ctx, key)
+				select {
+				case results <- resolvedBatch{key: key, value: value, servedBy: servedBy, err: err}:
+				case <-ctx.Done():
+				}
+			}(key)
+		}
+	}()
+
+	return results
+}
+
+// resolvedChunk holds the batches resolved and not stored yet
+type resolvedChunk struct {
+	data       []types.OffChainData
+	fetched    []common.Hash
+	served     []types.ServedValue
+	provenance []types.Provenance
+	resolved   []types.BatchKey
+	// size is the size of the data held
+	size uint64
+}
+
+// storeChunk stores the data of the chunk once it reached the chunk size, or whatever its size when last,
+// then marks its batches as resolved and empties it
+func (bs *BatchSynchronizer) storeChunk(ctx context.Context, chunk *resolvedChunk, last bool) error {
+	if !last && (bs.storeChunkSize == 0 || chunk.size < bs.storeChunkSize) {
+		return nil
 	}
 
 	// Store data of the batches to the DB
-	if len(data) > 0 {
-		if err = storeOffchainData(ctx, bs.db, data); err != nil {
+	if len(chunk.data) > 0 {
+		if err := storeOffchainData(ctx, bs.db, bs.dbTimeout, chunk.data); err != nil {
 			return fmt.Errorf("failed to store offchain data: %v", err)
 		}
+		gossip.Announce(chunk.fetched)
+		webhook.DataStored(chunk.data)
+	}
+
+	// Record where the data was fetched from
+	if len(chunk.provenance) > 0 {
+		if err := storeProvenance(ctx, bs.db, bs.dbTimeout, chunk.provenance); err != nil {
+			return fmt.Errorf("failed to store the provenance of the data: %v", err)
+		}
+	}
+	if len(chunk.served) > 0 {
+		if err := storeServedValues(ctx, bs.db, bs.dbTimeout, chunk.served); err != nil {
+			return fmt.Errorf("failed to store the members the data was served by: %v", err)
+		}
 	}
 
 	// Mark batches as resolved
-	if len(resolved) > 0 {
-		if err = deleteUnresolvedBatchKeys(ctx, bs.db, resolved); err != nil {
+	if len(chunk.resolved) > 0 {
+		if err := deleteUnresolvedBatchKeys(ctx, bs.db, bs.dbTimeout, chunk.resolved); err != nil {
 			return fmt.Errorf("failed to delete successfully resolved batch keys: %v", err)
 		}
 	}
 
+	*chunk = resolvedChunk{}
 	return nil
 }
 
-func (bs *BatchSynchronizer) resolve(ctx context.Context, batch types.BatchKey) (*types.OffChainData, error) {
+// resolve returns the data of the batch, from the trusted sequencer or else from a committee member,
+// along with the record of the member it was fetched from
+func (bs *BatchSynchronizer) resolve(
+	parentCtx context.Context,
+	batch types.BatchKey,
+) (_ *types.OffChainData, _ *types.ServedValue, err error) {
+	ctx, span := tracing.Start(parentCtx, "synchronizer.resolve", trace.SpanKindInternal,
+		attribute.Int64("batch.number", int64(batch.Number)),
+		attribute.String("batch.hash", batch.Hash.Hex()),
+	)
+	defer func() { tracing.End(span, err) }()
+	// a panic fails the batch, resolved again in the next round, instead of stopping the node
+	defer reporter.Isolate("synchronizer", &err)
+
+	// an injected fault fails the batch as if no one served it, or drops it once resolved
+	if err = fault.Before(ctx, "synchronizer.resolve"); err != nil {
+		return nil, nil, err
+	}
+	defer func() { err = fault.After("synchronizer.resolve", err) }()
+
 	// First try to get the data from the trusted sequencer
 	data := bs.trySequencer(ctx, batch)
 	if data != nil {
-		return data, nil
+		metrics.BatchResolved(metrics.SourceSequencer)
+		return data, nil, nil
 	}
 
 	// If the sequencer failed to produce data, try the other nodes
 	if bs.committee.Length() == 0 {
 		// committee is resolved again once all members are evicted. They can be evicted
 		// for not having data, or their config being malformed
-		if err := bs.resolveCommittee(); err != nil {
-			return nil, err
+		bs.committeeMisses.Add(1)
+		if err = bs.resolveCommittee(); err != nil {
+			return nil, nil, err
 		}
+	} else {
+		bs.committeeHits.Add(1)
 	}
 
 	// pull out the members, iterating will change the map on error
@@ -375,31 +819,56 @@ func (bs *BatchSynchronizer) resolve(ctx context.Context, batch types.BatchKey)
 			continue // malformed committee, skip what is known to be wrong
 		}
 
-		value, err := bs.resolveWithMember(ctx, batch, member)
+		start := time.Now()
+		value, served, err := bs.resolveWithMember(ctx, batch, member)
+		metrics.MemberResolve(member.Addr.Hex(), start, err)
 		if err != nil {
-			log.Warnf("error resolving, continuing: %v", err)
+			logger.WithFields(
+				log.FieldBatchNumber, batch.Number,
+				log.FieldKeyHash, batch.Hash.Hex(),
+				log.FieldMemberAddr, member.Addr.Hex(),
+				log.FieldMemberURL, member.URL,
+			).Warnf("error resolving, continuing: %v", err)
+			notifier.MemberResolveFailed(member.Addr.Hex(), member.URL, err)
 			bs.committee.Delete(member.Addr)
 			continue // did not have data or errored out
 		}
 
-		return value, nil
+		notifier.MemberResolved(member.Addr.Hex())
+		metrics.BatchResolved(metrics.SourceMember)
+		return value, served, nil
 	}
 
-	return nil, rpc.NewRPCError(rpc.NotFoundErrorCode,
+	return nil, nil, rpc.NewRPCError(rpc.NotFoundErrorCode,
 		"no data found for number %d, key %v", batch.Number, batch.Hash.Hex())
 }
 
 // trySequencer returns L2Data from the trusted sequencer, but does not return errors, only logs warnings if not found.
-func (bs *BatchSynchronizer) trySequencer(ctx context.Context, batch types.BatchKey) *types.OffChainData {
+func (bs *BatchSynchronizer) trySequencer(parentCtx context.Context, batch types.BatchKey) *types.OffChainData {
+	ctx, cancel := context.WithTimeout(parentCtx, bs.committeeTimeout)
+	defer cancel()
+
 	seqBatch, err := bs.sequencer.GetSequenceBatch(ctx, batch.Number)
+	if errors.Is(err, sequencer.ErrNoURL) {
+		// nothing to warn about, the batches are only served by the committee
+		return nil
+	}
 	if err != nil {
-		log.Warnf("failed to get data from sequencer: %v", err)
+		logger.WithFields(log.FieldBatchNumber, batch.Number, log.FieldKeyHash, batch.Hash.Hex()).
+			Warnf("failed to get data from sequencer: %v", err)
+		return nil
+	}
+
+	if err = bs.checkSize(seqBatch.BatchL2Data); err != nil {
+		logger.WithFields(log.FieldBatchNumber, batch.Number, log.FieldKeyHash, batch.Hash.Hex()).
+			Warnf("sequencer gave too much data: %v", err)
 		return nil
 	}
 
 	expectKey := crypto.Keccak256Hash(seqBatch.BatchL2Data)
 	if batch.Hash != expectKey {
-		log.Warnf("number %d: sequencer gave wrong data for key: %s", batch.Number, batch.Hash.Hex())
+		logger.WithFields(log.FieldBatchNumber, batch.Number, log.FieldKeyHash, batch.Hash.Hex()).
+			Warn("sequencer gave wrong data for key")
 		return nil
 	}
 
@@ -410,31 +879,73 @@ func (bs *BatchSynchronizer) trySequencer(ctx context.Context, batch types.Batch
 	}
 }
 
+// resolveWithMember fetches the data of the batch from the member, checking it hashes to the key of the
+// batch and, when the member signs the data it serves, that the signature is the member's
 func (bs *BatchSynchronizer) resolveWithMember(
 	parentCtx context.Context,
 	batch types.BatchKey,
 	member etherman.DataCommitteeMember,
-) (*types.OffChainData, error) {
+) (*types.OffChainData, *types.ServedValue, error) {
 	cm := bs.rpcClientFactory.New(member.URL)
 
-	ctx, cancel := context.WithTimeout(parentCtx, bs.rpcTimeout)
+	ctx, cancel := context.WithTimeout(parentCtx, bs.committeeTimeout)
 	defer cancel()
 
-	log.Debugf("trying member %v at %v for key %v", member.Addr.Hex(), member.URL, batch.Hash.Hex())
-
-	bytes, err := cm.GetOffChainData(ctx, batch.Hash)
+	memberLogger := logger.WithFields(
+		log.FieldBatchNumber, batch.Number,
+		log.FieldKeyHash, batch.Hash.Hex(),
+		log.FieldMemberAddr, member.Addr.Hex(),
+		log.FieldMemberURL, member.URL,
+	)
+	memberLogger.Debug("trying member")
+
+	signed := true
+	bytes, signature, err := cm.GetSignedOffChainData(ctx, batch.Hash)
+	if errors.Is(err, client.ErrMethodNotFound) {
+		// the members running an older version of the node do not sign the data they serve
+		memberLogger.Debug("member does not sign the data, fetching it unsigned")
+		signed = false
+		bytes, err = cm.GetOffChainData(ctx, batch.Hash)
+	}
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	if err = bs.checkSize(bytes); err != nil {
+		return nil, nil, fmt.Errorf("too much data gotten from member %v: %w", member.Addr.Hex(), err)
 	}
 
 	expectKey := crypto.Keccak256Hash(bytes)
 	if batch.Hash.Cmp(expectKey) != 0 {
-		return nil, fmt.Errorf("unexpected key gotten from member: %v. Key: %v", member.Addr.Hex(), expectKey.Hex())
+		return nil, nil, fmt.Errorf("unexpected key gotten from member: %v. Key: %v",
+			member.Addr.Hex(), expectKey.Hex())
 	}
 
-	return &types.OffChainData{
+	if signed {
+		signer, err := types.ServedSigner(batch.Hash, signature)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid signature gotten from member %v: %w", member.Addr.Hex(), err)
+		}
+		if signer != member.Addr {
+			return nil, nil, fmt.Errorf("data gotten from member %v signed by %v", member.Addr.Hex(), signer.Hex())
+		}
+	}
+
+	data := &types.OffChainData{
 		Key:      batch.Hash,
 		Value:    bytes,
 		BatchNum: batch.Number,
-	}, nil
+	}
+	served := &types.ServedValue{
+		Key:       batch.Hash,
+		Member:    member.Addr,
+		Signature: signature,
+		Timestamp: time.Now().UTC(),
+	}
+
+	return data, served, nil
+}
+
+// checkSize returns an error when the data of a batch is larger than allowed
+func (bs *BatchSynchronizer) checkSize(data []byte) error {
+	return types.CheckValueSize(data, bs.maxValueSize)
 }
@@ -3,20 +3,28 @@ package synchronizer
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/0xPolygon/cdk-data-availability/client"
+	"github.com/0xPolygon/cdk-data-availability/config"
+	cfgTypes "github.com/0xPolygon/cdk-data-availability/config/types"
 	"github.com/0xPolygon/cdk-data-availability/etherman"
 	elderberryValidium "github.com/0xPolygon/cdk-data-availability/etherman/smartcontracts/elderberry/polygonvalidium"
 	etrogValidium "github.com/0xPolygon/cdk-data-availability/etherman/smartcontracts/etrog/polygonvalidium"
 	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/reporter"
 	"github.com/0xPolygon/cdk-data-availability/sequencer"
 	"github.com/0xPolygon/cdk-data-availability/types"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	ethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/umbracle/ethgo"
@@ -32,7 +40,8 @@ func TestBatchSynchronizer_ResolveCommittee(t *testing.T) {
 		ethermanMock.On("GetCurrentDataCommittee").Return(nil, errors.New("error")).Once()
 
 		batchSyncronizer := &BatchSynchronizer{
-			client: ethermanMock,
+			client:    ethermanMock,
+			committee: NewCommitteeMapSafe(),
 		}
 
 		require.Error(t, batchSyncronizer.resolveCommittee())
@@ -63,7 +72,8 @@ func TestBatchSynchronizer_ResolveCommittee(t *testing.T) {
 		ethermanMock.On("GetCurrentDataCommittee").Return(committee, nil).Once()
 
 		batchSyncronizer := &BatchSynchronizer{
-			client: ethermanMock,
+			client:    ethermanMock,
+			committee: NewCommitteeMapSafe(),
 		}
 
 		require.NoError(t, batchSyncronizer.resolveCommittee())
@@ -73,6 +83,27 @@ func TestBatchSynchronizer_ResolveCommittee(t *testing.T) {
 	})
 }
 
+func TestBatchSynchronizer_Reload(t *testing.T) {
+	t.Parallel()
+
+	batchSyncronizer := &BatchSynchronizer{
+		retry:          time.Second,
+		blockBatchSize: 32,
+	}
+
+	batchSyncronizer.Reload(config.L1Config{
+		RetryPeriod:    cfgTypes.NewDuration(10 * time.Second),
+		BlockBatchSize: 64,
+	})
+	require.Equal(t, 10*time.Second, batchSyncronizer.retryPeriod())
+	require.Equal(t, uint(64), batchSyncronizer.batchSize())
+
+	// zero values are ignored
+	batchSyncronizer.Reload(config.L1Config{})
+	require.Equal(t, 10*time.Second, batchSyncronizer.retryPeriod())
+	require.Equal(t, uint(64), batchSyncronizer.batchSize())
+}
+
 func TestBatchSynchronizer_Resolve(t *testing.T) {
 	t.Parallel()
 
@@ -85,11 +116,15 @@ func TestBatchSynchronizer_Resolve(t *testing.T) {
 		// clientFactory mocks
 		newArgs [][]interface{}
 		// client mocks
-		getOffChainDataArgs    [][]interface{}
-		getOffChainDataReturns [][]interface{}
+		getSignedOffChainDataArgs    [][]interface{}
+		getSignedOffChainDataReturns [][]interface{}
+		getOffChainDataArgs          [][]interface{}
+		getOffChainDataReturns       [][]interface{}
 
+		maxValueSize    uint64
 		isErrorExpected bool
 		errorString     string
+		servedBy        common.Address
 	}
 
 	data := common.HexToHash("0xFFFF").Bytes()
@@ -98,6 +133,12 @@ func TestBatchSynchronizer_Resolve(t *testing.T) {
 		Hash:   crypto.Keccak256Hash(data),
 	}
 
+	memberKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	memberAddr := crypto.PubkeyToAddress(memberKey.PublicKey)
+	signature, err := types.SignServed(batchKey.Hash, memberKey)
+	require.NoError(t, err)
+
 	testFn := func(config testConfig) {
 		clientMock := mocks.NewClient(t)
 		ethermanMock := mocks.NewEtherman(t)
@@ -119,6 +160,13 @@ func TestBatchSynchronizer_Resolve(t *testing.T) {
 			}
 		}
 
+		if config.getSignedOffChainDataArgs != nil && config.getSignedOffChainDataReturns != nil {
+			for i, args := range config.getSignedOffChainDataArgs {
+				clientMock.On("GetSignedOffChainData", args...).Return(
+					config.getSignedOffChainDataReturns[i]...).Once()
+			}
+		}
+
 		if config.getOffChainDataArgs != nil && config.getOffChainDataReturns != nil {
 			for i, args := range config.getOffChainDataArgs {
 				clientMock.On("GetOffChainData", args...).Return(
@@ -131,9 +179,10 @@ func TestBatchSynchronizer_Resolve(t *testing.T) {
 			sequencer:        sequencerMock,
 			rpcClientFactory: clientFactoryMock,
 			committee:        NewCommitteeMapSafe(),
+			maxValueSize:     config.maxValueSize,
 		}
 
-		offChainData, err := batchSyncronizer.resolve(context.Background(), batchKey)
+		offChainData, served, err := batchSyncronizer.resolve(context.Background(), batchKey)
 		if config.isErrorExpected {
 			if config.errorString != "" {
 				require.ErrorContains(t, err, config.errorString)
@@ -144,6 +193,12 @@ func TestBatchSynchronizer_Resolve(t *testing.T) {
 			require.NoError(t, err)
 			require.Equal(t, batchKey.Hash, offChainData.Key)
 			require.Equal(t, data, offChainData.Value)
+			if config.servedBy == (common.Address{}) {
+				require.Nil(t, served)
+			} else {
+				require.Equal(t, batchKey.Hash, served.Key)
+				require.Equal(t, config.servedBy, served.Member)
+			}
 		}
 
 		clientMock.AssertExpectations(t)
@@ -156,7 +211,7 @@ func TestBatchSynchronizer_Resolve(t *testing.T) {
 		t.Parallel()
 
 		testFn(testConfig{
-			getSequenceBatchArgs: []interface{}{context.Background(), batchKey.Number},
+			getSequenceBatchArgs: []interface{}{mock.Anything, batchKey.Number},
 			getSequenceBatchReturns: []interface{}{&sequencer.SeqBatch{
 				Number:      types.ArgUint64(batchKey.Number),
 				BatchL2Data: types.ArgBytes(data),
@@ -170,21 +225,70 @@ func TestBatchSynchronizer_Resolve(t *testing.T) {
 		committee := &etherman.DataCommittee{
 			Members: []etherman.DataCommitteeMember{
 				{
-					Addr: common.HexToAddress("0x4321"),
+					Addr: memberAddr,
 					URL:  "http://url-22",
 				},
+			},
+		}
+
+		testFn(testConfig{
+			isErrorExpected:                false,
+			getSignedOffChainDataArgs:      [][]interface{}{{mock.Anything, batchKey.Hash}},
+			getSignedOffChainDataReturns:   [][]interface{}{{data, signature, nil}},
+			getSequenceBatchArgs:           []interface{}{mock.Anything, batchKey.Number},
+			getSequenceBatchReturns:        []interface{}{nil, errors.New("error")},
+			getCurrentDataCommitteeReturns: []interface{}{committee, nil},
+			newArgs:                        [][]interface{}{{committee.Members[0].URL}},
+			servedBy:                       memberAddr,
+		})
+	})
+
+	t.Run("Got unsigned data from a committee member not signing the data", func(t *testing.T) {
+		t.Parallel()
+
+		committee := &etherman.DataCommittee{
+			Members: []etherman.DataCommitteeMember{
 				{
-					Addr: common.HexToAddress("0x5321"),
+					Addr: common.HexToAddress("0x4321"),
 					URL:  "http://url-22",
 				},
 			},
 		}
 
 		testFn(testConfig{
-			isErrorExpected:                false,
+			isErrorExpected:           false,
+			getSignedOffChainDataArgs: [][]interface{}{{mock.Anything, batchKey.Hash}},
+			getSignedOffChainDataReturns: [][]interface{}{
+				{nil, nil, fmt.Errorf("%w: the method does not exist", client.ErrMethodNotFound)},
+			},
 			getOffChainDataArgs:            [][]interface{}{{mock.Anything, batchKey.Hash}},
 			getOffChainDataReturns:         [][]interface{}{{data, nil}},
-			getSequenceBatchArgs:           []interface{}{context.Background(), batchKey.Number},
+			getSequenceBatchArgs:           []interface{}{mock.Anything, batchKey.Number},
+			getSequenceBatchReturns:        []interface{}{nil, errors.New("error")},
+			getCurrentDataCommitteeReturns: []interface{}{committee, nil},
+			newArgs:                        [][]interface{}{{committee.Members[0].URL}},
+			servedBy:                       committee.Members[0].Addr,
+		})
+	})
+
+	t.Run("Committee member serves data signed by another key", func(t *testing.T) {
+		t.Parallel()
+
+		committee := &etherman.DataCommittee{
+			Members: []etherman.DataCommitteeMember{
+				{
+					Addr: common.HexToAddress("0x4321"),
+					URL:  "http://url-22",
+				},
+			},
+		}
+
+		testFn(testConfig{
+			isErrorExpected:                true,
+			errorString:                    "no data found for number",
+			getSignedOffChainDataArgs:      [][]interface{}{{mock.Anything, batchKey.Hash}},
+			getSignedOffChainDataReturns:   [][]interface{}{{data, signature, nil}},
+			getSequenceBatchArgs:           []interface{}{mock.Anything, batchKey.Number},
 			getSequenceBatchReturns:        []interface{}{nil, errors.New("error")},
 			getCurrentDataCommitteeReturns: []interface{}{committee, nil},
 			newArgs:                        [][]interface{}{{committee.Members[0].URL}},
@@ -208,19 +312,19 @@ func TestBatchSynchronizer_Resolve(t *testing.T) {
 		}
 
 		testFn(testConfig{
-			getSequenceBatchArgs:           []interface{}{context.Background(), batchKey.Number},
+			getSequenceBatchArgs:           []interface{}{mock.Anything, batchKey.Number},
 			getSequenceBatchReturns:        []interface{}{nil, errors.New("error")},
 			getCurrentDataCommitteeReturns: []interface{}{committee, nil},
 			newArgs: [][]interface{}{
 				{committee.Members[0].URL},
 				{committee.Members[1].URL}},
-			getOffChainDataArgs: [][]interface{}{
+			getSignedOffChainDataArgs: [][]interface{}{
 				{mock.Anything, batchKey.Hash},
 				{mock.Anything, batchKey.Hash},
 			},
-			getOffChainDataReturns: [][]interface{}{
-				{nil, errors.New("error")}, // member doesn't have batch
-				{nil, errors.New("error")}, // member doesn't have batch
+			getSignedOffChainDataReturns: [][]interface{}{
+				{nil, nil, errors.New("error")}, // member doesn't have batch
+				{nil, nil, errors.New("error")}, // member doesn't have batch
 			},
 			isErrorExpected: true,
 			errorString:     "no data found for number",
@@ -249,19 +353,47 @@ func TestBatchSynchronizer_Resolve(t *testing.T) {
 			newArgs: [][]interface{}{
 				{committee.Members[0].URL},
 				{committee.Members[1].URL}},
-			getOffChainDataArgs: [][]interface{}{
+			getSignedOffChainDataArgs: [][]interface{}{
 				{mock.Anything, batchKey.Hash},
 				{mock.Anything, batchKey.Hash},
 			},
-			getOffChainDataReturns: [][]interface{}{
-				{[]byte{0, 0, 0, 1}, nil}, // member doesn't have batch
-				{[]byte{0, 0, 0, 1}, nil}, // member doesn't have batch
+			getSignedOffChainDataReturns: [][]interface{}{
+				{[]byte{0, 0, 0, 1}, signature, nil}, // member doesn't have batch
+				{[]byte{0, 0, 0, 1}, signature, nil}, // member doesn't have batch
 			},
-			getSequenceBatchArgs:           []interface{}{context.Background(), batchKey.Number},
+			getSequenceBatchArgs:           []interface{}{mock.Anything, batchKey.Number},
 			getSequenceBatchReturns:        []interface{}{nil, errors.New("error")},
 			getCurrentDataCommitteeReturns: []interface{}{committee, nil},
 		})
 	})
+
+	t.Run("Sequencer and committee member give too much data", func(t *testing.T) {
+		t.Parallel()
+
+		committee := &etherman.DataCommittee{
+			Members: []etherman.DataCommitteeMember{
+				{
+					Addr: common.HexToAddress("0x123456"),
+					URL:  "http://url-11",
+				},
+			},
+		}
+
+		testFn(testConfig{
+			maxValueSize:         uint64(len(data)) - 1,
+			isErrorExpected:      true,
+			errorString:          "no data found for number",
+			getSequenceBatchArgs: []interface{}{mock.Anything, batchKey.Number},
+			getSequenceBatchReturns: []interface{}{&sequencer.SeqBatch{
+				Number:      types.ArgUint64(batchKey.Number),
+				BatchL2Data: types.ArgBytes(data),
+			}, nil},
+			getCurrentDataCommitteeReturns: []interface{}{committee, nil},
+			newArgs:                        [][]interface{}{{committee.Members[0].URL}},
+			getSignedOffChainDataArgs:      [][]interface{}{{mock.Anything, batchKey.Hash}},
+			getSignedOffChainDataReturns:   [][]interface{}{{data, signature, nil}},
+		})
+	})
 }
 
 func TestBatchSynchronizer_HandleEvent(t *testing.T) {
@@ -276,6 +408,8 @@ func TestBatchSynchronizer_HandleEvent(t *testing.T) {
 		storeUnresolvedBatchKeysReturns []interface{}
 
 		isErrorExpected bool
+		// expectedError is the error the event is expected to fail with, if any
+		expectedError error
 	}
 
 	to := common.HexToAddress("0xFFFF")
@@ -337,6 +471,9 @@ func TestBatchSynchronizer_HandleEvent(t *testing.T) {
 		err := batchSynronizer.handleEvent(context.Background(), event)
 		if config.isErrorExpected {
 			require.Error(t, err)
+			if config.expectedError != nil {
+				require.ErrorIs(t, err, config.expectedError)
+			}
 		} else {
 			require.NoError(t, err)
 		}
@@ -371,6 +508,24 @@ func TestBatchSynchronizer_HandleEvent(t *testing.T) {
 				},
 			), true, nil},
 			isErrorExpected: true,
+			// the transaction may be a valid sequence of a method unknown to the node, so it is not skipped
+			expectedError: ErrUnknownMethod,
+		})
+	})
+
+	t.Run("malformed tx data", func(t *testing.T) {
+		t.Parallel()
+
+		testFn(t, testConfig{
+			getTxArgs: []interface{}{mock.Anything, event.Raw.TxHash},
+			getTxReturns: []interface{}{ethTypes.NewTx(
+				&ethTypes.LegacyTx{
+					To:   &to,
+					Data: append(append([]byte{}, methodDefinition.ID...), 5, 6, 7),
+				},
+			), true, nil},
+			isErrorExpected: true,
+			expectedError:   ErrMalformedSequence,
 		})
 	})
 
@@ -449,6 +604,191 @@ func TestBatchSynchronizer_HandleEvent(t *testing.T) {
 			getTxReturns:                    []interface{}{tx, true, nil},
 		})
 	})
+
+	t.Run("panic while handling the event", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("StoreUnresolvedBatchKeys", mock.Anything, mock.Anything, mock.Anything).
+			Run(func(mock.Arguments) { panic("boom") }).Once()
+		ethermanMock := mocks.NewEtherman(t)
+		ethermanMock.On("GetTx", mock.Anything, event.Raw.TxHash).Return(tx, true, nil).Once()
+
+		batchSynronizer := &BatchSynchronizer{
+			db:     dbMock,
+			client: ethermanMock,
+		}
+
+		// the panic fails the event, which is handled again, without stopping the node
+		err := batchSynronizer.handleEvent(context.Background(), event)
+		require.ErrorIs(t, err, reporter.ErrPanic)
+	})
+}
+
+func TestBatchSynchronizer_FetchEvents(t *testing.T) {
+	t.Parallel()
+
+	to := common.HexToAddress("0xFFFF")
+	ethermanMock := mocks.NewEtherman(t)
+
+	events := make([]*etrogValidium.PolygonvalidiumSequenceBatches, 4)
+	keys := make([]common.Hash, len(events))
+	for i := range events {
+		events[i] = &etrogValidium.PolygonvalidiumSequenceBatches{
+			Raw:      ethTypes.Log{BlockNumber: uint64(i + 1), TxHash: common.BigToHash(big.NewInt(int64(i + 1)))},
+			NumBatch: uint64(i + 1),
+		}
+		keys[i] = crypto.Keccak256Hash([]byte{byte(i)})
+
+		tx := ethTypes.NewTx(&ethTypes.LegacyTx{To: &to, Data: sequenceTxData(t, keys[i])})
+		// the first events take the longest to fetch, so they complete out of order
+		ethermanMock.On("GetTx", mock.Anything, events[i].Raw.TxHash).
+			After(time.Duration(len(events)-i)*10*time.Millisecond).Return(tx, true, nil).Once()
+	}
+	failing := &etrogValidium.PolygonvalidiumSequenceBatches{Raw: ethTypes.Log{TxHash: common.HexToHash("0xdead")}}
+	ethermanMock.On("GetTx", mock.Anything, failing.Raw.TxHash).Return(nil, false, errors.New("error")).Once()
+
+	batchSynchronizer := &BatchSynchronizer{
+		client:           ethermanMock,
+		rpcTimeout:       time.Minute,
+		eventConcurrency: 2,
+	}
+
+	fetched := batchSynchronizer.fetchEvents(context.Background(), append(events, failing))
+	for i, event := range events {
+		result := <-fetched[i]
+		require.NoError(t, result.err)
+		require.Equal(t, []types.BatchKey{{Number: event.NumBatch, Hash: keys[i]}}, result.data.batchKeys)
+	}
+	require.Error(t, (<-fetched[len(events)]).err)
+}
+
+func TestBatchSynchronizer_ResolveBatches(t *testing.T) {
+	t.Parallel()
+
+	sequencerMock := mocks.NewSequencerTracker(t)
+
+	var (
+		running    atomic.Int32
+		maxRunning atomic.Int32
+	)
+	keys := make(map[common.Hash]types.BatchKey)
+	for i := 0; i < 6; i++ {
+		data := []byte{byte(i)}
+		key := types.BatchKey{Number: uint64(i + 1), Hash: crypto.Keccak256Hash(data)}
+		keys[key.Hash] = key
+
+		sequencerMock.On("GetSequenceBatch", mock.Anything, key.Number).Run(func(mock.Arguments) {
+			n := running.Add(1)
+			for seen := maxRunning.Load(); n > seen; seen = maxRunning.Load() {
+				if maxRunning.CompareAndSwap(seen, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			running.Add(-1)
+		}).Return(&sequencer.SeqBatch{Number: types.ArgUint64(key.Number), BatchL2Data: data}, nil).Once()
+	}
+
+	batchSynchronizer := &BatchSynchronizer{
+		sequencer:          sequencerMock,
+		committeeTimeout:   time.Minute,
+		resolveConcurrency: 2,
+	}
+
+	resolved := make(map[common.Hash]types.BatchKey)
+	for result := range batchSynchronizer.resolveBatches(context.Background(), keys) {
+		require.NoError(t, result.err)
+		require.Equal(t, result.key.Hash, result.value.Key)
+		resolved[result.key.Hash] = result.key
+	}
+	require.Equal(t, keys, resolved)
+	require.Equal(t, int32(2), maxRunning.Load())
+}
+
+func TestBatchSynchronizer_HandleEvent_Blobs(t *testing.T) {
+	t.Parallel()
+
+	event := &etrogValidium.PolygonvalidiumSequenceBatches{
+		Raw: ethTypes.Log{
+			BlockNumber: 100,
+			TxHash:      common.BytesToHash([]byte{0, 1, 2, 3}),
+		},
+		NumBatch: 10,
+	}
+	blobHash := common.HexToHash("0x01ab")
+	tx := ethTypes.NewTx(&ethTypes.BlobTx{BlobHashes: []common.Hash{blobHash}})
+
+	var blob kzg4844.Blob
+	copy(blob[1:], []byte{1, 2, 3})
+
+	t.Run("blobs stored", func(t *testing.T) {
+		t.Parallel()
+
+		ethermanMock := mocks.NewEtherman(t)
+		ethermanMock.On("GetTx", mock.Anything, event.Raw.TxHash).Return(tx, false, nil).Once()
+		ethermanMock.On("HeaderByNumber", mock.Anything, big.NewInt(100)).
+			Return(&ethTypes.Header{Time: 1700000000}, nil).Once()
+
+		blobsMock := mocks.NewBlobFetcher(t)
+		blobsMock.On("GetBlobs", mock.Anything, uint64(1700000000), []common.Hash{blobHash}).
+			Return([]kzg4844.Blob{blob}, nil).Once()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("StoreOffChainData", mock.Anything, []types.OffChainData{{
+			Key:      crypto.Keccak256Hash(blob[:]),
+			Value:    blob[:],
+			BatchNum: 10,
+		}}).Return(nil).Once()
+		dbMock.On("StoreProvenance", mock.Anything, mock.MatchedBy(func(provenance []types.Provenance) bool {
+			return len(provenance) == 1 && provenance[0].Source == types.ProvenanceL1 &&
+				provenance[0].Origin == event.Raw.TxHash.Hex()
+		})).Return(nil).Once()
+
+		batchSynchronizer := &BatchSynchronizer{
+			db:     dbMock,
+			client: ethermanMock,
+			blobs:  blobsMock,
+		}
+
+		require.NoError(t, batchSynchronizer.handleEvent(context.Background(), event))
+	})
+
+	t.Run("invalid blobs", func(t *testing.T) {
+		t.Parallel()
+
+		ethermanMock := mocks.NewEtherman(t)
+		ethermanMock.On("GetTx", mock.Anything, event.Raw.TxHash).Return(tx, false, nil).Once()
+		ethermanMock.On("HeaderByNumber", mock.Anything, big.NewInt(100)).
+			Return(&ethTypes.Header{Time: 1700000000}, nil).Once()
+
+		blobsMock := mocks.NewBlobFetcher(t)
+		blobsMock.On("GetBlobs", mock.Anything, uint64(1700000000), []common.Hash{blobHash}).
+			Return(nil, errors.New("invalid KZG proof")).Once()
+
+		batchSynchronizer := &BatchSynchronizer{
+			db:     mocks.NewDB(t),
+			client: ethermanMock,
+			blobs:  blobsMock,
+		}
+
+		require.ErrorContains(t, batchSynchronizer.handleEvent(context.Background(), event), "invalid KZG proof")
+	})
+
+	t.Run("no beacon API", func(t *testing.T) {
+		t.Parallel()
+
+		ethermanMock := mocks.NewEtherman(t)
+		ethermanMock.On("GetTx", mock.Anything, event.Raw.TxHash).Return(tx, false, nil).Once()
+
+		batchSynchronizer := &BatchSynchronizer{
+			db:     mocks.NewDB(t),
+			client: ethermanMock,
+		}
+
+		require.ErrorContains(t, batchSynchronizer.handleEvent(context.Background(), event),
+			"no beacon API is configured")
+	})
 }
 
 func TestBatchSynchronizer_HandleUnresolvedBatches(t *testing.T) {
@@ -462,6 +802,8 @@ func TestBatchSynchronizer_HandleUnresolvedBatches(t *testing.T) {
 		listOffchainDataReturns          []interface{}
 		storeOffChainDataArgs            []interface{}
 		storeOffChainDataReturns         []interface{}
+		storeProvenanceArgs              []interface{}
+		storeProvenanceReturns           []interface{}
 		deleteUnresolvedBatchKeysArgs    []interface{}
 		deleteUnresolvedBatchKeysReturns []interface{}
 		// sequencer mocks
@@ -496,6 +838,11 @@ func TestBatchSynchronizer_HandleUnresolvedBatches(t *testing.T) {
 				config.storeOffChainDataReturns...).Once()
 		}
 
+		if config.storeProvenanceArgs != nil && config.storeProvenanceReturns != nil {
+			dbMock.On("StoreProvenance", config.storeProvenanceArgs...).Return(
+				config.storeProvenanceReturns...).Once()
+		}
+
 		if config.deleteUnresolvedBatchKeysArgs != nil && config.deleteUnresolvedBatchKeysReturns != nil {
 			dbMock.On("DeleteUnresolvedBatchKeys", config.deleteUnresolvedBatchKeysArgs...).Return(
 				config.deleteUnresolvedBatchKeysReturns...).Once()
@@ -636,6 +983,13 @@ func TestBatchSynchronizer_HandleUnresolvedBatches(t *testing.T) {
 				mock.Anything,
 			},
 			storeOffChainDataReturns: []interface{}{nil},
+			storeProvenanceArgs: []interface{}{mock.Anything,
+				mock.MatchedBy(func(provenance []types.Provenance) bool {
+					return len(provenance) == 1 && provenance[0].Key == txHash &&
+						provenance[0].Source == types.ProvenanceSequencer
+				}),
+			},
+			storeProvenanceReturns: []interface{}{nil},
 			deleteUnresolvedBatchKeysArgs: []interface{}{mock.Anything,
 				[]types.BatchKey{{
 					Number: 10,
@@ -644,7 +998,7 @@ func TestBatchSynchronizer_HandleUnresolvedBatches(t *testing.T) {
 				mock.Anything,
 			},
 			deleteUnresolvedBatchKeysReturns: []interface{}{nil},
-			getSequenceBatchArgs:             []interface{}{context.Background(), uint64(10)},
+			getSequenceBatchArgs:             []interface{}{mock.Anything, uint64(10)},
 			getSequenceBatchReturns: []interface{}{&sequencer.SeqBatch{
 				Number:      types.ArgUint64(10),
 				BatchL2Data: types.ArgBytes(batchL2Data),
@@ -724,6 +1078,47 @@ func TestBatchSynchronizer_HandleUnresolvedBatches(t *testing.T) {
 	})*/
 }
 
+func TestBatchSynchronizer_HandleUnresolvedBatches_Chunks(t *testing.T) {
+	t.Parallel()
+
+	values := [][]byte{{1, 2, 3}, {4, 5, 6}}
+	batchKeys := []types.BatchKey{
+		{Number: 10, Hash: crypto.Keccak256Hash(values[0])},
+		{Number: 11, Hash: crypto.Keccak256Hash(values[1])},
+	}
+
+	dbMock := mocks.NewDB(t)
+	dbMock.On("GetUnresolvedBatchKeys", mock.Anything, uint(100)).Return(batchKeys, nil).Once()
+	dbMock.On("ListOffChainData", mock.Anything, mock.Anything).Return(nil, nil).Once()
+
+	// each value fills a chunk, the first one is stored and resolved before the second one fails
+	single := mock.MatchedBy(func(data []types.OffChainData) bool { return len(data) == 1 })
+	dbMock.On("StoreOffChainData", mock.Anything, single).Return(nil).Once()
+	dbMock.On("StoreOffChainData", mock.Anything, single).Return(errors.New("error")).Once()
+	dbMock.On("StoreProvenance", mock.Anything, mock.Anything).Return(nil).Once()
+	dbMock.On("DeleteUnresolvedBatchKeys", mock.Anything, mock.MatchedBy(func(keys []types.BatchKey) bool {
+		return len(keys) == 1
+	})).Return(nil).Once()
+
+	sequencerMock := mocks.NewSequencerTracker(t)
+	for i, key := range batchKeys {
+		sequencerMock.On("GetSequenceBatch", mock.Anything, key.Number).Return(&sequencer.SeqBatch{
+			Number:      types.ArgUint64(key.Number),
+			BatchL2Data: types.ArgBytes(values[i]),
+		}, nil).Once()
+	}
+
+	batchSynronizer := &BatchSynchronizer{
+		db:             dbMock,
+		client:         mocks.NewEtherman(t),
+		sequencer:      sequencerMock,
+		storeChunkSize: 1,
+	}
+
+	err := batchSynronizer.handleUnresolvedBatches(context.Background())
+	require.ErrorContains(t, err, "failed to store offchain data")
+}
+
 func TestBatchSyncronizer_HandleReorgs(t *testing.T) {
 	t.Parallel()
 
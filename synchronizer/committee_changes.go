@@ -0,0 +1,327 @@
+package synchronizer
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/etherman/smartcontracts/etrog/polygondatacommittee"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/reporter"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// committeeChangesPageSize is the number of committee changes loaded at once on start
+const committeeChangesPageSize = 1000
+
+// CommitteeWatcher records every change of the committee members observed on L1
+// (members added or removed, URL changes) along with the block it was made at.
+// The history starts at the block the node first watched the committee, when the
+// members at that block are recorded as added.
+type CommitteeWatcher struct {
+	client         etherman.Etherman
+	db             db.DB
+	stop           chan struct{}
+	settingsLock   sync.RWMutex
+	retry          time.Duration
+	rpcTimeout     time.Duration
+	dbTimeout      time.Duration
+	blockBatchSize uint
+	// members are the known members of the committee and their URLs
+	members map[common.Address]string
+}
+
+// NewCommitteeWatcher creates the CommitteeWatcher
+func NewCommitteeWatcher(
+	cfg config.L1Config,
+	timeouts config.TimeoutsConfig,
+	db db.DB,
+	ethClient etherman.Etherman,
+) *CommitteeWatcher {
+	blockBatchSize := cfg.BlockBatchSize
+	if blockBatchSize == 0 {
+		blockBatchSize = defaultBlockBatchSize
+	}
+	dbTimeout := timeouts.DBOperation.Duration
+	if dbTimeout <= 0 {
+		dbTimeout = defaultDBTimeout
+	}
+
+	return &CommitteeWatcher{
+		client:         ethClient,
+		db:             db,
+		stop:           make(chan struct{}),
+		retry:          cfg.RetryPeriod.Duration,
+		rpcTimeout:     cfg.Timeout.Duration,
+		dbTimeout:      dbTimeout,
+		blockBatchSize: blockBatchSize,
+		members:        make(map[common.Address]string),
+	}
+}
+
+// Start watches the committee changes until the watcher is stopped
+func (cw *CommitteeWatcher) Start(ctx context.Context) {
+	defer reporter.Recover()
+
+	logger.Info("starting committee watcher")
+	initialized := false
+	for {
+		delay := time.NewTimer(cw.retryPeriod())
+		select {
+		case <-delay.C:
+			if !initialized {
+				if err := cw.init(ctx); err != nil {
+					logger.Errorf("error loading the committee changes: %v", err)
+					continue
+				}
+				initialized = true
+			}
+
+			if err := cw.filterEvents(ctx); err != nil {
+				logger.Errorf("error filtering committee events: %v", err)
+			}
+		case <-cw.stop:
+			delay.Stop()
+			return
+		}
+	}
+}
+
+// Stop stops the watcher
+func (cw *CommitteeWatcher) Stop() {
+	close(cw.stop)
+}
+
+// init loads the known members from the recorded changes, or records the members
+// of the latest block the first time the committee is watched
+func (cw *CommitteeWatcher) init(ctx context.Context) error {
+	members := make(map[common.Address]string)
+	for fromID := uint64(0); ; {
+		changes, err := cw.listChanges(ctx, fromID)
+		if err != nil {
+			return err
+		}
+
+		for _, c := range changes {
+			applyCommitteeChange(members, c)
+			fromID = c.ID + 1
+		}
+
+		if len(changes) < committeeChangesPageSize {
+			break
+		}
+	}
+	cw.members = members
+
+	dbCtx, cancel := context.WithTimeout(ctx, cw.dbTimeout)
+	defer cancel()
+
+	// the task is only missing the first time the committee is watched
+	if _, err := cw.db.GetLastProcessedBlock(dbCtx, string(CommitteeSyncTask)); !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	header, err := cw.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	logger.WithFields(log.FieldBlockNumber, header.Number.Uint64()).Info("recording the current committee")
+	if err = cw.recordMembersAt(ctx, header.Number.Uint64(), common.Hash{}); err != nil {
+		return err
+	}
+
+	return setStartBlock(ctx, cw.db, cw.dbTimeout, header.Number.Uint64(), CommitteeSyncTask)
+}
+
+func (cw *CommitteeWatcher) listChanges(parentCtx context.Context, fromID uint64) ([]types.CommitteeChange, error) {
+	ctx, cancel := context.WithTimeout(parentCtx, cw.dbTimeout)
+	defer cancel()
+
+	return cw.db.ListCommitteeChanges(ctx, fromID, committeeChangesPageSize)
+}
+
+// Reload applies the settings of the committee watcher that can change at runtime
+func (cw *CommitteeWatcher) Reload(cfg config.L1Config) {
+	cw.settingsLock.Lock()
+	defer cw.settingsLock.Unlock()
+
+	if cfg.RetryPeriod.Duration > 0 && cfg.RetryPeriod.Duration != cw.retry {
+		logger.Infof("committee watcher retry period changed from %v to %v", cw.retry, cfg.RetryPeriod.Duration)
+		cw.retry = cfg.RetryPeriod.Duration
+	}
+
+	if cfg.BlockBatchSize > 0 && cfg.BlockBatchSize != cw.blockBatchSize {
+		logger.Infof("committee watcher block batch size changed from %d to %d", cw.blockBatchSize, cfg.BlockBatchSize)
+		cw.blockBatchSize = cfg.BlockBatchSize
+	}
+}
+
+func (cw *CommitteeWatcher) retryPeriod() time.Duration {
+	cw.settingsLock.RLock()
+	defer cw.settingsLock.RUnlock()
+	return cw.retry
+}
+
+func (cw *CommitteeWatcher) batchSize() uint {
+	cw.settingsLock.RLock()
+	defer cw.settingsLock.RUnlock()
+	return cw.blockBatchSize
+}
+
+// filterEvents records the committee changes of the CommitteeUpdated events found
+// from the last block processed
+func (cw *CommitteeWatcher) filterEvents(ctx context.Context) error {
+	start, err := getStartBlock(ctx, cw.db, cw.dbTimeout, CommitteeSyncTask)
+	if err != nil {
+		return err
+	}
+
+	header, err := cw.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	end := start + uint64(cw.batchSize())
+	if end > header.Number.Uint64() {
+		end = header.Number.Uint64()
+	}
+
+	iter, err := cw.client.FilterCommitteeUpdated(&bind.FilterOpts{
+		Context: ctx,
+		Start:   start,
+		End:     &end,
+	})
+	if err != nil {
+		return err
+	}
+
+	var events []*polygondatacommittee.PolygondatacommitteeCommitteeUpdated
+	for iter.Next() {
+		events = append(events, iter.Event)
+	}
+	if err = iter.Error(); err != nil {
+		return err
+	}
+	if err = iter.Close(); err != nil {
+		logger.Errorf("failed to close CommitteeUpdated event iterator: %v", err)
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Raw.BlockNumber < events[j].Raw.BlockNumber
+	})
+
+	for _, event := range events {
+		if err = cw.recordMembersAt(ctx, event.Raw.BlockNumber, event.Raw.TxHash); err != nil {
+			logger.WithFields(
+				log.FieldBlockNumber, event.Raw.BlockNumber,
+				log.FieldTxHash, event.Raw.TxHash.Hex(),
+			).Errorf("failed to record the committee changes: %v", err)
+			return setStartBlock(ctx, cw.db, cw.dbTimeout, event.Raw.BlockNumber-1, CommitteeSyncTask)
+		}
+	}
+
+	return setStartBlock(ctx, cw.db, cw.dbTimeout, end, CommitteeSyncTask)
+}
+
+// recordMembersAt records the changes between the known members and the members at the given block
+func (cw *CommitteeWatcher) recordMembersAt(parentCtx context.Context, block uint64, txHash common.Hash) error {
+	rpcCtx, cancel := context.WithTimeout(parentCtx, cw.rpcTimeout)
+	defer cancel()
+
+	members, err := cw.client.GetDataCommitteeMembers(rpcCtx, new(big.Int).SetUint64(block))
+	if err != nil {
+		return err
+	}
+
+	changes := committeeChanges(cw.members, members, block, txHash, time.Now().UTC())
+	if len(changes) == 0 {
+		return nil
+	}
+
+	dbCtx, cancel := context.WithTimeout(parentCtx, cw.dbTimeout)
+	defer cancel()
+
+	if err = cw.db.StoreCommitteeChanges(dbCtx, changes); err != nil {
+		return err
+	}
+
+	for _, c := range changes {
+		logger.WithFields(
+			log.FieldBlockNumber, c.BlockNumber,
+			log.FieldMemberAddr, c.Member.Hex(),
+			log.FieldMemberURL, c.URL,
+		).Infof("committee member %s", c.Kind)
+		applyCommitteeChange(cw.members, c)
+	}
+
+	return nil
+}
+
+// committeeChanges returns the changes from the known members to the given ones, ordered by member
+func committeeChanges(
+	known map[common.Address]string,
+	members []etherman.DataCommitteeMember,
+	block uint64,
+	txHash common.Hash,
+	timestamp time.Time,
+) []types.CommitteeChange {
+	change := func(kind types.CommitteeChangeKind, member common.Address, url string) types.CommitteeChange {
+		return types.CommitteeChange{
+			BlockNumber: block,
+			TxHash:      txHash,
+			Kind:        kind,
+			Member:      member,
+			URL:         url,
+			Timestamp:   timestamp,
+		}
+	}
+
+	var changes []types.CommitteeChange
+	current := make(map[common.Address]struct{}, len(members))
+	for _, m := range members {
+		current[m.Addr] = struct{}{}
+
+		url, ok := known[m.Addr]
+		switch {
+		case !ok:
+			changes = append(changes, change(types.CommitteeMemberAdded, m.Addr, m.URL))
+		case url != m.URL:
+			c := change(types.CommitteeMemberURLChanged, m.Addr, m.URL)
+			c.PreviousURL = url
+			changes = append(changes, c)
+		}
+	}
+
+	for addr, url := range known {
+		if _, ok := current[addr]; !ok {
+			changes = append(changes, change(types.CommitteeMemberRemoved, addr, url))
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return bytes.Compare(changes[i].Member.Bytes(), changes[j].Member.Bytes()) < 0
+	})
+
+	return changes
+}
+
+// applyCommitteeChange updates the known members with a change
+func applyCommitteeChange(members map[common.Address]string, c types.CommitteeChange) {
+	if c.Kind == types.CommitteeMemberRemoved {
+		delete(members, c.Member)
+		return
+	}
+
+	members[c.Member] = c.URL
+}
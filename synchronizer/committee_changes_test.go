@@ -0,0 +1,135 @@
+package synchronizer
+
+import (
+	"context"
+	"database/sql"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/config"
+	cfgTypes "github.com/0xPolygon/cdk-data-availability/config/types"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitteeChanges(t *testing.T) {
+	timestamp := time.Unix(1700000000, 0).UTC()
+	txHash := common.HexToHash("0xabc")
+
+	known := map[common.Address]string{
+		common.HexToAddress("0x1"): "http://member-1",
+		common.HexToAddress("0x2"): "http://member-2",
+		common.HexToAddress("0x3"): "http://member-3",
+	}
+	members := []etherman.DataCommitteeMember{
+		{Addr: common.HexToAddress("0x4"), URL: "http://member-4"},
+		{Addr: common.HexToAddress("0x1"), URL: "http://member-1"},
+		{Addr: common.HexToAddress("0x3"), URL: "http://member-3-new"},
+	}
+
+	changes := committeeChanges(known, members, 10, txHash, timestamp)
+	require.Equal(t, []types.CommitteeChange{
+		{
+			BlockNumber: 10,
+			TxHash:      txHash,
+			Kind:        types.CommitteeMemberRemoved,
+			Member:      common.HexToAddress("0x2"),
+			URL:         "http://member-2",
+			Timestamp:   timestamp,
+		},
+		{
+			BlockNumber: 10,
+			TxHash:      txHash,
+			Kind:        types.CommitteeMemberURLChanged,
+			Member:      common.HexToAddress("0x3"),
+			URL:         "http://member-3-new",
+			PreviousURL: "http://member-3",
+			Timestamp:   timestamp,
+		},
+		{
+			BlockNumber: 10,
+			TxHash:      txHash,
+			Kind:        types.CommitteeMemberAdded,
+			Member:      common.HexToAddress("0x4"),
+			URL:         "http://member-4",
+			Timestamp:   timestamp,
+		},
+	}, changes)
+
+	for _, c := range changes {
+		applyCommitteeChange(known, c)
+	}
+	require.Equal(t, map[common.Address]string{
+		common.HexToAddress("0x1"): "http://member-1",
+		common.HexToAddress("0x3"): "http://member-3-new",
+		common.HexToAddress("0x4"): "http://member-4",
+	}, known)
+
+	require.Empty(t, committeeChanges(known, members, 11, txHash, timestamp))
+}
+
+func TestCommitteeWatcher_Init(t *testing.T) {
+	t.Run("current committee recorded the first time", func(t *testing.T) {
+		dbMock := mocks.NewDB(t)
+		ethermanMock := mocks.NewEtherman(t)
+
+		dbMock.On("ListCommitteeChanges", mock.Anything, uint64(0), uint(committeeChangesPageSize)).
+			Return([]types.CommitteeChange{}, nil).Once()
+		dbMock.On("GetLastProcessedBlock", mock.Anything, string(CommitteeSyncTask)).
+			Return(uint64(0), sql.ErrNoRows).Once()
+		ethermanMock.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).
+			Return(&ethTypes.Header{Number: big.NewInt(100)}, nil).Once()
+		ethermanMock.On("GetDataCommitteeMembers", mock.Anything, big.NewInt(100)).
+			Return([]etherman.DataCommitteeMember{{Addr: common.HexToAddress("0x1"), URL: "http://member-1"}}, nil).Once()
+		dbMock.On("StoreCommitteeChanges", mock.Anything, mock.MatchedBy(func(changes []types.CommitteeChange) bool {
+			return len(changes) == 1 &&
+				changes[0].Kind == types.CommitteeMemberAdded &&
+				changes[0].BlockNumber == 100
+		})).Return(nil).Once()
+		dbMock.On("StoreLastProcessedBlock", mock.Anything, uint64(100), string(CommitteeSyncTask)).
+			Return(nil).Once()
+
+		cw := &CommitteeWatcher{client: ethermanMock, db: dbMock, dbTimeout: time.Second, rpcTimeout: time.Second}
+		require.NoError(t, cw.init(context.Background()))
+		require.Equal(t, map[common.Address]string{common.HexToAddress("0x1"): "http://member-1"}, cw.members)
+	})
+
+	t.Run("known members loaded from the recorded changes", func(t *testing.T) {
+		dbMock := mocks.NewDB(t)
+
+		dbMock.On("ListCommitteeChanges", mock.Anything, uint64(0), uint(committeeChangesPageSize)).
+			Return([]types.CommitteeChange{
+				{ID: 1, Kind: types.CommitteeMemberAdded, Member: common.HexToAddress("0x1"), URL: "http://member-1"},
+				{ID: 2, Kind: types.CommitteeMemberAdded, Member: common.HexToAddress("0x2"), URL: "http://member-2"},
+				{ID: 3, Kind: types.CommitteeMemberRemoved, Member: common.HexToAddress("0x1"), URL: "http://member-1"},
+			}, nil).Once()
+		dbMock.On("GetLastProcessedBlock", mock.Anything, string(CommitteeSyncTask)).
+			Return(uint64(120), nil).Once()
+
+		cw := &CommitteeWatcher{db: dbMock, dbTimeout: time.Second}
+		require.NoError(t, cw.init(context.Background()))
+		require.Equal(t, map[common.Address]string{common.HexToAddress("0x2"): "http://member-2"}, cw.members)
+	})
+}
+
+func TestCommitteeWatcher_Reload(t *testing.T) {
+	cw := &CommitteeWatcher{retry: time.Second, blockBatchSize: 32}
+
+	cw.Reload(config.L1Config{
+		RetryPeriod:    cfgTypes.NewDuration(10 * time.Second),
+		BlockBatchSize: 64,
+	})
+	require.Equal(t, 10*time.Second, cw.retryPeriod())
+	require.Equal(t, uint(64), cw.batchSize())
+
+	// zero values are ignored
+	cw.Reload(config.L1Config{})
+	require.Equal(t, 10*time.Second, cw.retryPeriod())
+	require.Equal(t, uint(64), cw.batchSize())
+}
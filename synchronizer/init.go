@@ -2,19 +2,21 @@ package synchronizer
 
 import (
 	"context"
+	"fmt"
 	"math/big"
 	"time"
 
+	"github.com/0xPolygon/cdk-data-availability/config"
 	"github.com/0xPolygon/cdk-data-availability/db"
 	"github.com/0xPolygon/cdk-data-availability/etherman"
-	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 )
 
 const (
-	initBlockTimeout    = 15 * time.Second
-	minCodeLen          = 2
-	maxUnprocessedBatch = 100
+	defaultInitBlockTimeout = 15 * time.Second
+	minCodeLen              = 2
+	maxUnprocessedBatch     = 100
 )
 
 // InitStartBlock initializes the L1 sync task by finding the inception block for the CDKValidium contract
@@ -23,11 +25,21 @@ func InitStartBlock(
 	db db.DB, em etherman.Etherman,
 	genesisBlock uint64,
 	validiumAddr common.Address,
+	timeouts config.TimeoutsConfig,
 ) error {
+	initBlockTimeout := timeouts.StartBlockSearch.Duration
+	if initBlockTimeout <= 0 {
+		initBlockTimeout = defaultInitBlockTimeout
+	}
+	dbTimeout := timeouts.DBOperation.Duration
+	if dbTimeout <= 0 {
+		dbTimeout = defaultDBTimeout
+	}
+
 	ctx, cancel := context.WithTimeout(parentCtx, initBlockTimeout)
 	defer cancel()
 
-	current, err := getStartBlock(ctx, db, L1SyncTask)
+	current, err := getStartBlock(ctx, db, dbTimeout, L1SyncTask)
 	if err != nil {
 		return err
 	}
@@ -37,22 +49,23 @@ func InitStartBlock(
 		return nil
 	}
 
-	log.Info("starting search for start block of contract ", validiumAddr)
+	logger.Info("starting search for start block of contract ", validiumAddr)
 
 	startBlock := new(big.Int)
 	if genesisBlock != 0 {
 		startBlock.SetUint64(genesisBlock)
 	} else {
-		startBlock, err = findContractDeploymentBlock(ctx, em, validiumAddr)
+		startBlock, err = FindContractDeploymentBlock(ctx, em, validiumAddr)
 		if err != nil {
 			return err
 		}
 	}
 
-	return setStartBlock(ctx, db, startBlock.Uint64(), L1SyncTask)
+	return setStartBlock(ctx, db, dbTimeout, startBlock.Uint64(), L1SyncTask)
 }
 
-func findContractDeploymentBlock(ctx context.Context, em etherman.Etherman, contract common.Address) (*big.Int, error) {
+// FindContractDeploymentBlock returns the first block holding the code of the given contract
+func FindContractDeploymentBlock(ctx context.Context, em etherman.Etherman, contract common.Address) (*big.Int, error) {
 	latestHeader, err := em.HeaderByNumber(ctx, nil)
 	if err != nil {
 		return nil, err
@@ -81,3 +94,44 @@ func codeLen(ctx context.Context, em etherman.Etherman, address common.Address,
 	}
 	return int64(len(data))
 }
+
+// LastBatchSequencedBefore returns the last batch sequenced before the given L1 block, filtering the
+// sequences backwards from the block blockRange blocks at a time
+func LastBatchSequencedBefore(
+	ctx context.Context,
+	em etherman.Etherman,
+	block, blockRange uint64,
+) (uint64, error) {
+	for end := block; end > 0; {
+		start := uint64(0)
+		if end > blockRange {
+			start = end - blockRange
+		}
+		last := end - 1
+
+		iter, err := em.FilterSequenceBatches(&bind.FilterOpts{Context: ctx, Start: start, End: &last}, nil)
+		if err != nil {
+			return 0, fmt.Errorf("failed to filter the sequences of the blocks %d to %d: %w", start, last, err)
+		}
+
+		var batch uint64
+		for iter.Next() {
+			if iter.Event.NumBatch > batch {
+				batch = iter.Event.NumBatch
+			}
+		}
+		if err = iter.Error(); err != nil {
+			return 0, err
+		}
+		if err = iter.Close(); err != nil {
+			return 0, err
+		}
+
+		if batch > 0 {
+			return batch, nil
+		}
+		end = start
+	}
+
+	return 0, nil
+}
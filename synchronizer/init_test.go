@@ -34,6 +34,11 @@ func Test_InitStartBlock(t *testing.T) {
 		isErrorExpected bool
 	}
 
+	timeouts := config.TimeoutsConfig{
+		DBOperation:      types.NewDuration(time.Second),
+		StartBlockSearch: types.NewDuration(time.Second),
+	}
+
 	l1Config := config.L1Config{
 		RpcURL: "ws://localhost:8080/ws",
 		// RpcURL:                 "http://localhost:8081",
@@ -78,6 +83,7 @@ func Test_InitStartBlock(t *testing.T) {
 			emMock,
 			l1Config.GenesisBlock,
 			common.HexToAddress(l1Config.PolygonValidiumAddress),
+			timeouts,
 		)
 		if config.isErrorExpected {
 			require.Error(t, err)
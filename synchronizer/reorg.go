@@ -2,18 +2,18 @@ package synchronizer
 
 import (
 	"context"
+	"fmt"
 	"time"
 
-	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
 	"github.com/umbracle/ethgo"
 	"github.com/umbracle/ethgo/blocktracker"
-	"github.com/umbracle/ethgo/jsonrpc"
 )
 
 // ReorgDetector watches for block reorganizations on chain, and sends messages to subscribing components when a reorg
 // is detected.
 type ReorgDetector struct {
-	rpcUrl        string
+	etherman      etherman.Etherman
 	pollingPeriod time.Duration
 	subscribers   []chan BlockReorg
 	cancel        context.CancelFunc
@@ -25,10 +25,10 @@ type BlockReorg struct {
 	Hash   ethgo.Hash
 }
 
-// NewReorgDetector creates a new ReorgDetector
-func NewReorgDetector(rpcUrl string, pollingPeriod time.Duration) (*ReorgDetector, error) {
+// NewReorgDetector creates a new ReorgDetector polling the L1 head through the given etherman
+func NewReorgDetector(em etherman.Etherman, pollingPeriod time.Duration) (*ReorgDetector, error) {
 	return &ReorgDetector{
-		rpcUrl:        rpcUrl,
+		etherman:      em,
 		pollingPeriod: pollingPeriod,
 	}, nil
 }
@@ -40,9 +40,14 @@ func (rd *ReorgDetector) Subscribe() <-chan BlockReorg {
 	return ch
 }
 
+// Subscribers returns the number of components subscribed to the reorgs
+func (rd *ReorgDetector) Subscribers() int {
+	return len(rd.subscribers)
+}
+
 // Start starts the ReorgDetector tracking for reorg events
 func (rd *ReorgDetector) Start() error {
-	log.Info("starting block reorganization detector")
+	logger.Info("starting block reorganization detector")
 
 	ctx, cancel := context.WithCancel(context.Background())
 	rd.cancel = cancel
@@ -87,11 +92,11 @@ func (rd *ReorgDetector) Stop() {
 }
 
 func (rd *ReorgDetector) trackBlocks(ctx context.Context, ch chan *ethgo.Block) error {
-	client, err := jsonrpc.NewClient(rd.rpcUrl)
-	if err != nil {
-		return err
-	}
-	tracker := blocktracker.NewJSONBlockTracker(client.Eth())
+	tracker := blocktracker.NewJSONBlockTracker(&headerProvider{
+		ctx:      ctx,
+		etherman: rd.etherman,
+		timeout:  rd.pollingPeriod,
+	})
 	tracker.PollInterval = rd.pollingPeriod
 	go func() {
 		_ = tracker.Track(ctx, func(block *ethgo.Block) error {
@@ -101,3 +106,38 @@ func (rd *ReorgDetector) trackBlocks(ctx context.Context, ch chan *ethgo.Block)
 	}()
 	return nil
 }
+
+// headerProvider provides the blocks tracked from the L1 headers of the etherman, so that the
+// detector shares its connection, and its proxy, rather than dialing the L1 node on its own
+type headerProvider struct {
+	ctx      context.Context
+	etherman etherman.Etherman
+	timeout  time.Duration
+}
+
+// GetBlockByNumber returns the latest block, the only one polled by the tracker
+func (p *headerProvider) GetBlockByNumber(i ethgo.BlockNumber, _ bool) (*ethgo.Block, error) {
+	if i != ethgo.Latest {
+		return nil, fmt.Errorf("block %s not supported, only the latest block is", i.String())
+	}
+
+	ctx, cancel := context.WithTimeout(p.ctx, p.timeout)
+	defer cancel()
+
+	header, err := p.etherman.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ethgo.Block{
+		Number:     header.Number.Uint64(),
+		Hash:       ethgo.Hash(header.Hash()),
+		ParentHash: ethgo.Hash(header.ParentHash),
+		Timestamp:  header.Time,
+	}, nil
+}
+
+// GetBlockByHash is not used by the tracker
+func (p *headerProvider) GetBlockByHash(hash ethgo.Hash, _ bool) (*ethgo.Block, error) {
+	return nil, fmt.Errorf("block %s not supported, only the latest block is", hash.String())
+}
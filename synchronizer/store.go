@@ -5,7 +5,7 @@ import (
 	"time"
 
 	dbTypes "github.com/0xPolygon/cdk-data-availability/db"
-	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/metrics"
 	"github.com/0xPolygon/cdk-data-availability/types"
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -16,17 +16,20 @@ type SyncTask string
 const (
 	// L1SyncTask is the name of the L1 sync task
 	L1SyncTask SyncTask = "L1"
+	// CommitteeSyncTask is the name of the task watching the committee changes
+	CommitteeSyncTask SyncTask = "committee"
 
-	dbTimeout = 2 * time.Second
+	// defaultDBTimeout is the timeout of the database operations when none is configured
+	defaultDBTimeout = 2 * time.Second
 )
 
-func getStartBlock(parentCtx context.Context, db dbTypes.DB, syncTask SyncTask) (uint64, error) {
-	ctx, cancel := context.WithTimeout(parentCtx, dbTimeout)
+func getStartBlock(parentCtx context.Context, db dbTypes.DB, timeout time.Duration, syncTask SyncTask) (uint64, error) {
+	ctx, cancel := context.WithTimeout(parentCtx, timeout)
 	defer cancel()
 
 	start, err := db.GetLastProcessedBlock(ctx, string(syncTask))
 	if err != nil {
-		log.Errorf("error retrieving last processed block for %s task, starting from 0: %v", syncTask, err)
+		logger.Errorf("error retrieving last processed block for %s task, starting from 0: %v", syncTask, err)
 	}
 
 	if start > 0 {
@@ -36,44 +39,114 @@ func getStartBlock(parentCtx context.Context, db dbTypes.DB, syncTask SyncTask)
 	return start, err
 }
 
-func setStartBlock(parentCtx context.Context, db dbTypes.DB, block uint64, syncTask SyncTask) error {
-	ctx, cancel := context.WithTimeout(parentCtx, dbTimeout)
+func setStartBlock(
+	parentCtx context.Context,
+	db dbTypes.DB,
+	timeout time.Duration,
+	block uint64,
+	syncTask SyncTask,
+) error {
+	ctx, cancel := context.WithTimeout(parentCtx, timeout)
 	defer cancel()
 
-	return db.StoreLastProcessedBlock(ctx, block, string(syncTask))
+	if err := db.StoreLastProcessedBlock(ctx, block, string(syncTask)); err != nil {
+		return err
+	}
+
+	if syncTask == L1SyncTask {
+		metrics.LastProcessedBlock(block)
+	}
+
+	return nil
+}
+
+// ResyncFrom rewinds the L1 sync task so that the synchronizer processes the blocks again from the given
+// block, and returns the block the task was at
+func ResyncFrom(parentCtx context.Context, db dbTypes.DB, timeout time.Duration, block uint64) (uint64, error) {
+	current, err := getStartBlock(parentCtx, db, timeout, L1SyncTask)
+	if err != nil {
+		return 0, err
+	}
+
+	// the synchronizer starts from the block before the one stored, see getStartBlock
+	return current, setStartBlock(parentCtx, db, timeout, block+1, L1SyncTask)
 }
 
-func listOffchainData(parentCtx context.Context, db dbTypes.DB, keys []common.Hash) ([]types.OffChainData, error) {
-	ctx, cancel := context.WithTimeout(parentCtx, dbTimeout)
+func listOffchainData(
+	parentCtx context.Context,
+	db dbTypes.DB,
+	timeout time.Duration,
+	keys []common.Hash,
+) ([]types.OffChainData, error) {
+	ctx, cancel := context.WithTimeout(parentCtx, timeout)
 	defer cancel()
 
 	return db.ListOffChainData(ctx, keys)
 }
 
-func storeUnresolvedBatchKeys(parentCtx context.Context, db dbTypes.DB, keys []types.BatchKey) error {
-	ctx, cancel := context.WithTimeout(parentCtx, dbTimeout)
+func storeUnresolvedBatchKeys(
+	parentCtx context.Context,
+	db dbTypes.DB,
+	timeout time.Duration,
+	keys []types.BatchKey,
+) error {
+	ctx, cancel := context.WithTimeout(parentCtx, timeout)
 	defer cancel()
 
 	return db.StoreUnresolvedBatchKeys(ctx, keys)
 }
 
-func getUnresolvedBatchKeys(parentCtx context.Context, db dbTypes.DB) ([]types.BatchKey, error) {
-	ctx, cancel := context.WithTimeout(parentCtx, dbTimeout)
+func getUnresolvedBatchKeys(parentCtx context.Context, db dbTypes.DB, timeout time.Duration) ([]types.BatchKey, error) {
+	ctx, cancel := context.WithTimeout(parentCtx, timeout)
 	defer cancel()
 
 	return db.GetUnresolvedBatchKeys(ctx, maxUnprocessedBatch)
 }
 
-func deleteUnresolvedBatchKeys(parentCtx context.Context, db dbTypes.DB, keys []types.BatchKey) error {
-	ctx, cancel := context.WithTimeout(parentCtx, dbTimeout)
+func deleteUnresolvedBatchKeys(
+	parentCtx context.Context,
+	db dbTypes.DB,
+	timeout time.Duration,
+	keys []types.BatchKey,
+) error {
+	ctx, cancel := context.WithTimeout(parentCtx, timeout)
 	defer cancel()
 
 	return db.DeleteUnresolvedBatchKeys(ctx, keys)
 }
 
-func storeOffchainData(parentCtx context.Context, db dbTypes.DB, data []types.OffChainData) error {
-	ctx, cancel := context.WithTimeout(parentCtx, dbTimeout)
+func storeOffchainData(
+	parentCtx context.Context,
+	db dbTypes.DB,
+	timeout time.Duration,
+	data []types.OffChainData,
+) error {
+	ctx, cancel := context.WithTimeout(parentCtx, timeout)
 	defer cancel()
 
 	return db.StoreOffChainData(ctx, data)
 }
+
+func storeServedValues(
+	parentCtx context.Context,
+	db dbTypes.DB,
+	timeout time.Duration,
+	served []types.ServedValue,
+) error {
+	ctx, cancel := context.WithTimeout(parentCtx, timeout)
+	defer cancel()
+
+	return db.StoreServedValues(ctx, served)
+}
+
+func storeProvenance(
+	parentCtx context.Context,
+	db dbTypes.DB,
+	timeout time.Duration,
+	provenance []types.Provenance,
+) error {
+	ctx, cancel := context.WithTimeout(parentCtx, timeout)
+	defer cancel()
+
+	return db.StoreProvenance(ctx, provenance)
+}
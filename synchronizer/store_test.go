@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/0xPolygon/cdk-data-availability/db"
 	"github.com/0xPolygon/cdk-data-availability/mocks"
@@ -53,7 +54,7 @@ func Test_getStartBlock(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			testDB := tt.db(t)
 
-			if block, err := getStartBlock(context.Background(), testDB, L1SyncTask); tt.wantErr {
+			if block, err := getStartBlock(context.Background(), testDB, time.Second, L1SyncTask); tt.wantErr {
 				require.ErrorIs(t, err, testError)
 			} else {
 				require.NoError(t, err)
@@ -104,7 +105,7 @@ func Test_setStartBlock(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			testDB := tt.db(t)
 
-			if err := setStartBlock(context.Background(), testDB, tt.block, L1SyncTask); tt.wantErr {
+			if err := setStartBlock(context.Background(), testDB, time.Second, tt.block, L1SyncTask); tt.wantErr {
 				require.ErrorIs(t, err, testError)
 			} else {
 				require.NoError(t, err)
@@ -113,6 +114,33 @@ func Test_setStartBlock(t *testing.T) {
 	}
 }
 
+func TestResyncFrom(t *testing.T) {
+	t.Run("rewinds the L1 sync task", func(t *testing.T) {
+		mockDB := mocks.NewDB(t)
+		mockDB.On("GetLastProcessedBlock", mock.Anything, "L1").Return(uint64(501), nil)
+		mockDB.On("StoreLastProcessedBlock", mock.Anything, uint64(101), "L1").Return(nil)
+
+		current, err := ResyncFrom(context.Background(), mockDB, time.Second, 100)
+		require.NoError(t, err)
+		require.Equal(t, uint64(500), current)
+
+		// the synchronizer restarts from the block given
+		mockDB.On("GetLastProcessedBlock", mock.Anything, "L1").Unset()
+		mockDB.On("GetLastProcessedBlock", mock.Anything, "L1").Return(uint64(101), nil)
+		start, err := getStartBlock(context.Background(), mockDB, time.Second, L1SyncTask)
+		require.NoError(t, err)
+		require.Equal(t, uint64(100), start)
+	})
+
+	t.Run("failure to get the L1 sync task", func(t *testing.T) {
+		mockDB := mocks.NewDB(t)
+		mockDB.On("GetLastProcessedBlock", mock.Anything, "L1").Return(uint64(0), errors.New("test error"))
+
+		_, err := ResyncFrom(context.Background(), mockDB, time.Second, 100)
+		require.ErrorContains(t, err, "test error")
+	})
+}
+
 func Test_storeUnresolvedBatchKeys(t *testing.T) {
 	testError := errors.New("test error")
 	testData := []types.BatchKey{
@@ -158,7 +186,7 @@ func Test_storeUnresolvedBatchKeys(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			testDB := tt.db(t)
 
-			if err := storeUnresolvedBatchKeys(context.Background(), testDB, tt.keys); tt.wantErr {
+			if err := storeUnresolvedBatchKeys(context.Background(), testDB, time.Second, tt.keys); tt.wantErr {
 				require.ErrorIs(t, err, testError)
 			} else {
 				require.NoError(t, err)
@@ -212,7 +240,7 @@ func Test_getUnresolvedBatchKeys(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			testDB := tt.db(t)
 
-			if keys, err := getUnresolvedBatchKeys(context.Background(), testDB); tt.wantErr {
+			if keys, err := getUnresolvedBatchKeys(context.Background(), testDB, time.Second); tt.wantErr {
 				require.ErrorIs(t, err, testError)
 			} else {
 				require.NoError(t, err)
@@ -266,7 +294,7 @@ func Test_deleteUnresolvedBatchKeys(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			testDB := tt.db(t)
 
-			if err := deleteUnresolvedBatchKeys(context.Background(), testDB, testData); tt.wantErr {
+			if err := deleteUnresolvedBatchKeys(context.Background(), testDB, time.Second, testData); tt.wantErr {
 				require.ErrorIs(t, err, testError)
 			} else {
 				require.NoError(t, err)
@@ -320,7 +348,58 @@ func Test_storeOffchainData(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			testDB := tt.db(t)
 
-			if err := storeOffchainData(context.Background(), testDB, tt.data); tt.wantErr {
+			if err := storeOffchainData(context.Background(), testDB, time.Second, tt.data); tt.wantErr {
+				require.ErrorIs(t, err, testError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_storeServedValues(t *testing.T) {
+	testError := errors.New("test error")
+	testData := []types.ServedValue{
+		{
+			Key:    common.HexToHash("0x01"),
+			Member: common.HexToAddress("0x02"),
+		},
+	}
+
+	tests := []struct {
+		name    string
+		db      func(t *testing.T) db.DB
+		wantErr bool
+	}{
+		{
+			name: "StoreServedValues returns error",
+			db: func(t *testing.T) db.DB {
+				t.Helper()
+				mockDB := mocks.NewDB(t)
+
+				mockDB.On("StoreServedValues", mock.Anything, testData).Return(testError)
+
+				return mockDB
+			},
+			wantErr: true,
+		},
+		{
+			name: "all good",
+			db: func(t *testing.T) db.DB {
+				t.Helper()
+				mockDB := mocks.NewDB(t)
+
+				mockDB.On("StoreServedValues", mock.Anything, testData).Return(nil)
+
+				return mockDB
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testDB := tt.db(t)
+
+			if err := storeServedValues(context.Background(), testDB, time.Second, testData); tt.wantErr {
 				require.ErrorIs(t, err, testError)
 			} else {
 				require.NoError(t, err)
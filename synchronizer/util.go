@@ -3,13 +3,14 @@ package synchronizer
 import (
 	"bytes"
 	"encoding/hex"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"strings"
 
 	elderberryValidium "github.com/0xPolygon/cdk-data-availability/etherman/smartcontracts/elderberry/polygonvalidium"
 	etrogValidium "github.com/0xPolygon/cdk-data-availability/etherman/smartcontracts/etrog/polygonvalidium"
+	"github.com/0xPolygon/cdk-data-availability/types"
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 )
@@ -26,57 +27,140 @@ var (
 	)[:methodIDLen]
 )
 
+var (
+	// methodSequenceBatchesValidiumEtrog is the sequenceBatchesValidium method of the Etrog fork, parsed once
+	// rather than for every event
+	methodSequenceBatchesValidiumEtrog = mustMethodByID(
+		etrogValidium.PolygonvalidiumMetaData, methodIDSequenceBatchesValidiumEtrog,
+	)
+
+	// methodSequenceBatchesValidiumElderberry is the sequenceBatchesValidium method of the Elderberry fork
+	methodSequenceBatchesValidiumElderberry = mustMethodByID(
+		elderberryValidium.PolygonvalidiumMetaData, methodIDSequenceBatchesValidiumElderberry,
+	)
+)
+
+// mustMethodByID returns the method of the contract with the given id, panicking if the ABI of the binding
+// does not have it
+func mustMethodByID(metaData *bind.MetaData, id []byte) *abi.Method {
+	parsed, err := metaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+	method, err := parsed.MethodById(id)
+	if err != nil {
+		panic(err)
+	}
+	return method
+}
+
 const (
 	// methodIDLen represents method id size in bytes
 	methodIDLen = 4
 )
 
+// ErrMalformedSequence is returned when the data of a sequence can not be decoded. Decoding it again
+// would fail the same way, so the sequence is skipped rather than retried.
+var ErrMalformedSequence = errors.New("malformed sequence")
+
+// ErrUnknownMethod is returned when the transaction of a sequence is not a call of a known sequenceBatchesValidium,
+// e.g. sent through a multisig or by a new fork. Its data may well be valid, so the sequence is not skipped.
+var ErrUnknownMethod = errors.New("unknown sequence method")
+
 // UnpackTxData unpacks the keys in a SequenceBatches event
 func UnpackTxData(txData []byte) ([]common.Hash, error) {
-	methodID := txData[:methodIDLen]
+	data, err := unpackSequenceBatches(txData)
+	if err != nil {
+		return nil, err
+	}
 
-	var (
-		a   abi.ABI
-		err error
-	)
+	// the decoder returns the batches as a slice of an anonymous struct, with a field tagged with its name
+	// for each component of the BatchData tuple both forks share. Asserting it directly spares the JSON
+	// round trip through the binding type, which dominated the CPU time of the backfills.
+	batches, ok := data[0].([]struct {
+		TransactionsHash     [32]byte `json:"transactionsHash"`
+		ForcedGlobalExitRoot [32]byte `json:"forcedGlobalExitRoot"`
+		ForcedTimestamp      uint64   `json:"forcedTimestamp"`
+		ForcedBlockHashL1    [32]byte `json:"forcedBlockHashL1"`
+	})
+	if !ok {
+		return nil, fmt.Errorf("%w: unexpected batches of type %T", ErrMalformedSequence, data[0])
+	}
 
-	if bytes.Equal(methodID, methodIDSequenceBatchesValidiumEtrog) {
-		a, err = abi.JSON(strings.NewReader(etrogValidium.PolygonvalidiumMetaData.ABI))
-		if err != nil {
-			return nil, err
-		}
-	} else if bytes.Equal(methodID, methodIDSequenceBatchesValidiumElderberry) {
-		a, err = abi.JSON(strings.NewReader(elderberryValidium.PolygonvalidiumMetaData.ABI))
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		return nil, fmt.Errorf("unrecognized method id: %s", hex.EncodeToString(methodID))
+	keys := make([]common.Hash, len(batches))
+	for i, batch := range batches {
+		keys[i] = batch.TransactionsHash
 	}
+	return keys, nil
+}
 
-	method, err := a.MethodById(methodID)
+// UnpackDataAvailabilityMessage unpacks the dataAvailabilityMessage of a sequenceBatchesValidium transaction:
+// the signatures of the committee members over the sequence, followed by the addresses of all the members
+func UnpackDataAvailabilityMessage(txData []byte) ([]byte, error) {
+	data, err := unpackSequenceBatches(txData)
 	if err != nil {
 		return nil, err
 	}
 
-	data, err := method.Inputs.Unpack(txData[methodIDLen:])
-	if err != nil {
-		return nil, err
+	// the message is the last argument in every fork
+	message, ok := data[len(data)-1].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("%w: unexpected dataAvailabilityMessage of type %T",
+			ErrMalformedSequence, data[len(data)-1])
+	}
+	return message, nil
+}
+
+// unpackSequenceBatches unpacks the arguments of a sequenceBatchesValidium transaction of any supported fork.
+// The transaction data is untrusted, the panics of the ABI decoder are returned as errors.
+func unpackSequenceBatches(txData []byte) (args []interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			args, err = nil, fmt.Errorf("%w: %v", ErrMalformedSequence, r)
+		}
+	}()
+
+	if len(txData) < methodIDLen {
+		return nil, fmt.Errorf("%w: the transaction data of %d bytes has no method id",
+			ErrUnknownMethod, len(txData))
 	}
+	methodID := txData[:methodIDLen]
 
-	bytes, err := json.Marshal(data[0])
+	var method *abi.Method
+	if bytes.Equal(methodID, methodIDSequenceBatchesValidiumEtrog) {
+		method = methodSequenceBatchesValidiumEtrog
+	} else if bytes.Equal(methodID, methodIDSequenceBatchesValidiumElderberry) {
+		method = methodSequenceBatchesValidiumElderberry
+	} else {
+		return nil, fmt.Errorf("%w: unrecognized method id: %s", ErrUnknownMethod, hex.EncodeToString(methodID))
+	}
+
+	args, err = method.Inputs.Unpack(txData[methodIDLen:])
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %v", ErrMalformedSequence, err)
+	}
+	if len(args) != len(method.Inputs) {
+		return nil, fmt.Errorf("%w: %d arguments unpacked, %d expected", ErrMalformedSequence, len(args),
+			len(method.Inputs))
 	}
 
-	var batches []etrogValidium.PolygonValidiumEtrogValidiumBatchData
-	if err = json.Unmarshal(bytes, &batches); err != nil {
-		return nil, err
+	return args, nil
+}
+
+// SequenceBatchKeys returns the keys of a sequence with their batch numbers, the event having the number of
+// the last batch and the keys being in the order of the batches
+func SequenceBatchKeys(lastBatch uint64, keys []common.Hash) ([]types.BatchKey, error) {
+	if uint64(len(keys)) > lastBatch {
+		return nil, fmt.Errorf("%w: %d keys sequenced up to the batch %d", ErrMalformedSequence, len(keys), lastBatch)
 	}
 
-	keys := make([]common.Hash, len(batches))
-	for i, batch := range batches {
-		keys[i] = batch.TransactionsHash
+	batchKeys := make([]types.BatchKey, len(keys))
+	for i, key := range keys {
+		batchKeys[i] = types.BatchKey{
+			Number: lastBatch - uint64(len(keys)-1-i),
+			Hash:   key,
+		}
 	}
-	return keys, nil
+
+	return batchKeys, nil
 }
@@ -2,8 +2,15 @@ package synchronizer
 
 import (
 	"encoding/hex"
+	"errors"
+	"strings"
 	"testing"
 
+	elderberryValidium "github.com/0xPolygon/cdk-data-availability/etherman/smartcontracts/elderberry/polygonvalidium"
+	etrogValidium "github.com/0xPolygon/cdk-data-availability/etherman/smartcontracts/etrog/polygonvalidium"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 )
 
@@ -16,3 +23,125 @@ func Test_SequenceBatchesValidiumMethodIDs_Equality(t *testing.T) {
 	require.Equal(t, expectedSequenceBatchesValidiumEtrog, hex.EncodeToString(methodIDSequenceBatchesValidiumEtrog))
 	require.Equal(t, expectedSequenceBatchesValidiumElderberry, hex.EncodeToString(methodIDSequenceBatchesValidiumElderberry))
 }
+
+// sequenceTxData returns the data of an Etrog sequenceBatchesValidium transaction of the given keys
+func sequenceTxData(t testing.TB, keys ...common.Hash) []byte {
+	t.Helper()
+
+	a, err := abi.JSON(strings.NewReader(etrogValidium.PolygonvalidiumABI))
+	require.NoError(t, err)
+	method := a.Methods["sequenceBatchesValidium"]
+
+	batches := make([]etrogValidium.PolygonValidiumEtrogValidiumBatchData, len(keys))
+	for i, key := range keys {
+		batches[i].TransactionsHash = key
+	}
+
+	data, err := method.Inputs.Pack(batches, common.HexToAddress("0xABCD"), []byte{1, 2, 3})
+	require.NoError(t, err)
+
+	return append(method.ID, data...)
+}
+
+func TestUnpackTxData(t *testing.T) {
+	keys := []common.Hash{common.HexToHash("0x1"), common.HexToHash("0x2")}
+	txData := sequenceTxData(t, keys...)
+
+	unpacked, err := UnpackTxData(txData)
+	require.NoError(t, err)
+	require.Equal(t, keys, unpacked)
+
+	message, err := UnpackDataAvailabilityMessage(txData)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2, 3}, message)
+
+	for name, data := range map[string][]byte{
+		"no method id":      txData[:2],
+		"unknown method id": append([]byte{1, 2, 3, 4}, txData[methodIDLen:]...),
+	} {
+		_, err = UnpackTxData(data)
+		require.ErrorIs(t, err, ErrUnknownMethod, name)
+		require.NotErrorIs(t, err, ErrMalformedSequence, name)
+
+		_, err = UnpackDataAvailabilityMessage(data)
+		require.ErrorIs(t, err, ErrUnknownMethod, name)
+	}
+
+	for name, data := range map[string][]byte{
+		"truncated arguments": txData[:len(txData)-40],
+		"only the method id":  txData[:methodIDLen],
+		"huge offset":         append(append([]byte{}, txData[:methodIDLen]...), common.MaxHash.Bytes()...),
+		"arguments of elderberry": append(append([]byte{}, methodIDSequenceBatchesValidiumElderberry...),
+			txData[methodIDLen:]...),
+	} {
+		_, err = UnpackTxData(data)
+		require.ErrorIs(t, err, ErrMalformedSequence, name)
+
+		_, err = UnpackDataAvailabilityMessage(data)
+		require.ErrorIs(t, err, ErrMalformedSequence, name)
+	}
+}
+
+func TestUnpackTxData_Elderberry(t *testing.T) {
+	a, err := abi.JSON(strings.NewReader(elderberryValidium.PolygonvalidiumABI))
+	require.NoError(t, err)
+	method := a.Methods["sequenceBatchesValidium"]
+
+	keys := []common.Hash{common.HexToHash("0x1"), common.HexToHash("0x2")}
+	batches := []elderberryValidium.PolygonValidiumEtrogValidiumBatchData{
+		{TransactionsHash: keys[0]}, {TransactionsHash: keys[1], ForcedTimestamp: 1},
+	}
+	data, err := method.Inputs.Pack(batches, uint64(10), uint64(20), common.HexToAddress("0xABCD"), []byte{1})
+	require.NoError(t, err)
+
+	unpacked, err := UnpackTxData(append(method.ID, data...))
+	require.NoError(t, err)
+	require.Equal(t, keys, unpacked)
+}
+
+func TestSequenceBatchKeys(t *testing.T) {
+	keys := []common.Hash{common.HexToHash("0x1"), common.HexToHash("0x2")}
+
+	batchKeys, err := SequenceBatchKeys(10, keys)
+	require.NoError(t, err)
+	require.Equal(t, []types.BatchKey{{Number: 9, Hash: keys[0]}, {Number: 10, Hash: keys[1]}}, batchKeys)
+
+	// the batch numbers would wrap around
+	_, err = SequenceBatchKeys(1, keys)
+	require.ErrorIs(t, err, ErrMalformedSequence)
+}
+
+func FuzzUnpackTxData(f *testing.F) {
+	f.Add(sequenceTxData(f, common.HexToHash("0x1"), common.HexToHash("0x2")))
+	f.Add(sequenceTxData(f))
+	f.Add(methodIDSequenceBatchesValidiumElderberry)
+	f.Add([]byte{})
+
+	f.Fuzz(func(t *testing.T, txData []byte) {
+		// any data is either decoded or reported as malformed or of an unknown method, without panicking
+		if _, err := UnpackTxData(txData); err != nil && !errors.Is(err, ErrUnknownMethod) {
+			require.ErrorIs(t, err, ErrMalformedSequence)
+		}
+		if _, err := UnpackDataAvailabilityMessage(txData); err != nil && !errors.Is(err, ErrUnknownMethod) {
+			require.ErrorIs(t, err, ErrMalformedSequence)
+		}
+	})
+}
+
+func FuzzUnpackTxData_Elderberry(f *testing.F) {
+	a, err := abi.JSON(strings.NewReader(elderberryValidium.PolygonvalidiumABI))
+	require.NoError(f, err)
+	method := a.Methods["sequenceBatchesValidium"]
+
+	batches := []elderberryValidium.PolygonValidiumEtrogValidiumBatchData{{TransactionsHash: common.HexToHash("0x1")}}
+	data, err := method.Inputs.Pack(batches, uint64(10), uint64(20), common.HexToAddress("0xABCD"), []byte{1})
+	require.NoError(f, err)
+	f.Add(data)
+
+	f.Fuzz(func(t *testing.T, args []byte) {
+		txData := append(append([]byte{}, method.ID...), args...)
+		if _, err := UnpackTxData(txData); err != nil {
+			require.ErrorIs(t, err, ErrMalformedSequence)
+		}
+	})
+}
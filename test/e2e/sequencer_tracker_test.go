@@ -52,7 +52,7 @@ func TestSequencerAddrExists(t *testing.T) {
 		etm, err := etherman.New(ctx.Context, cfg.L1)
 		require.NoError(t, err)
 
-		tracker := sequencer.NewTracker(cfg.L1, etm)
+		tracker := sequencer.NewTracker(cfg.L1, cfg.Timeouts, etm)
 
 		tracker.Start(ctx.Context)
 
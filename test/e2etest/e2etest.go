@@ -0,0 +1,306 @@
+package e2etest
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/client"
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/devnet"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/sequencer"
+	"github.com/0xPolygon/cdk-data-availability/synchronizer"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/umbracle/ethgo"
+)
+
+// logger is the logger of the e2etest component
+var logger = log.WithComponent("e2etest")
+
+// defaultRetryPeriod is how often the synchronizers of the nodes run when Config.RetryPeriod is not set
+const defaultRetryPeriod = 50 * time.Millisecond
+
+// Config is the configuration of a network
+type Config struct {
+	// Nodes is the number of nodes of the committee
+	Nodes int
+
+	// Required is the number of signatures each sequence requires, all the nodes when 0
+	Required int
+
+	// RetryPeriod is how often the synchronizers of the nodes filter the events and resolve the missing batches
+	RetryPeriod time.Duration
+
+	// Postgres is the PostgreSQL server the nodes store in, each in a database of its own created with the
+	// network and dropped when it stops. The nodes store in memory when nil.
+	Postgres *db.Config
+}
+
+// Network is a committee of DA nodes running in the process on a simulated L1, for the behaviors involving several
+// nodes to be tested without any external dependency but the optional PostgreSQL server. The test drives it as the
+// trusted sequencer and the rollup nodes do: it has sequences signed by the nodes it chooses then sequenced on L1,
+// and reads the data from any node. The nodes missing the data of a sequence resolve it from the others.
+//
+// The gossip of the stored keys is process-wide, so the nodes of a network replicate by resolving only.
+type Network struct {
+	cfg       Config
+	config    config.Config
+	chain     *devnet.Chain
+	tracker   *sequencer.Tracker
+	sequencer *ecdsa.PrivateKey
+	nodes     []*Node
+}
+
+// New returns a network of the committee of the config, its nodes not being started
+func New(ctx context.Context, cfg Config) (*Network, error) {
+	if cfg.Nodes <= 0 {
+		return nil, fmt.Errorf("invalid number of nodes %d", cfg.Nodes)
+	}
+	if cfg.Required == 0 {
+		cfg.Required = cfg.Nodes
+	}
+	if cfg.Required < 0 || cfg.Required > cfg.Nodes {
+		return nil, fmt.Errorf("invalid number of required signatures %d for %d nodes", cfg.Required, cfg.Nodes)
+	}
+	if cfg.RetryPeriod <= 0 {
+		cfg.RetryPeriod = defaultRetryPeriod
+	}
+
+	c, err := config.Default()
+	if err != nil {
+		return nil, err
+	}
+	c.L1.RetryPeriod.Duration = cfg.RetryPeriod
+	// the sequencer never changes, the nodes read it once from the simulated L1
+	c.L1.TrackSequencer = false
+
+	sequencerKey, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	network := &Network{
+		cfg:       cfg,
+		config:    *c,
+		chain:     devnet.NewChain(c.L1, crypto.PubkeyToAddress(sequencerKey.PublicKey)),
+		sequencer: sequencerKey,
+	}
+	network.tracker = sequencer.NewTracker(c.L1, c.Timeouts, network.chain)
+
+	committee := make([]etherman.DataCommitteeMember, cfg.Nodes)
+	for i := range committee {
+		node, err := newNode(ctx, network, i)
+		if err != nil {
+			_ = network.Stop()
+			return nil, err
+		}
+		network.nodes = append(network.nodes, node)
+		committee[i] = node.DataCommitteeMember
+	}
+	if _, err = network.chain.UpdateCommittee(committee, uint64(cfg.Required)); err != nil {
+		_ = network.Stop()
+		return nil, err
+	}
+
+	return network, nil
+}
+
+// Chain returns the simulated L1 of the network
+func (n *Network) Chain() *devnet.Chain {
+	return n.chain
+}
+
+// Nodes returns the nodes of the committee, in its order
+func (n *Network) Nodes() []*Node {
+	return n.nodes
+}
+
+// Start starts all the nodes
+func (n *Network) Start(ctx context.Context) error {
+	n.tracker.Start(ctx)
+	for _, node := range n.nodes {
+		if err := node.Start(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Stop stops all the nodes, dropping their databases, once the network is done with
+func (n *Network) Stop() error {
+	var failed []error
+	for _, node := range n.nodes {
+		if err := node.Stop(); err != nil {
+			failed = append(failed, err)
+		}
+		if err := node.drop(); err != nil {
+			failed = append(failed, err)
+		}
+	}
+	n.tracker.Stop()
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to stop the network: %v", failed)
+	}
+	return nil
+}
+
+// Sequenced is a sequence signed by the committee and sequenced on L1
+type Sequenced struct {
+	Tx   *ethTypes.Transaction
+	Keys []common.Hash
+	// Signers are the nodes which signed the sequence
+	Signers []common.Address
+}
+
+// RandomSequence returns a sequence of the given number of random batches of size bytes
+func RandomSequence(batches, size int) (types.Sequence, error) {
+	sequence := make(types.Sequence, batches)
+	for i := range sequence {
+		sequence[i] = make([]byte, size)
+		if _, err := rand.Read(sequence[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return sequence, nil
+}
+
+// Sequence has the sequence signed by the given nodes as the trusted sequencer does, by all the nodes when none is
+// given, then sequences it on L1 with the signatures collected. It fails without sequencing when fewer nodes signed
+// than required, the nodes failing to sign being logged. The request is signed with the current time, so the nodes
+// reject the same sequence requested again within a second as a replay.
+func (n *Network) Sequence(ctx context.Context, sequence types.Sequence, signers ...*Node) (*Sequenced, error) {
+	if len(signers) == 0 {
+		signers = n.nodes
+	}
+
+	signed, err := sequence.SignRequest(n.sequencer, uint64(time.Now().Unix()))
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]common.Hash, len(sequence))
+	for i, batch := range sequence {
+		keys[i] = crypto.Keccak256Hash(batch)
+	}
+
+	sequenced := &Sequenced{Keys: keys}
+	signatures := make(map[common.Address][]byte, len(signers))
+	for _, node := range signers {
+		signature, err := node.Client().SignSequence(ctx, *signed)
+		if err != nil {
+			logger.Warnf("node %s failed to sign the sequence: %v", node.Addr.Hex(), err)
+			continue
+		}
+		signatures[node.Addr] = signature
+		sequenced.Signers = append(sequenced.Signers, node.Addr)
+	}
+	if len(signatures) < n.cfg.Required {
+		return nil, fmt.Errorf("%d nodes signed the sequence, %d signatures are required",
+			len(signatures), n.cfg.Required)
+	}
+
+	// the signatures in the order of the committee, followed by the addresses of all the members
+	var message []byte
+	for _, node := range n.nodes {
+		message = append(message, signatures[node.Addr]...)
+	}
+	for _, node := range n.nodes {
+		message = append(message, node.Addr.Bytes()...)
+	}
+
+	if sequenced.Tx, err = n.chain.SequenceBatches(keys, message); err != nil {
+		return nil, err
+	}
+	return sequenced, nil
+}
+
+// Reorg drops the depth latest blocks of the simulated L1 and notifies the running nodes
+func (n *Network) Reorg(ctx context.Context, depth uint64) error {
+	header, err := n.chain.Reorg(depth)
+	if err != nil {
+		return err
+	}
+
+	reorg := synchronizer.BlockReorg{Number: header.Number.Uint64(), Hash: ethgo.Hash(header.Hash())}
+	for _, node := range n.nodes {
+		if err = node.reorg(ctx, reorg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WaitStored waits for every running node to store the data of the keys, checking every retry period
+func (n *Network) WaitStored(ctx context.Context, keys []common.Hash) error {
+	ticker := time.NewTicker(n.cfg.RetryPeriod)
+	defer ticker.Stop()
+
+	for {
+		node, key, err := n.missing(ctx, keys)
+		if err != nil || node == nil {
+			return err
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return fmt.Errorf("the node %s does not store the key %s: %w", node.Addr.Hex(), key.Hex(), ctx.Err())
+		}
+	}
+}
+
+// missing returns a running node not storing the data of a key with the key, no node when all store every key
+func (n *Network) missing(ctx context.Context, keys []common.Hash) (*Node, common.Hash, error) {
+	for _, node := range n.nodes {
+		if !node.Running() {
+			continue
+		}
+
+		stored, err := node.storage.ListOffChainData(ctx, keys)
+		if err != nil {
+			return nil, common.Hash{}, err
+		}
+		found := make(map[common.Hash]bool, len(stored))
+		for _, data := range stored {
+			found[data.Key] = true
+		}
+		for _, key := range keys {
+			if !found[key] {
+				return node, key, nil
+			}
+		}
+	}
+
+	return nil, common.Hash{}, nil
+}
+
+// Read reads the data of the keys from the node as a rollup node does, checking it against the keys
+func Read(ctx context.Context, c client.Client, keys []common.Hash) (map[common.Hash][]byte, error) {
+	values, err := c.ListOffChainData(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range keys {
+		value, ok := values[key]
+		if !ok {
+			return nil, fmt.Errorf("the data of the key %s is missing", key.Hex())
+		}
+		if crypto.Keccak256Hash(value) != key {
+			return nil, fmt.Errorf("the data of the key %s does not match it", key.Hex())
+		}
+	}
+
+	return values, nil
+}
@@ -0,0 +1,109 @@
+package e2etest
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	_, err := New(ctx, Config{})
+	require.EqualError(t, err, "invalid number of nodes 0")
+	_, err = New(ctx, Config{Nodes: 2, Required: 3})
+	require.EqualError(t, err, "invalid number of required signatures 3 for 2 nodes")
+
+	network, err := New(ctx, Config{Nodes: 2})
+	require.NoError(t, err)
+	committee, err := network.Chain().GetCurrentDataCommittee()
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), committee.RequiredSignatures)
+	require.Len(t, committee.Members, 2)
+	require.Equal(t, network.Nodes()[0].DataCommitteeMember, committee.Members[0])
+	require.NoError(t, network.Stop())
+}
+
+// testNetwork runs a committee of three nodes, two signatures being required
+func testNetwork(t *testing.T, postgres *db.Config) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	network, err := New(ctx, Config{Nodes: 3, Required: 2, Postgres: postgres})
+	require.NoError(t, err)
+	require.NoError(t, network.Start(ctx))
+	defer func() {
+		require.NoError(t, network.Stop())
+	}()
+	nodes := network.Nodes()
+
+	// the third node resolves the sequence signed by the others
+	sequence, err := RandomSequence(3, 32)
+	require.NoError(t, err)
+	sequenced, err := network.Sequence(ctx, sequence, nodes[0], nodes[1])
+	require.NoError(t, err)
+	require.Equal(t, []common.Address{nodes[0].Addr, nodes[1].Addr}, sequenced.Signers)
+	require.NoError(t, network.WaitStored(ctx, sequenced.Keys))
+	for _, node := range nodes {
+		values, err := Read(ctx, node.Client(), sequenced.Keys)
+		require.NoError(t, err)
+		for i, key := range sequenced.Keys {
+			require.Equal(t, []byte(sequence[i]), values[key])
+		}
+	}
+
+	// the quorum is not reached with a single node up
+	require.NoError(t, nodes[1].Stop())
+	require.NoError(t, nodes[2].Stop())
+	sequence, err = RandomSequence(1, 32)
+	require.NoError(t, err)
+	_, err = network.Sequence(ctx, sequence)
+	require.EqualError(t, err, "1 nodes signed the sequence, 2 signatures are required")
+
+	// a restarted node resolves the sequence it missed while down, the first one being reorganized away meanwhile
+	require.NoError(t, nodes[1].Start(ctx))
+	require.NoError(t, network.Reorg(ctx, 1))
+	sequence, err = RandomSequence(2, 32)
+	require.NoError(t, err)
+	sequenced, err = network.Sequence(ctx, sequence)
+	require.NoError(t, err)
+	require.Len(t, sequenced.Signers, 2)
+	require.NoError(t, nodes[2].Start(ctx))
+	require.NoError(t, network.WaitStored(ctx, sequenced.Keys))
+	_, err = Read(ctx, nodes[2].Client(), sequenced.Keys)
+	require.NoError(t, err)
+}
+
+func TestNetwork_Memory(t *testing.T) {
+	testNetwork(t, nil)
+}
+
+// TestNetwork_Postgres runs the nodes on the PostgreSQL server of the E2ETEST_DB_* variables, skipped when unset
+func TestNetwork_Postgres(t *testing.T) {
+	host := os.Getenv("E2ETEST_DB_HOST")
+	if host == "" {
+		t.Skip("E2ETEST_DB_HOST is not set")
+	}
+	port := os.Getenv("E2ETEST_DB_PORT")
+	if port == "" {
+		port = "5432"
+	}
+
+	testNetwork(t, &db.Config{
+		Name:     os.Getenv("E2ETEST_DB_NAME"),
+		User:     os.Getenv("E2ETEST_DB_USER"),
+		Password: os.Getenv("E2ETEST_DB_PASSWORD"),
+		Host:     host,
+		Port:     port,
+		MaxConns: 10,
+	})
+}
@@ -0,0 +1,267 @@
+package e2etest
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/client"
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/pkg/replay"
+	"github.com/0xPolygon/cdk-data-availability/rpc"
+	"github.com/0xPolygon/cdk-data-availability/services/datacom"
+	dacsync "github.com/0xPolygon/cdk-data-availability/services/sync"
+	"github.com/0xPolygon/cdk-data-availability/synchronizer"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/jmoiron/sqlx"
+)
+
+// startTimeout bounds the wait for the RPC server of a node to listen
+const startTimeout = 5 * time.Second
+
+// Node is a DA node of the network: its RPC server serves the sequencer and the other nodes, and its synchronizer
+// stores the keys sequenced on the simulated L1 and resolves the batches it did not sign from the other nodes.
+// A node can be stopped and started again, keeping its storage.
+type Node struct {
+	etherman.DataCommitteeMember
+
+	network *Network
+	key     *ecdsa.PrivateKey
+	port    int
+	storage db.DB
+	// drop drops the database of the node, when it stores in PostgreSQL
+	drop func() error
+
+	lock         sync.Mutex
+	server       *rpc.Server
+	synchronizer *synchronizer.BatchSynchronizer
+	reorgs       chan synchronizer.BlockReorg
+}
+
+// newNode returns a node with a generated key, storing in memory or in a new database of the server of the config
+func newNode(ctx context.Context, network *Network, index int) (*Node, error) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	port, err := freePort()
+	if err != nil {
+		return nil, err
+	}
+
+	node := &Node{
+		DataCommitteeMember: etherman.DataCommitteeMember{
+			Addr: crypto.PubkeyToAddress(key.PublicKey),
+			URL:  "http://" + net.JoinHostPort("127.0.0.1", strconv.Itoa(port)),
+		},
+		network: network,
+		key:     key,
+		port:    port,
+		drop:    func() error { return nil },
+	}
+
+	if network.cfg.Postgres == nil {
+		node.storage = db.NewMemory(0)
+		return node, nil
+	}
+
+	pg, drop, err := createDatabase(ctx, *network.cfg.Postgres, index)
+	if err != nil {
+		return nil, err
+	}
+	node.storage, node.drop = db.New(pg), drop
+
+	return node, nil
+}
+
+// createDatabase creates a database of its own for the node on the server of the config, returning the pool to it
+// with the migrations applied, and the function dropping it
+func createDatabase(ctx context.Context, cfg db.Config, index int) (*sqlx.DB, func() error, error) {
+	suffix := make([]byte, 4) //nolint:gomnd
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, nil, err
+	}
+	name := fmt.Sprintf("e2etest_%d_%s", index, hex.EncodeToString(suffix))
+
+	server, err := db.InitContext(ctx, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	// the name is generated, it can not inject anything
+	if _, err = server.ExecContext(ctx, "CREATE DATABASE "+name); err != nil { //nolint:gosec
+		_ = server.Close()
+		return nil, nil, err
+	}
+
+	cfg.Name = name
+	pg, err := db.InitContext(ctx, cfg)
+	if err == nil {
+		err = db.Migrate(pg, true)
+	}
+
+	drop := func() error {
+		if pg != nil {
+			_ = pg.Close()
+		}
+		defer server.Close()
+
+		_, err := server.Exec("DROP DATABASE IF EXISTS " + name) //nolint:gosec
+		return err
+	}
+	if err != nil {
+		_ = drop()
+		return nil, nil, err
+	}
+
+	return pg, drop, nil
+}
+
+// Key returns the key the node signs with
+func (n *Node) Key() *ecdsa.PrivateKey {
+	return n.key
+}
+
+// Storage returns the storage of the node
+func (n *Node) Storage() db.DB {
+	return n.storage
+}
+
+// Client returns a client of the node, reading the data as a rollup node does
+func (n *Node) Client() client.Client {
+	return client.New(n.URL)
+}
+
+// Running tells whether the node is started
+func (n *Node) Running() bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	return n.server != nil
+}
+
+// Start serves the endpoints of the node and starts its synchronizer, waiting for the node to listen
+func (n *Node) Start(ctx context.Context) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.server != nil {
+		return fmt.Errorf("the node %s is already started", n.Addr.Hex())
+	}
+
+	c := n.network.config
+	rpcCfg := c.RPC
+	rpcCfg.Host = "127.0.0.1"
+	rpcCfg.Port = n.port
+	server := rpc.NewServer(rpcCfg, []rpc.Service{
+		{
+			Name:    dacsync.APISYNC,
+			Service: dacsync.NewEndpoints(n.storage, n.key),
+		},
+		{
+			Name: datacom.APIDATACOM,
+			Service: datacom.NewEndpoints(
+				n.storage,
+				n.key,
+				n.network.tracker,
+				replay.New(c.Replay.Window.Duration, c.Replay.RequireTimestamp),
+				c.Limits,
+				config.DurabilityConfig{Level: config.DurabilityCommitted},
+				nil,
+			),
+		},
+	})
+	go func() {
+		if err := server.Start(); err != nil {
+			logger.Errorf("the node %s failed to serve: %v", n.Addr.Hex(), err)
+		}
+	}()
+	if err := waitListening(ctx, n.port); err != nil {
+		_ = server.Stop()
+		return err
+	}
+
+	reorgs := make(chan synchronizer.BlockReorg)
+	batchSynchronizer, err := synchronizer.NewBatchSynchronizer(c.L1, c.Timeouts, c.Limits, n.Addr, n.storage,
+		reorgs, n.network.chain, n.network.tracker, nil, client.NewFactory())
+	if err != nil {
+		_ = server.Stop()
+		return err
+	}
+	batchSynchronizer.Start(ctx)
+
+	n.server, n.synchronizer, n.reorgs = server, batchSynchronizer, reorgs
+	return nil
+}
+
+// Stop stops serving the endpoints of the node and its synchronizer, the node being down for the others
+func (n *Node) Stop() error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.server == nil {
+		return nil
+	}
+
+	n.synchronizer.Stop()
+	err := n.server.Stop()
+	n.server, n.synchronizer, n.reorgs = nil, nil, nil
+
+	return err
+}
+
+// reorg sends the reorganization to the synchronizer of the node, if running
+func (n *Node) reorg(ctx context.Context, reorg synchronizer.BlockReorg) error {
+	n.lock.Lock()
+	reorgs := n.reorgs
+	n.lock.Unlock()
+
+	if reorgs == nil {
+		return nil
+	}
+
+	select {
+	case reorgs <- reorg:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// freePort returns a port nothing listens on
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+// waitListening waits for a server to listen on the local port
+func waitListening(parentCtx context.Context, port int) error {
+	ctx, cancel := context.WithTimeout(parentCtx, startTimeout)
+	defer cancel()
+
+	address := net.JoinHostPort("127.0.0.1", strconv.Itoa(port))
+	for {
+		conn, err := net.Dial("tcp", address)
+		if err == nil {
+			return conn.Close()
+		}
+
+		select {
+		case <-time.After(10 * time.Millisecond): //nolint:gomnd
+		case <-ctx.Done():
+			return fmt.Errorf("nothing listens on %s: %w", address, ctx.Err())
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package tracing
+
+// Config represents the configuration of the OpenTelemetry tracing
+type Config struct {
+	// Enabled exports the traces to an OTLP collector (e.g. Jaeger, Tempo or the OpenTelemetry Collector)
+	Enabled bool `mapstructure:"Enabled"`
+
+	// Endpoint is the host:port of the OTLP/HTTP collector
+	Endpoint string `mapstructure:"Endpoint"`
+
+	// Insecure disables TLS when connecting to the collector
+	Insecure bool `mapstructure:"Insecure"`
+
+	// ServiceName identifies the node in the traces
+	ServiceName string `mapstructure:"ServiceName"`
+
+	// SampleRatio is the ratio of the traces started by the node that are sampled, from 0 to 1.
+	// Traces started by a caller are sampled according to the caller decision.
+	SampleRatio float64 `mapstructure:"SampleRatio"`
+}
@@ -0,0 +1,71 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	return recorder
+}
+
+func TestStartEnd(t *testing.T) {
+	recorder := recordSpans(t)
+
+	ctx, parent := Start(context.Background(), "parent", trace.SpanKindServer)
+	_, child := Start(ctx, "child", trace.SpanKindClient, attribute.String("key", "value"))
+	End(child, errors.New("boom"))
+	End(parent, nil)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	require.Equal(t, "child", spans[0].Name())
+	require.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	require.Equal(t, trace.SpanKindClient, spans[0].SpanKind())
+	require.Contains(t, spans[0].Attributes(), attribute.String("key", "value"))
+	require.Equal(t, codes.Error, spans[0].Status().Code)
+	require.Equal(t, "boom", spans[0].Status().Description)
+
+	require.Equal(t, "parent", spans[1].Name())
+	require.Equal(t, codes.Unset, spans[1].Status().Code)
+}
+
+func TestInjectExtract(t *testing.T) {
+	recordSpans(t)
+
+	// a disabled config still propagates the trace context of the callers
+	shutdown, err := Init(context.Background(), Config{})
+	require.NoError(t, err)
+	require.NoError(t, shutdown(context.Background()))
+
+	ctx, span := Start(context.Background(), "caller", trace.SpanKindClient)
+	defer span.End()
+
+	header := http.Header{}
+	Inject(ctx, propagation.HeaderCarrier(header))
+	require.NotEmpty(t, header.Get("traceparent"))
+
+	extracted := trace.SpanContextFromContext(Extract(context.Background(), propagation.HeaderCarrier(header)))
+	require.True(t, extracted.IsRemote())
+	require.Equal(t, span.SpanContext().TraceID(), extracted.TraceID())
+	require.Equal(t, span.SpanContext().SpanID(), extracted.SpanID())
+}
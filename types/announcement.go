@@ -0,0 +1,61 @@
+package types
+
+import (
+	"crypto/ecdsa"
+	"encoding/binary"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// announcementDomain separates the announcement signatures from the signatures of the sequences
+var announcementDomain = []byte("cdk-data-availability/announcement")
+
+// KeyAnnouncement announces the keys of the values newly stored by a committee member,
+// signed by the member so the other members can fetch the values from it
+type KeyAnnouncement struct {
+	Keys      []common.Hash `json:"keys"`
+	Signature ArgBytes      `json:"signature"`
+	// Timestamp is the unix time the announcement was signed at, covered by the signature when set
+	Timestamp ArgUint64 `json:"timestamp,omitempty"`
+}
+
+// HashToSign returns the hash of the announced keys, followed by the timestamp when set
+func (a *KeyAnnouncement) HashToSign() []byte {
+	data := make([]byte, 0, len(announcementDomain)+len(a.Keys)*common.HashLength)
+	data = append(data, announcementDomain...)
+	for _, key := range a.Keys {
+		data = append(data, key.Bytes()...)
+	}
+	if a.Timestamp != 0 {
+		data = binary.BigEndian.AppendUint64(data, uint64(a.Timestamp))
+	}
+
+	return crypto.Keccak256(data)
+}
+
+// Sign signs the announced keys with the private key
+func (a *KeyAnnouncement) Sign(privateKey *ecdsa.PrivateKey) error {
+	sig, err := crypto.Sign(a.HashToSign(), privateKey)
+	if err != nil {
+		return err
+	}
+
+	a.Signature = sig
+	return nil
+}
+
+// Signer returns the address of the signer
+func (a *KeyAnnouncement) Signer() (common.Address, error) {
+	if len(a.Signature) != signatureLen {
+		return common.Address{}, errors.New("invalid signature")
+	}
+
+	pubKey, err := crypto.SigToPub(a.HashToSign(), a.Signature)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
@@ -0,0 +1,39 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyAnnouncement_Signer(t *testing.T) {
+	pk, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	a := KeyAnnouncement{Keys: []common.Hash{common.HexToHash("0x1"), common.HexToHash("0x2")}}
+	require.NoError(t, a.Sign(pk))
+
+	signer, err := a.Signer()
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(pk.PublicKey), signer)
+
+	// the signature covers the keys
+	a.Keys = a.Keys[:1]
+	signer, err = a.Signer()
+	require.NoError(t, err)
+	require.NotEqual(t, crypto.PubkeyToAddress(pk.PublicKey), signer)
+
+	// and the timestamp when set
+	a.Timestamp = 1700000000
+	require.NoError(t, a.Sign(pk))
+	a.Timestamp++
+	signer, err = a.Signer()
+	require.NoError(t, err)
+	require.NotEqual(t, crypto.PubkeyToAddress(pk.PublicKey), signer)
+
+	a.Signature = a.Signature[:10]
+	_, err = a.Signer()
+	require.Error(t, err)
+}
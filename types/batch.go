@@ -0,0 +1,183 @@
+package types
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	// changeL2BlockMarker starts a new L2 block in the L2 data of a batch, followed by the
+	// delta timestamp and the L1 info tree index of the block, 4 bytes each
+	changeL2BlockMarker = 0x0b
+	changeL2BlockLen    = 1 + 4 + 4
+
+	// signatureSuffixLen is the length of the r, s and v of a transaction and of the effective
+	// gas price percentage following its RLP encoding in the L2 data of a batch
+	signatureSuffixLen = 32 + 32 + 1 + 1
+
+	// legacyTxFields and eip155TxFields are the number of fields of the RLP encoding of a transaction,
+	// with EIP-155 the chain ID and two zeros are appended
+	legacyTxFields = 6
+	eip155TxFields = 9
+
+	// vOffset is added to the recovery ID of the signatures in the L2 data of a batch
+	vOffset = 27
+)
+
+// BatchContents is the decoded view of the L2 data of a batch
+type BatchContents struct {
+	Blocks           []L2Block `json:"blocks"`
+	TransactionCount int       `json:"transactionCount"`
+	// TotalGas is the sum of the gas limits of the transactions, the gas used is only known
+	// once the batch is executed
+	TotalGas ArgUint64 `json:"totalGas"`
+}
+
+// L2Block is an L2 block of a batch
+type L2Block struct {
+	DeltaTimestamp  uint32          `json:"deltaTimestamp"`
+	L1InfoTreeIndex uint32          `json:"l1InfoTreeIndex"`
+	Transactions    []L2Transaction `json:"transactions"`
+}
+
+// L2Transaction is a transaction of an L2 block
+type L2Transaction struct {
+	Hash common.Hash `json:"hash"`
+	// From is omitted when the sender can not be recovered from the signature
+	From     *common.Address `json:"from,omitempty"`
+	To       *common.Address `json:"to"`
+	Nonce    ArgUint64       `json:"nonce"`
+	GasPrice *ArgBig         `json:"gasPrice"`
+	Gas      ArgUint64       `json:"gas"`
+	Value    *ArgBig         `json:"value"`
+	Input    ArgBytes        `json:"input"`
+	// EffectivePercentage is the percentage of the gas price charged, from 0 to 255
+	EffectivePercentage uint8 `json:"effectivePercentage"`
+}
+
+// DecodedOffChainData is a stored value along with the decoded view of its L2 data
+type DecodedOffChainData struct {
+	Key      common.Hash `json:"key"`
+	BatchNum ArgUint64   `json:"batchNum"`
+	Size     int         `json:"size"`
+	BatchContents
+}
+
+// DecodeBatch decodes the L2 data of a batch, as sent by the sequencer since the fork ID 5: the
+// transactions are RLP encoded without their signature, followed by the r, s and v of the signature
+// and the effective gas price percentage, and since the fork ID 7 (etrog) each L2 block starts with
+// a change of block. The transactions preceding any change of block belong to an implicit first block.
+func DecodeBatch(data []byte) (*BatchContents, error) {
+	contents := &BatchContents{Blocks: make([]L2Block, 0)}
+
+	for pos := 0; pos < len(data); {
+		if data[pos] == changeL2BlockMarker {
+			if len(data)-pos < changeL2BlockLen {
+				return nil, fmt.Errorf("truncated change of block at byte %d", pos)
+			}
+
+			contents.Blocks = append(contents.Blocks, L2Block{
+				DeltaTimestamp:  binary.BigEndian.Uint32(data[pos+1:]),
+				L1InfoTreeIndex: binary.BigEndian.Uint32(data[pos+5:]), //nolint:gomnd
+				Transactions:    make([]L2Transaction, 0),
+			})
+			pos += changeL2BlockLen
+			continue
+		}
+
+		kind, _, rest, err := rlp.Split(data[pos:])
+		if err != nil || kind != rlp.List {
+			return nil, fmt.Errorf("invalid transaction at byte %d: not an RLP list", pos)
+		}
+
+		end := len(data) - len(rest)
+		if len(rest) < signatureSuffixLen {
+			return nil, fmt.Errorf("truncated signature of the transaction at byte %d", pos)
+		}
+
+		tx, err := decodeL2Transaction(data[pos:end], rest[:signatureSuffixLen])
+		if err != nil {
+			return nil, fmt.Errorf("invalid transaction at byte %d: %w", pos, err)
+		}
+
+		if len(contents.Blocks) == 0 {
+			contents.Blocks = append(contents.Blocks, L2Block{Transactions: make([]L2Transaction, 0)})
+		}
+		block := &contents.Blocks[len(contents.Blocks)-1]
+		block.Transactions = append(block.Transactions, *tx)
+		contents.TransactionCount++
+		contents.TotalGas += tx.Gas
+
+		pos = end + signatureSuffixLen
+	}
+
+	return contents, nil
+}
+
+// decodeL2Transaction decodes a transaction from its RLP encoding without the signature,
+// and the signature and effective gas price percentage following it
+func decodeL2Transaction(encoded, suffix []byte) (*L2Transaction, error) {
+	var fields [][]byte
+	if err := rlp.DecodeBytes(encoded, &fields); err != nil {
+		return nil, err
+	}
+	if len(fields) != legacyTxFields && len(fields) != eip155TxFields {
+		return nil, fmt.Errorf("unexpected number of fields %d", len(fields))
+	}
+
+	nonce, gas := new(big.Int).SetBytes(fields[0]), new(big.Int).SetBytes(fields[2])
+	if !nonce.IsUint64() || !gas.IsUint64() {
+		return nil, fmt.Errorf("nonce or gas out of range")
+	}
+
+	var to *common.Address
+	if len(fields[3]) > 0 {
+		address := common.BytesToAddress(fields[3])
+		to = &address
+	}
+
+	var signer ethTypes.Signer = ethTypes.HomesteadSigner{}
+	v := new(big.Int).SetUint64(uint64(suffix[64]))
+	if len(fields) == eip155TxFields {
+		chainID := new(big.Int).SetBytes(fields[6])
+		signer = ethTypes.NewEIP155Signer(chainID)
+
+		// v = recovery ID + chain ID * 2 + 35
+		v.Sub(v, big.NewInt(vOffset))
+		v.Add(v, new(big.Int).Mul(chainID, big.NewInt(2))) //nolint:gomnd
+		v.Add(v, big.NewInt(35))                           //nolint:gomnd
+	}
+
+	tx := ethTypes.NewTx(&ethTypes.LegacyTx{
+		Nonce:    nonce.Uint64(),
+		GasPrice: new(big.Int).SetBytes(fields[1]),
+		Gas:      gas.Uint64(),
+		To:       to,
+		Value:    new(big.Int).SetBytes(fields[4]),
+		Data:     fields[5],
+		V:        v,
+		R:        new(big.Int).SetBytes(suffix[:32]),
+		S:        new(big.Int).SetBytes(suffix[32:64]),
+	})
+
+	decoded := &L2Transaction{
+		Hash:                tx.Hash(),
+		To:                  tx.To(),
+		Nonce:               ArgUint64(tx.Nonce()),
+		GasPrice:            (*ArgBig)(tx.GasPrice()),
+		Gas:                 ArgUint64(tx.Gas()),
+		Value:               (*ArgBig)(tx.Value()),
+		Input:               tx.Data(),
+		EffectivePercentage: suffix[65],
+	}
+	if from, err := ethTypes.Sender(signer, tx); err == nil {
+		decoded.From = &from
+	}
+
+	return decoded, nil
+}
@@ -0,0 +1,103 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+)
+
+// encodeL2Transaction encodes the signed transaction as in the L2 data of a batch
+func encodeL2Transaction(t *testing.T, tx *ethTypes.Transaction, effectivePercentage uint8) []byte {
+	t.Helper()
+
+	fields := []interface{}{tx.Nonce(), tx.GasPrice(), tx.Gas(), tx.To(), tx.Value(), tx.Data()}
+	v, r, s := tx.RawSignatureValues()
+	recoveryID := v.Uint64() - vOffset
+	if tx.Protected() {
+		fields = append(fields, tx.ChainId(), uint(0), uint(0))
+		recoveryID = v.Uint64() - 35 - 2*tx.ChainId().Uint64()
+	}
+
+	encoded, err := rlp.EncodeToBytes(fields)
+	require.NoError(t, err)
+
+	encoded = append(encoded, common.LeftPadBytes(r.Bytes(), 32)...)
+	encoded = append(encoded, common.LeftPadBytes(s.Bytes(), 32)...)
+	return append(encoded, byte(recoveryID+vOffset), effectivePercentage)
+}
+
+func TestDecodeBatch(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	to := common.HexToAddress("0x1234")
+
+	transfer, err := ethTypes.SignNewTx(key, ethTypes.NewEIP155Signer(big.NewInt(1001)), &ethTypes.LegacyTx{
+		Nonce: 3, GasPrice: big.NewInt(1000), Gas: 21000, To: &to, Value: big.NewInt(5),
+	})
+	require.NoError(t, err)
+	deployment, err := ethTypes.SignNewTx(key, ethTypes.HomesteadSigner{}, &ethTypes.LegacyTx{
+		Nonce: 4, GasPrice: big.NewInt(1000), Gas: 100000, Value: big.NewInt(0), Data: []byte{0x60, 0x80},
+	})
+	require.NoError(t, err)
+
+	t.Run("blocks and transactions", func(t *testing.T) {
+		data := []byte{changeL2BlockMarker, 0, 0, 0, 2, 0, 0, 0, 7}
+		data = append(data, encodeL2Transaction(t, transfer, 255)...)
+		data = append(data, changeL2BlockMarker, 0, 0, 0, 1, 0, 0, 0, 0)
+		data = append(data, encodeL2Transaction(t, deployment, 100)...)
+
+		contents, err := DecodeBatch(data)
+		require.NoError(t, err)
+		require.Equal(t, 2, contents.TransactionCount)
+		require.Equal(t, ArgUint64(121000), contents.TotalGas)
+		require.Len(t, contents.Blocks, 2)
+		require.Equal(t, uint32(2), contents.Blocks[0].DeltaTimestamp)
+		require.Equal(t, uint32(7), contents.Blocks[0].L1InfoTreeIndex)
+
+		first := contents.Blocks[0].Transactions[0]
+		require.Equal(t, transfer.Hash(), first.Hash)
+		require.Equal(t, &sender, first.From)
+		require.Equal(t, &to, first.To)
+		require.Equal(t, ArgUint64(3), first.Nonce)
+		require.Equal(t, uint8(255), first.EffectivePercentage)
+
+		second := contents.Blocks[1].Transactions[0]
+		require.Equal(t, deployment.Hash(), second.Hash)
+		require.Equal(t, &sender, second.From)
+		require.Nil(t, second.To)
+		require.Equal(t, ArgBytes{0x60, 0x80}, second.Input)
+	})
+
+	t.Run("transactions without change of block", func(t *testing.T) {
+		contents, err := DecodeBatch(encodeL2Transaction(t, transfer, 255))
+		require.NoError(t, err)
+		require.Len(t, contents.Blocks, 1)
+		require.Equal(t, transfer.Hash(), contents.Blocks[0].Transactions[0].Hash)
+	})
+
+	t.Run("empty batch", func(t *testing.T) {
+		contents, err := DecodeBatch(nil)
+		require.NoError(t, err)
+		require.Empty(t, contents.Blocks)
+		require.Zero(t, contents.TransactionCount)
+	})
+
+	t.Run("invalid data", func(t *testing.T) {
+		encoded := encodeL2Transaction(t, transfer, 255)
+
+		_, err := DecodeBatch(encoded[:len(encoded)-1])
+		require.ErrorContains(t, err, "truncated signature")
+
+		_, err = DecodeBatch([]byte{changeL2BlockMarker, 0, 0})
+		require.ErrorContains(t, err, "truncated change of block")
+
+		_, err = DecodeBatch([]byte{0x01, 0x02})
+		require.ErrorContains(t, err, "not an RLP list")
+	})
+}
@@ -0,0 +1,126 @@
+package types
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// certificateDomain separates the availability signatures from the other signatures of the members
+var certificateDomain = []byte("cdk-data-availability/certificate")
+
+// Certificate proves that a threshold of the committee members hold the data of the given hash,
+// so it can be retrieved as long as one of them is honest
+type Certificate struct {
+	// DataHash is the key of the data, i.e. the keccak256 hash of the batch data
+	DataHash common.Hash `json:"dataHash"`
+	// CommitteeHash is the hash of the addresses of the committee the signers were members of
+	CommitteeHash common.Hash `json:"committeeHash"`
+	// Threshold is the number of signatures required by the committee
+	Threshold uint64 `json:"threshold"`
+	// Signatures are the availability signatures of the members, ordered by signer address
+	Signatures []ArgBytes `json:"signatures"`
+	Timestamp  time.Time  `json:"timestamp"`
+}
+
+// AvailabilityHashToSign returns the hash a member signs to attest it holds the data of the given hash
+func AvailabilityHashToSign(dataHash common.Hash) []byte {
+	return crypto.Keccak256(certificateDomain, dataHash.Bytes())
+}
+
+// SignAvailability signs the availability of the data of the given hash with the private key
+func SignAvailability(dataHash common.Hash, privateKey *ecdsa.PrivateKey) ([]byte, error) {
+	return crypto.Sign(AvailabilityHashToSign(dataHash), privateKey)
+}
+
+// AvailabilitySigner returns the address of the signer of an availability signature
+func AvailabilitySigner(dataHash common.Hash, signature []byte) (common.Address, error) {
+	if len(signature) != signatureLen {
+		return common.Address{}, errors.New("invalid signature")
+	}
+
+	pubKey, err := crypto.SigToPub(AvailabilityHashToSign(dataHash), signature)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
+
+// SortSignatures orders the signatures by signer address
+func (c *Certificate) SortSignatures() error {
+	signers, err := c.Signers()
+	if err != nil {
+		return err
+	}
+
+	sort.Sort(bySigner{signers: signers, signatures: c.Signatures})
+	return nil
+}
+
+// Signers returns the addresses of the signers, in the order of the signatures
+func (c *Certificate) Signers() ([]common.Address, error) {
+	signers := make([]common.Address, len(c.Signatures))
+	for i, signature := range c.Signatures {
+		signer, err := AvailabilitySigner(c.DataHash, signature)
+		if err != nil {
+			return nil, fmt.Errorf("signature %d: %w", i, err)
+		}
+		signers[i] = signer
+	}
+
+	return signers, nil
+}
+
+// Verify checks the certificate is signed by at least the required number of distinct members
+func (c *Certificate) Verify(members []common.Address, required uint64) error {
+	signers, err := c.Signers()
+	if err != nil {
+		return err
+	}
+
+	isMember := make(map[common.Address]bool, len(members))
+	for _, member := range members {
+		isMember[member] = true
+	}
+
+	signed := make(map[common.Address]bool, len(signers))
+	for _, signer := range signers {
+		if !isMember[signer] {
+			return fmt.Errorf("%s is not a committee member", signer.Hex())
+		}
+		if signed[signer] {
+			return fmt.Errorf("%s signed more than once", signer.Hex())
+		}
+		signed[signer] = true
+	}
+
+	if uint64(len(signed)) < required {
+		return fmt.Errorf("%d signatures, %d required", len(signed), required)
+	}
+
+	return nil
+}
+
+// bySigner sorts the signatures by signer address
+type bySigner struct {
+	signers    []common.Address
+	signatures []ArgBytes
+}
+
+func (s bySigner) Len() int { return len(s.signers) }
+
+func (s bySigner) Less(i, j int) bool {
+	return bytes.Compare(s.signers[i].Bytes(), s.signers[j].Bytes()) < 0
+}
+
+func (s bySigner) Swap(i, j int) {
+	s.signers[i], s.signers[j] = s.signers[j], s.signers[i]
+	s.signatures[i], s.signatures[j] = s.signatures[j], s.signatures[i]
+}
@@ -0,0 +1,54 @@
+package types
+
+import (
+	"crypto/ecdsa"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertificate_Verify(t *testing.T) {
+	dataHash := crypto.Keccak256Hash([]byte("batch data"))
+
+	keys := make([]*ecdsa.PrivateKey, 3)
+	members := make([]common.Address, len(keys))
+	signatures := make([]ArgBytes, len(keys))
+	for i := range keys {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		keys[i] = key
+		members[i] = crypto.PubkeyToAddress(key.PublicKey)
+
+		signatures[i], err = SignAvailability(dataHash, key)
+		require.NoError(t, err)
+	}
+
+	cert := Certificate{DataHash: dataHash, Threshold: 2, Signatures: signatures[:2]}
+	require.NoError(t, cert.SortSignatures())
+	require.NoError(t, cert.Verify(members, 2))
+
+	signers, err := cert.Signers()
+	require.NoError(t, err)
+	require.Len(t, signers, 2)
+	require.Negative(t, signers[0].Big().Cmp(signers[1].Big()), "the signatures are ordered by signer")
+
+	// below the threshold
+	require.ErrorContains(t, cert.Verify(members, 3), "2 signatures, 3 required")
+
+	// the same member twice
+	cert.Signatures = []ArgBytes{signatures[0], signatures[0]}
+	require.ErrorContains(t, cert.Verify(members, 2), "more than once")
+
+	// not a member
+	cert.Signatures = signatures
+	require.ErrorContains(t, cert.Verify(members[:2], 2), "not a committee member")
+
+	// the signatures cover the data hash
+	cert.DataHash = common.HexToHash("0x1")
+	require.Error(t, cert.Verify(members, 2))
+
+	cert.Signatures = []ArgBytes{signatures[0][:10]}
+	require.Error(t, cert.Verify(members, 1))
+}
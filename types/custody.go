@@ -0,0 +1,12 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// CustodyProof returns the answer to a custody challenge over the given value, keccak256(value || nonce),
+// which can only be computed by holding the value as the nonce is chosen at random by the challenger
+func CustodyProof(value []byte, nonce common.Hash) common.Hash {
+	return crypto.Keccak256Hash(value, nonce.Bytes())
+}
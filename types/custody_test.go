@@ -0,0 +1,20 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustodyProof(t *testing.T) {
+	value := []byte("value")
+	nonce := common.HexToHash("0x1234")
+
+	proof := CustodyProof(value, nonce)
+	require.Equal(t, crypto.Keccak256Hash(append(value, nonce.Bytes()...)), proof)
+
+	// the proof changes with the nonce, so it can not be computed ahead of the challenge
+	require.NotEqual(t, proof, CustodyProof(value, common.HexToHash("0x5678")))
+}
@@ -0,0 +1,49 @@
+package types
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Sources a stored value can be obtained from
+const (
+	// ProvenanceSequencerPush is a value pushed by the trusted sequencer in a sequence to sign
+	ProvenanceSequencerPush = "sequencer_push"
+	// ProvenanceSequencer is a value resolved from the trusted sequencer
+	ProvenanceSequencer = "sequencer"
+	// ProvenanceMember is a value resolved from a committee member, the origin being its address
+	ProvenanceMember = "member"
+	// ProvenanceGossip is a value announced by a committee member, the origin being its address
+	ProvenanceGossip = "gossip"
+	// ProvenanceL1 is a value read from L1, the blob of a sequence, the origin being the hash of its transaction
+	ProvenanceL1 = "l1"
+	// ProvenancePeer is a value imported from a peer node, the origin being its URL
+	ProvenancePeer = "peer"
+	// ProvenanceExport is a value imported from an export, the origin being its file
+	ProvenanceExport = "export"
+	// ProvenanceSnapshot is a value restored from a snapshot, the origin being its file
+	ProvenanceSnapshot = "snapshot"
+)
+
+// Provenance records how a stored value was obtained, a value obtained from several sources having a
+// record for each of them
+type Provenance struct {
+	Key    common.Hash `json:"key"`
+	Source string      `json:"source"`
+	// Origin identifies the source the value was obtained from, e.g. the address of the member
+	Origin     string    `json:"origin,omitempty"`
+	ObtainedAt time.Time `json:"obtainedAt"`
+}
+
+// NewProvenance returns the records of the given values being obtained now from the source and origin
+func NewProvenance(od []OffChainData, source, origin string) []Provenance {
+	now := time.Now().UTC()
+
+	provenance := make([]Provenance, len(od))
+	for i, data := range od {
+		provenance[i] = Provenance{Key: data.Key, Source: source, Origin: origin, ObtainedAt: now}
+	}
+
+	return provenance
+}
@@ -2,7 +2,9 @@ package types
 
 import (
 	"crypto/ecdsa"
+	"encoding/binary"
 	"errors"
+	"fmt"
 	"math/big"
 	"strings"
 
@@ -20,28 +22,67 @@ const (
 type Sequence []ArgBytes
 
 // HashToSign returns the accumulated input hash of the sequence.
-// Note that this is equivalent to what happens on the smart contract
+// Note that this is equivalent to what happens on the smart contract: it accumulates the keccak256 hash of the
+// data of each batch, the TransactionsHash committed on L1, so a signature never covers other data than the
+// data the chain commits to
 func (s *Sequence) HashToSign() []byte {
+	keys := make([]common.Hash, len(*s))
+	for i, batchData := range ([]ArgBytes)(*s) {
+		keys[i] = crypto.Keccak256Hash(batchData)
+	}
+	return HashToSignFromKeys(keys).Bytes()
+}
+
+// HashToSignFromKeys returns the accumulated input hash of a sequence from the keys of its batches,
+// the TransactionsHash committed on L1 for each of them
+func HashToSignFromKeys(keys []common.Hash) common.Hash {
 	currentHash := common.Hash{}.Bytes()
-	for _, batchData := range ([]ArgBytes)(*s) {
+	for _, key := range keys {
 		types := []string{
 			"bytes32",
 			"bytes32",
 		}
 		values := []interface{}{
 			currentHash,
-			crypto.Keccak256(batchData),
+			key.Bytes(),
 		}
 		currentHash = solsha3.SoliditySHA3(types, values)
 	}
-	return currentHash
+	return common.BytesToHash(currentHash)
 }
 
 // Sign returns a signed sequence by the private key.
 // Note that what's being signed is the accumulated input hash
 func (s *Sequence) Sign(privateKey *ecdsa.PrivateKey) (*SignedSequence, error) {
-	hashToSign := s.HashToSign()
-	sig, err := crypto.Sign(hashToSign, privateKey)
+	signature, err := sign(s.HashToSign(), privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignedSequence{
+		Sequence:  *s,
+		Signature: signature,
+	}, nil
+}
+
+// SignRequest returns the sequence signed by the private key along with the given unix timestamp, as the
+// sequencer does to request its signature from a member without the request being replayable later
+func (s *Sequence) SignRequest(privateKey *ecdsa.PrivateKey, timestamp uint64) (*SignedSequence, error) {
+	signed := &SignedSequence{Sequence: *s, Timestamp: ArgUint64(timestamp)}
+
+	signature, err := sign(signed.RequestHash(), privateKey)
+	if err != nil {
+		return nil, err
+	}
+	signed.Signature = signature
+
+	return signed, nil
+}
+
+// sign signs the hash with the private key, in the form expected by the L1 contracts: s in the lower half
+// of the curve order and v being 27 or 28
+func sign(hash []byte, privateKey *ecdsa.PrivateKey) ([]byte, error) {
+	sig, err := crypto.Sign(hash, privateKey)
 	if err != nil {
 		return nil, err
 	}
@@ -69,10 +110,36 @@ func (s *Sequence) Sign(privateKey *ecdsa.PrivateKey) (*SignedSequence, error) {
 	actualSignature = append(actualSignature, sBytes...)
 	actualSignature = append(actualSignature, vByte)
 
-	return &SignedSequence{
-		Sequence:  *s,
-		Signature: actualSignature,
-	}, nil
+	return actualSignature, nil
+}
+
+// CheckSize returns an error when the data of a batch is larger than maxValueSize bytes,
+// or the data of all the batches larger than maxSequenceSize bytes
+func (s *Sequence) CheckSize(maxValueSize, maxSequenceSize uint64) error {
+	var total uint64
+	for i, batchData := range *s {
+		size := uint64(len(batchData))
+		if size > maxValueSize {
+			return fmt.Errorf("the data of the batch %d is %d bytes, more than the maximum of %d",
+				i, size, maxValueSize)
+		}
+		total += size
+	}
+
+	if total > maxSequenceSize {
+		return fmt.Errorf("the data of the sequence is %d bytes, more than the maximum of %d", total, maxSequenceSize)
+	}
+
+	return nil
+}
+
+// CheckValueSize returns an error when a value is larger than maxValueSize bytes, 0 for no limit
+func CheckValueSize(value []byte, maxValueSize uint64) error {
+	if maxValueSize > 0 && uint64(len(value)) > maxValueSize {
+		return fmt.Errorf("%d bytes, more than the maximum of %d", len(value), maxValueSize)
+	}
+
+	return nil
 }
 
 // OffChainData returns the data that needs to be stored off chain from a given sequence
@@ -91,6 +158,20 @@ func (s *Sequence) OffChainData() []OffChainData {
 type SignedSequence struct {
 	Sequence  Sequence `json:"sequence"`
 	Signature ArgBytes `json:"signature"`
+	// Timestamp is the unix time the request was signed at by the sequencer, covered by its signature
+	// when set so that the request can not be replayed later
+	Timestamp ArgUint64 `json:"timestamp,omitempty"`
+}
+
+// RequestHash returns the hash signed by the sequencer: the accumulated input hash, followed by the
+// timestamp of the request when set
+func (s *SignedSequence) RequestHash() []byte {
+	hash := s.Sequence.HashToSign()
+	if s.Timestamp == 0 {
+		return hash
+	}
+
+	return crypto.Keccak256(hash, binary.BigEndian.AppendUint64(nil, uint64(s.Timestamp)))
 }
 
 // Signer returns the address of the signer
@@ -101,7 +182,7 @@ func (s *SignedSequence) Signer() (common.Address, error) {
 	sig := make([]byte, signatureLen)
 	copy(sig, s.Signature)
 	sig[64] -= 27
-	pubKey, err := crypto.SigToPub(s.Sequence.HashToSign(), sig)
+	pubKey, err := crypto.SigToPub(s.RequestHash(), sig)
 	if err != nil {
 		return common.Address{}, err
 	}
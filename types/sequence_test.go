@@ -52,3 +52,51 @@ func TestSigning(t *testing.T) {
 		}
 	}
 }
+
+func TestSignRequest(t *testing.T) {
+	pk, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	signedSequence, err := testSequenceCases[0].s.SignRequest(pk, 1700000000)
+	require.NoError(t, err)
+	signer, err := signedSequence.Signer()
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(pk.PublicKey), signer)
+
+	// the signature covers the timestamp
+	signedSequence.Timestamp++
+	signer, err = signedSequence.Signer()
+	require.NoError(t, err)
+	assert.NotEqual(t, crypto.PubkeyToAddress(pk.PublicKey), signer)
+}
+
+func TestSequence_CheckSize(t *testing.T) {
+	s := Sequence{ArgBytes{1, 2, 3}, ArgBytes{4, 5}}
+
+	require.NoError(t, s.CheckSize(3, 5))
+	require.ErrorContains(t, s.CheckSize(2, 5), "the data of the batch 0 is 3 bytes")
+	require.ErrorContains(t, s.CheckSize(3, 4), "the data of the sequence is 5 bytes")
+}
+
+func TestCheckValueSize(t *testing.T) {
+	require.NoError(t, CheckValueSize([]byte{1, 2}, 2))
+	require.NoError(t, CheckValueSize([]byte{1, 2}, 0))
+	require.EqualError(t, CheckValueSize([]byte{1, 2}, 1), "2 bytes, more than the maximum of 1")
+}
+
+func TestSignRequest_BindsData(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	signed, err := (&Sequence{ArgBytes{1}, ArgBytes{2}}).SignRequest(privateKey, 1)
+	require.NoError(t, err)
+	signer, err := signed.Signer()
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(privateKey.PublicKey), signer)
+
+	// the signature covers the hash of the data of every batch, altered data is not signed by the sender
+	signed.Sequence[1] = ArgBytes{3}
+	signer, err = signed.Signer()
+	require.NoError(t, err)
+	require.NotEqual(t, crypto.PubkeyToAddress(privateKey.PublicKey), signer)
+}
@@ -0,0 +1,54 @@
+package types
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// servedDomain separates the signatures of the values served from the other signatures of the members
+var servedDomain = []byte("cdk-data-availability/served")
+
+// SignedOffChainData is a value served by a member along with its signature over the key of the value
+type SignedOffChainData struct {
+	Value     ArgBytes `json:"value"`
+	Signature ArgBytes `json:"signature"`
+}
+
+// ServedValue records the committee member a value was fetched from, so the member can be held
+// accountable for it
+type ServedValue struct {
+	Key    common.Hash    `json:"key"`
+	Member common.Address `json:"member"`
+	// Signature is the signature of the member over the key, empty when the member did not sign it
+	Signature ArgBytes  `json:"signature"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// ServedHashToSign returns the hash a member signs to vouch for the value it serves under the given key.
+// The key being the keccak256 hash of the value, the signature binds the member to the value itself.
+func ServedHashToSign(key common.Hash) []byte {
+	return crypto.Keccak256(servedDomain, key.Bytes())
+}
+
+// SignServed signs the value served under the given key with the private key
+func SignServed(key common.Hash, privateKey *ecdsa.PrivateKey) ([]byte, error) {
+	return crypto.Sign(ServedHashToSign(key), privateKey)
+}
+
+// ServedSigner returns the address of the signer of the value served under the given key
+func ServedSigner(key common.Hash, signature []byte) (common.Address, error) {
+	if len(signature) != signatureLen {
+		return common.Address{}, errors.New("invalid signature")
+	}
+
+	pubKey, err := crypto.SigToPub(ServedHashToSign(key), signature)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
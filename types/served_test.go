@@ -0,0 +1,38 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServedSigner(t *testing.T) {
+	key := crypto.Keccak256Hash([]byte("batch data"))
+
+	pk, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	signature, err := SignServed(key, pk)
+	require.NoError(t, err)
+
+	signer, err := ServedSigner(key, signature)
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(pk.PublicKey), signer)
+
+	// the signature covers the key
+	signer, err = ServedSigner(common.HexToHash("0x1"), signature)
+	require.NoError(t, err)
+	require.NotEqual(t, crypto.PubkeyToAddress(pk.PublicKey), signer)
+
+	// the availability signatures are not accepted in place of the served ones
+	availability, err := SignAvailability(key, pk)
+	require.NoError(t, err)
+	signer, err = ServedSigner(key, availability)
+	require.NoError(t, err)
+	require.NotEqual(t, crypto.PubkeyToAddress(pk.PublicKey), signer)
+
+	_, err = ServedSigner(key, signature[:10])
+	require.Error(t, err)
+}
@@ -0,0 +1,64 @@
+package types
+
+import (
+	"crypto/ecdsa"
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// tombstoneDomain separates the tombstone signatures from the other signatures of the node
+var tombstoneDomain = []byte("cdk-data-availability/tombstone")
+
+// Tombstone records a value deleted by the node per its retention policy, so an audit can tell the values
+// pruned from the values lost, and the operator can prove the deletion
+type Tombstone struct {
+	Key      common.Hash `json:"key"`
+	BatchNum ArgUint64   `json:"batchNum"`
+	// PrunedAtBlock is the L1 block the value was pruned at
+	PrunedAtBlock ArgUint64 `json:"prunedAtBlock"`
+	Reason        string    `json:"reason"`
+	// Signature is the signature of the node over the tombstone, empty when pruned by a mirror
+	Signature ArgBytes  `json:"signature"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// HashToSign returns the hash of the key, the batch, the block and the reason of the tombstone
+func (t *Tombstone) HashToSign() []byte {
+	data := make([]byte, 0, len(tombstoneDomain)+common.HashLength+16+len(t.Reason))
+	data = append(data, tombstoneDomain...)
+	data = append(data, t.Key.Bytes()...)
+	data = binary.BigEndian.AppendUint64(data, uint64(t.BatchNum))
+	data = binary.BigEndian.AppendUint64(data, uint64(t.PrunedAtBlock))
+	data = append(data, t.Reason...)
+
+	return crypto.Keccak256(data)
+}
+
+// Sign signs the tombstone with the private key
+func (t *Tombstone) Sign(privateKey *ecdsa.PrivateKey) error {
+	sig, err := crypto.Sign(t.HashToSign(), privateKey)
+	if err != nil {
+		return err
+	}
+
+	t.Signature = sig
+	return nil
+}
+
+// Signer returns the address of the signer
+func (t *Tombstone) Signer() (common.Address, error) {
+	if len(t.Signature) != signatureLen {
+		return common.Address{}, errors.New("invalid signature")
+	}
+
+	pubKey, err := crypto.SigToPub(t.HashToSign(), t.Signature)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
@@ -0,0 +1,37 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTombstone_Signer(t *testing.T) {
+	pk, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	tombstone := Tombstone{Key: common.HexToHash("0x1"), BatchNum: 10, PrunedAtBlock: 1000, Reason: "retention policy"}
+	require.NoError(t, tombstone.Sign(pk))
+
+	signer, err := tombstone.Signer()
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(pk.PublicKey), signer)
+
+	// the signature covers the block and the reason
+	tombstone.PrunedAtBlock++
+	signer, err = tombstone.Signer()
+	require.NoError(t, err)
+	require.NotEqual(t, crypto.PubkeyToAddress(pk.PublicKey), signer)
+
+	tombstone.PrunedAtBlock--
+	tombstone.Reason = "lost"
+	signer, err = tombstone.Signer()
+	require.NoError(t, err)
+	require.NotEqual(t, crypto.PubkeyToAddress(pk.PublicKey), signer)
+
+	tombstone.Signature = tombstone.Signature[:10]
+	_, err = tombstone.Signer()
+	require.Error(t, err)
+}
@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -36,6 +37,118 @@ type OffChainData struct {
 	BatchNum uint64
 }
 
+// OffChainDataEntry is a stored value as listed to the other nodes
+type OffChainDataEntry struct {
+	Key      common.Hash `json:"key"`
+	Value    ArgBytes    `json:"value"`
+	BatchNum ArgUint64   `json:"batchNum"`
+}
+
+// SignDecision is the outcome of a request to sign a sequence
+type SignDecision string
+
+const (
+	// SignDecisionSigned the sequence was signed
+	SignDecisionSigned = SignDecision("signed")
+	// SignDecisionUnauthorized the request does not come from the trusted sequencer
+	SignDecisionUnauthorized = SignDecision("unauthorized")
+	// SignDecisionReplayed the request was already received, or signed outside of the replay window
+	SignDecisionReplayed = SignDecision("replayed")
+	// SignDecisionFailed the request could not be verified, or the sequence stored or signed
+	SignDecisionFailed = SignDecision("failed")
+)
+
+// SignAuditEntry records a request to sign a sequence along with its outcome
+type SignAuditEntry struct {
+	ID           uint64         `json:"id"`
+	Requester    common.Address `json:"requester"`
+	SequenceHash common.Hash    `json:"sequence_hash"`
+	BatchCount   uint64         `json:"batch_count"`
+	Decision     SignDecision   `json:"decision"`
+	Signature    ArgBytes       `json:"signature,omitempty"`
+	Timestamp    time.Time      `json:"timestamp"`
+}
+
+// CommitteeChangeKind is the kind of change of a committee member
+type CommitteeChangeKind string
+
+const (
+	// CommitteeMemberAdded the member joined the committee
+	CommitteeMemberAdded = CommitteeChangeKind("added")
+	// CommitteeMemberRemoved the member left the committee
+	CommitteeMemberRemoved = CommitteeChangeKind("removed")
+	// CommitteeMemberURLChanged the member is still in the committee, with a new URL
+	CommitteeMemberURLChanged = CommitteeChangeKind("url_changed")
+)
+
+// CommitteeChange records a change of a committee member observed on L1
+type CommitteeChange struct {
+	ID          uint64              `json:"id"`
+	BlockNumber uint64              `json:"block_number"`
+	TxHash      common.Hash         `json:"tx_hash"`
+	Kind        CommitteeChangeKind `json:"kind"`
+	Member      common.Address      `json:"member"`
+	URL         string              `json:"url"`
+	PreviousURL string              `json:"previous_url,omitempty"`
+	Timestamp   time.Time           `json:"timestamp"`
+}
+
+// Publication records a value copied by the node to an external storage backend
+type Publication struct {
+	Key     common.Hash `json:"key"`
+	Backend string      `json:"backend"`
+	// Reference locates the value in the backend, e.g. the IPFS CID
+	Reference string    `json:"reference"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Attestation records a commitment to the stored keys submitted by the node to L1
+type Attestation struct {
+	ID uint64 `json:"id"`
+	// Root is the Merkle root of the keys of the values stored for the batches in [FromBatch, ToBatch]
+	Root      common.Hash `json:"root"`
+	FromBatch uint64      `json:"fromBatch"`
+	ToBatch   uint64      `json:"toBatch"`
+	KeyCount  uint32      `json:"keyCount"`
+	TxHash    common.Hash `json:"txHash"`
+	Timestamp time.Time   `json:"timestamp"`
+}
+
+// StoredDataEvent records a value stored by the node, to be streamed to the consumers of the
+// DA events. The events are delivered at least once, the ID identifies duplicates.
+type StoredDataEvent struct {
+	ID       uint64      `json:"id"`
+	Key      common.Hash `json:"key"`
+	BatchNum uint64      `json:"batchNum"`
+	// Size is the size of the value in bytes
+	Size     uint64    `json:"size"`
+	StoredAt time.Time `json:"storedAt"`
+}
+
+// ShardCommitment is the commitment to the erasure coded shards of a value, and the shards held by the node
+type ShardCommitment struct {
+	Key common.Hash `json:"key"`
+	// Root is the Merkle root of the shards
+	Root         common.Hash `json:"root"`
+	DataShards   uint        `json:"dataShards"`
+	ParityShards uint        `json:"parityShards"`
+	// Size is the size of the value in bytes
+	Size uint64 `json:"size"`
+	// The node holds the shards whose index modulo Members is MemberIndex, Members being the size
+	// of the committee when the value was sharded
+	MemberIndex uint      `json:"memberIndex"`
+	Members     uint      `json:"members"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// Shard is an erasure coded shard of a value, along with its Merkle proof
+type Shard struct {
+	Key   common.Hash   `json:"key"`
+	Index uint          `json:"index"`
+	Data  ArgBytes      `json:"data"`
+	Proof []common.Hash `json:"proof"`
+}
+
 // ArgUint64 helps to marshal uint64 values provided in the RPC requests
 type ArgUint64 uint64
 
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"io"
 	"runtime"
+	"sort"
+	"strings"
 )
 
 // Populated during build, don't touch!
@@ -14,6 +16,43 @@ var (
 	BuildDate = "Fri, 17 Jun 1988 01:58:00 +0200"
 )
 
+// RPCAPIVersions are the versions of the JSON-RPC namespaces served by the node, bumped on breaking changes
+var RPCAPIVersions = map[string]string{
+	"admin":    "1.0",
+	"dacert":   "1.0",
+	"das":      "1.0",
+	"datacom":  "1.0",
+	"explorer": "1.0",
+	"status":   "1.0",
+	"sync":     "1.0",
+}
+
+// Info is the version information of the build, as printed by the version command with --json
+type Info struct {
+	Version        string            `json:"version"`
+	GitRev         string            `json:"gitRev"`
+	GitBranch      string            `json:"gitBranch"`
+	BuildDate      string            `json:"buildDate"`
+	GoVersion      string            `json:"goVersion"`
+	OS             string            `json:"os"`
+	Arch           string            `json:"arch"`
+	RPCAPIVersions map[string]string `json:"rpcApiVersions"`
+}
+
+// GetInfo returns the version information of the build
+func GetInfo() Info {
+	return Info{
+		Version:        Version,
+		GitRev:         GitRev,
+		GitBranch:      GitBranch,
+		BuildDate:      BuildDate,
+		GoVersion:      runtime.Version(),
+		OS:             runtime.GOOS,
+		Arch:           runtime.GOARCH,
+		RPCAPIVersions: RPCAPIVersions,
+	}
+}
+
 // PrintVersion prints version info into the provided io.Writer.
 func PrintVersion(w io.Writer) {
 	fmt.Fprint(w, GetVersionInfo())
@@ -21,11 +60,18 @@ func PrintVersion(w io.Writer) {
 
 // GetVersionInfo returns version information as a formatted string.
 func GetVersionInfo() string {
+	apis := make([]string, 0, len(RPCAPIVersions))
+	for namespace, version := range RPCAPIVersions {
+		apis = append(apis, namespace+"/"+version)
+	}
+	sort.Strings(apis)
+
 	versionInfo := fmt.Sprintf("Version:      %s\n", Version)
 	versionInfo += fmt.Sprintf("Git revision: %s\n", GitRev)
 	versionInfo += fmt.Sprintf("Git branch:   %s\n", GitBranch)
 	versionInfo += fmt.Sprintf("Go version:   %s\n", runtime.Version())
 	versionInfo += fmt.Sprintf("Built:        %s\n", BuildDate)
 	versionInfo += fmt.Sprintf("OS/Arch:      %s/%s\n", runtime.GOOS, runtime.GOARCH)
+	versionInfo += fmt.Sprintf("RPC APIs:     %s\n", strings.Join(apis, ", "))
 	return versionInfo
 }
@@ -0,0 +1,37 @@
+package webhook
+
+import "github.com/0xPolygon/cdk-data-availability/config/types"
+
+// Config represents the configuration of the webhooks fired on the lifecycle events of the data
+type Config struct {
+	// Endpoints the events are posted to, no event is sent when empty
+	Endpoints []EndpointConfig `mapstructure:"Endpoints"`
+
+	// Source identifies the node in the events, the hostname by default
+	Source string `mapstructure:"Source"`
+
+	// Timeout bounds each request to an endpoint
+	Timeout types.Duration `mapstructure:"Timeout"`
+
+	// MaxAttempts is the number of times the delivery of an event to an endpoint is tried
+	MaxAttempts uint `mapstructure:"MaxAttempts"`
+
+	// RetryWait is the time before the first retry, doubled after each failure up to a minute
+	RetryWait types.Duration `mapstructure:"RetryWait"`
+
+	// QueueSize is the number of events waiting to be delivered to each endpoint,
+	// further ones are dropped
+	QueueSize uint `mapstructure:"QueueSize"`
+}
+
+// EndpointConfig represents an endpoint the events are posted to
+type EndpointConfig struct {
+	// URL of the endpoint
+	URL string `mapstructure:"URL" secret:"true"`
+
+	// Secret is the key of the HMAC-SHA256 signature of the payloads
+	Secret string `mapstructure:"Secret" secret:"true"`
+
+	// Events the endpoint is subscribed to, every event if empty
+	Events []string `mapstructure:"Events"`
+}
@@ -0,0 +1,55 @@
+package webhook
+
+import (
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Types of the lifecycle events of the data
+const (
+	// EventDataStored values were stored by the node
+	EventDataStored = "data.stored"
+	// EventDataPruned values were deleted by the node
+	EventDataPruned = "data.pruned"
+	// EventSequenceSigned the node signed a sequence for the sequencer
+	EventSequenceSigned = "sequence.signed"
+)
+
+// EventTypes are the types of the events the endpoints can subscribe to
+var EventTypes = []string{EventDataStored, EventDataPruned, EventSequenceSigned}
+
+// StoredValue describes a value stored by the node
+type StoredValue struct {
+	Key      common.Hash `json:"key"`
+	BatchNum uint64      `json:"batchNum"`
+	Size     int         `json:"size"`
+}
+
+// DataStored fires the data.stored event of the values, if the webhooks are running
+func DataStored(data []types.OffChainData) {
+	d := dispatcher.Load()
+	if d == nil || len(data) == 0 {
+		return
+	}
+
+	values := make([]StoredValue, len(data))
+	for i, value := range data {
+		values[i] = StoredValue{Key: value.Key, BatchNum: value.BatchNum, Size: len(value.Value)}
+	}
+
+	d.Fire(EventDataStored, map[string]interface{}{"values": values})
+}
+
+// DataPruned fires the data.pruned event of the keys, if the webhooks are running
+func DataPruned(keys []common.Hash) {
+	if d := dispatcher.Load(); d != nil && len(keys) > 0 {
+		d.Fire(EventDataPruned, map[string]interface{}{"keys": keys})
+	}
+}
+
+// SequenceSigned fires the sequence.signed event of the audit log entry, if the webhooks are running
+func SequenceSigned(entry types.SignAuditEntry) {
+	if d := dispatcher.Load(); d != nil {
+		d.Fire(EventSequenceSigned, entry)
+	}
+}
@@ -0,0 +1,225 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/metrics"
+	"github.com/0xPolygon/cdk-data-availability/pkg/backoff"
+	"github.com/0xPolygon/cdk-data-availability/reporter"
+)
+
+// logger is the logger of the webhook component
+var logger = log.WithComponent("webhook")
+
+// maxRetryWait caps the time between two attempts to deliver an event
+const maxRetryWait = time.Minute
+
+// Headers of the requests to the endpoints
+const (
+	// HeaderEvent is the type of the event
+	HeaderEvent = "X-DAC-Event"
+	// HeaderDelivery is the ID of the event, the same for every attempt to deliver it
+	HeaderDelivery = "X-DAC-Delivery"
+	// HeaderTimestamp is the Unix time of the attempt, covered by the signature
+	HeaderTimestamp = "X-DAC-Timestamp"
+	// HeaderSignature is the hex encoded HMAC-SHA256 of the timestamp, a dot and the body,
+	// prefixed by sha256=
+	HeaderSignature = "X-DAC-Signature"
+)
+
+// dispatcher is the Dispatcher the events are fired through, if any
+var dispatcher atomic.Pointer[Dispatcher]
+
+// Event is a lifecycle event of the data
+type Event struct {
+	// ID identifies the event, the receivers can discard the events delivered more than once
+	ID string `json:"id"`
+	// Type of the event, e.g. data.stored
+	Type string `json:"type"`
+	// Source identifies the node firing the event
+	Source    string      `json:"source"`
+	Timestamp time.Time   `json:"timestamp"`
+	Data      interface{} `json:"data"`
+}
+
+// Dispatcher posts the events to the configured endpoints. Each endpoint has its own queue,
+// so a slow or failing endpoint does not delay the others.
+type Dispatcher struct {
+	cfg    Config
+	client *http.Client
+	queues []chan Event
+	stop   chan struct{}
+}
+
+// New returns a Dispatcher posting to the endpoints of the given config
+func New(cfg Config) *Dispatcher {
+	if cfg.Source == "" {
+		cfg.Source, _ = os.Hostname()
+	}
+
+	queues := make([]chan Event, len(cfg.Endpoints))
+	for i := range queues {
+		queues[i] = make(chan Event, cfg.QueueSize)
+	}
+
+	return &Dispatcher{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout.Duration},
+		queues: queues,
+		stop:   make(chan struct{}),
+	}
+}
+
+// Start delivers the events fired until the dispatcher is stopped
+func (d *Dispatcher) Start(ctx context.Context) {
+	defer reporter.Recover()
+
+	logger.Infof("starting to post the events to %d webhooks", len(d.cfg.Endpoints))
+	dispatcher.Store(d)
+	defer dispatcher.CompareAndSwap(d, nil)
+
+	var wg sync.WaitGroup
+	for i := range d.cfg.Endpoints {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer reporter.Recover()
+			d.deliver(ctx, d.cfg.Endpoints[i], d.queues[i])
+		}(i)
+	}
+	wg.Wait()
+}
+
+// Stop stops the dispatcher, the events still queued are dropped
+func (d *Dispatcher) Stop() {
+	close(d.stop)
+}
+
+// Fire queues the event for every endpoint subscribed to its type
+func (d *Dispatcher) Fire(eventType string, data interface{}) {
+	event := Event{
+		ID:        newID(),
+		Type:      eventType,
+		Source:    d.cfg.Source,
+		Timestamp: time.Now().UTC(),
+		Data:      data,
+	}
+
+	for i, endpoint := range d.cfg.Endpoints {
+		if !subscribed(endpoint, eventType) {
+			continue
+		}
+
+		select {
+		case d.queues[i] <- event:
+		default:
+			metrics.WebhookDelivery(eventType, metrics.WebhookDropped)
+			logger.Warnf("dropped the %s event %s, too many events pending", eventType, event.ID)
+		}
+	}
+}
+
+// deliver posts the queued events to the endpoint, retrying with an exponential backoff
+func (d *Dispatcher) deliver(ctx context.Context, endpoint EndpointConfig, queue chan Event) {
+	for {
+		select {
+		case event := <-queue:
+			err := backoff.ExponentialWithCap(func() error {
+				return d.post(ctx, endpoint, event)
+			}, d.cfg.MaxAttempts, d.cfg.RetryWait.Duration, maxRetryWait)
+			metrics.WebhookDelivery(event.Type, metrics.Result(err))
+			if err != nil {
+				logger.Errorf("failed to post the %s event %s: %v", event.Type, event.ID, err)
+			}
+		case <-ctx.Done():
+			return
+		case <-d.stop:
+			return
+		}
+	}
+}
+
+// post sends the event to the endpoint, signed with its secret
+func (d *Dispatcher) post(parentCtx context.Context, endpoint EndpointConfig, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, d.cfg.Timeout.Duration)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, event.Type)
+	req.Header.Set(HeaderDelivery, event.ID)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	if endpoint.Secret != "" {
+		req.Header.Set(HeaderSignature, "sha256="+Sign(endpoint.Secret, timestamp, body))
+	}
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status code %d", res.StatusCode)
+	}
+
+	return nil
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of the timestamp and the body, as sent in the
+// signature header. The receivers compute it to authenticate the events.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// subscribed tells whether the endpoint is subscribed to the event type
+func subscribed(endpoint EndpointConfig, eventType string) bool {
+	if len(endpoint.Events) == 0 {
+		return true
+	}
+
+	for _, e := range endpoint.Events {
+		if e == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newID returns a random ID of an event
+func newID() string {
+	id := make([]byte, 16) //nolint:gomnd
+	_, _ = rand.Read(id)
+
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,100 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/config/types"
+	dataTypes "github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig(endpoints ...EndpointConfig) Config {
+	return Config{
+		Endpoints:   endpoints,
+		Source:      "node-1",
+		Timeout:     types.NewDuration(time.Second),
+		MaxAttempts: 3,
+		RetryWait:   types.NewDuration(time.Millisecond),
+		QueueSize:   10,
+	}
+}
+
+func TestDispatcher_Post(t *testing.T) {
+	received := make(chan *http.Request, 1)
+	bodies := make(chan []byte, 1)
+	var attempts int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		received <- r
+		bodies <- body
+	}))
+	defer server.Close()
+
+	d := New(testConfig(EndpointConfig{URL: server.URL, Secret: "secret"}))
+	go d.Start(context.Background())
+	defer func() {
+		d.Stop()
+		require.Eventually(t, func() bool { return dispatcher.Load() == nil }, time.Second, time.Millisecond)
+	}()
+
+	require.Eventually(t, func() bool { return dispatcher.Load() == d }, time.Second, time.Millisecond)
+
+	DataStored([]dataTypes.OffChainData{{Key: common.HexToHash("0x1"), Value: []byte{1, 2, 3}, BatchNum: 7}})
+
+	select {
+	case r := <-received:
+		body := <-bodies
+		require.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+		require.Equal(t, EventDataStored, r.Header.Get(HeaderEvent))
+		require.Equal(t, "sha256="+Sign("secret", r.Header.Get(HeaderTimestamp), body), r.Header.Get(HeaderSignature))
+
+		var event Event
+		require.NoError(t, json.Unmarshal(body, &event))
+		require.Equal(t, r.Header.Get(HeaderDelivery), event.ID)
+		require.Equal(t, "node-1", event.Source)
+		require.Equal(t, map[string]interface{}{"values": []interface{}{
+			map[string]interface{}{"key": common.HexToHash("0x1").Hex(), "batchNum": float64(7), "size": float64(3)},
+		}}, event.Data)
+	case <-time.After(5 * time.Second):
+		t.Fatal("event not delivered")
+	}
+}
+
+func TestDispatcher_Fire(t *testing.T) {
+	d := New(testConfig(
+		EndpointConfig{URL: "http://localhost:1", Events: []string{EventDataPruned}},
+		EndpointConfig{URL: "http://localhost:2"},
+	))
+
+	d.Fire(EventDataStored, nil)
+	d.Fire(EventDataPruned, nil)
+
+	require.Len(t, d.queues[0], 1)
+	require.Equal(t, EventDataPruned, (<-d.queues[0]).Type)
+	require.Len(t, d.queues[1], 2)
+}
+
+func TestDataStored_NoDispatcher(t *testing.T) {
+	require.Nil(t, dispatcher.Load())
+
+	require.NotPanics(t, func() {
+		DataStored([]dataTypes.OffChainData{{Key: common.HexToHash("0x1")}})
+		DataPruned([]common.Hash{common.HexToHash("0x1")})
+		SequenceSigned(dataTypes.SignAuditEntry{})
+	})
+}